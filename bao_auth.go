@@ -0,0 +1,72 @@
+package popsigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// kubernetesLogin returns a login func that exchanges the pod's
+// service-account token for an OpenBao token via the Kubernetes auth method.
+// The service-account token is read on every login, since the kubelet
+// rotates projected tokens.
+func (c *BaoClient) kubernetesLogin(cfg Config) func(ctx context.Context) (string, time.Duration, error) {
+	path := fmt.Sprintf("/v1/auth/%s/login", cfg.KubernetesAuthPath)
+	return func(ctx context.Context) (string, time.Duration, error) {
+		jwt, err := os.ReadFile(cfg.KubernetesTokenPath)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w: read service account token: %v", ErrBaoAuth, err)
+		}
+
+		body, err := json.Marshal(map[string]string{
+			"role": cfg.KubernetesRole,
+			"jwt":  strings.TrimSpace(string(jwt)),
+		})
+		if err != nil {
+			return "", 0, ErrBaoConnection
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+		if err != nil {
+			return "", 0, ErrBaoConnection
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if c.namespace != "" {
+			req.Header.Set("X-Vault-Namespace", c.namespace)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return "", 0, ErrBaoConnection
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", 0, ErrBaoConnection
+		}
+		if resp.StatusCode >= 400 {
+			var errResp struct {
+				Errors []string `json:"errors"`
+			}
+			_ = json.Unmarshal(respBody, &errResp)
+			return "", 0, fmt.Errorf("%w: kubernetes login: %w", ErrBaoAuth, NewBaoError(resp.StatusCode, errResp.Errors, ""))
+		}
+
+		var result struct {
+			Auth struct {
+				ClientToken   string `json:"client_token"`
+				LeaseDuration int    `json:"lease_duration"`
+			} `json:"auth"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil || result.Auth.ClientToken == "" {
+			return "", 0, fmt.Errorf("%w: kubernetes login returned no token", ErrBaoAuth)
+		}
+		return result.Auth.ClientToken, time.Duration(result.Auth.LeaseDuration) * time.Second, nil
+	}
+}
@@ -0,0 +1,156 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaoClient_KubernetesAuth(t *testing.T) {
+	var mu sync.Mutex
+	var logins []map[string]string
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/v1/auth/k8s-cluster/login" {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Empty(t, r.Header.Get("X-Vault-Token"))
+			assert.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			logins = append(logins, body)
+			fmt.Fprintf(w, `{"auth":{"client_token":"hvs.login-%d","lease_duration":3600,"renewable":true}}`, len(logins))
+			return
+		}
+
+		seen = append(seen, r.Header.Get("X-Vault-Token"))
+		_, _ = w.Write([]byte(`{"data":{"keys":[]}}`))
+	}))
+	defer server.Close()
+
+	saToken := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(saToken, []byte("sa-jwt-1\n"), 0o600))
+
+	client, err := NewBaoClient(Config{
+		BaoAddr:             server.URL,
+		BaoNamespace:        "team-a",
+		AuthMethod:          AuthMethodKubernetes,
+		KubernetesRole:      "popsigner",
+		KubernetesAuthPath:  "/k8s-cluster/",
+		KubernetesTokenPath: saToken,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	client.tokens.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	_, err = client.ListKeys(ctx)
+	require.NoError(t, err)
+	_, err = client.ListKeys(ctx)
+	require.NoError(t, err)
+
+	// Past 80% of the lease the client logs in again with the rotated JWT
+	require.NoError(t, os.WriteFile(saToken, []byte("sa-jwt-2\n"), 0o600))
+	now = now.Add(49 * time.Minute)
+	_, err = client.ListKeys(ctx)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []map[string]string{
+		{"role": "popsigner", "jwt": "sa-jwt-1"},
+		{"role": "popsigner", "jwt": "sa-jwt-2"},
+	}, logins)
+	assert.Equal(t, []string{"hvs.login-1", "hvs.login-1", "hvs.login-2"}, seen)
+}
+
+func TestBaoClient_KubernetesAuthRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":["invalid role name \"popsigner\""]}`))
+	}))
+	defer server.Close()
+
+	saToken := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(saToken, []byte("sa-jwt"), 0o600))
+
+	client, err := NewBaoClient(Config{
+		BaoAddr:             server.URL,
+		AuthMethod:          AuthMethodKubernetes,
+		KubernetesRole:      "popsigner",
+		KubernetesTokenPath: saToken,
+	})
+	require.NoError(t, err)
+
+	_, err = client.ListKeys(context.Background())
+	assert.ErrorIs(t, err, ErrBaoAuth)
+	assert.Contains(t, err.Error(), "invalid role name")
+}
+
+func TestBaoClient_KubernetesReloginAfterForbidden(t *testing.T) {
+	var mu sync.Mutex
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/v1/auth/kubernetes/login" {
+			logins++
+			fmt.Fprintf(w, `{"auth":{"client_token":"hvs.login-%d","lease_duration":3600}}`, logins)
+			return
+		}
+		// The first token has been revoked
+		if r.Header.Get("X-Vault-Token") == "hvs.login-1" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"keys":[]}}`))
+	}))
+	defer server.Close()
+
+	saToken := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(saToken, []byte("sa-jwt"), 0o600))
+
+	client, err := NewBaoClient(Config{
+		BaoAddr:             server.URL,
+		AuthMethod:          AuthMethodKubernetes,
+		KubernetesRole:      "popsigner",
+		KubernetesTokenPath: saToken,
+	})
+	require.NoError(t, err)
+
+	_, err = client.ListKeys(context.Background())
+	assert.ErrorIs(t, err, ErrBaoAuth)
+	_, err = client.ListKeys(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, logins)
+}
+
+func TestConfig_ValidateKubernetesAuth(t *testing.T) {
+	cfg := Config{BaoAddr: "https://localhost:8200", StorePath: "/tmp/test", AuthMethod: AuthMethodKubernetes}
+	assert.ErrorIs(t, cfg.Validate(), ErrMissingKubernetesRole)
+
+	cfg.KubernetesRole = "popsigner"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AuthMethod = "approle"
+	assert.ErrorIs(t, cfg.Validate(), ErrUnsupportedAuthMethod)
+}
@@ -3,8 +3,10 @@ package popsigner
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -17,7 +19,7 @@ import (
 type BaoClient struct {
 	httpClient    *http.Client
 	baseURL       string
-	token         string
+	tokens        *tokenSource
 	namespace     string
 	secp256k1Path string
 }
@@ -43,13 +45,24 @@ func NewBaoClient(cfg Config) (*BaoClient, error) {
 		TLSClientConfig:     tlsConfig,
 	}
 
-	return &BaoClient{
+	c := &BaoClient{
 		httpClient:    &http.Client{Timeout: cfg.HTTPTimeout, Transport: transport},
 		baseURL:       strings.TrimSuffix(cfg.BaoAddr, "/"),
-		token:         cfg.BaoToken,
 		namespace:     cfg.BaoNamespace,
 		secp256k1Path: cfg.Secp256k1Path,
-	}, nil
+	}
+
+	switch cfg.AuthMethod {
+	case AuthMethodKubernetes:
+		c.tokens = newLoginTokenSource(c.kubernetesLogin(cfg))
+	default:
+		tokens, err := newTokenSource(cfg.BaoToken, cfg.BaoTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		c.tokens = tokens
+	}
+	return c, nil
 }
 
 // CreateKey creates a new secp256k1 key.
@@ -88,6 +101,28 @@ func (c *BaoClient) GetKey(ctx context.Context, name string) (*KeyInfo, error) {
 	return &result.Data, nil
 }
 
+// PublicKey returns the compressed public key of a key.
+func (c *BaoClient) PublicKey(ctx context.Context, keyName string) ([]byte, error) {
+	info, err := c.GetKey(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := hex.DecodeString(info.PublicKey)
+	if err != nil {
+		return nil, WrapKeyError("get", keyName, err)
+	}
+	return pubKey, nil
+}
+
+// Address returns the hex-encoded Cosmos address of a key.
+func (c *BaoClient) Address(ctx context.Context, keyName string) (string, error) {
+	info, err := c.GetKey(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+	return info.Address, nil
+}
+
 // ListKeys lists all keys.
 func (c *BaoClient) ListKeys(ctx context.Context) ([]string, error) {
 	path := fmt.Sprintf("/v1/%s/keys", c.secp256k1Path)
@@ -118,7 +153,12 @@ func (c *BaoClient) DeleteKey(ctx context.Context, name string) error {
 }
 
 // Sign signs data and returns 64-byte Cosmos signature.
+// Prehashed data must be a 32-byte digest.
 func (c *BaoClient) Sign(ctx context.Context, keyName string, data []byte, prehashed bool) ([]byte, error) {
+	if prehashed && len(data) != sha256.Size {
+		return nil, WrapKeyError("sign", keyName, fmt.Errorf("%w, got %d bytes", ErrInvalidDigest, len(data)))
+	}
+
 	path := fmt.Sprintf("/v1/%s/sign/%s", c.secp256k1Path, keyName)
 	body := map[string]interface{}{
 		"input":         base64.StdEncoding.EncodeToString(data),
@@ -214,6 +254,7 @@ func (c *BaoClient) ExportKey(ctx context.Context, name string) (string, *KeyInf
 }
 
 // Health checks OpenBao status.
+// sys/health is served by the cluster, so no namespace header is sent.
 func (c *BaoClient) Health(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/sys/health", nil)
 	if err != nil {
@@ -269,7 +310,11 @@ func (c *BaoClient) doRequest(ctx context.Context, method, path string, body int
 		return nil, ErrBaoConnection
 	}
 
-	req.Header.Set("X-Vault-Token", c.token)
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
 	req.Header.Set("Content-Type", "application/json")
 	if c.namespace != "" {
 		req.Header.Set("X-Vault-Namespace", c.namespace)
@@ -291,6 +336,9 @@ func (c *BaoClient) doRequest(ctx context.Context, method, path string, body int
 			Errors []string `json:"errors"`
 		}
 		_ = json.Unmarshal(respBody, &errResp)
+		if resp.StatusCode == http.StatusForbidden {
+			c.tokens.invalidate(token)
+		}
 		return nil, NewBaoError(resp.StatusCode, errResp.Errors, "")
 	}
 
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -416,10 +417,23 @@ func TestBaoClient_Sign(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "prehashed data is not a digest",
+			keyName:   "test-key",
+			data:      make([]byte, 10),
+			prehashed: true,
+			serverResp: func(w http.ResponseWriter, r *http.Request) {
+				t.Error("request sent for an invalid digest")
+			},
+			wantErr: true,
+			errCheck: func(err error) bool {
+				return errors.Is(err, ErrInvalidDigest)
+			},
+		},
 		{
 			name:      "invalid signature length",
 			keyName:   "test-key",
-			data:      []byte("test"),
+			data:      make([]byte, 32),
 			prehashed: true,
 			serverResp: func(w http.ResponseWriter, r *http.Request) {
 				// Return signature that's not 64 bytes
@@ -438,7 +452,7 @@ func TestBaoClient_Sign(t *testing.T) {
 		{
 			name:      "invalid base64 signature",
 			keyName:   "test-key",
-			data:      []byte("test"),
+			data:      make([]byte, 32),
 			prehashed: true,
 			serverResp: func(w http.ResponseWriter, r *http.Request) {
 				_ = json.NewEncoder(w).Encode(map[string]interface{}{
@@ -452,7 +466,7 @@ func TestBaoClient_Sign(t *testing.T) {
 		{
 			name:      "server error",
 			keyName:   "test-key",
-			data:      []byte("test"),
+			data:      make([]byte, 32),
 			prehashed: true,
 			serverResp: func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusForbidden)
@@ -560,24 +574,91 @@ func TestBaoClient_HealthConnectionError(t *testing.T) {
 }
 
 func TestBaoClient_NamespaceHeader(t *testing.T) {
-	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "test-namespace", r.Header.Get("X-Vault-Namespace"))
-		_ = json.NewEncoder(w).Encode(map[string]interface{}{
-			"data": KeyInfo{Name: "test"},
+	tests := []struct {
+		name      string
+		namespace string
+		want      []string
+	}{
+		{name: "set", namespace: "test-namespace", want: []string{"test-namespace"}},
+		{name: "trailing slash trimmed", namespace: "team-a/", want: []string{"team-a"}},
+		{name: "unset", namespace: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.want, r.Header.Values("X-Vault-Namespace"))
+				// The namespace is a header; the mount path stays in the URL
+				assert.Equal(t, "/v1/custom-secp/keys/test", r.URL.Path)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": KeyInfo{Name: "test"},
+				})
+			}))
+			defer server.Close()
+
+			client, err := NewBaoClient(Config{
+				BaoAddr:       server.URL,
+				BaoToken:      "test-token",
+				BaoNamespace:  tt.namespace,
+				Secp256k1Path: "custom-secp",
+				SkipTLSVerify: true,
+			})
+			require.NoError(t, err)
+
+			_, err = client.GetKey(context.Background(), "test")
+			require.NoError(t, err)
 		})
+	}
+}
+
+func TestBaoClient_CustomMountPath(t *testing.T) {
+	var paths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case strings.Contains(r.URL.Path, "/sign/"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": SignResponse{Signature: base64.StdEncoding.EncodeToString(make([]byte, 64))},
+			})
+		case strings.Contains(r.URL.Path, "/export/"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"name": "k", "keys": map[string]string{"1": "a2V5"}},
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": KeyInfo{Name: "k"},
+			})
+		}
 	}))
 	defer server.Close()
 
 	client, err := NewBaoClient(Config{
 		BaoAddr:       server.URL,
 		BaoToken:      "test-token",
-		BaoNamespace:  "test-namespace",
+		Secp256k1Path: "/team-a/secp256k1/",
 		SkipTLSVerify: true,
 	})
 	require.NoError(t, err)
 
-	_, err = client.GetKey(context.Background(), "test")
+	ctx := context.Background()
+	_, err = client.CreateKey(ctx, "k", KeyOptions{})
+	require.NoError(t, err)
+	_, err = client.Sign(ctx, "k", []byte("data"), false)
+	require.NoError(t, err)
+	_, err = client.ImportKey(ctx, "k", "a2V5", false)
+	require.NoError(t, err)
+	_, _, err = client.ExportKey(ctx, "k")
 	require.NoError(t, err)
+	require.NoError(t, client.DeleteKey(ctx, "k"))
+
+	assert.Equal(t, []string{
+		"POST /v1/team-a/secp256k1/keys/k",
+		"POST /v1/team-a/secp256k1/sign/k",
+		"POST /v1/team-a/secp256k1/keys/k/import",
+		"GET /v1/team-a/secp256k1/export/k",
+		"POST /v1/team-a/secp256k1/keys/k/config",
+		"DELETE /v1/team-a/secp256k1/keys/k",
+	}, paths)
 }
 
 func TestBaoClient_ContextCancellation(t *testing.T) {
@@ -695,6 +776,6 @@ func TestBaoClient_SignResponseUnmarshalError(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	_, err = client.Sign(context.Background(), "key", []byte("data"), true)
+	_, err = client.Sign(context.Background(), "key", make([]byte, 32), true)
 	require.Error(t, err)
 }
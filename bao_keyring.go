@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"strings"
 	"sync"
 	"time"
@@ -43,8 +44,15 @@ const BackendType = "openbao"
 //	}
 //	wg.Wait()
 type BaoKeyring struct {
-	client *BaoClient
+	client *BaoClient // nil when Config.Backend is not OpenBao
+	signer Signer
 	store  *BaoStore
+
+	healthMu   sync.RWMutex
+	health     HealthStatus
+	healthStop chan struct{} // nil when the health poller is not running
+	healthDone chan struct{}
+	closeOnce  sync.Once
 }
 
 // Verify interface compliance
@@ -52,12 +60,27 @@ var _ keyring.Keyring = (*BaoKeyring)(nil)
 
 // New creates a BaoKeyring with the given configuration.
 // It validates the configuration, creates a client, performs a health check,
-// and initializes the local metadata store.
+// and initializes the local metadata store. If Config.HealthCheckInterval is
+// set, OpenBao health is re-checked in the background until Close.
 func New(ctx context.Context, cfg Config) (*BaoKeyring, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
+	if cfg.Backend != nil {
+		store, err := NewEncryptedBaoStore(cfg.StorePath, cfg.StoreEncryption())
+		if err != nil {
+			return nil, fmt.Errorf("create store: %w", err)
+		}
+		client, _ := cfg.Backend.(*BaoClient)
+		kr := &BaoKeyring{client: client, signer: cfg.Backend, store: store}
+		if client != nil && cfg.HealthCheckInterval > 0 {
+			kr.setHealth(client.Health(ctx))
+			kr.startHealthPoller(cfg.HealthCheckInterval)
+		}
+		return kr, nil
+	}
+
 	client, err := NewBaoClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("create client: %w", err)
@@ -67,12 +90,17 @@ func New(ctx context.Context, cfg Config) (*BaoKeyring, error) {
 		return nil, fmt.Errorf("health check: %w", err)
 	}
 
-	store, err := NewBaoStore(cfg.StorePath)
+	store, err := NewEncryptedBaoStore(cfg.StorePath, cfg.StoreEncryption())
 	if err != nil {
 		return nil, fmt.Errorf("create store: %w", err)
 	}
 
-	return &BaoKeyring{client: client, store: store}, nil
+	kr := &BaoKeyring{client: client, signer: client, store: store}
+	kr.setHealth(nil)
+	if cfg.HealthCheckInterval > 0 {
+		kr.startHealthPoller(cfg.HealthCheckInterval)
+	}
+	return kr, nil
 }
 
 // Backend returns the backend type.
@@ -96,6 +124,23 @@ func (k *BaoKeyring) List() ([]*keyring.Record, error) {
 	return records, nil
 }
 
+// ListByLabel returns all keys carrying every label in selector,
+// e.g. map[string]string{"environment": "prod"}.
+func (k *BaoKeyring) ListByLabel(selector map[string]string) ([]*keyring.Record, error) {
+	metas, err := k.store.ListByLabel(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*keyring.Record, 0, len(metas))
+	for _, meta := range metas {
+		if record, err := k.metadataToRecord(meta); err == nil {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
 // SupportedAlgorithms returns supported signing algorithms.
 // Returns (supported, default) algorithm lists - only secp256k1 is supported.
 func (k *BaoKeyring) SupportedAlgorithms() (keyring.SigningAlgoList, keyring.SigningAlgoList) {
@@ -122,7 +167,13 @@ func (k *BaoKeyring) KeyByAddress(address sdk.Address) (*keyring.Record, error)
 }
 
 // Delete removes a key by UID.
+// With a non-OpenBao backend only the local metadata is removed;
+// the backend key itself is left untouched.
 func (k *BaoKeyring) Delete(uid string) error {
+	if k.client == nil {
+		return k.store.Delete(uid)
+	}
+
 	ctx := context.Background()
 
 	// Delete from OpenBao first
@@ -162,6 +213,10 @@ func (k *BaoKeyring) NewAccount(uid, mnemonic, bip39Passphrase, hdPath string, a
 		return nil, ErrUnsupportedAlgo
 	}
 
+	if err := k.requireBao("create"); err != nil {
+		return nil, err
+	}
+
 	// Check if key already exists
 	if k.store.Has(uid) {
 		return nil, fmt.Errorf("%w: %s", ErrKeyExists, uid)
@@ -192,6 +247,7 @@ func (k *BaoKeyring) NewAccount(uid, mnemonic, bip39Passphrase, hdPath string, a
 		Address:     keyInfo.Address,
 		BaoKeyPath:  fmt.Sprintf("%s/keys/%s", k.client.secp256k1Path, uid),
 		Algorithm:   AlgorithmSecp256k1,
+		Curve:       CurveSecp256k1,
 		Exportable:  false,
 		CreatedAt:   time.Now().UTC(),
 		Source:      SourceGenerated,
@@ -225,7 +281,28 @@ func (k *BaoKeyring) SaveMultisig(uid string, pubkey cryptotypes.PubKey) (*keyri
 // Sign signs message bytes using OpenBao.
 // The message is hashed with SHA-256 before being sent to OpenBao.
 // Returns a 64-byte Cosmos signature (R||S format) and the secp256k1 public key.
+//
+// msg must be the sign bytes already produced by the SDK's sign mode handler.
+// Sign modes whose signatures are a secp256k1 signature over SHA-256 of those
+// bytes are supported: DIRECT, DIRECT_AUX, LEGACY_AMINO_JSON and TEXTUAL.
+// Other modes, such as EIP_191 which hashes with Keccak-256, return
+// ErrUnsupportedSignMode.
 func (k *BaoKeyring) Sign(uid string, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	return k.SignWithOptions(uid, msg, signMode, SignOptions{})
+}
+
+// SignWithOptions is Sign with a configurable signature format. The backend
+// always returns a 64-byte R||S signature; Ethereum and DER signatures are
+// encoded from it, with the Ethereum recovery id found by recovering the
+// key's public key from the SHA-256 digest of msg.
+func (k *BaoKeyring) SignWithOptions(uid string, msg []byte, signMode signing.SignMode, opts SignOptions) ([]byte, cryptotypes.PubKey, error) {
+	if err := validateSignMode(signMode); err != nil {
+		return nil, nil, err
+	}
+	if err := validateSignatureFormat(opts.OutputFormat); err != nil {
+		return nil, nil, err
+	}
+
 	// Get key metadata from store
 	meta, err := k.store.Get(uid)
 	if err != nil {
@@ -235,18 +312,50 @@ func (k *BaoKeyring) Sign(uid string, msg []byte, signMode signing.SignMode) ([]
 	// Hash the message with SHA-256
 	hash := sha256.Sum256(msg)
 
-	// Sign via OpenBao with prehashed=true (returns 64-byte Cosmos format)
+	// Sign via the backend with prehashed=true (returns 64-byte Cosmos format)
 	ctx := context.Background()
-	sig, err := k.client.Sign(ctx, uid, hash[:], true)
+	sig, err := k.signer.Sign(ctx, uid, hash[:], true)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Backends should already return low-S signatures; enforce it so
+	// callers never see a malleable one
+	sig, err = normalizeLowS(sig)
+	if err != nil {
+		return nil, nil, err
+	}
+	sig, err = formatSignature(sig, hash[:], meta.PubKeyBytes, opts.OutputFormat)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// A failed last-used write must not fail the signature
+	if err := k.store.MarkUsed(uid, time.Now()); err != nil {
+		slog.Warn("Failed to record key use",
+			slog.String("uid", uid),
+			slog.String("error", err.Error()),
+		)
+	}
+
 	// Return signature and public key
 	pubKey := &secp256k1.PubKey{Key: meta.PubKeyBytes}
 	return sig, pubKey, nil
 }
 
+// validateSignMode returns ErrUnsupportedSignMode for sign modes whose sign
+// bytes are not signed as SHA-256 digests.
+func validateSignMode(signMode signing.SignMode) error {
+	switch signMode {
+	case signing.SignMode_SIGN_MODE_DIRECT,
+		signing.SignMode_SIGN_MODE_DIRECT_AUX,
+		signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		signing.SignMode_SIGN_MODE_TEXTUAL:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedSignMode, signMode)
+	}
+}
+
 // SignByAddress signs using the key at the given address.
 // It looks up the key by address and delegates to Sign.
 func (k *BaoKeyring) SignByAddress(address sdk.Address, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
@@ -321,8 +430,10 @@ func (k *BaoKeyring) ExportPrivKeyArmorByAddress(address sdk.Address, encryptPas
 	return "", fmt.Errorf("%w: private keys never leave OpenBao", ErrKeyNotExportable)
 }
 
-// Close releases resources and syncs pending changes to the store.
+// Close stops the health poller, releases resources and syncs pending
+// changes to the store.
 func (k *BaoKeyring) Close() error {
+	k.closeOnce.Do(k.stopHealthPoller)
 	if k.store != nil {
 		return k.store.Close()
 	}
@@ -331,13 +442,29 @@ func (k *BaoKeyring) Close() error {
 
 // --- Extended methods for migration ---
 
-// GetMetadata returns raw metadata.
+// GetMetadata returns raw metadata, including the key's creation and
+// last-used timestamps. Algorithm and Curve are filled in for entries
+// written before they were recorded.
 func (k *BaoKeyring) GetMetadata(uid string) (*KeyMetadata, error) {
-	return k.store.Get(uid)
+	meta, err := k.store.Get(uid)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Algorithm == "" {
+		meta.Algorithm = AlgorithmSecp256k1
+	}
+	if meta.Curve == "" && meta.Algorithm == AlgorithmSecp256k1 {
+		meta.Curve = CurveSecp256k1
+	}
+	return meta, nil
 }
 
 // NewAccountWithOptions creates a key with options.
 func (k *BaoKeyring) NewAccountWithOptions(uid string, opts KeyOptions) (*keyring.Record, error) {
+	if err := k.requireBao("create"); err != nil {
+		return nil, err
+	}
+
 	// Check if key already exists
 	if k.store.Has(uid) {
 		return nil, fmt.Errorf("%w: %s", ErrKeyExists, uid)
@@ -367,9 +494,11 @@ func (k *BaoKeyring) NewAccountWithOptions(uid string, opts KeyOptions) (*keyrin
 		Address:     keyInfo.Address,
 		BaoKeyPath:  fmt.Sprintf("%s/keys/%s", k.client.secp256k1Path, uid),
 		Algorithm:   AlgorithmSecp256k1,
+		Curve:       CurveSecp256k1,
 		Exportable:  opts.Exportable,
 		CreatedAt:   time.Now().UTC(),
 		Source:      SourceGenerated,
+		Labels:      copyLabels(opts.Labels),
 	}
 
 	if err := k.store.Save(meta); err != nil {
@@ -383,6 +512,10 @@ func (k *BaoKeyring) NewAccountWithOptions(uid string, opts KeyOptions) (*keyrin
 // ImportKey imports a key from base64-encoded raw private key bytes.
 // This is used for secure key transfer from local keyrings to OpenBao.
 func (k *BaoKeyring) ImportKey(uid string, ciphertext string, exportable bool) (*keyring.Record, error) {
+	if err := k.requireBao("import"); err != nil {
+		return nil, err
+	}
+
 	// Check if key already exists
 	if k.store.Has(uid) {
 		return nil, fmt.Errorf("%w: %s", ErrKeyExists, uid)
@@ -413,6 +546,7 @@ func (k *BaoKeyring) ImportKey(uid string, ciphertext string, exportable bool) (
 		Address:     keyInfo.Address,
 		BaoKeyPath:  fmt.Sprintf("%s/keys/%s", k.client.secp256k1Path, uid),
 		Algorithm:   AlgorithmSecp256k1,
+		Curve:       CurveSecp256k1,
 		Exportable:  exportable,
 		CreatedAt:   time.Now().UTC(),
 		Source:      SourceImported,
@@ -441,6 +575,10 @@ func (k *BaoKeyring) ExportKey(uid string) (string, error) {
 		return "", fmt.Errorf("%w: %s", ErrKeyNotExportable, uid)
 	}
 
+	if err := k.requireBao("export"); err != nil {
+		return "", err
+	}
+
 	ctx := context.Background()
 	keyData, _, err := k.client.ExportKey(ctx, uid)
 	if err != nil {
@@ -578,8 +716,56 @@ func (k *BaoKeyring) SignBatch(ctx context.Context, requests []BatchSignRequest)
 	return results
 }
 
+// AddBackendKey registers an existing backend key in the local store so it
+// can be used for signing. This is how keys are added when Config.Backend is
+// a KMSClient: the key is looked up by uid (e.g. the KMS alias) and its
+// public key and address are recorded locally.
+func (k *BaoKeyring) AddBackendKey(uid string) (*keyring.Record, error) {
+	if k.store.Has(uid) {
+		return nil, fmt.Errorf("%w: %s", ErrKeyExists, uid)
+	}
+
+	ctx := context.Background()
+	pubKeyBytes, err := k.signer.PublicKey(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	address, err := k.signer.Address(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &KeyMetadata{
+		UID:         uid,
+		Name:        uid,
+		PubKeyBytes: pubKeyBytes,
+		PubKeyType:  "secp256k1",
+		Address:     address,
+		Algorithm:   AlgorithmSecp256k1,
+		Curve:       CurveSecp256k1,
+		CreatedAt:   time.Now().UTC(),
+		Source:      SourceSynced,
+	}
+	if k.client != nil {
+		meta.BaoKeyPath = fmt.Sprintf("%s/keys/%s", k.client.secp256k1Path, uid)
+	}
+
+	if err := k.store.Save(meta); err != nil {
+		return nil, err
+	}
+	return k.metadataToRecord(meta)
+}
+
 // --- Helper methods ---
 
+// requireBao returns ErrUnsupportedBackend for operations only OpenBao supports.
+func (k *BaoKeyring) requireBao(op string) error {
+	if k.client == nil {
+		return fmt.Errorf("%w: %s", ErrUnsupportedBackend, op)
+	}
+	return nil
+}
+
 // metadataToRecord converts KeyMetadata to keyring.Record.
 // Uses NewOfflineRecord since private keys are stored in OpenBao, not locally.
 func (k *BaoKeyring) metadataToRecord(meta *KeyMetadata) (*keyring.Record, error) {
@@ -590,8 +776,12 @@ func (k *BaoKeyring) metadataToRecord(meta *KeyMetadata) (*keyring.Record, error
 // newBaoKeyringForTesting creates a BaoKeyring for testing without real connections.
 // This is used only in tests to bypass the actual OpenBao connection.
 func newBaoKeyringForTesting(client *BaoClient, store *BaoStore) *BaoKeyring {
-	return &BaoKeyring{
+	kr := &BaoKeyring{
 		client: client,
 		store:  store,
 	}
+	if client != nil {
+		kr.signer = client
+	}
+	return kr
 }
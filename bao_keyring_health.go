@@ -0,0 +1,69 @@
+package popsigner
+
+import (
+	"context"
+	"time"
+)
+
+// Healthy reports whether the most recent OpenBao health check succeeded.
+// Services can use it as a readiness probe to stop taking traffic when
+// OpenBao seals, before signing starts to fail. Keyrings whose signing
+// backend is not OpenBao, or that have not checked OpenBao, are healthy.
+func (k *BaoKeyring) Healthy() bool {
+	if k.client == nil {
+		return true
+	}
+	status := k.HealthStatus()
+	return status.Healthy || status.CheckedAt.IsZero()
+}
+
+// HealthStatus returns the result of the most recent OpenBao health check.
+// Without Config.HealthCheckInterval this is the check made by New.
+func (k *BaoKeyring) HealthStatus() HealthStatus {
+	k.healthMu.RLock()
+	defer k.healthMu.RUnlock()
+	return k.health
+}
+
+// setHealth records the result of a health check.
+func (k *BaoKeyring) setHealth(err error) {
+	k.healthMu.Lock()
+	defer k.healthMu.Unlock()
+	k.health = HealthStatus{Healthy: err == nil, Err: err, CheckedAt: time.Now()}
+}
+
+// startHealthPoller checks OpenBao health every interval until
+// stopHealthPoller is called.
+func (k *BaoKeyring) startHealthPoller(interval time.Duration) {
+	k.healthStop = make(chan struct{})
+	k.healthDone = make(chan struct{})
+
+	go func() {
+		defer close(k.healthDone)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-k.healthStop:
+				return
+			case <-ticker.C:
+				// Bound each check by the interval so a hung request can't
+				// hold back the next one
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				k.setHealth(k.client.Health(ctx))
+				cancel()
+			}
+		}
+	}()
+}
+
+// stopHealthPoller stops the health poller and waits for it to exit.
+func (k *BaoKeyring) stopHealthPoller() {
+	if k.healthStop == nil {
+		return
+	}
+	close(k.healthStop)
+	<-k.healthDone
+}
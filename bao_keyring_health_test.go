@@ -0,0 +1,100 @@
+package popsigner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSealableServer returns an OpenBao test server whose health endpoint
+// reports sealed while sealed is true.
+func newSealableServer(sealed *atomic.Bool) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sys/health" && sealed.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestBaoKeyring_HealthPoller_TracksSealState(t *testing.T) {
+	var sealed atomic.Bool
+	server := newSealableServer(&sealed)
+	defer server.Close()
+
+	kr, err := New(context.Background(), Config{
+		BaoAddr:             server.URL,
+		BaoToken:            "test-token",
+		StorePath:           filepath.Join(t.TempDir(), "keyring.json"),
+		SkipTLSVerify:       true,
+		HealthCheckInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer kr.Close()
+
+	assert.True(t, kr.Healthy())
+	assert.False(t, kr.HealthStatus().CheckedAt.IsZero())
+
+	// healthy -> sealed
+	sealed.Store(true)
+	require.Eventually(t, func() bool { return !kr.Healthy() }, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, kr.HealthStatus().Err, ErrBaoSealed)
+
+	// sealed -> healthy
+	sealed.Store(false)
+	require.Eventually(t, kr.Healthy, time.Second, 5*time.Millisecond)
+	assert.NoError(t, kr.HealthStatus().Err)
+}
+
+func TestBaoKeyring_HealthPoller_StopsOnClose(t *testing.T) {
+	var sealed atomic.Bool
+	server := newSealableServer(&sealed)
+	defer server.Close()
+
+	kr, err := New(context.Background(), Config{
+		BaoAddr:             server.URL,
+		BaoToken:            "test-token",
+		StorePath:           filepath.Join(t.TempDir(), "keyring.json"),
+		SkipTLSVerify:       true,
+		HealthCheckInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.NoError(t, kr.Close())
+
+	// No checks run after Close, so the status stays healthy
+	sealed.Store(true)
+	checkedAt := kr.HealthStatus().CheckedAt
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, kr.Healthy())
+	assert.Equal(t, checkedAt, kr.HealthStatus().CheckedAt)
+
+	// Close is idempotent
+	assert.NoError(t, kr.Close())
+}
+
+func TestBaoKeyring_Healthy_WithoutPoller(t *testing.T) {
+	var sealed atomic.Bool
+	server := newSealableServer(&sealed)
+	defer server.Close()
+
+	kr, err := New(context.Background(), Config{
+		BaoAddr:       server.URL,
+		BaoToken:      "test-token",
+		StorePath:     filepath.Join(t.TempDir(), "keyring.json"),
+		SkipTLSVerify: true,
+	})
+	require.NoError(t, err)
+	defer kr.Close()
+
+	// Only the check made by New is reported
+	sealed.Store(true)
+	assert.True(t, kr.Healthy())
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -331,6 +333,7 @@ func TestBaoKeyring_Sign_DifferentSignModes(t *testing.T) {
 
 	signModes := []signing.SignMode{
 		signing.SignMode_SIGN_MODE_DIRECT,
+		signing.SignMode_SIGN_MODE_DIRECT_AUX,
 		signing.SignMode_SIGN_MODE_TEXTUAL,
 		signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
 	}
@@ -362,6 +365,94 @@ func TestBaoKeyring_Sign_DifferentSignModes(t *testing.T) {
 	}
 }
 
+func TestBaoKeyring_Sign_UpdatesLastUsedAt(t *testing.T) {
+	validSig := validSignatureResponse()
+	pubKeyBytes := testPubKeyBytes()
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sys/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": SignResponse{
+				Signature: base64.StdEncoding.EncodeToString(validSig),
+			},
+		})
+	}
+
+	kr, server := setupTestKeyringWithKey(t, "used-key", "cosmos1used", pubKeyBytes, handler)
+	defer server.Close()
+
+	meta, err := kr.GetMetadata("used-key")
+	require.NoError(t, err)
+	assert.Nil(t, meta.LastUsedAt)
+
+	before := time.Now()
+	_, _, err = kr.Sign("used-key", []byte("test"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+
+	meta, err = kr.GetMetadata("used-key")
+	require.NoError(t, err)
+	require.NotNil(t, meta.LastUsedAt)
+	assert.False(t, meta.LastUsedAt.Before(before))
+}
+
+func TestBaoKeyring_Sign_FailureDoesNotUpdateLastUsedAt(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sys/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	kr, server := setupTestKeyringWithKey(t, "failed-key", "cosmos1failed", testPubKeyBytes(), handler)
+	defer server.Close()
+
+	_, _, err := kr.Sign("failed-key", []byte("test"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.Error(t, err)
+
+	meta, err := kr.GetMetadata("failed-key")
+	require.NoError(t, err)
+	assert.Nil(t, meta.LastUsedAt)
+}
+
+func TestBaoKeyring_Sign_UnsupportedSignModes(t *testing.T) {
+	pubKeyBytes := testPubKeyBytes()
+
+	signModes := []signing.SignMode{
+		signing.SignMode_SIGN_MODE_UNSPECIFIED,
+		signing.SignMode_SIGN_MODE_EIP_191,
+		signing.SignMode(42),
+	}
+
+	for _, mode := range signModes {
+		t.Run(mode.String(), func(t *testing.T) {
+			signCalled := false
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/sys/health" {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				signCalled = true
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			kr, server := setupTestKeyringWithKey(t, "mode-test", "cosmos1mode", pubKeyBytes, handler)
+			defer server.Close()
+
+			sig, pubKey, err := kr.Sign("mode-test", []byte("test"), mode)
+
+			require.ErrorIs(t, err, ErrUnsupportedSignMode)
+			assert.Nil(t, sig)
+			assert.Nil(t, pubKey)
+			assert.False(t, signCalled, "unsupported sign mode should not reach OpenBao")
+		})
+	}
+}
+
 func TestBaoKeyring_Sign_ReturnsCorrectPubKey(t *testing.T) {
 	validSig := validSignatureResponse()
 	pubKeyBytes := testPubKeyBytes()
@@ -530,6 +621,118 @@ func TestBaoKeyring_SignByAddress_OpenBaoError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBaoKeyring_SignWithOptions_OutputFormats(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubKey := priv.PubKey()
+
+	// Sign the prehashed input with the real key, like the plugin does
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		hash, err := base64.StdEncoding.DecodeString(body["input"].(string))
+		require.NoError(t, err)
+
+		// SignCompact returns V||R||S
+		compact := ecdsa.SignCompact(priv, hash, true)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": SignResponse{Signature: base64.StdEncoding.EncodeToString(compact[1:])},
+		})
+	}
+
+	kr, server := setupTestKeyringWithKey(t, "format-key", "cosmos1format", pubKey.SerializeCompressed(), handler)
+	defer server.Close()
+
+	msg := []byte("format me")
+	hash := sha256.Sum256(msg)
+
+	sign := func(format SignatureFormat) []byte {
+		t.Helper()
+		sig, _, err := kr.SignWithOptions("format-key", msg, signing.SignMode_SIGN_MODE_DIRECT, SignOptions{OutputFormat: format})
+		require.NoError(t, err)
+		return sig
+	}
+
+	t.Run("compact64 is the default", func(t *testing.T) {
+		sig, cosmosPubKey, err := kr.Sign("format-key", msg, signing.SignMode_SIGN_MODE_DIRECT)
+		require.NoError(t, err)
+		assert.Equal(t, sig, sign(SignatureFormatCompact64))
+
+		require.Len(t, sig, 64)
+		assert.True(t, cosmosPubKey.VerifySignature(msg, sig))
+	})
+
+	t.Run("ethereum65 recovers the public key", func(t *testing.T) {
+		sig := sign(SignatureFormatEthereum65)
+		require.Len(t, sig, 65)
+		v := sig[64]
+		assert.Contains(t, []byte{27, 28}, v)
+
+		// RecoverCompact expects V||R||S
+		compact := append([]byte{v}, sig[:64]...)
+		recovered, _, err := ecdsa.RecoverCompact(compact, hash[:])
+		require.NoError(t, err)
+		assert.True(t, recovered.IsEqual(pubKey))
+	})
+
+	t.Run("der verifies", func(t *testing.T) {
+		sig := sign(SignatureFormatDER)
+		parsed, err := ecdsa.ParseDERSignature(sig)
+		require.NoError(t, err)
+		assert.True(t, parsed.Verify(hash[:], pubKey))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, _, err := kr.SignWithOptions("format-key", msg, signing.SignMode_SIGN_MODE_DIRECT, SignOptions{OutputFormat: "pem"})
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestBaoKeyring_Sign_NormalizesHighS(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	// The backend returns a valid signature with S in the high half
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		hash, err := base64.StdEncoding.DecodeString(body["input"].(string))
+		require.NoError(t, err)
+
+		compact := ecdsa.SignCompact(priv, hash, true)
+		var s secp256k1.ModNScalar
+		s.SetByteSlice(compact[33:])
+		s.Negate()
+		require.True(t, s.IsOverHalfOrder())
+
+		sig := make([]byte, 64)
+		copy(sig, compact[1:33])
+		s.PutBytesUnchecked(sig[32:])
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": SignResponse{Signature: base64.StdEncoding.EncodeToString(sig)},
+		})
+	}
+
+	kr, server := setupTestKeyringWithKey(t, "high-s-key", "cosmos1highs", priv.PubKey().SerializeCompressed(), handler)
+	defer server.Close()
+
+	msg := []byte("malleable")
+	sig, pubKey, err := kr.Sign("high-s-key", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	require.Len(t, sig, 64)
+
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(sig[32:])
+	assert.False(t, s.IsOverHalfOrder(), "signature should have low S")
+	// The Cosmos SDK rejects high-S signatures, so this also checks the value
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+
+	// Ethereum signatures are built from the normalized signature
+	eth, _, err := kr.SignWithOptions("high-s-key", msg, signing.SignMode_SIGN_MODE_DIRECT, SignOptions{OutputFormat: SignatureFormatEthereum65})
+	require.NoError(t, err)
+	assert.Equal(t, sig, eth[:64])
+}
+
 // ============================================
 // Integration-style Tests
 // ============================================
@@ -1005,6 +1208,48 @@ func TestBaoKeyring_List_MultipleKeys(t *testing.T) {
 	assert.Len(t, records, 3)
 }
 
+// TestBaoKeyring_ListByLabel tests filtering keys by label selector.
+func TestBaoKeyring_ListByLabel(t *testing.T) {
+	pubKeyBytes := testPubKeyBytes()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	kr, server := setupTestKeyring(t, handler)
+	defer server.Close()
+	defer func() { _ = kr.store.Close() }()
+
+	labels := []map[string]string{
+		{"environment": "prod", "role": "sequencer"},
+		{"environment": "prod", "role": "batcher"},
+		{"environment": "staging", "role": "sequencer"},
+	}
+	for i, l := range labels {
+		meta := &KeyMetadata{
+			UID:         fmt.Sprintf("key-%d", i),
+			Name:        fmt.Sprintf("key-%d", i),
+			PubKeyBytes: pubKeyBytes,
+			PubKeyType:  "secp256k1",
+			Address:     fmt.Sprintf("cosmos1addr%d", i),
+			BaoKeyPath:  fmt.Sprintf("secp256k1/keys/key-%d", i),
+			Algorithm:   AlgorithmSecp256k1,
+			CreatedAt:   time.Now(),
+			Source:      SourceGenerated,
+			Labels:      l,
+		}
+		require.NoError(t, kr.store.Save(meta))
+	}
+
+	records, err := kr.ListByLabel(map[string]string{"environment": "prod"})
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	records, err = kr.ListByLabel(map[string]string{"environment": "prod", "role": "sequencer"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "key-0", records[0].Name)
+}
+
 // TestBaoKeyring_NewAccount_Success tests successful account creation.
 func TestBaoKeyring_NewAccount_Success(t *testing.T) {
 	pubKeyHex := "02" + "0102030405060708091011121314151617181920212223242526272829303132"
@@ -1043,6 +1288,8 @@ func TestBaoKeyring_NewAccount_Success(t *testing.T) {
 	assert.Equal(t, "new-key", meta.UID)
 	assert.Equal(t, expectedAddr, meta.Address)
 	assert.Equal(t, SourceGenerated, meta.Source)
+	assert.Equal(t, AlgorithmSecp256k1, meta.Algorithm)
+	assert.Equal(t, CurveSecp256k1, meta.Curve)
 }
 
 // TestBaoKeyring_NewAccount_KeyExists tests account creation when key already exists.
@@ -1312,6 +1559,8 @@ func TestBaoKeyring_GetMetadata_Success(t *testing.T) {
 	assert.Equal(t, "test-key", meta.UID)
 	assert.Equal(t, "cosmos1test", meta.Address)
 	assert.Equal(t, pubKeyBytes, meta.PubKeyBytes)
+	assert.Equal(t, AlgorithmSecp256k1, meta.Algorithm)
+	assert.Equal(t, CurveSecp256k1, meta.Curve)
 }
 
 // TestBaoKeyring_GetMetadata_NotFound tests metadata retrieval for non-existent key.
@@ -1356,7 +1605,7 @@ func TestBaoKeyring_NewAccountWithOptions_Success(t *testing.T) {
 	defer server.Close()
 	defer func() { _ = kr.store.Close() }()
 
-	opts := KeyOptions{Exportable: true}
+	opts := KeyOptions{Exportable: true, Labels: map[string]string{"environment": "prod"}}
 	record, err := kr.NewAccountWithOptions("exportable-key", opts)
 	require.NoError(t, err)
 	require.NotNil(t, record)
@@ -1365,6 +1614,7 @@ func TestBaoKeyring_NewAccountWithOptions_Success(t *testing.T) {
 	meta, err := kr.store.Get("exportable-key")
 	require.NoError(t, err)
 	assert.True(t, meta.Exportable)
+	assert.Equal(t, map[string]string{"environment": "prod"}, meta.Labels)
 }
 
 // TestBaoKeyring_NewAccountWithOptions_KeyExists tests account creation with options when key exists.
@@ -7,26 +7,51 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 )
 
+// DefaultUsedFlushInterval is how often last-used timestamps are written to
+// disk. Signing is frequent, so updates in between are kept in memory.
+const DefaultUsedFlushInterval = time.Minute
+
 // BaoStore manages local key metadata with atomic file persistence.
 type BaoStore struct {
-	mu    sync.RWMutex
-	path  string
-	data  *StoreData
-	dirty bool
+	mu     sync.RWMutex
+	path   string
+	data   *StoreData
+	dirty  bool
+	cipher *storeCipher // nil when the store is written as plaintext
+
+	usedFlushInterval time.Duration
+	lastUsedFlush     time.Time // when MarkUsed last wrote to disk
 }
 
 // NewBaoStore creates or opens a store at the given path.
 // If the file doesn't exist, a new empty store is created.
 // If the directory doesn't exist, it is created with 0700 permissions.
 func NewBaoStore(path string) (*BaoStore, error) {
+	return NewEncryptedBaoStore(path, StoreEncryption{})
+}
+
+// NewEncryptedBaoStore creates or opens a store that is encrypted at rest with
+// AES-256-GCM. Existing plaintext stores are still loaded and are encrypted on
+// the next write. A zero StoreEncryption behaves like NewBaoStore.
+func NewEncryptedBaoStore(path string, enc StoreEncryption) (*BaoStore, error) {
 	store := &BaoStore{
 		path: path,
 		data: &StoreData{
 			Version: DefaultStoreVersion,
 			Keys:    make(map[string]*KeyMetadata),
 		},
+		usedFlushInterval: DefaultUsedFlushInterval,
+	}
+
+	if enc.Enabled() {
+		c, err := newStoreCipher(enc)
+		if err != nil {
+			return nil, err
+		}
+		store.cipher = c
 	}
 
 	// Create directory with restricted permissions
@@ -61,14 +86,46 @@ func (s *BaoStore) load() error {
 		return nil
 	}
 
+	if isEncryptedStore(data) {
+		if s.cipher == nil {
+			return ErrStoreEncrypted
+		}
+		if data, err = s.cipher.open(data); err != nil {
+			return err
+		}
+	}
+
+	storeData, err := decodeStoreData(data)
+	if err != nil {
+		return err
+	}
+
+	if storeData.Version < DefaultStoreVersion {
+		migrated, err := migrateStore(storeData)
+		if err != nil {
+			return err
+		}
+		// Persist the upgraded schema so migrations run only once.
+		s.data = migrated
+		s.dirty = true
+		return s.syncLocked()
+	}
+
+	s.data = storeData
+	s.dirty = false
+	return nil
+}
+
+// decodeStoreData parses and validates plaintext store JSON.
+func decodeStoreData(data []byte) (*StoreData, error) {
 	var storeData StoreData
 	if err := json.Unmarshal(data, &storeData); err != nil {
-		return fmt.Errorf("%w: %v", ErrStoreCorrupted, err)
+		return nil, fmt.Errorf("%w: %v", ErrStoreCorrupted, err)
 	}
 
 	// Version validation
 	if storeData.Version > DefaultStoreVersion {
-		return fmt.Errorf("%w: unsupported version %d", ErrStoreCorrupted, storeData.Version)
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrStoreCorrupted, storeData.Version)
 	}
 
 	// Ensure Keys map is initialized
@@ -76,9 +133,7 @@ func (s *BaoStore) load() error {
 		storeData.Keys = make(map[string]*KeyMetadata)
 	}
 
-	s.data = &storeData
-	s.dirty = false
-	return nil
+	return &storeData, nil
 }
 
 // syncLocked writes store data atomically using temp file + rename pattern.
@@ -93,7 +148,24 @@ func (s *BaoStore) syncLocked() error {
 		return fmt.Errorf("marshal: %w", err)
 	}
 
-	tmpPath := s.path + ".tmp"
+	if s.cipher != nil {
+		if data, err = s.cipher.seal(data); err != nil {
+			return fmt.Errorf("%w: encrypt: %v", ErrStorePersist, err)
+		}
+	}
+
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return err
+	}
+
+	s.dirty = false
+	return nil
+}
+
+// writeFileAtomic writes data to path using the temp file + rename pattern
+// with 0600 permissions.
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + ".tmp"
 
 	// Create temp file with restricted permissions
 	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
@@ -122,12 +194,11 @@ func (s *BaoStore) syncLocked() error {
 	}
 
 	// Atomic rename
-	if err := os.Rename(tmpPath, s.path); err != nil {
+	if err := os.Rename(tmpPath, path); err != nil {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("%w: rename: %v", ErrStorePersist, err)
 	}
 
-	s.dirty = false
 	return nil
 }
 
@@ -143,6 +214,81 @@ func (s *BaoStore) Close() error {
 	return s.Sync()
 }
 
+// Backup writes a plaintext JSON snapshot of the store to w.
+// The snapshot is never encrypted, even for encrypted stores; protect it accordingly.
+func (s *BaoStore) Backup(w io.Writer) error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write backup: %w", err)
+	}
+	return nil
+}
+
+// RestoreStore replaces the store file at path with a snapshot written by Backup.
+// Snapshots from a newer store version are rejected with ErrStoreCorrupted.
+// The file is written atomically with 0600 permissions, as plaintext. If path
+// holds an encrypted store, RestoreStore returns ErrStoreEncrypted and leaves
+// it untouched; use RestoreEncryptedStore instead.
+// Any BaoStore already open on path must be reopened to see the restored keys.
+func RestoreStore(r io.Reader, path string) error {
+	return RestoreEncryptedStore(r, path, StoreEncryption{})
+}
+
+// RestoreEncryptedStore is like RestoreStore but encrypts the restored store
+// with enc, the same way NewEncryptedBaoStore writes it. A zero StoreEncryption
+// behaves like RestoreStore.
+func RestoreEncryptedStore(r io.Reader, path string, enc StoreEncryption) error {
+	var cipher *storeCipher
+	if enc.Enabled() {
+		c, err := newStoreCipher(enc)
+		if err != nil {
+			return err
+		}
+		cipher = c
+	} else {
+		// Never downgrade an encrypted store to plaintext
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("read file: %w", err)
+		}
+		if isEncryptedStore(existing) {
+			return ErrStoreEncrypted
+		}
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read backup: %w", err)
+	}
+
+	storeData, err := decodeStoreData(data)
+	if err != nil {
+		return err
+	}
+
+	data, err = json.MarshalIndent(storeData, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if cipher != nil {
+		if data, err = cipher.seal(data); err != nil {
+			return fmt.Errorf("%w: encrypt: %v", ErrStorePersist, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	return writeFileAtomic(path, data)
+}
+
 // Path returns the store file path.
 func (s *BaoStore) Path() string {
 	return s.path
@@ -208,6 +354,20 @@ func (s *BaoStore) List() ([]*KeyMetadata, error) {
 	return result, nil
 }
 
+// ListByLabel returns metadata for keys carrying every label in selector.
+func (s *BaoStore) ListByLabel(selector map[string]string) ([]*KeyMetadata, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*KeyMetadata, 0)
+	for _, meta := range s.data.Keys {
+		if meta.MatchesLabels(selector) {
+			result = append(result, copyMetadata(meta))
+		}
+	}
+	return result, nil
+}
+
 // Delete removes metadata.
 func (s *BaoStore) Delete(uid string) error {
 	s.mu.Lock()
@@ -247,6 +407,31 @@ func (s *BaoStore) Rename(oldUID, newUID string) error {
 	return s.syncLocked()
 }
 
+// MarkUsed records that the key was used at t. To avoid a write per
+// signature, the update is written to disk at most once per flush interval;
+// in between it is kept in memory and written by the next Sync or Close.
+func (s *BaoStore) MarkUsed(uid string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, exists := s.data.Keys[uid]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, uid)
+	}
+	if meta.LastUsedAt != nil && !t.After(*meta.LastUsedAt) {
+		return nil
+	}
+
+	meta.LastUsedAt = &t
+	s.dirty = true
+
+	if t.Sub(s.lastUsedFlush) < s.usedFlushInterval {
+		return nil
+	}
+	s.lastUsedFlush = t
+	return s.syncLocked()
+}
+
 // Has checks existence.
 func (s *BaoStore) Has(uid string) bool {
 	s.mu.RLock()
@@ -284,9 +469,32 @@ func copyMetadata(meta *KeyMetadata) *KeyMetadata {
 		cp.PubKeyBytes = make([]byte, len(meta.PubKeyBytes))
 		copy(cp.PubKeyBytes, meta.PubKeyBytes)
 	}
+	cp.LastUsedAt = copyTime(meta.LastUsedAt)
+	cp.Labels = copyLabels(meta.Labels)
 	return &cp
 }
 
+// copyTime returns a copy of a time pointer, preserving nil.
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	cp := *t
+	return &cp
+}
+
+// copyLabels returns a copy of a label map, preserving nil.
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	cp := make(map[string]string, len(labels))
+	for k, v := range labels {
+		cp[k] = v
+	}
+	return cp
+}
+
 // newStoreForTesting creates a store for testing without file operations.
 // This is used only in tests to bypass the file-based NewBaoStore.
 func newStoreForTesting() *BaoStore {
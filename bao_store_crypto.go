@@ -0,0 +1,178 @@
+package popsigner
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted store file layout:
+//
+//	magic (12) | format version (1) | kdf (1) | salt (16) | nonce (12) | ciphertext
+//
+// The header up to and including the nonce is authenticated as additional data.
+const (
+	storeEncryptionMagic   = "POPSIGNERENC"
+	storeEncryptionVersion = 1
+
+	storeKDFNone   = 0 // StoreEncryption.Key used as-is
+	storeKDFScrypt = 1 // key derived from StoreEncryption.Passphrase
+
+	storeKeySize   = 32
+	storeSaltSize  = 16
+	storeNonceSize = 12
+
+	storeHeaderSize = len(storeEncryptionMagic) + 2 + storeSaltSize + storeNonceSize
+)
+
+// scrypt parameters for passphrase-derived store keys.
+const (
+	storeScryptN = 1 << 15
+	storeScryptR = 8
+	storeScryptP = 1
+)
+
+// StoreEncryption configures at-rest encryption of the local metadata store.
+// Set either Key or Passphrase; a zero value disables encryption.
+type StoreEncryption struct {
+	Key        []byte // 32-byte AES-256 key
+	Passphrase string // Passphrase stretched with scrypt
+}
+
+// Enabled reports whether encryption is configured.
+func (e StoreEncryption) Enabled() bool {
+	return len(e.Key) > 0 || e.Passphrase != ""
+}
+
+// Validate checks the encryption settings.
+func (e StoreEncryption) Validate() error {
+	if len(e.Key) > 0 && e.Passphrase != "" {
+		return fmt.Errorf("%w: set either a key or a passphrase, not both", ErrInvalidStoreKey)
+	}
+	if len(e.Key) > 0 && len(e.Key) != storeKeySize {
+		return fmt.Errorf("%w: key must be %d bytes, got %d", ErrInvalidStoreKey, storeKeySize, len(e.Key))
+	}
+	return nil
+}
+
+// storeCipher encrypts and decrypts store files.
+// For passphrases the derived key is cached together with its salt so that
+// scrypt runs once per store rather than on every sync.
+type storeCipher struct {
+	enc  StoreEncryption
+	salt []byte
+	key  []byte
+}
+
+func newStoreCipher(enc StoreEncryption) (*storeCipher, error) {
+	if err := enc.Validate(); err != nil {
+		return nil, err
+	}
+	return &storeCipher{enc: enc}, nil
+}
+
+// isEncryptedStore reports whether data carries the encrypted store header.
+func isEncryptedStore(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(storeEncryptionMagic))
+}
+
+// kdf returns the key derivation identifier written to the header.
+func (c *storeCipher) kdf() byte {
+	if c.enc.Passphrase != "" {
+		return storeKDFScrypt
+	}
+	return storeKDFNone
+}
+
+// keyFor returns the AES key for the given salt, deriving it if needed.
+func (c *storeCipher) keyFor(salt []byte) ([]byte, error) {
+	if c.enc.Passphrase == "" {
+		return c.enc.Key, nil
+	}
+	if c.key != nil && bytes.Equal(c.salt, salt) {
+		return c.key, nil
+	}
+	key, err := scrypt.Key([]byte(c.enc.Passphrase), salt, storeScryptN, storeScryptR, storeScryptP, storeKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive store key: %w", err)
+	}
+	c.salt = append([]byte(nil), salt...)
+	c.key = key
+	return key, nil
+}
+
+// seal encrypts plaintext into the encrypted store format.
+func (c *storeCipher) seal(plaintext []byte) ([]byte, error) {
+	salt := c.salt
+	if salt == nil {
+		salt = make([]byte, storeSaltSize)
+		if c.enc.Passphrase != "" {
+			if _, err := rand.Read(salt); err != nil {
+				return nil, fmt.Errorf("generate salt: %w", err)
+			}
+		}
+	}
+	key, err := c.keyFor(salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newStoreGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, storeHeaderSize)
+	header = append(header, storeEncryptionMagic...)
+	header = append(header, storeEncryptionVersion, c.kdf())
+	header = append(header, salt...)
+	nonce := make([]byte, storeNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	header = append(header, nonce...)
+
+	return gcm.Seal(header, nonce, plaintext, header), nil
+}
+
+// open decrypts data written by seal.
+func (c *storeCipher) open(data []byte) ([]byte, error) {
+	if len(data) < storeHeaderSize {
+		return nil, fmt.Errorf("%w: truncated encrypted store", ErrStoreCorrupted)
+	}
+	header := data[:storeHeaderSize]
+	offset := len(storeEncryptionMagic)
+	if header[offset] != storeEncryptionVersion {
+		return nil, fmt.Errorf("%w: unsupported encryption version %d", ErrStoreCorrupted, header[offset])
+	}
+	if header[offset+1] != c.kdf() {
+		return nil, fmt.Errorf("%w: store was encrypted with a different key type", ErrStoreDecrypt)
+	}
+	salt := header[offset+2 : offset+2+storeSaltSize]
+	nonce := header[offset+2+storeSaltSize:]
+
+	key, err := c.keyFor(salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newStoreGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, nonce, data[storeHeaderSize:], header)
+	if err != nil {
+		return nil, ErrStoreDecrypt
+	}
+	return plaintext, nil
+}
+
+func newStoreGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStoreKey, err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,169 @@
+package popsigner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStoreKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, storeKeySize)
+}
+
+func testEncryptedMetadata() *KeyMetadata {
+	return &KeyMetadata{
+		UID:         "enc-key",
+		Name:        "enc-key",
+		PubKeyBytes: []byte{0x02, 0x0a, 0x0b},
+		PubKeyType:  "secp256k1",
+		Address:     "cosmos1encrypted",
+		BaoKeyPath:  "secp256k1/keys/enc-key",
+		Algorithm:   AlgorithmSecp256k1,
+		CreatedAt:   time.Now().Truncate(time.Millisecond),
+		Source:      SourceGenerated,
+	}
+}
+
+func TestEncryptedBaoStore_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  StoreEncryption
+	}{
+		{"key", StoreEncryption{Key: testStoreKey(0x42)}},
+		{"passphrase", StoreEncryption{Passphrase: "correct horse battery staple"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storePath := filepath.Join(t.TempDir(), "store.json")
+
+			store1, err := NewEncryptedBaoStore(storePath, tt.enc)
+			require.NoError(t, err)
+			meta := testEncryptedMetadata()
+			require.NoError(t, store1.Save(meta))
+			require.NoError(t, store1.Close())
+
+			// Nothing readable ends up on disk.
+			raw, err := os.ReadFile(storePath)
+			require.NoError(t, err)
+			assert.True(t, isEncryptedStore(raw))
+			assert.NotContains(t, string(raw), meta.Address)
+			assert.NotContains(t, string(raw), meta.BaoKeyPath)
+
+			store2, err := NewEncryptedBaoStore(storePath, tt.enc)
+			require.NoError(t, err)
+			loaded, err := store2.Get(meta.UID)
+			require.NoError(t, err)
+			assert.Equal(t, meta.Address, loaded.Address)
+			assert.Equal(t, meta.BaoKeyPath, loaded.BaoKeyPath)
+			assert.Equal(t, meta.PubKeyBytes, loaded.PubKeyBytes)
+		})
+	}
+}
+
+func TestEncryptedBaoStore_WrongKey(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := NewEncryptedBaoStore(storePath, StoreEncryption{Key: testStoreKey(0x01)})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testEncryptedMetadata()))
+
+	_, err = NewEncryptedBaoStore(storePath, StoreEncryption{Key: testStoreKey(0x02)})
+	assert.ErrorIs(t, err, ErrStoreDecrypt)
+
+	_, err = NewEncryptedBaoStore(storePath, StoreEncryption{Passphrase: "not the key"})
+	assert.ErrorIs(t, err, ErrStoreDecrypt)
+}
+
+func TestEncryptedBaoStore_WrongPassphrase(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := NewEncryptedBaoStore(storePath, StoreEncryption{Passphrase: "right"})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testEncryptedMetadata()))
+
+	_, err = NewEncryptedBaoStore(storePath, StoreEncryption{Passphrase: "wrong"})
+	assert.ErrorIs(t, err, ErrStoreDecrypt)
+}
+
+func TestEncryptedBaoStore_NoKeyConfigured(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := NewEncryptedBaoStore(storePath, StoreEncryption{Key: testStoreKey(0x01)})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testEncryptedMetadata()))
+
+	_, err = NewBaoStore(storePath)
+	assert.ErrorIs(t, err, ErrStoreEncrypted)
+}
+
+func TestEncryptedBaoStore_TamperedFile(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+	enc := StoreEncryption{Key: testStoreKey(0x01)}
+
+	store, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testEncryptedMetadata()))
+
+	raw, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	raw[len(raw)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(storePath, raw, 0600))
+
+	_, err = NewEncryptedBaoStore(storePath, enc)
+	assert.ErrorIs(t, err, ErrStoreDecrypt)
+
+	require.NoError(t, os.WriteFile(storePath, raw[:storeHeaderSize-1], 0600))
+	_, err = NewEncryptedBaoStore(storePath, enc)
+	assert.ErrorIs(t, err, ErrStoreCorrupted)
+}
+
+func TestEncryptedBaoStore_LoadsPlaintextStore(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	plain, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	meta := testEncryptedMetadata()
+	require.NoError(t, plain.Save(meta))
+
+	enc := StoreEncryption{Key: testStoreKey(0x07)}
+	store, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	loaded, err := store.Get(meta.UID)
+	require.NoError(t, err)
+	assert.Equal(t, meta.Address, loaded.Address)
+
+	// The file stays plaintext until the next write, which encrypts it.
+	raw, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	assert.False(t, isEncryptedStore(raw))
+
+	second := testEncryptedMetadata()
+	second.UID = "enc-key-2"
+	second.Address = "cosmos1second"
+	require.NoError(t, store.Save(second))
+
+	raw, err = os.ReadFile(storePath)
+	require.NoError(t, err)
+	assert.True(t, isEncryptedStore(raw))
+
+	reopened, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, reopened.Count())
+}
+
+func TestStoreEncryption_Validate(t *testing.T) {
+	assert.NoError(t, StoreEncryption{}.Validate())
+	assert.NoError(t, StoreEncryption{Key: testStoreKey(0x01)}.Validate())
+	assert.NoError(t, StoreEncryption{Passphrase: "secret"}.Validate())
+	assert.ErrorIs(t, StoreEncryption{Key: []byte("short")}.Validate(), ErrInvalidStoreKey)
+	assert.ErrorIs(t, StoreEncryption{Key: testStoreKey(0x01), Passphrase: "secret"}.Validate(), ErrInvalidStoreKey)
+
+	_, err := NewEncryptedBaoStore(filepath.Join(t.TempDir(), "store.json"), StoreEncryption{Key: []byte("short")})
+	assert.ErrorIs(t, err, ErrInvalidStoreKey)
+}
@@ -0,0 +1,54 @@
+package popsigner
+
+import "fmt"
+
+// storeMigrations holds the upgrade steps for the store schema.
+// storeMigrations[v] upgrades a store from version v to v+1, so the slice
+// must have exactly DefaultStoreVersion entries.
+var storeMigrations = []func(*StoreData) error{
+	migrateStoreV0,
+}
+
+// migrateStore upgrades store data to DefaultStoreVersion by running every
+// step between its version and the current one. Versions from the future
+// are rejected by decodeStoreData before migration.
+func migrateStore(old *StoreData) (*StoreData, error) {
+	if old.Version < 0 || old.Version > DefaultStoreVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrStoreCorrupted, old.Version)
+	}
+
+	for v := old.Version; v < DefaultStoreVersion; v++ {
+		if err := storeMigrations[v](old); err != nil {
+			return nil, fmt.Errorf("%w: migrate from version %d: %v", ErrStoreCorrupted, v, err)
+		}
+		old.Version = v + 1
+	}
+	return old, nil
+}
+
+// migrateStoreV0 upgrades unversioned stores, which predate the Name,
+// PubKeyType, Algorithm and Source fields being written.
+func migrateStoreV0(data *StoreData) error {
+	for uid, meta := range data.Keys {
+		if meta == nil {
+			delete(data.Keys, uid)
+			continue
+		}
+		if meta.UID == "" {
+			meta.UID = uid
+		}
+		if meta.Name == "" {
+			meta.Name = meta.UID
+		}
+		if meta.PubKeyType == "" {
+			meta.PubKeyType = AlgorithmSecp256k1
+		}
+		if meta.Algorithm == "" {
+			meta.Algorithm = AlgorithmSecp256k1
+		}
+		if meta.Source == "" {
+			meta.Source = SourceGenerated
+		}
+	}
+	return nil
+}
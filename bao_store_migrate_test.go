@@ -0,0 +1,98 @@
+package popsigner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMigrations_CoverEveryVersion(t *testing.T) {
+	assert.Len(t, storeMigrations, DefaultStoreVersion)
+}
+
+func TestNewBaoStore_MigratesV0Store(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	// A v0 store: no version field and keys without the newer fields.
+	v0 := `{
+  "keys": {
+    "legacy-key": {
+      "pub_key": "AgECAw==",
+      "address": "cosmos1legacy",
+      "bao_key_path": "secp256k1/keys/legacy-key"
+    }
+  }
+}`
+	require.NoError(t, os.WriteFile(storePath, []byte(v0), 0600))
+
+	store, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	assert.False(t, store.dirty)
+
+	meta, err := store.Get("legacy-key")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-key", meta.UID)
+	assert.Equal(t, "legacy-key", meta.Name)
+	assert.Equal(t, AlgorithmSecp256k1, meta.Algorithm)
+	assert.Equal(t, "secp256k1", meta.PubKeyType)
+	assert.Equal(t, SourceGenerated, meta.Source)
+	assert.Equal(t, "cosmos1legacy", meta.Address)
+	assert.Equal(t, []byte{0x02, 0x01, 0x02, 0x03}, meta.PubKeyBytes)
+
+	// The upgraded store was written back.
+	raw, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	var onDisk StoreData
+	require.NoError(t, json.Unmarshal(raw, &onDisk))
+	assert.Equal(t, DefaultStoreVersion, onDisk.Version)
+	assert.Equal(t, "legacy-key", onDisk.Keys["legacy-key"].Name)
+
+	info, err := os.Stat(storePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestNewBaoStore_MigratesEncryptedV0Store(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+	require.NoError(t, os.WriteFile(storePath, []byte(`{"keys":{"k":{"address":"cosmos1k"}}}`), 0600))
+
+	enc := StoreEncryption{Key: testStoreKey(0x03)}
+	store, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStoreVersion, store.data.Version)
+
+	// The migrated store is written back encrypted.
+	raw, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	assert.True(t, isEncryptedStore(raw))
+}
+
+func TestMigrateStore_DropsNilEntries(t *testing.T) {
+	data := &StoreData{Keys: map[string]*KeyMetadata{"nil-key": nil, "k": {Address: "cosmos1k"}}}
+
+	migrated, err := migrateStore(data)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStoreVersion, migrated.Version)
+	assert.Len(t, migrated.Keys, 1)
+}
+
+func TestMigrateStore_RejectsFutureVersion(t *testing.T) {
+	_, err := migrateStore(&StoreData{Version: DefaultStoreVersion + 1})
+	assert.ErrorIs(t, err, ErrStoreCorrupted)
+}
+
+func TestMigrateStore_CurrentVersionUnchanged(t *testing.T) {
+	data := &StoreData{
+		Version: DefaultStoreVersion,
+		Keys:    map[string]*KeyMetadata{"k": {UID: "k", Address: "cosmos1k"}},
+	}
+
+	migrated, err := migrateStore(data)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStoreVersion, migrated.Version)
+	assert.Empty(t, migrated.Keys["k"].Name)
+}
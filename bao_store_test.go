@@ -1,9 +1,11 @@
 package popsigner
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -398,11 +400,11 @@ func TestBaoStore_Load_MissingVersionField(t *testing.T) {
 	err := os.WriteFile(storePath, []byte(`{"keys":{}}`), 0600)
 	require.NoError(t, err)
 
-	// Should load successfully (version 0 <= DefaultStoreVersion)
+	// Should load successfully and be migrated to the current version
 	store, err := NewBaoStore(storePath)
 	require.NoError(t, err)
 	assert.NotNil(t, store)
-	assert.Equal(t, 0, store.data.Version)
+	assert.Equal(t, DefaultStoreVersion, store.data.Version)
 }
 
 func TestBaoStore_MultipleCloseIsSafe(t *testing.T) {
@@ -1187,3 +1189,256 @@ func TestNewStoreForTesting(t *testing.T) {
 	assert.Equal(t, DefaultStoreVersion, store.data.Version)
 	assert.False(t, store.dirty)
 }
+
+func TestBaoStore_BackupRestore(t *testing.T) {
+	tmpDir := t.TempDir()
+	storePath := filepath.Join(tmpDir, "store.json")
+
+	store, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	for i, uid := range []string{"sequencer", "worker-1", "worker-2"} {
+		require.NoError(t, store.Save(&KeyMetadata{
+			UID:         uid,
+			Name:        uid,
+			PubKeyBytes: []byte{0x02, byte(i)},
+			PubKeyType:  "secp256k1",
+			Address:     "cosmos1" + uid,
+			BaoKeyPath:  "secp256k1/keys/" + uid,
+			Algorithm:   AlgorithmSecp256k1,
+			Exportable:  i == 0,
+			CreatedAt:   time.Now().Truncate(time.Millisecond),
+			Source:      SourceGenerated,
+		}))
+	}
+	original, err := store.List()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Backup(&buf))
+
+	// Wipe the store
+	require.NoError(t, os.Remove(storePath))
+
+	require.NoError(t, RestoreStore(&buf, storePath))
+
+	info, err := os.Stat(storePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	_, err = os.Stat(storePath + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	restored, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	assert.Equal(t, len(original), restored.Count())
+	for _, meta := range original {
+		got, err := restored.Get(meta.UID)
+		require.NoError(t, err)
+		assert.Equal(t, meta.Address, got.Address)
+		assert.Equal(t, meta.PubKeyBytes, got.PubKeyBytes)
+		assert.Equal(t, meta.BaoKeyPath, got.BaoKeyPath)
+		assert.Equal(t, meta.Exportable, got.Exportable)
+		assert.True(t, meta.CreatedAt.Equal(got.CreatedAt))
+	}
+}
+
+func TestBaoStore_BackupEncryptedStoreIsPlaintext(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	store, err := NewEncryptedBaoStore(storePath, StoreEncryption{Passphrase: "secret"})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&KeyMetadata{UID: "key1", Name: "key1", Address: "cosmos1key"}))
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Backup(&buf))
+	assert.Contains(t, buf.String(), "cosmos1key")
+}
+
+func TestRestoreStore_RejectsCorruptBackup(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	err := RestoreStore(strings.NewReader("not valid json{{{"), storePath)
+	assert.ErrorIs(t, err, ErrStoreCorrupted)
+
+	_, err = os.Stat(storePath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRestoreStore_RejectsNewerVersion(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+
+	// Existing store must be left untouched
+	store, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&KeyMetadata{UID: "keep", Name: "keep", Address: "cosmos1keep"}))
+
+	data, err := json.Marshal(StoreData{
+		Version: DefaultStoreVersion + 1,
+		Keys:    map[string]*KeyMetadata{},
+	})
+	require.NoError(t, err)
+
+	err = RestoreStore(bytes.NewReader(data), storePath)
+	assert.ErrorIs(t, err, ErrStoreCorrupted)
+
+	reopened, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	assert.True(t, reopened.Has("keep"))
+}
+
+func TestRestoreStore_RefusesEncryptedStore(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+	enc := StoreEncryption{Passphrase: "secret"}
+
+	store, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(&KeyMetadata{UID: "keep", Name: "keep", Address: "cosmos1keep"}))
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Backup(&buf))
+
+	err = RestoreStore(bytes.NewReader(buf.Bytes()), storePath)
+	assert.ErrorIs(t, err, ErrStoreEncrypted)
+
+	// Restoring with the key keeps the store encrypted
+	require.NoError(t, RestoreEncryptedStore(&buf, storePath, enc))
+
+	data, err := os.ReadFile(storePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "cosmos1keep")
+
+	_, err = NewBaoStore(storePath)
+	assert.ErrorIs(t, err, ErrStoreEncrypted)
+
+	reopened, err := NewEncryptedBaoStore(storePath, enc)
+	require.NoError(t, err)
+	assert.True(t, reopened.Has("keep"))
+}
+
+func TestSave_LabelsRoundTrip(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "store.json")
+	store, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+
+	labels := map[string]string{"environment": "prod", "team": "rollup"}
+	require.NoError(t, store.Save(&KeyMetadata{UID: "key1", Name: "key1", Address: "addr1", Labels: labels}))
+
+	reopened, err := NewBaoStore(storePath)
+	require.NoError(t, err)
+	got, err := reopened.Get("key1")
+	require.NoError(t, err)
+	assert.Equal(t, labels, got.Labels)
+}
+
+func TestListByLabel(t *testing.T) {
+	store := newStoreForTesting()
+	store.data.Keys["key1"] = &KeyMetadata{UID: "key1", Address: "addr1", Labels: map[string]string{"environment": "prod", "role": "sequencer"}}
+	store.data.Keys["key2"] = &KeyMetadata{UID: "key2", Address: "addr2", Labels: map[string]string{"environment": "prod", "role": "batcher"}}
+	store.data.Keys["key3"] = &KeyMetadata{UID: "key3", Address: "addr3", Labels: map[string]string{"environment": "staging", "role": "sequencer"}}
+	store.data.Keys["key4"] = &KeyMetadata{UID: "key4", Address: "addr4"}
+
+	prod, err := store.ListByLabel(map[string]string{"environment": "prod"})
+	require.NoError(t, err)
+	assert.Len(t, prod, 2)
+
+	match, err := store.ListByLabel(map[string]string{"environment": "prod", "role": "sequencer"})
+	require.NoError(t, err)
+	require.Len(t, match, 1)
+	assert.Equal(t, "key1", match[0].UID)
+
+	none, err := store.ListByLabel(map[string]string{"environment": "dev"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	all, err := store.ListByLabel(nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+}
+
+func TestCopyMetadata_DeepCopiesLabels(t *testing.T) {
+	meta := &KeyMetadata{UID: "key1", Labels: map[string]string{"environment": "prod"}}
+
+	copied := copyMetadata(meta)
+	meta.Labels["environment"] = "staging"
+
+	assert.Equal(t, "prod", copied.Labels["environment"])
+	assert.Nil(t, copyMetadata(&KeyMetadata{UID: "key2"}).Labels)
+}
+
+// readStoredKey reads a key's metadata from the store file on disk.
+func readStoredKey(t *testing.T, path, uid string) *KeyMetadata {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var storeData StoreData
+	require.NoError(t, json.Unmarshal(data, &storeData))
+	return storeData.Keys[uid]
+}
+
+// TestMarkUsed_ThrottlesWrites tests that rapid use is written to disk once
+// per flush interval and that Close writes the final value
+func TestMarkUsed_ThrottlesWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := NewBaoStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testMetadata("key1", "addr1")))
+
+	start := time.Now()
+	require.NoError(t, store.MarkUsed("key1", start))
+	require.True(t, readStoredKey(t, path, "key1").LastUsedAt.Equal(start))
+
+	last := start
+	for i := 1; i <= 100; i++ {
+		last = start.Add(time.Duration(i) * 100 * time.Millisecond)
+		require.NoError(t, store.MarkUsed("key1", last))
+	}
+
+	// Only the first use reached the disk
+	assert.True(t, readStoredKey(t, path, "key1").LastUsedAt.Equal(start))
+
+	meta, err := store.Get("key1")
+	require.NoError(t, err)
+	assert.True(t, meta.LastUsedAt.Equal(last))
+
+	require.NoError(t, store.Close())
+	assert.True(t, readStoredKey(t, path, "key1").LastUsedAt.Equal(last))
+}
+
+// TestMarkUsed_WritesAfterInterval tests that use is written again once the
+// flush interval has passed
+func TestMarkUsed_WritesAfterInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	store, err := NewBaoStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testMetadata("key1", "addr1")))
+
+	start := time.Now()
+	require.NoError(t, store.MarkUsed("key1", start))
+
+	later := start.Add(DefaultUsedFlushInterval)
+	require.NoError(t, store.MarkUsed("key1", later))
+	assert.True(t, readStoredKey(t, path, "key1").LastUsedAt.Equal(later))
+}
+
+// TestMarkUsed_IgnoresOlderTimes tests that LastUsedAt never moves backwards
+func TestMarkUsed_IgnoresOlderTimes(t *testing.T) {
+	store, err := NewBaoStore(filepath.Join(t.TempDir(), "store.json"))
+	require.NoError(t, err)
+	require.NoError(t, store.Save(testMetadata("key1", "addr1")))
+
+	now := time.Now()
+	require.NoError(t, store.MarkUsed("key1", now))
+	require.NoError(t, store.MarkUsed("key1", now.Add(-time.Hour)))
+
+	meta, err := store.Get("key1")
+	require.NoError(t, err)
+	assert.True(t, meta.LastUsedAt.Equal(now))
+}
+
+// TestMarkUsed_NotFound tests marking a missing key returns ErrKeyNotFound
+func TestMarkUsed_NotFound(t *testing.T) {
+	store, err := NewBaoStore(filepath.Join(t.TempDir(), "store.json"))
+	require.NoError(t, err)
+
+	err = store.MarkUsed("missing", time.Now())
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
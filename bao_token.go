@@ -0,0 +1,143 @@
+package popsigner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRenewFraction is how much of a login token's lease may elapse before
+// the source logs in again.
+const tokenRenewFraction = 0.8
+
+// tokenSource supplies the OpenBao token: a fixed token, one read from a file
+// that is re-read whenever the file changes, so tokens renewed by a Vault
+// agent or a projected Kubernetes secret are picked up without a restart, or
+// one obtained from an auth method login that is repeated before the token's
+// lease runs out.
+type tokenSource struct {
+	path  string
+	login func(ctx context.Context) (token string, ttl time.Duration, err error)
+	now   func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	modTime   time.Time
+	size      int64
+	renewAt   time.Time
+	expiresAt time.Time
+}
+
+// newLoginTokenSource returns a source that gets its token from login. The
+// first login happens on the first request.
+func newLoginTokenSource(login func(ctx context.Context) (string, time.Duration, error)) *tokenSource {
+	return &tokenSource{login: login, now: time.Now}
+}
+
+// newTokenSource returns a source for the token file at path, or for the
+// fixed token if path is empty. The file is read immediately.
+func newTokenSource(token, path string) (*tokenSource, error) {
+	s := &tokenSource{path: path, token: token}
+	if path == "" {
+		return s, nil
+	}
+	if _, err := s.Token(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Token returns the current token. If the token file can't be re-read, the
+// last token read from it is returned.
+func (s *tokenSource) Token(ctx context.Context) (string, error) {
+	if s.login != nil {
+		return s.loginToken(ctx)
+	}
+	if s.path == "" {
+		return s.token, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// os.Stat follows symlinks, so atomic symlink swaps by the kubelet count
+	// as changes too
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return s.fallback(fmt.Errorf("popsigner: read BaoTokenFile: %w", err))
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.token, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return s.fallback(fmt.Errorf("popsigner: read BaoTokenFile: %w", err))
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		// A writer may have truncated the file before rewriting it
+		return s.fallback(fmt.Errorf("popsigner: BaoTokenFile %s is empty", s.path))
+	}
+
+	s.token = token
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return s.token, nil
+}
+
+// loginToken returns the token from the last login, logging in again when
+// there is none or most of its lease has elapsed. A failed re-login keeps
+// the current token while its lease lasts.
+func (s *tokenSource) loginToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Before(s.renewAt) {
+		return s.token, nil
+	}
+
+	token, ttl, err := s.login(ctx)
+	if err != nil {
+		if s.token != "" && now.Before(s.expiresAt) {
+			return s.token, nil
+		}
+		return "", err
+	}
+	s.token = token
+	if ttl > 0 {
+		s.renewAt = now.Add(time.Duration(float64(ttl) * tokenRenewFraction))
+		s.expiresAt = now.Add(ttl)
+	} else {
+		// Token without a lease
+		s.renewAt = time.Time{}.AddDate(9999, 0, 0)
+		s.expiresAt = s.renewAt
+	}
+	return s.token, nil
+}
+
+// invalidate drops a login token OpenBao has rejected, so the next request
+// logs in again. Fixed and file tokens are left alone.
+func (s *tokenSource) invalidate(token string) {
+	if s.login == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// fallback returns the last token read, or err if there is none.
+func (s *tokenSource) fallback(err error) (string, error) {
+	if s.token != "" {
+		return s.token, nil
+	}
+	return "", errors.Join(ErrMissingBaoToken, err)
+}
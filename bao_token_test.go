@@ -0,0 +1,76 @@
+package popsigner
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeToken writes a token file and moves its modification time forward,
+// so consecutive writes are seen as changes even on coarse-grained clocks.
+func writeToken(t *testing.T, path, token string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestBaoClient_TokenFile(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("X-Vault-Token"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":{"keys":[]}}`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	start := time.Now().Add(-time.Hour)
+	writeToken(t, tokenFile, "hvs.first", start)
+
+	client, err := NewBaoClient(Config{
+		BaoAddr:      server.URL,
+		BaoToken:     "hvs.literal",
+		BaoTokenFile: tokenFile,
+	})
+	require.NoError(t, err)
+
+	_, err = client.ListKeys(context.Background())
+	require.NoError(t, err)
+
+	// A renewed token is used from the next request on
+	writeToken(t, tokenFile, "hvs.second", start.Add(time.Minute))
+	_, err = client.ListKeys(context.Background())
+	require.NoError(t, err)
+
+	// A file being rewritten keeps the last good token
+	writeToken(t, tokenFile, "", start.Add(2*time.Minute))
+	_, err = client.ListKeys(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"hvs.first", "hvs.second", "hvs.second"}, seen)
+}
+
+func TestNewBaoClient_TokenFileMissing(t *testing.T) {
+	_, err := NewBaoClient(Config{
+		BaoAddr:      "https://localhost:8200",
+		BaoTokenFile: filepath.Join(t.TempDir(), "missing"),
+	})
+	assert.ErrorIs(t, err, ErrMissingBaoToken)
+}
+
+func TestConfig_ValidateTokenFile(t *testing.T) {
+	cfg := Config{BaoAddr: "https://localhost:8200", BaoTokenFile: "/var/run/secrets/bao/token", StorePath: "/tmp/test"}
+	assert.NoError(t, cfg.Validate())
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"time"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/opstack"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/popdeployer"
 	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/state"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
@@ -49,11 +51,13 @@ const (
 	gasLimit  = 30000000
 
 	// Timeouts
-	httpReadyTimeout     = 30 * time.Second
-	deploymentTimeout    = 30 * time.Minute
-	anvilShutdownTimeout = 5 * time.Second
-	httpClientTimeout    = 2 * time.Second
-	httpPollInterval     = 1 * time.Second
+	httpReadyTimeout  = 30 * time.Second
+	httpClientTimeout = 2 * time.Second
+	httpPollInterval  = 1 * time.Second
+
+	// Defaults for the -deployment-timeout and -anvil-shutdown-timeout flags
+	defaultDeploymentTimeout    = 30 * time.Minute
+	defaultAnvilShutdownTimeout = 5 * time.Second
 )
 
 // StackType represents the type of rollup stack to deploy.
@@ -72,15 +76,35 @@ type bundleBuilder struct {
 	bundleDir string
 	stackType StackType
 
+	// deploymentTimeout bounds the whole pipeline; anvilShutdownTimeout is
+	// how long to wait for Anvil to dump its state before killing it.
+	deploymentTimeout    time.Duration
+	anvilShutdownTimeout time.Duration
+
+	// celestiaKeyID is the popsigner-lite key used for Celestia blobs. When
+	// empty it is looked up by celestiaAddress once popsigner-lite is up.
+	celestiaKeyID   string
+	celestiaAddress string
+
 	// Managed processes
 	anvilCmd     *exec.Cmd
 	popSignerCmd *exec.Cmd
 }
 
+// options holds the command-line flags.
+type options struct {
+	bundleDir            string
+	stackType            StackType
+	deploymentTimeout    time.Duration
+	anvilShutdownTimeout time.Duration
+	celestiaKeyID        string
+	celestiaAddress      string
+}
+
 func main() {
-	bundleDir, stackType := parseFlags()
+	opts := parseFlags()
 
-	builder := newBundleBuilder(bundleDir, stackType)
+	builder := newBundleBuilder(opts)
 	defer builder.cleanup()
 	builder.setupSignalHandler()
 
@@ -90,32 +114,67 @@ func main() {
 	}
 }
 
-func parseFlags() (string, StackType) {
+func parseFlags() options {
 	bundleDirFlag := flag.String("bundle-dir", filepath.Join(os.TempDir(), "pop-deployer-bundle"),
 		"Directory to write bundle files (default: /tmp/pop-deployer-bundle)")
 	stackFlag := flag.String("stack", "opstack", "Stack type: opstack or nitro")
+	deploymentTimeoutFlag := flag.Duration("deployment-timeout", defaultDeploymentTimeout,
+		"Maximum time for the whole bundle creation")
+	anvilShutdownTimeoutFlag := flag.Duration("anvil-shutdown-timeout", defaultAnvilShutdownTimeout,
+		"How long to wait for Anvil to dump its state before killing it")
+	celestiaKeyIDFlag := flag.String("celestia-key-id", "",
+		"Celestia signing key ID in popsigner-lite (default: looked up by -celestia-address)")
+	celestiaAddressFlag := flag.String("celestia-address", celestiaAddress,
+		"Address of the Celestia signing key, used to look up its key ID")
 	flag.Parse()
 
 	stackType := StackType(*stackFlag)
 	if stackType != StackOPStack && stackType != StackNitro {
 		log.Fatalf("unknown stack type: %s (valid: opstack, nitro)", *stackFlag)
 	}
+	if *deploymentTimeoutFlag <= 0 {
+		log.Fatalf("invalid -deployment-timeout: %s (must be positive)", *deploymentTimeoutFlag)
+	}
+	if *anvilShutdownTimeoutFlag <= 0 {
+		log.Fatalf("invalid -anvil-shutdown-timeout: %s (must be positive)", *anvilShutdownTimeoutFlag)
+	}
 
-	return *bundleDirFlag, stackType
+	return options{
+		bundleDir:            *bundleDirFlag,
+		stackType:            stackType,
+		deploymentTimeout:    *deploymentTimeoutFlag,
+		anvilShutdownTimeout: *anvilShutdownTimeoutFlag,
+		celestiaKeyID:        *celestiaKeyIDFlag,
+		celestiaAddress:      *celestiaAddressFlag,
+	}
 }
 
-func newBundleBuilder(bundleDir string, stackType StackType) *bundleBuilder {
+func newBundleBuilder(opts options) *bundleBuilder {
+	if opts.deploymentTimeout <= 0 {
+		opts.deploymentTimeout = defaultDeploymentTimeout
+	}
+	if opts.anvilShutdownTimeout <= 0 {
+		opts.anvilShutdownTimeout = defaultAnvilShutdownTimeout
+	}
+	if opts.celestiaAddress == "" {
+		opts.celestiaAddress = celestiaAddress
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
 
 	return &bundleBuilder{
-		ctx:       ctx,
-		cancel:    cancel,
-		logger:    logger,
-		bundleDir: bundleDir,
-		stackType: stackType,
+		ctx:                  ctx,
+		cancel:               cancel,
+		logger:               logger,
+		bundleDir:            opts.bundleDir,
+		stackType:            opts.stackType,
+		deploymentTimeout:    opts.deploymentTimeout,
+		anvilShutdownTimeout: opts.anvilShutdownTimeout,
+		celestiaKeyID:        opts.celestiaKeyID,
+		celestiaAddress:      opts.celestiaAddress,
 	}
 }
 
@@ -141,16 +200,28 @@ func (b *bundleBuilder) setupSignalHandler() {
 	}()
 }
 
-// run dispatches to the appropriate stack-specific pipeline.
+// run dispatches to the appropriate stack-specific pipeline, bounded by the
+// deployment timeout. Anvil and popsigner-lite run under the same context,
+// so they are killed when the timeout expires.
 func (b *bundleBuilder) run() error {
+	ctx, cancel := context.WithTimeout(b.ctx, b.deploymentTimeout)
+	defer cancel()
+	b.ctx = ctx
+
+	var err error
 	switch b.stackType {
 	case StackOPStack:
-		return b.runOPStack()
+		err = b.runOPStack()
 	case StackNitro:
-		return b.runNitro()
+		err = b.runNitro()
 	default:
 		return fmt.Errorf("unknown stack type: %s", b.stackType)
 	}
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", b.deploymentTimeout, err)
+	}
+	return err
 }
 
 // runOPStack executes the 7-step OP Stack bundle creation pipeline.
@@ -313,10 +384,7 @@ func (b *bundleBuilder) deployOPStack() (*opstack.DeployResult, *opstack.Deploym
 		)
 	}
 
-	deployCtx, deployCancel := context.WithTimeout(b.ctx, deploymentTimeout)
-	defer deployCancel()
-
-	result, err := deployer.Deploy(deployCtx, cfg, adapter, progressCallback)
+	result, err := deployer.Deploy(b.ctx, cfg, adapter, progressCallback)
 	if err != nil {
 		return nil, nil, fmt.Errorf("deploy: %w", err)
 	}
@@ -375,50 +443,27 @@ func (b *bundleBuilder) populateStartBlock(result *opstack.DeployResult) error {
 func (b *bundleBuilder) shutdownAnvilAndDumpState(stateFile string) error {
 	b.logger.Info("4️⃣  Shutting down Anvil to dump state...")
 
-	if err := b.anvilCmd.Process.Signal(syscall.SIGTERM); err != nil {
-		return fmt.Errorf("send SIGTERM: %w", err)
-	}
-
-	// Wait for Anvil to finish with timeout
-	anvilDone := make(chan error, 1)
-	go func() {
-		anvilDone <- b.anvilCmd.Wait()
-	}()
-
-	select {
-	case err := <-anvilDone:
-		if err != nil && err.Error() != "signal: terminated" {
-			b.logger.Warn("Anvil exited with error", slog.String("error", err.Error()))
-		}
-	case <-time.After(anvilShutdownTimeout):
-		b.logger.Warn("Anvil shutdown timeout, forcing kill")
-		b.anvilCmd.Process.Kill()
-	}
-
-	// Verify state file was created
-	info, err := os.Stat(stateFile)
-	if err != nil {
-		return fmt.Errorf("state file not created: %w", err)
-	}
-
-	b.logger.Info("Anvil state dumped successfully",
-		slog.String("file", stateFile),
-		slog.Int64("size_kb", info.Size()/1024),
-	)
-	return nil
+	return popdeployer.StopAnvil(b.anvilCmd, stateFile, b.anvilShutdownTimeout, b.logger)
 }
 
 func (b *bundleBuilder) getCelestiaKeyID() (string, error) {
+	if b.celestiaKeyID != "" {
+		b.logger.Info("5️⃣  Using configured Celestia key",
+			slog.String("key_id", b.celestiaKeyID),
+		)
+		return b.celestiaKeyID, nil
+	}
+
 	b.logger.Info("5️⃣  Getting Celestia key from popsigner-lite...")
 
-	keyID, err := getKeyID(b.ctx, popSignerRestURL, celestiaAddress)
+	keyID, err := getKeyID(b.ctx, popSignerRestURL, b.celestiaAddress)
 	if err != nil {
 		return "", err
 	}
 
 	b.logger.Info("Celestia key found",
 		slog.String("key_id", keyID),
-		slog.String("address", celestiaAddress),
+		slog.String("address", b.celestiaAddress),
 	)
 	return keyID, nil
 }
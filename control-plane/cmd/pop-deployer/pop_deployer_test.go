@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -351,7 +352,7 @@ func TestNewBundleBuilder(t *testing.T) {
 	bundleDir := "/tmp/test-bundle"
 	stackType := StackNitro
 
-	builder := newBundleBuilder(bundleDir, stackType)
+	builder := newBundleBuilder(options{bundleDir: bundleDir, stackType: stackType})
 
 	if builder.bundleDir != bundleDir {
 		t.Errorf("expected bundleDir %q, got %q", bundleDir, builder.bundleDir)
@@ -368,6 +369,12 @@ func TestNewBundleBuilder(t *testing.T) {
 	if builder.logger == nil {
 		t.Error("expected logger to be set")
 	}
+	if builder.deploymentTimeout != defaultDeploymentTimeout {
+		t.Errorf("expected deploymentTimeout %s, got %s", defaultDeploymentTimeout, builder.deploymentTimeout)
+	}
+	if builder.anvilShutdownTimeout != defaultAnvilShutdownTimeout {
+		t.Errorf("expected anvilShutdownTimeout %s, got %s", defaultAnvilShutdownTimeout, builder.anvilShutdownTimeout)
+	}
 }
 
 func TestBundleBuilder_PrepareBundleDirectory(t *testing.T) {
@@ -383,7 +390,7 @@ func TestBundleBuilder_PrepareBundleDirectory(t *testing.T) {
 	os.MkdirAll(bundleDir, 0755)
 	os.WriteFile(filepath.Join(bundleDir, "old-file.txt"), []byte("old content"), 0644)
 
-	builder := newBundleBuilder(bundleDir, StackOPStack)
+	builder := newBundleBuilder(options{bundleDir: bundleDir, stackType: StackOPStack})
 
 	err = builder.prepareBundleDirectory()
 	if err != nil {
@@ -406,7 +413,7 @@ func TestBundleBuilder_PrepareBundleDirectory(t *testing.T) {
 }
 
 func TestBundleBuilder_Run_UnknownStack(t *testing.T) {
-	builder := newBundleBuilder("/tmp/test", StackType("unknown"))
+	builder := newBundleBuilder(options{bundleDir: "/tmp/test", stackType: StackType("unknown")})
 
 	err := builder.run()
 
@@ -415,6 +422,46 @@ func TestBundleBuilder_Run_UnknownStack(t *testing.T) {
 	}
 }
 
+func TestBundleBuilder_GetCelestiaKeyID_Configured(t *testing.T) {
+	builder := newBundleBuilder(options{bundleDir: "/tmp/test", stackType: StackOPStack, celestiaKeyID: "celestia-prod"})
+
+	keyID, err := builder.getCelestiaKeyID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keyID != "celestia-prod" {
+		t.Errorf("expected configured key ID, got %s", keyID)
+	}
+}
+
+func TestNewBundleBuilder_DefaultCelestiaAddress(t *testing.T) {
+	builder := newBundleBuilder(options{bundleDir: "/tmp/test", stackType: StackOPStack})
+
+	if builder.celestiaAddress != celestiaAddress {
+		t.Errorf("expected default Celestia address %s, got %s", celestiaAddress, builder.celestiaAddress)
+	}
+}
+
+func TestBundleBuilder_Run_Timeout(t *testing.T) {
+	builder := newBundleBuilder(options{
+		bundleDir:         filepath.Join(t.TempDir(), "bundle"),
+		stackType:         StackOPStack,
+		deploymentTimeout: time.Nanosecond,
+	})
+
+	done := make(chan error, 1)
+	go func() { done <- builder.run() }()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "timed out after 1ns") {
+			t.Errorf("expected timeout error, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("run did not return after the deployment timeout")
+	}
+}
+
 // =============================================================================
 // waitForReceipt tests
 // =============================================================================
@@ -18,12 +18,21 @@ func SetupRouter(ks *keystore.Keystore) *gin.Engine {
 	// Add CORS middleware
 	router.Use(corsMiddleware())
 
-	// Health check endpoint (root)
+	// Health check endpoint (root). Returns 503 until the keys are loaded,
+	// so clients waiting for popsigner-lite don't sign against an empty keystore.
 	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, HealthResponse{
+		resp := HealthResponse{
 			Status:  "ok",
 			Version: "1.0.0",
-		})
+			Keys:    ks.KeyCount(),
+			Ready:   ks.Ready(),
+		}
+		status := http.StatusOK
+		if !resp.Ready {
+			resp.Status = "starting"
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, resp)
 	})
 
 	// API v1 routes
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Bidon15/popsigner/control-plane/cmd/popsigner-lite/internal/keystore"
+)
+
+func getHealth(t *testing.T, router *gin.Engine) (int, HealthResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var resp HealthResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode health response: %v", err)
+	}
+	return w.Code, resp
+}
+
+func TestHealth_ReportsReadiness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ks := keystore.NewKeystore()
+	router := SetupRouter(ks)
+
+	// Before the keys are loaded
+	code, resp := getHealth(t, router)
+	if code != http.StatusServiceUnavailable || resp.Status == "ok" || resp.Ready {
+		t.Errorf("before keys: got %d %+v, want 503 and not ready", code, resp)
+	}
+
+	keys, err := keystore.LoadAnvilKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if err := ks.AddKey(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ks.MarkReady()
+
+	code, resp = getHealth(t, router)
+	if code != http.StatusOK || resp.Status != "ok" || !resp.Ready {
+		t.Errorf("after keys: got %d %+v, want 200 ok and ready", code, resp)
+	}
+	if resp.Keys != len(keystore.AnvilPrivateKeys) {
+		t.Errorf("keys = %d, want %d", resp.Keys, len(keystore.AnvilPrivateKeys))
+	}
+}
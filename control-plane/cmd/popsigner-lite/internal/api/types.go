@@ -59,6 +59,8 @@ type ErrorResponse struct {
 
 // HealthResponse represents a health check response.
 type HealthResponse struct {
-	Status  string `json:"status"`
+	Status  string `json:"status"` // "ok" once keys are loaded, "starting" before
 	Version string `json:"version"`
+	Keys    int    `json:"keys"`  // Number of keys available for signing
+	Ready   bool   `json:"ready"` // Whether the initial keys are loaded
 }
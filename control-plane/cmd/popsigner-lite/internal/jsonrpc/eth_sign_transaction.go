@@ -62,7 +62,17 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 	if txArgs.From == nil {
 		return nil, ErrInvalidParams("from address is required")
 	}
-	if txArgs.ChainID == nil {
+
+	// Use the configured chain ID, if any; requests may omit it but not
+	// name another chain
+	chainID := h.signer.ChainID()
+	if txArgs.ChainID != nil {
+		if chainID != nil && txArgs.ChainID.ToInt().Cmp(chainID) != 0 {
+			return nil, ErrInvalidParams(fmt.Sprintf("chainId %s does not match the configured chain ID %s", txArgs.ChainID.ToInt(), chainID))
+		}
+		chainID = txArgs.ChainID.ToInt()
+	}
+	if chainID == nil {
 		return nil, ErrInvalidParams("chainId is required")
 	}
 
@@ -111,8 +121,6 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 		gasLimit = uint64(*txArgs.Gas)
 	}
 
-	chainID := txArgs.ChainID.ToInt()
-
 	// Determine transaction type and build transaction
 	var tx *types.Transaction
 	if txArgs.MaxFeePerGas != nil {
@@ -0,0 +1,61 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Bidon15/popsigner/control-plane/cmd/popsigner-lite/internal/keystore"
+	"github.com/Bidon15/popsigner/control-plane/cmd/popsigner-lite/internal/signer"
+)
+
+func TestEthSignTransaction_ConfiguredChainID(t *testing.T) {
+	ks := keystore.NewKeystore()
+	keys, err := keystore.LoadAnvilKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if err := ks.AddKey(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	txSigner, err := signer.NewTransactionSignerWithConfig(signer.TransactionSignerConfig{ChainID: big.NewInt(901)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewEthSignTransactionHandler(ks, txSigner)
+	from := common.HexToAddress(keys[0].Address)
+
+	// chainId omitted: signed for the configured chain
+	result, rpcErr := h.Handle(context.Background(), json.RawMessage(`[{
+		"from": "`+keys[0].Address+`",
+		"to": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"gasPrice": "0x3b9aca00",
+		"nonce": "0x0"
+	}]`))
+	if rpcErr != nil {
+		t.Fatalf("Handle() error = %v", rpcErr.Message)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(hexutil.MustDecode(result.(string))); err != nil {
+		t.Fatalf("decode signed tx: %v", err)
+	}
+	if tx.ChainId().Int64() != 901 {
+		t.Errorf("chain ID = %s, want 901", tx.ChainId())
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(901)), tx); err != nil || sender != from {
+		t.Errorf("sender = %s, %v; want %s", sender.Hex(), err, from.Hex())
+	}
+
+	// Another chainId is rejected
+	_, rpcErr = h.Handle(context.Background(), json.RawMessage(`[{"from": "`+keys[0].Address+`", "chainId": "0x7a69"}]`))
+	if rpcErr == nil || rpcErr.Code != ErrCodeInvalidParams {
+		t.Errorf("expected invalid params for a mismatched chainId, got %+v", rpcErr)
+	}
+}
@@ -3,6 +3,7 @@ package keystore
 import (
 	"crypto/ecdsa"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -29,24 +30,34 @@ func LoadAnvilKeys() ([]*Key, error) {
 	keys := make([]*Key, len(AnvilPrivateKeys))
 
 	for i, hexKey := range AnvilPrivateKeys {
-		// Remove 0x prefix
-		privateKey, err := crypto.HexToECDSA(hexKey[2:])
+		id := fmt.Sprintf("anvil-%d", i)
+		key, err := newKey(id, id, hexKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse private key %d: %w", i, err)
 		}
+		keys[i] = key
+	}
 
-		publicKey := privateKey.Public().(*ecdsa.PublicKey)
-		address := crypto.PubkeyToAddress(*publicKey).Hex()
+	return keys, nil
+}
 
-		keys[i] = &Key{
-			ID:         fmt.Sprintf("anvil-%d", i),
-			Name:       fmt.Sprintf("anvil-%d", i),
-			Address:    address,
-			PrivateKey: privateKey,
-			PublicKey:  crypto.FromECDSAPub(publicKey),
-			CreatedAt:  time.Now(),
-		}
+// newKey builds a Key from a hex-encoded private key, with or without the
+// 0x prefix.
+func newKey(id, name, hexKey string) (*Key, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, err
 	}
 
-	return keys, nil
+	publicKey := privateKey.Public().(*ecdsa.PublicKey)
+	address := crypto.PubkeyToAddress(*publicKey).Hex()
+
+	return &Key{
+		ID:         id,
+		Name:       name,
+		Address:    address,
+		PrivateKey: privateKey,
+		PublicKey:  crypto.FromECDSAPub(publicKey),
+		CreatedAt:  time.Now(),
+	}, nil
 }
@@ -0,0 +1,50 @@
+package keystore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// fileKey is one entry of a keys file.
+type fileKey struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	PrivateKey string `json:"private_key"`
+}
+
+// LoadKeysFile loads additional keys from a JSON file holding an array of
+// {"id", "name", "private_key"} objects. The private key is hex, with or
+// without 0x. The ID defaults to "key-<index>" and the name to the ID.
+func LoadKeysFile(path string) ([]*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keys file: %w", err)
+	}
+
+	var entries []fileKey
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse keys file %s: %w", path, err)
+	}
+
+	keys := make([]*Key, len(entries))
+	for i, entry := range entries {
+		id := entry.ID
+		if id == "" {
+			id = fmt.Sprintf("key-%d", i)
+		}
+		name := entry.Name
+		if name == "" {
+			name = id
+		}
+
+		key, err := newKey(id, name, entry.PrivateKey)
+		if err != nil {
+			// Don't echo the key material
+			return nil, fmt.Errorf("keys file %s: invalid private key for %s", path, id)
+		}
+		keys[i] = key
+	}
+
+	return keys, nil
+}
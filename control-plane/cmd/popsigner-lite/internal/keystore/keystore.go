@@ -17,6 +17,7 @@ import (
 type Keystore struct {
 	keys    map[string]*Key // address -> key
 	apiKeys map[string]*APIKey
+	ready   bool
 	mu      sync.RWMutex
 }
 
@@ -61,6 +62,27 @@ func (k *Keystore) AddKey(key *Key) error {
 	return nil
 }
 
+// MarkReady records that the initial keys are loaded.
+func (k *Keystore) MarkReady() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.ready = true
+}
+
+// Ready reports whether the initial keys are loaded.
+func (k *Keystore) Ready() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.ready
+}
+
+// KeyCount returns the number of keys in the keystore.
+func (k *Keystore) KeyCount() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.keys)
+}
+
 // GetKey retrieves a key by address.
 func (k *Keystore) GetKey(address string) (*Key, error) {
 	k.mu.RLock()
@@ -9,21 +9,83 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// Signer types, selecting how transactions are signed.
+const (
+	// SignerTypeLatest signs legacy transactions with EIP-155 replay
+	// protection and supports typed (EIP-2930, EIP-1559, ...) transactions.
+	SignerTypeLatest = "latest"
+	// SignerTypeEIP155 signs legacy transactions with EIP-155 replay
+	// protection only.
+	SignerTypeEIP155 = "eip155"
+	// SignerTypeLegacy signs legacy transactions without a chain ID, as
+	// before EIP-155.
+	SignerTypeLegacy = "legacy"
+)
+
+// TransactionSignerConfig configures a TransactionSigner.
+type TransactionSignerConfig struct {
+	// ChainID, if set, is the chain transactions are signed for. Requests
+	// without a chain ID use it, and requests for another chain are rejected.
+	ChainID *big.Int
+	// SignerType is one of the SignerType constants (default: SignerTypeLatest).
+	SignerType string
+}
+
 // TransactionSigner handles signing Ethereum transactions (legacy and EIP-1559).
 type TransactionSigner struct {
-	signer *EthereumSigner
+	signer     *EthereumSigner
+	chainID    *big.Int
+	signerType string
 }
 
-// NewTransactionSigner creates a new transaction signer.
+// NewTransactionSigner creates a new transaction signer that signs for the
+// chain ID of each request with the latest signer.
 func NewTransactionSigner() *TransactionSigner {
 	return &TransactionSigner{
-		signer: NewEthereumSigner(),
+		signer:     NewEthereumSigner(),
+		signerType: SignerTypeLatest,
+	}
+}
+
+// NewTransactionSignerWithConfig creates a transaction signer for a fixed
+// chain ID and signer type.
+func NewTransactionSignerWithConfig(cfg TransactionSignerConfig) (*TransactionSigner, error) {
+	s := NewTransactionSigner()
+	switch cfg.SignerType {
+	case "":
+	case SignerTypeLatest, SignerTypeEIP155, SignerTypeLegacy:
+		s.signerType = cfg.SignerType
+	default:
+		return nil, fmt.Errorf("unknown signer type %q: must be %q, %q or %q",
+			cfg.SignerType, SignerTypeLatest, SignerTypeEIP155, SignerTypeLegacy)
+	}
+	if cfg.ChainID != nil {
+		if cfg.ChainID.Sign() <= 0 {
+			return nil, fmt.Errorf("chain ID must be positive, got %s", cfg.ChainID)
+		}
+		s.chainID = new(big.Int).Set(cfg.ChainID)
+	}
+	return s, nil
+}
+
+// ChainID returns the configured chain ID, or nil if the signer signs for
+// the chain ID of each request.
+func (s *TransactionSigner) ChainID() *big.Int {
+	if s.chainID == nil {
+		return nil
 	}
+	return new(big.Int).Set(s.chainID)
+}
+
+// SignerType returns the configured signer type.
+func (s *TransactionSigner) SignerType() string {
+	return s.signerType
 }
 
 // SignTransaction signs an Ethereum transaction with the given private key and chain ID.
-// Supports both legacy and EIP-1559 (type 2) transactions.
-// Returns the RLP-encoded signed transaction bytes.
+// Supports both legacy and EIP-1559 (type 2) transactions. A nil chain ID
+// selects the configured one; a chain ID other than the configured one is an
+// error. Returns the RLP-encoded signed transaction bytes.
 func (s *TransactionSigner) SignTransaction(tx *types.Transaction, privateKey *ecdsa.PrivateKey, chainID *big.Int) ([]byte, error) {
 	if tx == nil {
 		return nil, fmt.Errorf("transaction is nil")
@@ -31,12 +93,13 @@ func (s *TransactionSigner) SignTransaction(tx *types.Transaction, privateKey *e
 	if privateKey == nil {
 		return nil, fmt.Errorf("private key is nil")
 	}
-	if chainID == nil {
-		return nil, fmt.Errorf("chain ID is nil")
+	chainID, err := s.resolveChainID(chainID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create the appropriate signer for the chain ID
-	signer := types.LatestSignerForChainID(chainID)
+	signer := s.GetTransactionSigner(chainID)
 
 	// Sign the transaction
 	signedTx, err := types.SignTx(tx, signer, privateKey)
@@ -131,10 +194,31 @@ func (s *TransactionSigner) SignEIP1559ContractDeployment(
 	return s.SignTransaction(tx, privateKey, chainID)
 }
 
-// GetTransactionSigner returns the appropriate signer for a chain ID.
-// This is useful for manual transaction signing operations.
+// GetTransactionSigner returns the appropriate signer for a chain ID and the
+// configured signer type. This is useful for manual transaction signing operations.
 func (s *TransactionSigner) GetTransactionSigner(chainID *big.Int) types.Signer {
-	return types.LatestSignerForChainID(chainID)
+	switch s.signerType {
+	case SignerTypeEIP155:
+		return types.NewEIP155Signer(chainID)
+	case SignerTypeLegacy:
+		return types.HomesteadSigner{}
+	default:
+		return types.LatestSignerForChainID(chainID)
+	}
+}
+
+// resolveChainID returns the chain ID to sign for.
+func (s *TransactionSigner) resolveChainID(chainID *big.Int) (*big.Int, error) {
+	switch {
+	case chainID == nil && s.chainID == nil:
+		return nil, fmt.Errorf("chain ID is nil")
+	case chainID == nil:
+		return s.chainID, nil
+	case s.chainID != nil && chainID.Cmp(s.chainID) != 0:
+		return nil, fmt.Errorf("chain ID %s does not match the configured chain ID %s", chainID, s.chainID)
+	default:
+		return chainID, nil
+	}
 }
 
 // ExtractSignature extracts the V, R, S values from a signed transaction.
@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// anvilKey0 is Anvil's first deterministic private key.
+const anvilKey0 = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func decodeTx(t *testing.T, raw []byte) *types.Transaction {
+	t.Helper()
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		t.Fatalf("decode signed tx: %v", err)
+	}
+	return tx
+}
+
+func TestTransactionSigner_ConfiguredChainID(t *testing.T) {
+	key, err := crypto.HexToECDSA(anvilKey0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	chainID := big.NewInt(901)
+
+	s, err := NewTransactionSignerWithConfig(TransactionSignerConfig{ChainID: chainID, SignerType: SignerTypeEIP155})
+	if err != nil {
+		t.Fatalf("NewTransactionSignerWithConfig() error = %v", err)
+	}
+
+	// No chain ID in the request: the configured one is used
+	raw, err := s.SignLegacyTransaction(0, &to, big.NewInt(1), 21000, big.NewInt(1e9), nil, key, nil)
+	if err != nil {
+		t.Fatalf("SignLegacyTransaction() error = %v", err)
+	}
+	tx := decodeTx(t, raw)
+
+	if tx.ChainId().Cmp(chainID) != 0 {
+		t.Errorf("chain ID = %s, want %s", tx.ChainId(), chainID)
+	}
+	// EIP-155: v = chainID * 2 + 35 + yParity
+	v, _, _ := tx.RawSignatureValues()
+	if base := new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35)); v.Cmp(base) != 0 && v.Cmp(new(big.Int).Add(base, big.NewInt(1))) != 0 {
+		t.Errorf("v = %s, want %s or %s+1", v, base, base)
+	}
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), tx)
+	if err != nil || sender != from {
+		t.Errorf("sender = %s, %v; want %s", sender.Hex(), err, from.Hex())
+	}
+
+	// Another chain ID is refused
+	if _, err := s.SignLegacyTransaction(0, &to, big.NewInt(1), 21000, big.NewInt(1e9), nil, key, big.NewInt(31337)); err == nil {
+		t.Error("signing for another chain ID succeeded")
+	}
+
+	// EIP-155 signers don't sign typed transactions
+	if _, err := s.SignEIP1559Transaction(0, &to, big.NewInt(1), 21000, big.NewInt(1), big.NewInt(1e9), nil, key, chainID); err == nil {
+		t.Error("EIP-155 signer signed an EIP-1559 transaction")
+	}
+}
+
+func TestTransactionSigner_Legacy(t *testing.T) {
+	key, err := crypto.HexToECDSA(anvilKey0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	s, err := NewTransactionSignerWithConfig(TransactionSignerConfig{ChainID: big.NewInt(901), SignerType: SignerTypeLegacy})
+	if err != nil {
+		t.Fatalf("NewTransactionSignerWithConfig() error = %v", err)
+	}
+	raw, err := s.SignLegacyTransaction(0, &to, big.NewInt(1), 21000, big.NewInt(1e9), nil, key, nil)
+	if err != nil {
+		t.Fatalf("SignLegacyTransaction() error = %v", err)
+	}
+
+	tx := decodeTx(t, raw)
+	if tx.Protected() {
+		t.Error("legacy signer produced a replay-protected signature")
+	}
+	if v, _, _ := tx.RawSignatureValues(); v.Uint64() != 27 && v.Uint64() != 28 {
+		t.Errorf("v = %s, want 27 or 28", v)
+	}
+}
+
+func TestNewTransactionSignerWithConfig_Invalid(t *testing.T) {
+	if _, err := NewTransactionSignerWithConfig(TransactionSignerConfig{SignerType: "frontier"}); err == nil {
+		t.Error("unknown signer type accepted")
+	}
+	if _, err := NewTransactionSignerWithConfig(TransactionSignerConfig{ChainID: big.NewInt(0)}); err == nil {
+		t.Error("zero chain ID accepted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
@@ -61,7 +62,9 @@ func (sm *serverManager) run() error {
 		return fmt.Errorf("initialize keystore: %w", err)
 	}
 
-	sm.setupServers()
+	if err := sm.setupServers(); err != nil {
+		return fmt.Errorf("set up servers: %w", err)
+	}
 	sm.startServers()
 
 	sm.logger.Info("popsigner-lite is ready",
@@ -79,7 +82,8 @@ func setupLogger() *slog.Logger {
 	}))
 }
 
-// initializeKeystore creates the keystore and loads Anvil keys.
+// initializeKeystore creates the keystore and loads Anvil keys, plus any
+// keys from the file named by KEYS_FILE.
 func (sm *serverManager) initializeKeystore() error {
 	sm.keystore = keystore.NewKeystore()
 
@@ -97,6 +101,24 @@ func (sm *serverManager) initializeKeystore() error {
 
 	sm.logger.Info("Loaded Anvil keys", slog.Int("count", len(anvilKeys)))
 
+	if path := os.Getenv("KEYS_FILE"); path != "" {
+		fileKeys, err := keystore.LoadKeysFile(path)
+		if err != nil {
+			return err
+		}
+		for _, key := range fileKeys {
+			if _, err := sm.keystore.GetKeyByID(key.ID); err == nil {
+				return fmt.Errorf("add key %s: a key with this ID already exists", key.ID)
+			}
+			if err := sm.keystore.AddKey(key); err != nil {
+				return fmt.Errorf("add key %s: %w", key.ID, err)
+			}
+		}
+		sm.logger.Info("Loaded keys file", slog.String("path", path), slog.Int("count", len(fileKeys)))
+	}
+
+	sm.keystore.MarkReady()
+
 	keys := sm.keystore.ListKeys()
 	addresses := make([]string, len(keys))
 	for i, key := range keys {
@@ -108,11 +130,18 @@ func (sm *serverManager) initializeKeystore() error {
 }
 
 // setupServers creates both HTTP servers with their handlers.
-func (sm *serverManager) setupServers() {
+func (sm *serverManager) setupServers() error {
 	sm.jsonrpcPort = getEnv("JSONRPC_PORT", defaultJSONRPCPort)
 	sm.restAPIPort = getEnv("REST_API_PORT", defaultRESTAPIPort)
 
-	txSigner := signer.NewTransactionSigner()
+	txSigner, err := newTransactionSigner()
+	if err != nil {
+		return err
+	}
+	sm.logger.Info("Configured transaction signer",
+		slog.String("signer_type", txSigner.SignerType()),
+		slog.Any("chain_id", txSigner.ChainID()),
+	)
 
 	sm.logger.Info("Setting up JSON-RPC server", slog.String("port", sm.jsonrpcPort))
 	rpcHandler := jsonrpc.NewServer(jsonrpc.ServerConfig{
@@ -139,6 +168,22 @@ func (sm *serverManager) setupServers() {
 		WriteTimeout: httpWriteTimeout,
 		IdleTimeout:  httpIdleTimeout,
 	}
+	return nil
+}
+
+// newTransactionSigner creates the transaction signer from CHAIN_ID (decimal
+// or 0x-prefixed hex; unset signs for each request's chain ID) and
+// SIGNER_TYPE (latest, eip155 or legacy).
+func newTransactionSigner() (*signer.TransactionSigner, error) {
+	cfg := signer.TransactionSignerConfig{SignerType: os.Getenv("SIGNER_TYPE")}
+	if v := os.Getenv("CHAIN_ID"); v != "" {
+		chainID, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid CHAIN_ID %q", v)
+		}
+		cfg.ChainID = chainID
+	}
+	return signer.NewTransactionSignerWithConfig(cfg)
 }
 
 // startServers starts both HTTP servers in separate goroutines with error propagation.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"strings"
 
@@ -65,7 +66,7 @@ func (m *DualAuthMiddleware) Handler(next http.Handler) http.Handler {
 		// Try API Key first (OP Stack)
 		apiKey := extractAPIKey(r)
 		if apiKey != "" {
-			result, err = m.authenticateAPIKey(ctx, apiKey)
+			result, err = m.authenticateAPIKey(ctx, apiKey, middleware.ClientIP(r))
 			if err != nil {
 				m.logger.Warn("API key auth failed",
 					slog.String("method", "api_key"),
@@ -95,6 +96,7 @@ func (m *DualAuthMiddleware) Handler(next http.Handler) http.Handler {
 		// Use middleware.OrgIDKey for compatibility with jsonrpc handlers
 		ctx = context.WithValue(ctx, middleware.OrgIDKey, result.OrgID)
 		ctx = context.WithValue(ctx, AuthMethodKey, result.Method)
+		ctx = withActorID(ctx, result)
 
 		// Log successful auth
 		m.logger.Debug("Request authenticated",
@@ -108,13 +110,18 @@ func (m *DualAuthMiddleware) Handler(next http.Handler) http.Handler {
 }
 
 // authenticateAPIKey validates an API key and returns auth result.
-func (m *DualAuthMiddleware) authenticateAPIKey(ctx context.Context, apiKey string) (*AuthResult, error) {
+func (m *DualAuthMiddleware) authenticateAPIKey(ctx context.Context, apiKey string, clientIP net.IP) (*AuthResult, error) {
 	// Validate API key
 	key, err := m.apiKeyValidator.Validate(ctx, apiKey)
 	if err != nil {
 		return nil, err
 	}
 
+	// Enforce the key's IP allowlist
+	if !key.AllowsIP(clientIP) {
+		return nil, fmt.Errorf("API key not allowed from IP %s", clientIP)
+	}
+
 	// Check for EVM signing scope
 	if !key.HasScope("keys:sign:evm") && !key.HasScope("keys:sign") {
 		return nil, fmt.Errorf("API key missing required scope: keys:sign:evm or keys:sign")
@@ -129,10 +136,30 @@ func (m *DualAuthMiddleware) authenticateAPIKey(ctx context.Context, apiKey stri
 	return &AuthResult{
 		OrgID:      key.OrgID.String(),
 		Method:     "api_key",
+		ActorID:    key.ID.String(),
 		Identifier: identifier,
+		APIKey:     key,
 	}, nil
 }
 
+// withActorID adds the authenticated API key or certificate to the context so
+// sign audit entries can attribute the request and signing handlers can check
+// its signing scope.
+func withActorID(ctx context.Context, result *AuthResult) context.Context {
+	if result.Method == "mtls" {
+		ctx = context.WithValue(ctx, middleware.CertificateIDKey, result.ActorID)
+		if result.Certificate != nil {
+			ctx = context.WithValue(ctx, middleware.CertificateContextKey, result.Certificate)
+		}
+		return ctx
+	}
+	ctx = context.WithValue(ctx, middleware.APIKeyIDKey, result.ActorID)
+	if result.APIKey != nil {
+		ctx = context.WithValue(ctx, middleware.APIKeyContextKey, result.APIKey)
+	}
+	return ctx
+}
+
 // extractAPIKey extracts the API key from the request.
 // Supports Authorization header with "Bearer" scheme and X-API-Key header.
 func extractAPIKey(r *http.Request) string {
@@ -198,6 +225,7 @@ func MTLSOnlyMiddleware(certRepo repository.CertificateRepository, logger *slog.
 			// Use middleware.OrgIDKey for compatibility with jsonrpc handlers
 			ctx := context.WithValue(r.Context(), middleware.OrgIDKey, result.OrgID)
 			ctx = context.WithValue(ctx, AuthMethodKey, result.Method)
+			ctx = withActorID(ctx, result)
 
 			logger.Debug("Request authenticated via mTLS",
 				slog.String("org_id", result.OrgID),
@@ -233,6 +261,12 @@ func APIKeyOnlyMiddleware(apiKeyValidator APIKeyValidator, logger *slog.Logger)
 				return
 			}
 
+			// Enforce the key's IP allowlist
+			if !key.AllowsIP(middleware.ClientIP(r)) {
+				jsonRPCError(w, -32003, "Forbidden: API key not allowed from this IP address")
+				return
+			}
+
 			// Check for EVM signing scope
 			if !key.HasScope("keys:sign:evm") && !key.HasScope("keys:sign") {
 				jsonRPCError(w, -32003, "Forbidden: API key missing required scope")
@@ -248,6 +282,7 @@ func APIKeyOnlyMiddleware(apiKeyValidator APIKeyValidator, logger *slog.Logger)
 			ctx := context.WithValue(r.Context(), middleware.OrgIDKey, key.OrgID.String())
 			ctx = context.WithValue(ctx, AuthMethodKey, "api_key")
 			ctx = context.WithValue(ctx, middleware.APIKeyIDKey, key.ID.String())
+			ctx = context.WithValue(ctx, middleware.APIKeyContextKey, key)
 
 			logger.Debug("Request authenticated via API key",
 				slog.String("org_id", key.OrgID.String()),
@@ -26,9 +26,12 @@ func NewMTLSAuthenticator(certRepo repository.CertificateRepository) *MTLSAuthen
 
 // AuthResult contains the result of authentication.
 type AuthResult struct {
-	OrgID      string
-	Method     string // "api_key" or "mtls"
-	Identifier string // API key prefix or cert fingerprint (truncated)
+	OrgID       string
+	Method      string              // "api_key" or "mtls"
+	ActorID     string              // API key or certificate ID
+	Identifier  string              // API key prefix or cert fingerprint (truncated)
+	APIKey      *models.APIKey      // Set for API key auth
+	Certificate *models.Certificate // Set for mTLS auth
 }
 
 // Authenticate validates a client certificate and returns the organization ID.
@@ -48,51 +51,54 @@ func (a *MTLSAuthenticator) Authenticate(ctx context.Context, r *http.Request) (
 	fingerprint := CalculateCertFingerprint(cert)
 
 	// Validate certificate in database
-	orgID, err := a.validateCertificate(ctx, fingerprint, cert)
+	dbCert, err := a.validateCertificate(ctx, fingerprint, cert)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResult{
-		OrgID:      orgID,
-		Method:     "mtls",
-		Identifier: fingerprint[:16] + "...", // Truncated for logging
+		OrgID:       dbCert.OrgID.String(),
+		Method:      "mtls",
+		ActorID:     dbCert.ID.String(),
+		Identifier:  fingerprint[:16] + "...", // Truncated for logging
+		Certificate: dbCert,
 	}, nil
 }
 
-// validateCertificate checks if the certificate is valid and returns the org ID.
-func (a *MTLSAuthenticator) validateCertificate(ctx context.Context, fingerprint string, cert *x509.Certificate) (string, error) {
+// validateCertificate checks if the certificate is valid and returns its
+// database record.
+func (a *MTLSAuthenticator) validateCertificate(ctx context.Context, fingerprint string, cert *x509.Certificate) (*models.Certificate, error) {
 	// Look up certificate in database
 	dbCert, err := a.certRepo.GetByFingerprint(ctx, fingerprint)
 	if err != nil {
-		return "", fmt.Errorf("database error: %w", err)
+		return nil, fmt.Errorf("database error: %w", err)
 	}
 
 	if dbCert == nil {
-		return "", fmt.Errorf("certificate not registered")
+		return nil, fmt.Errorf("certificate not registered")
 	}
 
 	// Check if revoked
 	if dbCert.IsRevoked() {
-		return "", fmt.Errorf("certificate has been revoked")
+		return nil, fmt.Errorf("certificate has been revoked")
 	}
 
 	// Check if expired
 	if dbCert.IsExpired() {
-		return "", fmt.Errorf("certificate has expired")
+		return nil, fmt.Errorf("certificate has expired")
 	}
 
 	// Extract org ID from CN and verify it matches database record
 	orgID, err := models.OrgIDFromCN(cert.Subject.CommonName)
 	if err != nil {
-		return "", fmt.Errorf("invalid certificate CN: %w", err)
+		return nil, fmt.Errorf("invalid certificate CN: %w", err)
 	}
 
 	if orgID != dbCert.OrgID.String() {
-		return "", fmt.Errorf("certificate CN does not match registered organization")
+		return nil, fmt.Errorf("certificate CN does not match registered organization")
 	}
 
-	return dbCert.OrgID.String(), nil
+	return dbCert, nil
 }
 
 // CalculateCertFingerprint computes SHA256 fingerprint of a certificate.
@@ -8,16 +8,26 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"math/big"
+	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/handler/jsonrpc"
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/service"
 )
 
 // mockCertRepo implements a mock CertificateRepository for testing.
@@ -36,13 +46,18 @@ func (m *mockCertRepo) GetByFingerprint(ctx context.Context, fingerprint string)
 	return nil, nil
 }
 
-// Implement other interface methods (not used in tests but required)
 func (m *mockCertRepo) Create(ctx context.Context, cert *models.Certificate) error {
+	m.cert = cert
 	return nil
 }
 func (m *mockCertRepo) GetByID(ctx context.Context, id string) (*models.Certificate, error) {
+	if m.cert != nil && m.cert.ID.String() == id {
+		return m.cert, nil
+	}
 	return nil, nil
 }
+
+// Implement other interface methods (not used in tests but required)
 func (m *mockCertRepo) GetBySerialNumber(ctx context.Context, serialNumber string) (*models.Certificate, error) {
 	return nil, nil
 }
@@ -59,6 +74,18 @@ func (m *mockCertRepo) CountByOrg(ctx context.Context, orgID string) (int, error
 	return 0, nil
 }
 func (m *mockCertRepo) Revoke(ctx context.Context, id string, reason string) error {
+	if m.cert != nil && m.cert.ID.String() == id {
+		now := time.Now()
+		m.cert.RevokedAt = &now
+		m.cert.RevocationReason = &reason
+	}
+	return nil
+}
+func (m *mockCertRepo) SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error {
+	if m.cert != nil && m.cert.ID.String() == id {
+		m.cert.AllowedKeyIDs = keyIDs
+		m.cert.AllowedNamespaceIDs = namespaceIDs
+	}
 	return nil
 }
 func (m *mockCertRepo) Delete(ctx context.Context, id string) error {
@@ -402,6 +429,273 @@ func TestExtractAPIKey(t *testing.T) {
 	}
 }
 
+// testPKI signs CSRs with a test CA, standing in for OpenBao.
+type testPKI struct {
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+}
+
+func (p *testPKI) IssueCertificate(ctx context.Context, req *service.IssueCertRequest) (*service.IssuedCertificate, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *testPKI) SignCertificateRequest(ctx context.Context, req *service.SignCSRRequest) (*service.IssuedCertificate, error) {
+	block, _ := pem.Decode([]byte(req.CSRPEM))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: req.CommonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, p.caCert, csr.PublicKey, p.caKey)
+	if err != nil {
+		return nil, err
+	}
+	return &service.IssuedCertificate{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		CACertPEM:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.caCert.Raw})),
+		SerialNumber:   template.SerialNumber.String(),
+		IssuedAt:       template.NotBefore,
+		ExpiresAt:      template.NotAfter,
+	}, nil
+}
+
+func (p *testPKI) RevokeCertificate(ctx context.Context, serialNumber string) error {
+	return nil
+}
+
+func (p *testPKI) GetCACertificate(ctx context.Context) (*service.CACertificate, error) {
+	return nil, errors.New("not supported")
+}
+
+// testOrgRepo returns a single organization.
+type testOrgRepo struct {
+	repository.OrgRepository
+	org *models.Organization
+}
+
+func (r *testOrgRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	if r.org.ID == id {
+		return r.org, nil
+	}
+	return nil, nil
+}
+
+// testAuditRepo hands created audit logs to the test, or discards them if
+// created is nil.
+type testAuditRepo struct {
+	repository.AuditRepository
+	created chan *models.AuditLog
+}
+
+func (r *testAuditRepo) Create(ctx context.Context, log *models.AuditLog) error {
+	if r.created != nil {
+		r.created <- log
+	}
+	return nil
+}
+
+// testKeyRepo looks up keys by Ethereum address.
+type testKeyRepo struct {
+	repository.KeyRepository
+	keys []*models.Key
+}
+
+func (r *testKeyRepo) GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddress string) (*models.Key, error) {
+	for _, key := range r.keys {
+		if key.OrgID == orgID && strings.EqualFold(*key.EthAddress, ethAddress) {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestMTLSOnlyMiddleware_EnrolledCertificate(t *testing.T) {
+	caKey, caCert := generateTestCA(t)
+	org := &models.Organization{ID: uuid.New()}
+	certRepo := &mockCertRepo{}
+	certSvc := service.NewCertificateService(certRepo, &testPKI{caKey: caKey, caCert: caCert}, &testOrgRepo{org: org}, &testAuditRepo{})
+
+	// The client keeps its private key and only sends a CSR
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate client key: %v", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "batch-poster"},
+	}, clientKey)
+	if err != nil {
+		t.Fatalf("Failed to create CSR: %v", err)
+	}
+
+	namespaceID := uuid.New()
+	bundle, err := certSvc.Enroll(context.Background(), &models.EnrollCertificateRequest{
+		OrgID:               org.ID,
+		Name:                "batch-poster",
+		CSRPEM:              string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})),
+		AllowedNamespaceIDs: []uuid.UUID{namespaceID},
+	})
+	if err != nil {
+		t.Fatalf("Enroll failed: %v", err)
+	}
+
+	block, _ := pem.Decode(bundle.ClientCert)
+	if block == nil {
+		t.Fatal("Failed to decode enrolled certificate")
+	}
+	clientCert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse enrolled certificate: %v", err)
+	}
+	if certRepo.cert == nil || certRepo.cert.Fingerprint != CalculateCertFingerprint(clientCert) {
+		t.Fatal("Enrolled certificate fingerprint was not stored")
+	}
+
+	var gotOrgID string
+	var gotCert *models.Certificate
+	handler := MTLSOnlyMiddleware(certRepo, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotOrgID = GetOrgID(r.Context())
+		gotCert = middleware.GetCertificateFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func() int {
+		r := httptest.NewRequest("POST", "/rpc", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", code, http.StatusOK)
+	}
+	if gotOrgID != org.ID.String() {
+		t.Errorf("OrgID = %s, want %s", gotOrgID, org.ID)
+	}
+	if gotCert == nil {
+		t.Fatal("certificate not set in context")
+	}
+	if !gotCert.AllowsKey(&models.Key{ID: uuid.New(), NamespaceID: namespaceID}) {
+		t.Error("key in the enrolled namespace should be allowed")
+	}
+	if gotCert.AllowsKey(&models.Key{ID: uuid.New(), NamespaceID: uuid.New()}) {
+		t.Error("key outside the enrolled namespace should not be allowed")
+	}
+
+	// Revoked certificates are rejected
+	if err := certSvc.Revoke(context.Background(), org.ID.String(), certRepo.cert.ID.String(), "rotated"); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if code := serve(); code != http.StatusUnauthorized {
+		t.Errorf("status after revoke = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestMTLSOnlyMiddleware_SigningScope(t *testing.T) {
+	var signs int32
+	bao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&signs, 1)
+		w.Write([]byte(`{"data":{"r":"` + strings.Repeat("ab", 32) + `","s":"` + strings.Repeat("cd", 32) + `","v_int":27}}`))
+	}))
+	defer bao.Close()
+
+	orgID := uuid.New()
+	batcherAddr := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	stakerAddr := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	batcher := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), EthAddress: &batcherAddr, BaoKeyPath: "batcher"}
+	staker := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), EthAddress: &stakerAddr, BaoKeyPath: "staker"}
+
+	auditRepo := &testAuditRepo{created: make(chan *models.AuditLog, 10)}
+	rpcServer := jsonrpc.NewServer(jsonrpc.ServerConfig{
+		KeyRepo:   &testKeyRepo{keys: []*models.Key{batcher, staker}},
+		AuditRepo: auditRepo,
+		BaoClient: openbao.NewClient(&config.OpenBaoConfig{Address: bao.URL, Token: "test"}),
+	})
+
+	// The staker certificate may only sign with keys in the staker's namespace
+	caKey, caCert := generateTestCA(t)
+	_, clientCert := generateTestClientCert(t, caKey, caCert, models.CNFromOrgID(orgID.String()))
+	stakerCert := &models.Certificate{
+		ID:                  uuid.New(),
+		OrgID:               orgID,
+		Name:                "nitro-staker",
+		Fingerprint:         CalculateCertFingerprint(clientCert),
+		ExpiresAt:           time.Now().Add(time.Hour),
+		AllowedNamespaceIDs: []uuid.UUID{staker.NamespaceID},
+	}
+	handler := MTLSOnlyMiddleware(&mockCertRepo{cert: stakerCert}, nil)(rpcServer)
+
+	sign := func(addr string) *jsonrpc.Response {
+		t.Helper()
+		body := `{"jsonrpc":"2.0","method":"eth_sign","params":["` + addr + `","0x48656c6c6f"],"id":1}`
+		r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{clientCert}}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		var resp jsonrpc.Response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", w.Body.String(), err)
+		}
+		return &resp
+	}
+	nextAudit := func() *models.AuditLog {
+		t.Helper()
+		select {
+		case log := <-auditRepo.created:
+			return log
+		case <-time.After(time.Second):
+			t.Fatal("no audit entry written")
+			return nil
+		}
+	}
+
+	t.Run("in scope", func(t *testing.T) {
+		resp := sign(stakerAddr)
+		if resp.Error != nil {
+			t.Fatalf("sign failed: %+v", resp.Error)
+		}
+
+		log := nextAudit()
+		if log.ActorType != models.ActorTypeCertificate || log.ActorID == nil || *log.ActorID != stakerCert.ID {
+			t.Errorf("audit actor = %s %v, want certificate %s", log.ActorType, log.ActorID, stakerCert.ID)
+		}
+		var metadata map[string]any
+		if err := json.Unmarshal(log.Metadata, &metadata); err != nil {
+			t.Fatalf("Failed to decode audit metadata: %v", err)
+		}
+		if metadata["certificate_name"] != stakerCert.Name || metadata["certificate_fingerprint"] != stakerCert.Fingerprint {
+			t.Errorf("audit metadata = %v, want certificate identity", metadata)
+		}
+	})
+
+	t.Run("out of scope", func(t *testing.T) {
+		before := atomic.LoadInt32(&signs)
+		resp := sign(batcherAddr)
+		if resp.Error == nil || resp.Error.Code != jsonrpc.UnauthorizedError {
+			t.Fatalf("error = %+v, want code %d", resp.Error, jsonrpc.UnauthorizedError)
+		}
+		if atomic.LoadInt32(&signs) != before {
+			t.Error("out-of-scope request must not reach OpenBao")
+		}
+
+		log := nextAudit()
+		if log.Event != models.AuditEventKeySignFailed {
+			t.Errorf("audit event = %s, want %s", log.Event, models.AuditEventKeySignFailed)
+		}
+		if log.ActorID == nil || *log.ActorID != stakerCert.ID {
+			t.Errorf("audit actor = %v, want %s", log.ActorID, stakerCert.ID)
+		}
+	})
+}
+
 // Test helpers
 
 func generateTestCA(t *testing.T) (*ecdsa.PrivateKey, *x509.Certificate) {
@@ -12,6 +12,7 @@ import (
 	"log"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
@@ -20,12 +21,21 @@ import (
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/Bidon15/popsigner/control-plane/cmd/rpc-gateway/internal/auth"
 	"github.com/Bidon15/popsigner/control-plane/internal/config"
 	"github.com/Bidon15/popsigner/control-plane/internal/database"
+	"github.com/Bidon15/popsigner/control-plane/internal/handler"
 	"github.com/Bidon15/popsigner/control-plane/internal/handler/jsonrpc"
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
@@ -56,6 +66,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
 
 	// Load port configurations
 	apiKeyPort := getEnvInt("POPSIGNER_RPC_GATEWAY_PORT", defaultPort)
@@ -69,6 +83,14 @@ func main() {
 		slog.Bool("mtls_enabled", mtlsEnabled),
 	)
 
+	// Initialize tracing (no-op unless an OTLP endpoint is configured)
+	tp, shutdownTracing, err := setupTracing(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+	otel.SetTracerProvider(tp)
+
 	// Connect to PostgreSQL
 	db, err := database.NewPostgres(cfg.Database)
 	if err != nil {
@@ -90,14 +112,43 @@ func main() {
 	logger.Info("OpenBao client initialized", slog.String("address", cfg.OpenBao.Address))
 
 	// Initialize repositories
-	keyRepo := repository.NewKeyRepository(db.Pool())
+	// Key metadata is cached in Redis and shared across gateway replicas
+	keyRepo := repository.NewCachedKeyRepository(
+		repository.NewKeyRepository(db.Pool()),
+		redis,
+		time.Duration(getEnvInt("POPSIGNER_KEY_CACHE_TTL_SECONDS", int(repository.DefaultKeyCacheTTL/time.Second)))*time.Second,
+		logger,
+	)
 	apiKeyRepo := repository.NewAPIKeyRepository(db.Pool())
 	certRepo := repository.NewCertificateRepository(db.Pool())
 	auditRepo := repository.NewAuditRepository(db.Pool())
 	usageRepo := repository.NewUsageRepository(db.Pool())
+	// API call counts are batched in memory and flushed periodically
+	apiUsage := middleware.NewUsageAggregator(usageRepo,
+		time.Duration(getEnvInt("POPSIGNER_USAGE_FLUSH_INTERVAL_SECONDS", int(middleware.DefaultUsageFlushInterval/time.Second)))*time.Second)
+	apiUsage.Start()
+	orgRepo := repository.NewOrgRepository(db.Pool())
+
+	// Evict cached keys when they are deleted or changed elsewhere
+	keyEvents := redis.Subscribe(context.Background(), repository.KeyInvalidationChannel)
+	defer keyEvents.Close()
+	go func() {
+		for msg := range keyEvents.Channel() {
+			if err := keyRepo.HandleInvalidation(context.Background(), msg.Payload); err != nil {
+				logger.Warn("Failed to handle key invalidation", slog.String("error", err.Error()))
+			}
+		}
+	}()
 
 	// Initialize services
-	apiKeySvc := service.NewAPIKeyService(apiKeyRepo)
+	apiKeySvc := service.NewAPIKeyService(apiKeyRepo, service.WithAPIKeyPrefix(cfg.Auth.APIKeyPrefix))
+
+	// Tracks in-flight signs so shutdown can let them finish
+	drainer := middleware.NewDrainer()
+
+	// Largest JSON-RPC request body accepted, shared by the middleware that
+	// reads it before the server does
+	maxBodyBytes := int64(getEnvInt("POPSIGNER_RPC_MAX_BODY_BYTES", jsonrpc.DefaultMaxRequestBodySize))
 
 	// Create JSON-RPC server
 	rpcServer := jsonrpc.NewServer(jsonrpc.ServerConfig{
@@ -106,19 +157,51 @@ func main() {
 		UsageRepo: usageRepo,
 		BaoClient: baoClient,
 		Logger:    logger,
+		Drainer:   drainer,
+		// Reject oversized payloads before they are parsed or signed
+		MaxRequestBodySize: maxBodyBytes,
+		// Hold signs through brief OpenBao outages instead of failing them
+		SignRetry: jsonrpc.SignRetryConfig{
+			GracePeriod: time.Duration(getEnvInt("POPSIGNER_RPC_SIGN_RETRY_GRACE_MS", 0)) * time.Millisecond,
+			Interval:    time.Duration(getEnvInt("POPSIGNER_RPC_SIGN_RETRY_INTERVAL_MS", int(jsonrpc.DefaultSignRetryInterval/time.Millisecond))) * time.Millisecond,
+			MaxQueue:    getEnvInt("POPSIGNER_RPC_SIGN_RETRY_MAX_QUEUE", jsonrpc.DefaultSignRetryMaxQueue),
+		},
 	})
 
 	// Rate limit config (shared between servers)
+	// Limits are selected by the plan of the authenticated organization. An
+	// explicit POPSIGNER_RPC_RATE_LIMIT_RPS applies to every plan instead.
+	planResolver := middleware.NewPlanResolver(orgRepo, middleware.DefaultPlanCacheTTL)
 	rateLimitCfg := middleware.RPCRateLimitConfig{
 		RequestsPerSecond: getEnvInt("POPSIGNER_RPC_RATE_LIMIT_RPS", 100),
 		BurstSize:         getEnvInt("POPSIGNER_RPC_RATE_LIMIT_BURST", 200),
+		MaxBodyBytes:      maxBodyBytes,
+	}
+	if os.Getenv("POPSIGNER_RPC_RATE_LIMIT_RPS") == "" {
+		rateLimitCfg.Plans = middleware.DefaultRPCPlanRequestsPerSecond()
+		rateLimitCfg.PlanResolver = planResolver
+	}
+
+	// Monthly sign quota by plan (shared between servers)
+	signQuotaCfg := middleware.SignQuotaConfig{
+		PlanResolver: planResolver,
+		MaxBodyBytes: maxBodyBytes,
 	}
 
+	// Readiness covers OpenBao too: a sealed Bao can't sign anything
+	ready := handler.ReadyHandler(db, redis, baoClient)
+
 	// ===========================================
 	// Server 1: API Key authentication (Port 8545)
 	// For OP Stack and general clients
 	// ===========================================
-	apiKeyRouter := createAPIKeyRouter(apiKeySvc, redis, rpcServer, rateLimitCfg, usageRepo, db, logger)
+	corsCfg := middleware.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+	}
+	apiKeyRouter := createAPIKeyRouter(apiKeySvc, orgRepo, redis, rpcServer, drainer, rateLimitCfg, signQuotaCfg, corsCfg, trustedProxies, apiUsage, ready, tp, logger)
 
 	apiKeySrv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", apiKeyPort),
@@ -145,7 +228,7 @@ func main() {
 	// ===========================================
 	var mtlsSrv *http.Server
 	if mtlsEnabled {
-		mtlsRouter := createMTLSRouter(certRepo, redis, rpcServer, rateLimitCfg, db, logger)
+		mtlsRouter := createMTLSRouter(certRepo, redis, rpcServer, drainer, rateLimitCfg, signQuotaCfg, trustedProxies, ready, tp, logger)
 
 		tlsConfig, err := buildMTLSTLSConfig(logger)
 		if err != nil {
@@ -192,6 +275,11 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Fail readiness and let in-flight signs finish before closing connections
+	if err := drainer.Drain(ctx); err != nil {
+		logger.Error("Timed out draining in-flight signs", slog.Any("error", err))
+	}
+
 	// Shutdown API Key server
 	if err := apiKeySrv.Shutdown(ctx); err != nil {
 		logger.Error("API Key server shutdown error", slog.Any("error", err))
@@ -204,6 +292,11 @@ func main() {
 		}
 	}
 
+	// Write API call counts buffered since the last flush
+	if err := apiUsage.Close(ctx); err != nil {
+		logger.Error("Failed to flush API usage", slog.Any("error", err))
+	}
+
 	logger.Info("RPC Gateway stopped gracefully")
 }
 
@@ -212,28 +305,35 @@ func main() {
 // Endpoint: POST https://rpc.popsigner.com/ with X-API-Key header
 func createAPIKeyRouter(
 	apiKeySvc service.APIKeyService,
+	orgRepo repository.OrgRepository,
 	redis *database.Redis,
 	rpcServer *jsonrpc.Server,
+	drainer *middleware.Drainer,
 	rateLimitCfg middleware.RPCRateLimitConfig,
-	usageRepo repository.UsageRepository,
-	db *database.Postgres,
+	signQuotaCfg middleware.SignQuotaConfig,
+	corsCfg middleware.CORSConfig,
+	trustedProxies []netip.Prefix,
+	apiUsage *middleware.UsageAggregator,
+	ready http.Handler,
+	tp trace.TracerProvider,
 	logger *slog.Logger,
 ) chi.Router {
 	r := chi.NewRouter()
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.RealIP(trustedProxies))
+	r.Use(middleware.Tracing(tp))
 	r.Use(middleware.Logging(logger))
 	r.Use(chimiddleware.Recoverer)
-	r.Use(middleware.CORS())
+	r.Use(middleware.CORS(corsCfg))
 	r.Use(chimiddleware.Timeout(defaultTimeout))
 
 	// Health check (no auth)
 	r.Get("/health", healthHandler())
 
 	// Ready check (verifies dependencies)
-	r.Get("/ready", readyHandler(db, redis))
+	r.Get("/ready", drainer.Ready(ready).ServeHTTP)
 
 	// Metrics endpoint (no auth, but should be protected at ingress level)
 	r.Handle("/metrics", promhttp.Handler())
@@ -241,9 +341,13 @@ func createAPIKeyRouter(
 	// JSON-RPC endpoint at root with API Key auth and rate limiting
 	// OP Stack: --signer.endpoint="https://rpc.popsigner.com"
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.APIKeyAuth(apiKeySvc))
-		r.Use(middleware.TrackAPIUsage(usageRepo))
-		r.Use(middleware.RPCRateLimit(redis, rateLimitCfg))
+		r.Use(middleware.TraceStep(tp, "auth.api_key", middleware.APIKeyAuth(apiKeySvc)))
+		// Signing needs the operator role, as on the control plane's /v1/rpc
+		r.Use(middleware.ResolveOrgRole(orgRepo))
+		r.Use(middleware.RequireRole(models.RoleOperator))
+		r.Use(middleware.TrackAPIUsage(apiUsage))
+		r.Use(middleware.TraceStep(tp, "rate_limit", middleware.RPCRateLimit(redis, rateLimitCfg)))
+		r.Use(middleware.TraceStep(tp, "sign_quota", middleware.RPCSignQuota(redis, signQuotaCfg)))
 		r.Post("/", rpcServer.ServeHTTP)
 	})
 
@@ -257,15 +361,20 @@ func createMTLSRouter(
 	certRepo repository.CertificateRepository,
 	redis *database.Redis,
 	rpcServer *jsonrpc.Server,
+	drainer *middleware.Drainer,
 	rateLimitCfg middleware.RPCRateLimitConfig,
-	db *database.Postgres,
+	signQuotaCfg middleware.SignQuotaConfig,
+	trustedProxies []netip.Prefix,
+	ready http.Handler,
+	tp trace.TracerProvider,
 	logger *slog.Logger,
 ) chi.Router {
 	r := chi.NewRouter()
 
 	// Global middleware
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.RealIP(trustedProxies))
+	r.Use(middleware.Tracing(tp))
 	r.Use(middleware.Logging(logger))
 	r.Use(chimiddleware.Recoverer)
 	r.Use(chimiddleware.Timeout(defaultTimeout))
@@ -274,19 +383,43 @@ func createMTLSRouter(
 	r.Get("/health", healthHandler())
 
 	// Ready check (verifies dependencies)
-	r.Get("/ready", readyHandler(db, redis))
+	r.Get("/ready", drainer.Ready(ready).ServeHTTP)
 
 	// JSON-RPC endpoint at root with mTLS auth and rate limiting
 	// Nitro: --*.external-signer.url="https://rpc-mtls.popsigner.com"
 	r.Group(func(r chi.Router) {
-		r.Use(auth.MTLSOnlyMiddleware(certRepo, logger))
-		r.Use(middleware.RPCRateLimit(redis, rateLimitCfg))
+		r.Use(middleware.TraceStep(tp, "auth.mtls", auth.MTLSOnlyMiddleware(certRepo, logger)))
+		r.Use(middleware.TraceStep(tp, "rate_limit", middleware.RPCRateLimit(redis, rateLimitCfg)))
+		r.Use(middleware.TraceStep(tp, "sign_quota", middleware.RPCSignQuota(redis, signQuotaCfg)))
 		r.Post("/", rpcServer.ServeHTTP)
 	})
 
 	return r
 }
 
+// setupTracing creates the tracer provider for the gateway. Spans are exported
+// over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT (or the traces-specific
+// variant) is set; otherwise tracing is a no-op. The exporter reads the
+// standard OTEL_EXPORTER_OTLP_* environment variables.
+func setupTracing(ctx context.Context) (trace.TracerProvider, func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+	)
+	return tp, tp.Shutdown, nil
+}
+
 // buildMTLSTLSConfig creates the TLS configuration for the mTLS server.
 func buildMTLSTLSConfig(logger *slog.Logger) (*tls.Config, error) {
 	caCertPath := getEnvString("POPSIGNER_CA_CERT_PATH", defaultCACertPath)
@@ -332,34 +465,6 @@ func healthHandler() http.HandlerFunc {
 	}
 }
 
-// readyHandler returns a readiness check that verifies database and Redis connections.
-func readyHandler(db *database.Postgres, redis *database.Redis) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		// Check database connection
-		if err := db.Ping(ctx); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"error","component":"database"}`))
-			return
-		}
-
-		// Check Redis connection
-		if err := redis.Ping(ctx); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"error","component":"redis"}`))
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ok","database":"connected","redis":"connected"}`))
-	}
-}
-
 // getEnvInt returns an environment variable as int with default.
 func getEnvInt(key string, defaultVal int) int {
 	if v := os.Getenv(key); v != "" {
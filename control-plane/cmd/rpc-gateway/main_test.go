@@ -52,6 +52,10 @@ func (m *mockKeyRepo) Create(ctx context.Context, key *models.Key) error {
 	return nil
 }
 
+func (m *mockKeyRepo) CreateBatch(ctx context.Context, keys []*models.Key) error {
+	return nil
+}
+
 func (m *mockKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
 	return nil, nil
 }
@@ -72,6 +76,14 @@ func (m *mockKeyRepo) ListByNamespace(ctx context.Context, namespaceID uuid.UUID
 	return nil, nil
 }
 
+func (m *mockKeyRepo) ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error) {
+	return nil, nil
+}
+
+func (m *mockKeyRepo) ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error) {
+	return nil, 0, nil
+}
+
 func (m *mockKeyRepo) CountByOrg(ctx context.Context, orgID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -80,10 +92,30 @@ func (m *mockKeyRepo) Update(ctx context.Context, key *models.Key) error {
 	return nil
 }
 
+func (m *mockKeyRepo) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	return nil
+}
+
+func (m *mockKeyRepo) MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	return nil
+}
+
+func (m *mockKeyRepo) GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error) {
+	return nil, nil
+}
+
 func (m *mockKeyRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockKeyRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockKeyRepo) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error) {
+	return nil, nil
+}
+
 func (m *mockKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
@@ -131,6 +163,14 @@ func (m *mockAPIKeyService) Revoke(ctx context.Context, orgID, keyID uuid.UUID)
 	return nil
 }
 
+func (m *mockAPIKeyService) Rotate(ctx context.Context, orgID, keyID uuid.UUID, grace time.Duration) (*service.APIKeyRotation, error) {
+	return nil, nil
+}
+
+func (m *mockAPIKeyService) SetSigningScope(ctx context.Context, orgID, keyID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error) {
+	return nil, nil
+}
+
 func (m *mockAPIKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 	return nil
 }
@@ -200,6 +240,10 @@ func (m *mockCertRepo) Revoke(ctx context.Context, id string, reason string) err
 	return nil
 }
 
+func (m *mockCertRepo) SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error {
+	return nil
+}
+
 func (m *mockCertRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
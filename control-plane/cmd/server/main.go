@@ -4,14 +4,16 @@ package main
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -27,6 +29,7 @@ import (
 	bootstraporchestrator "github.com/Bidon15/popsigner/control-plane/internal/bootstrap/orchestrator"
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/popdeployer"
 	bootstraprepo "github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/verifier"
 	"github.com/Bidon15/popsigner/control-plane/internal/config"
 	"github.com/Bidon15/popsigner/control-plane/internal/database"
 	"github.com/Bidon15/popsigner/control-plane/internal/handler"
@@ -35,6 +38,8 @@ import (
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/address"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
@@ -62,6 +67,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
 
 	logger.Info("Starting Control Plane API",
 		slog.String("environment", cfg.Server.Environment),
@@ -94,11 +103,16 @@ func main() {
 	userRepo := repository.NewUserRepository(db.Pool())
 	sessionRepo := repository.NewSessionRepository(db.Pool())
 	orgRepo := repository.NewOrgRepository(db.Pool())
-	keyRepo := repository.NewKeyRepository(db.Pool())
+	// Key changes evict the shared key cache used by RPC gateway replicas
+	keyRepo := repository.NewCachedKeyRepository(repository.NewKeyRepository(db.Pool()), redis, 0, logger)
 	apiKeyRepo := repository.NewAPIKeyRepository(db.Pool())
 	auditRepo := repository.NewAuditRepository(db.Pool())
 	usageRepo := repository.NewUsageRepository(db.Pool())
+	// API call counts are batched in memory and flushed periodically
+	apiUsage := middleware.NewUsageAggregator(usageRepo, middleware.DefaultUsageFlushInterval)
+	apiUsage.Start()
 	certRepo := repository.NewCertificateRepository(db.Pool())
+	webhookRepo := repository.NewWebhookRepository(db.Pool())
 
 	// Initialize OpenBao client
 	baoClient := openbao.NewClient(&cfg.OpenBao)
@@ -123,13 +137,41 @@ func main() {
 
 	// Initialize services
 	oauthSvc := service.NewOAuthService(&cfg.Auth, userRepo, sessionRepo)
-	keySvc := service.NewKeyService(keyRepo, orgRepo, auditRepo, usageRepo, baoClient)
-	apiKeySvc := service.NewAPIKeyService(apiKeyRepo)
+	webhookSvc := service.NewWebhookService(webhookRepo, service.DefaultWebhookServiceConfig())
+	keySvc := service.NewKeyService(keyRepo, orgRepo, auditRepo, usageRepo, baoClient,
+		service.WithEventPublisher(webhookSvc),
+		service.WithKeyConcurrencyLimit(cfg.OpenBao.MaxConcurrentSignsPerKey, cfg.OpenBao.SignQueueTimeout),
+		service.WithSignatureCache(cfg.OpenBao.SignCacheTTL),
+		service.WithMaxSignMessageSize(cfg.OpenBao.MaxSignMessageSize),
+	)
+	namespaceSvc := service.NewNamespaceService(orgRepo)
+	auditSvc := service.NewAuditService(auditRepo, orgRepo)
+	usageSvc := service.NewUsageService(usageRepo, orgRepo, keyRepo, auditRepo)
+	apiKeySvc := service.NewAPIKeyService(apiKeyRepo, service.WithAPIKeyPrefix(cfg.Auth.APIKeyPrefix))
 	certSvc := service.NewCertificateService(certRepo, pkiAdapter, orgRepo, auditRepo)
 
+	// Tracks in-flight signs so shutdown can let them finish
+	drainer := middleware.NewDrainer()
+
+	// Enforces each organization's monthly sign quota on sign endpoints
+	planResolver := middleware.NewPlanResolver(orgRepo, middleware.DefaultPlanCacheTTL)
+	signQuotaCfg := middleware.SignQuotaConfig{
+		PlanResolver: planResolver,
+		MaxBodyBytes: cfg.Server.MaxRPCRequestSize,
+	}
+	signQuota := middleware.SignQuota(redis, signQuotaCfg)
+
 	// Initialize API handlers
-	keyHandler := handler.NewKeyHandler(keySvc)
+	keyHandler := handler.NewKeyHandler(keySvc, drainer.Track, signQuota)
+	// Key creates with an Idempotency-Key header replay their first result
+	// on retry
+	keyHandler.SetCreateMiddleware(middleware.Idempotency(redis, middleware.DefaultIdempotencyTTL))
+	namespaceHandler := handler.NewNamespaceHandler(namespaceSvc)
+	auditAPIHandler := handler.NewAuditHandler(auditSvc)
+	usageAPIHandler := handler.NewUsageHandler(usageSvc)
 	signHandler := handler.NewSignHandler(keySvc)
+	webhookHandler := handler.NewWebhookHandler(webhookSvc)
+	certificateHandler := handler.NewCertificateHandler(certSvc)
 
 	// Initialize JSON-RPC server for Ethereum signing (used by orchestrator)
 	jsonRPCServer := jsonrpc.NewServer(jsonrpc.ServerConfig{
@@ -138,19 +180,39 @@ func main() {
 		UsageRepo: usageRepo,
 		BaoClient: baoClient,
 		Logger:    logger,
+		Drainer:   drainer,
+		// Reject oversized payloads before they are parsed or signed
+		MaxRequestBodySize: cfg.Server.MaxRPCRequestSize,
 	})
 	logger.Info("JSON-RPC server initialized")
 
 	// Initialize bootstrap (deployment) repository
 	bootstrapRepo := bootstraprepo.NewPostgresRepository(db.Pool())
 
+	// Initialize contract verifier for deployed L1 contracts (opt-in)
+	var contractVerifier *verifier.ContractVerifier
+	if cfg.Verification.Enabled {
+		contractVerifier = verifier.NewContractVerifier(
+			verifier.Config{
+				APIURL: cfg.Verification.APIURL,
+				APIKey: cfg.Verification.APIKey,
+			},
+			verifier.NewDirSourceProvider(cfg.Verification.SourcesDir),
+			logger,
+		)
+		logger.Info("contract verification enabled",
+			slog.String("api_url", cfg.Verification.APIURL),
+		)
+	}
+
 	// Initialize OP Stack orchestrator for chain deployments
 	opstackOrch := opstack.NewOrchestrator(
 		bootstrapRepo,
 		&opstack.DefaultSignerFactory{},
 		opstack.NewEthClientFactory(),
 		opstack.OrchestratorConfig{
-			Logger: logger,
+			Logger:   logger,
+			Verifier: contractVerifier,
 		},
 	)
 	logger.Info("OP Stack orchestrator initialized")
@@ -179,6 +241,7 @@ func main() {
 			WorkerPath:            "internal/bootstrap/nitro/worker",
 			POPSignerMTLSEndpoint: nitroMTLSEndpoint,
 			NitroInfraRepo:        nitroInfraRepo,
+			Verifier:              contractVerifier,
 		},
 	)
 	logger.Info("Nitro orchestrator initialized",
@@ -190,12 +253,17 @@ func main() {
 	popBundleOrch := popdeployer.NewOrchestrator(
 		bootstrapRepo,
 		popdeployer.OrchestratorConfig{
-			Logger:   logger,
-			CacheDir: "/tmp/popdeployer",
-			WorkDir:  "/tmp/popdeployer/work",
+			Logger:                   logger,
+			CacheDir:                 "/tmp/popdeployer",
+			WorkDir:                  "/tmp/popdeployer/work",
+			MaxConcurrentDeployments: cfg.Bundle.MaxConcurrentDeployments,
+			DeploymentTimeout:        cfg.Bundle.DeploymentTimeout,
+			AnvilShutdownTimeout:     cfg.Bundle.AnvilShutdownTimeout,
 		},
 	)
-	logger.Info("POPKins Bundle orchestrator initialized")
+	logger.Info("POPKins Bundle orchestrator initialized",
+		slog.Int("max_concurrent_deployments", cfg.Bundle.MaxConcurrentDeployments),
+	)
 
 	// Initialize key resolver and API key manager for orchestrator
 	keyResolver := bootstraporchestrator.NewKeyServiceResolver(keySvc)
@@ -230,8 +298,44 @@ func main() {
 
 	// Initialize POPKins (chain deployment) handler
 	// Uses same session store as main dashboard for SSO
-	authSvc := service.NewAuthService(userRepo, sessionRepo, service.DefaultAuthServiceConfig())
-	orgSvc := service.NewOrgService(orgRepo, userRepo, service.DefaultOrgServiceConfig())
+	// Invitations and password reset links are emailed when SMTP is configured,
+	// otherwise the links are logged
+	var (
+		invitationSender        service.InvitationSender
+		passwordResetSender     service.PasswordResetSender
+		emailVerificationSender service.EmailVerificationSender
+	)
+	if cfg.Email.Enabled() {
+		smtpCfg := service.SMTPConfig{
+			Host:     cfg.Email.SMTPHost,
+			Port:     cfg.Email.SMTPPort,
+			Username: cfg.Email.SMTPUsername,
+			Password: cfg.Email.SMTPPassword,
+			From:     cfg.Email.From,
+		}
+		invitationSender = service.NewSMTPInvitationSender(smtpCfg)
+		passwordResetSender = service.NewSMTPPasswordResetSender(smtpCfg)
+		emailVerificationSender = service.NewSMTPEmailVerificationSender(smtpCfg)
+	} else {
+		invitationSender = service.NewLogInvitationSender(logger)
+		passwordResetSender = service.NewLogPasswordResetSender(logger)
+		emailVerificationSender = service.NewLogEmailVerificationSender(logger)
+	}
+	authCfg := service.DefaultAuthServiceConfig()
+	if cfg.Auth.SessionExpiry > 0 {
+		authCfg.SessionExpiry = cfg.Auth.SessionExpiry
+	}
+	authCfg.DashboardURL = cfg.Auth.DashboardURL
+	passwordResetRepo := repository.NewPasswordResetRepository(db.Pool())
+	emailVerificationRepo := repository.NewEmailVerificationRepository(db.Pool())
+	authSvc := service.NewAuthService(userRepo, sessionRepo, authCfg,
+		service.WithPasswordResets(passwordResetRepo, passwordResetSender),
+		service.WithEmailVerification(emailVerificationRepo, emailVerificationSender),
+	)
+	orgCfg := service.DefaultOrgServiceConfig()
+	orgCfg.InvitationBaseURL = cfg.Auth.DashboardURL
+	orgSvc := service.NewOrgService(orgRepo, userRepo, orgCfg, service.WithInvitationSender(invitationSender))
+	orgHandler := handler.NewOrgHandler(orgSvc, authSvc)
 
 	// Initialize bootstrap (deployment) handler with the orchestrator
 	deploymentHandler := bootstraphandler.NewDeploymentHandler(bootstrapRepo, unifiedOrch, orgSvc)
@@ -248,6 +352,36 @@ func main() {
 		}
 	}()
 
+	// Keep the active sessions gauge current for /metrics
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			count, err := sessionRepo.CountActive(context.Background())
+			if err != nil {
+				logger.Warn("Failed to count active sessions", slog.String("error", err.Error()))
+				continue
+			}
+			middleware.SetActiveSessions(count)
+		}
+	}()
+
+	// Purge deleted keys once their restore grace period has passed
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for ; ; <-ticker.C {
+			purged, err := keySvc.PurgeDeletedKeys(context.Background())
+			if err != nil {
+				logger.Error("Failed to purge deleted keys", slog.String("error", err.Error()))
+				continue
+			}
+			if purged > 0 {
+				logger.Info("Purged deleted keys", slog.Int64("count", purged))
+			}
+		}
+	}()
+
 	logger.Info("OAuth providers configured",
 		slog.Any("providers", oauthSvc.GetSupportedProviders()),
 	)
@@ -257,11 +391,16 @@ func main() {
 
 	// Global middleware stack
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)
+	r.Use(middleware.RealIP(trustedProxies))
 	r.Use(middleware.Logging(logger))
 	r.Use(middleware.Metrics()) // Prometheus metrics and visitor tracking
 	r.Use(chimiddleware.Recoverer)
-	r.Use(middleware.CORS())
+	r.Use(middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+	}))
 	r.Use(chimiddleware.Timeout(30 * time.Second))
 
 	// Custom 404 Not Found handler
@@ -272,7 +411,7 @@ func main() {
 
 	// Health check endpoints (no auth required)
 	r.Get("/health", healthHandler(db, redis))
-	r.Get("/ready", readyHandler(db, redis))
+	r.Get("/ready", drainer.Ready(handler.ReadyHandler(db, redis, baoClient)).ServeHTTP)
 
 	// Prometheus metrics endpoint (protect via ingress in production)
 	r.Handle("/metrics", promhttp.Handler())
@@ -288,33 +427,67 @@ func main() {
 	r.Get("/", landingPageHandler())
 	r.Get("/login", loginPageHandler())
 	r.Get("/signup", signupPageHandler())
+
+	// Email/password sign-in alongside OAuth, limited per client IP against guessing
+	passwordAuthLimit := middleware.RateLimitByKey(redis, middleware.RateLimitConfig{RequestsPerMinute: 10, BurstSize: 5}, passwordAuthRateLimitKey)
+	r.With(passwordAuthLimit).Post("/login", passwordLoginHandler(authSvc, cfg))
+	r.With(passwordAuthLimit).Post("/signup", passwordSignupHandler(authSvc, cfg))
+	r.Get("/forgot-password", forgotPasswordPageHandler())
+	r.With(passwordAuthLimit).Post("/forgot-password", forgotPasswordHandler(authSvc))
+	r.Get("/reset-password", resetPasswordPageHandler())
+	r.With(passwordAuthLimit).Post("/reset-password", resetPasswordHandler(authSvc))
+	r.Get("/verify-email", verifyEmailHandler(authSvc))
 	r.Get("/logout", logoutHandler(sessionRepo))
 	r.Post("/logout", logoutHandler(sessionRepo))
 
 	// Dashboard (protected by session check)
 	r.Get("/dashboard", dashboardHandler(sessionRepo, userRepo, orgRepo, keyRepo, auditRepo, usageRepo))
 
+	// Role checks for dashboard actions: orgRole checks the user's role in their
+	// organization, keyRole checks it in the organization owning the key in the URL.
+	orgRole := func(role models.Role) func(http.Handler) http.Handler {
+		return requireOrgRole(sessionRepo, userRepo, orgRepo, nil, role)
+	}
+	keyRole := func(role models.Role) func(http.Handler) http.Handler {
+		return requireOrgRole(sessionRepo, userRepo, orgRepo, keyRepo, role)
+	}
+	// Unverified users can browse the dashboard but not create or rotate keys
+	// or API keys
+	verifiedEmail := middleware.RequireVerifiedEmail(func(w http.ResponseWriter, r *http.Request) *models.User {
+		return getAuthenticatedUser(w, r, sessionRepo, userRepo)
+	})
+
 	// Protected dashboard pages
-	r.Get("/keys", keysListHandler(sessionRepo, userRepo, orgRepo, keyRepo))
-	r.Post("/keys", keysCreateHandler(sessionRepo, userRepo, orgRepo, keySvc))
+	r.Get("/keys", keysListHandler(sessionRepo, userRepo, orgRepo, keySvc))
+	r.With(orgRole(models.RoleOperator), verifiedEmail).Post("/keys", keysCreateHandler(sessionRepo, userRepo, orgRepo, keySvc))
 	r.Get("/keys/new", keysNewHandler(sessionRepo, userRepo))
-	r.Get("/keys/{id}", keyViewHandler(sessionRepo, userRepo, keyRepo))
-	r.Delete("/keys/{id}", keyDeleteHandler(sessionRepo, userRepo, orgRepo, keyRepo, keySvc))
-	r.Post("/keys/{id}/sign-test", keySignHandler(sessionRepo, userRepo, keyRepo, keySvc))
+	r.With(keyRole(models.RoleViewer)).Get("/keys/{id}", keyViewHandler(sessionRepo, userRepo, keyRepo))
+	r.With(keyRole(models.RoleAdmin)).Delete("/keys/{id}", keyDeleteHandler(sessionRepo, userRepo, orgRepo, keyRepo, keySvc))
+	r.With(keyRole(models.RoleOperator)).Post("/keys/{id}/sign-test", keySignHandler(sessionRepo, userRepo, keyRepo, keySvc))
+	r.With(keyRole(models.RoleAdmin), verifiedEmail).Post("/keys/{id}/rotate", keyRotateHandler(sessionRepo, userRepo, keyRepo, keySvc))
 	r.Get("/settings/api-keys", settingsAPIKeysHandler(sessionRepo, userRepo, orgRepo, apiKeyRepo))
 	r.Get("/settings/api-keys/new", settingsAPIKeysNewHandler(sessionRepo, userRepo, orgRepo))
-	r.Post("/settings/api-keys", settingsAPIKeysCreateHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
-	r.Delete("/settings/api-keys/{id}", settingsAPIKeysDeleteHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
+	r.With(orgRole(models.RoleAdmin), verifiedEmail).Post("/settings/api-keys", settingsAPIKeysCreateHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
+	r.With(orgRole(models.RoleAdmin)).Delete("/settings/api-keys/{id}", settingsAPIKeysDeleteHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
+	r.With(orgRole(models.RoleAdmin), verifiedEmail).Post("/settings/api-keys/{id}/rotate", settingsAPIKeysRotateHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
+	r.With(orgRole(models.RoleAdmin)).Get("/settings/api-keys/{id}/signing-scope", settingsAPIKeysSigningScopeHandler(sessionRepo, userRepo, orgRepo, keyRepo, apiKeySvc))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/api-keys/{id}/signing-scope", settingsAPIKeysSigningScopeUpdateHandler(sessionRepo, userRepo, orgRepo, apiKeySvc))
+	r.Get("/settings/webhooks", settingsWebhooksHandler(sessionRepo, userRepo, orgRepo, webhookSvc))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/webhooks", settingsWebhooksCreateHandler(sessionRepo, userRepo, orgRepo, webhookSvc))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/webhooks/{id}/toggle", settingsWebhooksToggleHandler(sessionRepo, userRepo, orgRepo, webhookSvc))
+	r.Get("/settings/webhooks/{id}/deliveries", settingsWebhooksDeliveriesHandler(sessionRepo, userRepo, orgRepo, webhookSvc))
+	r.With(orgRole(models.RoleAdmin)).Delete("/settings/webhooks/{id}", settingsWebhooksDeleteHandler(sessionRepo, userRepo, orgRepo, webhookSvc))
 	r.Get("/settings/profile", settingsProfileHandler(sessionRepo, userRepo))
+	r.Post("/settings/profile/verify-email", resendVerificationHandler(sessionRepo, userRepo, authSvc))
 
 	// Certificate management routes
 	r.Get("/settings/certificates", settingsCertificatesHandler(sessionRepo, userRepo, orgRepo, certSvc))
 	r.Get("/settings/certificates/new", settingsCertificatesNewHandler(sessionRepo, userRepo, orgRepo))
 	r.Get("/settings/certificates/ca", settingsCertificatesCAHandler(sessionRepo, userRepo, orgRepo, certSvc))
 	r.Get("/settings/certificates/{id}/download", settingsCertificatesDownloadHandler(sessionRepo, userRepo, orgRepo, certSvc))
-	r.Post("/settings/certificates", settingsCertificatesCreateHandler(sessionRepo, userRepo, orgRepo, certSvc))
-	r.Post("/settings/certificates/{id}/revoke", settingsCertificatesRevokeHandler(sessionRepo, userRepo, orgRepo, certSvc))
-	r.Delete("/settings/certificates/{id}", settingsCertificatesDeleteHandler(sessionRepo, userRepo, orgRepo, certSvc))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/certificates", settingsCertificatesCreateHandler(sessionRepo, userRepo, orgRepo, certSvc))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/certificates/{id}/revoke", settingsCertificatesRevokeHandler(sessionRepo, userRepo, orgRepo, certSvc))
+	r.With(orgRole(models.RoleAdmin)).Delete("/settings/certificates/{id}", settingsCertificatesDeleteHandler(sessionRepo, userRepo, orgRepo, certSvc))
 
 	r.Get("/docs", docsHandler(sessionRepo, userRepo))
 
@@ -326,6 +499,14 @@ func main() {
 
 	// Team management
 	r.Get("/settings/team", settingsTeamHandler(sessionRepo, userRepo, orgRepo))
+	r.Get("/settings/team/invite", settingsTeamInviteModalHandler(sessionRepo, userRepo))
+	r.With(orgRole(models.RoleAdmin)).Post("/settings/team/invite", settingsTeamInviteHandler(sessionRepo, userRepo, orgRepo, orgSvc))
+
+	// Invitation links (sent by email) land here
+	r.Get("/invites/{token}", acceptInviteHandler(sessionRepo, userRepo, orgSvc))
+
+	// Organization switcher
+	r.Post("/orgs/switch", orgSwitchHandler(sessionRepo, userRepo, orgRepo))
 
 	// POPKins - Chain deployment platform (separate product)
 	// In production: popkins.popsigner.com
@@ -341,38 +522,63 @@ func main() {
 
 	// API v1 routes
 	r.Route("/v1", func(r chi.Router) {
-		// Rate limiting for API routes
-		r.Use(middleware.RateLimit(redis, middleware.DefaultRateLimitConfig()))
-
-		// Public endpoints (no auth)
-		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-			response.OK(w, map[string]string{
-				"name":    "BanhBaoRing Control Plane API",
-				"version": "1.0.0",
+		r.Group(func(r chi.Router) {
+			// Rate limiting for unauthenticated and session routes
+			r.Use(middleware.RateLimit(redis, middleware.DefaultRateLimitConfig()))
+
+			// Public endpoints (no auth)
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				response.OK(w, map[string]string{
+					"name":    "BanhBaoRing Control Plane API",
+					"version": "1.0.0",
+				})
 			})
+
+			// Organization and invitation APIs (session authentication)
+			r.Mount("/organizations", orgHandler.Routes())
+			r.Mount("/invitations", orgHandler.InvitationRoutes())
 		})
 
 		// Protected API routes (require API key authentication)
 		r.Group(func(r chi.Router) {
 			// API key authentication middleware
 			r.Use(middleware.APIKeyAuth(apiKeySvc))
+			// Rate limiting by the organization's plan
+			r.Use(middleware.PlanRateLimit(redis, planResolver, middleware.DefaultPlanRateLimitConfig()))
+			// Resolve the key owner's role in the org for RequireRole checks
+			r.Use(middleware.ResolveOrgRole(orgRepo))
 			// Track API usage for billing/analytics
-			r.Use(middleware.TrackAPIUsage(usageRepo))
+			r.Use(middleware.TrackAPIUsage(apiUsage))
 
 			// Keys API - CRUD and signing operations
 			r.Mount("/keys", keyHandler.Routes())
 
 			// Batch signing endpoint
-			r.Mount("/sign", signHandler.Routes())
+			r.With(drainer.Track, signQuota).Mount("/sign", signHandler.Routes())
+
+			// Signature verification - checks a signature against any public key
+			r.Post("/verify", signHandler.Verify)
 
 			// JSON-RPC endpoint for Ethereum signing (eth_signTransaction, eth_sign, personal_sign)
-			r.Mount("/rpc", jsonRPCServer)
+			r.With(middleware.RequireRole(models.RoleOperator), middleware.RPCSignQuota(redis, signQuotaCfg)).Mount("/rpc", jsonRPCServer)
 
 			// Deployments API - chain deployment management
 			r.Mount("/deployments", deploymentHandler.Routes())
 
-			// Namespaces API - list namespaces for the authenticated org
-			r.Get("/namespaces", namespacesListHandler(orgRepo))
+			// Namespaces API - CRUD for the authenticated org's namespaces
+			r.Mount("/namespaces", namespaceHandler.Routes())
+
+			// Audit API - query the org's audit trail
+			r.Mount("/audit", auditAPIHandler.Routes())
+
+			// Usage API - consumption against plan limits
+			r.Mount("/usage", usageAPIHandler.Routes())
+
+			// Webhooks API - event subscriptions and delivery history
+			r.Mount("/webhooks", webhookHandler.Routes())
+
+			// Certificates API - mTLS client certificates, including CSR enrollment
+			r.Mount("/certificates", certificateHandler.Routes())
 		})
 	})
 
@@ -409,10 +615,20 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Fail readiness and let in-flight signs finish before closing connections
+	if err := drainer.Drain(ctx); err != nil {
+		logger.Error("Timed out draining in-flight signs", slog.Any("error", err))
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server shutdown error: %v", err)
 	}
 
+	// Write API call counts buffered since the last flush
+	if err := apiUsage.Close(ctx); err != nil {
+		logger.Error("Failed to flush API usage", slog.Any("error", err))
+	}
+
 	logger.Info("Server stopped gracefully")
 }
 
@@ -425,34 +641,6 @@ func healthHandler(db *database.Postgres, redis *database.Redis) http.HandlerFun
 	}
 }
 
-// readyHandler returns a readiness check that verifies database and Redis connections.
-func readyHandler(db *database.Postgres, redis *database.Redis) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		// Check database connection
-		if err := db.Ping(ctx); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"error","component":"database"}`))
-			return
-		}
-
-		// Check Redis connection
-		if err := redis.Ping(ctx); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"error","component":"redis"}`))
-			return
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ok","database":"connected","redis":"connected"}`))
-	}
-}
-
 // TODO: statusPageHandler is disabled until pages.StatusPage template is created
 // func statusPageHandler(db *database.Postgres, redis *database.Redis, baoClient *openbao.Client) http.HandlerFunc { ... }
 
@@ -469,7 +657,8 @@ func loginPageHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		errorMsg := r.URL.Query().Get("error")
-		pages.LoginPage(errorMsg).Render(r.Context(), w)
+		successMsg := r.URL.Query().Get("success")
+		pages.LoginPage(errorMsg, successMsg).Render(r.Context(), w)
 	}
 }
 
@@ -486,6 +675,240 @@ func signupPageHandler() http.HandlerFunc {
 	}
 }
 
+// passwordAuthRateLimitKey rate limits password sign-in attempts by client IP,
+// separately from the API's rate limit.
+func passwordAuthRateLimitKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "password_auth:" + host
+}
+
+// passwordLoginHandler signs a user in with email and password and starts a
+// session, the same way the OAuth callback does.
+func passwordLoginHandler(authSvc service.AuthService, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := strings.TrimSpace(r.FormValue("email"))
+		password := r.FormValue("password")
+		if email == "" || password == "" {
+			http.Redirect(w, r, "/login?error="+url.QueryEscape("Email and password are required"), http.StatusFound)
+			return
+		}
+
+		user, sessionID, err := authSvc.Login(r.Context(), email, password)
+		if err != nil {
+			var apiErr *apierrors.APIError
+			if !errors.As(err, &apiErr) {
+				slog.Error("Password login failed", slog.String("error", err.Error()))
+			}
+			http.Redirect(w, r, "/login?error="+url.QueryEscape("Invalid email or password"), http.StatusFound)
+			return
+		}
+
+		slog.Info("User authenticated via password", slog.String("user_id", user.ID.String()))
+
+		setSessionCookie(w, sessionID, cfg)
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	}
+}
+
+// passwordSignupHandler creates an account with email and password and signs
+// the new user in. The default organization is created on the first dashboard visit.
+func passwordSignupHandler(authSvc service.AuthService, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.FormValue("name"))
+		email := strings.TrimSpace(r.FormValue("email"))
+		password := r.FormValue("password")
+
+		fail := func(msg string) {
+			q := url.Values{"error": {msg}, "name": {name}, "email": {email}}
+			http.Redirect(w, r, "/signup?"+q.Encode(), http.StatusFound)
+		}
+
+		if name == "" || email == "" || password == "" {
+			fail("All fields are required")
+			return
+		}
+		if password != r.FormValue("password_confirm") {
+			fail("Passwords do not match")
+			return
+		}
+
+		if _, err := authSvc.Register(r.Context(), service.RegisterRequest{
+			Email:    email,
+			Password: password,
+			Name:     name,
+		}); err != nil {
+			fail(passwordAuthErrorMessage(err, "Could not create account"))
+			return
+		}
+
+		user, sessionID, err := authSvc.Login(r.Context(), email, password)
+		if err != nil {
+			slog.Error("Login after signup failed", slog.String("error", err.Error()))
+			http.Redirect(w, r, "/login?success="+url.QueryEscape("Account created. Please log in."), http.StatusFound)
+			return
+		}
+
+		slog.Info("User signed up with password", slog.String("user_id", user.ID.String()))
+
+		setSessionCookie(w, sessionID, cfg)
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	}
+}
+
+// forgotPasswordPageHandler serves the password reset request page.
+func forgotPasswordPageHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		pages.ForgotPasswordPage(r.URL.Query().Get("error"), r.URL.Query().Get("success")).Render(r.Context(), w)
+	}
+}
+
+// forgotPasswordHandler emails a password reset link. It reports success
+// whether or not the account exists, so emails can't be enumerated.
+func forgotPasswordHandler(authSvc service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := strings.TrimSpace(r.FormValue("email"))
+		if email == "" {
+			http.Redirect(w, r, "/forgot-password?error="+url.QueryEscape("Email is required"), http.StatusFound)
+			return
+		}
+
+		if _, err := authSvc.RequestPasswordReset(r.Context(), email); err != nil {
+			slog.Error("Password reset request failed", slog.String("error", err.Error()))
+		}
+
+		msg := "If an account exists with that email, we've sent a password reset link."
+		http.Redirect(w, r, "/forgot-password?success="+url.QueryEscape(msg), http.StatusFound)
+	}
+}
+
+// resetPasswordPageHandler serves the form for choosing a new password.
+func resetPasswordPageHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("token")
+		if token == "" {
+			http.Redirect(w, r, "/forgot-password?error="+url.QueryEscape("Invalid or expired reset link"), http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		pages.ResetPasswordPage(token, r.URL.Query().Get("error")).Render(r.Context(), w)
+	}
+}
+
+// resetPasswordHandler sets a new password from a reset link.
+func resetPasswordHandler(authSvc service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.FormValue("token")
+		password := r.FormValue("password")
+		if token == "" {
+			http.Redirect(w, r, "/forgot-password?error="+url.QueryEscape("Invalid or expired reset link"), http.StatusFound)
+			return
+		}
+
+		retry := func(msg string) {
+			q := url.Values{"token": {token}, "error": {msg}}
+			http.Redirect(w, r, "/reset-password?"+q.Encode(), http.StatusFound)
+		}
+		if password != r.FormValue("password_confirm") {
+			retry("Passwords do not match")
+			return
+		}
+
+		if err := authSvc.ResetPassword(r.Context(), token, password); err != nil {
+			var apiErr *apierrors.APIError
+			if errors.As(err, &apiErr) && apiErr.Code == "validation_error" {
+				retry(passwordAuthErrorMessage(err, "Invalid password"))
+				return
+			}
+			if apiErr == nil {
+				slog.Error("Password reset failed", slog.String("error", err.Error()))
+			}
+			http.Redirect(w, r, "/forgot-password?error="+url.QueryEscape("Invalid or expired reset link"), http.StatusFound)
+			return
+		}
+
+		http.Redirect(w, r, "/login?success="+url.QueryEscape("Password updated. Please log in."), http.StatusFound)
+	}
+}
+
+// verifyEmailHandler verifies a user's email from the link in the verification email.
+func verifyEmailHandler(authSvc service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := authSvc.VerifyEmail(r.Context(), r.URL.Query().Get("token")); err != nil {
+			var apiErr *apierrors.APIError
+			if !errors.As(err, &apiErr) {
+				slog.Error("Email verification failed", slog.String("error", err.Error()))
+			}
+			msg := "Invalid or expired verification link. Sign in and resend it from your profile."
+			http.Redirect(w, r, "/login?error="+url.QueryEscape(msg), http.StatusFound)
+			return
+		}
+
+		// Signed-in users see the verified badge on their profile
+		if _, err := r.Cookie(sessionCookieName); err == nil {
+			http.Redirect(w, r, "/settings/profile", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/login?success="+url.QueryEscape("Email verified. Please log in."), http.StatusFound)
+	}
+}
+
+// resendVerificationHandler emails the signed-in user a new verification link.
+// The outcome is shown as a toast.
+func resendVerificationHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, authSvc service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		if err := authSvc.SendEmailVerification(r.Context(), user.ID); err != nil {
+			apiErr := apierrors.AsAPIError(err)
+			if apiErr.StatusCode >= http.StatusInternalServerError {
+				slog.Error("Failed to resend verification email", slog.String("error", err.Error()))
+			}
+			w.Header().Set("X-Toast-Message", apiErr.Message)
+			w.Header().Set("X-Toast-Variant", "error")
+			w.WriteHeader(apiErr.StatusCode)
+			return
+		}
+
+		w.Header().Set("X-Toast-Message", "Verification email sent to "+user.Email)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// passwordAuthErrorMessage returns the message to show for a failed signup or
+// password reset: validation and conflict errors are shown as-is.
+func passwordAuthErrorMessage(err error, fallback string) string {
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) {
+		slog.Error("Password auth failed", slog.String("error", err.Error()))
+		return fallback
+	}
+	if msg, ok := apiErr.Details.(map[string]string); ok && msg["error"] != "" {
+		return msg["error"]
+	}
+	return apiErr.Message
+}
+
+// setSessionCookie sets the dashboard session cookie, shared across all subdomains.
+func setSessionCookie(w http.ResponseWriter, sessionID string, cfg *config.Config) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		Domain:   ".popsigner.com", // Share session across all subdomains
+		MaxAge:   int(cfg.Auth.SessionExpiry.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
 // oauthRedirectHandler redirects the user to the OAuth provider.
 func oauthRedirectHandler(oauthSvc service.OAuthService, provider string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -571,16 +994,7 @@ func oauthCallbackHandler(oauthSvc service.OAuthService, provider string, cfg *c
 		)
 
 		// Set the session cookie (domain shared across all subdomains)
-		http.SetCookie(w, &http.Cookie{
-			Name:     sessionCookieName,
-			Value:    sessionID,
-			Path:     "/",
-			Domain:   ".popsigner.com", // Share session across all subdomains
-			MaxAge:   int(cfg.Auth.SessionExpiry.Seconds()),
-			HttpOnly: true,
-			Secure:   true,
-			SameSite: http.SameSiteLaxMode,
-		})
+		setSessionCookie(w, sessionID, cfg)
 
 		// Clear the OAuth state cookie
 		http.SetCookie(w, &http.Cookie{
@@ -666,16 +1080,16 @@ func dashboardHandler(sessionRepo repository.SessionRepository, userRepo reposit
 		keyCount := 0
 		signatureLimit := 1000 // Default free tier
 		var orgID uuid.UUID
-		orgs, err := orgRepo.ListUserOrgs(r.Context(), user.ID)
-		if err == nil && len(orgs) > 0 {
-			orgID = orgs[0].ID
-			// Get keys for the first org
+		org, err := service.NewCurrentOrgService(orgRepo, sessionRepo).Current(r.Context(), session)
+		if err == nil && org != nil {
+			orgID = org.ID
+			// Get keys for the current org
 			keys, err := keyRepo.ListByOrg(r.Context(), orgID)
 			if err == nil {
 				keyCount = len(keys)
 			}
 			// Get plan limits
-			limits := models.PlanLimitsMap[orgs[0].Plan]
+			limits := models.PlanLimitsMap[org.Plan]
 			signatureLimit = int(limits.SignaturesPerMonth)
 		}
 
@@ -762,6 +1176,54 @@ func getAuthenticatedUser(w http.ResponseWriter, r *http.Request, sessionRepo re
 	return user
 }
 
+// requireOrgRole returns a middleware that checks the session user has at least
+// the given role. With a key repository the organization is the one owning the
+// key in the {id} URL parameter; otherwise it is the user's organization.
+func requireOrgRole(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keyRepo repository.KeyRepository, role models.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+			if user == nil {
+				return
+			}
+
+			var orgID uuid.UUID
+			if keyRepo != nil {
+				keyUUID, err := uuid.Parse(chi.URLParam(r, "id"))
+				if err != nil {
+					http.Error(w, "Invalid key ID", http.StatusBadRequest)
+					return
+				}
+				key, err := keyRepo.GetByID(r.Context(), keyUUID)
+				if err != nil || key == nil {
+					http.Error(w, "Key not found", http.StatusNotFound)
+					return
+				}
+				orgID = key.OrgID
+			} else {
+				org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+				if err != nil || org == nil {
+					http.Error(w, "No organization found", http.StatusBadRequest)
+					return
+				}
+				orgID = org.ID
+			}
+
+			member, err := orgRepo.GetMember(r.Context(), orgID, user.ID)
+			if err != nil {
+				http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
+				return
+			}
+			if member == nil || models.RoleLevel(member.Role) < models.RoleLevel(role) {
+				http.Error(w, "This action requires the "+string(role)+" role", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // buildDashboardData creates the common dashboard data from a user.
 func buildDashboardData(user *models.User, activePath string) layouts.DashboardData {
 	name := user.Email
@@ -782,8 +1244,10 @@ func buildDashboardData(user *models.User, activePath string) layouts.DashboardD
 	}
 }
 
-// keysListHandler serves the keys list page.
-func keysListHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keyRepo repository.KeyRepository) http.HandlerFunc {
+// keysListHandler serves the keys list page. The q, namespace, network,
+// sort, page and size query parameters filter and page the list; HTMX
+// requests targeting #keys-list get just the list.
+func keysListHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keySvc service.KeyService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
 		if user == nil {
@@ -791,7 +1255,7 @@ func keysListHandler(sessionRepo repository.SessionRepository, userRepo reposito
 		}
 
 		// Ensure user has an org
-		org, _ := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, _ := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 
 		dashData := buildDashboardData(user, "/keys")
 		if org != nil {
@@ -799,25 +1263,53 @@ func keysListHandler(sessionRepo repository.SessionRepository, userRepo reposito
 			dashData.OrgPlan = string(org.Plan)
 		}
 
+		query := r.URL.Query()
+		filter := service.KeyPageFilter{
+			Search: query.Get("q"),
+			Sort:   models.KeySort(query.Get("sort")),
+		}
+		filter.Page, _ = strconv.Atoi(query.Get("page"))
+		filter.Size, _ = strconv.Atoi(query.Get("size"))
+		if id, err := uuid.Parse(query.Get("namespace")); err == nil {
+			filter.NamespaceID = &id
+		}
+		if nt := models.NetworkType(query.Get("network")); nt.Valid() {
+			filter.NetworkType = &nt
+		}
+
 		// Fetch keys and namespaces
-		var keys []*models.Key
+		keyPage := &service.KeyPage{Page: 1, Size: service.DefaultKeyPageSize, Sort: models.KeySortCreated}
 		var namespaces []*models.Namespace
 		if org != nil {
-			keys, _ = keyRepo.ListByOrg(r.Context(), org.ID)
+			if p, err := keySvc.ListByOrgPaged(r.Context(), org.ID, filter); err == nil {
+				keyPage = p
+			}
 			namespaces, _ = orgRepo.ListNamespaces(r.Context(), org.ID)
 		}
 
 		data := pages.KeysPageData{
-			UserName:   dashData.UserName,
-			UserEmail:  dashData.UserEmail,
-			AvatarURL:  dashData.AvatarURL,
-			OrgName:    dashData.OrgName,
-			OrgPlan:    dashData.OrgPlan,
-			Keys:       keys,
-			Namespaces: namespaces,
+			UserName:      dashData.UserName,
+			UserEmail:     dashData.UserEmail,
+			AvatarURL:     dashData.AvatarURL,
+			OrgName:       dashData.OrgName,
+			OrgPlan:       dashData.OrgPlan,
+			Keys:          keyPage.Keys,
+			Namespaces:    namespaces,
+			SearchQuery:   filter.Search,
+			NamespaceID:   query.Get("namespace"),
+			NetworkFilter: query.Get("network"),
+			Sort:          string(keyPage.Sort),
+			Page:          keyPage.Page,
+			PageSize:      keyPage.Size,
+			Total:         keyPage.Total,
+			Pages:         keyPage.Pages(),
 		}
 
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if r.Header.Get("HX-Request") == "true" && r.Header.Get("HX-Target") == "keys-list" {
+			pages.KeysListPanel(data).Render(r.Context(), w)
+			return
+		}
 		pages.KeysListPage(data).Render(r.Context(), w)
 	}
 }
@@ -874,21 +1366,21 @@ func keysCreateHandler(sessionRepo repository.SessionRepository, userRepo reposi
 		}
 
 		// Ensure user has an org and get default namespace
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 			w.Write([]byte(`<div class="p-4 bg-red-500/20 border border-red-500/50 rounded-xl text-red-400">Failed to get organization</div>`))
 			return
 		}
 
-		// Get default namespace
-		namespaces, _ := orgRepo.ListNamespaces(r.Context(), org.ID)
-		if len(namespaces) == 0 {
+		// Get default namespace, creating it for orgs that have none
+		defaultNS, err := service.NewNamespaceService(orgRepo).EnsureDefault(r.Context(), org.ID)
+		if err != nil {
+			slog.Error("Failed to get default namespace", slog.String("org_id", org.ID.String()), slog.String("error", err.Error()))
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
-			w.Write([]byte(`<div class="p-4 bg-red-500/20 border border-red-500/50 rounded-xl text-red-400">No namespace found</div>`))
+			w.Write([]byte(`<div class="p-4 bg-red-500/20 border border-red-500/50 rounded-xl text-red-400">Failed to get namespace</div>`))
 			return
 		}
-		defaultNS := namespaces[0]
 
 		// Create key via KeyService
 		key, err := keySvc.Create(r.Context(), service.CreateKeyRequest{
@@ -1061,6 +1553,51 @@ func keySignHandler(sessionRepo repository.SessionRepository, userRepo repositor
 	}
 }
 
+// keyRotateHandler handles rotating a key from the detail page.
+func keyRotateHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, keyRepo repository.KeyRepository, keySvc service.KeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		keyUUID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid key ID", http.StatusBadRequest)
+			return
+		}
+
+		key, err := keyRepo.GetByID(r.Context(), keyUUID)
+		if err != nil || key == nil {
+			http.Error(w, "Key not found", http.StatusNotFound)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+
+		opts := service.RotateKeyOptions{
+			AllowPreviousVersionSigning: r.FormValue("allow_previous_version_signing") == "on",
+		}
+		if _, err := keySvc.Rotate(r.Context(), key.OrgID, key.ID, opts); err != nil {
+			slog.Error("Failed to rotate key", slog.String("error", err.Error()))
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(fmt.Sprintf(`<div class="fixed bottom-4 right-4 z-50 flex items-center gap-3 px-4 py-3 bg-black border border-[#FF3333] text-[#FF3333] min-w-[200px] font-mono uppercase" x-data="{ show: true }" x-show="show" x-init="setTimeout(() => { show = false; setTimeout(() => $el.remove(), 200) }, 5000)" x-transition><span>✗</span><span class="text-sm font-medium">%s</span></div>`, err.Error())))
+			return
+		}
+
+		slog.Info("Key rotated successfully",
+			slog.String("user_id", user.ID.String()),
+			slog.String("key_id", key.ID.String()),
+		)
+
+		w.Header().Set("HX-Refresh", "true")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 // keyDeleteHandler handles deleting a key.
 func keyDeleteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keyRepo repository.KeyRepository, keySvc service.KeyService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1101,7 +1638,7 @@ func keyDeleteHandler(sessionRepo repository.SessionRepository, userRepo reposit
 		)
 
 		// Get org for the keys list
-		org, _ := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, _ := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 
 		dashData := buildDashboardData(user, "/keys")
 		if org != nil {
@@ -1141,7 +1678,7 @@ func settingsAPIKeysHandler(sessionRepo repository.SessionRepository, userRepo r
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			slog.Error("Failed to get/create org for API keys page", slog.String("error", err.Error()))
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
@@ -1194,7 +1731,7 @@ func settingsAPIKeysNewHandler(sessionRepo repository.SessionRepository, userRep
 		}
 
 		// Verify user has an org
-		_, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		_, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil {
 			http.Error(w, "Session expired or no organization", http.StatusUnauthorized)
 			return
@@ -1214,7 +1751,7 @@ func settingsAPIKeysCreateHandler(sessionRepo repository.SessionRepository, user
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 			pages.APIKeyCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
@@ -1242,10 +1779,21 @@ func settingsAPIKeysCreateHandler(sessionRepo repository.SessionRepository, user
 			return
 		}
 
-		// Create the API key
+		// Create the API key, optionally restricted to a comma or space separated IP allowlist
 		req := service.CreateAPIKeyRequest{
-			Name:   name,
-			Scopes: scopes,
+			Name:         name,
+			Scopes:       scopes,
+			AllowedCIDRs: strings.Fields(strings.ReplaceAll(r.FormValue("allowed_ips"), ",", " ")),
+			UserID:       &user.ID,
+		}
+		if expires := r.FormValue("expires"); expires != "" {
+			days, ok := apiKeyExpiryDays[expires]
+			if !ok {
+				w.Header().Set("Content-Type", "text/html; charset=utf-8")
+				pages.APIKeyCreateError("Invalid expiration").Render(r.Context(), w)
+				return
+			}
+			req.ExpiresInDays = &days
 		}
 		apiKey, rawKey, err := apiKeySvc.Create(r.Context(), org.ID, req)
 		if err != nil {
@@ -1267,23 +1815,186 @@ func settingsAPIKeysCreateHandler(sessionRepo repository.SessionRepository, user
 	}
 }
 
-// settingsAPIKeysDeleteHandler handles revoking an API key.
-func settingsAPIKeysDeleteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, apiKeySvc service.APIKeyService) http.HandlerFunc {
+// apiKeyExpiryDays maps the expiration options of the create API key form to
+// key lifetimes in days.
+var apiKeyExpiryDays = map[string]int{"30d": 30, "90d": 90, "1y": 365}
+
+// settingsAPIKeysRotateHandler replaces an API key with a new one. The old key
+// keeps working for the default grace period so clients can switch over.
+func settingsAPIKeysRotateHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, apiKeySvc service.APIKeyService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
 		if user == nil {
 			return
 		}
 
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
-			http.Error(w, "No organization found", http.StatusBadRequest)
+			pages.APIKeyCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
 			return
 		}
 
-		keyID := chi.URLParam(r, "id")
-		if keyID == "" {
+		keyUUID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			pages.APIKeyCreateError("Invalid API key ID").Render(r.Context(), w)
+			return
+		}
+
+		rotation, err := apiKeySvc.Rotate(r.Context(), org.ID, keyUUID, service.DefaultAPIKeyRotationGrace)
+		if err != nil {
+			slog.Error("Failed to rotate API key", slog.String("error", err.Error()))
+			pages.APIKeyCreateError("Failed to rotate API key: "+err.Error()).Render(r.Context(), w)
+			return
+		}
+
+		slog.Info("API key rotated",
+			slog.String("user_id", user.ID.String()),
+			slog.String("org_id", org.ID.String()),
+			slog.String("api_key_id", keyUUID.String()),
+			slog.String("successor_id", rotation.Key.ID.String()),
+		)
+
+		// Show the successor's raw key (only shown once)
+		pages.APIKeyRotatedSuccess(rotation.RawKey, rotation.Key.KeyPrefix, *rotation.Previous.ExpiresAt).Render(r.Context(), w)
+	}
+}
+
+// settingsAPIKeysSigningScopeHandler returns the modal for choosing the keys
+// and namespaces an API key may sign with.
+func settingsAPIKeysSigningScopeHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keyRepo repository.KeyRepository, apiKeySvc service.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			pages.APIKeyCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
+			return
+		}
+
+		keyUUID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			pages.APIKeyCreateError("Invalid API key ID").Render(r.Context(), w)
+			return
+		}
+
+		apiKey, err := apiKeySvc.Get(r.Context(), org.ID, keyUUID)
+		if err != nil {
+			pages.APIKeyCreateError("API key not found").Render(r.Context(), w)
+			return
+		}
+
+		namespaces, err := orgRepo.ListNamespaces(r.Context(), org.ID)
+		if err != nil {
+			slog.Error("Failed to list namespaces", slog.String("error", err.Error()))
+			pages.APIKeyCreateError("Failed to load namespaces").Render(r.Context(), w)
+			return
+		}
+		keys, err := keyRepo.ListByOrg(r.Context(), org.ID)
+		if err != nil {
+			slog.Error("Failed to list keys", slog.String("error", err.Error()))
+			pages.APIKeyCreateError("Failed to load keys").Render(r.Context(), w)
+			return
+		}
+
+		pages.APIKeySigningScopeModal(apiKey, namespaces, keys).Render(r.Context(), w)
+	}
+}
+
+// settingsAPIKeysSigningScopeUpdateHandler saves the keys and namespaces an
+// API key may sign with.
+func settingsAPIKeysSigningScopeUpdateHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, apiKeySvc service.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			pages.APIKeyCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
+			return
+		}
+
+		keyUUID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			pages.APIKeyCreateError("Invalid API key ID").Render(r.Context(), w)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			pages.APIKeyCreateError("Invalid form data").Render(r.Context(), w)
+			return
+		}
+		keyIDs, err := parseUUIDs(r.Form["key_ids"])
+		if err != nil {
+			pages.APIKeyCreateError("Invalid key ID").Render(r.Context(), w)
+			return
+		}
+		namespaceIDs, err := parseUUIDs(r.Form["namespace_ids"])
+		if err != nil {
+			pages.APIKeyCreateError("Invalid namespace ID").Render(r.Context(), w)
+			return
+		}
+
+		if _, err := apiKeySvc.SetSigningScope(r.Context(), org.ID, keyUUID, keyIDs, namespaceIDs); err != nil {
+			slog.Error("Failed to update API key signing scope", slog.String("error", err.Error()))
+			pages.APIKeyCreateError("Failed to update signing scope: "+err.Error()).Render(r.Context(), w)
+			return
+		}
+
+		slog.Info("API key signing scope updated",
+			slog.String("user_id", user.ID.String()),
+			slog.String("api_key_id", keyUUID.String()),
+			slog.Int("keys", len(keyIDs)),
+			slog.Int("namespaces", len(namespaceIDs)),
+		)
+
+		w.Header().Set("HX-Trigger", "modal-close")
+		w.Header().Set("HX-Refresh", "true")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// parseUUIDs parses a list of form values as UUIDs.
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// settingsAPIKeysDeleteHandler handles revoking an API key.
+func settingsAPIKeysDeleteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, apiKeySvc service.APIKeyService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		// Ensure user has an org
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "No organization found", http.StatusBadRequest)
+			return
+		}
+
+		keyID := chi.URLParam(r, "id")
+		if keyID == "" {
 			http.Error(w, "API key ID required", http.StatusBadRequest)
 			return
 		}
@@ -1313,6 +2024,208 @@ func settingsAPIKeysDeleteHandler(sessionRepo repository.SessionRepository, user
 	}
 }
 
+// settingsWebhooksHandler serves the webhooks settings page.
+func settingsWebhooksHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, webhookSvc service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		// Ensure user has an org
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
+			return
+		}
+
+		webhooks, err := webhookSvc.List(r.Context(), org.ID)
+		if err != nil {
+			slog.Error("Failed to list webhooks",
+				slog.String("error", err.Error()),
+				slog.String("org_id", org.ID.String()),
+			)
+			webhooks = []*models.Webhook{}
+		}
+
+		dashData := buildDashboardData(user, "/settings/webhooks")
+		dashData.OrgName = org.Name
+		dashData.OrgPlan = string(org.Plan)
+
+		data := pages.WebhooksPageData{
+			DashboardData: layouts.DashboardData{
+				UserName:   dashData.UserName,
+				UserEmail:  dashData.UserEmail,
+				AvatarURL:  dashData.AvatarURL,
+				OrgName:    dashData.OrgName,
+				OrgPlan:    dashData.OrgPlan,
+				ActivePath: dashData.ActivePath,
+			},
+			Webhooks: webhooks,
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		pages.SettingsWebhooksPage(data).Render(r.Context(), w)
+	}
+}
+
+// settingsWebhooksCreateHandler handles registering a new webhook endpoint.
+func settingsWebhooksCreateHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, webhookSvc service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			pages.WebhookCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			pages.WebhookCreateError("Failed to parse form").Render(r.Context(), w)
+			return
+		}
+
+		events := make([]models.WebhookEvent, 0, len(r.Form["events"]))
+		for _, event := range r.Form["events"] {
+			events = append(events, models.WebhookEvent(event))
+		}
+
+		webhook, err := webhookSvc.Create(r.Context(), org.ID, service.CreateWebhookRequest{
+			URL:    r.FormValue("url"),
+			Events: events,
+		})
+		if err != nil {
+			pages.WebhookCreateError(err.Error()).Render(r.Context(), w)
+			return
+		}
+
+		slog.Info("Webhook created",
+			slog.String("user_id", user.ID.String()),
+			slog.String("org_id", org.ID.String()),
+			slog.String("webhook_id", webhook.ID.String()),
+		)
+
+		// Show the signing secret (only shown once)
+		pages.WebhookCreatedSuccess(webhook.Secret).Render(r.Context(), w)
+	}
+}
+
+// settingsWebhooksToggleHandler enables or disables a webhook.
+func settingsWebhooksToggleHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, webhookSvc service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "No organization found", http.StatusBadRequest)
+			return
+		}
+
+		webhookID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := webhookSvc.Get(r.Context(), org.ID, webhookID)
+		if err != nil {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+
+		enabled := !webhook.Enabled
+		if _, err := webhookSvc.Update(r.Context(), org.ID, webhookID, service.UpdateWebhookRequest{Enabled: &enabled}); err != nil {
+			slog.Error("Failed to update webhook", slog.String("error", err.Error()))
+			http.Error(w, "Failed to update webhook", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("HX-Refresh", "true")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// settingsWebhooksDeliveriesHandler returns the delivery log modal for a webhook.
+func settingsWebhooksDeliveriesHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, webhookSvc service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "No organization found", http.StatusBadRequest)
+			return
+		}
+
+		webhookID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+			return
+		}
+
+		webhook, err := webhookSvc.Get(r.Context(), org.ID, webhookID)
+		if err != nil {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+
+		deliveries, err := webhookSvc.GetDeliveries(r.Context(), org.ID, webhookID, 50)
+		if err != nil {
+			slog.Error("Failed to list webhook deliveries", slog.String("error", err.Error()))
+			deliveries = []*models.WebhookDelivery{}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		pages.WebhookDeliveriesModal(webhook, deliveries).Render(r.Context(), w)
+	}
+}
+
+// settingsWebhooksDeleteHandler handles deleting a webhook.
+func settingsWebhooksDeleteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, webhookSvc service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "No organization found", http.StatusBadRequest)
+			return
+		}
+
+		webhookID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+			return
+		}
+
+		if err := webhookSvc.Delete(r.Context(), org.ID, webhookID); err != nil {
+			slog.Error("Failed to delete webhook", slog.String("error", err.Error()))
+			http.Error(w, "Failed to delete webhook", http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Webhook deleted",
+			slog.String("user_id", user.ID.String()),
+			slog.String("webhook_id", webhookID.String()),
+		)
+
+		w.Header().Set("HX-Refresh", "true")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 // settingsProfileHandler serves the profile settings page.
 func settingsProfileHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1380,7 +2293,7 @@ func settingsCertificatesHandler(sessionRepo repository.SessionRepository, userR
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			slog.Error("Failed to get/create org for certificates page", slog.String("error", err.Error()))
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
@@ -1430,7 +2343,7 @@ func settingsCertificatesNewHandler(sessionRepo repository.SessionRepository, us
 		}
 
 		// Verify user has an org
-		_, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		_, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil {
 			http.Error(w, "Session expired or no organization", http.StatusUnauthorized)
 			return
@@ -1450,7 +2363,7 @@ func settingsCertificatesCreateHandler(sessionRepo repository.SessionRepository,
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 			pages.CertificateCreateError("No organization found. Please refresh and try again.").Render(r.Context(), w)
@@ -1516,7 +2429,7 @@ func settingsCertificatesRevokeHandler(sessionRepo repository.SessionRepository,
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "No organization found", http.StatusBadRequest)
 			return
@@ -1561,7 +2474,7 @@ func settingsCertificatesDeleteHandler(sessionRepo repository.SessionRepository,
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "No organization found", http.StatusBadRequest)
 			return
@@ -1626,7 +2539,7 @@ func settingsCertificatesDownloadHandler(sessionRepo repository.SessionRepositor
 			return
 		}
 
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
 			return
@@ -1672,7 +2585,7 @@ func deriveCelestiaAddress(hexAddr string) string {
 	}
 
 	// Convert to bech32 with "celestia" prefix
-	celestiaAddr, err := bech32Encode("celestia", addrBytes)
+	celestiaAddr, err := address.Bech32("celestia", addrBytes)
 	if err != nil {
 		return "(bech32 encoding failed)"
 	}
@@ -1680,83 +2593,25 @@ func deriveCelestiaAddress(hexAddr string) string {
 	return celestiaAddr
 }
 
-// bech32Encode encodes data to bech32 format with the given human-readable prefix.
-func bech32Encode(hrp string, data []byte) (string, error) {
-	// Convert 8-bit data to 5-bit groups
-	converted := make([]byte, 0, len(data)*8/5+1)
-	acc := 0
-	bits := 0
-	for _, b := range data {
-		acc = (acc << 8) | int(b)
-		bits += 8
-		for bits >= 5 {
-			bits -= 5
-			converted = append(converted, byte((acc>>bits)&0x1f))
-		}
-	}
-	if bits > 0 {
-		converted = append(converted, byte((acc<<(5-bits))&0x1f))
-	}
-
-	// Create checksum
-	values := append(expandHRP(hrp), converted...)
-	values = append(values, 0, 0, 0, 0, 0, 0)
-	polymod := bech32Polymod(values) ^ 1
-	checksum := make([]byte, 6)
-	for i := 0; i < 6; i++ {
-		checksum[i] = byte((polymod >> (5 * (5 - i))) & 0x1f)
-	}
-
-	// Encode to charset
-	charset := "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
-	result := hrp + "1"
-	for _, b := range converted {
-		result += string(charset[b])
-	}
-	for _, b := range checksum {
-		result += string(charset[b])
-	}
-
-	return result, nil
-}
-
-func expandHRP(hrp string) []byte {
-	result := make([]byte, len(hrp)*2+1)
-	for i, c := range hrp {
-		result[i] = byte(c >> 5)
-		result[i+len(hrp)+1] = byte(c & 0x1f)
-	}
-	result[len(hrp)] = 0
-	return result
-}
+// ensureUserHasOrg returns the organization the user's session currently works in.
+// If the user has no orgs, it creates a default "Personal" org.
+func ensureUserHasOrg(r *http.Request, user *models.User, sessionRepo repository.SessionRepository, orgRepo repository.OrgRepository) (*models.Organization, error) {
+	ctx := r.Context()
 
-func bech32Polymod(values []byte) int {
-	gen := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
-	chk := 1
-	for _, v := range values {
-		b := chk >> 25
-		chk = ((chk & 0x1ffffff) << 5) ^ int(v)
-		for i := 0; i < 5; i++ {
-			if (b>>i)&1 == 1 {
-				chk ^= gen[i]
-			}
+	// Use the org selected in the session, or the user's first org
+	session := &models.Session{UserID: user.ID}
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if s, err := sessionRepo.Get(ctx, cookie.Value); err == nil && s != nil && s.UserID == user.ID {
+			session = s
 		}
 	}
-	return chk
-}
-
-// ensureUserHasOrg ensures the user has at least one organization.
-// If the user has no orgs, it creates a default "Personal" org.
-func ensureUserHasOrg(ctx context.Context, user *models.User, orgRepo repository.OrgRepository) (*models.Organization, error) {
-	// Check if user already has orgs
-	orgs, err := orgRepo.ListUserOrgs(ctx, user.ID)
+	current, err := service.NewCurrentOrgService(orgRepo, sessionRepo).Current(ctx, session)
 	if err != nil {
-		slog.Error("Failed to list user orgs", slog.String("user_id", user.ID.String()), slog.String("error", err.Error()))
+		slog.Error("Failed to get current org", slog.String("user_id", user.ID.String()), slog.String("error", err.Error()))
 		return nil, err
 	}
-	if len(orgs) > 0 {
-		slog.Info("User has existing org", slog.String("user_id", user.ID.String()), slog.String("org_id", orgs[0].ID.String()))
-		return orgs[0], nil
+	if current != nil {
+		return current, nil
 	}
 
 	// Create default org
@@ -1775,6 +2630,12 @@ func ensureUserHasOrg(ctx context.Context, user *models.User, orgRepo repository
 		return nil, fmt.Errorf("failed to create default org: %w", err)
 	}
 
+	// Make sure the first key can be created without a namespace step
+	if _, err := service.NewNamespaceService(orgRepo).EnsureDefault(ctx, org.ID); err != nil {
+		slog.Error("Failed to create default namespace", slog.String("org_id", org.ID.String()), slog.String("error", err.Error()))
+		return nil, err
+	}
+
 	slog.Info("Created default organization for user",
 		slog.String("user_id", user.ID.String()),
 		slog.String("org_id", org.ID.String()),
@@ -1784,55 +2645,6 @@ func ensureUserHasOrg(ctx context.Context, user *models.User, orgRepo repository
 	return org, nil
 }
 
-// namespacesListHandler returns the namespaces for the authenticated organization.
-// This is used by the API to get namespace IDs for key creation.
-func namespacesListHandler(orgRepo repository.OrgRepository) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		orgID := middleware.GetOrgIDFromContext(r.Context())
-		if orgID == uuid.Nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusUnauthorized)
-			w.Write([]byte(`{"error":"unauthorized"}`))
-			return
-		}
-
-		namespaces, err := orgRepo.ListNamespaces(r.Context(), orgID)
-		if err != nil {
-			slog.Error("Failed to list namespaces", slog.String("error", err.Error()))
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(`{"error":"failed to list namespaces"}`))
-			return
-		}
-
-		// Return namespaces as JSON
-		type namespaceResponse struct {
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			Description string `json:"description,omitempty"`
-		}
-
-		result := make([]namespaceResponse, len(namespaces))
-		for i, ns := range namespaces {
-			desc := ""
-			if ns.Description != nil {
-				desc = *ns.Description
-			}
-			result[i] = namespaceResponse{
-				ID:          ns.ID.String(),
-				Name:        ns.Name,
-				Description: desc,
-			}
-		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		if err := json.NewEncoder(w).Encode(result); err != nil {
-			slog.Error("Failed to encode namespaces", slog.String("error", err.Error()))
-		}
-	}
-}
-
 // usageHandler serves the usage analytics page.
 func usageHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, keyRepo repository.KeyRepository, usageRepo repository.UsageRepository) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -1842,7 +2654,7 @@ func usageHandler(sessionRepo repository.SessionRepository, userRepo repository.
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
 			return
@@ -1920,7 +2732,7 @@ func auditHandler(sessionRepo repository.SessionRepository, userRepo repository.
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
 			return
@@ -2097,6 +2909,9 @@ func createHostRouter(dashboardRouter http.Handler, popkinsRouter http.Handler,
 			strings.HasPrefix(path, "/auth/") ||
 			path == "/login" ||
 			path == "/logout" ||
+			path == "/forgot-password" ||
+			path == "/reset-password" ||
+			path == "/verify-email" ||
 			path == "/health" ||
 			path == "/ready" ||
 			path == "/metrics" {
@@ -2130,7 +2945,7 @@ func settingsTeamHandler(sessionRepo repository.SessionRepository, userRepo repo
 		}
 
 		// Ensure user has an org
-		org, err := ensureUserHasOrg(r.Context(), user, orgRepo)
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
 		if err != nil || org == nil {
 			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
 			return
@@ -2143,26 +2958,44 @@ func settingsTeamHandler(sessionRepo repository.SessionRepository, userRepo repo
 		// Get plan limits
 		limits := models.GetPlanLimits(org.Plan)
 
-		// Get current user as the only member (simplified - full team management requires OrgService)
-		userName := ""
-		if user.Name != nil {
-			userName = *user.Name
+		orgMembers, err := orgRepo.ListMembers(r.Context(), org.ID)
+		if err != nil {
+			http.Error(w, "Failed to load team members", http.StatusInternalServerError)
+			return
 		}
-		avatarURL := ""
-		if user.AvatarURL != nil {
-			avatarURL = *user.AvatarURL
+
+		currentRole := models.RoleViewer
+		members := make([]*pages.TeamMemberDisplay, 0, len(orgMembers))
+		for _, m := range orgMembers {
+			display := &pages.TeamMemberDisplay{
+				ID:            m.UserID,
+				Role:          m.Role,
+				JoinedAt:      m.JoinedAt.Format("Jan 2, 2006"),
+				IsCurrentUser: m.UserID == user.ID,
+			}
+			if m.User != nil {
+				display.Email = m.User.Email
+				if m.User.Name != nil {
+					display.Name = *m.User.Name
+				}
+				if m.User.AvatarURL != nil {
+					display.AvatarURL = *m.User.AvatarURL
+				}
+			}
+			if display.IsCurrentUser {
+				currentRole = m.Role
+			}
+			members = append(members, display)
 		}
 
-		members := []*pages.TeamMemberDisplay{
-			{
-				ID:            user.ID,
-				Name:          userName,
-				Email:         user.Email,
-				AvatarURL:     avatarURL,
-				Role:          models.RoleOwner,
-				JoinedAt:      user.CreatedAt.Format("Jan 2, 2006"),
-				IsCurrentUser: true,
-			},
+		// Pending invitations are only shown to members who can manage them
+		var invitations []*models.Invitation
+		if models.RoleLevel(currentRole) >= models.RoleLevel(models.RoleAdmin) {
+			invitations, err = orgRepo.ListPendingInvitations(r.Context(), org.ID)
+			if err != nil {
+				http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+				return
+			}
 		}
 
 		data := pages.TeamPageData{
@@ -2175,13 +3008,145 @@ func settingsTeamHandler(sessionRepo repository.SessionRepository, userRepo repo
 				ActivePath: "/settings/team",
 			},
 			Members:     members,
-			Invitations: nil,
-			CurrentRole: models.RoleOwner,
+			Invitations: invitations,
+			CurrentRole: currentRole,
 			MemberLimit: limits.TeamMembers,
-			MemberCount: 1,
+			MemberCount: len(members),
 		}
 
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		pages.SettingsTeamPage(data).Render(r.Context(), w)
 	}
 }
+
+// settingsTeamInviteModalHandler serves the invite member modal.
+func settingsTeamInviteModalHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		pages.InviteMemberModal().Render(r.Context(), w)
+	}
+}
+
+// settingsTeamInviteHandler invites a new member to the user's organization.
+func settingsTeamInviteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository, orgSvc service.OrgService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		org, err := ensureUserHasOrg(r, user, sessionRepo, orgRepo)
+		if err != nil || org == nil {
+			http.Error(w, "Failed to get organization", http.StatusInternalServerError)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+
+		email := strings.TrimSpace(r.FormValue("email"))
+		role := models.Role(r.FormValue("role"))
+		if email == "" {
+			http.Error(w, "Email is required", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := orgSvc.InviteMember(r.Context(), org.ID, email, role, user.ID); err != nil {
+			var apiErr *apierrors.APIError
+			if errors.As(err, &apiErr) {
+				http.Error(w, apiErr.Message, apiErr.StatusCode)
+				return
+			}
+			slog.Error("Failed to invite member", slog.String("org_id", org.ID.String()), slog.String("error", err.Error()))
+			http.Error(w, "Failed to send invitation", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// acceptInviteHandler accepts the invitation in the link and adds the user to the organization.
+func acceptInviteHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgSvc service.OrgService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		if _, err := orgSvc.AcceptInvitation(r.Context(), chi.URLParam(r, "token"), user.ID); err != nil {
+			var apiErr *apierrors.APIError
+			if errors.As(err, &apiErr) {
+				http.Error(w, apiErr.Message, apiErr.StatusCode)
+				return
+			}
+			slog.Error("Failed to accept invitation", slog.String("user_id", user.ID.String()), slog.String("error", err.Error()))
+			http.Error(w, "Failed to accept invitation", http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, "/settings/team", http.StatusFound)
+	}
+}
+
+// orgSwitchHandler selects the organization the dashboard session works in.
+func orgSwitchHandler(sessionRepo repository.SessionRepository, userRepo repository.UserRepository, orgRepo repository.OrgRepository) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := getAuthenticatedUser(w, r, sessionRepo, userRepo)
+		if user == nil {
+			return
+		}
+
+		cookie, err := r.Cookie(sessionCookieName)
+		if err != nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		session, err := sessionRepo.Get(r.Context(), cookie.Value)
+		if err != nil || session == nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Failed to parse form", http.StatusBadRequest)
+			return
+		}
+
+		orgID, err := uuid.Parse(r.FormValue("org_id"))
+		if err != nil {
+			http.Error(w, "Invalid organization ID", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := service.NewCurrentOrgService(orgRepo, sessionRepo).Switch(r.Context(), session, orgID); err != nil {
+			var apiErr *apierrors.APIError
+			if errors.As(err, &apiErr) {
+				http.Error(w, apiErr.Message, apiErr.StatusCode)
+				return
+			}
+			slog.Error("Failed to switch organization", slog.String("user_id", user.ID.String()), slog.String("error", err.Error()))
+			http.Error(w, "Failed to switch organization", http.StatusInternalServerError)
+			return
+		}
+
+		// Go back to the page the switcher was used on
+		redirect := "/keys"
+		if ref, err := url.Parse(r.Referer()); err == nil && ref.Path != "" && ref.Host == r.Host {
+			redirect = ref.Path
+		}
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", redirect)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Redirect(w, r, redirect, http.StatusFound)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -24,16 +26,27 @@ import (
 // This will be implemented by the stack-specific orchestrators.
 type Orchestrator interface {
 	StartDeployment(ctx context.Context, deploymentID uuid.UUID) error
+	// DeleteDeployment removes a deployment and its artifacts. It returns
+	// repository.ErrDeploymentRunning if the deployment is still running.
+	DeleteDeployment(ctx context.Context, deploymentID uuid.UUID) error
 }
 
 // noopOrchestrator is a placeholder orchestrator that does nothing.
 // Used when no orchestrator is configured.
-type noopOrchestrator struct{}
+type noopOrchestrator struct {
+	repo repository.Repository
+}
 
 func (n *noopOrchestrator) StartDeployment(_ context.Context, _ uuid.UUID) error {
 	return nil
 }
 
+// DeleteDeployment deletes straight from the repository, as nothing can be
+// running without an orchestrator.
+func (n *noopOrchestrator) DeleteDeployment(ctx context.Context, deploymentID uuid.UUID) error {
+	return n.repo.DeleteDeployment(ctx, deploymentID)
+}
+
 // DeploymentHandler handles deployment-related HTTP requests.
 type DeploymentHandler struct {
 	repo         repository.Repository
@@ -45,7 +58,7 @@ type DeploymentHandler struct {
 // NewDeploymentHandler creates a new deployment handler.
 func NewDeploymentHandler(repo repository.Repository, orch Orchestrator, orgService service.OrgService) *DeploymentHandler {
 	if orch == nil {
-		orch = &noopOrchestrator{}
+		orch = &noopOrchestrator{repo: repo}
 	}
 	return &DeploymentHandler{
 		repo:         repo,
@@ -299,7 +312,69 @@ func (h *DeploymentHandler) Start(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Delete handles DELETE /api/v1/deployments/{id}
+// It removes the deployment with its transactions and artifacts. Running
+// deployments can't be deleted until they are cancelled.
+func (h *DeploymentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.getUserIDFromContext(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	orgID, err := h.getOrgIDFromContext(r)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("invalid deployment ID"))
+		return
+	}
+
+	deployment, err := h.repo.GetDeployment(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			response.Error(w, apierrors.NewNotFoundError("deployment"))
+			return
+		}
+		response.Error(w, apierrors.ErrInternal)
+		return
+	}
+
+	if err := h.checkDeploymentAccess(r.Context(), deployment, orgID); err != nil {
+		response.Error(w, apierrors.NewNotFoundError("deployment"))
+		return
+	}
+
+	// Deleting a deployment requires admin role
+	if h.orgService != nil {
+		if err := h.orgService.CheckAccess(r.Context(), orgID, userID, models.RoleAdmin); err != nil {
+			response.Error(w, apierrors.ErrForbidden.WithMessage("insufficient permissions to delete deployments"))
+			return
+		}
+	}
+
+	if err := h.orchestrator.DeleteDeployment(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDeploymentRunning):
+			response.Error(w, apierrors.NewConflictError("deployment is running; cancel it before deleting"))
+		case errors.Is(err, repository.ErrNotFound):
+			response.Error(w, apierrors.NewNotFoundError("deployment"))
+		default:
+			response.Error(w, apierrors.ErrInternal.WithMessage("failed to delete deployment"))
+		}
+		return
+	}
+
+	response.NoContent(w)
+}
+
 // GetArtifacts handles GET /api/v1/deployments/{id}/artifacts
+// With ?content=false only each artifact's size, content type and creation
+// time are returned, and internal deployer state is left out.
 func (h *DeploymentHandler) GetArtifacts(w http.ResponseWriter, r *http.Request) {
 	// CRIT-010: Get authenticated user's org for authorization
 	orgID, err := h.getOrgIDFromContext(r)
@@ -337,6 +412,21 @@ func (h *DeploymentHandler) GetArtifacts(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Get("content") == "false" {
+		infos := repository.ListArtifactInfo(artifacts)
+		infoResponses := make([]ArtifactInfoResponse, len(infos))
+		for i, info := range infos {
+			infoResponses[i] = ArtifactInfoResponse{
+				Type:        info.Type,
+				Size:        info.Size,
+				ContentType: info.ContentType,
+				CreatedAt:   info.CreatedAt.Format(time.RFC3339),
+			}
+		}
+		response.OK(w, &ArtifactInfoListResponse{Artifacts: infoResponses})
+		return
+	}
+
 	artifactResponses := make([]ArtifactResponse, len(artifacts))
 	for i, a := range artifacts {
 		artifactResponses[i] = *toArtifactResponse(&a)
@@ -346,6 +436,8 @@ func (h *DeploymentHandler) GetArtifacts(w http.ResponseWriter, r *http.Request)
 }
 
 // GetArtifact handles GET /api/v1/deployments/{id}/artifacts/{type}
+// With ?raw=true the artifact is sent as a file download, with plain-text
+// artifacts unwrapped from their JSON storage form.
 func (h *DeploymentHandler) GetArtifact(w http.ResponseWriter, r *http.Request) {
 	// CRIT-010: Get authenticated user's org for authorization
 	orgID, err := h.getOrgIDFromContext(r)
@@ -393,9 +485,25 @@ func (h *DeploymentHandler) GetArtifact(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Get("raw") == "true" {
+		writeRawArtifact(w, artifact)
+		return
+	}
+
 	response.OK(w, toArtifactResponse(artifact))
 }
 
+// writeRawArtifact sends an artifact's content as a download named after its
+// type, e.g. genesis.json or jwt.txt.
+func writeRawArtifact(w http.ResponseWriter, artifact *repository.Artifact) {
+	content := artifact.RawContent()
+
+	w.Header().Set("Content-Type", artifact.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifact.ArtifactType))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+	w.Write(content)
+}
+
 // GetBundle handles GET /api/v1/deployments/{id}/bundle
 // Returns a downloadable .tar.gz bundle containing all deployment artifacts.
 func (h *DeploymentHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
@@ -535,6 +643,13 @@ func (h *DeploymentHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Optional status filter
 	statusFilter := r.URL.Query().Get("status")
 
+	// Paginated listing is opt-in so existing clients keep receiving all deployments
+	q := r.URL.Query()
+	if q.Has("limit") || q.Has("cursor") {
+		h.listPage(w, r, orgID, statusFilter)
+		return
+	}
+
 	var deployments []*repository.Deployment
 
 	if statusFilter != "" {
@@ -559,6 +674,59 @@ func (h *DeploymentHandler) List(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, responses)
 }
 
+// listPage handles GET /api/v1/deployments with limit and cursor query
+// parameters, returning the org's deployments newest first.
+func (h *DeploymentHandler) listPage(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, statusFilter string) {
+	q := r.URL.Query()
+	limit := defaultDeploymentPageSize
+	if limitStr := q.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 || l > maxDeploymentPageSize {
+			response.Error(w, apierrors.NewValidationError("limit", fmt.Sprintf("limit must be between 1 and %d", maxDeploymentPageSize)))
+			return
+		}
+		limit = l
+	}
+
+	query := repository.DeploymentListQuery{
+		OrgID: orgID,
+		Limit: limit + 1, // Fetch one extra to determine if there's a next page
+	}
+	if statusFilter != "" {
+		status := repository.Status(statusFilter)
+		query.Status = &status
+	}
+	if cursor := q.Get("cursor"); cursor != "" {
+		createdAt, id, err := decodeCursor(cursor)
+		if err != nil {
+			response.Error(w, apierrors.NewValidationError("cursor", "invalid cursor"))
+			return
+		}
+		query.AfterCreatedAt = &createdAt
+		query.AfterID = &id
+	}
+
+	deployments, err := h.repo.ListDeploymentsPage(r.Context(), query)
+	if err != nil {
+		response.Error(w, apierrors.ErrInternal.WithMessage("failed to list deployments"))
+		return
+	}
+
+	var nextCursor string
+	if len(deployments) > limit {
+		deployments = deployments[:limit]
+		last := deployments[limit-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	responses := make([]*DeploymentResponse, len(deployments))
+	for i, d := range deployments {
+		responses[i] = toDeploymentResponse(d)
+	}
+
+	response.JSONWithMeta(w, http.StatusOK, responses, &response.Meta{NextCursor: nextCursor})
+}
+
 // PreflightRequest is the request body for pre-flight checks.
 type PreflightRequest struct {
 	L1RPC           string `json:"l1_rpc"`
@@ -23,7 +23,9 @@ type DeploymentResponse struct {
 	ID           uuid.UUID       `json:"id"`
 	OrgID        uuid.UUID       `json:"org_id"`
 	ChainID      int64           `json:"chain_id"`
+	ChainName    string          `json:"chain_name,omitempty"`
 	Stack        string          `json:"stack"`
+	BundleStack  string          `json:"bundle_stack,omitempty"` // Rollup stack of a pop-bundle: "opstack" or "nitro"
 	Status       string          `json:"status"`
 	CurrentStage *string         `json:"current_stage,omitempty"`
 	Config       json.RawMessage `json:"config"`
@@ -53,6 +55,20 @@ type ArtifactListResponse struct {
 	Artifacts []ArtifactResponse `json:"artifacts"`
 }
 
+// ArtifactInfoResponse describes an artifact without its content. Size is
+// the length of the file served by ?raw=true.
+type ArtifactInfoResponse struct {
+	Type        string `json:"type"`
+	Size        int    `json:"size"`
+	ContentType string `json:"content_type"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ArtifactInfoListResponse wraps a list of artifact descriptions.
+type ArtifactInfoListResponse struct {
+	Artifacts []ArtifactInfoResponse `json:"artifacts"`
+}
+
 // StartResponse is the response for starting a deployment.
 type StartResponse struct {
 	Status  string `json:"status"`
@@ -61,11 +77,25 @@ type StartResponse struct {
 
 // toDeploymentResponse converts a repository Deployment to an API response.
 func toDeploymentResponse(d *repository.Deployment) *DeploymentResponse {
+	// Chain name and bundle stack live in the stack-specific config
+	var cfg struct {
+		ChainName   string `json:"chain_name"`
+		BundleStack string `json:"bundle_stack"`
+	}
+	_ = json.Unmarshal(d.Config, &cfg)
+	if d.Stack == repository.StackPopBundle && cfg.BundleStack == "" {
+		cfg.BundleStack = string(repository.StackOPStack)
+	} else if d.Stack != repository.StackPopBundle {
+		cfg.BundleStack = ""
+	}
+
 	return &DeploymentResponse{
 		ID:           d.ID,
 		OrgID:        d.OrgID,
 		ChainID:      d.ChainID,
+		ChainName:    cfg.ChainName,
 		Stack:        string(d.Stack),
+		BundleStack:  cfg.BundleStack,
 		Status:       string(d.Status),
 		CurrentStage: d.CurrentStage,
 		Config:       d.Config,
@@ -13,9 +13,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
 )
 
 // MockRepository is a mock implementation of repository.Repository for testing.
@@ -103,6 +105,19 @@ func (m *MockRepository) ListDeploymentsByOrgAndStatus(ctx context.Context, orgI
 	return args.Get(0).([]*repository.Deployment), args.Error(1)
 }
 
+func (m *MockRepository) ListDeploymentsPage(ctx context.Context, q repository.DeploymentListQuery) ([]*repository.Deployment, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Deployment), args.Error(1)
+}
+
+func (m *MockRepository) DeleteDeployment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockRepository) MarkStaleDeploymentsFailed(ctx context.Context, orgID uuid.UUID, timeout time.Duration) (int, error) {
 	args := m.Called(ctx, orgID, timeout)
 	return args.Int(0), args.Error(1)
@@ -168,6 +183,11 @@ func (m *MockOrchestrator) StartDeployment(ctx context.Context, deploymentID uui
 	return args.Error(0)
 }
 
+func (m *MockOrchestrator) DeleteDeployment(ctx context.Context, deploymentID uuid.UUID) error {
+	args := m.Called(ctx, deploymentID)
+	return args.Error(0)
+}
+
 var _ Orchestrator = (*MockOrchestrator)(nil)
 
 // Test org and user IDs for authentication context
@@ -183,6 +203,7 @@ func mockAuthMiddleware(next http.Handler) http.Handler {
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, middleware.OrgIDKey, testOrgID.String())
 		ctx = context.WithValue(ctx, middleware.UserIDKey, testUserID.String())
+		ctx = middleware.SetRoleInContext(ctx, models.RoleOwner)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -524,6 +545,49 @@ func TestGetArtifacts_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetArtifacts_Metadata(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockOrch := new(MockOrchestrator)
+
+	deploymentID := uuid.New()
+	deployment := &repository.Deployment{
+		ID:     deploymentID,
+		OrgID:  testOrgID,
+		Stack:  repository.StackOPStack,
+		Status: repository.StatusCompleted,
+	}
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	artifacts := []repository.Artifact{
+		{ArtifactType: "genesis.json", Content: json.RawMessage(`{"config":{}}`), CreatedAt: createdAt},
+		{ArtifactType: "deployment_state", Content: json.RawMessage(`{"huge":"state"}`), CreatedAt: createdAt},
+		{ArtifactType: "jwt.txt", Content: json.RawMessage(`{"_type":"base64","data":"MHhkZWFkYmVlZgo="}`), CreatedAt: createdAt},
+	}
+
+	mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(deployment, nil)
+	mockRepo.On("GetAllArtifacts", mock.Anything, deploymentID).Return(artifacts, nil)
+
+	router := setupTestRouter(mockRepo, mockOrch)
+
+	req := httptest.NewRequest("GET", "/api/v1/deployments/"+deploymentID.String()+"/artifacts?content=false", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data ArtifactInfoListResponse `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, []ArtifactInfoResponse{
+		{Type: "genesis.json", Size: 13, ContentType: "application/json", CreatedAt: "2024-01-01T12:00:00Z"},
+		{Type: "jwt.txt", Size: 11, ContentType: "text/plain; charset=utf-8", CreatedAt: "2024-01-01T12:00:00Z"},
+	}, resp.Data.Artifacts)
+	assert.NotContains(t, rec.Body.String(), "content\":")
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestGetArtifact_Success(t *testing.T) {
 	mockRepo := new(MockRepository)
 	mockOrch := new(MockOrchestrator)
@@ -599,6 +663,74 @@ func TestGetArtifact_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetArtifact_Raw(t *testing.T) {
+	tests := []struct {
+		name        string
+		artifact    string
+		content     string
+		want        string
+		contentType string
+	}{
+		{
+			name:        "json artifact",
+			artifact:    "genesis.json",
+			content:     `{"config":{"chainId":42069}}`,
+			want:        `{"config":{"chainId":42069}}`,
+			contentType: "application/json",
+		},
+		{
+			name:        "text stored as json string",
+			artifact:    "docker-compose.yml",
+			content:     `"services:\n  op-geth:\n    image: op-geth\n"`,
+			want:        "services:\n  op-geth:\n    image: op-geth\n",
+			contentType: "text/plain; charset=utf-8",
+		},
+		{
+			name:        "text stored base64-wrapped",
+			artifact:    "jwt.txt",
+			content:     `{"_type":"base64","data":"MHhkZWFkYmVlZgo="}`,
+			want:        "0xdeadbeef\n",
+			contentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			mockOrch := new(MockOrchestrator)
+
+			deploymentID := uuid.New()
+			deployment := &repository.Deployment{
+				ID:     deploymentID,
+				OrgID:  testOrgID,
+				Stack:  repository.StackOPStack,
+				Status: repository.StatusCompleted,
+			}
+			artifact := &repository.Artifact{
+				ID:           uuid.New(),
+				DeploymentID: deploymentID,
+				ArtifactType: tt.artifact,
+				Content:      json.RawMessage(tt.content),
+			}
+			mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(deployment, nil)
+			mockRepo.On("GetArtifact", mock.Anything, deploymentID, tt.artifact).Return(artifact, nil)
+
+			router := setupTestRouter(mockRepo, mockOrch)
+
+			req := httptest.NewRequest("GET", "/api/v1/deployments/"+deploymentID.String()+"/artifacts/"+tt.artifact+"?raw=true", nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, rec.Body.String())
+			assert.Equal(t, tt.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="`+tt.artifact+`"`, rec.Header().Get("Content-Disposition"))
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // --- Get Transactions Tests ---
 
 func TestGetTransactions_Success(t *testing.T) {
@@ -734,3 +866,233 @@ func TestList_WithStatusFilter(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+
+func TestList_Paginated(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockOrch := new(MockOrchestrator)
+
+	now := time.Now().UTC()
+	deployments := make([]*repository.Deployment, 3)
+	for i := range deployments {
+		deployments[i] = &repository.Deployment{
+			ID:        uuid.New(),
+			ChainID:   int64(12345 + i),
+			OrgID:     testOrgID,
+			Stack:     repository.StackPopBundle,
+			Status:    repository.StatusCompleted,
+			Config:    json.RawMessage(`{"chain_name": "devnet", "bundle_stack": "nitro"}`),
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+			UpdatedAt: now,
+		}
+	}
+
+	// CRIT-014: every page query is scoped to the caller's org
+	firstPage := mock.MatchedBy(func(q repository.DeploymentListQuery) bool {
+		return q.OrgID == testOrgID && q.Limit == 3 && q.AfterID == nil && q.Status == nil
+	})
+	mockRepo.On("ListDeploymentsPage", mock.Anything, firstPage).Return(deployments, nil)
+
+	router := setupTestRouter(mockRepo, mockOrch)
+
+	req := httptest.NewRequest("GET", "/api/v1/deployments?limit=2", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp struct {
+		Data []DeploymentResponse `json:"data"`
+		Meta struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, deployments[0].ID, resp.Data[0].ID)
+	assert.Equal(t, "devnet", resp.Data[0].ChainName)
+	assert.Equal(t, "nitro", resp.Data[0].BundleStack)
+	require.NotEmpty(t, resp.Meta.NextCursor)
+
+	// The cursor resumes after the last deployment of the first page
+	secondPage := mock.MatchedBy(func(q repository.DeploymentListQuery) bool {
+		return q.OrgID == testOrgID && q.AfterID != nil && *q.AfterID == deployments[1].ID &&
+			q.AfterCreatedAt.Equal(deployments[1].CreatedAt) &&
+			q.Status != nil && *q.Status == repository.StatusCompleted
+	})
+	mockRepo.On("ListDeploymentsPage", mock.Anything, secondPage).Return(deployments[2:], nil)
+
+	req = httptest.NewRequest("GET", "/api/v1/deployments?limit=2&status=completed&cursor="+resp.Meta.NextCursor, nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	resp.Meta.NextCursor = ""
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, deployments[2].ID, resp.Data[0].ID)
+	assert.Empty(t, resp.Meta.NextCursor)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestList_InvalidPagination(t *testing.T) {
+	router := setupTestRouter(new(MockRepository), new(MockOrchestrator))
+
+	for _, query := range []string{"limit=0", "limit=101", "limit=abc", "cursor=not-a-cursor"} {
+		req := httptest.NewRequest("GET", "/api/v1/deployments?"+query, nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestToDeploymentResponse_ChainNameAndBundleStack(t *testing.T) {
+	tests := []struct {
+		stack       repository.Stack
+		config      string
+		chainName   string
+		bundleStack string
+	}{
+		{repository.StackPopBundle, `{"chain_name": "devnet"}`, "devnet", "opstack"},
+		{repository.StackPopBundle, `{"chain_name": "devnet", "bundle_stack": "nitro"}`, "devnet", "nitro"},
+		{repository.StackOPStack, `{"chain_name": "mainnet-l2", "bundle_stack": "nitro"}`, "mainnet-l2", ""},
+		{repository.StackNitro, `{}`, "", ""},
+	}
+
+	for _, tt := range tests {
+		resp := toDeploymentResponse(&repository.Deployment{Stack: tt.stack, Config: json.RawMessage(tt.config)})
+		assert.Equal(t, tt.chainName, resp.ChainName, tt.config)
+		assert.Equal(t, tt.bundleStack, resp.BundleStack, tt.config)
+	}
+}
+
+// --- Delete Deployment Tests ---
+
+func TestDelete_Success(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockOrch := new(MockOrchestrator)
+
+	deploymentID := uuid.New()
+	mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(&repository.Deployment{
+		ID:     deploymentID,
+		OrgID:  testOrgID,
+		Stack:  repository.StackOPStack,
+		Status: repository.StatusCompleted,
+	}, nil)
+	mockOrch.On("DeleteDeployment", mock.Anything, deploymentID).Return(nil)
+
+	router := setupTestRouter(mockRepo, mockOrch)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/deployments/"+deploymentID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockRepo.AssertExpectations(t)
+	mockOrch.AssertExpectations(t)
+}
+
+func TestDelete_WithoutOrchestrator(t *testing.T) {
+	mockRepo := new(MockRepository)
+
+	deploymentID := uuid.New()
+	mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(&repository.Deployment{
+		ID:     deploymentID,
+		OrgID:  testOrgID,
+		Status: repository.StatusFailed,
+	}, nil)
+	mockRepo.On("DeleteDeployment", mock.Anything, deploymentID).Return(nil)
+
+	router := chi.NewRouter()
+	router.Use(mockAuthMiddleware)
+	router.Mount("/api/v1/deployments", NewDeploymentHandler(mockRepo, nil, nil).Routes())
+
+	req := httptest.NewRequest("DELETE", "/api/v1/deployments/"+deploymentID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDelete_Running(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockOrch := new(MockOrchestrator)
+
+	deploymentID := uuid.New()
+	mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(&repository.Deployment{
+		ID:     deploymentID,
+		OrgID:  testOrgID,
+		Status: repository.StatusRunning,
+	}, nil)
+	mockOrch.On("DeleteDeployment", mock.Anything, deploymentID).Return(repository.ErrDeploymentRunning)
+
+	router := setupTestRouter(mockRepo, mockOrch)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/deployments/"+deploymentID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "cancel it before deleting")
+	mockOrch.AssertExpectations(t)
+}
+
+func TestDelete_OtherOrg(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockOrch := new(MockOrchestrator)
+
+	deploymentID := uuid.New()
+	mockRepo.On("GetDeployment", mock.Anything, deploymentID).Return(&repository.Deployment{
+		ID:     deploymentID,
+		OrgID:  uuid.New(),
+		Status: repository.StatusCompleted,
+	}, nil)
+
+	router := setupTestRouter(mockRepo, mockOrch)
+
+	req := httptest.NewRequest("DELETE", "/api/v1/deployments/"+deploymentID.String(), nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockOrch.AssertNotCalled(t, "DeleteDeployment", mock.Anything, mock.Anything)
+}
+
+func TestRoutes_RequireRole(t *testing.T) {
+	deploymentID := uuid.New()
+	viewer := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(middleware.SetRoleInContext(r.Context(), models.RoleViewer)))
+		})
+	}
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{"POST", "/api/v1/deployments/preflight"},
+		{"POST", "/api/v1/deployments"},
+		{"DELETE", "/api/v1/deployments/" + deploymentID.String()},
+		{"POST", "/api/v1/deployments/" + deploymentID.String() + "/start"},
+		{"GET", "/api/v1/deployments/" + deploymentID.String() + "/bundle"},
+		{"GET", "/api/v1/deployments/" + deploymentID.String() + "/artifacts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			router := chi.NewRouter()
+			router.Use(mockAuthMiddleware, viewer)
+			router.Mount("/api/v1/deployments", NewDeploymentHandler(mockRepo, nil, nil).Routes())
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader([]byte(`{}`)))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,30 +2,38 @@ package handler
 
 import (
 	"github.com/go-chi/chi/v5"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
 )
 
 // Routes returns a chi router with all deployment routes configured.
+// Starting deployments needs the operator role and deleting them the admin
+// role; the role is resolved by middleware.ResolveOrgRole.
 func (h *DeploymentHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
+	operator := middleware.RequireRole(models.RoleOperator)
+	admin := middleware.RequireRole(models.RoleAdmin)
+
 	// Pre-flight checks (must be before /{id} routes to avoid conflict)
-	r.Post("/preflight", h.Preflight) // POST /api/v1/deployments/preflight
+	r.With(operator).Post("/preflight", h.Preflight) // POST /api/v1/deployments/preflight
 
 	// Deployment CRUD and management
-	r.Post("/", h.Create)           // POST /api/v1/deployments
-	r.Get("/", h.List)              // GET /api/v1/deployments
-	r.Get("/{id}", h.Get)           // GET /api/v1/deployments/{id}
-	r.Get("/{id}/status", h.Get)    // GET /api/v1/deployments/{id}/status (alias)
-	r.Post("/{id}/start", h.Start)  // POST /api/v1/deployments/{id}/start
+	r.With(operator).Post("/", h.Create)          // POST /api/v1/deployments
+	r.Get("/", h.List)                            // GET /api/v1/deployments
+	r.Get("/{id}", h.Get)                         // GET /api/v1/deployments/{id}
+	r.With(admin).Delete("/{id}", h.Delete)       // DELETE /api/v1/deployments/{id}
+	r.Get("/{id}/status", h.Get)                  // GET /api/v1/deployments/{id}/status (alias)
+	r.With(operator).Post("/{id}/start", h.Start) // POST /api/v1/deployments/{id}/start
 
 	// Artifacts
-	r.Get("/{id}/artifacts", h.GetArtifacts)        // GET /api/v1/deployments/{id}/artifacts
-	r.Get("/{id}/artifacts/{type}", h.GetArtifact)  // GET /api/v1/deployments/{id}/artifacts/{type}
-	r.Get("/{id}/bundle", h.GetBundle)              // GET /api/v1/deployments/{id}/bundle
+	r.With(operator).Get("/{id}/artifacts", h.GetArtifacts)       // GET /api/v1/deployments/{id}/artifacts[?content=false]
+	r.With(operator).Get("/{id}/artifacts/{type}", h.GetArtifact) // GET /api/v1/deployments/{id}/artifacts/{type}[?raw=true]
+	r.With(operator).Get("/{id}/bundle", h.GetBundle)             // GET /api/v1/deployments/{id}/bundle
 
 	// Transactions
-	r.Get("/{id}/transactions", h.GetTransactions)  // GET /api/v1/deployments/{id}/transactions
+	r.Get("/{id}/transactions", h.GetTransactions) // GET /api/v1/deployments/{id}/transactions
 
 	return r
 }
-
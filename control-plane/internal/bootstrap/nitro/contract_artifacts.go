@@ -6,12 +6,14 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,7 +44,25 @@ const ArtifactBaseURL = "https://nitro-contracts.s3.nl-ams.scw.cloud"
 
 // ContractArtifactURL is the URL to nitro-contracts v3.2.0-beta.0 artifacts.
 // v3.2 includes CUSTOM_DA_MESSAGE_HEADER_FLAG (0x01) for External DA support.
-var ContractArtifactURL = ArtifactBaseURL + "/" + ArtifactVersion + ".zip"
+var ContractArtifactURL = ArtifactURLForVersion(ArtifactVersion)
+
+// ArtifactURLForVersion returns the S3 URL of the given artifact version.
+func ArtifactURLForVersion(version string) string {
+	return ArtifactBaseURL + "/" + version + ".zip"
+}
+
+// NormalizeChecksum returns a SHA-256 checksum in the "sha256:<hex>" form
+// used by ArtifactChecksums. The "sha256:" prefix is optional.
+func NormalizeChecksum(checksum string) (string, error) {
+	digest := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(checksum)), "sha256:")
+	if len(digest) != 2*sha256.Size {
+		return "", fmt.Errorf("checksum must be %d hex characters, got %d", 2*sha256.Size, len(digest))
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", fmt.Errorf("checksum is not valid hex: %w", err)
+	}
+	return "sha256:" + digest, nil
+}
 
 // ContractArtifact represents a compiled Solidity contract with ABI and bytecode.
 type ContractArtifact struct {
@@ -137,10 +157,12 @@ type NitroArtifacts struct {
 	// EIP-4844 blob reader (for L1 chains, wraps BLOBBASEFEE/BLOBHASH opcodes)
 	Reader4844 *ContractArtifact
 
-	// Version metadata
+	// Version metadata. Checksum is the verified SHA-256 of the downloaded
+	// archive, in "sha256:<hex>" form.
 	Version   string
 	LoadedAt  time.Time
 	SourceURL string
+	Checksum  string
 }
 
 // ContractArtifactDownloader handles downloading and parsing Nitro contract artifacts from S3.
@@ -163,6 +185,13 @@ func NewContractArtifactDownloader(cacheDir string) *ContractArtifactDownloader
 // Returns a NitroArtifacts struct with all contracts loaded.
 // The version parameter is used for checksum verification.
 func (d *ContractArtifactDownloader) Download(ctx context.Context, url string, version string) (*NitroArtifacts, error) {
+	return d.DownloadWithChecksum(ctx, url, version, "")
+}
+
+// DownloadWithChecksum is like Download, but verifies the archive against
+// checksum instead of the one registered in ArtifactChecksums. An empty
+// checksum falls back to the registered one.
+func (d *ContractArtifactDownloader) DownloadWithChecksum(ctx context.Context, url, version, checksum string) (*NitroArtifacts, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -179,7 +208,8 @@ func (d *ContractArtifactDownloader) Download(ctx context.Context, url string, v
 	defer os.Remove(zipPath)
 
 	// CRIT-020: Verify checksum BEFORE parsing to prevent use of tampered artifacts
-	if err := d.verifyChecksum(zipPath, version); err != nil {
+	actualChecksum, err := d.verifyExpectedChecksum(zipPath, version, checksum)
+	if err != nil {
 		return nil, fmt.Errorf("artifact integrity check failed: %w", err)
 	}
 
@@ -192,6 +222,7 @@ func (d *ContractArtifactDownloader) Download(ctx context.Context, url string, v
 	artifacts.Version = version
 	artifacts.LoadedAt = time.Now()
 	artifacts.SourceURL = url
+	artifacts.Checksum = actualChecksum
 
 	return artifacts, nil
 }
@@ -201,6 +232,12 @@ func (d *ContractArtifactDownloader) DownloadDefault(ctx context.Context) (*Nitr
 	return d.Download(ctx, ContractArtifactURL, ArtifactVersion)
 }
 
+// DownloadVersion downloads the given artifact version from S3, verified
+// against checksum or, if empty, the checksum registered for the version.
+func (d *ContractArtifactDownloader) DownloadVersion(ctx context.Context, version, checksum string) (*NitroArtifacts, error) {
+	return d.DownloadWithChecksum(ctx, ArtifactURLForVersion(version), version, checksum)
+}
+
 // downloadFile downloads a file from URL to the given path.
 func (d *ContractArtifactDownloader) downloadFile(ctx context.Context, url, destPath string) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -248,28 +285,81 @@ func (d *ContractArtifactDownloader) verifyChecksum(filePath, version string) er
 		return nil
 	}
 
+	_, err := d.verifyExpectedChecksum(filePath, version, "")
+	return err
+}
+
+// verifyExpectedChecksum compares the file's SHA256 with expected, or with
+// the checksum registered for version if expected is empty, and returns the
+// file's checksum.
+func (d *ContractArtifactDownloader) verifyExpectedChecksum(filePath, version, expected string) (string, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("open file for checksum verification: %w", err)
+		return "", fmt.Errorf("open file for checksum verification: %w", err)
 	}
 	defer f.Close()
 
 	h := sha256.New()
 	if _, err := io.Copy(h, f); err != nil {
-		return fmt.Errorf("calculate checksum: %w", err)
+		return "", fmt.Errorf("calculate checksum: %w", err)
 	}
-
 	actualHash := fmt.Sprintf("sha256:%x", h.Sum(nil))
-	expectedHash, ok := ArtifactChecksums[version]
-	if !ok {
-		return fmt.Errorf("SECURITY: no checksum registered for artifact version %s - refusing to use unverified artifacts", version)
+
+	// Allow skipping in test environments (but never in production)
+	if SkipChecksumVerification {
+		return actualHash, nil
+	}
+
+	expectedHash := expected
+	if expectedHash != "" {
+		if expectedHash, err = NormalizeChecksum(expectedHash); err != nil {
+			return "", fmt.Errorf("expected checksum for %s: %w", version, err)
+		}
+	} else {
+		var ok bool
+		expectedHash, ok = ArtifactChecksums[version]
+		if !ok {
+			return "", fmt.Errorf("SECURITY: no checksum registered for artifact version %s - refusing to use unverified artifacts", version)
+		}
 	}
 
 	if actualHash != expectedHash {
-		return fmt.Errorf("SECURITY: checksum mismatch for %s - expected %s, got %s - artifacts may be tampered", version, expectedHash, actualHash)
+		return "", fmt.Errorf("SECURITY: checksum mismatch for %s - expected %s, got %s - artifacts may be tampered", version, expectedHash, actualHash)
 	}
 
-	return nil
+	return actualHash, nil
+}
+
+// requiredContracts lists the contracts we need (BOLD protocol for v3.2+).
+var requiredContracts = []string{
+	// Core deployment
+	"RollupCreator",
+	"BridgeCreator",
+	// Rollup infrastructure (ETH native)
+	"SequencerInbox",
+	"Bridge",
+	"Inbox",
+	"Outbox",
+	"RollupEventInbox",
+	"RollupCore",
+	"RollupAdminLogic",
+	"RollupUserLogic",
+	// ERC20 native token variants
+	"ERC20Bridge",
+	"ERC20Inbox",
+	// Challenge/Fraud proofs (BOLD)
+	"EdgeChallengeManager",
+	"OneStepProofEntry",
+	"OneStepProver0",
+	"OneStepProverMemory",
+	"OneStepProverMath",
+	"OneStepProverHostIo",
+	// Upgrade/Validator infrastructure
+	"UpgradeExecutor",
+	"ValidatorWalletCreator",
+	"DeployHelper",
+	// EIP-4844 blob reader
+	"Reader4844",
 }
 
 // parseZip extracts and parses contract artifacts from a zip file.
@@ -284,38 +374,6 @@ func (d *ContractArtifactDownloader) parseZip(zipPath string) (*NitroArtifacts,
 	// Map to store loaded artifacts by contract name
 	loaded := make(map[string]*ContractArtifact)
 
-	// List of contracts we need (BOLD protocol for v3.2+)
-	requiredContracts := []string{
-		// Core deployment
-		"RollupCreator",
-		"BridgeCreator",
-		// Rollup infrastructure (ETH native)
-		"SequencerInbox",
-		"Bridge",
-		"Inbox",
-		"Outbox",
-		"RollupEventInbox",
-		"RollupCore",
-		"RollupAdminLogic",
-		"RollupUserLogic",
-		// ERC20 native token variants
-		"ERC20Bridge",
-		"ERC20Inbox",
-		// Challenge/Fraud proofs (BOLD)
-		"EdgeChallengeManager",
-		"OneStepProofEntry",
-		"OneStepProver0",
-		"OneStepProverMemory",
-		"OneStepProverMath",
-		"OneStepProverHostIo",
-		// Upgrade/Validator infrastructure
-		"UpgradeExecutor",
-		"ValidatorWalletCreator",
-		"DeployHelper",
-		// EIP-4844 blob reader
-		"Reader4844",
-	}
-
 	// Build a set for quick lookup
 	requiredSet := make(map[string]bool)
 	for _, name := range requiredContracts {
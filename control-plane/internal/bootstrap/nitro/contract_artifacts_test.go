@@ -1,8 +1,13 @@
 package nitro
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -149,3 +154,73 @@ func TestArtifactChecksums_HasCurrentVersion(t *testing.T) {
 	_, ok := ArtifactChecksums[ArtifactVersion]
 	assert.True(t, ok, "ArtifactChecksums should have an entry for current ArtifactVersion %s", ArtifactVersion)
 }
+
+// testArtifactZip builds an artifact archive holding every required contract.
+func testArtifactZip(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range requiredContracts {
+		w, err := zw.Create("build/contracts/" + name + ".json")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(`{"abi": [], "bytecode": "0x00"}`))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadWithChecksum(t *testing.T) {
+	archive := testArtifactZip(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	originalSkip := SkipChecksumVerification
+	SkipChecksumVerification = false
+	defer func() { SkipChecksumVerification = originalSkip }()
+
+	downloader := NewContractArtifactDownloader(t.TempDir())
+	checksum := fmt.Sprintf("%x", sha256.Sum256(archive))
+
+	t.Run("matching checksum proceeds", func(t *testing.T) {
+		artifacts, err := downloader.DownloadWithChecksum(context.Background(), server.URL+"/v9.9.9.zip", "v9.9.9", checksum)
+		require.NoError(t, err)
+		assert.Equal(t, "v9.9.9", artifacts.Version)
+		assert.Equal(t, "sha256:"+checksum, artifacts.Checksum)
+		assert.NotNil(t, artifacts.RollupCreator)
+	})
+
+	t.Run("mismatched checksum aborts", func(t *testing.T) {
+		wrong := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("other archive")))
+		_, err := downloader.DownloadWithChecksum(context.Background(), server.URL+"/v9.9.9.zip", "v9.9.9", wrong)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "checksum mismatch")
+	})
+
+	t.Run("unregistered version without checksum aborts", func(t *testing.T) {
+		_, err := downloader.DownloadWithChecksum(context.Background(), server.URL+"/v9.9.9.zip", "v9.9.9", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no checksum registered")
+	})
+}
+
+func TestNormalizeChecksum(t *testing.T) {
+	digest := fmt.Sprintf("%x", sha256.Sum256([]byte("archive")))
+
+	got, err := NormalizeChecksum(digest)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+digest, got)
+
+	got, err = NormalizeChecksum("SHA256:" + digest)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+digest, got)
+
+	_, err = NormalizeChecksum("sha256:abc")
+	assert.Error(t, err)
+
+	_, err = NormalizeChecksum(digest[:62] + "zz")
+	assert.Error(t, err)
+}
@@ -218,7 +218,7 @@ func (d *RollupDeployer) Deploy(
 	})
 	if err != nil {
 		// Use a high default for rollup creation
-		gasLimit = 15_000_000
+		gasLimit = defaultCreateRollupGas
 		d.logger.Warn("gas estimation failed, using default",
 			slog.Uint64("gas_limit", gasLimit),
 			slog.String("error", err.Error()),
@@ -0,0 +1,136 @@
+package nitro
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Gas limits the deployers fall back to when estimation fails. Estimates use
+// the same values so they match what a deployment would actually send.
+const (
+	defaultContractGas     = 10_000_000
+	defaultReader4844Gas   = 200_000
+	defaultCreateRollupGas = 15_000_000
+)
+
+// GasEstimate is the estimated gas for a set of deployment transactions.
+type GasEstimate struct {
+	// Transactions is the number of transactions estimated.
+	Transactions int
+	// Gas is the total estimated gas.
+	Gas uint64
+	// Defaulted is the number of transactions that could not be estimated
+	// and were counted at the deployer's default gas limit instead.
+	Defaulted int
+}
+
+// Add adds a single transaction to the estimate.
+func (e *GasEstimate) Add(gas uint64, defaulted bool) {
+	e.Transactions++
+	e.Gas += gas
+	if defaulted {
+		e.Defaulted++
+	}
+}
+
+// EstimateGas estimates the gas needed to deploy the infrastructure on the
+// parent chain without sending any transactions. Contract addresses are
+// predicted from the signer's nonce, so constructor arguments match those of
+// a real deployment; the predicted addresses are returned by contract name.
+func (d *InfrastructureDeployer) EstimateGas(ctx context.Context, client *ethclient.Client) (*GasEstimate, map[string]common.Address, error) {
+	from := d.signer.Address()
+	nonce, err := client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get nonce: %w", err)
+	}
+
+	estimate := &GasEstimate{}
+	create := func(name string, data []byte, defaultGas uint64) common.Address {
+		gas, err := client.EstimateGas(ctx, ethereum.CallMsg{From: from, Data: data})
+		if err != nil {
+			d.logger.Debug("gas estimation failed, using default",
+				slog.String("name", name),
+				slog.Uint64("gas_limit", defaultGas),
+				slog.String("error", err.Error()),
+			)
+			estimate.Add(defaultGas, true)
+		} else {
+			estimate.Add(gas, false)
+		}
+
+		addr := crypto.CreateAddress(from, nonce)
+		nonce++
+		return addr
+	}
+
+	deploy := func(name string, artifact *ContractArtifact, constructorArgs []byte) (common.Address, error) {
+		bytecode, err := artifact.GetBytecodeBytes()
+		if err != nil {
+			return common.Address{}, fmt.Errorf("get %s bytecode: %w", name, err)
+		}
+		return create(name, append(bytecode, constructorArgs...), defaultContractGas), nil
+	}
+
+	deployReader4844 := func() (common.Address, error) {
+		bytecode, err := d.artifacts.Reader4844.GetBytecodeBytes()
+		if err != nil {
+			return common.Address{}, fmt.Errorf("get Reader4844 bytecode: %w", err)
+		}
+		return create("Reader4844", bytecode, defaultReader4844Gas), nil
+	}
+
+	predicted, err := d.deployContracts(ctx, client, deploy, deployReader4844)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return estimate, predicted, nil
+}
+
+// EstimateGas estimates the gas of the createRollup call without sending it.
+// If the RollupCreator has no code on the parent chain yet (it is deployed
+// as part of the same deployment), the call can't be simulated and the
+// deployer's default gas limit is returned with defaulted set.
+func (d *RollupDeployer) EstimateGas(
+	ctx context.Context,
+	client *ethclient.Client,
+	cfg *RollupConfig,
+	rollupCreatorAddr common.Address,
+) (gas uint64, defaulted bool, err error) {
+	d.applyDefaults(cfg)
+
+	callData, err := d.encoder.EncodeCreateRollup(cfg, PrepareChainConfig(cfg))
+	if err != nil {
+		return 0, false, fmt.Errorf("encode createRollup: %w", err)
+	}
+
+	code, err := client.CodeAt(ctx, rollupCreatorAddr, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("get RollupCreator code: %w", err)
+	}
+	if len(code) == 0 {
+		return defaultCreateRollupGas, true, nil
+	}
+
+	gas, err = client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  d.signer.Address(),
+		To:    &rollupCreatorAddr,
+		Value: big.NewInt(0),
+		Data:  callData,
+	})
+	if err != nil {
+		d.logger.Debug("createRollup gas estimation failed, using default",
+			slog.Uint64("gas_limit", defaultCreateRollupGas),
+			slog.String("error", err.Error()),
+		)
+		return defaultCreateRollupGas, true, nil
+	}
+	return gas, false, nil
+}
@@ -58,6 +58,9 @@ type InfrastructureResult struct {
 
 	// All deployed infrastructure addresses (for transparency)
 	DeployedContracts map[string]common.Address
+	// ABI-encoded constructor arguments by contract name, for source
+	// verification. Contracts without arguments are omitted.
+	ConstructorArgs map[string][]byte
 }
 
 // NewInfrastructureDeployer creates a new infrastructure deployer.
@@ -192,8 +195,6 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	ctx context.Context,
 	client *ethclient.Client,
 ) (*InfrastructureResult, error) {
-	deployed := make(map[string]common.Address)
-
 	// Get gas price (boosted 50%)
 	gasPrice, err := client.SuggestGasPrice(ctx)
 	if err != nil {
@@ -204,8 +205,10 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 
 	chainID := d.signer.ChainID()
 
-	// Helper to deploy a contract and track it
-	deploy := func(name string, artifact *ContractArtifact, constructorArgs []byte) error {
+	// Deploys a contract and returns its address
+	var rollupCreatorTxHash common.Hash
+	deployedArgs := make(map[string][]byte)
+	deploy := func(name string, artifact *ContractArtifact, constructorArgs []byte) (common.Address, error) {
 		d.logger.Info("deploying contract...",
 			slog.String("name", name),
 			slog.Int("constructor_args_len", len(constructorArgs)),
@@ -213,7 +216,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 
 		nonce, err := client.PendingNonceAt(ctx, d.signer.Address())
 		if err != nil {
-			return fmt.Errorf("get nonce for %s: %w", name, err)
+			return common.Address{}, fmt.Errorf("get nonce for %s: %w", name, err)
 		}
 
 		addr, txHash, err := d.deployContract(ctx, client, artifact, nonce, gasPrice, chainID, constructorArgs)
@@ -223,15 +226,68 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 				slog.Uint64("nonce", nonce),
 				slog.String("error", err.Error()),
 			)
-			return fmt.Errorf("deploy %s: %w", name, err)
+			return common.Address{}, fmt.Errorf("deploy %s: %w", name, err)
+		}
+		if name == "RollupCreator" {
+			rollupCreatorTxHash = txHash
+		}
+		if len(constructorArgs) > 0 {
+			deployedArgs[name] = constructorArgs
 		}
 
-		deployed[name] = addr
 		d.logger.Info("deployed contract",
 			slog.String("name", name),
 			slog.String("address", addr.Hex()),
 			slog.String("tx_hash", txHash.Hex()),
 		)
+		return addr, nil
+	}
+
+	deployReader4844 := func() (common.Address, error) {
+		return d.deployReader4844(ctx, client, gasPrice, chainID)
+	}
+
+	deployed, err := d.deployContracts(ctx, client, deploy, deployReader4844)
+	if err != nil {
+		return nil, err
+	}
+	rollupCreatorAddr := deployed["RollupCreator"]
+
+	d.logger.Info("Infrastructure deployment complete!",
+		slog.String("rollup_creator", rollupCreatorAddr.Hex()),
+		slog.Int("total_contracts", len(deployed)),
+	)
+
+	return &InfrastructureResult{
+		RollupCreatorAddress: rollupCreatorAddr,
+		BridgeCreatorAddress: deployed["BridgeCreator"],
+		Version:              d.artifacts.Version,
+		DeploymentTxHash:     rollupCreatorTxHash,
+		AlreadyDeployed:      false,
+		DeployedContracts:    deployed,
+		ConstructorArgs:      deployedArgs,
+	}, nil
+}
+
+// infraDeployFunc deploys a single infrastructure contract and returns its address.
+type infraDeployFunc func(name string, artifact *ContractArtifact, constructorArgs []byte) (common.Address, error)
+
+// deployContracts runs the infrastructure deployment plan, calling deploy for
+// each contract in dependency order. It returns the addresses by contract name.
+// The plan is shared by deployInfrastructure and EstimateGas.
+func (d *InfrastructureDeployer) deployContracts(
+	ctx context.Context,
+	client *ethclient.Client,
+	deploy infraDeployFunc,
+	deployReader4844 func() (common.Address, error),
+) (map[string]common.Address, error) {
+	deployed := make(map[string]common.Address)
+	step := func(name string, artifact *ContractArtifact, constructorArgs []byte) error {
+		addr, err := deploy(name, artifact, constructorArgs)
+		if err != nil {
+			return err
+		}
+		deployed[name] = addr
 		return nil
 	}
 
@@ -249,7 +305,8 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	} else {
 		// Deploy Reader4844 for L1/local chains (requires Cancun hardfork for EIP-4844)
 		d.logger.Info("Deploying Reader4844 for L1/local chain (requires Cancun EVM)...")
-		reader4844Addr, err = d.deployReader4844(ctx, client, gasPrice, chainID)
+		var err error
+		reader4844Addr, err = deployReader4844()
 		if err != nil {
 			return nil, fmt.Errorf("deploy Reader4844: %w", err)
 		}
@@ -287,7 +344,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	}
 
 	for _, c := range simpleContracts {
-		if err := deploy(c.name, c.artifact, nil); err != nil {
+		if err := step(c.name, c.artifact, nil); err != nil {
 			return nil, err
 		}
 	}
@@ -302,7 +359,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode OneStepProverHostIo args: %w", err)
 	}
-	if err := deploy("OneStepProverHostIo", d.artifacts.OneStepProverHostIo, ospHostIoArgs); err != nil {
+	if err := step("OneStepProverHostIo", d.artifacts.OneStepProverHostIo, ospHostIoArgs); err != nil {
 		return nil, err
 	}
 
@@ -317,7 +374,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode SequencerInbox args: %w", err)
 	}
-	if err := deploy("SequencerInbox", d.artifacts.SequencerInbox, seqInboxArgs); err != nil {
+	if err := step("SequencerInbox", d.artifacts.SequencerInbox, seqInboxArgs); err != nil {
 		return nil, err
 	}
 
@@ -331,7 +388,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode SequencerInboxDelay args: %w", err)
 	}
-	if err := deploy("SequencerInboxDelay", d.artifacts.SequencerInbox, seqInboxDelayArgs); err != nil {
+	if err := step("SequencerInboxDelay", d.artifacts.SequencerInbox, seqInboxDelayArgs); err != nil {
 		return nil, err
 	}
 
@@ -340,7 +397,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode Inbox args: %w", err)
 	}
-	if err := deploy("Inbox", d.artifacts.Inbox, inboxArgs); err != nil {
+	if err := step("Inbox", d.artifacts.Inbox, inboxArgs); err != nil {
 		return nil, err
 	}
 
@@ -349,7 +406,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode ERC20Inbox args: %w", err)
 	}
-	if err := deploy("ERC20Inbox", d.artifacts.ERC20Inbox, erc20InboxArgs); err != nil {
+	if err := step("ERC20Inbox", d.artifacts.ERC20Inbox, erc20InboxArgs); err != nil {
 		return nil, err
 	}
 
@@ -368,7 +425,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode OneStepProofEntry args: %w", err)
 	}
-	if err := deploy("OneStepProofEntry", d.artifacts.OneStepProofEntry, ospArgs); err != nil {
+	if err := step("OneStepProofEntry", d.artifacts.OneStepProofEntry, ospArgs); err != nil {
 		return nil, err
 	}
 
@@ -413,7 +470,7 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 	if err != nil {
 		return nil, fmt.Errorf("encode BridgeCreator args: %w", err)
 	}
-	if err := deploy("BridgeCreator", d.artifacts.BridgeCreator, bridgeCreatorArgs); err != nil {
+	if err := step("BridgeCreator", d.artifacts.BridgeCreator, bridgeCreatorArgs); err != nil {
 		return nil, err
 	}
 
@@ -448,35 +505,11 @@ func (d *InfrastructureDeployer) deployInfrastructure(
 		return nil, fmt.Errorf("encode RollupCreator args: %w", err)
 	}
 
-	nonce, err := client.PendingNonceAt(ctx, d.signer.Address())
-	if err != nil {
-		return nil, fmt.Errorf("get nonce for RollupCreator: %w", err)
-	}
-	rollupCreatorAddr, txHash, err := d.deployContract(
-		ctx, client,
-		d.artifacts.RollupCreator,
-		nonce, gasPrice, chainID,
-		rollupCreatorArgs,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("deploy RollupCreator: %w", err)
+	if err := step("RollupCreator", d.artifacts.RollupCreator, rollupCreatorArgs); err != nil {
+		return nil, err
 	}
 
-	deployed["RollupCreator"] = rollupCreatorAddr
-
-	d.logger.Info("Infrastructure deployment complete!",
-		slog.String("rollup_creator", rollupCreatorAddr.Hex()),
-		slog.Int("total_contracts", len(deployed)),
-	)
-
-	return &InfrastructureResult{
-		RollupCreatorAddress: rollupCreatorAddr,
-		BridgeCreatorAddress: deployed["BridgeCreator"],
-		Version:              d.artifacts.Version,
-		DeploymentTxHash:     txHash,
-		AlreadyDeployed:      false,
-		DeployedContracts:    deployed,
-	}, nil
+	return deployed, nil
 }
 
 // isArbitrumChain checks if the connected chain is an Arbitrum chain.
@@ -521,7 +554,7 @@ func (d *InfrastructureDeployer) deployReader4844(
 		Data:     reader4844Bytecode,
 	})
 	if err != nil {
-		gasLimit = 200000 // Default for small contract
+		gasLimit = defaultReader4844Gas
 	}
 	gasLimit = gasLimit * 120 / 100
 
@@ -580,7 +613,7 @@ func (d *InfrastructureDeployer) deployContract(
 	})
 	if err != nil {
 		// Use a high default if estimation fails (common for contract deployment)
-		gasLimit = 10_000_000
+		gasLimit = defaultContractGas
 		d.logger.Warn("gas estimation failed, using default",
 			slog.Uint64("gas_limit", gasLimit),
 			slog.Int("bytecode_length", len(data)),
@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	boostrepo "github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/verifier"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
 
@@ -52,6 +53,10 @@ type OrchestratorConfig struct {
 	// NitroInfraRepo is the repository for Nitro infrastructure (RollupCreator addresses).
 	// Only used when UseGoDeployer is true.
 	NitroInfraRepo repository.NitroInfrastructureRepository
+
+	// Verifier submits deployed contracts for source verification.
+	// Optional: verification is skipped when nil.
+	Verifier *verifier.ContractVerifier
 }
 
 // Orchestrator coordinates Nitro/Orbit chain deployments.
@@ -385,6 +390,8 @@ func (o *Orchestrator) deployWithGo(
 	}
 
 	// If no RollupCreator found, we need to deploy infrastructure
+	// Infrastructure deployed by this run, verified along with the rollup
+	var infra *InfrastructureResult
 	if rollupCreatorAddr == (common.Address{}) {
 		reportProgress("infrastructure", 0.45, "Deploying 22 infrastructure contracts...")
 
@@ -404,6 +411,7 @@ func (o *Orchestrator) deployWithGo(
 		}
 
 		rollupCreatorAddr = infraResult.RollupCreatorAddress
+		infra = infraResult
 	}
 
 	reportProgress("deploying", 0.70, "Calling createRollup()...")
@@ -464,6 +472,8 @@ func (o *Orchestrator) deployWithGo(
 		)
 	}
 
+	o.verifyContracts(ctx, deploymentID, uint64(config.ParentChainID), result.Contracts, infra)
+
 	reportProgress("completed", 1.0, fmt.Sprintf("Deployment successful! Rollup: %s", coreContracts.Rollup))
 
 	o.logger.Info("Nitro deployment completed successfully (Go deployer)",
@@ -0,0 +1,114 @@
+package nitro
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	boostrepo "github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/verifier"
+)
+
+// verificationContracts lists the parent chain contracts of a deployment to
+// verify. The RollupCreator puts the core contracts behind
+// TransparentUpgradeableProxy instances, so those are verified against the
+// proxy source. Infrastructure contracts are keyed by deployment step name and
+// are only included when they were deployed as part of this deployment, with
+// the constructor arguments they were deployed with.
+func verificationContracts(rollup *RollupContracts, infra *InfrastructureResult) []verifier.Contract {
+	var contracts []verifier.Contract
+	add := func(label, name string, addr common.Address, args []byte) {
+		if addr == (common.Address{}) {
+			return
+		}
+		contracts = append(contracts, verifier.Contract{
+			Label:           label,
+			Name:            name,
+			Address:         addr,
+			ConstructorArgs: args,
+		})
+	}
+
+	if rollup != nil {
+		add("Rollup", "RollupProxy", rollup.Rollup, nil)
+		add("Inbox", "TransparentUpgradeableProxy", rollup.Inbox, nil)
+		add("Outbox", "TransparentUpgradeableProxy", rollup.Outbox, nil)
+		add("Bridge", "TransparentUpgradeableProxy", rollup.Bridge, nil)
+		add("SequencerInbox", "TransparentUpgradeableProxy", rollup.SequencerInbox, nil)
+		add("RollupEventInbox", "TransparentUpgradeableProxy", rollup.RollupEventInbox, nil)
+		add("ChallengeManager", "TransparentUpgradeableProxy", rollup.ChallengeManager, nil)
+		add("UpgradeExecutor", "TransparentUpgradeableProxy", rollup.UpgradeExecutor, nil)
+		add("AdminProxy", "ProxyAdmin", rollup.AdminProxy, nil)
+		add("ValidatorWalletCreator", "ValidatorWalletCreator", rollup.ValidatorWalletCreator, nil)
+	}
+
+	if infra == nil {
+		return contracts
+	}
+	names := make([]string, 0, len(infra.DeployedContracts))
+	for name := range infra.DeployedContracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		contractName := name
+		if source, ok := infraSourceNames[name]; ok {
+			contractName = source
+		}
+		add(name, contractName, infra.DeployedContracts[name], infra.ConstructorArgs[name])
+	}
+
+	return contracts
+}
+
+// infraSourceNames maps infrastructure deployment steps that reuse another
+// contract's artifact to that contract's name.
+var infraSourceNames = map[string]string{
+	"SequencerInboxDelay": "SequencerInbox",
+}
+
+// verifyContracts submits the deployed parent chain contracts for source
+// verification and records the results as an artifact. Verification is best
+// effort and never fails the deployment.
+func (o *Orchestrator) verifyContracts(
+	ctx context.Context,
+	deploymentID uuid.UUID,
+	parentChainID uint64,
+	rollup *RollupContracts,
+	infra *InfrastructureResult,
+) {
+	if o.config.Verifier == nil {
+		return
+	}
+
+	report, err := o.config.Verifier.Verify(ctx, parentChainID, verificationContracts(rollup, infra))
+	if err != nil {
+		o.logger.Warn("contract verification failed", slog.String("error", err.Error()))
+		return
+	}
+	if report == nil {
+		return
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		o.logger.Warn("failed to marshal verification report", slog.String("error", err.Error()))
+		return
+	}
+
+	artifact := &boostrepo.Artifact{
+		ID:           uuid.New(),
+		DeploymentID: deploymentID,
+		ArtifactType: verifier.ArtifactType,
+		Content:      content,
+		CreatedAt:    time.Now(),
+	}
+	if err := o.repo.SaveArtifact(ctx, artifact); err != nil {
+		o.logger.Warn("failed to save verification artifact", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,40 @@
+package nitro
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationContracts_InfraConstructorArgs(t *testing.T) {
+	inboxArgs := []byte{0x01, 0x02}
+	delayArgs := []byte{0x03}
+	infra := &InfrastructureResult{
+		DeployedContracts: map[string]common.Address{
+			"Inbox":               common.HexToAddress("0x1111111111111111111111111111111111111111"),
+			"SequencerInboxDelay": common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			"Bridge":              common.HexToAddress("0x3333333333333333333333333333333333333333"),
+		},
+		ConstructorArgs: map[string][]byte{
+			"Inbox":               inboxArgs,
+			"SequencerInboxDelay": delayArgs,
+		},
+	}
+
+	contracts := verificationContracts(nil, infra)
+	require.Len(t, contracts, 3)
+
+	byLabel := make(map[string]int)
+	for i, c := range contracts {
+		byLabel[c.Label] = i
+	}
+
+	assert.Equal(t, inboxArgs, contracts[byLabel["Inbox"]].ConstructorArgs)
+	assert.Nil(t, contracts[byLabel["Bridge"]].ConstructorArgs)
+
+	delay := contracts[byLabel["SequencerInboxDelay"]]
+	assert.Equal(t, "SequencerInbox", delay.Name)
+	assert.Equal(t, delayArgs, delay.ConstructorArgs)
+}
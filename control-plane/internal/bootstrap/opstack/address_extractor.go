@@ -50,6 +50,7 @@ func (e *ArtifactExtractor) extractContractAddresses(ctx context.Context, deploy
 		addrs.SystemConfigProxy = getAddressFromState(chainState, "SystemConfigProxy")
 		addrs.OptimismMintableERC20Factory = getAddressFromState(chainState, "OptimismMintableErc20FactoryProxy")
 		addrs.AddressManager = getAddressFromState(chainState, "AddressManagerImpl")
+		addrs.ProxyAdmin = getAddressFromState(chainState, "OpChainProxyAdminImpl")
 
 		// OpChainFaultProofsContracts
 		addrs.DisputeGameFactoryProxy = getAddressFromState(chainState, "DisputeGameFactoryProxy")
@@ -61,6 +62,7 @@ func (e *ArtifactExtractor) extractContractAddresses(ctx context.Context, deploy
 		if superchain != nil {
 			addrs.SuperchainConfig = getAddressFromState(superchain, "SuperchainConfigProxy")
 			addrs.ProtocolVersions = getAddressFromState(superchain, "ProtocolVersionsProxy")
+			addrs.SuperchainProxyAdmin = getAddressFromState(superchain, "SuperchainProxyAdminImpl")
 		}
 	}
 
@@ -0,0 +1,155 @@
+package opstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// diffArtifacts are the artifacts compared by Diff, in output order.
+var diffArtifacts = []string{"addresses.json", "rollup.json", "deploy-config.json"}
+
+// diffIgnoredPaths lists fields excluded from Diff per artifact: values
+// that differ on every deployment or must not be echoed back.
+var diffIgnoredPaths = map[string]map[string]bool{
+	"deploy-config.json": {
+		"popsigner_api_key": true,
+	},
+}
+
+// ArtifactDiff lists the differences between two deployments' artifacts.
+type ArtifactDiff struct {
+	DeploymentA uuid.UUID        `json:"deployment_a"`
+	DeploymentB uuid.UUID        `json:"deployment_b"`
+	Changes     []ArtifactChange `json:"changes"`
+}
+
+// ArtifactChange is a single differing field. Path is the field's JSON path
+// within the artifact, e.g. "genesis.l1.hash" or "chains[0].id", and is
+// empty when the whole artifact exists for one deployment only. A or B is
+// nil when the field is missing on that side.
+type ArtifactChange struct {
+	Artifact string      `json:"artifact"`
+	Path     string      `json:"path"`
+	A        interface{} `json:"a"`
+	B        interface{} `json:"b"`
+}
+
+// Diff compares the addresses.json, rollup.json and deploy-config.json
+// artifacts of two deployments.
+func (e *ArtifactExtractor) Diff(ctx context.Context, deploymentA, deploymentB uuid.UUID) (*ArtifactDiff, error) {
+	diff := &ArtifactDiff{
+		DeploymentA: deploymentA,
+		DeploymentB: deploymentB,
+		Changes:     []ArtifactChange{},
+	}
+
+	for _, name := range diffArtifacts {
+		a, err := e.loadDiffArtifact(ctx, deploymentA, name)
+		if err != nil {
+			return nil, err
+		}
+		b, err := e.loadDiffArtifact(ctx, deploymentB, name)
+		if err != nil {
+			return nil, err
+		}
+		ignored := diffIgnoredPaths[name]
+		if (a == nil) != (b == nil) {
+			diff.Changes = append(diff.Changes, ArtifactChange{
+				Artifact: name,
+				A:        withoutIgnored(a, ignored),
+				B:        withoutIgnored(b, ignored),
+			})
+			continue
+		}
+		diffJSON(name, "", a, b, ignored, &diff.Changes)
+	}
+
+	return diff, nil
+}
+
+// loadDiffArtifact decodes an artifact, returning nil if it doesn't exist.
+func (e *ArtifactExtractor) loadDiffArtifact(ctx context.Context, deploymentID uuid.UUID, name string) (interface{}, error) {
+	artifact, err := e.repo.GetArtifact(ctx, deploymentID, name)
+	if err != nil {
+		return nil, fmt.Errorf("get %s for %s: %w", name, deploymentID, err)
+	}
+	if artifact == nil {
+		return nil, nil
+	}
+
+	// Keep numbers as written so large values such as gas limits compare exactly
+	dec := json.NewDecoder(bytes.NewReader(artifact.Content))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("decode %s for %s: %w", name, deploymentID, err)
+	}
+	return v, nil
+}
+
+// diffJSON appends the differences between two decoded JSON values.
+func diffJSON(artifact, path string, a, b interface{}, ignored map[string]bool, out *[]ArtifactChange) {
+	if ignored[path] {
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				diffJSON(artifact, joinPath(path, k), av[k], bv[k], ignored, out)
+			}
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok && len(av) == len(bv) {
+			for i := range av {
+				diffJSON(artifact, fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], ignored, out)
+			}
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*out = append(*out, ArtifactChange{Artifact: artifact, Path: path, A: a, B: b})
+	}
+}
+
+// withoutIgnored returns v without its ignored top-level fields.
+func withoutIgnored(v interface{}, ignored map[string]bool) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(ignored) == 0 {
+		return v
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, val := range m {
+		if !ignored[k] {
+			out[k] = val
+		}
+	}
+	return out
+}
+
+// joinPath appends an object key to a JSON path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -62,28 +63,44 @@ func (e *ArtifactExtractor) ExtractArtifacts(
 	}
 	artifacts.DeployConfig = cfgJSON
 
-	// 5. Generate JWT secret for Engine API
-	artifacts.JWTSecret = generateJWTSecret()
-
-	// 6. Generate Docker Compose
-	compose, err := GenerateDockerCompose(cfg, artifacts)
-	if err != nil {
-		return nil, fmt.Errorf("generate docker-compose: %w", err)
+	// 5. Generate JWT secret for Engine API, unless the config fixes one
+	if cfg.JWTSecret != "" {
+		secret, err := normalizeJWTSecret(cfg.JWTSecret)
+		if err != nil {
+			return nil, fmt.Errorf("jwt secret: %w", err)
+		}
+		artifacts.JWTSecret = secret
+	} else {
+		artifacts.JWTSecret = generateJWTSecret()
 	}
-	artifacts.DockerCompose = compose
-
-	// 7. Generate .env.example
-	artifacts.EnvExample = GenerateEnvExample(cfg, &addrs)
 
-	// 8. Generate op-alt-da config.toml (Celestia DA - always enabled for POPKins)
+	// 6. Generate op-alt-da config.toml (Celestia DA - always enabled for POPKins)
 	altDAConfig, err := GenerateAltDAConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("generate altda config: %w", err)
 	}
 	artifacts.AltDAConfig = altDAConfig
 
+	// 7. Generate Docker Compose, or Kubernetes manifests if selected
+	if cfg.OutputFormat == OutputFormatKubernetes {
+		manifests, err := GenerateKubernetesManifests(cfg, artifacts)
+		if err != nil {
+			return nil, fmt.Errorf("generate kubernetes manifests: %w", err)
+		}
+		artifacts.K8sManifests = manifests
+	} else {
+		compose, err := GenerateDockerCompose(cfg, artifacts)
+		if err != nil {
+			return nil, fmt.Errorf("generate docker-compose: %w", err)
+		}
+		artifacts.DockerCompose = compose
+	}
+
+	// 8. Generate .env.example
+	artifacts.EnvExample = GenerateEnvExample(cfg, &addrs)
+
 	// 9. Generate README (POPKins always uses Celestia DA)
-	artifacts.Readme = GenerateBundleReadme(cfg.ChainName, true)
+	artifacts.Readme = GenerateBundleReadme(cfg.ChainName, true, cfg.DataAvailability)
 
 	// 10. Save all artifacts to database
 	if err := e.saveAllArtifacts(ctx, deploymentID, artifacts); err != nil {
@@ -105,22 +122,14 @@ func (e *ArtifactExtractor) GetArtifact(ctx context.Context, deploymentID uuid.U
 	return artifact.Content, nil
 }
 
-// ListArtifacts returns all available artifact types for a deployment.
-func (e *ArtifactExtractor) ListArtifacts(ctx context.Context, deploymentID uuid.UUID) ([]string, error) {
+// ListArtifacts describes the artifacts available for a deployment, with
+// their size and content type. Internal deployer state is skipped.
+func (e *ArtifactExtractor) ListArtifacts(ctx context.Context, deploymentID uuid.UUID) ([]repository.ArtifactInfo, error) {
 	artifacts, err := e.repo.GetAllArtifacts(ctx, deploymentID)
 	if err != nil {
 		return nil, fmt.Errorf("get artifacts: %w", err)
 	}
-
-	types := make([]string, 0, len(artifacts))
-	for _, a := range artifacts {
-		// Skip internal artifacts
-		if a.ArtifactType == "deployment_state" {
-			continue
-		}
-		types = append(types, a.ArtifactType)
-	}
-	return types, nil
+	return repository.ListArtifactInfo(artifacts), nil
 }
 
 // CreateBundle packages all artifacts into a ZIP bundle.
@@ -156,6 +165,9 @@ func (e *ArtifactExtractor) CreateBundle(ctx context.Context, deploymentID uuid.
 		case "docker-compose.yml":
 			path = bundlePrefix + "docker-compose.yml"
 			isPlainText = true
+		case "k8s.yaml":
+			path = bundlePrefix + "k8s.yaml"
+			isPlainText = true
 		case ".env.example":
 			path = bundlePrefix + ".env.example"
 			isPlainText = true
@@ -230,6 +242,13 @@ func (e *ArtifactExtractor) saveAllArtifacts(ctx context.Context, deploymentID u
 		}
 	}
 
+	// Save k8s.yaml
+	if arts.K8sManifests != "" {
+		if err := e.saveArtifact(ctx, deploymentID, "k8s.yaml", []byte(arts.K8sManifests)); err != nil {
+			return err
+		}
+	}
+
 	// Save .env.example
 	if arts.EnvExample != "" {
 		if err := e.saveArtifact(ctx, deploymentID, ".env.example", []byte(arts.EnvExample)); err != nil {
@@ -309,6 +328,19 @@ func generateJWTSecret() string {
 	return "0x" + hex.EncodeToString(secret)
 }
 
+// normalizeJWTSecret checks that secret is 32 bytes of hex, with or without
+// a 0x prefix, and returns it in the form generateJWTSecret produces.
+func normalizeJWTSecret(secret string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(secret, "0x"), "0X"))
+	if err != nil {
+		return "", fmt.Errorf("must be hex: %w", err)
+	}
+	if len(raw) != 32 {
+		return "", fmt.Errorf("must be 32 bytes, got %d", len(raw))
+	}
+	return "0x" + hex.EncodeToString(raw), nil
+}
+
 // addToZip adds a file to the ZIP archive.
 func addToZip(zw *zip.Writer, name string, content []byte) error {
 	w, err := zw.Create(name)
@@ -13,6 +13,7 @@ type OPStackArtifacts struct {
 	DeployConfig  json.RawMessage   `json:"deploy_config"`      // Original deployment config
 	JWTSecret     string            `json:"jwt_secret"`         // Engine API JWT secret
 	DockerCompose string            `json:"docker_compose"`     // Generated docker-compose.yml
+	K8sManifests  string            `json:"k8s_manifests"`      // Generated k8s.yaml (kubernetes output format)
 	EnvExample    string            `json:"env_example"`        // .env.example template
 	AltDAConfig   string            `json:"altda_config"`       // op-alt-da config.toml (Celestia)
 	Readme        string            `json:"readme"`             // Bundle README.md
@@ -39,6 +40,10 @@ type ContractAddresses struct {
 	AddressManager               string `json:"address_manager,omitempty"`
 	BatchInbox                   string `json:"batch_inbox"`
 	L2OutputOracle               string `json:"l2_output_oracle,omitempty"` // Legacy, pre-fault proofs
+
+	// Proxy admins, the constructor argument of the proxies they own
+	ProxyAdmin           string `json:"proxy_admin,omitempty"`
+	SuperchainProxyAdmin string `json:"superchain_proxy_admin,omitempty"`
 }
 
 // RollupConfig represents the rollup.json configuration structure.
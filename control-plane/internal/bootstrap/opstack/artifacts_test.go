@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
 )
@@ -95,6 +98,19 @@ func (m *mockArtifactRepository) ListDeploymentsByOrgAndStatus(ctx context.Conte
 	return args.Get(0).([]*repository.Deployment), args.Error(1)
 }
 
+func (m *mockArtifactRepository) ListDeploymentsPage(ctx context.Context, q repository.DeploymentListQuery) ([]*repository.Deployment, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Deployment), args.Error(1)
+}
+
+func (m *mockArtifactRepository) DeleteDeployment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *mockArtifactRepository) MarkStaleDeploymentsFailed(ctx context.Context, orgID uuid.UUID, timeout time.Duration) (int, error) {
 	args := m.Called(ctx, orgID, timeout)
 	return args.Int(0), args.Error(1)
@@ -345,6 +361,51 @@ func TestArtifactExtractor_CreateBundle(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestArtifactExtractor_CreateBundle_CompressedArtifact(t *testing.T) {
+	ctx := context.Background()
+	deploymentID := uuid.New()
+	mockRepo := new(mockArtifactRepository)
+	extractor := NewArtifactExtractor(mockRepo)
+
+	// A genesis.json large enough to be stored compressed
+	var b strings.Builder
+	b.WriteString(`{"alloc": {`)
+	for i := 0; b.Len() < 2*repository.ArtifactCompressionThreshold; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(`"0x` + strings.Repeat("ab", 20) + `": {"balance": "0x1"}`)
+	}
+	b.WriteString(`}}`)
+	genesis := json.RawMessage(b.String())
+
+	// Round-trip through storage encoding as the repository does
+	stored, encoding, err := repository.EncodeArtifactContent(genesis)
+	require.NoError(t, err)
+	require.Equal(t, repository.ContentEncodingGzip, encoding)
+	loaded, err := repository.DecodeArtifactContent(stored, encoding)
+	require.NoError(t, err)
+
+	mockRepo.On("GetAllArtifacts", ctx, deploymentID).Return([]repository.Artifact{
+		{DeploymentID: deploymentID, ArtifactType: "genesis.json", Content: loaded},
+	}, nil)
+
+	bundle, err := extractor.CreateBundle(ctx, deploymentID, "my-chain")
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 1)
+	assert.Equal(t, "my-chain-opstack-bundle/genesis.json", zr.File[0].Name)
+
+	rc, err := zr.File[0].Open()
+	require.NoError(t, err)
+	defer rc.Close()
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(genesis), content)
+}
+
 func TestArtifactExtractor_CreateBundle_NoArtifacts(t *testing.T) {
 	ctx := context.Background()
 	deploymentID := uuid.New()
@@ -405,26 +466,98 @@ func TestArtifactExtractor_ListArtifacts(t *testing.T) {
 	mockRepo := new(mockArtifactRepository)
 	extractor := NewArtifactExtractor(mockRepo)
 
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 	artifacts := []repository.Artifact{
-		{ArtifactType: "genesis.json"},
-		{ArtifactType: "rollup.json"},
-		{ArtifactType: "deployment_state"}, // Should be filtered out
-		{ArtifactType: "addresses.json"},
+		{ArtifactType: "genesis.json", Content: json.RawMessage(`{"config":{}}`), CreatedAt: createdAt},
+		{ArtifactType: "rollup.json", Content: json.RawMessage(`{}`), CreatedAt: createdAt},
+		{ArtifactType: "deployment_state", Content: json.RawMessage(`{}`)},    // Should be filtered out
+		{ArtifactType: "opdeployer_state", Content: json.RawMessage(`{}`)},    // Should be filtered out
+		{ArtifactType: "jwt.txt", Content: json.RawMessage(`"0xdeadbeef\n"`)}, // Stored as a JSON string
+		{ArtifactType: "addresses.json", Content: json.RawMessage(`{"a":"0x1"}`)},
 	}
 
 	mockRepo.On("GetAllArtifacts", ctx, deploymentID).Return(artifacts, nil)
 
-	types, err := extractor.ListArtifacts(ctx, deploymentID)
+	infos, err := extractor.ListArtifacts(ctx, deploymentID)
 	require.NoError(t, err)
-	assert.Len(t, types, 3)
-	assert.Contains(t, types, "genesis.json")
-	assert.Contains(t, types, "rollup.json")
-	assert.Contains(t, types, "addresses.json")
-	assert.NotContains(t, types, "deployment_state")
+	assert.Equal(t, []repository.ArtifactInfo{
+		{Type: "genesis.json", Size: 13, ContentType: "application/json", CreatedAt: createdAt},
+		{Type: "rollup.json", Size: 2, ContentType: "application/json", CreatedAt: createdAt},
+		{Type: "jwt.txt", Size: 11, ContentType: "text/plain; charset=utf-8"},
+		{Type: "addresses.json", Size: 11, ContentType: "application/json"},
+	}, infos)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestArtifactExtractor_Diff(t *testing.T) {
+	ctx := context.Background()
+	deploymentA, deploymentB := uuid.New(), uuid.New()
+	mockRepo := new(mockArtifactRepository)
+	extractor := NewArtifactExtractor(mockRepo)
+
+	rollup := `{"block_time": 2, "l2_chain_id": 42069, "genesis": {"l1": {"number": 100}}}`
+	artifacts := map[uuid.UUID]map[string]string{
+		deploymentA: {
+			"addresses.json":     `{"optimism_portal_proxy": "0x1111111111111111111111111111111111111111", "batch_inbox": "0x00aa"}`,
+			"rollup.json":        rollup,
+			"deploy-config.json": `{"chain_id": 42069, "gas_limit": 30000000, "popsigner_api_key": "psk_a"}`,
+		},
+		deploymentB: {
+			"addresses.json":     `{"optimism_portal_proxy": "0x2222222222222222222222222222222222222222", "batch_inbox": "0x00aa"}`,
+			"rollup.json":        rollup,
+			"deploy-config.json": `{"chain_id": 42069, "gas_limit": 30000000, "popsigner_api_key": "psk_b"}`,
+		},
+	}
+	for id, byName := range artifacts {
+		for name, content := range byName {
+			mockRepo.On("GetArtifact", ctx, id, name).Return(&repository.Artifact{
+				DeploymentID: id,
+				ArtifactType: name,
+				Content:      json.RawMessage(content),
+			}, nil)
+		}
+	}
+
+	diff, err := extractor.Diff(ctx, deploymentA, deploymentB)
+	require.NoError(t, err)
+
+	assert.Equal(t, deploymentA, diff.DeploymentA)
+	assert.Equal(t, deploymentB, diff.DeploymentB)
+	assert.Equal(t, []ArtifactChange{{
+		Artifact: "addresses.json",
+		Path:     "optimism_portal_proxy",
+		A:        "0x1111111111111111111111111111111111111111",
+		B:        "0x2222222222222222222222222222222222222222",
+	}}, diff.Changes)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestArtifactExtractor_Diff_NestedAndMissing(t *testing.T) {
+	ctx := context.Background()
+	deploymentA, deploymentB := uuid.New(), uuid.New()
+	mockRepo := new(mockArtifactRepository)
+	extractor := NewArtifactExtractor(mockRepo)
+
+	mockRepo.On("GetArtifact", ctx, deploymentA, "addresses.json").Return(&repository.Artifact{Content: json.RawMessage(`{"batch_inbox": "0x00aa"}`)}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentB, "addresses.json").Return(&repository.Artifact{Content: json.RawMessage(`{"batch_inbox": "0x00aa"}`)}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentA, "rollup.json").Return(&repository.Artifact{Content: json.RawMessage(`{"genesis": {"l1": {"number": 100}}, "chains": [1, 2]}`)}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentB, "rollup.json").Return(&repository.Artifact{Content: json.RawMessage(`{"genesis": {"l1": {"number": 250}}, "chains": [1, 3], "fjord_time": 0}`)}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentA, "deploy-config.json").Return(&repository.Artifact{Content: json.RawMessage(`{"chain_id": 1, "popsigner_api_key": "psk_a"}`)}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentB, "deploy-config.json").Return(nil, nil)
+
+	diff, err := extractor.Diff(ctx, deploymentA, deploymentB)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ArtifactChange{
+		{Artifact: "rollup.json", Path: "chains[1]", A: json.Number("2"), B: json.Number("3")},
+		{Artifact: "rollup.json", Path: "fjord_time", A: nil, B: json.Number("0")},
+		{Artifact: "rollup.json", Path: "genesis.l1.number", A: json.Number("100"), B: json.Number("250")},
+		{Artifact: "deploy-config.json", Path: "", A: map[string]interface{}{"chain_id": json.Number("1")}, B: nil},
+	}, diff.Changes)
+}
+
 func TestGenerateJWTSecret(t *testing.T) {
 	secret1 := generateJWTSecret()
 	secret2 := generateJWTSecret()
@@ -441,6 +574,87 @@ func TestGenerateJWTSecret(t *testing.T) {
 	assert.NotEqual(t, secret1, secret2)
 }
 
+// extractJWTSecret runs ExtractArtifacts against a minimal deployment and
+// returns the jwt.txt it saved.
+func extractJWTSecret(t *testing.T, cfg *DeploymentConfig) string {
+	t.Helper()
+	ctx := context.Background()
+	deploymentID := uuid.New()
+	mockRepo := new(mockArtifactRepository)
+
+	mockRepo.On("GetArtifact", ctx, deploymentID, "genesis.json").Return(&repository.Artifact{
+		ArtifactType: "genesis.json",
+		Content:      json.RawMessage(`{"config": {"chainId": 12345}}`),
+	}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentID, "deployment_state").Return(&repository.Artifact{
+		ArtifactType: "deployment_state",
+		Content:      json.RawMessage(`{"OptimismPortalProxy": "0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}`),
+	}, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentID, "rollup.json").Return(nil, nil)
+	mockRepo.On("GetArtifact", ctx, deploymentID, "rollup_config").Return(nil, nil)
+	mockRepo.On("GetDeployment", ctx, deploymentID).Return(&repository.Deployment{
+		ID:      deploymentID,
+		ChainID: 12345,
+		Status:  repository.StatusCompleted,
+	}, nil)
+
+	var jwt []byte
+	mockRepo.On("SaveArtifact", ctx, mock.AnythingOfType("*repository.Artifact")).Run(func(args mock.Arguments) {
+		if a := args.Get(1).(*repository.Artifact); a.ArtifactType == "jwt.txt" {
+			jwt = a.RawContent()
+		}
+	}).Return(nil)
+
+	_, err := NewArtifactExtractor(mockRepo).ExtractArtifacts(ctx, deploymentID, cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, jwt)
+	return string(jwt)
+}
+
+func TestArtifactExtractor_ExtractArtifacts_JWTSecret(t *testing.T) {
+	cfg := &DeploymentConfig{
+		ChainID:         12345,
+		ChainName:       "test-chain",
+		L1ChainID:       11155111,
+		L1RPC:           "https://eth-sepolia.example.com",
+		DeployerAddress: "0x1234567890123456789012345678901234567890",
+	}
+	cfg.ApplyDefaults()
+
+	// Random by default
+	assert.NotEqual(t, extractJWTSecret(t, cfg), extractJWTSecret(t, cfg))
+
+	// A fixed secret makes jwt.txt reproducible, in canonical form
+	cfg.JWTSecret = "AB" + strings.Repeat("01", 31)
+	first := extractJWTSecret(t, cfg)
+	assert.Equal(t, "0xab"+strings.Repeat("01", 31), first)
+	assert.Equal(t, first, extractJWTSecret(t, cfg))
+}
+
+func TestDeploymentConfig_ValidateJWTSecret(t *testing.T) {
+	tests := []struct {
+		secret  string
+		wantErr bool
+	}{
+		{"", false},
+		{"0x" + strings.Repeat("ab", 32), false},
+		{strings.Repeat("ab", 32), false},
+		{"0x" + strings.Repeat("ab", 16), true},
+		{"0x" + strings.Repeat("zz", 32), true},
+	}
+
+	for _, tc := range tests {
+		cfg := createTestDeploymentConfig()
+		cfg.JWTSecret = tc.secret
+		err := cfg.Validate()
+		if tc.wantErr {
+			assert.ErrorContains(t, err, "jwt_secret", tc.secret)
+		} else {
+			assert.NoError(t, err, tc.secret)
+		}
+	}
+}
+
 func TestCalculateBatchInboxAddress(t *testing.T) {
 	tests := []struct {
 		chainID  uint64
@@ -575,6 +789,64 @@ func TestGenerateEnvExample(t *testing.T) {
 	assert.Contains(t, env, "CHAIN_ID=12345")
 }
 
+func TestGenerateBundleConfig_Avail(t *testing.T) {
+	cfg := &DeploymentConfig{
+		ChainID:             12345,
+		ChainName:           "test-chain",
+		L1ChainID:           11155111,
+		L1RPC:               "https://eth-sepolia.example.com",
+		DataAvailability:    DataAvailabilityAvail,
+		AvailLightClientURL: "http://avail-light:7007",
+		AvailAppID:          42,
+	}
+
+	daConfig, err := GenerateAltDAConfig(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, daConfig, "[avail]")
+	assert.Contains(t, daConfig, `light_client_url = "http://avail-light:7007"`)
+	assert.Contains(t, daConfig, "app_id = 42")
+	assert.Contains(t, daConfig, `network = "turing"`)
+	assert.NotContains(t, daConfig, "[celestia]")
+
+	env := GenerateEnvExample(cfg, &ContractAddresses{})
+	assert.Contains(t, env, "AVAIL_LIGHT_CLIENT_URL=http://avail-light:7007")
+	assert.Contains(t, env, "AVAIL_APP_ID=42")
+	assert.Contains(t, env, "AVAIL_NETWORK=turing")
+	assert.NotContains(t, env, "CELESTIA_")
+
+	readme := GenerateBundleReadme(cfg.ChainName, true, cfg.DataAvailability)
+	assert.Contains(t, readme, "## Avail DA")
+	assert.Contains(t, readme, "avail-light --network turing --app-id")
+	assert.NotContains(t, readme, "Celestia")
+	assert.NotContains(t, readme, "keyring")
+}
+
+func TestGenerateBundleConfig_AvailPlaceholders(t *testing.T) {
+	cfg := &DeploymentConfig{
+		ChainID:          12345,
+		ChainName:        "test-chain",
+		L1ChainID:        1,
+		DataAvailability: DataAvailabilityAvail,
+	}
+
+	daConfig, err := GenerateAltDAConfig(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, daConfig, `light_client_url = "${AVAIL_LIGHT_CLIENT_URL}"`)
+	assert.Contains(t, daConfig, `app_id = "${AVAIL_APP_ID}"`)
+	assert.Contains(t, daConfig, `network = "mainnet"`)
+
+	env := GenerateEnvExample(cfg, &ContractAddresses{})
+	assert.Contains(t, env, "AVAIL_LIGHT_CLIENT_URL=<REQUIRED>")
+	assert.Contains(t, env, "AVAIL_APP_ID=<REQUIRED>")
+}
+
+func TestGenerateBundleReadme_Celestia(t *testing.T) {
+	readme := GenerateBundleReadme("test-chain", true, "")
+	assert.Contains(t, readme, "## Celestia DA")
+	assert.Contains(t, readme, "**Celestia signing**")
+	assert.NotContains(t, readme, "## Avail DA")
+}
+
 func TestContractAddresses_JSONMarshaling(t *testing.T) {
 	addrs := ContractAddresses{
 		OptimismPortalProxy:         "0xAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
@@ -640,3 +912,104 @@ func TestRollupConfig_JSONMarshaling(t *testing.T) {
 	assert.Equal(t, rollup.Genesis.SystemConfig.BatcherAddr, decoded.Genesis.SystemConfig.BatcherAddr)
 }
 
+func TestGenerateKubernetesManifests(t *testing.T) {
+	cfg := &DeploymentConfig{
+		ChainID:         12345,
+		ChainName:       "Test_Chain",
+		L1ChainID:       11155111,
+		L1RPC:           "https://eth-sepolia.example.com",
+		DeployerAddress: "0x1234567890123456789012345678901234567890",
+		OutputFormat:    OutputFormatKubernetes,
+	}
+	cfg.ApplyDefaults()
+
+	altDAConfig, err := GenerateAltDAConfig(cfg)
+	require.NoError(t, err)
+
+	artifacts := &OPStackArtifacts{
+		Rollup:      json.RawMessage(`{"l2_chain_id": 12345}`),
+		JWTSecret:   "0x" + strings.Repeat("ab", 32),
+		AltDAConfig: altDAConfig,
+	}
+
+	manifests, err := GenerateKubernetesManifests(cfg, artifacts)
+	require.NoError(t, err)
+
+	// Parse every document and index them by kind/name
+	docs := map[string]map[string]any{}
+	dec := yaml.NewDecoder(strings.NewReader(manifests))
+	for {
+		var doc map[string]any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		if doc == nil {
+			continue
+		}
+		metadata := doc["metadata"].(map[string]any)
+		docs[doc["kind"].(string)+"/"+metadata["name"].(string)] = doc
+	}
+
+	for _, svc := range []string{"op-geth", "op-node", "op-batcher", "op-proposer", "op-alt-da"} {
+		assert.Contains(t, docs, "Deployment/"+svc)
+		assert.Contains(t, docs, "Service/"+svc)
+	}
+
+	// JWT secret
+	jwt := docs["Secret/test-chain-jwt"]
+	require.NotNil(t, jwt)
+	assert.Equal(t, artifacts.JWTSecret, jwt["stringData"].(map[string]any)["jwt.txt"])
+
+	// Celestia config and rollup config
+	config := docs["ConfigMap/test-chain-config"]
+	require.NotNil(t, config)
+	data := config["data"].(map[string]any)
+	assert.Equal(t, altDAConfig, data["config.toml"])
+	assert.JSONEq(t, string(artifacts.Rollup), data["rollup.json"].(string))
+
+	podSpec := func(name string) map[string]any {
+		spec := docs["Deployment/"+name]["spec"].(map[string]any)
+		return spec["template"].(map[string]any)["spec"].(map[string]any)
+	}
+	volumeSource := func(spec map[string]any, volume string) map[string]any {
+		for _, v := range spec["volumes"].([]any) {
+			v := v.(map[string]any)
+			if v["name"] == volume {
+				return v
+			}
+		}
+		t.Fatalf("volume %s not found", volume)
+		return nil
+	}
+
+	// op-geth and op-node mount the JWT secret
+	for _, name := range []string{"op-geth", "op-node"} {
+		jwtVolume := volumeSource(podSpec(name), "jwt")
+		assert.Equal(t, "test-chain-jwt", jwtVolume["secret"].(map[string]any)["secretName"], name)
+	}
+	assert.Contains(t, manifests, "--authrpc.jwtsecret=/config/jwt.txt")
+	assert.Contains(t, manifests, "--l2.jwt-secret=/config/jwt.txt")
+
+	// op-alt-da mounts config.toml from the config map
+	altDA := podSpec("op-alt-da")
+	assert.Equal(t, "test-chain-config", volumeSource(altDA, "config")["configMap"].(map[string]any)["name"])
+	mount := altDA["containers"].([]any)[0].(map[string]any)["volumeMounts"].([]any)[0].(map[string]any)
+	assert.Equal(t, "/config/config.toml", mount["mountPath"])
+	assert.Equal(t, "config.toml", mount["subPath"])
+
+	// Same env wiring as compose, in Kubernetes syntax
+	assert.Contains(t, manifests, "--signer.address=$(BATCHER_ADDRESS)")
+	assert.Contains(t, manifests, "--altda.da-server=http://op-alt-da:3100")
+}
+
+func TestGenerateKubernetesManifests_RequiresArtifacts(t *testing.T) {
+	cfg := &DeploymentConfig{ChainID: 12345, ChainName: "test-chain"}
+
+	_, err := GenerateKubernetesManifests(cfg, nil)
+	require.Error(t, err)
+
+	_, err = GenerateKubernetesManifests(cfg, &OPStackArtifacts{Rollup: json.RawMessage(`{}`)})
+	require.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package opstack
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+)
+
+// availDAConfigTemplate is the config.toml template for the DA server when
+// the chain uses Avail. Blobs are submitted and read through an Avail light
+// client running with the chain's app ID.
+const availDAConfigTemplate = `# ==============================================================================
+# Avail DA Server Configuration
+# ==============================================================================
+#
+# Generated by POPKins - https://popkins.popsigner.com
+#
+# This server submits blobs to Avail and retrieves them on demand through an
+# Avail light client. Blobs are scoped to your chain's application ID.
+#
+# ==============================================================================
+
+# Server listening address (use 0.0.0.0 for Docker)
+addr = "0.0.0.0"
+
+# Server listening port
+port = 3100
+
+# Log level: debug, info, warn, error
+log_level = "info"
+
+# ==============================================================================
+# AVAIL CONFIGURATION
+# ==============================================================================
+
+[avail]
+# Avail light client HTTP API (avail-light, default port 7007)
+light_client_url = "{{ .AvailLightClientURL }}"
+
+# Application ID the chain's blobs are submitted under
+app_id = {{ .AvailAppID }}
+
+# Avail network: turing (testnet) or mainnet
+network = "{{ .AvailNetwork }}"
+
+# Time to wait for blob submission to be finalized
+timeout = "100s"
+
+# ==============================================================================
+# METRICS CONFIGURATION
+# ==============================================================================
+
+[metrics]
+enabled = true
+port = 6060
+`
+
+// AvailDAConfigVars holds variables for the Avail config.toml template.
+type AvailDAConfigVars struct {
+	AvailLightClientURL string // Avail light client HTTP API
+	AvailAppID          string // TOML value: the app ID, or a quoted placeholder
+	AvailNetwork        string // turing or mainnet
+}
+
+// availNetwork returns the Avail network matching the L1.
+func availNetwork(l1ChainID uint64) string {
+	if l1ChainID == 1 {
+		return "mainnet"
+	}
+	return "turing"
+}
+
+// generateAvailDAConfig generates the DA server config.toml for Avail.
+func generateAvailDAConfig(cfg *DeploymentConfig) (string, error) {
+	tmpl, err := template.New("avail-config").Parse(availDAConfigTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+
+	lightClientURL := cfg.AvailLightClientURL
+	if lightClientURL == "" {
+		lightClientURL = "${AVAIL_LIGHT_CLIENT_URL}"
+	}
+
+	appID := `"${AVAIL_APP_ID}"`
+	if cfg.AvailAppID != 0 {
+		appID = strconv.FormatUint(uint64(cfg.AvailAppID), 10)
+	}
+
+	vars := AvailDAConfigVars{
+		AvailLightClientURL: lightClientURL,
+		AvailAppID:          appID,
+		AvailNetwork:        availNetwork(cfg.L1ChainID),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// availEnvSection returns the .env.example section for Avail DA.
+func availEnvSection(cfg *DeploymentConfig) string {
+	lightClientURL := cfg.AvailLightClientURL
+	if lightClientURL == "" {
+		lightClientURL = "<REQUIRED>"
+	}
+	appID := "<REQUIRED>"
+	if cfg.AvailAppID != 0 {
+		appID = strconv.FormatUint(uint64(cfg.AvailAppID), 10)
+	}
+
+	return fmt.Sprintf(`################################################################################
+# AVAIL DA CONFIGURATION - REQUIRED
+################################################################################
+# You need an Avail light client running with your chain's app ID.
+
+# Avail light client HTTP API
+# Example: http://avail-light:7007
+AVAIL_LIGHT_CLIENT_URL=%s
+
+# Avail application ID for your chain's blobs
+AVAIL_APP_ID=%s
+
+# Avail network: turing (testnet) or mainnet
+AVAIL_NETWORK=%s
+`, lightClientURL, appID, availNetwork(cfg.L1ChainID))
+}
+
+// availReadmeSection is the README section describing Avail DA setup.
+const availReadmeSection = `
+## Avail DA
+
+Your chain uses **Avail** as the Data Availability layer. The DA server
+submits blobs through an Avail light client under your chain's application ID.
+
+### Setup
+
+1. **Create an application ID** for your chain on Avail
+   (see https://docs.availproject.org) and set ` + "`AVAIL_APP_ID`" + ` in your ` + "`.env`" + `.
+
+2. **Run an Avail light client** with that app ID:
+` + "```bash" + `
+avail-light --network turing --app-id <AVAIL_APP_ID>
+` + "```" + `
+   Set ` + "`AVAIL_LIGHT_CLIENT_URL`" + ` to its HTTP API (default port 7007).
+
+3. **Fund the light client's account** with AVAIL for blob submission fees.
+
+**Testnet (Turing):**
+- Use the faucet: https://faucet.avail.tools/
+
+**Mainnet:**
+- Transfer AVAIL tokens to the light client's account
+`
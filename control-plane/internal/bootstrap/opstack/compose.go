@@ -486,8 +486,13 @@ type AltDAConfigVars struct {
 }
 
 // GenerateAltDAConfig generates the config.toml for op-alt-da (Celestia).
-// Uses POPSigner for Celestia signing.
+// Uses POPSigner for Celestia signing. Chains using Avail get the Avail DA
+// server config instead.
 func GenerateAltDAConfig(cfg *DeploymentConfig) (string, error) {
+	if cfg.UsesAvail() {
+		return generateAvailDAConfig(cfg)
+	}
+
 	tmpl, err := template.New("altda-config").Parse(opAltDAConfigTemplate)
 	if err != nil {
 		return "", fmt.Errorf("parse template: %w", err)
@@ -6,6 +6,16 @@ import (
 	"math/big"
 )
 
+// DataAvailability selects the DA layer the bundle's op-alt-da server uses.
+type DataAvailability string
+
+const (
+	// DataAvailabilityCelestia uses Celestia (default).
+	DataAvailabilityCelestia DataAvailability = "celestia"
+	// DataAvailabilityAvail uses Avail via an Avail light client.
+	DataAvailabilityAvail DataAvailability = "avail"
+)
+
 // DeploymentConfig contains configuration for an OP Stack deployment.
 type DeploymentConfig struct {
 	// Chain identification
@@ -36,6 +46,12 @@ type DeploymentConfig struct {
 	CelestiaNamespace string `json:"celestia_namespace,omitempty"` // Celestia namespace (hex, auto-generated if empty)
 	CelestiaKeyID     string `json:"celestia_key_id,omitempty"`    // POPSigner Celestia key UUID
 
+	// DataAvailability selects the DA layer: "celestia" (default) or "avail".
+	// Like Celestia, Avail endpoints can also be set at runtime in .env.
+	DataAvailability    DataAvailability `json:"data_availability,omitempty"`
+	AvailLightClientURL string           `json:"avail_light_client_url,omitempty"` // Avail light client HTTP API
+	AvailAppID          uint32           `json:"avail_app_id,omitempty"`           // Avail application ID for the chain's blobs
+
 	// Fee recipients
 	BaseFeeVaultRecipient      string `json:"base_fee_vault_recipient,omitempty"`
 	L1FeeVaultRecipient        string `json:"l1_fee_vault_recipient,omitempty"`
@@ -73,6 +89,15 @@ type DeploymentConfig struct {
 
 	// ExistingSuperchainConfigAddress is the superchain config to join (if reusing)
 	ExistingSuperchainConfigAddress string `json:"existing_superchain_config_address,omitempty"`
+
+	// OutputFormat selects how the bundle defines the chain's services:
+	// "compose" (default) for docker-compose.yml or "kubernetes" for k8s.yaml.
+	OutputFormat string `json:"output_format,omitempty"`
+
+	// JWTSecret fixes the Engine API secret written to jwt.txt (32 bytes,
+	// hex). Leave empty in production so a random secret is generated; set it
+	// in test environments that compare bundles across extractions.
+	JWTSecret string `json:"jwt_secret,omitempty"`
 }
 
 // Validate checks that required fields are set and values are valid.
@@ -104,6 +129,24 @@ func (c *DeploymentConfig) Validate() error {
 		return fmt.Errorf("deployer_address is required")
 	}
 
+	switch c.DataAvailability {
+	case "", DataAvailabilityCelestia, DataAvailabilityAvail:
+	default:
+		return fmt.Errorf("data_availability must be %q or %q", DataAvailabilityCelestia, DataAvailabilityAvail)
+	}
+
+	switch c.OutputFormat {
+	case "", OutputFormatCompose, OutputFormatKubernetes:
+	default:
+		return fmt.Errorf("output_format must be %q or %q", OutputFormatCompose, OutputFormatKubernetes)
+	}
+
+	if c.JWTSecret != "" {
+		if _, err := normalizeJWTSecret(c.JWTSecret); err != nil {
+			return fmt.Errorf("jwt_secret: %w", err)
+		}
+	}
+
 	// Note: Celestia RPC is NOT required for contract deployment
 	// It's only needed at runtime when using the docker-compose bundle
 	// Users configure Celestia in .env when they download the bundle
@@ -184,6 +227,11 @@ func ParseConfig(raw json.RawMessage) (*DeploymentConfig, error) {
 	return &cfg, nil
 }
 
+// UsesAvail reports whether the chain uses Avail for data availability.
+func (c *DeploymentConfig) UsesAvail() bool {
+	return c.DataAvailability == DataAvailabilityAvail
+}
+
 // L1ChainIDBig returns L1ChainID as *big.Int.
 func (c *DeploymentConfig) L1ChainIDBig() *big.Int {
 	return new(big.Int).SetUint64(c.L1ChainID)
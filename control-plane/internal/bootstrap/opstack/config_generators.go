@@ -30,16 +30,10 @@ func GenerateEnvExample(cfg *DeploymentConfig, addrs *ContractAddresses) string
 		l1BeaconURL = "https://ethereum-holesky-beacon-api.publicnode.com"
 	}
 
-	// Generate namespace if not set
-	celestiaNamespace := cfg.CelestiaNamespace
-	if celestiaNamespace == "" {
-		celestiaNamespace = fmt.Sprintf("0000000000000000000000000000000000000000%08x", cfg.ChainID)
-	}
-
-	// Celestia key ID
-	celestiaKeyID := cfg.CelestiaKeyID
-	if celestiaKeyID == "" {
-		celestiaKeyID = "<your-celestia-key-uuid>"
+	// DA layer configuration
+	daSection, daPreconfigured := celestiaEnvSections(cfg, celestiaNetwork)
+	if cfg.UsesAvail() {
+		daSection, daPreconfigured = availEnvSection(cfg), ""
 	}
 
 	return fmt.Sprintf(`################################################################################
@@ -62,24 +56,7 @@ L1_RPC_URL=%s
 # L1 Beacon API endpoint (required for op-node)
 L1_BEACON_URL=%s
 
-################################################################################
-# CELESTIA DA CONFIGURATION - REQUIRED
-################################################################################
-# You need a Celestia node provider (QuickNode, etc.) or run your own node.
-
-# Your Celestia key ID from POPSigner (UUID format)
-CELESTIA_KEY_ID=%s
-
-# Celestia Bridge/Light Node RPC (for reading blobs)
-# Example: https://your-provider.celestia-mocha.quiknode.pro/your-token/
-CELESTIA_BRIDGE_ADDR=<REQUIRED>
-CELESTIA_BRIDGE_AUTH_TOKEN=
-
-# Celestia Core gRPC (for submitting blobs)
-# Example: your-provider.celestia-mocha.quiknode.pro:9090
-CELESTIA_GRPC_ADDR=<REQUIRED>
-CELESTIA_GRPC_AUTH_TOKEN=<your-token-if-required>
-
+%s
 ################################################################################
 # PRE-CONFIGURED - Usually no changes needed
 ################################################################################
@@ -89,13 +66,7 @@ L1_RPC_KIND=basic
 
 # Your L2 chain ID
 CHAIN_ID=%d
-
-# Celestia network: mocha-4 (testnet) or celestia (mainnet)
-CELESTIA_NETWORK=%s
-
-# Celestia namespace (auto-generated from chain ID)
-CELESTIA_NAMESPACE=%s
-
+%s
 # POPSigner RPC endpoint (for op-node, op-batcher signing)
 POPSIGNER_RPC_URL=%s
 
@@ -110,10 +81,9 @@ DISPUTE_GAME_FACTORY_ADDRESS=%s
 		cfg.ChainName,
 		cfg.L1RPC,
 		l1BeaconURL,
-		celestiaKeyID,
+		daSection,
 		cfg.ChainID,
-		celestiaNetwork,
-		celestiaNamespace,
+		daPreconfigured,
 		popsignerRPC,
 		cfg.SequencerAddress,
 		cfg.BatcherAddress,
@@ -122,10 +92,60 @@ DISPUTE_GAME_FACTORY_ADDRESS=%s
 	)
 }
 
+// celestiaEnvSections returns the .env.example sections for Celestia DA: the
+// required endpoints and the pre-configured network and namespace.
+func celestiaEnvSections(cfg *DeploymentConfig, celestiaNetwork string) (required, preconfigured string) {
+	// Generate namespace if not set
+	celestiaNamespace := cfg.CelestiaNamespace
+	if celestiaNamespace == "" {
+		celestiaNamespace = fmt.Sprintf("0000000000000000000000000000000000000000%08x", cfg.ChainID)
+	}
+
+	// Celestia key ID
+	celestiaKeyID := cfg.CelestiaKeyID
+	if celestiaKeyID == "" {
+		celestiaKeyID = "<your-celestia-key-uuid>"
+	}
+
+	required = fmt.Sprintf(`################################################################################
+# CELESTIA DA CONFIGURATION - REQUIRED
+################################################################################
+# You need a Celestia node provider (QuickNode, etc.) or run your own node.
+
+# Your Celestia key ID from POPSigner (UUID format)
+CELESTIA_KEY_ID=%s
+
+# Celestia Bridge/Light Node RPC (for reading blobs)
+# Example: https://your-provider.celestia-mocha.quiknode.pro/your-token/
+CELESTIA_BRIDGE_ADDR=<REQUIRED>
+CELESTIA_BRIDGE_AUTH_TOKEN=
+
+# Celestia Core gRPC (for submitting blobs)
+# Example: your-provider.celestia-mocha.quiknode.pro:9090
+CELESTIA_GRPC_ADDR=<REQUIRED>
+CELESTIA_GRPC_AUTH_TOKEN=<your-token-if-required>
+`, celestiaKeyID)
+
+	preconfigured = fmt.Sprintf(`
+# Celestia network: mocha-4 (testnet) or celestia (mainnet)
+CELESTIA_NETWORK=%s
+
+# Celestia namespace (auto-generated from chain ID)
+CELESTIA_NAMESPACE=%s
+`, celestiaNetwork, celestiaNamespace)
+
+	return required, preconfigured
+}
+
 // GenerateBundleReadme generates the README.md for the artifact bundle.
-func GenerateBundleReadme(chainName string, useAltDA bool) string {
+// When useAltDA is set, da selects the DA layer the setup steps are for.
+func GenerateBundleReadme(chainName string, useAltDA bool, da DataAvailability) string {
+	useAvail := useAltDA && da == DataAvailabilityAvail
+
 	daDescription := "Ethereum calldata"
-	if useAltDA {
+	if useAvail {
+		daDescription = "Avail DA"
+	} else if useAltDA {
 		daDescription = "Celestia DA"
 	}
 
@@ -173,7 +193,9 @@ curl -s http://localhost:9545 -X POST \
 | `+"`.env.example`"+` | Environment variable template |
 `, chainName, daDescription)
 
-	if useAltDA {
+	if useAvail {
+		readme += availReadmeSection
+	} else if useAltDA {
 		readme += `
 ## Celestia DA
 
@@ -208,7 +230,7 @@ All signing operations are handled automatically:
 - **Batcher signing** - L1 batch submissions
 - **Proposer signing** - L1 state root proposals
 `
-	if useAltDA {
+	if useAltDA && !useAvail {
 		readme += `- **Celestia signing** - DA blob submissions
 `
 	}
@@ -265,8 +287,16 @@ docker compose logs -f op-node
 
 - [OP Stack Documentation](https://docs.optimism.io)
 - [POPSigner Documentation](https://docs.popsigner.io)
-- [Celestia Documentation](https://docs.celestia.org)
+`
+	if useAvail {
+		readme += `- [Avail Documentation](https://docs.availproject.org)
+`
+	} else {
+		readme += `- [Celestia Documentation](https://docs.celestia.org)
+`
+	}
 
+	readme += `
 ---
 Generated by [POPKins](https://popkins.popsigner.com) Chain Bootstrapping Service
 `
@@ -61,7 +61,8 @@ func NewContractArtifactDownloader(cacheDir string) *ContractArtifactDownloader
 
 // Download downloads and extracts artifacts, returning the path to the extracted directory.
 // The result can be used with artifacts.NewFileLocator() for op-deployer.
-// Caching is DISABLED - always downloads fresh to avoid stale artifact issues.
+// Archives are cached only when their version has a checksum, and the cached
+// archive is re-verified before every use; extraction is always fresh.
 func (d *ContractArtifactDownloader) Download(ctx context.Context, url string) (string, error) {
 	return d.DownloadWithVersion(ctx, url, ArtifactVersion)
 }
@@ -77,30 +78,20 @@ func (d *ContractArtifactDownloader) DownloadWithVersion(ctx context.Context, ur
 		return "", fmt.Errorf("create cache dir: %w", err)
 	}
 
-	// Use URL hash + timestamp as cache key to force fresh download every time
-	urlHash := fmt.Sprintf("%x", sha256.Sum256([]byte(url)))
+	// Use a timestamp for the extraction directory so every deployment
+	// extracts fresh
 	timestamp := fmt.Sprintf("%d", time.Now().UnixNano())
 	extractDir := filepath.Join(d.cacheDir, "artifacts-"+timestamp[:16])
 
-	// Always download fresh - no caching
-	tzstPath := filepath.Join(d.cacheDir, urlHash+"-"+timestamp[:10]+".tzst")
-	if err := d.downloadFile(ctx, url, tzstPath); err != nil {
-		return "", fmt.Errorf("download artifacts: %w", err)
-	}
-	// Log the downloaded file size for debugging
-	if info, err := os.Stat(tzstPath); err == nil {
-		slog.Debug("downloaded artifact", slog.Int64("size_bytes", info.Size()), slog.String("url", url))
+	tzstPath, cached, err := d.verifiedArchive(ctx, url, version)
+	if err != nil {
+		return "", err
 	}
-
-	// Verify artifact integrity before extraction
-	if err := d.verifyChecksum(tzstPath, version); err != nil {
-		os.Remove(tzstPath)
-		return "", fmt.Errorf("artifact integrity check failed: %w", err)
+	if !cached {
+		// Clean up after extraction
+		defer os.Remove(tzstPath)
 	}
 
-	// Clean up after extraction
-	defer os.Remove(tzstPath)
-
 	// Extract to a temporary directory first to check structure
 	if err := os.MkdirAll(extractDir, 0755); err != nil {
 		return "", fmt.Errorf("create extract dir: %w", err)
@@ -164,6 +155,61 @@ func (d *ContractArtifactDownloader) DownloadWithVersion(ctx context.Context, ur
 	return extractDir, nil
 }
 
+// verifiedArchiveDir is the cacheDir subdirectory holding verified archives.
+// It is kept out of OPDeployer's cache cleanup, which removes top-level
+// artifacts-* directories and *.tzst files.
+const verifiedArchiveDir = "verified-archives"
+
+// verifiedArchive returns the path of a checksum-verified archive for url,
+// reusing the cached copy while it still matches the expected checksum. A
+// corrupted or partial cached archive is removed and downloaded again.
+// cached reports whether the returned archive is kept in the cache.
+func (d *ContractArtifactDownloader) verifiedArchive(ctx context.Context, url, version string) (path string, cached bool, err error) {
+	urlHash := fmt.Sprintf("%x", sha256.Sum256([]byte(url)))
+
+	// Only archives with a known checksum can be verified on load
+	var cachePath string
+	if ArtifactChecksums[version] != "" {
+		cachePath = filepath.Join(d.cacheDir, verifiedArchiveDir, urlHash[:16]+"-"+version+".tzst")
+		if _, err := os.Stat(cachePath); err == nil {
+			err := d.verifyChecksum(cachePath, version)
+			if err == nil {
+				slog.Debug("using cached artifact", slog.String("path", cachePath))
+				return cachePath, true, nil
+			}
+			slog.Warn("cached artifact failed integrity check, downloading again",
+				slog.String("path", cachePath),
+				slog.String("error", err.Error()),
+			)
+			os.Remove(cachePath)
+		}
+	}
+
+	tzstPath := filepath.Join(d.cacheDir, urlHash+"-"+fmt.Sprintf("%d", time.Now().UnixNano())[:10]+".tzst")
+	if err := d.downloadFile(ctx, url, tzstPath); err != nil {
+		return "", false, fmt.Errorf("download artifacts: %w", err)
+	}
+	// Log the downloaded file size for debugging
+	if info, err := os.Stat(tzstPath); err == nil {
+		slog.Debug("downloaded artifact", slog.Int64("size_bytes", info.Size()), slog.String("url", url))
+	}
+
+	// Verify artifact integrity before extraction
+	if err := d.verifyChecksum(tzstPath, version); err != nil {
+		os.Remove(tzstPath)
+		return "", false, fmt.Errorf("artifact integrity check failed: %w", err)
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+			if err := os.Rename(tzstPath, cachePath); err == nil {
+				return cachePath, true, nil
+			}
+		}
+	}
+	return tzstPath, false, nil
+}
+
 // verifyChecksum calculates SHA256 of the file and compares with the expected checksum.
 // If the expected checksum is empty (not yet configured), verification is skipped with a warning.
 func (d *ContractArtifactDownloader) verifyChecksum(filePath, version string) error {
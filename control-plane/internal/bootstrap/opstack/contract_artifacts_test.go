@@ -1,12 +1,19 @@
 package opstack
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -104,3 +111,91 @@ func TestArtifactChecksums(t *testing.T) {
 		assert.True(t, ok, "ArtifactVersion %s should have an entry in ArtifactChecksums", ArtifactVersion)
 	})
 }
+
+// testArtifactArchive builds a minimal .tzst artifact archive.
+func testArtifactArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	tw := tar.NewWriter(zw)
+	content := []byte(`{"abi": []}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "forge-artifacts/OPContractsManager.sol/OPContractsManager.json",
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	}))
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadWithVersion_CachedArchive(t *testing.T) {
+	archive := testArtifactArchive(t)
+	ArtifactChecksums["test-cache"] = fmt.Sprintf("%x", sha256.Sum256(archive))
+	defer delete(ArtifactChecksums, "test-cache")
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	downloader := NewContractArtifactDownloader(cacheDir)
+	url := server.URL + "/artifacts.tzst"
+	artifactFile := filepath.Join("forge-artifacts", "OPContractsManager.sol", "OPContractsManager.json")
+
+	dir, err := downloader.DownloadWithVersion(ctx, url, "test-cache")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, artifactFile))
+	assert.Equal(t, int32(1), hits.Load())
+
+	// A valid cached archive is reused
+	_, err = downloader.DownloadWithVersion(ctx, url, "test-cache")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), hits.Load())
+
+	// A corrupted cached archive is downloaded again instead of used
+	cached, err := filepath.Glob(filepath.Join(cacheDir, verifiedArchiveDir, "*.tzst"))
+	require.NoError(t, err)
+	require.Len(t, cached, 1)
+	require.NoError(t, os.WriteFile(cached[0], archive[:len(archive)/2], 0644))
+
+	dir, err = downloader.DownloadWithVersion(ctx, url, "test-cache")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), hits.Load())
+	data, err := os.ReadFile(filepath.Join(dir, artifactFile))
+	require.NoError(t, err)
+	assert.Equal(t, `{"abi": []}`, string(data))
+	assert.NoError(t, downloader.verifyChecksum(cached[0], "test-cache"))
+}
+
+func TestDownloadWithVersion_NoChecksumNotCached(t *testing.T) {
+	archive := testArtifactArchive(t)
+	ArtifactChecksums["test-nocache"] = ""
+	defer delete(ArtifactChecksums, "test-nocache")
+
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	downloader := NewContractArtifactDownloader(cacheDir)
+	for i := 0; i < 2; i++ {
+		_, err := downloader.DownloadWithVersion(context.Background(), server.URL+"/artifacts.tzst", "test-nocache")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), hits.Load())
+	assert.NoDirExists(t, filepath.Join(cacheDir, verifiedArchiveDir))
+}
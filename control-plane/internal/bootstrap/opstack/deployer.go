@@ -176,52 +176,12 @@ func (d *OPDeployer) Deploy(ctx context.Context, cfg *DeploymentConfig, signerAd
 
 	l1Client := ethclient.NewClient(rpcClient)
 
-	// 4. Download and extract artifacts ourselves to avoid op-deployer's
-	// finicky directory structure expectations
-	d.logger.Info("downloading contract artifacts",
-		slog.String("url", ContractArtifactURL),
-	)
-
-	// Clean any cached artifacts from op-deployer's cache to force fresh download
-	// This ensures we always use the latest artifacts from S3
-	if err := d.cleanArtifactCache(); err != nil {
-		d.logger.Warn("failed to clean artifact cache", slog.String("error", err.Error()))
-	}
-
-	artifactDownloader := NewContractArtifactDownloader(d.cacheDir)
-	artifactDir, err := artifactDownloader.Download(ctx, ContractArtifactURL)
-	if err != nil {
-		return nil, fmt.Errorf("download artifacts: %w", err)
-	}
-
-	d.logger.Info("artifacts downloaded and extracted",
-		slog.String("path", artifactDir),
-	)
-
-	// Create file:// locator pointing to our extracted artifacts
-	// op-deployer's file handler correctly looks for forge-artifacts/ subdirectory
-	fileLocator, err := artifacts.NewFileLocator(artifactDir)
-	if err != nil {
-		return nil, fmt.Errorf("create file locator: %w", err)
-	}
-
-	// Update intent to use our local artifacts
-	intent.L1ContractsLocator = fileLocator
-	intent.L2ContractsLocator = fileLocator
-
-	// Now use op-deployer's Download which will just use os.DirFS for file:// locators
-	l1Artifacts, err := artifacts.Download(ctx, intent.L1ContractsLocator, nil, d.cacheDir)
+	// 4. Download and extract artifacts
+	bundle, artifactDir, err := d.loadArtifacts(ctx, intent)
 	if err != nil {
-		return nil, fmt.Errorf("load L1 artifacts: %w", err)
-	}
-
-	// L2 uses same artifacts
-	l2Artifacts := l1Artifacts
-
-	bundle := pipeline.ArtifactsBundle{
-		L1: l1Artifacts,
-		L2: l2Artifacts,
+		return nil, err
 	}
+	l1Artifacts := bundle.L1
 
 	d.logger.Info("artifacts downloaded successfully")
 
@@ -481,6 +441,57 @@ func (d *OPDeployer) Deploy(ctx context.Context, cfg *DeploymentConfig, signerAd
 	return result, nil
 }
 
+// loadArtifacts downloads and extracts the contract artifacts ourselves to
+// avoid op-deployer's finicky directory structure expectations, and points
+// the intent's contract locators at them. It returns the loaded artifacts and
+// the directory they were extracted to.
+func (d *OPDeployer) loadArtifacts(ctx context.Context, intent *state.Intent) (pipeline.ArtifactsBundle, string, error) {
+	d.logger.Info("downloading contract artifacts",
+		slog.String("url", ContractArtifactURL),
+	)
+
+	// Clean any cached artifacts from op-deployer's cache to force fresh download
+	// This ensures we always use the latest artifacts from S3
+	if err := d.cleanArtifactCache(); err != nil {
+		d.logger.Warn("failed to clean artifact cache", slog.String("error", err.Error()))
+	}
+
+	artifactDownloader := NewContractArtifactDownloader(d.cacheDir)
+	artifactDir, err := artifactDownloader.Download(ctx, ContractArtifactURL)
+	if err != nil {
+		return pipeline.ArtifactsBundle{}, "", fmt.Errorf("download artifacts: %w", err)
+	}
+
+	d.logger.Info("artifacts downloaded and extracted",
+		slog.String("path", artifactDir),
+	)
+
+	// Create file:// locator pointing to our extracted artifacts
+	// op-deployer's file handler correctly looks for forge-artifacts/ subdirectory
+	fileLocator, err := artifacts.NewFileLocator(artifactDir)
+	if err != nil {
+		return pipeline.ArtifactsBundle{}, "", fmt.Errorf("create file locator: %w", err)
+	}
+
+	// Update intent to use our local artifacts
+	intent.L1ContractsLocator = fileLocator
+	intent.L2ContractsLocator = fileLocator
+
+	// Now use op-deployer's Download which will just use os.DirFS for file:// locators
+	l1Artifacts, err := artifacts.Download(ctx, intent.L1ContractsLocator, nil, d.cacheDir)
+	if err != nil {
+		return pipeline.ArtifactsBundle{}, "", fmt.Errorf("load L1 artifacts: %w", err)
+	}
+
+	// L2 uses same artifacts
+	l2Artifacts := l1Artifacts
+
+	return pipeline.ArtifactsBundle{
+		L1: l1Artifacts,
+		L2: l2Artifacts,
+	}, artifactDir, nil
+}
+
 // stateWriter returns a pipeline.StateWriter that updates the given state.
 func (d *OPDeployer) stateWriter(st *state.State) pipeline.StateWriter {
 	return stateWriterFunc(func(newState *state.State) error {
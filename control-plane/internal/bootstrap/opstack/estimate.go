@@ -0,0 +1,135 @@
+package opstack
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script"
+	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/broadcaster"
+	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/opcm"
+	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/pipeline"
+	openv "github.com/ethereum-optimism/optimism/op-deployer/pkg/env"
+)
+
+// GasEstimate is the estimated L1 gas of an OP Stack deployment.
+type GasEstimate struct {
+	// Transactions is the number of L1 transactions the deployment sends.
+	Transactions int
+	// Gas is the total gas used by those transactions in simulation.
+	Gas uint64
+}
+
+// gasRecorder is a broadcaster that records the gas used by simulated
+// broadcasts instead of sending them.
+type gasRecorder struct {
+	mu       sync.Mutex
+	estimate GasEstimate
+}
+
+var _ broadcaster.Broadcaster = (*gasRecorder)(nil)
+
+// Hook records a broadcast from the script host.
+func (r *gasRecorder) Hook(bcast script.Broadcast) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.estimate.Transactions++
+	r.estimate.Gas += bcast.GasUsed
+}
+
+// Broadcast is a no-op: nothing is sent to L1.
+func (r *gasRecorder) Broadcast(ctx context.Context) ([]broadcaster.BroadcastResult, error) {
+	return nil, nil
+}
+
+// Estimate returns the gas recorded so far.
+func (r *gasRecorder) Estimate() GasEstimate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.estimate
+}
+
+// EstimateGas runs the L1 stages of the deployment pipeline (Init,
+// DeploySuperchain, DeployImplementations and DeployOPChain) against a fork
+// of cfg.L1RPC without broadcasting, and returns the gas the deployment
+// transactions would use. Like op-deployer's calldata target, the fork is
+// never refreshed, so each stage sees the simulated contracts of the previous
+// ones. Estimates always assume an isolated deployment.
+func (d *OPDeployer) EstimateGas(ctx context.Context, cfg *DeploymentConfig) (*GasEstimate, error) {
+	intent, err := BuildIntent(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("build intent: %w", err)
+	}
+	st := BuildState(cfg.ChainName, cfg.ChainID)
+
+	rpcClient, err := rpc.DialContext(ctx, cfg.L1RPC)
+	if err != nil {
+		return nil, fmt.Errorf("dial L1 RPC: %w", err)
+	}
+	defer rpcClient.Close()
+
+	l1Client := ethclient.NewClient(rpcClient)
+
+	bundle, _, err := d.loadArtifacts(ctx, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder := &gasRecorder{}
+	deployerAddr := common.HexToAddress(cfg.DeployerAddress)
+
+	l1Host, err := openv.DefaultForkedScriptHost(
+		ctx,
+		recorder,
+		d.gethLogger(),
+		deployerAddr,
+		bundle.L1,
+		rpcClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create L1 script host: %w", err)
+	}
+
+	scripts, err := opcm.NewScripts(l1Host)
+	if err != nil {
+		return nil, fmt.Errorf("load deployment scripts: %w", err)
+	}
+
+	env := &pipeline.Env{
+		StateWriter:  d.stateWriter(st),
+		L1ScriptHost: l1Host,
+		L1Client:     l1Client,
+		Broadcaster:  recorder,
+		Deployer:     deployerAddr,
+		Logger:       d.gethLogger(),
+		Scripts:      scripts,
+	}
+
+	if err := pipeline.InitLiveStrategy(ctx, env, intent, st); err != nil {
+		return nil, fmt.Errorf("init live strategy: %w", err)
+	}
+	if err := pipeline.DeploySuperchain(env, intent, st); err != nil {
+		return nil, fmt.Errorf("deploy superchain: %w", err)
+	}
+	if err := pipeline.DeployImplementations(env, intent, st); err != nil {
+		return nil, fmt.Errorf("deploy implementations: %w", err)
+	}
+	for _, chainIntent := range intent.Chains {
+		if err := pipeline.DeployOPChain(env, intent, st, chainIntent.ID); err != nil {
+			return nil, fmt.Errorf("deploy OP chain %s: %w", chainIntent.ID.Hex(), err)
+		}
+	}
+
+	estimate := recorder.Estimate()
+	d.logger.Info("OP Stack deployment gas estimated",
+		slog.Int("transactions", estimate.Transactions),
+		slog.Uint64("gas", estimate.Gas),
+	)
+
+	return &estimate, nil
+}
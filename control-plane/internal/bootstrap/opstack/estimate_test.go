@@ -0,0 +1,24 @@
+package opstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasRecorder(t *testing.T) {
+	recorder := &gasRecorder{}
+
+	recorder.Hook(script.Broadcast{Type: script.BroadcastCreate, GasUsed: 1_500_000})
+	recorder.Hook(script.Broadcast{Type: script.BroadcastCall, GasUsed: 50_000})
+
+	// Broadcasting sends nothing and keeps the recorded gas
+	results, err := recorder.Broadcast(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	assert.Equal(t, GasEstimate{Transactions: 2, Gas: 1_550_000}, recorder.Estimate())
+}
@@ -0,0 +1,591 @@
+package opstack
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Output formats for the bundle's service definitions.
+const (
+	// OutputFormatCompose generates a docker-compose.yml (default).
+	OutputFormatCompose = "compose"
+	// OutputFormatKubernetes generates Kubernetes manifests (k8s.yaml).
+	OutputFormatKubernetes = "kubernetes"
+)
+
+// kubernetesManifestsTemplate is the template for generating OP Stack Kubernetes
+// manifests with Celestia DA. It mirrors dockerComposeTemplate: the same images,
+// flags and environment variables, with Services in place of the compose network.
+// genesis.json can exceed the 1 MiB ConfigMap limit, and .env holds secrets, so
+// both are created from the bundle files instead of being embedded.
+const kubernetesManifestsTemplate = `# {{ .ChainName }} - OP Stack with Celestia DA (Kubernetes)
+# Generated by POPKins - https://popkins.popsigner.com
+#
+# Usage:
+#   1. Copy .env.example to .env and fill in POPSIGNER_API_KEY
+#   2. kubectl create configmap {{ .Name }}-genesis --from-file=genesis.json
+#   3. kubectl create secret generic {{ .Name }}-env --from-env-file=.env
+#   4. kubectl apply -f k8s.yaml
+#
+# Environment variables are loaded from the {{ .Name }}-env secret.
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{ .Name }}-jwt
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+type: Opaque
+stringData:
+  jwt.txt: {{ printf "%q" .JWTSecret }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Name }}-config
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+data:
+  rollup.json: |
+{{ indent 4 .RollupJSON }}
+{{- if .UseAltDA }}
+  config.toml: |
+{{ indent 4 .AltDAConfig }}
+{{- end }}
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: {{ .Name }}-op-geth-data
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  accessModes: ["ReadWriteOnce"]
+  resources:
+    requests:
+      storage: 100Gi
+{{- if .UseAltDA }}
+---
+# =============================================================
+# OP-ALT-DA - Celestia DA Server
+# Posts blobs to Celestia, serves commitments to op-node/op-batcher
+# Uses POPSigner for Celestia transaction signing (see config.toml)
+# =============================================================
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: op-alt-da
+  labels:
+    app.kubernetes.io/name: op-alt-da
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: op-alt-da
+      app.kubernetes.io/part-of: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: op-alt-da
+        app.kubernetes.io/part-of: {{ .Name }}
+    spec:
+      containers:
+        - name: op-alt-da
+          image: ghcr.io/celestiaorg/op-alt-da:{{ .OpAltDAVersion }}
+          args:
+            - --config=/config/config.toml
+          envFrom:
+            - secretRef:
+                name: {{ .Name }}-env
+          ports:
+            - name: http
+              containerPort: 3100
+          readinessProbe:
+            httpGet:
+              path: /health
+              port: 3100
+            periodSeconds: 5
+          volumeMounts:
+            - name: config
+              mountPath: /config/config.toml
+              subPath: config.toml
+              readOnly: true
+      volumes:
+        - name: config
+          configMap:
+            name: {{ .Name }}-config
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: op-alt-da
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  selector:
+    app.kubernetes.io/name: op-alt-da
+    app.kubernetes.io/part-of: {{ .Name }}
+  ports:
+    - name: http
+      port: 3100
+      targetPort: 3100
+{{- end }}
+---
+# =============================================================
+# OP GETH - L2 execution layer
+# The init container initializes genesis on first start
+# =============================================================
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: op-geth
+  labels:
+    app.kubernetes.io/name: op-geth
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  replicas: 1
+  strategy:
+    type: Recreate
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: op-geth
+      app.kubernetes.io/part-of: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: op-geth
+        app.kubernetes.io/part-of: {{ .Name }}
+    spec:
+      initContainers:
+        - name: op-geth-init
+          image: us-docker.pkg.dev/oplabs-tools-artifacts/images/op-geth:{{ .OpGethVersion }}
+          command: ["/bin/sh", "-c"]
+          args:
+            - |
+              if [ -d /data/geth/chaindata ]; then
+                echo "op-geth already initialized, skipping genesis init"
+              else
+                echo "Initializing op-geth with genesis..."
+                geth init --datadir=/data /genesis/genesis.json
+              fi
+          volumeMounts:
+            - name: data
+              mountPath: /data
+            - name: genesis
+              mountPath: /genesis
+              readOnly: true
+      containers:
+        - name: op-geth
+          image: us-docker.pkg.dev/oplabs-tools-artifacts/images/op-geth:{{ .OpGethVersion }}
+          args:
+            - --datadir=/data
+            - --http
+            - --http.addr=0.0.0.0
+            - --http.port=8545
+            - --http.vhosts=*
+            - --http.corsdomain=*
+            - --http.api=web3,debug,eth,txpool,net,engine,miner
+            - --ws
+            - --ws.addr=0.0.0.0
+            - --ws.port=8546
+            - --ws.origins=*
+            - --ws.api=debug,eth,txpool,net,engine,miner
+            - --syncmode=full
+            - --gcmode=archive
+            - --nodiscover
+            - --maxpeers=0
+            - --authrpc.addr=0.0.0.0
+            - --authrpc.port=8551
+            - --authrpc.vhosts=*
+            - --authrpc.jwtsecret=/config/jwt.txt
+            - --rollup.disabletxpoolgossip=true
+            - --ipcdisable
+            - --metrics
+            - --metrics.port=7299
+          ports:
+            - name: rpc
+              containerPort: 8545
+            - name: ws
+              containerPort: 8546
+            - name: engine
+              containerPort: 8551
+            - name: metrics
+              containerPort: 7299
+          readinessProbe:
+            tcpSocket:
+              port: 8545
+            periodSeconds: 15
+          volumeMounts:
+            - name: data
+              mountPath: /data
+            - name: jwt
+              mountPath: /config/jwt.txt
+              subPath: jwt.txt
+              readOnly: true
+      volumes:
+        - name: data
+          persistentVolumeClaim:
+            claimName: {{ .Name }}-op-geth-data
+        - name: genesis
+          configMap:
+            name: {{ .Name }}-genesis
+        - name: jwt
+          secret:
+            secretName: {{ .Name }}-jwt
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: op-geth
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  selector:
+    app.kubernetes.io/name: op-geth
+    app.kubernetes.io/part-of: {{ .Name }}
+  ports:
+    - name: rpc
+      port: 8545
+      targetPort: 8545
+    - name: ws
+      port: 8546
+      targetPort: 8546
+    - name: engine
+      port: 8551
+      targetPort: 8551
+---
+# =============================================================
+# OP NODE - Derives L2 state from L1, rollup consensus
+# =============================================================
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: op-node
+  labels:
+    app.kubernetes.io/name: op-node
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: op-node
+      app.kubernetes.io/part-of: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: op-node
+        app.kubernetes.io/part-of: {{ .Name }}
+    spec:
+      containers:
+        - name: op-node
+          image: us-docker.pkg.dev/oplabs-tools-artifacts/images/op-node:{{ .OpNodeVersion }}
+          args:
+            - op-node
+            - --l2=http://op-geth:8551
+            - --l2.jwt-secret=/config/jwt.txt
+            - --sequencer.enabled
+            - --sequencer.l1-confs=5
+            - --verifier.l1-confs=4
+            - --rollup.config=/config/rollup.json
+            - --rpc.addr=0.0.0.0
+            - --rpc.port=9545
+            - --rpc.enable-admin
+            - --p2p.disable
+            - --l1=$(L1_RPC_URL)
+            - --l1.rpckind=$(L1_RPC_KIND)
+            - --l1.trustrpc
+            - --l1.beacon=$(L1_BEACON_URL)
+{{- if .UseAltDA }}
+            # Celestia Alt-DA
+            - --altda.enabled=true
+            - --altda.verify-on-read=true
+            - --altda.da-server=http://op-alt-da:3100
+{{- end }}
+            - --metrics.enabled
+            - --metrics.port=7300
+          envFrom:
+            - secretRef:
+                name: {{ .Name }}-env
+          ports:
+            - name: rpc
+              containerPort: 9545
+            - name: metrics
+              containerPort: 7300
+          readinessProbe:
+            tcpSocket:
+              port: 9545
+            periodSeconds: 15
+          volumeMounts:
+            - name: config
+              mountPath: /config/rollup.json
+              subPath: rollup.json
+              readOnly: true
+            - name: jwt
+              mountPath: /config/jwt.txt
+              subPath: jwt.txt
+              readOnly: true
+      volumes:
+        - name: config
+          configMap:
+            name: {{ .Name }}-config
+        - name: jwt
+          secret:
+            secretName: {{ .Name }}-jwt
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: op-node
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  selector:
+    app.kubernetes.io/name: op-node
+    app.kubernetes.io/part-of: {{ .Name }}
+  ports:
+    - name: rpc
+      port: 9545
+      targetPort: 9545
+---
+# =============================================================
+# OP BATCHER - Submits L2 batches to DA layer
+# =============================================================
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: op-batcher
+  labels:
+    app.kubernetes.io/name: op-batcher
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: op-batcher
+      app.kubernetes.io/part-of: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: op-batcher
+        app.kubernetes.io/part-of: {{ .Name }}
+    spec:
+      containers:
+        - name: op-batcher
+          image: us-docker.pkg.dev/oplabs-tools-artifacts/images/op-batcher:{{ .OpBatcherVersion }}
+          args:
+            - op-batcher
+            - --l2-eth-rpc=http://op-geth:8545
+            - --rollup-rpc=http://op-node:9545
+            - --poll-interval=1s
+            - --sub-safety-margin=6
+            - --num-confirmations=1
+            - --safe-abort-nonce-too-low-count=3
+            - --resubmission-timeout=30s
+            - --rpc.addr=0.0.0.0
+            - --rpc.port=8548
+            - --max-channel-duration=25
+            - --l1-eth-rpc=$(L1_RPC_URL)
+            # POPSigner for batcher signing
+            - --signer.endpoint=$(POPSIGNER_RPC_URL)
+            - --signer.address=$(BATCHER_ADDRESS)
+            - --signer.header=X-API-Key=$(POPSIGNER_API_KEY)
+            - --signer.tls.enabled=false
+{{- if .UseAltDA }}
+            # Celestia Alt-DA
+            - --altda.da-service=true
+            - --altda.enabled=true
+            - --altda.da-server=http://op-alt-da:3100
+{{- end }}
+            - --metrics.enabled
+            - --metrics.port=7301
+          envFrom:
+            - secretRef:
+                name: {{ .Name }}-env
+          ports:
+            - name: rpc
+              containerPort: 8548
+            - name: metrics
+              containerPort: 7301
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: op-batcher
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  selector:
+    app.kubernetes.io/name: op-batcher
+    app.kubernetes.io/part-of: {{ .Name }}
+  ports:
+    - name: rpc
+      port: 8548
+      targetPort: 8548
+---
+# =============================================================
+# OP PROPOSER - Submits L2 state roots to L1
+# =============================================================
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: op-proposer
+  labels:
+    app.kubernetes.io/name: op-proposer
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: op-proposer
+      app.kubernetes.io/part-of: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: op-proposer
+        app.kubernetes.io/part-of: {{ .Name }}
+    spec:
+      containers:
+        - name: op-proposer
+          image: us-docker.pkg.dev/oplabs-tools-artifacts/images/op-proposer:{{ .OpProposerVersion }}
+          args:
+            - op-proposer
+            - --poll-interval=12s
+            - --rpc.port=8560
+            - --rollup-rpc=http://op-node:9545
+            - --game-factory-address=$(DISPUTE_GAME_FACTORY_ADDRESS)
+            - --proposal-interval=6h
+            - --l1-eth-rpc=$(L1_RPC_URL)
+            # POPSigner for proposer signing
+            - --signer.endpoint=$(POPSIGNER_RPC_URL)
+            - --signer.address=$(PROPOSER_ADDRESS)
+            - --signer.header=X-API-Key=$(POPSIGNER_API_KEY)
+            - --signer.tls.enabled=false
+            - --metrics.enabled
+            - --metrics.port=7302
+          envFrom:
+            - secretRef:
+                name: {{ .Name }}-env
+          ports:
+            - name: rpc
+              containerPort: 8560
+            - name: metrics
+              containerPort: 7302
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: op-proposer
+  labels:
+    app.kubernetes.io/part-of: {{ .Name }}
+spec:
+  selector:
+    app.kubernetes.io/name: op-proposer
+    app.kubernetes.io/part-of: {{ .Name }}
+  ports:
+    - name: rpc
+      port: 8560
+      targetPort: 8560
+`
+
+// KubernetesTemplateVars holds variables for the Kubernetes manifests template.
+type KubernetesTemplateVars struct {
+	// ChainName is the display name of the chain
+	ChainName string
+	// Name prefixes the chain's shared resources (DNS-1123 label)
+	Name string
+
+	// Embedded configuration
+	JWTSecret   string
+	RollupJSON  string
+	AltDAConfig string
+
+	// DA configuration - always Celestia
+	UseAltDA bool
+
+	// Image versions
+	OpNodeVersion     string
+	OpBatcherVersion  string
+	OpProposerVersion string
+	OpGethVersion     string
+	OpAltDAVersion    string
+}
+
+// GenerateKubernetesManifests generates multi-document Kubernetes manifests
+// (k8s.yaml) from the deployment config, as an alternative to
+// GenerateDockerCompose. The JWT secret, rollup.json and op-alt-da config.toml
+// are taken from artifacts.
+func GenerateKubernetesManifests(cfg *DeploymentConfig, artifacts *OPStackArtifacts) (string, error) {
+	if artifacts == nil {
+		return "", fmt.Errorf("artifacts are required")
+	}
+	if artifacts.JWTSecret == "" {
+		return "", fmt.Errorf("JWT secret is required")
+	}
+	if len(artifacts.Rollup) == 0 {
+		return "", fmt.Errorf("rollup config is required")
+	}
+	if artifacts.AltDAConfig == "" {
+		return "", fmt.Errorf("op-alt-da config is required")
+	}
+
+	tmpl, err := template.New("k8s").Funcs(template.FuncMap{
+		"indent": indentLines,
+	}).Parse(kubernetesManifestsTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse template: %w", err)
+	}
+
+	vars := KubernetesTemplateVars{
+		ChainName:   sanitizeChainName(cfg.ChainName),
+		Name:        kubernetesName(cfg.ChainName),
+		JWTSecret:   artifacts.JWTSecret,
+		RollupJSON:  string(artifacts.Rollup),
+		AltDAConfig: artifacts.AltDAConfig,
+		// Always use Celestia DA - POPKins only supports Celestia
+		UseAltDA: true,
+
+		OpNodeVersion:     OpNodeVersion,
+		OpBatcherVersion:  OpBatcherVersion,
+		OpProposerVersion: OpProposerVersion,
+		OpGethVersion:     OpGethVersion,
+		OpAltDAVersion:    OpAltDAVersion,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// indentLines indents every non-empty line of s by n spaces, for embedding
+// file contents in YAML block scalars.
+func indentLines(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// kubernetesName converts a chain name to a valid Kubernetes resource name
+// prefix (lowercase alphanumerics and hyphens).
+func kubernetesName(name string) string {
+	name = strings.ToLower(sanitizeChainName(name))
+	name = strings.ReplaceAll(name, "_", "-")
+	name = strings.Trim(name, "-")
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-")
+	}
+	if name == "" {
+		return "opstack"
+	}
+	return name
+}
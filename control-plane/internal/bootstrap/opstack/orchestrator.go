@@ -19,6 +19,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/verifier"
 )
 
 // L1Client defines the interface for L1 Ethereum operations.
@@ -57,6 +58,10 @@ type OrchestratorConfig struct {
 
 	// RetryDelay between retry attempts
 	RetryDelay time.Duration
+
+	// Verifier submits deployed contracts for source verification.
+	// Optional: verification is skipped when nil.
+	Verifier *verifier.ContractVerifier
 }
 
 // Orchestrator coordinates OP Stack chain deployments.
@@ -444,11 +449,14 @@ func (o *Orchestrator) deployWithOPDeployer(
 	}
 
 	extractor := NewArtifactExtractor(o.repo)
-	if _, err := extractor.ExtractArtifacts(ctx, deploymentID, cfg); err != nil {
+	bundleArtifacts, err := extractor.ExtractArtifacts(ctx, deploymentID, cfg)
+	if err != nil {
 		o.logger.Warn("failed to generate bundle artifacts (deployment still succeeded)",
 			slog.String("error", err.Error()),
 		)
 		// Don't fail the deployment - the raw artifacts are saved
+	} else {
+		o.verifyContracts(ctx, deploymentID, cfg.L1ChainID, &bundleArtifacts.Addresses)
 	}
 
 	o.logger.Info("OP Stack deployment and bundle generation completed")
@@ -418,6 +418,28 @@ func TestDeploymentConfig_Validate(t *testing.T) {
 		err := cfg.Validate()
 		require.NoError(t, err)
 	})
+
+	t.Run("rejects unknown output_format", func(t *testing.T) {
+		cfg := createTestDeploymentConfig()
+		cfg.OutputFormat = "helm"
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "output_format")
+
+		cfg.OutputFormat = OutputFormatKubernetes
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("rejects unknown data_availability", func(t *testing.T) {
+		cfg := createTestDeploymentConfig()
+		cfg.DataAvailability = "eigenda"
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data_availability")
+
+		cfg.DataAvailability = DataAvailabilityAvail
+		require.NoError(t, cfg.Validate())
+	})
 }
 
 func TestDeploymentConfig_ApplyDefaults(t *testing.T) {
@@ -96,6 +96,19 @@ func (m *MockRepository) ListDeploymentsByOrgAndStatus(ctx context.Context, orgI
 	return args.Get(0).([]*repository.Deployment), args.Error(1)
 }
 
+func (m *MockRepository) ListDeploymentsPage(ctx context.Context, q repository.DeploymentListQuery) ([]*repository.Deployment, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Deployment), args.Error(1)
+}
+
+func (m *MockRepository) DeleteDeployment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockRepository) MarkStaleDeploymentsFailed(ctx context.Context, orgID uuid.UUID, timeout time.Duration) (int, error) {
 	args := m.Called(ctx, orgID, timeout)
 	return args.Int(0), args.Error(1)
@@ -0,0 +1,130 @@
+package opstack
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/verifier"
+)
+
+// verificationContracts lists the L1 contracts of a deployment to verify.
+// The system contracts sit behind op-deployer's Proxy, so the proxies are
+// verified against the Proxy source, with their proxy admin as the constructor
+// argument. The batch inbox is an EOA-like address without code and is not
+// included.
+func verificationContracts(addrs *ContractAddresses) []verifier.Contract {
+	proxyArgs := addressArgs(addrs.ProxyAdmin)
+	superchainProxyArgs := addressArgs(addrs.SuperchainProxyAdmin)
+
+	candidates := []struct {
+		label string
+		name  string
+		addr  string
+		args  []byte
+	}{
+		{"SuperchainConfigProxy", "Proxy", addrs.SuperchainConfig, superchainProxyArgs},
+		{"ProtocolVersionsProxy", "Proxy", addrs.ProtocolVersions, superchainProxyArgs},
+		{"OptimismPortalProxy", "Proxy", addrs.OptimismPortalProxy, proxyArgs},
+		{"L1CrossDomainMessengerProxy", "ResolvedDelegateProxy", addrs.L1CrossDomainMessengerProxy, resolvedDelegateProxyArgs(addrs.AddressManager)},
+		{"L1StandardBridgeProxy", "L1ChugSplashProxy", addrs.L1StandardBridgeProxy, proxyArgs},
+		{"L1ERC721BridgeProxy", "Proxy", addrs.L1ERC721BridgeProxy, proxyArgs},
+		{"SystemConfigProxy", "Proxy", addrs.SystemConfigProxy, proxyArgs},
+		{"DisputeGameFactoryProxy", "Proxy", addrs.DisputeGameFactoryProxy, proxyArgs},
+		{"AnchorStateRegistryProxy", "Proxy", addrs.AnchorStateRegistryProxy, proxyArgs},
+		{"DelayedWETHProxy", "Proxy", addrs.DelayedWETHProxy, proxyArgs},
+		{"OptimismMintableERC20FactoryProxy", "Proxy", addrs.OptimismMintableERC20Factory, proxyArgs},
+		{"AddressManager", "AddressManager", addrs.AddressManager, nil},
+	}
+
+	var contracts []verifier.Contract
+	for _, c := range candidates {
+		if c.addr == "" || !common.IsHexAddress(c.addr) {
+			continue
+		}
+		addr := common.HexToAddress(c.addr)
+		if addr == (common.Address{}) {
+			continue
+		}
+		contracts = append(contracts, verifier.Contract{
+			Label:           c.label,
+			Name:            c.name,
+			Address:         addr,
+			ConstructorArgs: c.args,
+		})
+	}
+	return contracts
+}
+
+// l1CrossDomainMessengerName is the AddressManager entry the
+// L1CrossDomainMessenger's ResolvedDelegateProxy resolves its implementation by.
+const l1CrossDomainMessengerName = "OVM_L1CrossDomainMessenger"
+
+// addressArgs encodes a single address constructor argument, as taken by
+// Proxy(_admin) and L1ChugSplashProxy(_owner). It returns nil for unknown
+// addresses so the contract is still submitted without arguments.
+func addressArgs(addr string) []byte {
+	if !common.IsHexAddress(addr) || common.HexToAddress(addr) == (common.Address{}) {
+		return nil
+	}
+	return common.LeftPadBytes(common.HexToAddress(addr).Bytes(), 32)
+}
+
+// resolvedDelegateProxyArgs encodes the ResolvedDelegateProxy(_addressManager,
+// _implementationName) constructor arguments of the L1CrossDomainMessenger proxy.
+func resolvedDelegateProxyArgs(addressManager string) []byte {
+	if addressArgs(addressManager) == nil {
+		return nil
+	}
+	addressType, _ := abi.NewType("address", "", nil)
+	stringType, _ := abi.NewType("string", "", nil)
+	args, err := abi.Arguments{{Type: addressType}, {Type: stringType}}.Pack(
+		common.HexToAddress(addressManager),
+		l1CrossDomainMessengerName,
+	)
+	if err != nil {
+		return nil
+	}
+	return args
+}
+
+// verifyContracts submits the deployed L1 contracts for source verification
+// and records the results as an artifact. Verification is best effort and
+// never fails the deployment.
+func (o *Orchestrator) verifyContracts(ctx context.Context, deploymentID uuid.UUID, l1ChainID uint64, addrs *ContractAddresses) {
+	if o.config.Verifier == nil {
+		return
+	}
+
+	report, err := o.config.Verifier.Verify(ctx, l1ChainID, verificationContracts(addrs))
+	if err != nil {
+		o.logger.Warn("contract verification failed", slog.String("error", err.Error()))
+		return
+	}
+	if report == nil {
+		return
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		o.logger.Warn("failed to marshal verification report", slog.String("error", err.Error()))
+		return
+	}
+
+	artifact := &repository.Artifact{
+		ID:           uuid.New(),
+		DeploymentID: deploymentID,
+		ArtifactType: verifier.ArtifactType,
+		Content:      content,
+		CreatedAt:    time.Now(),
+	}
+	if err := o.repo.SaveArtifact(ctx, artifact); err != nil {
+		o.logger.Warn("failed to save verification artifact", slog.String("error", err.Error()))
+	}
+}
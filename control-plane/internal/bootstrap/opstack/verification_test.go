@@ -0,0 +1,49 @@
+package opstack
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerificationContracts_ProxyConstructorArgs(t *testing.T) {
+	proxyAdmin := "0x1111111111111111111111111111111111111111"
+	superchainAdmin := "0x2222222222222222222222222222222222222222"
+	addressManager := "0x3333333333333333333333333333333333333333"
+
+	contracts := verificationContracts(&ContractAddresses{
+		SuperchainConfig:            "0x4444444444444444444444444444444444444444",
+		OptimismPortalProxy:         "0x5555555555555555555555555555555555555555",
+		L1CrossDomainMessengerProxy: "0x6666666666666666666666666666666666666666",
+		AddressManager:              addressManager,
+		ProxyAdmin:                  proxyAdmin,
+		SuperchainProxyAdmin:        superchainAdmin,
+	})
+	require.Len(t, contracts, 4)
+
+	args := make(map[string][]byte)
+	for _, c := range contracts {
+		args[c.Label] = c.ConstructorArgs
+	}
+
+	assert.Equal(t, common.LeftPadBytes(common.HexToAddress(proxyAdmin).Bytes(), 32), args["OptimismPortalProxy"])
+	assert.Equal(t, common.LeftPadBytes(common.HexToAddress(superchainAdmin).Bytes(), 32), args["SuperchainConfigProxy"])
+	assert.Nil(t, args["AddressManager"])
+
+	// address, string offset, string length, "OVM_L1CrossDomainMessenger"
+	messenger := args["L1CrossDomainMessengerProxy"]
+	require.Len(t, messenger, 4*32)
+	assert.Equal(t, common.HexToAddress(addressManager).Bytes(), messenger[12:32])
+	assert.Equal(t, byte(len(l1CrossDomainMessengerName)), messenger[95])
+	assert.Equal(t, l1CrossDomainMessengerName, string(messenger[96:96+len(l1CrossDomainMessengerName)]))
+}
+
+func TestVerificationContracts_UnknownProxyAdmin(t *testing.T) {
+	contracts := verificationContracts(&ContractAddresses{
+		OptimismPortalProxy: "0x5555555555555555555555555555555555555555",
+	})
+	require.Len(t, contracts, 1)
+	assert.Nil(t, contracts[0].ConstructorArgs)
+}
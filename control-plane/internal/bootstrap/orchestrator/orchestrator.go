@@ -323,6 +323,21 @@ func (o *Orchestrator) StopDeployment(deploymentID uuid.UUID) error {
 	return o.repo.UpdateDeploymentStatus(context.Background(), deploymentID, repository.StatusPaused, nil)
 }
 
+// DeleteDeployment removes a deployment and its artifacts. A deployment that
+// is still running must be stopped with StopDeployment first.
+func (o *Orchestrator) DeleteDeployment(ctx context.Context, deploymentID uuid.UUID) error {
+	if _, ok := o.runningJobs[deploymentID]; ok {
+		return repository.ErrDeploymentRunning
+	}
+
+	if err := o.repo.DeleteDeployment(ctx, deploymentID); err != nil {
+		return err
+	}
+
+	o.logger.Info("deleted deployment", slog.String("deployment_id", deploymentID.String()))
+	return nil
+}
+
 // ProcessPendingDeployments starts any deployments that are in pending state.
 // This is called at startup to resume any deployments that were interrupted.
 func (o *Orchestrator) ProcessPendingDeployments(ctx context.Context) error {
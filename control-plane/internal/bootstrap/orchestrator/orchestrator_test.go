@@ -0,0 +1,51 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+)
+
+// deleteRepo records deletions; the rest of the repository is unused.
+type deleteRepo struct {
+	repository.Repository
+	deleted []uuid.UUID
+}
+
+func (r *deleteRepo) DeleteDeployment(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func TestDeleteDeployment(t *testing.T) {
+	repo := &deleteRepo{}
+	o := New(repo, nil, nil, nil, nil, nil, Config{})
+
+	id := uuid.New()
+	if err := o.DeleteDeployment(context.Background(), id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != id {
+		t.Errorf("expected %s to be deleted, got %v", id, repo.deleted)
+	}
+}
+
+func TestDeleteDeployment_Running(t *testing.T) {
+	repo := &deleteRepo{}
+	o := New(repo, nil, nil, nil, nil, nil, Config{})
+
+	id := uuid.New()
+	o.runningJobs[id] = func() {}
+
+	err := o.DeleteDeployment(context.Background(), id)
+	if !errors.Is(err, repository.ErrDeploymentRunning) {
+		t.Fatalf("expected ErrDeploymentRunning, got %v", err)
+	}
+	if len(repo.deleted) != 0 {
+		t.Errorf("running deployment was deleted")
+	}
+}
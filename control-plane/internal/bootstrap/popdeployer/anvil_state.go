@@ -0,0 +1,99 @@
+package popdeployer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// ErrInvalidAnvilState is returned when Anvil's state dump is incomplete,
+// typically because Anvil was killed while writing it.
+var ErrInvalidAnvilState = errors.New("invalid anvil state dump")
+
+// anvilStateKeys are the top-level keys every Anvil state dump has.
+var anvilStateKeys = []string{"block", "accounts"}
+
+// ValidateAnvilState checks that the state file Anvil dumped on shutdown is
+// a complete JSON object with the expected top-level keys.
+func ValidateAnvilState(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("anvil state file not created: %w", err)
+	}
+	defer f.Close()
+
+	var state map[string]json.RawMessage
+	if err := json.NewDecoder(f).Decode(&state); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidAnvilState, path, err)
+	}
+	for _, key := range anvilStateKeys {
+		if _, ok := state[key]; !ok {
+			return fmt.Errorf("%w: %s: missing %q", ErrInvalidAnvilState, path, key)
+		}
+	}
+	return nil
+}
+
+// StopAnvil sends SIGTERM so Anvil dumps its state to stateFile, and waits
+// up to timeout for it to exit. Killing Anvil mid-dump truncates the file,
+// so if it is still running the graceful shutdown is retried once before
+// Anvil is killed. The dump is then validated.
+func StopAnvil(cmd *exec.Cmd, stateFile string, timeout time.Duration, logger *slog.Logger) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	exited := false
+	for attempt := 1; attempt <= 2 && !exited; attempt++ {
+		if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+			return fmt.Errorf("send SIGTERM to anvil: %w", err)
+		}
+
+		select {
+		case err := <-done:
+			exited = true
+			if err != nil && !signaled(err) {
+				logger.Warn("Anvil exited with error", slog.String("error", err.Error()))
+			}
+		case <-time.After(timeout):
+			if attempt == 1 {
+				logger.Warn("Anvil shutdown timeout, retrying graceful shutdown",
+					slog.Duration("timeout", timeout),
+				)
+			}
+		}
+	}
+	if !exited {
+		logger.Warn("Anvil shutdown timeout, forcing kill")
+		cmd.Process.Kill()
+		<-done
+	}
+
+	if err := ValidateAnvilState(stateFile); err != nil {
+		return err
+	}
+	if info, err := os.Stat(stateFile); err == nil {
+		logger.Info("Anvil state dumped successfully",
+			slog.String("file", stateFile),
+			slog.Int64("size_kb", info.Size()/1024),
+		)
+	}
+	return nil
+}
+
+// signaled reports whether a process exited because of a signal, which is
+// expected after SIGTERM.
+func signaled(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	return ok && status.Signaled()
+}
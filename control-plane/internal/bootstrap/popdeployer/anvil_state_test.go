@@ -0,0 +1,90 @@
+package popdeployer
+
+import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validAnvilState = `{"block": {"number": "0x10"}, "accounts": {"0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266": {"nonce": 3}}, "best_block_number": "0x10"}`
+
+func TestValidateAnvilState(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"complete", validAnvilState, ""},
+		{"truncated", validAnvilState[:60], "unexpected EOF"},
+		{"empty", "", "EOF"},
+		{"missing accounts", `{"block": {}}`, `missing "accounts"`},
+		{"not an object", `[1, 2, 3]`, "cannot unmarshal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "anvil-state.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			err := ValidateAnvilState(path)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidAnvilState)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	err := ValidateAnvilState(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not created")
+}
+
+// fakeAnvil starts a process that writes content to stateFile when it gets
+// SIGTERM, like Anvil dumping its state.
+func fakeAnvil(t *testing.T, stateFile, content string) *exec.Cmd {
+	t.Helper()
+	contentFile := filepath.Join(t.TempDir(), "content")
+	require.NoError(t, os.WriteFile(contentFile, []byte(content), 0o644))
+
+	cmd := exec.Command("sh", "-c", `trap 'cp "$1" "$2"; exit 0' TERM; while :; do sleep 0.01; done`, "sh", contentFile, stateFile)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() { cmd.Process.Kill() })
+	// Give the shell time to install its trap
+	time.Sleep(100 * time.Millisecond)
+	return cmd
+}
+
+func TestStopAnvil(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "anvil-state.json")
+	cmd := fakeAnvil(t, stateFile, validAnvilState)
+
+	require.NoError(t, StopAnvil(cmd, stateFile, 5*time.Second, slog.Default()))
+}
+
+func TestStopAnvil_TruncatedState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "anvil-state.json")
+	cmd := fakeAnvil(t, stateFile, validAnvilState[:60])
+
+	err := StopAnvil(cmd, stateFile, 5*time.Second, slog.Default())
+	require.ErrorIs(t, err, ErrInvalidAnvilState)
+}
+
+func TestStopAnvil_RetriesBeforeKill(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "anvil-state.json")
+	// Ignores the first SIGTERM, dumps on the second
+	script := `n=0; trap 'n=$((n+1)); if [ $n -ge 2 ]; then printf %s "$1" > "$2"; exit 0; fi' TERM; while :; do sleep 0.01; done`
+	cmd := exec.Command("sh", "-c", script, "sh", validAnvilState, stateFile)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() { cmd.Process.Kill() })
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, StopAnvil(cmd, stateFile, 500*time.Millisecond, slog.Default()))
+}
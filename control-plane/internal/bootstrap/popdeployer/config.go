@@ -1,5 +1,15 @@
 package popdeployer
 
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/nitro"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // DeploymentConfig holds configuration for a POPKins devnet bundle deployment.
 type DeploymentConfig struct {
 	// User-configurable parameters
@@ -7,16 +17,382 @@ type DeploymentConfig struct {
 	ChainName   string `json:"chain_name"`
 	BundleStack string `json:"bundle_stack"` // "opstack" or "nitro" (defaults to "opstack")
 
+	// Optional L1 fork: when L1ForkURL is set, Anvil forks that chain's state
+	// instead of starting from an empty genesis. L1ForkBlock pins the fork
+	// block (0 = latest).
+	L1ForkURL   string `json:"l1_fork_url,omitempty"`
+	L1ForkBlock uint64 `json:"l1_fork_block,omitempty"`
+
+	// Optional Anvil dev accounts: how many to create and the balance of each
+	// in ETH (0 = DefaultAnvilAccounts / DefaultAnvilBalance). The balance is
+	// ignored in fork mode, where accounts keep their forked state.
+	AnvilAccounts int    `json:"anvil_accounts,omitempty"`
+	AnvilBalance  uint64 `json:"anvil_balance,omitempty"`
+
+	// Optional L2 genesis allocations (address -> balance in wei), merged
+	// into the generated genesis.json, e.g. to pre-fund a faucet or treasury.
+	GenesisAlloc map[string]*big.Int `json:"genesis_alloc,omitempty"`
+
+	// Optional Nitro batch posters and validators. When unset, the rollup
+	// uses the batcher (anvil-1) and proposer (anvil-2) alone. The bundled
+	// nodes post and stake as anvil-1 and anvil-2, so include them to keep
+	// the bundle working out of the box.
+	BatchPosters []string `json:"batch_posters,omitempty"`
+	Validators   []string `json:"validators,omitempty"`
+
+	// Optional Nitro BOLD staking parameters. BaseStake is in wei
+	// (0 = DefaultNitroBaseStake). When StakeTokenAddress is set, that token
+	// is used for staking and the WETH deployment is skipped; it must
+	// already exist on the L1, e.g. when forking with l1_fork_url.
+	BaseStake         *big.Int `json:"base_stake,omitempty"`
+	StakeTokenAddress string   `json:"stake_token_address,omitempty"`
+
+	// Optional Nitro contract artifact pin (empty = nitro.ArtifactVersion).
+	// NitroArtifactSHA256 is the expected SHA-256 of the downloaded archive
+	// and is required for versions without a registered checksum.
+	NitroArtifactVersion string `json:"nitro_artifact_version,omitempty"`
+	NitroArtifactSHA256  string `json:"nitro_artifact_sha256,omitempty"`
+
+	// Optional popsigner key ID used to sign Celestia blobs (empty =
+	// DefaultCelestiaKeyID, popsigner-lite's ID for anvil-9). Set it when
+	// the bundle runs against a provisioned Celestia key.
+	CelestiaKeyID string `json:"celestia_key_id,omitempty"`
+
 	// Hardcoded parameters (populated by orchestrator)
 	L1ChainID       uint64 `json:"l1_chain_id"`      // 31337 (Anvil)
 	L1RPC           string `json:"l1_rpc"`           // IPC path or HTTP URL to Anvil
 	DeployerAddress string `json:"deployer_address"` // anvil-0
 	BatcherAddress  string `json:"batcher_address"`  // anvil-1
 	ProposerAddress string `json:"proposer_address"` // anvil-2
-	BlockTime       uint64 `json:"block_time"`       // 2 seconds
-	GasLimit        uint64 `json:"gas_limit"`        // 30000000
+
+	// Optional chain parameters, shared by Anvil and the L2 (0 =
+	// DefaultBlockTime / DefaultGasLimit).
+	BlockTime uint64 `json:"block_time"` // seconds
+	GasLimit  uint64 `json:"gas_limit"`
 
 	// Note: POPSigner fields removed - not needed during bundle build.
 	// We use AnvilSigner for direct ECDSA signing with Anvil's well-known keys.
 	// POPSigner-Lite is only used at runtime (in docker-compose for op-batcher/op-proposer).
 }
+
+// Anvil dev account defaults and limits. At least MinAnvilAccounts are needed
+// for the deployer, batcher and proposer (anvil-0 to anvil-2).
+const (
+	DefaultAnvilAccounts = 10
+	DefaultAnvilBalance  = 10000 // ETH
+	MinAnvilAccounts     = 3
+	MaxAnvilAccounts     = 1000
+	MaxAnvilBalance      = 1_000_000_000 // ETH
+)
+
+// Block time and gas limit defaults and limits. The gas limit bounds are
+// those of the OP Stack SystemConfig contract.
+const (
+	DefaultBlockTime = 2 // seconds
+	MinBlockTime     = 1
+	MaxBlockTime     = 60
+	DefaultGasLimit  = 30_000_000
+	MinGasLimit      = 21_000_000
+	MaxGasLimit      = 500_000_000
+)
+
+// DefaultNitroBaseStake is the default BOLD base stake in wei (0.1 ETH).
+const DefaultNitroBaseStake = 100_000_000_000_000_000
+
+// DefaultCelestiaKeyID is popsigner-lite's deterministic key ID for anvil-9,
+// the Celestia signing account of local devnets.
+const DefaultCelestiaKeyID = "anvil-9"
+
+// MaxCelestiaKeyIDLength bounds CelestiaKeyID.
+const MaxCelestiaKeyIDLength = 128
+
+// Anvil dev accounts used for the deployer, batcher and proposer roles.
+const (
+	anvilDeployerAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" // anvil-0
+	anvilBatcherAddress  = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8" // anvil-1
+	anvilProposerAddress = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC" // anvil-2
+)
+
+// IsFork reports whether the L1 is forked from an existing chain.
+func (c DeploymentConfig) IsFork() bool {
+	return c.L1ForkURL != ""
+}
+
+// AnvilAccountCount returns the number of Anvil dev accounts to create.
+func (c DeploymentConfig) AnvilAccountCount() int {
+	if c.AnvilAccounts == 0 {
+		return DefaultAnvilAccounts
+	}
+	return c.AnvilAccounts
+}
+
+// AnvilBalanceETH returns the balance of each Anvil dev account in ETH.
+func (c DeploymentConfig) AnvilBalanceETH() uint64 {
+	if c.AnvilBalance == 0 {
+		return DefaultAnvilBalance
+	}
+	return c.AnvilBalance
+}
+
+// NitroBatchPosters returns the addresses allowed to post batches to the
+// Nitro sequencer inbox.
+func (c DeploymentConfig) NitroBatchPosters() []common.Address {
+	return addressesOr(c.BatchPosters, c.BatcherAddress)
+}
+
+// NitroValidators returns the addresses allowed to validate the Nitro rollup.
+func (c DeploymentConfig) NitroValidators() []common.Address {
+	return addressesOr(c.Validators, c.ProposerAddress)
+}
+
+// addressesOr converts addrs, or fallback if addrs is unset.
+func addressesOr(addrs []string, fallback string) []common.Address {
+	if len(addrs) == 0 {
+		return []common.Address{common.HexToAddress(fallback)}
+	}
+	out := make([]common.Address, len(addrs))
+	for i, addr := range addrs {
+		out[i] = common.HexToAddress(addr)
+	}
+	return out
+}
+
+// NitroBaseStake returns the BOLD base stake in wei.
+func (c DeploymentConfig) NitroBaseStake() *big.Int {
+	if c.BaseStake == nil || c.BaseStake.Sign() == 0 {
+		return big.NewInt(DefaultNitroBaseStake)
+	}
+	return new(big.Int).Set(c.BaseStake)
+}
+
+// NitroStakeToken returns the configured stake token, if any.
+func (c DeploymentConfig) NitroStakeToken() (common.Address, bool) {
+	if c.StakeTokenAddress == "" {
+		return common.Address{}, false
+	}
+	return common.HexToAddress(c.StakeTokenAddress), true
+}
+
+// celestiaKeyIDPattern matches key IDs that are safe to embed in the
+// generated TOML and env files, e.g. "anvil-9" or a UUID.
+var celestiaKeyIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]*$`)
+
+// CelestiaKey returns the popsigner key ID used to sign Celestia blobs.
+func (c DeploymentConfig) CelestiaKey() string {
+	if c.CelestiaKeyID == "" {
+		return DefaultCelestiaKeyID
+	}
+	return c.CelestiaKeyID
+}
+
+// nitroArtifactVersionPattern matches artifact versions that are safe to use
+// in the artifact URL, e.g. "v3.2.0-beta.0".
+var nitroArtifactVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// NitroArtifactPin returns the Nitro contract artifact version to deploy and
+// its expected checksum. An empty checksum means the one registered in
+// nitro.ArtifactChecksums.
+func (c DeploymentConfig) NitroArtifactPin() (version, checksum string) {
+	version = c.NitroArtifactVersion
+	if version == "" {
+		version = nitro.ArtifactVersion
+	}
+	return version, c.NitroArtifactSHA256
+}
+
+// ValidateConfig checks a deployment config once the orchestrator's
+// hardcoded values are populated, so a bad config fails before Anvil starts.
+func ValidateConfig(cfg DeploymentConfig) error {
+	if cfg.ChainID == 0 {
+		return fmt.Errorf("chain_id: is required")
+	}
+	if cfg.L1ChainID == 0 {
+		return fmt.Errorf("l1_chain_id: is required")
+	}
+	if cfg.ChainID == cfg.L1ChainID {
+		return fmt.Errorf("chain_id: %d is also the L1 chain ID", cfg.ChainID)
+	}
+	switch cfg.BundleStack {
+	case "", "opstack", "nitro":
+	default:
+		return fmt.Errorf("bundle_stack: must be %q or %q, got %q", "opstack", "nitro", cfg.BundleStack)
+	}
+	for _, role := range []struct{ field, addr string }{
+		{"deployer_address", cfg.DeployerAddress},
+		{"batcher_address", cfg.BatcherAddress},
+		{"proposer_address", cfg.ProposerAddress},
+	} {
+		if !common.IsHexAddress(role.addr) {
+			return fmt.Errorf("%s: invalid address %q", role.field, role.addr)
+		}
+	}
+	return cfg.Validate()
+}
+
+// Validate checks the user-configurable parameters.
+func (c DeploymentConfig) Validate() error {
+	if err := c.ValidateChainParams(); err != nil {
+		return err
+	}
+	if err := c.ValidateAnvilAccounts(); err != nil {
+		return err
+	}
+	if err := c.ValidateNitroRoles(); err != nil {
+		return err
+	}
+	if err := c.ValidateNitroStaking(); err != nil {
+		return err
+	}
+	if err := c.ValidateNitroArtifacts(); err != nil {
+		return err
+	}
+	if err := c.ValidateCelestiaKeyID(); err != nil {
+		return err
+	}
+	return c.ValidateGenesisAlloc()
+}
+
+// ValidateCelestiaKeyID checks the Celestia key ID, if set.
+func (c DeploymentConfig) ValidateCelestiaKeyID() error {
+	if c.CelestiaKeyID == "" {
+		return nil
+	}
+	if len(c.CelestiaKeyID) > MaxCelestiaKeyIDLength {
+		return fmt.Errorf("celestia_key_id: must be at most %d characters", MaxCelestiaKeyIDLength)
+	}
+	if !celestiaKeyIDPattern.MatchString(c.CelestiaKeyID) {
+		return fmt.Errorf("celestia_key_id: invalid key ID %q", c.CelestiaKeyID)
+	}
+	return nil
+}
+
+// ValidateNitroArtifacts checks the artifact version and checksum pin.
+func (c DeploymentConfig) ValidateNitroArtifacts() error {
+	if (c.NitroArtifactVersion != "" || c.NitroArtifactSHA256 != "") && c.BundleStack != "nitro" {
+		return fmt.Errorf("nitro_artifact_version and nitro_artifact_sha256 are only supported for nitro bundles")
+	}
+	if c.NitroArtifactVersion != "" && !nitroArtifactVersionPattern.MatchString(c.NitroArtifactVersion) {
+		return fmt.Errorf("nitro_artifact_version: invalid version %q", c.NitroArtifactVersion)
+	}
+	if c.NitroArtifactSHA256 != "" {
+		if _, err := nitro.NormalizeChecksum(c.NitroArtifactSHA256); err != nil {
+			return fmt.Errorf("nitro_artifact_sha256: %w", err)
+		}
+		return nil
+	}
+	if version, _ := c.NitroArtifactPin(); nitro.ArtifactChecksums[version] == "" {
+		return fmt.Errorf("nitro_artifact_sha256: required for version %s, which has no registered checksum", version)
+	}
+	return nil
+}
+
+// ValidateNitroStaking checks the base stake and stake token.
+func (c DeploymentConfig) ValidateNitroStaking() error {
+	if (c.BaseStake != nil || c.StakeTokenAddress != "") && c.BundleStack != "nitro" {
+		return fmt.Errorf("base_stake and stake_token_address are only supported for nitro bundles")
+	}
+	if c.BaseStake != nil && c.BaseStake.Sign() < 0 {
+		return fmt.Errorf("base_stake: must be non-negative")
+	}
+	if c.StakeTokenAddress != "" {
+		if !common.IsHexAddress(c.StakeTokenAddress) {
+			return fmt.Errorf("stake_token_address: invalid address %q", c.StakeTokenAddress)
+		}
+		if common.HexToAddress(c.StakeTokenAddress) == (common.Address{}) {
+			return fmt.Errorf("stake_token_address: zero address is not allowed, BOLD requires an ERC-20 stake token")
+		}
+	}
+	return nil
+}
+
+// ValidateNitroRoles checks the batch poster and validator lists. Each list
+// may be omitted, but if given it must be non-empty and hold distinct,
+// non-zero addresses other than the deployer.
+func (c DeploymentConfig) ValidateNitroRoles() error {
+	if (c.BatchPosters != nil || c.Validators != nil) && c.BundleStack != "nitro" {
+		return fmt.Errorf("batch_posters and validators are only supported for nitro bundles")
+	}
+	if err := validateRoleAddresses("batch_posters", c.BatchPosters); err != nil {
+		return err
+	}
+	return validateRoleAddresses("validators", c.Validators)
+}
+
+// validateRoleAddresses checks one role's address list.
+func validateRoleAddresses(field string, addrs []string) error {
+	if addrs == nil {
+		return nil
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("%s: must not be empty", field)
+	}
+	deployer := common.HexToAddress(anvilDeployerAddress)
+	seen := make(map[common.Address]bool, len(addrs))
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("%s: invalid address %q", field, addr)
+		}
+		a := common.HexToAddress(addr)
+		if a == (common.Address{}) {
+			return fmt.Errorf("%s: zero address is not allowed", field)
+		}
+		if a == deployer {
+			return fmt.Errorf("%s: %s is the deployer and cannot take this role", field, addr)
+		}
+		if seen[a] {
+			return fmt.Errorf("%s: duplicate address %s", field, addr)
+		}
+		seen[a] = true
+	}
+	return nil
+}
+
+// ValidateChainParams checks that the block time and gas limit are within
+// range. Zero values select the defaults.
+func (c DeploymentConfig) ValidateChainParams() error {
+	if c.BlockTime != 0 && (c.BlockTime < MinBlockTime || c.BlockTime > MaxBlockTime) {
+		return fmt.Errorf("block_time: must be between %d and %d seconds, got %d", MinBlockTime, MaxBlockTime, c.BlockTime)
+	}
+	if c.GasLimit != 0 && (c.GasLimit < MinGasLimit || c.GasLimit > MaxGasLimit) {
+		return fmt.Errorf("gas_limit: must be between %d and %d, got %d", MinGasLimit, MaxGasLimit, c.GasLimit)
+	}
+	return nil
+}
+
+// ValidateAnvilAccounts checks that the Anvil account count and balance are
+// within sane ranges. Zero values select the defaults.
+func (c DeploymentConfig) ValidateAnvilAccounts() error {
+	if c.AnvilAccounts != 0 && (c.AnvilAccounts < MinAnvilAccounts || c.AnvilAccounts > MaxAnvilAccounts) {
+		return fmt.Errorf("anvil_accounts: must be between %d and %d, got %d", MinAnvilAccounts, MaxAnvilAccounts, c.AnvilAccounts)
+	}
+	if c.AnvilBalance > MaxAnvilBalance {
+		return fmt.Errorf("anvil_balance: must be at most %d ETH, got %d", MaxAnvilBalance, c.AnvilBalance)
+	}
+	return nil
+}
+
+// ValidateGenesisAlloc checks that every genesis allocation has a valid
+// address and a non-negative balance, and that it doesn't collide with the
+// dev accounts funded by op-deployer.
+func (c DeploymentConfig) ValidateGenesisAlloc() error {
+	seen := make(map[common.Address]string, len(c.GenesisAlloc))
+	for addr, balance := range c.GenesisAlloc {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("genesis_alloc: invalid address %q", addr)
+		}
+		if balance == nil || balance.Sign() < 0 {
+			return fmt.Errorf("genesis_alloc: balance for %s must be non-negative", addr)
+		}
+		a := common.HexToAddress(addr)
+		if prev, ok := seen[a]; ok {
+			return fmt.Errorf("genesis_alloc: duplicate address %s (also given as %s)", addr, prev)
+		}
+		seen[a] = addr
+		for _, dev := range genesis.DevAccounts {
+			if a == dev {
+				return fmt.Errorf("genesis_alloc: %s is a dev account and is already funded", addr)
+			}
+		}
+	}
+	return nil
+}
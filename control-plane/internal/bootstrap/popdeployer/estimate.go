@@ -0,0 +1,179 @@
+package popdeployer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/nitro"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/opstack"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// DeploymentEstimate is the projected L1 cost of a bundle deployment.
+type DeploymentEstimate struct {
+	BundleStack string `json:"bundle_stack"`
+
+	// Transactions is the number of L1 transactions the deployment sends.
+	Transactions int `json:"transactions"`
+
+	// GasTotal is the total estimated gas of those transactions.
+	GasTotal uint64 `json:"gas_total"`
+
+	// DefaultedTransactions is the number of transactions that could not be
+	// simulated and are counted at the deployer's default gas limit.
+	DefaultedTransactions int `json:"defaulted_transactions"`
+
+	// GasPrice is the current gas price of the target L1 in wei.
+	GasPrice *big.Int `json:"gas_price_wei"`
+
+	// Cost is GasTotal at GasPrice, in wei.
+	Cost *big.Int `json:"cost_wei"`
+
+	// Duration is a rough estimate of the contract deployment time.
+	Duration time.Duration `json:"duration"`
+}
+
+// EstimateDeployment estimates the gas, cost and duration of a deployment
+// without broadcasting any transactions. The target L1 is the fork URL for
+// forked deployments, and cfg.L1RPC otherwise.
+func (o *Orchestrator) EstimateDeployment(ctx context.Context, cfg DeploymentConfig) (*DeploymentEstimate, error) {
+	target := cfg.L1RPC
+	if cfg.IsFork() {
+		target = cfg.L1ForkURL
+	}
+	if target == "" {
+		return nil, fmt.Errorf("no L1 to estimate against: set l1_rpc or l1_fork_url")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client, err := ethclient.DialContext(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("connect to L1: %w", err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get L1 chain ID: %w", err)
+	}
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get gas price: %w", err)
+	}
+
+	// Estimate against the target instead of the local Anvil
+	cfg = o.populateDefaults(cfg)
+	cfg.L1RPC = target
+	cfg.L1ChainID = chainID.Uint64()
+
+	bundleStack := cfg.BundleStack
+	if bundleStack == "" {
+		bundleStack = "opstack"
+	}
+
+	estimate := &DeploymentEstimate{
+		BundleStack: bundleStack,
+		GasPrice:    gasPrice,
+	}
+
+	switch bundleStack {
+	case "nitro":
+		gas, err := o.estimateNitro(ctx, client, &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("estimate nitro deployment: %w", err)
+		}
+		estimate.Transactions = gas.Transactions
+		estimate.GasTotal = gas.Gas
+		estimate.DefaultedTransactions = gas.Defaulted
+	default:
+		deployer := opstack.NewOPDeployer(opstack.OPDeployerConfig{
+			Logger:   o.logger,
+			CacheDir: o.config.CacheDir,
+		})
+		gas, err := deployer.EstimateGas(ctx, opstackDeploymentConfig(&cfg))
+		if err != nil {
+			return nil, fmt.Errorf("estimate OP Stack deployment: %w", err)
+		}
+		estimate.Transactions = gas.Transactions
+		estimate.GasTotal = gas.Gas
+	}
+
+	estimate.Cost = new(big.Int).Mul(new(big.Int).SetUint64(estimate.GasTotal), gasPrice)
+	estimate.Duration = estimateDuration(estimate.Transactions, cfg.BlockTime)
+
+	o.logger.Info("deployment estimated",
+		slog.String("bundle_stack", bundleStack),
+		slog.Int("transactions", estimate.Transactions),
+		slog.Uint64("gas_total", estimate.GasTotal),
+		slog.String("cost_wei", estimate.Cost.String()),
+		slog.Duration("duration", estimate.Duration),
+	)
+
+	return estimate, nil
+}
+
+// estimateNitro estimates the Nitro pipeline: infrastructure, WETH and the
+// RollupCreator call. Addresses of contracts the pipeline would deploy are
+// predicted from the deployer's nonce.
+func (o *Orchestrator) estimateNitro(ctx context.Context, client *ethclient.Client, cfg *DeploymentConfig) (*nitro.GasEstimate, error) {
+	downloader := nitro.NewContractArtifactDownloader(filepath.Join(o.config.CacheDir, "nitro-artifacts"))
+	artifacts, err := downloader.DownloadDefault(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("download artifacts: %w", err)
+	}
+
+	signer, err := o.createNitroLocalSigner(&DeploymentContext{Config: cfg})
+	if err != nil {
+		return nil, err
+	}
+
+	startNonce, err := client.PendingNonceAt(ctx, signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("get nonce: %w", err)
+	}
+
+	estimate, contracts, err := nitro.NewInfrastructureDeployer(artifacts, signer, nil, o.logger).EstimateGas(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("estimate infrastructure: %w", err)
+	}
+
+	// WETH is deployed right after the infrastructure
+	wethGas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From: signer.Address(),
+		Data: common.FromHex(weth9Bytecode),
+	})
+	if err != nil {
+		estimate.Add(wethGasLimit, true)
+	} else {
+		estimate.Add(wethGas, false)
+	}
+	stakeToken := crypto.CreateAddress(signer.Address(), startNonce+uint64(estimate.Transactions-1))
+
+	deployer, err := nitro.NewRollupDeployer(artifacts, signer, o.logger)
+	if err != nil {
+		return nil, fmt.Errorf("create rollup deployer: %w", err)
+	}
+	rollupGas, defaulted, err := deployer.EstimateGas(ctx, client,
+		nitroRollupConfig(cfg, signer.Address(), stakeToken), contracts["RollupCreator"])
+	if err != nil {
+		return nil, fmt.Errorf("estimate createRollup: %w", err)
+	}
+	estimate.Add(rollupGas, defaulted)
+
+	return estimate, nil
+}
+
+// estimateDuration roughly estimates how long contract deployment takes,
+// assuming each transaction is mined in its own block.
+func estimateDuration(transactions int, blockTime uint64) time.Duration {
+	return time.Duration(transactions) * time.Duration(blockTime) * time.Second
+}
@@ -0,0 +1,95 @@
+package popdeployer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateDuration(t *testing.T) {
+	assert.Equal(t, 60*time.Second, estimateDuration(30, 2))
+	assert.Equal(t, time.Duration(0), estimateDuration(0, 2))
+}
+
+func TestEstimateDeployment_RequiresL1(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{})
+
+	_, err := o.EstimateDeployment(context.Background(), DeploymentConfig{ChainID: 42069, ChainName: "estimate"})
+	assert.Error(t, err)
+}
+
+// TestEstimateDeployment_Anvil estimates an OP Stack deployment against a
+// local Anvil. It needs anvil on PATH and network access for the contract
+// artifacts.
+func TestEstimateDeployment_Anvil(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Anvil estimation test in short mode")
+	}
+	if _, err := exec.LookPath("anvil"); err != nil {
+		t.Skip("anvil not installed")
+	}
+
+	rpcURL := startTestAnvil(t)
+	o := NewOrchestrator(nil, OrchestratorConfig{CacheDir: t.TempDir(), WorkDir: t.TempDir()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	estimate, err := o.EstimateDeployment(ctx, DeploymentConfig{
+		ChainID:   42069,
+		ChainName: "estimate-test",
+		L1RPC:     rpcURL,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "opstack", estimate.BundleStack)
+	assert.Greater(t, estimate.Transactions, 0)
+	assert.Greater(t, estimate.GasTotal, uint64(0))
+	assert.Positive(t, estimate.Cost.Sign())
+	assert.Greater(t, estimate.Duration, time.Duration(0))
+
+	// Nothing was broadcast
+	client, err := ethclient.Dial(rpcURL)
+	require.NoError(t, err)
+	defer client.Close()
+	block, err := client.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), block)
+}
+
+// startTestAnvil starts Anvil on a free port and returns its HTTP URL.
+func startTestAnvil(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	cmd := exec.Command("anvil", "--port", fmt.Sprint(port), "--silent")
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	rpcURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		client, err := ethclient.Dial(rpcURL)
+		if err != nil {
+			return false
+		}
+		defer client.Close()
+		_, err = client.ChainID(context.Background())
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	return rpcURL
+}
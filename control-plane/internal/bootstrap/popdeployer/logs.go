@@ -0,0 +1,166 @@
+package popdeployer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// LogArtifactPrefix prefixes the artifact types of deployment logs. The
+	// bundle download places them under the same path.
+	LogArtifactPrefix = "logs/"
+
+	// AnvilLogArtifact is the artifact type of the Anvil process output.
+	AnvilLogArtifact = LogArtifactPrefix + "anvil.log"
+
+	// DeploymentLogArtifact is the artifact type of the orchestrator's own
+	// log for a deployment, including the op-deployer and Nitro deployers.
+	DeploymentLogArtifact = LogArtifactPrefix + "deployment.log"
+
+	// maxLogArtifactSize bounds the stored size of each log. Longer logs
+	// keep their tail, where failures are reported.
+	maxLogArtifactSize = 1 << 20 // 1 MiB
+)
+
+// IsLogArtifact reports whether an artifact type is a deployment log.
+func IsLogArtifact(artifactType string) bool {
+	return strings.HasPrefix(artifactType, LogArtifactPrefix)
+}
+
+// deploymentLogger returns a logger that writes to both base and w, so a
+// deployment's log can be saved without affecting the server log.
+func deploymentLogger(base *slog.Logger, w io.Writer) *slog.Logger {
+	return slog.New(teeHandler{
+		base.Handler(),
+		slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	})
+}
+
+// teeHandler fans records out to multiple slog handlers.
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			if err := h.Handle(ctx, r.Clone()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	out := make(teeHandler, len(t))
+	for i, h := range t {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+// saveLogArtifacts saves the Anvil and deployment logs from the work
+// directory as artifacts. It runs for failed deployments too, so errors are
+// only logged.
+func (o *Orchestrator) saveLogArtifacts(ctx context.Context, deploymentID uuid.UUID, workDir string) {
+	logs := map[string]string{
+		AnvilLogArtifact:      filepath.Join(workDir, "anvil.log"),
+		DeploymentLogArtifact: filepath.Join(workDir, "deployment.log"),
+	}
+
+	for artifactType, path := range logs {
+		content, err := readLogTail(path, maxLogArtifactSize)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			o.logger.Warn("failed to read log",
+				slog.String("path", path),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		jsonContent, err := wrapContentForStorage(content)
+		if err != nil {
+			o.logger.Warn("failed to wrap log", slog.String("type", artifactType), slog.String("error", err.Error()))
+			continue
+		}
+
+		artifact := &repository.Artifact{
+			ID:           uuid.New(),
+			DeploymentID: deploymentID,
+			ArtifactType: artifactType,
+			Content:      jsonContent,
+			CreatedAt:    time.Now(),
+		}
+		if err := o.repo.SaveArtifact(ctx, artifact); err != nil {
+			o.logger.Warn("failed to save log artifact",
+				slog.String("type", artifactType),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// readLogTail reads a log file, keeping only its last max bytes. A truncated
+// log starts at a line boundary, after a marker noting the dropped bytes.
+func readLogTail(path string, max int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= max {
+		return io.ReadAll(f)
+	}
+
+	offset := info.Size() - max
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	tail, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the partial first line
+	if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+		offset += int64(i + 1)
+		tail = tail[i+1:]
+	}
+
+	marker := fmt.Sprintf("[log truncated: %d earlier bytes dropped]\n", offset)
+	return append([]byte(marker), tail...), nil
+}
@@ -0,0 +1,100 @@
+package popdeployer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+)
+
+// artifactRepo is an in-memory artifact store.
+type artifactRepo struct {
+	repository.Repository
+	artifacts map[string]*repository.Artifact
+}
+
+func (r *artifactRepo) SaveArtifact(ctx context.Context, a *repository.Artifact) error {
+	r.artifacts[a.ArtifactType] = a
+	return nil
+}
+
+func (r *artifactRepo) GetArtifact(ctx context.Context, deploymentID uuid.UUID, artifactType string) (*repository.Artifact, error) {
+	a, ok := r.artifacts[artifactType]
+	if !ok || a.DeploymentID != deploymentID {
+		return nil, nil
+	}
+	return a, nil
+}
+
+func unwrapStored(t *testing.T, content json.RawMessage) string {
+	t.Helper()
+	var wrapper struct {
+		Type string `json:"_type"`
+		Data string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(content, &wrapper))
+	require.Equal(t, "base64", wrapper.Type)
+	data, err := base64.StdEncoding.DecodeString(wrapper.Data)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestSaveLogArtifacts(t *testing.T) {
+	workDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "anvil.log"), []byte("Listening on anvil.ipc\n"), 0644))
+
+	var logBuf bytes.Buffer
+	logger := deploymentLogger(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)), &logBuf)
+	logger.Info("deploying contracts")
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "deployment.log"), logBuf.Bytes(), 0644))
+
+	repo := &artifactRepo{artifacts: map[string]*repository.Artifact{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{})
+	deploymentID := uuid.New()
+
+	o.saveLogArtifacts(context.Background(), deploymentID, workDir)
+
+	anvilLog, err := repo.GetArtifact(context.Background(), deploymentID, AnvilLogArtifact)
+	require.NoError(t, err)
+	require.NotNil(t, anvilLog)
+	assert.Equal(t, "Listening on anvil.ipc\n", unwrapStored(t, anvilLog.Content))
+	assert.True(t, IsLogArtifact(anvilLog.ArtifactType))
+
+	deploymentLog, err := repo.GetArtifact(context.Background(), deploymentID, DeploymentLogArtifact)
+	require.NoError(t, err)
+	require.NotNil(t, deploymentLog)
+	assert.Contains(t, unwrapStored(t, deploymentLog.Content), "deploying contracts")
+}
+
+func TestSaveLogArtifacts_MissingLogs(t *testing.T) {
+	repo := &artifactRepo{artifacts: map[string]*repository.Artifact{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{})
+
+	o.saveLogArtifacts(context.Background(), uuid.New(), t.TempDir())
+	assert.Empty(t, repo.artifacts)
+}
+
+func TestReadLogTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anvil.log")
+	content := strings.Repeat("old line\n", 10) + "last line\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	full, err := readLogTail(path, int64(len(content)))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(full))
+
+	tail, err := readLogTail(path, 15)
+	require.NoError(t, err)
+	assert.Equal(t, "[log truncated: 90 earlier bytes dropped]\nlast line\n", string(tail))
+}
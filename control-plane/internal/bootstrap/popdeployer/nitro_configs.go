@@ -16,6 +16,16 @@ type NitroConfigWriter struct {
 	celestiaKeyID string
 }
 
+// newNitroConfigWriter creates a NitroConfigWriter for cfg.
+func newNitroConfigWriter(logger *slog.Logger, result *nitroDeployResult, cfg *DeploymentConfig) *NitroConfigWriter {
+	return &NitroConfigWriter{
+		logger:        logger,
+		result:        result,
+		config:        cfg,
+		celestiaKeyID: cfg.CelestiaKey(),
+	}
+}
+
 // GenerateAll generates all Nitro configuration files and returns them as a map.
 func (w *NitroConfigWriter) GenerateAll() (map[string]string, error) {
 	artifacts := make(map[string]string)
@@ -38,6 +48,13 @@ func (w *NitroConfigWriter) GenerateAll() (map[string]string, error) {
 	}
 	artifacts["addresses.json"] = addresses
 
+	// nitro-artifacts.json
+	artifactInfo, err := w.generateArtifactInfo()
+	if err != nil {
+		return nil, fmt.Errorf("generate nitro-artifacts.json: %w", err)
+	}
+	artifacts["nitro-artifacts.json"] = artifactInfo
+
 	// jwt.txt
 	jwt, err := w.generateJWT()
 	if err != nil {
@@ -212,6 +229,23 @@ func (w *NitroConfigWriter) generateAddresses() (string, error) {
 	return string(data), nil
 }
 
+// generateArtifactInfo generates the nitro-artifacts.json recording the
+// contract artifact version and checksum the chain was deployed from.
+func (w *NitroConfigWriter) generateArtifactInfo() (string, error) {
+	info := map[string]interface{}{
+		"version":   w.result.artifacts.Version,
+		"sourceUrl": w.result.artifacts.SourceURL,
+		"sha256":    w.result.artifacts.Checksum,
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal artifact info: %w", err)
+	}
+
+	return string(data), nil
+}
+
 // generateJWT generates a random JWT secret.
 func (w *NitroConfigWriter) generateJWT() (string, error) {
 	secret := make([]byte, 32)
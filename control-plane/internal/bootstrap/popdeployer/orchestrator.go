@@ -4,19 +4,22 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"syscall"
+	"strings"
 	"time"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/nitro"
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/opstack"
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
 	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/state"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -24,14 +27,23 @@ import (
 )
 
 const (
-	// deploymentTimeout is the maximum time allowed for OP Stack deployment.
+	// DefaultDeploymentTimeout is the default maximum time allowed for a
+	// bundle deployment, from starting Anvil to saving the configs.
 	// Typical deployments take 5-15 minutes; 30 minutes provides adequate buffer
 	// for slow networks or resource-constrained environments.
-	deploymentTimeout = 30 * time.Minute
+	DefaultDeploymentTimeout = 30 * time.Minute
 
-	// anvilShutdownTimeout is how long to wait for Anvil to gracefully shut down
-	// before forcing a kill signal.
-	anvilShutdownTimeout = 5 * time.Second
+	// DefaultAnvilShutdownTimeout is the default time to wait for Anvil to
+	// gracefully shut down before forcing a kill signal.
+	DefaultAnvilShutdownTimeout = 5 * time.Second
+
+	// anvilForkShutdownTimeout is the minimum shutdown timeout for forked
+	// chains, whose state dump includes all state fetched from the fork and
+	// takes longer.
+	anvilForkShutdownTimeout = 60 * time.Second
+
+	// wethGasLimit is the gas limit of the WETH deployment transaction.
+	wethGasLimit = 1_000_000
 )
 
 // Stage represents the deployment stage for progress tracking.
@@ -39,6 +51,7 @@ type Stage string
 
 const (
 	// Common stages
+	StageQueued            Stage = "queued"
 	StageStartingAnvil     Stage = "starting_anvil"
 	StageCapturingState    Stage = "capturing_state"
 	StageGeneratingConfigs Stage = "generating_configs"
@@ -54,12 +67,18 @@ const (
 	StageCreatingRollup          Stage = "creating_rollup"
 )
 
+// ErrDeploymentTimeout is returned when a deployment exceeds the configured
+// DeploymentTimeout.
+var ErrDeploymentTimeout = errors.New("deployment timed out")
+
 // String returns the string representation of the stage.
 func (s Stage) String() string {
 	return string(s)
 }
 
-// ProgressCallback is called during deployment to report progress.
+// ProgressCallback is called during deployment to report progress. Progress
+// is overall, from 0.0 to 1.0, weighted by typical stage durations; it only
+// reaches 1.0 on completion.
 type ProgressCallback func(stage Stage, progress float64, message string)
 
 // OrchestratorConfig contains configuration for the orchestrator.
@@ -72,6 +91,29 @@ type OrchestratorConfig struct {
 
 	// WorkDir for temporary files (Anvil state, etc.)
 	WorkDir string
+
+	// MaxConcurrentDeployments caps how many deployments run Anvil and
+	// op-deployer at once. Excess deployments wait in the queue.
+	// Zero means no limit.
+	MaxConcurrentDeployments int
+
+	// DeploymentTimeout bounds a single deployment once it has left the
+	// queue. Defaults to DefaultDeploymentTimeout.
+	DeploymentTimeout time.Duration
+
+	// AnvilShutdownTimeout is how long to wait for Anvil to dump its state
+	// and exit before killing it. Forked chains wait at least 60 seconds.
+	// Defaults to DefaultAnvilShutdownTimeout.
+	AnvilShutdownTimeout time.Duration
+
+	// StageAttempts is how many times a network-bound stage (artifact
+	// download, contract deployment, WETH deployment, rollup creation) is
+	// attempted before the deployment fails. Defaults to DefaultStageAttempts.
+	StageAttempts int
+
+	// StageRetryBackoff is the wait before the first retry of a failed
+	// stage. Defaults to DefaultStageRetryBackoff.
+	StageRetryBackoff time.Duration
 }
 
 // Orchestrator coordinates POPKins devnet bundle deployments.
@@ -79,6 +121,7 @@ type Orchestrator struct {
 	repo   repository.Repository
 	config OrchestratorConfig
 	logger *slog.Logger
+	queue  *deploymentQueue
 }
 
 // New Orchestrator creates a new POPKins bundle deployment orchestrator.
@@ -99,10 +142,27 @@ func NewOrchestrator(
 		config.WorkDir = filepath.Join(os.TempDir(), "popdeployer-work")
 	}
 
+	if config.DeploymentTimeout <= 0 {
+		config.DeploymentTimeout = DefaultDeploymentTimeout
+	}
+
+	if config.AnvilShutdownTimeout <= 0 {
+		config.AnvilShutdownTimeout = DefaultAnvilShutdownTimeout
+	}
+
+	if config.StageAttempts <= 0 {
+		config.StageAttempts = DefaultStageAttempts
+	}
+
+	if config.StageRetryBackoff <= 0 {
+		config.StageRetryBackoff = DefaultStageRetryBackoff
+	}
+
 	return &Orchestrator{
 		repo:   repo,
 		config: config,
 		logger: logger,
+		queue:  newDeploymentQueue(config.MaxConcurrentDeployments),
 	}
 }
 
@@ -129,8 +189,18 @@ func (o *Orchestrator) Deploy(ctx context.Context, deploymentID uuid.UUID, onPro
 		return fmt.Errorf("unmarshal config: %w", err)
 	}
 
-	// 3. Populate hardcoded values
+	// 3. Populate hardcoded values and validate before any work starts
 	cfg = o.populateDefaults(cfg)
+	if err := ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	// Wait for a free deployment slot
+	stageWriter := &StageWriter{repo: o.repo, deploymentID: deploymentID}
+	if err := o.acquireSlot(ctx, deploymentID, stageWriter, onProgress); err != nil {
+		return err
+	}
+	defer o.queue.release()
 
 	// 4. Create work directory for this deployment
 	workDir := filepath.Join(o.config.WorkDir, deploymentID.String())
@@ -139,45 +209,64 @@ func (o *Orchestrator) Deploy(ctx context.Context, deploymentID uuid.UUID, onPro
 	}
 	defer os.RemoveAll(workDir) // Clean up after deployment
 
-	// 5. Create deployment context
-	deployCtx := &DeploymentContext{
-		DeploymentID: deploymentID,
-		Config:       &cfg,
-		WorkDir:      workDir,
-		OnProgress:   onProgress,
-	}
-
-	// 6. Dispatch based on bundle_stack
-	stageWriter := &StageWriter{repo: o.repo, deploymentID: deploymentID}
-
+	// 5. Dispatch based on bundle_stack
 	// Default to "opstack" if bundle_stack is empty
 	bundleStack := cfg.BundleStack
 	if bundleStack == "" {
 		bundleStack = "opstack"
 	}
+	stageWeights := opStackStageWeights
+	if bundleStack == "nitro" {
+		stageWeights = nitroStageWeights
+	}
+	progress := newProgressTracker(stageWeights, onProgress)
+
+	// 6. Create deployment context
+	deployCtx := &DeploymentContext{
+		DeploymentID: deploymentID,
+		Config:       &cfg,
+		WorkDir:      workDir,
+		OnProgress:   progress.report,
+	}
 
 	o.logger.Info("deploying bundle",
 		slog.String("bundle_stack", bundleStack),
 		slog.String("deployment_id", deploymentID.String()),
 	)
 
-	var deployErr error
-	switch bundleStack {
-	case "nitro":
-		deployErr = o.deployNitroBundle(ctx, deployCtx, stageWriter)
-	default:
-		// OP Stack (default)
-		deployErr = o.deployOPStackBundle(ctx, deployCtx, stageWriter)
+	// Capture this deployment's log in the work dir alongside the server log,
+	// so it can be saved with anvil.log before the work dir is removed
+	logFile, err := os.Create(filepath.Join(workDir, "deployment.log"))
+	if err != nil {
+		return fmt.Errorf("create deployment log: %w", err)
+	}
+	do := *o
+	do.logger = deploymentLogger(o.logger, logFile)
+
+	deployErr := do.withDeploymentTimeout(ctx, func(ctx context.Context) error {
+		switch bundleStack {
+		case "nitro":
+			return do.deployNitroBundle(ctx, deployCtx, stageWriter)
+		default:
+			// OP Stack (default)
+			return do.deployOPStackBundle(ctx, deployCtx, stageWriter)
+		}
+	})
+
+	if deployErr != nil {
+		do.logger.Error("bundle deployment failed", slog.String("error", deployErr.Error()))
+		// Stop Anvil if it is still running so its log is complete
+		deployCtx.Cleanup()
 	}
+	logFile.Close()
+	o.saveLogArtifacts(ctx, deploymentID, workDir)
 
 	if deployErr != nil {
 		return deployErr
 	}
 
 	// Mark as complete
-	if onProgress != nil {
-		onProgress(StageComplete, 1.0, "Bundle deployment complete")
-	}
+	progress.complete("Bundle deployment complete")
 
 	// Update deployment status to completed (not just the stage)
 	stageStr := StageComplete.String()
@@ -195,6 +284,100 @@ func (o *Orchestrator) Deploy(ctx context.Context, deploymentID uuid.UUID, onPro
 	return nil
 }
 
+// withDeploymentTimeout runs deploy under the configured DeploymentTimeout.
+// It returns ErrDeploymentTimeout once the timeout expires, even if deploy is
+// blocked in a call that doesn't honor its context; the caller's Cleanup then
+// stops Anvil, which unblocks any pending RPC calls.
+func (o *Orchestrator) withDeploymentTimeout(ctx context.Context, deploy func(context.Context) error) error {
+	timeout := o.config.DeploymentTimeout
+	if timeout <= 0 {
+		timeout = DefaultDeploymentTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- deploy(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s: %v", ErrDeploymentTimeout, timeout, err)
+	}
+	return err
+}
+
+// acquireSlot waits until the deployment may run. While queued, the
+// deployment's stage is set to queued.
+func (o *Orchestrator) acquireSlot(ctx context.Context, deploymentID uuid.UUID, sw *StageWriter, onProgress ProgressCallback) error {
+	entry := o.queue.tryAcquire(deploymentID)
+	if entry == nil {
+		return nil
+	}
+
+	position := o.queue.position(deploymentID)
+	o.logger.Info("deployment queued",
+		slog.String("deployment_id", deploymentID.String()),
+		slog.Int("position", position),
+	)
+	if err := sw.UpdateStage(ctx, StageQueued); err != nil {
+		o.logger.Warn("failed to mark deployment as queued", slog.String("error", err.Error()))
+	}
+	if onProgress != nil {
+		onProgress(StageQueued, 0, fmt.Sprintf("Waiting for a free deployment slot (position %d)", position))
+	}
+
+	if err := o.queue.wait(ctx, entry); err != nil {
+		return fmt.Errorf("wait for deployment slot: %w", err)
+	}
+
+	o.logger.Info("deployment dequeued", slog.String("deployment_id", deploymentID.String()))
+	return nil
+}
+
+// GetDeploymentStatus returns the current status of a deployment, including
+// its position in the queue while it waits for a deployment slot.
+func (o *Orchestrator) GetDeploymentStatus(ctx context.Context, deploymentID uuid.UUID) (*DeploymentStatus, error) {
+	deployment, err := o.repo.GetDeployment(ctx, deploymentID)
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %w", err)
+	}
+	if deployment == nil {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+
+	var currentStage Stage
+	if deployment.CurrentStage != nil {
+		currentStage = Stage(*deployment.CurrentStage)
+	}
+
+	return &DeploymentStatus{
+		DeploymentID:  deploymentID,
+		Status:        deployment.Status,
+		CurrentStage:  currentStage,
+		QueuePosition: o.queue.position(deploymentID),
+		Error:         deployment.ErrorMessage,
+	}, nil
+}
+
+// DeploymentStatus represents the current state of a deployment.
+type DeploymentStatus struct {
+	DeploymentID uuid.UUID
+	Status       repository.Status
+	CurrentStage Stage
+	// QueuePosition is the 1-based position in the deployment queue, or 0
+	// if the deployment is not waiting for a slot.
+	QueuePosition int
+	Error         *string
+}
+
 // deployOPStackBundle deploys an OP Stack devnet bundle.
 func (o *Orchestrator) deployOPStackBundle(ctx context.Context, deployCtx *DeploymentContext, stageWriter *StageWriter) error {
 	// Stage 1: Start Anvil
@@ -202,8 +385,15 @@ func (o *Orchestrator) deployOPStackBundle(ctx context.Context, deployCtx *Deplo
 		return fmt.Errorf("start anvil: %w", err)
 	}
 
-	// Stage 2: Deploy OP Stack contracts (Anvil handles signing directly)
-	result, err := o.deployOPStack(ctx, deployCtx, stageWriter)
+	// Stage 2: Deploy OP Stack contracts (Anvil handles signing directly).
+	// Retried only while nothing has been broadcast.
+	var result *opstack.DeployResult
+	deployer := common.HexToAddress(deployCtx.Config.DeployerAddress)
+	err := o.retryStage(ctx, StageDeployingContracts, broadcastGuard(l1PendingNonce(deployCtx, deployer), func(ctx context.Context) error {
+		var err error
+		result, err = o.deployOPStack(ctx, deployCtx, stageWriter)
+		return err
+	}))
 	if err != nil {
 		return fmt.Errorf("deploy opstack: %w", err)
 	}
@@ -227,6 +417,7 @@ type nitroDeployResult struct {
 	chainConfig     map[string]interface{}
 	deploymentBlock uint64
 	stakeToken      common.Address
+	artifacts       *nitro.NitroArtifacts
 }
 
 // deployNitroBundle deploys a Nitro devnet bundle.
@@ -244,7 +435,12 @@ func (o *Orchestrator) deployNitroBundle(ctx context.Context, deployCtx *Deploym
 	}
 
 	// Stage 2: Download Nitro contract artifacts
-	artifacts, err := o.downloadNitroArtifacts(ctx, deployCtx, stageWriter)
+	var artifacts *nitro.NitroArtifacts
+	err := o.retryStage(ctx, StageDownloadingArtifacts, func(ctx context.Context) error {
+		var err error
+		artifacts, err = o.downloadNitroArtifacts(ctx, deployCtx, stageWriter)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("download nitro artifacts: %w", err)
 	}
@@ -261,14 +457,19 @@ func (o *Orchestrator) deployNitroBundle(ctx context.Context, deployCtx *Deploym
 		return fmt.Errorf("deploy infrastructure: %w", err)
 	}
 
-	// Stage 5: Deploy WETH for BOLD staking
-	stakeToken, err := o.deployWETH(ctx, deployCtx, stageWriter, signer)
+	// Stage 5: Deploy WETH for BOLD staking, unless a stake token is configured
+	stakeToken, err := o.resolveStakeToken(ctx, deployCtx, stageWriter, signer)
 	if err != nil {
-		return fmt.Errorf("deploy WETH: %w", err)
+		return fmt.Errorf("resolve stake token: %w", err)
 	}
 
-	// Stage 6: Create Rollup
-	rollupResult, err := o.deployNitroRollup(ctx, deployCtx, stageWriter, artifacts, signer, infraResult.RollupCreatorAddress, stakeToken)
+	// Stage 6: Create Rollup, retried only while nothing has been broadcast
+	var rollupResult *nitro.RollupDeployResult
+	err = o.retryStage(ctx, StageCreatingRollup, broadcastGuard(l1PendingNonce(deployCtx, signer.Address()), func(ctx context.Context) error {
+		var err error
+		rollupResult, err = o.deployNitroRollup(ctx, deployCtx, stageWriter, artifacts, signer, infraResult.RollupCreatorAddress, stakeToken)
+		return err
+	}))
 	if err != nil {
 		return fmt.Errorf("deploy rollup: %w", err)
 	}
@@ -284,6 +485,7 @@ func (o *Orchestrator) deployNitroBundle(ctx context.Context, deployCtx *Deploym
 		chainConfig:     rollupResult.ChainConfig,
 		deploymentBlock: rollupResult.BlockNumber,
 		stakeToken:      stakeToken,
+		artifacts:       artifacts,
 	}
 	if err := o.generateNitroConfigs(ctx, deployCtx, nitroResult, stageWriter); err != nil {
 		return fmt.Errorf("generate nitro configs: %w", err)
@@ -295,7 +497,7 @@ func (o *Orchestrator) deployNitroBundle(ctx context.Context, deployCtx *Deploym
 // downloadNitroArtifacts downloads Nitro contract artifacts from S3.
 func (o *Orchestrator) downloadNitroArtifacts(ctx context.Context, deployCtx *DeploymentContext, sw *StageWriter) (*nitro.NitroArtifacts, error) {
 	if deployCtx.OnProgress != nil {
-		deployCtx.OnProgress(StageDownloadingArtifacts, 0.15, "Downloading Nitro contract artifacts...")
+		deployCtx.OnProgress(StageDownloadingArtifacts, 0, "Downloading Nitro contract artifacts...")
 	}
 	if err := sw.UpdateStage(ctx, StageDownloadingArtifacts); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
@@ -304,7 +506,8 @@ func (o *Orchestrator) downloadNitroArtifacts(ctx context.Context, deployCtx *De
 	cacheDir := filepath.Join(o.config.CacheDir, "nitro-artifacts")
 	downloader := nitro.NewContractArtifactDownloader(cacheDir)
 
-	artifacts, err := downloader.DownloadDefault(ctx)
+	version, checksum := deployCtx.Config.NitroArtifactPin()
+	artifacts, err := downloader.DownloadVersion(ctx, version, checksum)
 	if err != nil {
 		return nil, fmt.Errorf("download artifacts: %w", err)
 	}
@@ -312,6 +515,7 @@ func (o *Orchestrator) downloadNitroArtifacts(ctx context.Context, deployCtx *De
 	o.logger.Info("Nitro artifacts downloaded",
 		slog.String("version", artifacts.Version),
 		slog.String("source", artifacts.SourceURL),
+		slog.String("checksum", artifacts.Checksum),
 	)
 
 	return artifacts, nil
@@ -343,7 +547,7 @@ func (o *Orchestrator) deployNitroInfrastructure(
 	signer *nitro.LocalSigner,
 ) (*nitro.InfrastructureResult, error) {
 	if deployCtx.OnProgress != nil {
-		deployCtx.OnProgress(StageDeployingInfrastructure, 0.25, "Deploying Nitro infrastructure...")
+		deployCtx.OnProgress(StageDeployingInfrastructure, 0, "Deploying Nitro infrastructure...")
 	}
 	if err := sw.UpdateStage(ctx, StageDeployingInfrastructure); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
@@ -380,7 +584,7 @@ func (o *Orchestrator) deployWETH(
 	signer *nitro.LocalSigner,
 ) (common.Address, error) {
 	if deployCtx.OnProgress != nil {
-		deployCtx.OnProgress(StageDeployingWETH, 0.35, "Deploying WETH for BOLD staking...")
+		deployCtx.OnProgress(StageDeployingWETH, 0, "Deploying WETH for BOLD staking...")
 	}
 	if err := sw.UpdateStage(ctx, StageDeployingWETH); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
@@ -405,7 +609,7 @@ func (o *Orchestrator) deployWETH(
 	}
 
 	// Create contract creation transaction
-	tx := types.NewContractCreation(nonce, big.NewInt(0), 1_000_000, gasPrice, wethBytecode)
+	tx := types.NewContractCreation(nonce, big.NewInt(0), wethGasLimit, gasPrice, wethBytecode)
 
 	signedTx, err := signer.SignTransaction(ctx, tx)
 	if err != nil {
@@ -426,6 +630,10 @@ func (o *Orchestrator) deployWETH(
 		return common.Address{}, fmt.Errorf("WETH deployment reverted")
 	}
 
+	if err := checkWETH(ctx, client, receipt.ContractAddress); err != nil {
+		return common.Address{}, fmt.Errorf("verify WETH at %s: %w", receipt.ContractAddress.Hex(), err)
+	}
+
 	o.logger.Info("WETH deployed",
 		slog.String("address", receipt.ContractAddress.Hex()),
 	)
@@ -433,6 +641,77 @@ func (o *Orchestrator) deployWETH(
 	return receipt.ContractAddress, nil
 }
 
+// wethMetadataABI is the ERC-20 metadata subset of WETH9.
+const wethMetadataABI = `[
+	{"inputs": [], "name": "name", "outputs": [{"name": "", "type": "string"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [], "name": "symbol", "outputs": [{"name": "", "type": "string"}], "stateMutability": "view", "type": "function"},
+	{"inputs": [], "name": "decimals", "outputs": [{"name": "", "type": "uint8"}], "stateMutability": "view", "type": "function"}
+]`
+
+// checkWETH calls name(), symbol() and decimals() on a freshly deployed
+// WETH9, catching truncated or wrong bytecode before the rollup stakes with it.
+func checkWETH(ctx context.Context, caller ethereum.ContractCaller, addr common.Address) error {
+	parsed, err := abi.JSON(strings.NewReader(wethMetadataABI))
+	if err != nil {
+		return fmt.Errorf("parse WETH ABI: %w", err)
+	}
+
+	want := []struct {
+		method string
+		value  interface{}
+	}{
+		{"name", "Wrapped Ether"},
+		{"symbol", "WETH"},
+		{"decimals", uint8(18)},
+	}
+	for _, w := range want {
+		data, err := parsed.Pack(w.method)
+		if err != nil {
+			return fmt.Errorf("pack %s: %w", w.method, err)
+		}
+		result, err := caller.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: data}, nil)
+		if err != nil {
+			return fmt.Errorf("call %s: %w", w.method, err)
+		}
+		values, err := parsed.Unpack(w.method, result)
+		if err != nil || len(values) != 1 {
+			return fmt.Errorf("%s() returned malformed data %#x", w.method, result)
+		}
+		if values[0] != w.value {
+			return fmt.Errorf("%s() returned %v, want %v", w.method, values[0], w.value)
+		}
+	}
+	return nil
+}
+
+// resolveStakeToken returns the configured stake token, or deploys WETH if
+// none is configured.
+func (o *Orchestrator) resolveStakeToken(
+	ctx context.Context,
+	deployCtx *DeploymentContext,
+	sw *StageWriter,
+	signer *nitro.LocalSigner,
+) (common.Address, error) {
+	if token, ok := deployCtx.Config.NitroStakeToken(); ok {
+		o.logger.Info("using configured stake token, skipping WETH deployment",
+			slog.String("address", token.Hex()),
+		)
+		return token, nil
+	}
+
+	// Retried only while nothing has been broadcast
+	var token common.Address
+	err := o.retryStage(ctx, StageDeployingWETH, broadcastGuard(l1PendingNonce(deployCtx, signer.Address()), func(ctx context.Context) error {
+		var err error
+		token, err = o.deployWETH(ctx, deployCtx, sw, signer)
+		return err
+	}))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("deploy WETH: %w", err)
+	}
+	return token, nil
+}
+
 // waitForReceipt waits for a transaction receipt.
 func waitForReceipt(ctx context.Context, client *ethclient.Client, txHash common.Hash) (*types.Receipt, error) {
 	ticker := time.NewTicker(500 * time.Millisecond)
@@ -463,7 +742,7 @@ func (o *Orchestrator) deployNitroRollup(
 	stakeToken common.Address,
 ) (*nitro.RollupDeployResult, error) {
 	if deployCtx.OnProgress != nil {
-		deployCtx.OnProgress(StageCreatingRollup, 0.45, "Creating Nitro rollup...")
+		deployCtx.OnProgress(StageCreatingRollup, 0, "Creating Nitro rollup...")
 	}
 	if err := sw.UpdateStage(ctx, StageCreatingRollup); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
@@ -474,22 +753,7 @@ func (o *Orchestrator) deployNitroRollup(
 		return nil, fmt.Errorf("create rollup deployer: %w", err)
 	}
 
-	// Use hardcoded Anvil addresses for batch poster and validator
-	batchPosterAddr := common.HexToAddress(deployCtx.Config.BatcherAddress)
-	validatorAddr := common.HexToAddress(deployCtx.Config.ProposerAddress)
-
-	cfg := &nitro.RollupConfig{
-		ChainID:          int64(deployCtx.Config.ChainID),
-		ChainName:        deployCtx.Config.ChainName,
-		ParentChainID:    int64(deployCtx.Config.L1ChainID),
-		ParentChainRPC:   deployCtx.Config.L1RPC,
-		Owner:            signer.Address(),
-		BatchPosters:     []common.Address{batchPosterAddr},
-		Validators:       []common.Address{validatorAddr},
-		StakeToken:       stakeToken,
-		BaseStake:        big.NewInt(100000000000000000), // 0.1 ETH
-		DataAvailability: nitro.DAModeCelestia,
-	}
+	cfg := nitroRollupConfig(deployCtx.Config, signer.Address(), stakeToken)
 
 	result, err := deployer.Deploy(ctx, cfg, rollupCreatorAddr)
 	if err != nil {
@@ -510,29 +774,37 @@ func (o *Orchestrator) deployNitroRollup(
 	return result, nil
 }
 
+// nitroRollupConfig creates the Nitro rollup config for a bundle.
+func nitroRollupConfig(cfg *DeploymentConfig, owner, stakeToken common.Address) *nitro.RollupConfig {
+	return &nitro.RollupConfig{
+		ChainID:          int64(cfg.ChainID),
+		ChainName:        cfg.ChainName,
+		ParentChainID:    int64(cfg.L1ChainID),
+		ParentChainRPC:   cfg.L1RPC,
+		Owner:            owner,
+		BatchPosters:     cfg.NitroBatchPosters(),
+		Validators:       cfg.NitroValidators(),
+		StakeToken:       stakeToken,
+		BaseStake:        cfg.NitroBaseStake(),
+		DataAvailability: nitro.DAModeCelestia,
+	}
+}
+
 // generateNitroConfigs generates all Nitro configuration files and saves them as artifacts.
 func (o *Orchestrator) generateNitroConfigs(ctx context.Context, deployCtx *DeploymentContext, result *nitroDeployResult, sw *StageWriter) error {
 	if deployCtx.OnProgress != nil {
-		deployCtx.OnProgress(StageGeneratingConfigs, 0.8, "Generating Nitro configuration files...")
+		deployCtx.OnProgress(StageGeneratingConfigs, 0, "Generating Nitro configuration files...")
 	}
 	if err := sw.UpdateStage(ctx, StageGeneratingConfigs); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
 	}
 
-	// Celestia key ID is hardcoded - popsigner-lite uses deterministic IDs for Anvil accounts
-	celestiaKeyID := "anvil-9"
-
 	o.logger.Info("Using Celestia key",
-		slog.String("key_id", celestiaKeyID),
+		slog.String("key_id", deployCtx.Config.CelestiaKey()),
 	)
 
 	// Create Nitro config writer
-	writer := &NitroConfigWriter{
-		logger:        o.logger,
-		result:        result,
-		config:        deployCtx.Config,
-		celestiaKeyID: celestiaKeyID,
-	}
+	writer := newNitroConfigWriter(o.logger, result, deployCtx.Config)
 
 	// Generate all configs
 	artifacts, err := writer.GenerateAll()
@@ -596,7 +868,10 @@ type DeploymentContext struct {
 	DeploymentID uuid.UUID
 	Config       *DeploymentConfig
 	WorkDir      string
-	OnProgress   ProgressCallback
+
+	// OnProgress reports progress within the current stage, from 0.0 at its
+	// start to 1.0 at its end. Deploy maps it to overall progress.
+	OnProgress ProgressCallback
 
 	// Process handles for cleanup
 	AnvilCmd *exec.Cmd
@@ -629,16 +904,16 @@ func (o *Orchestrator) populateDefaults(cfg DeploymentConfig) DeploymentConfig {
 	cfg.L1RPC = "http://localhost:8545"
 
 	// Set hardcoded Anvil accounts
-	cfg.DeployerAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" // anvil-0
-	cfg.BatcherAddress = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"  // anvil-1
-	cfg.ProposerAddress = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC" // anvil-2
+	cfg.DeployerAddress = anvilDeployerAddress
+	cfg.BatcherAddress = anvilBatcherAddress
+	cfg.ProposerAddress = anvilProposerAddress
 
-	// Set hardcoded chain parameters
+	// Default chain parameters
 	if cfg.BlockTime == 0 {
-		cfg.BlockTime = 2
+		cfg.BlockTime = DefaultBlockTime
 	}
 	if cfg.GasLimit == 0 {
-		cfg.GasLimit = 30000000
+		cfg.GasLimit = DefaultGasLimit
 	}
 
 	// Note: POPSigner-Lite is NOT needed during bundle build phase
@@ -653,7 +928,7 @@ func (o *Orchestrator) populateDefaults(cfg DeploymentConfig) DeploymentConfig {
 // without port conflicts.
 func (o *Orchestrator) startAnvil(ctx context.Context, dc *DeploymentContext, sw *StageWriter) error {
 	if dc.OnProgress != nil {
-		dc.OnProgress(StageStartingAnvil, 0.1, "Starting ephemeral Anvil L1...")
+		dc.OnProgress(StageStartingAnvil, 0, "Starting ephemeral Anvil L1...")
 	}
 	if err := sw.UpdateStage(ctx, StageStartingAnvil); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
@@ -670,15 +945,7 @@ func (o *Orchestrator) startAnvil(ctx context.Context, dc *DeploymentContext, sw
 	// go-ethereum's rpc.DialContext supports IPC paths directly
 	dc.Config.L1RPC = ipcPath
 
-	dc.AnvilCmd = exec.CommandContext(ctx, "anvil",
-		"--chain-id", fmt.Sprintf("%d", dc.Config.L1ChainID),
-		"--accounts", "10",
-		"--balance", "10000",
-		"--gas-limit", fmt.Sprintf("%d", dc.Config.GasLimit),
-		"--block-time", fmt.Sprintf("%d", dc.Config.BlockTime),
-		"--ipc", ipcPath,
-		"--state", stateFile,
-	)
+	dc.AnvilCmd = exec.CommandContext(ctx, "anvil", anvilArgs(dc.Config, ipcPath, stateFile)...)
 
 	// Redirect output to log files in work directory
 	anvilLog := filepath.Join(dc.WorkDir, "anvil.log")
@@ -703,11 +970,37 @@ func (o *Orchestrator) startAnvil(ctx context.Context, dc *DeploymentContext, sw
 	o.logger.Info("Anvil is ready (IPC mode)",
 		slog.String("ipc", ipcPath),
 		slog.String("state_file", stateFile),
+		slog.Bool("fork", dc.Config.IsFork()),
 	)
 
 	return nil
 }
 
+// anvilArgs builds the Anvil command line. A fresh chain gets the configured
+// number of dev accounts (10 by default) with an explicit genesis balance. In fork mode Anvil starts from the forked
+// chain's state instead, so the genesis balance (which assumes empty accounts)
+// is not passed and the fork URL and optional block are added.
+func anvilArgs(cfg *DeploymentConfig, ipcPath, stateFile string) []string {
+	args := []string{
+		"--chain-id", fmt.Sprintf("%d", cfg.L1ChainID),
+		"--accounts", fmt.Sprintf("%d", cfg.AnvilAccountCount()),
+	}
+	if cfg.IsFork() {
+		args = append(args, "--fork-url", cfg.L1ForkURL)
+		if cfg.L1ForkBlock > 0 {
+			args = append(args, "--fork-block-number", fmt.Sprintf("%d", cfg.L1ForkBlock))
+		}
+	} else {
+		args = append(args, "--balance", fmt.Sprintf("%d", cfg.AnvilBalanceETH()))
+	}
+	return append(args,
+		"--gas-limit", fmt.Sprintf("%d", cfg.GasLimit),
+		"--block-time", fmt.Sprintf("%d", cfg.BlockTime),
+		"--ipc", ipcPath,
+		"--state", stateFile,
+	)
+}
+
 // waitForIPC polls for an IPC socket to be ready by attempting actual RPC connections.
 // Simply checking file existence is insufficient - the socket file can exist before
 // anvil is ready to accept connections (especially on macOS).
@@ -744,28 +1037,13 @@ func waitForIPC(path string, timeout time.Duration) bool {
 // deployOPStack deploys OP Stack contracts using the op-deployer.
 func (o *Orchestrator) deployOPStack(ctx context.Context, dc *DeploymentContext, sw *StageWriter) (*opstack.DeployResult, error) {
 	if dc.OnProgress != nil {
-		dc.OnProgress(StageDeployingContracts, 0.3, "Deploying OP Stack contracts...")
+		dc.OnProgress(StageDeployingContracts, 0, "Deploying OP Stack contracts...")
 	}
 	if err := sw.UpdateStage(ctx, StageDeployingContracts); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
 	}
 
-	// Create opstack deployment config
-	// UseLocalSigning=true skips POPSigner validation - we use AnvilSigner instead
-	// FundDevAccounts=true pre-funds Anvil's accounts on L2 for testing
-	opstackCfg := &opstack.DeploymentConfig{
-		ChainID:         dc.Config.ChainID,
-		ChainName:       dc.Config.ChainName,
-		L1ChainID:       dc.Config.L1ChainID,
-		L1RPC:           dc.Config.L1RPC,
-		DeployerAddress: dc.Config.DeployerAddress,
-		BatcherAddress:  dc.Config.BatcherAddress,
-		ProposerAddress: dc.Config.ProposerAddress,
-		BlockTime:       dc.Config.BlockTime,
-		GasLimit:        dc.Config.GasLimit,
-		UseLocalSigning: true, // Use AnvilSigner for Anvil's well-known keys
-		FundDevAccounts: true, // Pre-fund Anvil accounts on L2 for local testing
-	}
+	opstackCfg := opstackDeploymentConfig(dc.Config)
 
 	// Create deployer
 	deployer := opstack.NewOPDeployer(opstack.OPDeployerConfig{
@@ -788,17 +1066,11 @@ func (o *Orchestrator) deployOPStack(ctx context.Context, dc *DeploymentContext,
 			slog.Float64("progress", progress*100),
 		)
 		if dc.OnProgress != nil {
-			// Map opstack progress (0.0-1.0) to our range (0.3-0.6)
-			adjustedProgress := 0.3 + (progress * 0.3)
-			dc.OnProgress(StageDeployingContracts, adjustedProgress, message)
+			dc.OnProgress(StageDeployingContracts, progress, message)
 		}
 	}
 
-	// Deploy with timeout
-	deployCtx, deployCancel := context.WithTimeout(ctx, deploymentTimeout)
-	defer deployCancel()
-
-	result, err := deployer.Deploy(deployCtx, opstackCfg, adapter, progressCallback)
+	result, err := deployer.Deploy(ctx, opstackCfg, adapter, progressCallback)
 	if err != nil {
 		return nil, fmt.Errorf("deploy: %w", err)
 	}
@@ -845,83 +1117,62 @@ func (o *Orchestrator) deployOPStack(ctx context.Context, dc *DeploymentContext,
 	return result, nil
 }
 
-// captureAnvilState gracefully shuts down Anvil to trigger state dump.
+// opstackDeploymentConfig creates the opstack deployment config for a bundle.
+// UseLocalSigning=true skips POPSigner validation - we use AnvilSigner instead
+// FundDevAccounts=true pre-funds Anvil's accounts on L2 for testing
+func opstackDeploymentConfig(cfg *DeploymentConfig) *opstack.DeploymentConfig {
+	return &opstack.DeploymentConfig{
+		ChainID:         cfg.ChainID,
+		ChainName:       cfg.ChainName,
+		L1ChainID:       cfg.L1ChainID,
+		L1RPC:           cfg.L1RPC,
+		DeployerAddress: cfg.DeployerAddress,
+		BatcherAddress:  cfg.BatcherAddress,
+		ProposerAddress: cfg.ProposerAddress,
+		BlockTime:       cfg.BlockTime,
+		GasLimit:        cfg.GasLimit,
+		UseLocalSigning: true, // Use AnvilSigner for Anvil's well-known keys
+		FundDevAccounts: true, // Pre-fund Anvil accounts on L2 for local testing
+	}
+}
+
+// captureAnvilState gracefully shuts down Anvil to trigger state dump, and
+// fails if the dump is incomplete rather than saving a truncated state.
 func (o *Orchestrator) captureAnvilState(ctx context.Context, dc *DeploymentContext, sw *StageWriter) error {
 	if dc.OnProgress != nil {
-		dc.OnProgress(StageCapturingState, 0.7, "Capturing Anvil state...")
+		dc.OnProgress(StageCapturingState, 0, "Capturing Anvil state...")
 	}
 	if err := sw.UpdateStage(ctx, StageCapturingState); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
 	}
 
-	// Send SIGTERM for graceful shutdown
-	if err := dc.AnvilCmd.Process.Signal(syscall.SIGTERM); err != nil {
-		return fmt.Errorf("send SIGTERM to anvil: %w", err)
+	shutdownTimeout := o.config.AnvilShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DefaultAnvilShutdownTimeout
 	}
-
-	// Wait for Anvil to finish (with timeout)
-	anvilDone := make(chan error, 1)
-	go func() {
-		anvilDone <- dc.AnvilCmd.Wait()
-	}()
-
-	select {
-	case err := <-anvilDone:
-		// SIGTERM exit is expected, other errors are warnings
-		if err != nil {
-			// Check if it's a signal termination (expected)
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
-					// Normal SIGTERM exit - no warning needed
-					break
-				}
-			}
-			// Unexpected error
-			o.logger.Warn("Anvil exited with error", slog.String("error", err.Error()))
-		}
-	case <-time.After(anvilShutdownTimeout):
-		o.logger.Warn("Anvil shutdown timeout, forcing kill")
-		dc.AnvilCmd.Process.Kill()
+	if dc.Config.IsFork() && shutdownTimeout < anvilForkShutdownTimeout {
+		shutdownTimeout = anvilForkShutdownTimeout
 	}
 
-	// Verify state file was created
 	stateFile := filepath.Join(dc.WorkDir, "anvil-state.json")
-	if info, err := os.Stat(stateFile); err == nil {
-		o.logger.Info("Anvil state dumped successfully",
-			slog.String("file", stateFile),
-			slog.Int64("size_kb", info.Size()/1024),
-		)
-	} else {
-		return fmt.Errorf("anvil state file not created: %w", err)
-	}
-
-	return nil
+	return StopAnvil(dc.AnvilCmd, stateFile, shutdownTimeout, o.logger)
 }
 
 // generateConfigs generates all configuration files and saves them as artifacts.
 func (o *Orchestrator) generateConfigs(ctx context.Context, dc *DeploymentContext, result *opstack.DeployResult, sw *StageWriter) error {
 	if dc.OnProgress != nil {
-		dc.OnProgress(StageGeneratingConfigs, 0.8, "Generating configuration files...")
+		dc.OnProgress(StageGeneratingConfigs, 0, "Generating configuration files...")
 	}
 	if err := sw.UpdateStage(ctx, StageGeneratingConfigs); err != nil {
 		o.logger.Warn("failed to update stage", slog.String("error", err.Error()))
 	}
 
-	// Celestia key ID is hardcoded - popsigner-lite uses deterministic IDs for Anvil accounts
-	// anvil-9 = 0xa0Ee7A142d267C1f36714E4a8F75612F20a79720
-	celestiaKeyID := "anvil-9"
-
 	o.logger.Info("Using Celestia key",
-		slog.String("key_id", celestiaKeyID),
+		slog.String("key_id", dc.Config.CelestiaKey()),
 	)
 
 	// Create config writer
-	writer := &ConfigWriter{
-		logger:        o.logger,
-		result:        result,
-		config:        dc.Config,
-		celestiaKeyID: celestiaKeyID,
-	}
+	writer := newConfigWriter(o.logger, result, dc.Config)
 
 	// Generate all configs
 	artifacts, err := writer.GenerateAll()
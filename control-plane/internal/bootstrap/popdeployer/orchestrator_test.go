@@ -0,0 +1,565 @@
+package popdeployer
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/big"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/nitro"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnvilArgs_FreshChain(t *testing.T) {
+	cfg := &DeploymentConfig{L1ChainID: 31337, GasLimit: 30000000, BlockTime: 2}
+
+	args := anvilArgs(cfg, "/work/anvil.ipc", "/work/anvil-state.json")
+
+	assert.Equal(t, []string{
+		"--chain-id", "31337",
+		"--accounts", "10",
+		"--balance", "10000",
+		"--gas-limit", "30000000",
+		"--block-time", "2",
+		"--ipc", "/work/anvil.ipc",
+		"--state", "/work/anvil-state.json",
+	}, args)
+}
+
+func TestAnvilArgs_Fork(t *testing.T) {
+	cfg := &DeploymentConfig{
+		L1ChainID:   31337,
+		GasLimit:    30000000,
+		BlockTime:   2,
+		L1ForkURL:   "https://sepolia.example.com",
+		L1ForkBlock: 7000000,
+	}
+
+	args := anvilArgs(cfg, "/work/anvil.ipc", "/work/anvil-state.json")
+
+	assert.Equal(t, []string{
+		"--chain-id", "31337",
+		"--accounts", "10",
+		"--fork-url", "https://sepolia.example.com",
+		"--fork-block-number", "7000000",
+		"--gas-limit", "30000000",
+		"--block-time", "2",
+		"--ipc", "/work/anvil.ipc",
+		"--state", "/work/anvil-state.json",
+	}, args)
+}
+
+func TestAnvilArgs_ForkLatestBlock(t *testing.T) {
+	cfg := &DeploymentConfig{L1ChainID: 31337, L1ForkURL: "https://sepolia.example.com"}
+
+	args := anvilArgs(cfg, "/work/anvil.ipc", "/work/anvil-state.json")
+
+	assert.Contains(t, args, "--fork-url")
+	assert.NotContains(t, args, "--fork-block-number")
+	assert.NotContains(t, args, "--balance")
+	// State is still dumped so the forked chain can be captured
+	assert.Contains(t, args, "--state")
+}
+
+func TestAnvilArgs_CustomAccounts(t *testing.T) {
+	cfg := &DeploymentConfig{L1ChainID: 31337, GasLimit: 30000000, BlockTime: 2, AnvilAccounts: 50, AnvilBalance: 1000000}
+
+	args := anvilArgs(cfg, "/work/anvil.ipc", "/work/anvil-state.json")
+
+	assert.Equal(t, []string{
+		"--chain-id", "31337",
+		"--accounts", "50",
+		"--balance", "1000000",
+		"--gas-limit", "30000000",
+		"--block-time", "2",
+		"--ipc", "/work/anvil.ipc",
+		"--state", "/work/anvil-state.json",
+	}, args)
+}
+
+func TestAnvilArgs_ForkIgnoresBalance(t *testing.T) {
+	cfg := &DeploymentConfig{L1ChainID: 31337, L1ForkURL: "https://sepolia.example.com", AnvilAccounts: 20, AnvilBalance: 5}
+
+	args := anvilArgs(cfg, "/work/anvil.ipc", "/work/anvil-state.json")
+
+	assert.Equal(t, []string{"--chain-id", "31337", "--accounts", "20"}, args[:4])
+	assert.NotContains(t, args, "--balance")
+}
+
+func TestValidateAnvilAccounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		accounts int
+		balance  uint64
+		wantErr  bool
+	}{
+		{"defaults", 0, 0, false},
+		{"custom", 100, 50, false},
+		{"minimum accounts", MinAnvilAccounts, 1, false},
+		{"maximum", MaxAnvilAccounts, MaxAnvilBalance, false},
+		{"too few accounts", 2, 0, true},
+		{"negative accounts", -1, 0, true},
+		{"too many accounts", MaxAnvilAccounts + 1, 0, true},
+		{"balance too high", 0, MaxAnvilBalance + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeploymentConfig{AnvilAccounts: tt.accounts, AnvilBalance: tt.balance}.ValidateAnvilAccounts()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateChainParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		blockTime uint64
+		gasLimit  uint64
+		wantErr   string
+	}{
+		{"defaults", 0, 0, ""},
+		{"minimum", MinBlockTime, MinGasLimit, ""},
+		{"maximum", MaxBlockTime, MaxGasLimit, ""},
+		{"block time too long", MaxBlockTime + 1, 0, "block_time"},
+		{"gas limit too low", 0, MinGasLimit - 1, "gas_limit"},
+		{"gas limit too high", 0, MaxGasLimit + 1, "gas_limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeploymentConfig{BlockTime: tt.blockTime, GasLimit: tt.gasLimit}.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChainParams_FlowToConfigs(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{})
+
+	defaults := o.populateDefaults(DeploymentConfig{ChainID: 42069})
+	assert.Equal(t, uint64(DefaultBlockTime), defaults.BlockTime)
+	assert.Equal(t, uint64(DefaultGasLimit), defaults.GasLimit)
+
+	cfg := o.populateDefaults(DeploymentConfig{ChainID: 42069, BlockTime: 12, GasLimit: 60_000_000})
+	require.NoError(t, ValidateConfig(cfg))
+
+	args := strings.Join(anvilArgs(&cfg, "/tmp/anvil.ipc", "/tmp/state.json"), " ")
+	assert.Contains(t, args, "--gas-limit 60000000")
+	assert.Contains(t, args, "--block-time 12")
+
+	// OP Stack
+	opCfg := opstackDeploymentConfig(&cfg)
+	assert.Equal(t, uint64(12), opCfg.BlockTime)
+	assert.Equal(t, uint64(60_000_000), opCfg.GasLimit)
+	env, err := newConfigWriter(slog.Default(), nil, &cfg).generateEnvExample()
+	require.NoError(t, err)
+	assert.Contains(t, string(env), "BLOCK_TIME=12\nGAS_LIMIT=60000000\n")
+
+	// Nitro
+	nitroEnv := newNitroConfigWriter(slog.Default(), &nitroDeployResult{contracts: &nitro.RollupContracts{}}, &cfg).generateEnv()
+	assert.Contains(t, nitroEnv, "BLOCK_TIME=12\nGAS_LIMIT=60000000\n")
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := NewOrchestrator(nil, OrchestratorConfig{}).populateDefaults(DeploymentConfig{ChainID: 42069, ChainName: "test"})
+	require.NoError(t, ValidateConfig(valid))
+
+	tests := []struct {
+		name    string
+		modify  func(*DeploymentConfig)
+		wantErr string
+	}{
+		{"missing chain id", func(c *DeploymentConfig) { c.ChainID = 0 }, "chain_id: is required"},
+		{"missing L1 chain id", func(c *DeploymentConfig) { c.L1ChainID = 0 }, "l1_chain_id: is required"},
+		{"chain id is the L1's", func(c *DeploymentConfig) { c.ChainID = 31337 }, "chain_id: 31337 is also the L1 chain ID"},
+		{"unknown stack", func(c *DeploymentConfig) { c.BundleStack = "orbit" }, "bundle_stack"},
+		{"invalid batcher", func(c *DeploymentConfig) { c.BatcherAddress = "0x1234" }, "batcher_address: invalid address"},
+		{"invalid user setting", func(c *DeploymentConfig) { c.AnvilAccounts = 1 }, "anvil_accounts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := ValidateConfig(cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// configRepo serves one deployment with the given config and records stage
+// updates.
+type configRepo struct {
+	statusRepo
+	config json.RawMessage
+}
+
+func (r *configRepo) GetDeployment(ctx context.Context, id uuid.UUID) (*repository.Deployment, error) {
+	return &repository.Deployment{ID: id, Status: repository.StatusPending, Config: r.config}, nil
+}
+
+func TestDeploy_InvalidConfigFailsBeforeAnvil(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{"invalid address", `{"chain_id": 42069, "bundle_stack": "nitro", "validators": ["0xnot-an-address"]}`, "validators: invalid address"},
+		{"duplicate chain id", `{"chain_id": 31337}`, "chain_id: 31337 is also the L1 chain ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &configRepo{statusRepo: statusRepo{stages: map[uuid.UUID]string{}}, config: json.RawMessage(tt.config)}
+			workDir := filepath.Join(t.TempDir(), "work")
+			o := NewOrchestrator(repo, OrchestratorConfig{WorkDir: workDir})
+
+			err := o.Deploy(context.Background(), uuid.New(), nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			// Nothing was started: no stage was reached and no work dir created
+			assert.Empty(t, repo.stages)
+			assert.NoDirExists(t, workDir)
+		})
+	}
+}
+
+func TestNitroRollupConfig_DefaultRoles(t *testing.T) {
+	cfg := DeploymentConfig{BundleStack: "nitro"}
+	cfg = (&Orchestrator{}).populateDefaults(cfg)
+
+	rollupCfg := nitroRollupConfig(&cfg, common.HexToAddress(anvilDeployerAddress), common.Address{})
+
+	assert.Equal(t, []common.Address{common.HexToAddress(anvilBatcherAddress)}, rollupCfg.BatchPosters)
+	assert.Equal(t, []common.Address{common.HexToAddress(anvilProposerAddress)}, rollupCfg.Validators)
+}
+
+func TestNitroRollupConfig_MultipleValidators(t *testing.T) {
+	second := "0x90F79bf6EB2c4f870365E785982E1f101E93b906" // anvil-3
+	cfg := DeploymentConfig{
+		BundleStack:  "nitro",
+		BatchPosters: []string{anvilBatcherAddress, second},
+		Validators:   []string{anvilProposerAddress, second},
+	}
+	require.NoError(t, cfg.Validate())
+	cfg = (&Orchestrator{}).populateDefaults(cfg)
+
+	rollupCfg := nitroRollupConfig(&cfg, common.HexToAddress(anvilDeployerAddress), common.Address{})
+
+	assert.Equal(t, []common.Address{
+		common.HexToAddress(anvilBatcherAddress),
+		common.HexToAddress(second),
+	}, rollupCfg.BatchPosters)
+	assert.Equal(t, []common.Address{
+		common.HexToAddress(anvilProposerAddress),
+		common.HexToAddress(second),
+	}, rollupCfg.Validators)
+}
+
+func TestValidateNitroRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DeploymentConfig
+		wantErr string
+	}{
+		{"unset", DeploymentConfig{BundleStack: "nitro"}, ""},
+		{"valid", DeploymentConfig{BundleStack: "nitro", Validators: []string{anvilProposerAddress, anvilBatcherAddress}}, ""},
+		{"empty list", DeploymentConfig{BundleStack: "nitro", BatchPosters: []string{}}, "must not be empty"},
+		{"invalid address", DeploymentConfig{BundleStack: "nitro", Validators: []string{"0x1234"}}, "invalid address"},
+		{"zero address", DeploymentConfig{BundleStack: "nitro", BatchPosters: []string{"0x0000000000000000000000000000000000000000"}}, "zero address"},
+		{"deployer", DeploymentConfig{BundleStack: "nitro", Validators: []string{anvilDeployerAddress}}, "is the deployer"},
+		{"duplicate", DeploymentConfig{BundleStack: "nitro", BatchPosters: []string{anvilBatcherAddress, "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"}}, "duplicate address"},
+		{"opstack", DeploymentConfig{Validators: []string{anvilProposerAddress}}, "only supported for nitro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateNitroRoles()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveStakeToken_Configured(t *testing.T) {
+	token := "0x7b79995e5f793A07Bc00c21412e50Ecae098E7f9"
+	cfg := DeploymentConfig{BundleStack: "nitro", StakeTokenAddress: token, BaseStake: big.NewInt(1e18)}
+	require.NoError(t, cfg.Validate())
+	cfg = (&Orchestrator{}).populateDefaults(cfg)
+
+	repo := &statusRepo{stages: map[uuid.UUID]string{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{})
+	id := uuid.New()
+
+	// No signer or L1 is needed when WETH isn't deployed
+	got, err := o.resolveStakeToken(context.Background(), &DeploymentContext{DeploymentID: id, Config: &cfg}, &StageWriter{repo: repo, deploymentID: id}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(token), got)
+	assert.NotContains(t, repo.stages, id)
+
+	rollupCfg := nitroRollupConfig(&cfg, common.HexToAddress(anvilDeployerAddress), got)
+	assert.Equal(t, common.HexToAddress(token), rollupCfg.StakeToken)
+	assert.Equal(t, big.NewInt(1e18), rollupCfg.BaseStake)
+}
+
+// TestResolveStakeToken_DeploysWETH deploys WETH to a local Anvil. It needs
+// anvil on PATH.
+func TestResolveStakeToken_DeploysWETH(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Anvil WETH test in short mode")
+	}
+	if _, err := exec.LookPath("anvil"); err != nil {
+		t.Skip("anvil not installed")
+	}
+
+	cfg := (&Orchestrator{}).populateDefaults(DeploymentConfig{BundleStack: "nitro"})
+	cfg.L1RPC = startTestAnvil(t)
+
+	repo := &statusRepo{stages: map[uuid.UUID]string{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{})
+	id := uuid.New()
+	dc := &DeploymentContext{DeploymentID: id, Config: &cfg}
+
+	signer, err := o.createNitroLocalSigner(dc)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	token, err := o.resolveStakeToken(ctx, dc, &StageWriter{repo: repo, deploymentID: id}, signer)
+	require.NoError(t, err)
+	assert.NotEqual(t, common.Address{}, token)
+	assert.Equal(t, StageDeployingWETH.String(), repo.stages[id])
+
+	client, err := ethclient.Dial(cfg.L1RPC)
+	require.NoError(t, err)
+	defer client.Close()
+	code, err := client.CodeAt(ctx, token, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+
+	rollupCfg := nitroRollupConfig(&cfg, signer.Address(), token)
+	assert.Equal(t, token, rollupCfg.StakeToken)
+	assert.Equal(t, big.NewInt(DefaultNitroBaseStake), rollupCfg.BaseStake)
+}
+
+// metadataCaller answers WETH metadata calls with fixed values.
+type metadataCaller struct {
+	name, symbol string
+	decimals     uint8
+}
+
+func (c metadataCaller) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(wethMetadataABI))
+	if err != nil {
+		return nil, err
+	}
+	method, err := parsed.MethodById(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "name":
+		return method.Outputs.Pack(c.name)
+	case "symbol":
+		return method.Outputs.Pack(c.symbol)
+	default:
+		return method.Outputs.Pack(c.decimals)
+	}
+}
+
+func TestCheckWETH(t *testing.T) {
+	addr := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+
+	assert.NoError(t, checkWETH(context.Background(), metadataCaller{"Wrapped Ether", "WETH", 18}, addr))
+
+	err := checkWETH(context.Background(), metadataCaller{"Wrapped Ether", "WETH", 6}, addr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "decimals() returned 6, want 18")
+
+	err = checkWETH(context.Background(), metadataCaller{"Token", "TKN", 18}, addr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name()")
+}
+
+// TestCheckWETH_Anvil deploys WETH9 to a local Anvil and checks it passes the
+// sanity check, while an account without code fails it. It needs anvil on
+// PATH.
+func TestCheckWETH_Anvil(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping Anvil WETH test in short mode")
+	}
+	if _, err := exec.LookPath("anvil"); err != nil {
+		t.Skip("anvil not installed")
+	}
+
+	cfg := (&Orchestrator{}).populateDefaults(DeploymentConfig{BundleStack: "nitro"})
+	cfg.L1RPC = startTestAnvil(t)
+
+	repo := &statusRepo{stages: map[uuid.UUID]string{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{})
+	id := uuid.New()
+	dc := &DeploymentContext{DeploymentID: id, Config: &cfg}
+
+	signer, err := o.createNitroLocalSigner(dc)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	token, err := o.deployWETH(ctx, dc, &StageWriter{repo: repo, deploymentID: id}, signer)
+	require.NoError(t, err)
+
+	client, err := ethclient.Dial(cfg.L1RPC)
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, checkWETH(ctx, client, token))
+	assert.Error(t, checkWETH(ctx, client, signer.Address()))
+}
+
+func TestValidateNitroStaking(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     DeploymentConfig
+		wantErr string
+	}{
+		{"unset", DeploymentConfig{BundleStack: "nitro"}, ""},
+		{"valid", DeploymentConfig{BundleStack: "nitro", BaseStake: big.NewInt(1), StakeTokenAddress: "0x7b79995e5f793A07Bc00c21412e50Ecae098E7f9"}, ""},
+		{"negative stake", DeploymentConfig{BundleStack: "nitro", BaseStake: big.NewInt(-1)}, "non-negative"},
+		{"invalid token", DeploymentConfig{BundleStack: "nitro", StakeTokenAddress: "weth"}, "invalid address"},
+		{"zero token", DeploymentConfig{BundleStack: "nitro", StakeTokenAddress: "0x0000000000000000000000000000000000000000"}, "zero address"},
+		{"opstack", DeploymentConfig{BaseStake: big.NewInt(1)}, "only supported for nitro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateNitroStaking()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNitroArtifacts(t *testing.T) {
+	checksum := "sha256:10f1c0eade0e1d9c51ddc9df04d96bca108f6794dc132139c3a1ae1024608b9c"
+	tests := []struct {
+		name    string
+		cfg     DeploymentConfig
+		wantErr string
+	}{
+		{"unset", DeploymentConfig{BundleStack: "nitro"}, ""},
+		{"registered version", DeploymentConfig{BundleStack: "nitro", NitroArtifactVersion: nitro.ArtifactVersion}, ""},
+		{"pinned checksum", DeploymentConfig{BundleStack: "nitro", NitroArtifactVersion: "v3.3.0", NitroArtifactSHA256: checksum}, ""},
+		{"unregistered version", DeploymentConfig{BundleStack: "nitro", NitroArtifactVersion: "v3.3.0"}, "no registered checksum"},
+		{"invalid version", DeploymentConfig{BundleStack: "nitro", NitroArtifactVersion: "../v3"}, "invalid version"},
+		{"invalid checksum", DeploymentConfig{BundleStack: "nitro", NitroArtifactSHA256: "sha256:abc"}, "nitro_artifact_sha256"},
+		{"opstack", DeploymentConfig{NitroArtifactVersion: nitro.ArtifactVersion}, "only supported for nitro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateNitroArtifacts()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNitroArtifactPin(t *testing.T) {
+	version, checksum := DeploymentConfig{}.NitroArtifactPin()
+	assert.Equal(t, nitro.ArtifactVersion, version)
+	assert.Empty(t, checksum)
+
+	version, checksum = DeploymentConfig{NitroArtifactVersion: "v3.3.0", NitroArtifactSHA256: "abc"}.NitroArtifactPin()
+	assert.Equal(t, "v3.3.0", version)
+	assert.Equal(t, "abc", checksum)
+}
+
+func TestNewOrchestrator_DefaultTimeouts(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{})
+	assert.Equal(t, DefaultDeploymentTimeout, o.config.DeploymentTimeout)
+	assert.Equal(t, DefaultAnvilShutdownTimeout, o.config.AnvilShutdownTimeout)
+
+	o = NewOrchestrator(nil, OrchestratorConfig{DeploymentTimeout: time.Minute, AnvilShutdownTimeout: time.Second})
+	assert.Equal(t, time.Minute, o.config.DeploymentTimeout)
+	assert.Equal(t, time.Second, o.config.AnvilShutdownTimeout)
+}
+
+func TestWithDeploymentTimeout(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{DeploymentTimeout: 20 * time.Millisecond})
+
+	t.Run("completes in time", func(t *testing.T) {
+		err := o.withDeploymentTimeout(context.Background(), func(context.Context) error { return nil })
+		assert.NoError(t, err)
+	})
+
+	t.Run("step honors context", func(t *testing.T) {
+		err := o.withDeploymentTimeout(context.Background(), func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		require.ErrorIs(t, err, ErrDeploymentTimeout)
+		assert.Contains(t, err.Error(), "20ms")
+	})
+
+	t.Run("step ignores context", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		start := time.Now()
+		err := o.withDeploymentTimeout(context.Background(), func(context.Context) error {
+			<-block
+			return nil
+		})
+		require.ErrorIs(t, err, ErrDeploymentTimeout)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("caller cancels", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := NewOrchestrator(nil, OrchestratorConfig{}).withDeploymentTimeout(ctx, func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, ErrDeploymentTimeout)
+	})
+}
@@ -0,0 +1,121 @@
+package popdeployer
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// stageWeight is a stage's share of its pipeline's total duration.
+type stageWeight struct {
+	stage  Stage
+	weight float64
+}
+
+// opStackStageWeights lists the OP Stack pipeline stages in order, weighted
+// by their typical duration. op-deployer dominates.
+var opStackStageWeights = []stageWeight{
+	{StageStartingAnvil, 5},
+	{StageDeployingContracts, 75},
+	{StageCapturingState, 10},
+	{StageGeneratingConfigs, 10},
+}
+
+// nitroStageWeights lists the Nitro pipeline stages in order, weighted by
+// their typical duration.
+var nitroStageWeights = []stageWeight{
+	{StageStartingAnvil, 5},
+	{StageDownloadingArtifacts, 10},
+	{StageDeployingInfrastructure, 40},
+	{StageDeployingWETH, 5},
+	{StageCreatingRollup, 25},
+	{StageCapturingState, 10},
+	{StageGeneratingConfigs, 5},
+}
+
+// Running progress is capped below 1.0 so only completion reports 1.0, and
+// no ETA is given until minETAProgress, when estimates become meaningful.
+const (
+	maxRunningProgress = 0.99
+	minETAProgress     = 0.05
+)
+
+// progressTracker turns per-stage progress into overall progress using a
+// stage-weight table, and appends an ETA to each message.
+type progressTracker struct {
+	onProgress ProgressCallback
+	offsets    map[Stage]float64 // overall progress at the start of each stage
+	weights    map[Stage]float64 // normalized weight of each stage
+	start      time.Time
+	now        func() time.Time
+
+	mu   sync.Mutex
+	last float64
+}
+
+// newProgressTracker creates a tracker for the pipeline described by
+// weights, reporting to onProgress, which may be nil.
+func newProgressTracker(weights []stageWeight, onProgress ProgressCallback) *progressTracker {
+	var total float64
+	for _, w := range weights {
+		total += w.weight
+	}
+
+	t := &progressTracker{
+		onProgress: onProgress,
+		offsets:    make(map[Stage]float64, len(weights)),
+		weights:    make(map[Stage]float64, len(weights)),
+		now:        time.Now,
+	}
+	var offset float64
+	for _, w := range weights {
+		t.offsets[w.stage] = offset
+		t.weights[w.stage] = w.weight / total
+		offset += w.weight / total
+	}
+	t.start = t.now()
+	return t
+}
+
+// report reports progress within stage, from 0.0 at its start to 1.0 at its
+// end. Overall progress never decreases; stages outside the pipeline keep
+// the current progress.
+func (t *progressTracker) report(stage Stage, stageProgress float64, message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if weight, ok := t.weights[stage]; ok {
+		stageProgress = min(max(stageProgress, 0), 1)
+		progress := min(t.offsets[stage]+weight*stageProgress, maxRunningProgress)
+		t.last = max(t.last, progress)
+	}
+
+	if eta, ok := t.eta(); ok {
+		message = fmt.Sprintf("%s (about %s remaining)", message, eta)
+	}
+	if t.onProgress != nil {
+		t.onProgress(stage, t.last, message)
+	}
+}
+
+// complete reports the end of the deployment with progress of exactly 1.0.
+func (t *progressTracker) complete(message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.last = 1.0
+	if t.onProgress != nil {
+		t.onProgress(StageComplete, 1.0, message)
+	}
+}
+
+// eta estimates the remaining time from the elapsed time and the remaining
+// weight, assuming the rest runs at the average pace so far.
+func (t *progressTracker) eta() (time.Duration, bool) {
+	if t.last < minETAProgress {
+		return 0, false
+	}
+	elapsed := t.now().Sub(t.start)
+	remaining := time.Duration(float64(elapsed) * (1 - t.last) / t.last)
+	return remaining.Round(time.Second), true
+}
@@ -0,0 +1,96 @@
+package popdeployer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// progressRecorder collects the reports of a progressTracker.
+type progressRecorder struct {
+	stages   []Stage
+	progress []float64
+	messages []string
+}
+
+func (r *progressRecorder) record(stage Stage, progress float64, message string) {
+	r.stages = append(r.stages, stage)
+	r.progress = append(r.progress, progress)
+	r.messages = append(r.messages, message)
+}
+
+func TestProgressTracker_MonotonicAndCompletes(t *testing.T) {
+	for name, weights := range map[string][]stageWeight{
+		"opstack": opStackStageWeights,
+		"nitro":   nitroStageWeights,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &progressRecorder{}
+			tracker := newProgressTracker(weights, r.record)
+
+			for _, w := range weights {
+				tracker.report(w.stage, 0, "start")
+				tracker.report(w.stage, 0.5, "halfway")
+				// Sub-stage progress that goes backwards is ignored
+				tracker.report(w.stage, 0.25, "behind")
+				tracker.report(w.stage, 1, "done")
+			}
+			tracker.complete("complete")
+
+			require.NotEmpty(t, r.progress)
+			for i := 1; i < len(r.progress); i++ {
+				assert.GreaterOrEqual(t, r.progress[i], r.progress[i-1], "progress decreased at report %d", i)
+			}
+			for _, p := range r.progress[:len(r.progress)-1] {
+				assert.Less(t, p, 1.0, "progress reached 1.0 before completion")
+			}
+			assert.Equal(t, 1.0, r.progress[len(r.progress)-1])
+			assert.Equal(t, StageComplete, r.stages[len(r.stages)-1])
+		})
+	}
+}
+
+func TestProgressTracker_WeightedStages(t *testing.T) {
+	r := &progressRecorder{}
+	tracker := newProgressTracker([]stageWeight{
+		{StageStartingAnvil, 1},
+		{StageDeployingContracts, 3},
+	}, r.record)
+
+	tracker.report(StageStartingAnvil, 1, "anvil started")
+	tracker.report(StageDeployingContracts, 0.5, "deploying")
+	// Stages outside the pipeline keep the current progress
+	tracker.report(StageQueued, 0.9, "queued")
+
+	assert.InDelta(t, 0.25, r.progress[0], 1e-9)
+	assert.InDelta(t, 0.625, r.progress[1], 1e-9)
+	assert.InDelta(t, 0.625, r.progress[2], 1e-9)
+}
+
+func TestProgressTracker_ETA(t *testing.T) {
+	r := &progressRecorder{}
+	tracker := newProgressTracker([]stageWeight{
+		{StageStartingAnvil, 1},
+		{StageDeployingContracts, 3},
+	}, r.record)
+
+	// No ETA before any meaningful progress
+	tracker.report(StageStartingAnvil, 0, "starting")
+	assert.Equal(t, "starting", r.messages[0])
+
+	// A quarter done after two minutes: six minutes to go
+	tracker.now = func() time.Time { return tracker.start.Add(2 * time.Minute) }
+	tracker.report(StageStartingAnvil, 1, "anvil started")
+	assert.Equal(t, "anvil started (about 6m0s remaining)", r.messages[1])
+}
+
+func TestProgressTracker_NilCallback(t *testing.T) {
+	tracker := newProgressTracker(opStackStageWeights, nil)
+
+	assert.NotPanics(t, func() {
+		tracker.report(StageStartingAnvil, 0.5, "starting")
+		tracker.complete("complete")
+	})
+}
@@ -0,0 +1,101 @@
+package popdeployer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// deploymentQueue limits how many deployments run at once. Deployments that
+// exceed the limit wait in FIFO order for a running one to finish.
+type deploymentQueue struct {
+	mu      sync.Mutex
+	max     int
+	running int
+	waiting []*queuedDeployment
+}
+
+// queuedDeployment is a deployment waiting for a slot. ready is closed when
+// the slot is handed over.
+type queuedDeployment struct {
+	id    uuid.UUID
+	ready chan struct{}
+}
+
+// newDeploymentQueue creates a queue allowing max concurrent deployments.
+// A max of zero or less means no limit.
+func newDeploymentQueue(max int) *deploymentQueue {
+	return &deploymentQueue{max: max}
+}
+
+// tryAcquire takes a slot if one is free and nobody is waiting. Otherwise it
+// enqueues the deployment and returns the entry to wait on.
+func (q *deploymentQueue) tryAcquire(id uuid.UUID) *queuedDeployment {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.max <= 0 || (q.running < q.max && len(q.waiting) == 0) {
+		q.running++
+		return nil
+	}
+
+	entry := &queuedDeployment{id: id, ready: make(chan struct{})}
+	q.waiting = append(q.waiting, entry)
+	return entry
+}
+
+// wait blocks until entry is handed a slot or ctx is done. A cancelled
+// deployment leaves the queue without taking a slot.
+func (q *deploymentQueue) wait(ctx context.Context, entry *queuedDeployment) error {
+	select {
+	case <-entry.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, e := range q.waiting {
+		if e == entry {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			return ctx.Err()
+		}
+	}
+
+	// The slot was handed over as ctx was cancelled; pass it on
+	q.releaseLocked()
+	return ctx.Err()
+}
+
+// release frees a slot, handing it to the next waiting deployment if any.
+func (q *deploymentQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.releaseLocked()
+}
+
+func (q *deploymentQueue) releaseLocked() {
+	if len(q.waiting) > 0 {
+		next := q.waiting[0]
+		q.waiting = q.waiting[1:]
+		close(next.ready)
+		return
+	}
+	q.running--
+}
+
+// position returns a deployment's 1-based position in the queue, or 0 if it
+// is not waiting.
+func (q *deploymentQueue) position(id uuid.UUID) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i, e := range q.waiting {
+		if e.id == id {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,108 @@
+package popdeployer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+)
+
+// statusRepo is an in-memory deployment status store.
+type statusRepo struct {
+	repository.Repository
+	mu     sync.Mutex
+	stages map[uuid.UUID]string
+}
+
+func (r *statusRepo) UpdateDeploymentStatus(ctx context.Context, id uuid.UUID, status repository.Status, stage *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stage != nil {
+		r.stages[id] = *stage
+	}
+	return nil
+}
+
+func (r *statusRepo) GetDeployment(ctx context.Context, id uuid.UUID) (*repository.Deployment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := &repository.Deployment{ID: id, Status: repository.StatusRunning}
+	if stage, ok := r.stages[id]; ok {
+		d.CurrentStage = &stage
+	}
+	return d, nil
+}
+
+func TestAcquireSlot_WaitsForRunningDeployment(t *testing.T) {
+	repo := &statusRepo{stages: map[uuid.UUID]string{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{MaxConcurrentDeployments: 1})
+	ctx := context.Background()
+
+	first, second := uuid.New(), uuid.New()
+	require.NoError(t, o.acquireSlot(ctx, first, &StageWriter{repo: repo, deploymentID: first}, nil))
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- o.acquireSlot(ctx, second, &StageWriter{repo: repo, deploymentID: second}, nil)
+	}()
+
+	require.Eventually(t, func() bool {
+		return o.queue.position(second) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	status, err := o.GetDeploymentStatus(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, StageQueued, status.CurrentStage)
+	assert.Equal(t, 1, status.QueuePosition)
+
+	select {
+	case <-acquired:
+		t.Fatal("second deployment started while the first was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The first deployment finishing hands its slot to the second
+	o.queue.release()
+	select {
+	case err := <-acquired:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("second deployment did not start after the first finished")
+	}
+
+	status, err = o.GetDeploymentStatus(ctx, second)
+	require.NoError(t, err)
+	assert.Zero(t, status.QueuePosition)
+}
+
+func TestAcquireSlot_CancelledWhileQueued(t *testing.T) {
+	repo := &statusRepo{stages: map[uuid.UUID]string{}}
+	o := NewOrchestrator(repo, OrchestratorConfig{MaxConcurrentDeployments: 1})
+
+	first, second := uuid.New(), uuid.New()
+	require.NoError(t, o.acquireSlot(context.Background(), first, &StageWriter{repo: repo, deploymentID: first}, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := o.acquireSlot(ctx, second, &StageWriter{repo: repo, deploymentID: second}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, o.queue.position(second))
+
+	// The slot is still held by the first deployment only
+	o.queue.release()
+	third := uuid.New()
+	require.NoError(t, o.acquireSlot(context.Background(), third, &StageWriter{repo: repo, deploymentID: third}, nil))
+}
+
+func TestDeploymentQueue_Unlimited(t *testing.T) {
+	q := newDeploymentQueue(0)
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, q.tryAcquire(uuid.New()))
+	}
+}
@@ -0,0 +1,100 @@
+package popdeployer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// DefaultStageAttempts is the default number of attempts for a
+	// network-bound deployment stage.
+	DefaultStageAttempts = 3
+
+	// DefaultStageRetryBackoff is the default wait before the first retry
+	// of a failed stage. It doubles on each retry, up to maxStageRetryBackoff.
+	DefaultStageRetryBackoff = 2 * time.Second
+
+	maxStageRetryBackoff = 30 * time.Second
+)
+
+// permanentError marks a stage failure that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// retryStage runs attempt until it succeeds, fails permanently, or the
+// configured number of attempts is used up. Waits between attempts end early
+// when ctx is done, so retries never outlast the deployment timeout.
+func (o *Orchestrator) retryStage(ctx context.Context, stage Stage, attempt func(ctx context.Context) error) error {
+	backoff := o.config.StageRetryBackoff
+	for i := 1; ; i++ {
+		err := attempt(ctx)
+		if err == nil {
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
+		if i >= o.config.StageAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		o.logger.Warn("deployment stage failed, retrying",
+			slog.String("stage", stage.String()),
+			slog.Int("attempt", i),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()),
+		)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxStageRetryBackoff)
+	}
+}
+
+// broadcastGuard makes a stage that sends transactions safe to retry: a
+// failed attempt is only retried if the sender's pending nonce hasn't moved.
+// Once anything was broadcast, retrying could deploy contracts twice, so the
+// failure becomes permanent.
+func broadcastGuard(nonce func(ctx context.Context) (uint64, error), attempt func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		before, err := nonce(ctx)
+		if err != nil {
+			return fmt.Errorf("get nonce: %w", err)
+		}
+		err = attempt(ctx)
+		if err == nil {
+			return nil
+		}
+		after, nonceErr := nonce(ctx)
+		if nonceErr != nil || after != before {
+			return &permanentError{err: err}
+		}
+		return err
+	}
+}
+
+// l1PendingNonce returns a nonce function for addr on the deployment's L1.
+// The RPC endpoint is read on each call, as it is only known once Anvil runs.
+func l1PendingNonce(dc *DeploymentContext, addr common.Address) func(ctx context.Context) (uint64, error) {
+	return func(ctx context.Context) (uint64, error) {
+		client, err := ethclient.DialContext(ctx, dc.Config.L1RPC)
+		if err != nil {
+			return 0, fmt.Errorf("connect to L1: %w", err)
+		}
+		defer client.Close()
+		return client.PendingNonceAt(ctx, addr)
+	}
+}
@@ -0,0 +1,126 @@
+package popdeployer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryOrchestrator(attempts int) *Orchestrator {
+	return NewOrchestrator(nil, OrchestratorConfig{StageAttempts: attempts, StageRetryBackoff: time.Millisecond})
+}
+
+func TestRetryStage_SucceedsAfterTransientFailures(t *testing.T) {
+	o := newRetryOrchestrator(3)
+
+	calls := 0
+	err := o.retryStage(context.Background(), StageDownloadingArtifacts, func(context.Context) error {
+		calls++
+		if calls <= 2 {
+			return errors.New("connection reset by peer")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryStage_GivesUp(t *testing.T) {
+	o := newRetryOrchestrator(3)
+
+	calls := 0
+	err := o.retryStage(context.Background(), StageDownloadingArtifacts, func(context.Context) error {
+		calls++
+		return errors.New("connection reset by peer")
+	})
+	require.EqualError(t, err, "connection reset by peer")
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryStage_Permanent(t *testing.T) {
+	o := newRetryOrchestrator(3)
+	cause := errors.New("rollup creation reverted")
+
+	calls := 0
+	err := o.retryStage(context.Background(), StageCreatingRollup, func(context.Context) error {
+		calls++
+		return &permanentError{err: cause}
+	})
+	assert.Same(t, cause, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryStage_RespectsDeadline(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{StageAttempts: 5, StageRetryBackoff: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	calls := 0
+	err := o.retryStage(ctx, StageDownloadingArtifacts, func(context.Context) error {
+		calls++
+		return errors.New("connection reset by peer")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestBroadcastGuard(t *testing.T) {
+	o := newRetryOrchestrator(3)
+
+	t.Run("retries when nothing was broadcast", func(t *testing.T) {
+		nonce := func(context.Context) (uint64, error) { return 7, nil }
+		calls := 0
+		err := o.retryStage(context.Background(), StageDeployingWETH, broadcastGuard(nonce, func(context.Context) error {
+			calls++
+			if calls <= 2 {
+				return errors.New("get gas price: connection refused")
+			}
+			return nil
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry after a broadcast", func(t *testing.T) {
+		var sent uint64
+		nonce := func(context.Context) (uint64, error) { return sent, nil }
+		calls := 0
+		err := o.retryStage(context.Background(), StageDeployingWETH, broadcastGuard(nonce, func(context.Context) error {
+			calls++
+			sent++
+			return errors.New("wait for receipt: connection refused")
+		}))
+		require.EqualError(t, err, "wait for receipt: connection refused")
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("does not retry when the nonce is unknown", func(t *testing.T) {
+		checks := 0
+		nonce := func(context.Context) (uint64, error) {
+			checks++
+			if checks > 1 {
+				return 0, errors.New("connection refused")
+			}
+			return 0, nil
+		}
+		calls := 0
+		err := o.retryStage(context.Background(), StageCreatingRollup, broadcastGuard(nonce, func(context.Context) error {
+			calls++
+			return errors.New("deploy rollup: connection refused")
+		}))
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestNewOrchestrator_DefaultStageRetries(t *testing.T) {
+	o := NewOrchestrator(nil, OrchestratorConfig{})
+	assert.Equal(t, DefaultStageAttempts, o.config.StageAttempts)
+	assert.Equal(t, DefaultStageRetryBackoff, o.config.StageRetryBackoff)
+}
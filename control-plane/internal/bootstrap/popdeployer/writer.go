@@ -6,10 +6,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math/big"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/opstack"
 	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/inspect"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // ConfigWriter generates POPKins bundle configuration files.
@@ -24,6 +28,17 @@ type ConfigWriter struct {
 	celestiaKeyID string
 }
 
+// newConfigWriter creates a ConfigWriter for cfg, signing Celestia blobs with
+// the configured key.
+func newConfigWriter(logger *slog.Logger, result *opstack.DeployResult, cfg *DeploymentConfig) *ConfigWriter {
+	return &ConfigWriter{
+		logger:        logger,
+		result:        result,
+		config:        cfg,
+		celestiaKeyID: cfg.CelestiaKey(),
+	}
+}
+
 // GenerateAll generates all configuration files and returns them as a map.
 // Keys are artifact types (filenames), values are the file contents as bytes.
 //
@@ -67,8 +82,7 @@ func (w *ConfigWriter) generateGenesis() ([]byte, error) {
 
 	chainState := w.result.ChainStates[0]
 
-	// Generate genesis using op-deployer's inspect package
-	l2Genesis, _, err := inspect.GenesisAndRollup(w.result.State, chainState.ID)
+	l2Genesis, _, err := w.genesisAndRollup(chainState.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate genesis: %w", err)
 	}
@@ -85,6 +99,41 @@ func (w *ConfigWriter) generateGenesis() ([]byte, error) {
 	return data, nil
 }
 
+// genesisAndRollup generates the L2 genesis and rollup config using
+// op-deployer's inspect package, then merges the custom genesis allocations.
+// The rollup config's L2 genesis hash is updated to match.
+func (w *ConfigWriter) genesisAndRollup(chainID common.Hash) (*core.Genesis, *rollup.Config, error) {
+	l2Genesis, rollupCfg, err := inspect.GenesisAndRollup(w.result.State, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l2Genesis == nil || rollupCfg == nil || len(w.config.GenesisAlloc) == 0 {
+		return l2Genesis, rollupCfg, nil
+	}
+
+	if err := applyGenesisAlloc(l2Genesis, w.config.GenesisAlloc); err != nil {
+		return nil, nil, err
+	}
+	rollupCfg.Genesis.L2.Hash = l2Genesis.ToBlock().Hash()
+
+	w.logger.Info("custom genesis allocations applied", slog.Int("count", len(w.config.GenesisAlloc)))
+	return l2Genesis, rollupCfg, nil
+}
+
+// applyGenesisAlloc adds the custom allocations to the genesis. Addresses
+// that are already allocated (predeploys, dev accounts) are rejected rather
+// than overwritten.
+func applyGenesisAlloc(gen *core.Genesis, alloc map[string]*big.Int) error {
+	for addr, balance := range alloc {
+		a := common.HexToAddress(addr)
+		if _, ok := gen.Alloc[a]; ok {
+			return fmt.Errorf("genesis_alloc: %s is already allocated in the L2 genesis", addr)
+		}
+		gen.Alloc[a] = types.Account{Balance: new(big.Int).Set(balance)}
+	}
+	return nil
+}
+
 // generateRollupConfig generates the rollup.json configuration file.
 func (w *ConfigWriter) generateRollupConfig() ([]byte, error) {
 	if len(w.result.ChainStates) == 0 {
@@ -93,8 +142,7 @@ func (w *ConfigWriter) generateRollupConfig() ([]byte, error) {
 
 	chainState := w.result.ChainStates[0]
 
-	// Generate rollup config using op-deployer's inspect package
-	_, rollupCfg, err := inspect.GenesisAndRollup(w.result.State, chainState.ID)
+	_, rollupCfg, err := w.genesisAndRollup(chainState.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate rollup config: %w", err)
 	}
@@ -0,0 +1,130 @@
+package popdeployer
+
+import (
+	"log/slog"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyGenesisAlloc(t *testing.T) {
+	gen := &core.Genesis{Alloc: types.GenesisAlloc{}}
+	genesis.FundDevAccounts(gen)
+	devBalances := make(map[common.Address]*big.Int, len(gen.Alloc))
+	for addr, acc := range gen.Alloc {
+		devBalances[addr] = acc.Balance
+	}
+
+	faucet := "0x000000000000000000000000000000000000fa0c"
+	treasury := "0x00000000000000000000000000000000000007ea"
+	cfg := DeploymentConfig{GenesisAlloc: map[string]*big.Int{
+		faucet:   big.NewInt(1_000_000_000_000_000_000),
+		treasury: big.NewInt(0),
+	}}
+	require.NoError(t, cfg.ValidateGenesisAlloc())
+	require.NoError(t, applyGenesisAlloc(gen, cfg.GenesisAlloc))
+
+	assert.Equal(t, big.NewInt(1_000_000_000_000_000_000), gen.Alloc[common.HexToAddress(faucet)].Balance)
+	assert.Equal(t, big.NewInt(0), gen.Alloc[common.HexToAddress(treasury)].Balance)
+
+	// Dev account funding is untouched
+	assert.Len(t, gen.Alloc, len(devBalances)+2)
+	for addr, balance := range devBalances {
+		assert.Equal(t, balance, gen.Alloc[addr].Balance, "dev account %s", addr)
+	}
+}
+
+func TestApplyGenesisAlloc_ExistingAccount(t *testing.T) {
+	gen := &core.Genesis{Alloc: types.GenesisAlloc{}}
+	genesis.FundDevAccounts(gen)
+
+	err := applyGenesisAlloc(gen, map[string]*big.Int{
+		genesis.DevAccounts[0].Hex(): big.NewInt(1),
+	})
+	assert.Error(t, err)
+}
+
+func TestValidateGenesisAlloc(t *testing.T) {
+	tests := []struct {
+		name    string
+		alloc   map[string]*big.Int
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"valid", map[string]*big.Int{"0x000000000000000000000000000000000000fa0c": big.NewInt(1)}, false},
+		{"invalid address", map[string]*big.Int{"0x1234": big.NewInt(1)}, true},
+		{"negative balance", map[string]*big.Int{"0x000000000000000000000000000000000000fa0c": big.NewInt(-1)}, true},
+		{"missing balance", map[string]*big.Int{"0x000000000000000000000000000000000000fa0c": nil}, true},
+		{"dev account", map[string]*big.Int{genesis.DevAccounts[0].Hex(): big.NewInt(1)}, true},
+		{"duplicate address", map[string]*big.Int{
+			"0x000000000000000000000000000000000000fa0c": big.NewInt(1),
+			"0x000000000000000000000000000000000000FA0C": big.NewInt(2),
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeploymentConfig{GenesisAlloc: tt.alloc}.ValidateGenesisAlloc()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGenerateConfigToml_CelestiaKeyID(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  DeploymentConfig
+		want string
+	}{
+		{"default", DeploymentConfig{ChainID: 42069}, `key_id = "anvil-9"`},
+		{"configured", DeploymentConfig{ChainID: 42069, CelestiaKeyID: "0b7e4c2a-5f1d-4a8e-9c3b-2d6f8e1a7b90"}, `key_id = "0b7e4c2a-5f1d-4a8e-9c3b-2d6f8e1a7b90"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.cfg.ValidateCelestiaKeyID())
+			w := newConfigWriter(slog.Default(), nil, &tt.cfg)
+
+			data, err := w.generateConfigToml()
+			require.NoError(t, err)
+			assert.Contains(t, string(data), tt.want)
+		})
+	}
+}
+
+func TestValidateCelestiaKeyID(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyID   string
+		wantErr bool
+	}{
+		{"unset", "", false},
+		{"anvil", "anvil-9", false},
+		{"uuid", "0b7e4c2a-5f1d-4a8e-9c3b-2d6f8e1a7b90", false},
+		{"quote", `key"`, true},
+		{"newline", "key\nmode = \"local\"", true},
+		{"too long", strings.Repeat("a", MaxCelestiaKeyIDLength+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeploymentConfig{CelestiaKeyID: tt.keyID}.ValidateCelestiaKeyID()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Artifact content encodings, stored in deployment_artifacts.content_encoding.
+const (
+	ContentEncodingIdentity = "identity"
+	ContentEncodingGzip     = "gzip"
+)
+
+// ArtifactCompressionThreshold is the content size in bytes from which
+// artifacts are stored gzip-compressed. Smaller artifacts stay in the JSONB
+// column, where they remain queryable.
+const ArtifactCompressionThreshold = 64 << 10
+
+// EncodeArtifactContent prepares artifact content for storage. Content of at
+// least ArtifactCompressionThreshold bytes is gzip-compressed; anything
+// smaller, or that doesn't shrink, is returned as is.
+func EncodeArtifactContent(content json.RawMessage) ([]byte, string, error) {
+	if len(content) < ArtifactCompressionThreshold {
+		return content, ContentEncodingIdentity, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		return nil, "", fmt.Errorf("compress artifact: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", fmt.Errorf("compress artifact: %w", err)
+	}
+
+	if buf.Len() >= len(content) {
+		return content, ContentEncodingIdentity, nil
+	}
+	return buf.Bytes(), ContentEncodingGzip, nil
+}
+
+// DecodeArtifactContent reverses EncodeArtifactContent.
+func DecodeArtifactContent(stored []byte, encoding string) (json.RawMessage, error) {
+	switch encoding {
+	case "", ContentEncodingIdentity:
+		return stored, nil
+	case ContentEncodingGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(stored))
+		if err != nil {
+			return nil, fmt.Errorf("decompress artifact: %w", err)
+		}
+		defer zr.Close()
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("decompress artifact: %w", err)
+		}
+		return content, nil
+	default:
+		return nil, fmt.Errorf("unknown artifact content encoding %q", encoding)
+	}
+}
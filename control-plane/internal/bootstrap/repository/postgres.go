@@ -15,6 +15,10 @@ import (
 // ErrNotFound is returned when a requested entity does not exist.
 var ErrNotFound = errors.New("not found")
 
+// ErrDeploymentRunning is returned when deleting a deployment that is still
+// running. It has to be cancelled first.
+var ErrDeploymentRunning = errors.New("deployment is running")
+
 // PostgresRepository implements Repository using PostgreSQL.
 type PostgresRepository struct {
 	pool *pgxpool.Pool
@@ -288,6 +292,87 @@ func (r *PostgresRepository) ListDeploymentsByOrgAndStatus(ctx context.Context,
 	return deployments, rows.Err()
 }
 
+// DeleteDeployment removes a deployment together with its transactions and
+// artifacts in a single transaction. The deployment row is locked first so a
+// concurrent start can't slip in between the status check and the delete.
+func (r *PostgresRepository) DeleteDeployment(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("DeleteDeployment begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var status Status
+	err = tx.QueryRow(ctx, `SELECT status FROM deployments WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("DeleteDeployment: %w", err)
+	}
+	if status == StatusRunning {
+		return ErrDeploymentRunning
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM deployment_artifacts WHERE deployment_id = $1`, id); err != nil {
+		return fmt.Errorf("DeleteDeployment artifacts: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM deployment_transactions WHERE deployment_id = $1`, id); err != nil {
+		return fmt.Errorf("DeleteDeployment transactions: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM deployments WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("DeleteDeployment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("DeleteDeployment commit: %w", err)
+	}
+	return nil
+}
+
+// ListDeploymentsPage retrieves a page of an organization's deployments using
+// keyset pagination, newest first.
+func (r *PostgresRepository) ListDeploymentsPage(ctx context.Context, q DeploymentListQuery) ([]*Deployment, error) {
+	query := `
+		SELECT id, org_id, chain_id, stack, status, current_stage, config, error_message, created_at, updated_at
+		FROM deployments
+		WHERE org_id = $1`
+
+	args := []any{q.OrgID}
+
+	if q.Status != nil {
+		args = append(args, *q.Status)
+		query += fmt.Sprintf(` AND status = $%d`, len(args))
+	}
+
+	if q.AfterCreatedAt != nil && q.AfterID != nil {
+		args = append(args, *q.AfterCreatedAt, *q.AfterID)
+		query += fmt.Sprintf(` AND (created_at, id) < ($%d, $%d)`, len(args)-1, len(args))
+	}
+
+	args = append(args, q.Limit)
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ListDeploymentsPage: %w", err)
+	}
+	defer rows.Close()
+
+	var deployments []*Deployment
+	for rows.Next() {
+		var d Deployment
+		if err := rows.Scan(
+			&d.ID, &d.OrgID, &d.ChainID, &d.Stack, &d.Status, &d.CurrentStage,
+			&d.Config, &d.ErrorMessage, &d.CreatedAt, &d.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("ListDeploymentsPage scan: %w", err)
+		}
+		deployments = append(deployments, &d)
+	}
+	return deployments, rows.Err()
+}
+
 // RecordTransaction inserts a new transaction record.
 func (r *PostgresRepository) RecordTransaction(ctx context.Context, tx *Transaction) error {
 	if tx.ID == uuid.Nil {
@@ -356,20 +441,34 @@ func (r *PostgresRepository) GetTransactionByHash(ctx context.Context, hash stri
 }
 
 // SaveArtifact inserts or updates an artifact (upsert by deployment_id + artifact_type).
+// Large artifacts are stored gzip-compressed; see EncodeArtifactContent.
 func (r *PostgresRepository) SaveArtifact(ctx context.Context, a *Artifact) error {
 	if a.ID == uuid.Nil {
 		a.ID = uuid.New()
 	}
 
+	stored, encoding, err := EncodeArtifactContent(a.Content)
+	if err != nil {
+		return fmt.Errorf("SaveArtifact: %w", err)
+	}
+	var content, compressed any
+	if encoding == ContentEncodingGzip {
+		compressed = stored
+	} else {
+		content = a.Content
+	}
+
 	query := `
-		INSERT INTO deployment_artifacts (id, deployment_id, artifact_type, content)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO deployment_artifacts (id, deployment_id, artifact_type, content, content_encoding, content_gzip)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (deployment_id, artifact_type)
-		DO UPDATE SET content = EXCLUDED.content
+		DO UPDATE SET content = EXCLUDED.content,
+			content_encoding = EXCLUDED.content_encoding,
+			content_gzip = EXCLUDED.content_gzip
 		RETURNING created_at`
 
-	err := r.pool.QueryRow(ctx, query,
-		a.ID, a.DeploymentID, a.ArtifactType, a.Content,
+	err = r.pool.QueryRow(ctx, query,
+		a.ID, a.DeploymentID, a.ArtifactType, content, encoding, compressed,
 	).Scan(&a.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("SaveArtifact: %w", err)
@@ -377,30 +476,56 @@ func (r *PostgresRepository) SaveArtifact(ctx context.Context, a *Artifact) erro
 	return nil
 }
 
+// artifactColumns are the columns read by scanArtifact.
+const artifactColumns = `id, deployment_id, artifact_type, content, content_encoding, content_gzip, created_at`
+
+// scanArtifact scans an artifact row, decompressing its content if needed.
+func scanArtifact(row pgx.Row) (*Artifact, error) {
+	var (
+		a          Artifact
+		content    []byte
+		encoding   string
+		compressed []byte
+	)
+	if err := row.Scan(
+		&a.ID, &a.DeploymentID, &a.ArtifactType, &content, &encoding, &compressed, &a.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	stored := content
+	if encoding == ContentEncodingGzip {
+		stored = compressed
+	}
+	decoded, err := DecodeArtifactContent(stored, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", a.ArtifactType, err)
+	}
+	a.Content = decoded
+	return &a, nil
+}
+
 // GetArtifact retrieves an artifact by deployment ID and type.
 func (r *PostgresRepository) GetArtifact(ctx context.Context, deploymentID uuid.UUID, artifactType string) (*Artifact, error) {
 	query := `
-		SELECT id, deployment_id, artifact_type, content, created_at
+		SELECT ` + artifactColumns + `
 		FROM deployment_artifacts
 		WHERE deployment_id = $1 AND artifact_type = $2`
 
-	var a Artifact
-	err := r.pool.QueryRow(ctx, query, deploymentID, artifactType).Scan(
-		&a.ID, &a.DeploymentID, &a.ArtifactType, &a.Content, &a.CreatedAt,
-	)
+	a, err := scanArtifact(r.pool.QueryRow(ctx, query, deploymentID, artifactType))
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, ErrNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("GetArtifact: %w", err)
 	}
-	return &a, nil
+	return a, nil
 }
 
 // GetAllArtifacts retrieves all artifacts for a deployment.
 func (r *PostgresRepository) GetAllArtifacts(ctx context.Context, deploymentID uuid.UUID) ([]Artifact, error) {
 	query := `
-		SELECT id, deployment_id, artifact_type, content, created_at
+		SELECT ` + artifactColumns + `
 		FROM deployment_artifacts
 		WHERE deployment_id = $1
 		ORDER BY created_at ASC`
@@ -413,13 +538,11 @@ func (r *PostgresRepository) GetAllArtifacts(ctx context.Context, deploymentID u
 
 	var artifacts []Artifact
 	for rows.Next() {
-		var a Artifact
-		if err := rows.Scan(
-			&a.ID, &a.DeploymentID, &a.ArtifactType, &a.Content, &a.CreatedAt,
-		); err != nil {
+		a, err := scanArtifact(rows)
+		if err != nil {
 			return nil, fmt.Errorf("GetAllArtifacts scan: %w", err)
 		}
-		artifacts = append(artifacts, a)
+		artifacts = append(artifacts, *a)
 	}
 	return artifacts, rows.Err()
 }
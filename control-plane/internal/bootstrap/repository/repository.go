@@ -26,6 +26,11 @@ type Repository interface {
 	ListDeploymentsByOrg(ctx context.Context, orgID uuid.UUID) ([]*Deployment, error)
 	// ListDeploymentsByOrgAndStatus lists deployments filtered by org and status.
 	ListDeploymentsByOrgAndStatus(ctx context.Context, orgID uuid.UUID, status Status) ([]*Deployment, error)
+	// ListDeploymentsPage lists a page of an organization's deployments.
+	ListDeploymentsPage(ctx context.Context, q DeploymentListQuery) ([]*Deployment, error)
+	// DeleteDeployment removes a deployment with its transactions and artifacts.
+	// It returns ErrDeploymentRunning if the deployment is still running.
+	DeleteDeployment(ctx context.Context, id uuid.UUID) error
 
 	// MarkStaleDeploymentsFailed marks deployments that have been "running" for longer
 	// than the timeout as "failed". This handles cases where the deployment pod crashed
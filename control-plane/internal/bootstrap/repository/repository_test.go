@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRepository is a mock implementation of Repository for testing.
@@ -99,6 +102,19 @@ func (m *MockRepository) ListDeploymentsByOrgAndStatus(ctx context.Context, orgI
 	return args.Get(0).([]*Deployment), args.Error(1)
 }
 
+func (m *MockRepository) ListDeploymentsPage(ctx context.Context, q DeploymentListQuery) ([]*Deployment, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Deployment), args.Error(1)
+}
+
+func (m *MockRepository) DeleteDeployment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockRepository) MarkStaleDeploymentsFailed(ctx context.Context, orgID uuid.UUID, timeout time.Duration) (int, error) {
 	args := m.Called(ctx, orgID, timeout)
 	return args.Int(0), args.Error(1)
@@ -452,6 +468,56 @@ func TestMockRepository_GetAllArtifacts_Empty(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// --- Artifact Encoding Tests ---
+
+// largeArtifact returns a genesis-like JSON document of at least size bytes.
+func largeArtifact(size int) json.RawMessage {
+	var b strings.Builder
+	b.WriteString(`{"alloc": {`)
+	for i := 0; b.Len() < size; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `"0x%040x": {"balance": "0x%x"}`, i, i*1000)
+	}
+	b.WriteString(`}}`)
+	return json.RawMessage(b.String())
+}
+
+func TestEncodeArtifactContent_LargeRoundTrip(t *testing.T) {
+	content := largeArtifact(2 * ArtifactCompressionThreshold)
+
+	stored, encoding, err := EncodeArtifactContent(content)
+	require.NoError(t, err)
+	assert.Equal(t, ContentEncodingGzip, encoding)
+	assert.Less(t, len(stored), len(content)/2)
+
+	decoded, err := DecodeArtifactContent(stored, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, content, decoded)
+}
+
+func TestEncodeArtifactContent_SmallUncompressed(t *testing.T) {
+	content := json.RawMessage(`{"l2_chain_id": 42069}`)
+
+	stored, encoding, err := EncodeArtifactContent(content)
+	require.NoError(t, err)
+	assert.Equal(t, ContentEncodingIdentity, encoding)
+	assert.Equal(t, []byte(content), stored)
+
+	decoded, err := DecodeArtifactContent(stored, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, content, decoded)
+}
+
+func TestDecodeArtifactContent_Errors(t *testing.T) {
+	_, err := DecodeArtifactContent([]byte("not gzip"), ContentEncodingGzip)
+	assert.Error(t, err)
+
+	_, err = DecodeArtifactContent([]byte("{}"), "br")
+	assert.ErrorContains(t, err, "unknown artifact content encoding")
+}
+
 // --- Type Tests ---
 
 func TestStackConstants(t *testing.T) {
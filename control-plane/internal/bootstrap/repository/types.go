@@ -2,8 +2,10 @@
 package repository
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -52,6 +54,17 @@ type Deployment struct {
 	UpdatedAt    time.Time
 }
 
+// DeploymentListQuery represents query parameters for paging through an
+// organization's deployments. Results are ordered by (created_at DESC, id DESC);
+// AfterCreatedAt and AfterID identify the last deployment of the previous page.
+type DeploymentListQuery struct {
+	OrgID          uuid.UUID
+	Status         *Status
+	AfterCreatedAt *time.Time
+	AfterID        *uuid.UUID
+	Limit          int
+}
+
 // Transaction represents a blockchain transaction recorded during deployment.
 type Transaction struct {
 	ID           uuid.UUID
@@ -71,3 +84,70 @@ type Artifact struct {
 	CreatedAt    time.Time
 }
 
+// RawContent returns the artifact as it was produced. Non-JSON artifacts are
+// stored either as a JSON string or wrapped as {"_type":"base64","data":...};
+// both are decoded. JSON artifacts are returned unchanged.
+func (a *Artifact) RawContent() []byte {
+	var s string
+	if err := json.Unmarshal(a.Content, &s); err == nil {
+		return []byte(s)
+	}
+
+	var wrapper struct {
+		Type string `json:"_type"`
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(a.Content, &wrapper); err == nil && wrapper.Type == "base64" {
+		if decoded, err := base64.StdEncoding.DecodeString(wrapper.Data); err == nil {
+			return decoded
+		}
+	}
+	return a.Content
+}
+
+// ContentType infers the media type of RawContent.
+func (a *Artifact) ContentType() string {
+	content := a.RawContent()
+	switch {
+	case json.Valid(content):
+		return "application/json"
+	case utf8.Valid(content):
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// IsInternalArtifact reports whether an artifact type holds deployer state
+// rather than a file for the user.
+func IsInternalArtifact(artifactType string) bool {
+	return artifactType == "deployment_state" || artifactType == "opdeployer_state"
+}
+
+// ArtifactInfo describes an artifact without its content.
+type ArtifactInfo struct {
+	Type        string
+	Size        int // Size of RawContent in bytes
+	ContentType string
+	CreatedAt   time.Time
+}
+
+// ListArtifactInfo describes the user-facing artifacts, skipping internal
+// ones.
+func ListArtifactInfo(artifacts []Artifact) []ArtifactInfo {
+	infos := make([]ArtifactInfo, 0, len(artifacts))
+	for i := range artifacts {
+		a := &artifacts[i]
+		if IsInternalArtifact(a.ArtifactType) {
+			continue
+		}
+		infos = append(infos, ArtifactInfo{
+			Type:        a.ArtifactType,
+			Size:        len(a.RawContent()),
+			ContentType: a.ContentType(),
+			CreatedAt:   a.CreatedAt,
+		})
+	}
+	return infos
+}
+
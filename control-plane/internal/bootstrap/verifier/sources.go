@@ -0,0 +1,61 @@
+package verifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrSourceNotFound is returned when no source is known for a contract.
+var ErrSourceNotFound = errors.New("contract source not found")
+
+// ContractSource is the source of a contract in the form Etherscan-compatible
+// explorers accept for verification.
+type ContractSource struct {
+	// ContractName is the fully qualified name, e.g. "src/universal/Proxy.sol:Proxy".
+	ContractName string `json:"contract_name"`
+	// CompilerVersion is the full solc version, e.g. "v0.8.15+commit.e14f2714".
+	CompilerVersion string `json:"compiler_version"`
+	// StandardJSONInput is the solc standard JSON input used for the build.
+	StandardJSONInput json.RawMessage `json:"standard_json_input"`
+}
+
+// SourceProvider looks up contract sources by contract name.
+type SourceProvider interface {
+	Source(name string) (*ContractSource, error)
+}
+
+// DirSourceProvider reads contract sources from <dir>/<name>.json files.
+// The contract artifacts only carry ABIs and bytecode, so sources for
+// verification are provided separately (e.g. from
+// `forge verify-contract --show-standard-json-input`).
+type DirSourceProvider struct {
+	dir string
+}
+
+// NewDirSourceProvider creates a source provider backed by a directory.
+func NewDirSourceProvider(dir string) *DirSourceProvider {
+	return &DirSourceProvider{dir: dir}
+}
+
+// Source returns the source of the named contract.
+func (p *DirSourceProvider) Source(name string) (*ContractSource, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, filepath.Base(name)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read source for %s: %w", name, err)
+	}
+
+	var src ContractSource
+	if err := json.Unmarshal(data, &src); err != nil {
+		return nil, fmt.Errorf("parse source for %s: %w", name, err)
+	}
+	if src.ContractName == "" || src.CompilerVersion == "" || len(src.StandardJSONInput) == 0 {
+		return nil, fmt.Errorf("source for %s is missing contract_name, compiler_version or standard_json_input", name)
+	}
+	return &src, nil
+}
@@ -0,0 +1,248 @@
+// Package verifier provides source verification of deployed contracts on
+// Etherscan-compatible block explorers.
+package verifier
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AnvilChainID is the chain ID of local Anvil devnets, which have no
+// block explorer to verify against.
+const AnvilChainID = 31337
+
+// DefaultTimeout is the default timeout for verification API calls.
+const DefaultTimeout = 30 * time.Second
+
+// ArtifactType is the deployment artifact that verification results are
+// recorded as.
+const ArtifactType = "contract_verification.json"
+
+// Status is the verification status of a single contract.
+type Status string
+
+const (
+	// StatusSubmitted means the explorer accepted the verification request.
+	StatusSubmitted Status = "submitted"
+	// StatusAlreadyVerified means the explorer already has the source.
+	StatusAlreadyVerified Status = "already_verified"
+	// StatusSkipped means no source is known for the contract.
+	StatusSkipped Status = "skipped"
+	// StatusFailed means the explorer rejected the request or was unreachable.
+	StatusFailed Status = "failed"
+)
+
+// Config contains configuration for the contract verifier.
+type Config struct {
+	// APIURL is the Etherscan-compatible API endpoint,
+	// e.g. "https://api.etherscan.io/v2/api".
+	APIURL string
+
+	// APIKey is the explorer API key.
+	APIKey string
+
+	// Timeout for each API call. Defaults to DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Contract is a deployed contract to verify.
+type Contract struct {
+	// Label identifies the deployment role of the contract, e.g. "OptimismPortalProxy".
+	Label string
+	// Name is the contract name sources are looked up by, e.g. "Proxy".
+	Name string
+	// Address is the deployed address.
+	Address common.Address
+	// ConstructorArgs are the ABI-encoded constructor arguments, if known.
+	ConstructorArgs []byte
+}
+
+// Result is the verification outcome of a single contract.
+type Result struct {
+	Label   string         `json:"label"`
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+	Status  Status         `json:"status"`
+	GUID    string         `json:"guid,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
+
+// Report is the verification outcome of a deployment.
+type Report struct {
+	ChainID   uint64    `json:"chain_id"`
+	Results   []Result  `json:"results"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// apiResponse is the response envelope of Etherscan-compatible APIs.
+type apiResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  string `json:"result"`
+}
+
+// ContractVerifier submits source verification requests for deployed
+// contracts to an Etherscan-compatible API.
+type ContractVerifier struct {
+	config     Config
+	sources    SourceProvider
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// NewContractVerifier creates a new contract verifier.
+func NewContractVerifier(config Config, sources SourceProvider, logger *slog.Logger) *ContractVerifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	return &ContractVerifier{
+		config:     config,
+		sources:    sources,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		logger:     logger,
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func (v *ContractVerifier) WithHTTPClient(client *http.Client) *ContractVerifier {
+	v.httpClient = client
+	return v
+}
+
+// Verify submits verification requests for the given contracts on chainID.
+// A failure for one contract is recorded in its result and does not stop
+// the others. Anvil chains are skipped entirely and yield a nil report.
+func (v *ContractVerifier) Verify(ctx context.Context, chainID uint64, contracts []Contract) (*Report, error) {
+	if chainID == AnvilChainID {
+		v.logger.Info("skipping contract verification on Anvil")
+		return nil, nil
+	}
+	if v.config.APIURL == "" {
+		return nil, fmt.Errorf("verification API URL is not configured")
+	}
+
+	report := &Report{
+		ChainID:   chainID,
+		Results:   make([]Result, 0, len(contracts)),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	for _, c := range contracts {
+		result := v.verifyContract(ctx, chainID, c)
+		v.logger.Info("contract verification",
+			slog.String("label", c.Label),
+			slog.String("address", c.Address.Hex()),
+			slog.String("status", string(result.Status)),
+			slog.String("message", result.Message),
+		)
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+func (v *ContractVerifier) verifyContract(ctx context.Context, chainID uint64, c Contract) Result {
+	result := Result{
+		Label:   c.Label,
+		Name:    c.Name,
+		Address: c.Address,
+	}
+
+	src, err := v.sources.Source(c.Name)
+	if errors.Is(err, ErrSourceNotFound) {
+		result.Status = StatusSkipped
+		result.Message = fmt.Sprintf("no source for %s", c.Name)
+		return result
+	}
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		return result
+	}
+
+	resp, err := v.submit(ctx, chainID, c, src)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Message = err.Error()
+		return result
+	}
+
+	switch {
+	case resp.Status == "1":
+		result.Status = StatusSubmitted
+		result.GUID = resp.Result
+		result.Message = resp.Message
+	case strings.Contains(strings.ToLower(resp.Result), "already verified"):
+		result.Status = StatusAlreadyVerified
+		result.Message = resp.Result
+	default:
+		result.Status = StatusFailed
+		result.Message = resp.Result
+	}
+	return result
+}
+
+// submit sends a verifysourcecode request for the contract.
+func (v *ContractVerifier) submit(ctx context.Context, chainID uint64, c Contract, src *ContractSource) (*apiResponse, error) {
+	endpoint, err := url.Parse(v.config.APIURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse API URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("chainid", strconv.FormatUint(chainID, 10))
+	endpoint.RawQuery = query.Encode()
+
+	form := url.Values{}
+	form.Set("module", "contract")
+	form.Set("action", "verifysourcecode")
+	form.Set("apikey", v.config.APIKey)
+	form.Set("contractaddress", c.Address.Hex())
+	form.Set("sourceCode", string(src.StandardJSONInput))
+	form.Set("codeformat", "solidity-standard-json-input")
+	form.Set("contractname", src.ContractName)
+	form.Set("compilerversion", src.CompilerVersion)
+	// The misspelling is part of the Etherscan API.
+	form.Set("constructorArguements", hex.EncodeToString(c.ConstructorArgs))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("submit verification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("verification API returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &apiResp, nil
+}
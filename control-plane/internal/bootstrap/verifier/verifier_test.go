@@ -0,0 +1,137 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSource(t *testing.T, dir, name string, src ContractSource) {
+	t.Helper()
+	data, err := json.Marshal(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), data, 0o644))
+}
+
+func TestContractVerifier_Verify(t *testing.T) {
+	dir := t.TempDir()
+	writeSource(t, dir, "Proxy", ContractSource{
+		ContractName:      "src/universal/Proxy.sol:Proxy",
+		CompilerVersion:   "v0.8.15+commit.e14f2714",
+		StandardJSONInput: json.RawMessage(`{"language":"Solidity"}`),
+	})
+
+	var (
+		query url.Values
+		form  url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+		require.NoError(t, r.ParseForm())
+		query = r.URL.Query()
+		form = r.PostForm
+		_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"guid-123"}`))
+	}))
+	defer server.Close()
+
+	v := NewContractVerifier(Config{APIURL: server.URL + "/api", APIKey: "test-key"}, NewDirSourceProvider(dir), nil)
+	addr := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	report, err := v.Verify(context.Background(), 11155111, []Contract{{
+		Label:           "OptimismPortalProxy",
+		Name:            "Proxy",
+		Address:         addr,
+		ConstructorArgs: []byte{0xab, 0xcd},
+	}})
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	require.Len(t, report.Results, 1)
+
+	assert.Equal(t, "11155111", query.Get("chainid"))
+	assert.Equal(t, "contract", form.Get("module"))
+	assert.Equal(t, "verifysourcecode", form.Get("action"))
+	assert.Equal(t, "test-key", form.Get("apikey"))
+	assert.Equal(t, addr.Hex(), form.Get("contractaddress"))
+	assert.Equal(t, `{"language":"Solidity"}`, form.Get("sourceCode"))
+	assert.Equal(t, "solidity-standard-json-input", form.Get("codeformat"))
+	assert.Equal(t, "src/universal/Proxy.sol:Proxy", form.Get("contractname"))
+	assert.Equal(t, "v0.8.15+commit.e14f2714", form.Get("compilerversion"))
+	assert.Equal(t, "abcd", form.Get("constructorArguements"))
+
+	result := report.Results[0]
+	assert.Equal(t, StatusSubmitted, result.Status)
+	assert.Equal(t, "guid-123", result.GUID)
+	assert.Equal(t, "OptimismPortalProxy", result.Label)
+	assert.Equal(t, uint64(11155111), report.ChainID)
+}
+
+func TestContractVerifier_Verify_Statuses(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"Verified", "Rejected"} {
+		writeSource(t, dir, name, ContractSource{
+			ContractName:      "src/" + name + ".sol:" + name,
+			CompilerVersion:   "v0.8.15+commit.e14f2714",
+			StandardJSONInput: json.RawMessage(`{}`),
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch r.PostForm.Get("contractname") {
+		case "src/Verified.sol:Verified":
+			_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Contract source code already verified"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Invalid API Key"}`))
+		}
+	}))
+	defer server.Close()
+
+	v := NewContractVerifier(Config{APIURL: server.URL}, NewDirSourceProvider(dir), nil)
+	report, err := v.Verify(context.Background(), 1, []Contract{
+		{Label: "A", Name: "Verified"},
+		{Label: "B", Name: "Rejected"},
+		{Label: "C", Name: "Unknown"},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 3)
+
+	assert.Equal(t, StatusAlreadyVerified, report.Results[0].Status)
+	assert.Equal(t, StatusFailed, report.Results[1].Status)
+	assert.Equal(t, "Invalid API Key", report.Results[1].Message)
+	assert.Equal(t, StatusSkipped, report.Results[2].Status)
+}
+
+func TestContractVerifier_Verify_SkipsAnvil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected verification request on Anvil")
+	}))
+	defer server.Close()
+
+	v := NewContractVerifier(Config{APIURL: server.URL}, NewDirSourceProvider(t.TempDir()), nil)
+	report, err := v.Verify(context.Background(), AnvilChainID, []Contract{{Label: "A", Name: "Proxy"}})
+	require.NoError(t, err)
+	assert.Nil(t, report)
+}
+
+func TestDirSourceProvider_Source(t *testing.T) {
+	dir := t.TempDir()
+	p := NewDirSourceProvider(dir)
+
+	_, err := p.Source("Missing")
+	assert.ErrorIs(t, err, ErrSourceNotFound)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Incomplete.json"), []byte(`{"contract_name":"x"}`), 0o644))
+	_, err = p.Source("Incomplete")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrSourceNotFound)
+}
@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
@@ -11,13 +12,21 @@ import (
 
 // Config holds all configuration for the application.
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	Redis    RedisConfig    `mapstructure:"redis"`
-	OpenBao  OpenBaoConfig  `mapstructure:"openbao"`
-	Auth     AuthConfig     `mapstructure:"auth"`
+	Server       ServerConfig       `mapstructure:"server"`
+	CORS         CORSConfig         `mapstructure:"cors"`
+	Database     DatabaseConfig     `mapstructure:"database"`
+	Redis        RedisConfig        `mapstructure:"redis"`
+	OpenBao      OpenBaoConfig      `mapstructure:"openbao"`
+	Auth         AuthConfig         `mapstructure:"auth"`
+	Email        EmailConfig        `mapstructure:"email"`
+	Verification VerificationConfig `mapstructure:"verification"`
+	Bundle       BundleConfig       `mapstructure:"bundle"`
 }
 
+// apiKeyPrefixPattern matches valid API key prefixes. Underscores separate
+// the parts of a key, so they can't appear in the prefix.
+var apiKeyPrefixPattern = regexp.MustCompile(`^[a-z][a-z0-9]{0,15}$`)
+
 // ServerConfig holds HTTP server configuration.
 type ServerConfig struct {
 	Port         int           `mapstructure:"port"`
@@ -25,6 +34,21 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	Environment  string        `mapstructure:"environment"` // dev, staging, prod
+	// MaxRPCRequestSize caps JSON-RPC request bodies, in bytes.
+	MaxRPCRequestSize int64 `mapstructure:"max_rpc_request_size"`
+	// TrustedProxies lists the proxy IPs and CIDRs whose X-Forwarded-For and
+	// X-Real-IP headers are honoured. Headers from other peers are ignored.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// CORSConfig holds cross-origin request configuration for the API servers.
+// With no allowed origins, only same-origin requests are allowed, except in
+// the dev environment where localhost origins are allowed by default.
+type CORSConfig struct {
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedMethods   []string `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
 }
 
 // DatabaseConfig holds PostgreSQL configuration.
@@ -54,6 +78,10 @@ type RedisConfig struct {
 	Port     int    `mapstructure:"port"`
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
+	// KeyPrefix namespaces every key and pub/sub channel, so environments
+	// can share a Redis instance. Pub/sub ignores DB, so set a prefix even
+	// when environments use different DB indexes.
+	KeyPrefix string `mapstructure:"key_prefix"`
 }
 
 // Addr returns the Redis address string.
@@ -67,6 +95,18 @@ type OpenBaoConfig struct {
 	Token         string `mapstructure:"token"`
 	Namespace     string `mapstructure:"namespace"`
 	Secp256k1Path string `mapstructure:"secp256k1_path"`
+	// MaxConcurrentSignsPerKey caps in-flight signs against a single key so
+	// bursts can't overwhelm the plugin. Zero means no limit.
+	MaxConcurrentSignsPerKey int `mapstructure:"max_concurrent_signs_per_key"`
+	// SignQueueTimeout is how long a sign may wait for a slot on a key at
+	// its limit before it is rejected.
+	SignQueueTimeout time.Duration `mapstructure:"sign_queue_timeout"`
+	// SignCacheTTL is how long a signature is reused for exact repeats of
+	// its sign request. Zero disables the cache.
+	SignCacheTTL time.Duration `mapstructure:"sign_cache_ttl"`
+	// MaxSignMessageSize is the largest message, in bytes, accepted for
+	// signing. Larger messages are rejected before they are hashed.
+	MaxSignMessageSize int `mapstructure:"max_sign_message_size"`
 }
 
 // AuthConfig holds authentication configuration.
@@ -81,6 +121,46 @@ type AuthConfig struct {
 	OAuthGoogleSecret string        `mapstructure:"oauth_google_secret"`
 	OAuthCallbackURL  string        `mapstructure:"oauth_callback_url"`
 	DashboardURL      string        `mapstructure:"dashboard_url"`
+	// APIKeyPrefix starts every generated API key, e.g. "psk" for
+	// psk_live_<secret>, so leaked keys are recognizable.
+	APIKeyPrefix string `mapstructure:"api_key_prefix"`
+}
+
+// EmailConfig holds outgoing email configuration.
+// Emails are only sent when SMTPHost is set.
+type EmailConfig struct {
+	SMTPHost     string `mapstructure:"smtp_host"`
+	SMTPPort     int    `mapstructure:"smtp_port"`
+	SMTPUsername string `mapstructure:"smtp_username"`
+	SMTPPassword string `mapstructure:"smtp_password"`
+	From         string `mapstructure:"from"`
+}
+
+// Enabled reports whether an SMTP server is configured.
+func (c EmailConfig) Enabled() bool {
+	return c.SMTPHost != ""
+}
+
+// VerificationConfig holds block explorer source verification configuration
+// for contracts deployed by chain deployments. Verification is opt-in and is
+// always skipped on Anvil.
+type VerificationConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	APIURL     string `mapstructure:"api_url"`
+	APIKey     string `mapstructure:"api_key"`
+	SourcesDir string `mapstructure:"sources_dir"`
+}
+
+// BundleConfig holds configuration for POPKins devnet bundle deployments.
+type BundleConfig struct {
+	// MaxConcurrentDeployments caps concurrent bundle deployments, each of
+	// which runs its own Anvil and op-deployer. Zero means no limit.
+	MaxConcurrentDeployments int `mapstructure:"max_concurrent_deployments"`
+	// DeploymentTimeout bounds a single deployment once it leaves the queue.
+	DeploymentTimeout time.Duration `mapstructure:"deployment_timeout"`
+	// AnvilShutdownTimeout is how long to wait for Anvil to dump its state
+	// before killing it.
+	AnvilShutdownTimeout time.Duration `mapstructure:"anvil_shutdown_timeout"`
 }
 
 // Load reads configuration from files and environment variables.
@@ -129,6 +209,15 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if !apiKeyPrefixPattern.MatchString(cfg.Auth.APIKeyPrefix) {
+		return nil, fmt.Errorf("invalid auth.api_key_prefix %q: use 1-16 lowercase letters or digits, starting with a letter", cfg.Auth.APIKeyPrefix)
+	}
+
+	// Allow the local dashboard and tools in dev unless origins are configured
+	if len(cfg.CORS.AllowedOrigins) == 0 && cfg.Server.Environment == "dev" {
+		cfg.CORS.AllowedOrigins = []string{"http://localhost:*"}
+	}
+
 	return &cfg, nil
 }
 
@@ -140,6 +229,17 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.read_timeout", "30s")
 	v.SetDefault("server.write_timeout", "30s")
 	v.SetDefault("server.environment", "dev")
+	v.SetDefault("server.max_rpc_request_size", 2<<20) // 2 MiB
+	// e.g. BANHBAO_SERVER_TRUSTED_PROXIES=10.0.0.0/8,172.16.0.0/12
+	v.SetDefault("server.trusted_proxies", []string{})
+
+	// CORS defaults (same-origin only; methods and headers use the
+	// middleware defaults when empty). Lists may be given comma-separated,
+	// e.g. BANHBAO_CORS_ALLOWED_ORIGINS=https://app.example.com,https://*.example.com
+	v.SetDefault("cors.allowed_origins", []string{})
+	v.SetDefault("cors.allowed_methods", []string{})
+	v.SetDefault("cors.allowed_headers", []string{})
+	v.SetDefault("cors.allow_credentials", false)
 
 	// Database defaults
 	v.SetDefault("database.host", "localhost")
@@ -157,16 +257,40 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("redis.port", 6379)
 	v.SetDefault("redis.password", "")
 	v.SetDefault("redis.db", 0)
+	v.SetDefault("redis.key_prefix", "")
 
 	// OpenBao defaults
 	v.SetDefault("openbao.address", "http://localhost:8200")
 	v.SetDefault("openbao.namespace", "")
 	v.SetDefault("openbao.secp256k1_path", "secp256k1") // Use secp256k1 plugin
+	v.SetDefault("openbao.max_concurrent_signs_per_key", 0)
+	v.SetDefault("openbao.sign_queue_timeout", "5s")
+	v.SetDefault("openbao.sign_cache_ttl", 0)
+	v.SetDefault("openbao.max_sign_message_size", 1<<20) // 1 MiB
 
 	// Auth defaults (OAuth-only, no email/password)
 	v.SetDefault("auth.jwt_expiry", "24h")
 	v.SetDefault("auth.session_expiry", "168h") // 7 days
 	v.SetDefault("auth.oauth_callback_url", "http://localhost:8080")
 	v.SetDefault("auth.dashboard_url", "http://localhost:3000")
+	v.SetDefault("auth.api_key_prefix", "bbr")
+
+	// Email defaults (disabled until an SMTP host is configured)
+	v.SetDefault("email.smtp_host", "")
+	v.SetDefault("email.smtp_port", 587)
+	v.SetDefault("email.smtp_username", "")
+	v.SetDefault("email.smtp_password", "")
+	v.SetDefault("email.from", "POPSigner <noreply@popsigner.com>")
+
+	// Contract verification defaults (opt-in)
+	v.SetDefault("verification.enabled", false)
+	v.SetDefault("verification.api_url", "https://api.etherscan.io/v2/api")
+	v.SetDefault("verification.api_key", "")
+	v.SetDefault("verification.sources_dir", "./contract-sources")
+
+	// Bundle deployment defaults
+	v.SetDefault("bundle.max_concurrent_deployments", 2)
+	v.SetDefault("bundle.deployment_timeout", "30m")
+	v.SetDefault("bundle.anvil_shutdown_timeout", "5s")
 }
 
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_keys_namespace_created_id;
+DROP INDEX IF EXISTS idx_keys_org_created_id;
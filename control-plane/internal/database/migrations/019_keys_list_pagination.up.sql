@@ -0,0 +1,6 @@
+-- Support keyset pagination on the keys list endpoint
+CREATE INDEX IF NOT EXISTS idx_keys_org_created_id ON keys (org_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_keys_namespace_created_id ON keys (namespace_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+ALTER TABLE namespaces DROP COLUMN IF EXISTS bech32_prefix;
@@ -0,0 +1,2 @@
+-- Bech32 human-readable prefix used to render key addresses in a namespace
+ALTER TABLE namespaces ADD COLUMN IF NOT EXISTS bech32_prefix VARCHAR(83) NOT NULL DEFAULT 'celestia';
@@ -0,0 +1,14 @@
+-- Key material retired by rotation. The current version stays on keys;
+-- signing_allowed records whether a retired version may still sign.
+CREATE TABLE IF NOT EXISTS key_versions (
+    key_id UUID NOT NULL REFERENCES keys(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    bao_key_path VARCHAR(500) NOT NULL,
+    public_key BYTEA NOT NULL,
+    address VARCHAR(100) NOT NULL,
+    eth_address VARCHAR(42),
+    signing_allowed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key_id, version)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+
+ALTER TABLE webhooks ALTER COLUMN events DROP DEFAULT;
+ALTER TABLE webhooks ALTER COLUMN events TYPE TEXT[] USING ARRAY(SELECT jsonb_array_elements_text(events));
+ALTER TABLE webhooks ALTER COLUMN events SET DEFAULT '{}';
@@ -0,0 +1,21 @@
+-- The webhook repository stores subscribed events as a JSON array and
+-- matches them with @>, so events moves from TEXT[] to JSONB.
+ALTER TABLE webhooks ALTER COLUMN events DROP DEFAULT;
+ALTER TABLE webhooks ALTER COLUMN events TYPE JSONB USING to_jsonb(events);
+ALTER TABLE webhooks ALTER COLUMN events SET DEFAULT '[]'::jsonb;
+
+-- Delivery log, one row per delivery (after retries)
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL DEFAULT '',
+    status_code INTEGER NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL DEFAULT FALSE,
+    error TEXT NOT NULL DEFAULT '',
+    attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, attempted_at DESC);
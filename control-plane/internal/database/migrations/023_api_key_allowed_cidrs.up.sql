@@ -0,0 +1,2 @@
+-- Optional source IP allowlist per API key (empty = any IP)
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS allowed_cidrs TEXT[] NOT NULL DEFAULT '{}';
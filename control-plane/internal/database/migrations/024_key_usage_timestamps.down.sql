@@ -0,0 +1,2 @@
+ALTER TABLE keys DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE keys DROP COLUMN IF EXISTS rotated_at;
@@ -0,0 +1,3 @@
+-- When a key was last rotated and last used to sign (NULL = never)
+ALTER TABLE keys ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMPTZ;
+ALTER TABLE keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ;
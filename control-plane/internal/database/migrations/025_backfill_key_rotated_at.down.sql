@@ -0,0 +1,2 @@
+-- Backfilled values are indistinguishable from recorded ones; nothing to undo
+SELECT 1;
@@ -0,0 +1,5 @@
+-- Keys rotated before rotated_at existed: the current version was created
+-- when the previous one was retired
+UPDATE keys SET rotated_at = v.retired_at
+FROM (SELECT key_id, MAX(retired_at) AS retired_at FROM key_versions GROUP BY key_id) v
+WHERE keys.id = v.key_id AND keys.rotated_at IS NULL;
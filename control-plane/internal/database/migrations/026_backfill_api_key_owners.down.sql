@@ -0,0 +1,2 @@
+-- Backfilled owners are indistinguishable from recorded ones; nothing to undo
+SELECT 1;
@@ -0,0 +1,11 @@
+-- API keys now act with their creator's role. Keys created before user_id was
+-- recorded are assigned to their org's only member, who must have created
+-- them; keys in multi-member orgs stay ownerless and get the operator role.
+UPDATE api_keys SET user_id = m.user_id
+FROM (
+    SELECT org_id, MIN(user_id::text)::uuid AS user_id
+    FROM org_members
+    GROUP BY org_id
+    HAVING COUNT(*) = 1
+) m
+WHERE api_keys.org_id = m.org_id AND api_keys.user_id IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_keys_deleted_at;
@@ -0,0 +1,3 @@
+-- Support purging soft-deleted keys once their grace period has passed
+CREATE INDEX IF NOT EXISTS idx_keys_deleted_at ON keys (deleted_at)
+WHERE deleted_at IS NOT NULL;
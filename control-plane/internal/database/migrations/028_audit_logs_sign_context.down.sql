@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_audit_logs_resource_time;
+
+ALTER TABLE audit_logs
+    DROP COLUMN IF EXISTS result,
+    DROP COLUMN IF EXISTS sign_mode,
+    DROP COLUMN IF EXISTS request_hash;
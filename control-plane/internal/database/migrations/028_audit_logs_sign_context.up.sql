@@ -0,0 +1,9 @@
+-- Forensic detail for sign requests: payload hash, sign mode and outcome
+ALTER TABLE audit_logs
+    ADD COLUMN IF NOT EXISTS request_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS sign_mode VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS result VARCHAR(50);
+
+-- Look up a key's audit trail by time
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource_time ON audit_logs (resource_id, created_at DESC)
+WHERE resource_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_email_lower;
+
+DROP TABLE IF EXISTS password_reset_tokens;
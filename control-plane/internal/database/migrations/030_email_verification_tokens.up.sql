@@ -0,0 +1,14 @@
+-- Single-use email verification tokens; only the SHA-256 hash of a token is stored
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user ON email_verification_tokens(user_id, created_at);
+
+-- Emails of OAuth accounts were confirmed by the provider
+UPDATE users SET email_verified = TRUE WHERE oauth_provider IS NOT NULL AND email_verified = FALSE;
@@ -0,0 +1,8 @@
+-- Compressed artifacts can't be decompressed in SQL and are dropped;
+-- redeploy to regenerate them
+DELETE FROM deployment_artifacts WHERE content_encoding = 'gzip';
+
+ALTER TABLE deployment_artifacts DROP CONSTRAINT IF EXISTS deployment_artifacts_content_check;
+ALTER TABLE deployment_artifacts DROP COLUMN IF EXISTS content_gzip;
+ALTER TABLE deployment_artifacts DROP COLUMN IF EXISTS content_encoding;
+ALTER TABLE deployment_artifacts ALTER COLUMN content SET NOT NULL;
@@ -0,0 +1,10 @@
+-- Large artifacts (genesis.json, anvil-state.json) are stored gzip-compressed
+-- in content_gzip instead of the JSONB content column
+ALTER TABLE deployment_artifacts ALTER COLUMN content DROP NOT NULL;
+ALTER TABLE deployment_artifacts ADD COLUMN IF NOT EXISTS content_encoding VARCHAR(16) NOT NULL DEFAULT 'identity';
+ALTER TABLE deployment_artifacts ADD COLUMN IF NOT EXISTS content_gzip BYTEA;
+
+ALTER TABLE deployment_artifacts ADD CONSTRAINT deployment_artifacts_content_check CHECK (
+    (content_encoding = 'identity' AND content IS NOT NULL) OR
+    (content_encoding = 'gzip' AND content_gzip IS NOT NULL)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS allowed_namespace_ids,
+    DROP COLUMN IF EXISTS allowed_key_ids;
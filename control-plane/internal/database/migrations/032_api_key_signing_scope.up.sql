@@ -0,0 +1,5 @@
+-- Optional signing scope per API key: the keys it may sign with, listed by
+-- key or by namespace (both empty = any key in the org)
+ALTER TABLE api_keys
+    ADD COLUMN IF NOT EXISTS allowed_key_ids UUID[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_namespace_ids UUID[] NOT NULL DEFAULT '{}';
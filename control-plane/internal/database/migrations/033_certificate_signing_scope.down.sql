@@ -0,0 +1,3 @@
+ALTER TABLE client_certificates
+    DROP COLUMN IF EXISTS allowed_namespace_ids,
+    DROP COLUMN IF EXISTS allowed_key_ids;
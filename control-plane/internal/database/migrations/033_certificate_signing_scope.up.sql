@@ -0,0 +1,5 @@
+-- Optional signing scope per client certificate, mirroring api_keys: the keys
+-- an mTLS client may sign with (both empty = any key in the org)
+ALTER TABLE client_certificates
+    ADD COLUMN IF NOT EXISTS allowed_key_ids UUID[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_namespace_ids UUID[] NOT NULL DEFAULT '{}';
@@ -10,13 +10,19 @@ import (
 	"github.com/Bidon15/popsigner/control-plane/internal/config"
 )
 
-// Redis wraps a Redis client.
+// Redis wraps a Redis client. Keys and channels passed to its methods are
+// namespaced with the configured key prefix.
 type Redis struct {
 	client *redis.Client
+	prefix string
 }
 
 // NewRedis creates a new Redis client.
 func NewRedis(cfg config.RedisConfig) (*Redis, error) {
+	if cfg.DB < 0 {
+		return nil, fmt.Errorf("invalid redis db index %d", cfg.DB)
+	}
+
 	client := redis.NewClient(&redis.Options{
 		Addr:     cfg.Addr(),
 		Password: cfg.Password,
@@ -31,14 +37,35 @@ func NewRedis(cfg config.RedisConfig) (*Redis, error) {
 		return nil, fmt.Errorf("failed to connect to redis: %w", err)
 	}
 
-	return &Redis{client: client}, nil
+	return &Redis{client: client, prefix: cfg.KeyPrefix}, nil
 }
 
-// Client returns the underlying Redis client.
+// Client returns the underlying Redis client. Keys used with it directly
+// must be namespaced with Key.
 func (r *Redis) Client() *redis.Client {
 	return r.client
 }
 
+// Key namespaces key with the configured key prefix, as "prefix:key".
+func (r *Redis) Key(key string) string {
+	if r.prefix == "" {
+		return key
+	}
+	return r.prefix + ":" + key
+}
+
+// keys namespaces each of keys with the configured key prefix.
+func (r *Redis) keys(keys []string) []string {
+	if r.prefix == "" {
+		return keys
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.Key(key)
+	}
+	return prefixed
+}
+
 // Close closes the Redis connection.
 func (r *Redis) Close() error {
 	if r.client != nil {
@@ -54,31 +81,32 @@ func (r *Redis) Ping(ctx context.Context) error {
 
 // Set stores a key-value pair with optional expiration.
 func (r *Redis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	return r.client.Set(ctx, key, value, expiration).Err()
+	return r.client.Set(ctx, r.Key(key), value, expiration).Err()
 }
 
 // Get retrieves a value by key.
 func (r *Redis) Get(ctx context.Context, key string) (string, error) {
-	return r.client.Get(ctx, key).Result()
+	return r.client.Get(ctx, r.Key(key)).Result()
 }
 
 // Delete removes a key.
 func (r *Redis) Delete(ctx context.Context, keys ...string) error {
-	return r.client.Del(ctx, keys...).Err()
+	return r.client.Del(ctx, r.keys(keys)...).Err()
 }
 
 // Exists checks if a key exists.
 func (r *Redis) Exists(ctx context.Context, keys ...string) (int64, error) {
-	return r.client.Exists(ctx, keys...).Result()
+	return r.client.Exists(ctx, r.keys(keys)...).Result()
 }
 
 // Incr increments a key's value.
 func (r *Redis) Incr(ctx context.Context, key string) (int64, error) {
-	return r.client.Incr(ctx, key).Result()
+	return r.client.Incr(ctx, r.Key(key)).Result()
 }
 
 // IncrWithExpire increments a key and sets expiration if it doesn't exist.
 func (r *Redis) IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	key = r.Key(key)
 	pipe := r.client.Pipeline()
 	incr := pipe.Incr(ctx, key)
 	pipe.Expire(ctx, key, expiration)
@@ -89,8 +117,33 @@ func (r *Redis) IncrWithExpire(ctx context.Context, key string, expiration time.
 	return incr.Val(), nil
 }
 
+// IncrByWithExpire increments a key by n and sets expiration if it doesn't
+// exist.
+func (r *Redis) IncrByWithExpire(ctx context.Context, key string, n int64, expiration time.Duration) (int64, error) {
+	key = r.Key(key)
+	pipe := r.client.Pipeline()
+	incr := pipe.IncrBy(ctx, key, n)
+	pipe.Expire(ctx, key, expiration)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
 // SetNX sets a key only if it doesn't exist.
 func (r *Redis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
-	return r.client.SetNX(ctx, key, value, expiration).Result()
+	return r.client.SetNX(ctx, r.Key(key), value, expiration).Result()
 }
 
+
+// Publish sends a message to a pub/sub channel.
+func (r *Redis) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, r.Key(channel), message).Err()
+}
+
+// Subscribe subscribes to pub/sub channels. The caller must close the returned PubSub.
+// Received messages carry the namespaced channel name.
+func (r *Redis) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.client.Subscribe(ctx, r.keys(channels)...)
+}
@@ -0,0 +1,241 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+)
+
+// fakeRedis is a minimal RESP2 server standing in for a shared Redis
+// instance. It implements the commands the Redis wrapper uses, with a
+// keyspace per DB index.
+type fakeRedis struct {
+	ln net.Listener
+
+	mu  sync.Mutex
+	dbs map[int]map[string]string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeRedis{ln: ln, dbs: make(map[int]map[string]string)}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeRedis) config(db int, prefix string) config.RedisConfig {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return config.RedisConfig{Host: addr.IP.String(), Port: addr.Port, DB: db, KeyPrefix: prefix}
+}
+
+// keys returns the keys stored in a DB.
+func (s *fakeRedis) keys(db int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.dbs[db] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	db := 0
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		var reply string
+		db, reply = s.exec(db, args)
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeRedis) exec(db int, args []string) (int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dbs[db] == nil {
+		s.dbs[db] = make(map[string]string)
+	}
+	data := s.dbs[db]
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return db, "+PONG\r\n"
+	case "SELECT":
+		n, _ := strconv.Atoi(args[1])
+		return n, "+OK\r\n"
+	case "SET":
+		data[args[1]] = args[2]
+		return db, "+OK\r\n"
+	case "GET":
+		value, ok := data[args[1]]
+		if !ok {
+			return db, "$-1\r\n"
+		}
+		return db, fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "INCR":
+		n, _ := strconv.ParseInt(data[args[1]], 10, 64)
+		n++
+		data[args[1]] = strconv.FormatInt(n, 10)
+		return db, fmt.Sprintf(":%d\r\n", n)
+	case "EXPIRE":
+		return db, ":1\r\n"
+	case "DEL":
+		var n int
+		for _, key := range args[1:] {
+			if _, ok := data[key]; ok {
+				delete(data, key)
+				n++
+			}
+		}
+		return db, fmt.Sprintf(":%d\r\n", n)
+	default:
+		// Includes HELLO, so the client falls back to RESP2
+		return db, "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+}
+
+// readCommand reads a command sent as a RESP array of bulk strings.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, errors.New("expected array")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedis_KeyPrefixIsolatesRateLimitCounters(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+
+	staging, err := NewRedis(server.config(0, "staging"))
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	defer staging.Close()
+	prod, err := NewRedis(server.config(0, "prod"))
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	defer prod.Close()
+
+	const key = "ratelimit:ip:10.0.0.1"
+	for i := 1; i <= 3; i++ {
+		count, err := staging.IncrWithExpire(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("IncrWithExpire() error = %v", err)
+		}
+		if count != int64(i) {
+			t.Errorf("staging count = %d, want %d", count, i)
+		}
+	}
+
+	count, err := prod.IncrWithExpire(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("IncrWithExpire() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("prod count = %d, want 1: counters are shared across prefixes", count)
+	}
+
+	if err := prod.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, err := staging.Get(ctx, key); err != nil || got != "3" {
+		t.Errorf("staging Get() = %q, %v; want \"3\" after prod deleted its own key", got, err)
+	}
+
+	keys := server.keys(0)
+	if len(keys) != 1 || keys[0] != "staging:"+key {
+		t.Errorf("stored keys = %v, want [staging:%s]", keys, key)
+	}
+}
+
+func TestRedis_DBIndex(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+
+	r, err := NewRedis(server.config(3, ""))
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	defer r.Close()
+
+	if err := r.Set(ctx, "idempotency:key", "value", time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if keys := server.keys(3); len(keys) != 1 || keys[0] != "idempotency:key" {
+		t.Errorf("DB 3 keys = %v, want [idempotency:key]", keys)
+	}
+	if keys := server.keys(0); len(keys) != 0 {
+		t.Errorf("DB 0 keys = %v, want none", keys)
+	}
+}
+
+func TestNewRedis_RejectsNegativeDB(t *testing.T) {
+	if _, err := NewRedis(config.RedisConfig{Host: "127.0.0.1", Port: 6379, DB: -1}); err == nil {
+		t.Error("NewRedis() expected error for negative DB index")
+	}
+}
+
+func TestRedis_Key(t *testing.T) {
+	if got := (&Redis{}).Key("ratelimit:x"); got != "ratelimit:x" {
+		t.Errorf("Key() without prefix = %q", got)
+	}
+	if got := (&Redis{prefix: "staging"}).Key("ratelimit:x"); got != "staging:ratelimit:x" {
+		t.Errorf("Key() with prefix = %q", got)
+	}
+}
@@ -3,7 +3,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -47,6 +51,12 @@ func (h *APIKeyHandler) Routes() chi.Router {
 	// Revoke an API key (soft delete - key remains in DB but becomes invalid)
 	r.Post("/{id}/revoke", h.Revoke)
 
+	// Rotate an API key (mints a successor; the old key expires after a grace period)
+	r.Post("/{id}/rotate", h.Rotate)
+
+	// Replace the keys an API key may sign with
+	r.Put("/{id}/signing-scope", h.SetSigningScope)
+
 	return r
 }
 
@@ -56,6 +66,24 @@ type CreateAPIKeyRequest struct {
 	Scopes        []string `json:"scopes"`
 	ExpiresInDays *int     `json:"expires_in_days,omitempty"`
 	Environment   string   `json:"environment,omitempty"`
+	AllowedCIDRs  []string `json:"allowed_cidrs,omitempty"`
+	// Signing scope; both empty = the key may sign with any key in the org
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
+}
+
+// SigningScopeRequest represents the request body for replacing the signing
+// scope of an API key or client certificate. Empty lists let it sign with any
+// key in the org.
+type SigningScopeRequest struct {
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids"`
+}
+
+// RotateAPIKeyRequest represents the optional request body for rotating an
+// API key. GracePeriodHours defaults to 24; 0 expires the old key at once.
+type RotateAPIKeyRequest struct {
+	GracePeriodHours *int `json:"grace_period_hours,omitempty"`
 }
 
 // Create handles POST /v1/api-keys
@@ -90,13 +118,20 @@ func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create the API key
-	key, rawKey, err := h.apiKeyService.Create(r.Context(), orgID, service.CreateAPIKeyRequest{
-		Name:          req.Name,
-		Scopes:        req.Scopes,
-		ExpiresInDays: req.ExpiresInDays,
-		Environment:   req.Environment,
-	})
+	// Create the API key, owned by the authenticated user when there is one
+	createReq := service.CreateAPIKeyRequest{
+		Name:                req.Name,
+		Scopes:              req.Scopes,
+		ExpiresInDays:       req.ExpiresInDays,
+		Environment:         req.Environment,
+		AllowedCIDRs:        req.AllowedCIDRs,
+		AllowedKeyIDs:       req.AllowedKeyIDs,
+		AllowedNamespaceIDs: req.AllowedNamespaceIDs,
+	}
+	if userID := middleware.GetUserIDFromContext(r.Context()); userID != uuid.Nil {
+		createReq.UserID = &userID
+	}
+	key, rawKey, err := h.apiKeyService.Create(r.Context(), orgID, createReq)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -204,3 +239,77 @@ func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w)
 }
 
+// Rotate handles POST /v1/api-keys/{id}/rotate
+// Creates a successor key and returns its full key (shown only once). The old
+// key keeps working until the grace period ends.
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid API key ID"))
+		return
+	}
+
+	var req RotateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+	grace := service.DefaultAPIKeyRotationGrace
+	if req.GracePeriodHours != nil {
+		hours := *req.GracePeriodHours
+		if hours < 0 || hours > int(service.MaxAPIKeyRotationGrace/time.Hour) {
+			response.Error(w, apierrors.NewValidationError("grace_period_hours", fmt.Sprintf("must be between 0 and %d", int(service.MaxAPIKeyRotationGrace/time.Hour))))
+			return
+		}
+		grace = time.Duration(hours) * time.Hour
+	}
+
+	rotation, err := h.apiKeyService.Rotate(r.Context(), orgID, keyID, grace)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, models.RotateAPIKeyResponse{
+		APIKey:   rotation.Key.ToResponse(),
+		Key:      rotation.RawKey,
+		Previous: rotation.Previous.ToResponse(),
+		Warning:  "This key will not be shown again. Store it securely.",
+	})
+}
+
+// SetSigningScope handles PUT /v1/api-keys/{id}/signing-scope
+// Replaces the keys and namespaces an API key may sign with.
+func (h *APIKeyHandler) SetSigningScope(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid API key ID"))
+		return
+	}
+
+	var req SigningScopeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	key, err := h.apiKeyService.SetSigningScope(r.Context(), orgID, keyID, req.AllowedKeyIDs, req.AllowedNamespaceIDs)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, key.ToResponse())
+}
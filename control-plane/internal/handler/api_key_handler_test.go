@@ -20,12 +20,14 @@ import (
 
 // mockAPIKeyService is a mock implementation of APIKeyService for testing.
 type mockAPIKeyService struct {
-	createFunc func(ctx context.Context, orgID uuid.UUID, req service.CreateAPIKeyRequest) (*models.APIKey, string, error)
-	validateFunc func(ctx context.Context, rawKey string) (*models.APIKey, error)
-	listFunc     func(ctx context.Context, orgID uuid.UUID) ([]*models.APIKey, error)
-	getFunc      func(ctx context.Context, orgID, keyID uuid.UUID) (*models.APIKey, error)
-	revokeFunc   func(ctx context.Context, orgID, keyID uuid.UUID) error
-	deleteFunc   func(ctx context.Context, orgID, keyID uuid.UUID) error
+	createFunc          func(ctx context.Context, orgID uuid.UUID, req service.CreateAPIKeyRequest) (*models.APIKey, string, error)
+	validateFunc        func(ctx context.Context, rawKey string) (*models.APIKey, error)
+	listFunc            func(ctx context.Context, orgID uuid.UUID) ([]*models.APIKey, error)
+	getFunc             func(ctx context.Context, orgID, keyID uuid.UUID) (*models.APIKey, error)
+	revokeFunc          func(ctx context.Context, orgID, keyID uuid.UUID) error
+	rotateFunc          func(ctx context.Context, orgID, keyID uuid.UUID, grace time.Duration) (*service.APIKeyRotation, error)
+	setSigningScopeFunc func(ctx context.Context, orgID, keyID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error)
+	deleteFunc          func(ctx context.Context, orgID, keyID uuid.UUID) error
 }
 
 func (m *mockAPIKeyService) Create(ctx context.Context, orgID uuid.UUID, req service.CreateAPIKeyRequest) (*models.APIKey, string, error) {
@@ -63,6 +65,20 @@ func (m *mockAPIKeyService) Revoke(ctx context.Context, orgID, keyID uuid.UUID)
 	return nil
 }
 
+func (m *mockAPIKeyService) Rotate(ctx context.Context, orgID, keyID uuid.UUID, grace time.Duration) (*service.APIKeyRotation, error) {
+	if m.rotateFunc != nil {
+		return m.rotateFunc(ctx, orgID, keyID, grace)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIKeyService) SetSigningScope(ctx context.Context, orgID, keyID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error) {
+	if m.setSigningScopeFunc != nil {
+		return m.setSigningScopeFunc(ctx, orgID, keyID, keyIDs, namespaceIDs)
+	}
+	return nil, nil
+}
+
 func (m *mockAPIKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, orgID, keyID)
@@ -476,6 +492,104 @@ func TestAPIKeyHandler_Revoke(t *testing.T) {
 	}
 }
 
+func TestAPIKeyHandler_Rotate(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+	previousExpiry := time.Now().Add(2 * time.Hour)
+	twoHours, negative := 2, -1
+
+	tests := []struct {
+		name           string
+		keyIDParam     string
+		body           interface{}
+		wantGrace      time.Duration
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "rotates with the default grace period",
+			keyIDParam:     keyID.String(),
+			wantGrace:      service.DefaultAPIKeyRotationGrace,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "rotates with a custom grace period",
+			keyIDParam:     keyID.String(),
+			body:           RotateAPIKeyRequest{GracePeriodHours: &twoHours},
+			wantGrace:      2 * time.Hour,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "rejects a negative grace period",
+			keyIDParam:     keyID.String(),
+			body:           RotateAPIKeyRequest{GracePeriodHours: &negative},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "returns 409 for a revoked key",
+			keyIDParam:     keyID.String(),
+			wantGrace:      service.DefaultAPIKeyRotationGrace,
+			mockErr:        apierrors.NewConflictError("API key is revoked or expired"),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "rejects invalid UUID",
+			keyIDParam:     "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &mockAPIKeyService{
+				rotateFunc: func(ctx context.Context, oID, kID uuid.UUID, grace time.Duration) (*service.APIKeyRotation, error) {
+					if grace != tt.wantGrace {
+						t.Errorf("grace = %v, want %v", grace, tt.wantGrace)
+					}
+					if tt.mockErr != nil {
+						return nil, tt.mockErr
+					}
+					return &service.APIKeyRotation{
+						Key:      &models.APIKey{ID: uuid.New(), OrgID: oID, Name: "CI", KeyPrefix: "bbr_live_newkey12"},
+						RawKey:   "bbr_live_newkey12secret",
+						Previous: &models.APIKey{ID: kID, OrgID: oID, Name: "CI", KeyPrefix: "bbr_live_oldkey12", ExpiresAt: &previousExpiry},
+					}, nil
+				},
+			}
+			handler := NewAPIKeyHandler(mockService)
+
+			req := createTestRequest(t, http.MethodPost, "/v1/api-keys/"+tt.keyIDParam+"/rotate", tt.body, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.keyIDParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.Rotate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Status = %d, want %d: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if rec.Code != http.StatusCreated {
+				return
+			}
+
+			var resp struct {
+				Data models.RotateAPIKeyResponse `json:"data"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Data.Key != "bbr_live_newkey12secret" {
+				t.Errorf("Key = %q, want the successor's raw key", resp.Data.Key)
+			}
+			if resp.Data.Previous == nil || resp.Data.Previous.ID != keyID || resp.Data.Previous.ExpiresAt == nil {
+				t.Errorf("Previous = %+v, want the old key with its expiry", resp.Data.Previous)
+			}
+		})
+	}
+}
+
 func TestAPIKeyHandler_Routes(t *testing.T) {
 	mockService := &mockAPIKeyService{}
 	handler := NewAPIKeyHandler(mockService)
@@ -500,3 +614,64 @@ func TestAPIKeyHandler_Unauthorized(t *testing.T) {
 	}
 }
 
+func TestAPIKeyHandler_SetSigningScope(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+	batcher := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockService    *mockAPIKeyService
+		expectedStatus int
+	}{
+		{
+			name: "updates signing scope",
+			body: SigningScopeRequest{AllowedKeyIDs: []uuid.UUID{batcher}},
+			mockService: &mockAPIKeyService{
+				setSigningScopeFunc: func(ctx context.Context, oID, kID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error) {
+					if oID != orgID || kID != keyID || len(keyIDs) != 1 || keyIDs[0] != batcher || len(namespaceIDs) != 0 {
+						t.Errorf("SetSigningScope(%s, %s, %v, %v)", oID, kID, keyIDs, namespaceIDs)
+					}
+					return &models.APIKey{ID: kID, OrgID: oID, AllowedKeyIDs: keyIDs}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects invalid key IDs",
+			body:           map[string][]string{"allowed_key_ids": {"not-a-uuid"}},
+			mockService:    &mockAPIKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "returns 404 for nonexistent key",
+			body: SigningScopeRequest{},
+			mockService: &mockAPIKeyService{
+				setSigningScopeFunc: func(ctx context.Context, oID, kID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error) {
+					return nil, apierrors.NewNotFoundError("API key")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAPIKeyHandler(tt.mockService)
+
+			req := createTestRequest(t, http.MethodPut, "/v1/api-keys/"+keyID.String()+"/signing-scope", tt.body, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", keyID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.SetSigningScope(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
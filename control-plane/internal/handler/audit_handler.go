@@ -52,6 +52,9 @@ type AuditLogResponse struct {
 	IPAddress    *string                `json:"ip_address,omitempty"`
 	UserAgent    *string                `json:"user_agent,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	RequestHash  *string                `json:"request_hash,omitempty"`
+	SignMode     *string                `json:"sign_mode,omitempty"`
+	Result       *string                `json:"result,omitempty"`
 	CreatedAt    string                 `json:"created_at"`
 }
 
@@ -66,6 +69,9 @@ func toAuditLogResponse(log *models.AuditLog) *AuditLogResponse {
 		ResourceType: log.ResourceType,
 		ResourceID:   log.ResourceID,
 		UserAgent:    log.UserAgent,
+		RequestHash:  log.RequestHash,
+		SignMode:     log.SignMode,
+		Result:       log.Result,
 		CreatedAt:    log.CreatedAt.Format(time.RFC3339),
 	}
 
@@ -142,16 +148,23 @@ func (h *AuditHandler) ListLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Parse time filters
+	// Parse time filters. A malformed bound is rejected rather than ignored
+	// so that a typo doesn't silently widen the queried window.
 	if startStr := r.URL.Query().Get("start_time"); startStr != "" {
-		if t, err := time.Parse(time.RFC3339, startStr); err == nil {
-			filter.StartTime = &t
+		t, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			response.Error(w, apierrors.NewValidationError("start_time", "must be an RFC3339 timestamp"))
+			return
 		}
+		filter.StartTime = &t
 	}
 	if endStr := r.URL.Query().Get("end_time"); endStr != "" {
-		if t, err := time.Parse(time.RFC3339, endStr); err == nil {
-			filter.EndTime = &t
+		t, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			response.Error(w, apierrors.NewValidationError("end_time", "must be an RFC3339 timestamp"))
+			return
 		}
+		filter.EndTime = &t
 	}
 
 	// Parse limit
@@ -150,6 +150,19 @@ func TestAuditHandler_ListLogs_WithTimeFilters(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuditHandler_ListLogs_InvalidTimeFilter(t *testing.T) {
+	mockService := new(MockAuditService)
+	handler := NewAuditHandler(mockService)
+
+	req := createAuditRequest(http.MethodGet, "/logs?start_time=yesterday", uuid.New())
+	rr := httptest.NewRecorder()
+
+	handler.ListLogs(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestAuditHandler_ListLogs_Unauthorized(t *testing.T) {
 	mockService := new(MockAuditService)
 	handler := NewAuditHandler(mockService)
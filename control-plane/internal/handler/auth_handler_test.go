@@ -89,6 +89,11 @@ func (m *MockAuthService) ResetPassword(ctx context.Context, token, newPassword
 	return args.Error(0)
 }
 
+func (m *MockAuthService) SendEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) error {
 	args := m.Called(ctx, token)
 	return args.Error(0)
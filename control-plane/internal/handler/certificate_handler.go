@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
@@ -34,11 +35,13 @@ func (h *CertificateHandler) Routes() chi.Router {
 
 	// Certificate CRUD operations
 	r.With(middleware.RequireScope("certificates:read")).Get("/", h.List)
-	r.With(middleware.RequireScope("certificates:write")).Post("/", h.Create)
+	r.With(middleware.RequireScope("certificates:write"), middleware.RequireRole(models.RoleAdmin)).Post("/", h.Create)
+	r.With(middleware.RequireScope("certificates:write"), middleware.RequireRole(models.RoleAdmin)).Post("/enroll", h.Enroll)
 	r.Get("/ca", h.GetCA) // CA download doesn't require specific scope
 	r.With(middleware.RequireScope("certificates:read")).Get("/{id}", h.Get)
-	r.With(middleware.RequireScope("certificates:write")).Post("/{id}/revoke", h.Revoke)
-	r.With(middleware.RequireScope("certificates:write")).Delete("/{id}", h.Delete)
+	r.With(middleware.RequireScope("certificates:write"), middleware.RequireRole(models.RoleAdmin)).Post("/{id}/revoke", h.Revoke)
+	r.With(middleware.RequireScope("certificates:write"), middleware.RequireRole(models.RoleAdmin)).Put("/{id}/signing-scope", h.SetSigningScope)
+	r.With(middleware.RequireScope("certificates:write"), middleware.RequireRole(models.RoleAdmin)).Delete("/{id}", h.Delete)
 
 	return r
 }
@@ -95,6 +98,65 @@ func (h *CertificateHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.Created(w, bundle)
 }
 
+// EnrollCertificateHTTPRequest is the HTTP request body for enrolling a
+// client-generated CSR.
+type EnrollCertificateHTTPRequest struct {
+	Name                string      `json:"name"`
+	CSR                 string      `json:"csr"`                       // PEM-encoded ECDSA P-256 CSR
+	ValidityPeriod      string      `json:"validity_period,omitempty"` // e.g., "8760h" for 1 year
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
+}
+
+// Enroll handles POST /v1/certificates/enroll
+func (h *CertificateHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID.String() == "00000000-0000-0000-0000-000000000000" {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	var req EnrollCertificateHTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	if req.Name == "" {
+		response.Error(w, apierrors.NewValidationError("name", "name is required"))
+		return
+	}
+	if req.CSR == "" {
+		response.Error(w, apierrors.NewValidationError("csr", "csr is required"))
+		return
+	}
+
+	validityPeriod := models.DefaultValidityPeriod
+	if req.ValidityPeriod != "" {
+		d, err := time.ParseDuration(req.ValidityPeriod)
+		if err != nil {
+			response.Error(w, apierrors.NewValidationError("validity_period", "invalid duration format (e.g., '8760h')"))
+			return
+		}
+		validityPeriod = d
+	}
+
+	bundle, err := h.certService.Enroll(r.Context(), &models.EnrollCertificateRequest{
+		OrgID:               orgID,
+		Name:                req.Name,
+		CSRPEM:              req.CSR,
+		ValidityPeriod:      validityPeriod,
+		AllowedKeyIDs:       req.AllowedKeyIDs,
+		AllowedNamespaceIDs: req.AllowedNamespaceIDs,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, bundle)
+}
+
 // List handles GET /v1/certificates
 func (h *CertificateHandler) List(w http.ResponseWriter, r *http.Request) {
 	orgID := middleware.GetOrgIDFromContext(r.Context())
@@ -205,6 +267,35 @@ func (h *CertificateHandler) Revoke(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, map[string]string{"status": "revoked"})
 }
 
+// SetSigningScope handles PUT /v1/certificates/{id}/signing-scope
+func (h *CertificateHandler) SetSigningScope(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID.String() == "00000000-0000-0000-0000-000000000000" {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	certID := chi.URLParam(r, "id")
+	if certID == "" {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid certificate ID"))
+		return
+	}
+
+	var req SigningScopeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	cert, err := h.certService.SetSigningScope(r.Context(), orgID.String(), certID, req.AllowedKeyIDs, req.AllowedNamespaceIDs)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, toCertificateResponse(cert))
+}
+
 // Delete handles DELETE /v1/certificates/{id}
 func (h *CertificateHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	orgID := middleware.GetOrgIDFromContext(r.Context())
@@ -240,6 +331,9 @@ type CertificateResponse struct {
 	RevokedAt        *string                  `json:"revoked_at,omitempty"`
 	RevocationReason *string                  `json:"revocation_reason,omitempty"`
 	CreatedAt        string                   `json:"created_at"`
+
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
 }
 
 // toCertificateResponse converts a Certificate model to a CertificateResponse.
@@ -255,6 +349,9 @@ func toCertificateResponse(cert *models.Certificate) *CertificateResponse {
 		ExpiresAt:        cert.ExpiresAt.Format(time.RFC3339),
 		RevocationReason: cert.RevocationReason,
 		CreatedAt:        cert.CreatedAt.Format(time.RFC3339),
+
+		AllowedKeyIDs:       cert.AllowedKeyIDs,
+		AllowedNamespaceIDs: cert.AllowedNamespaceIDs,
 	}
 
 	if cert.RevokedAt != nil {
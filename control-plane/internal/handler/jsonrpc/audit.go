@@ -0,0 +1,105 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// auditSign wraps a signing method handler so that every call, successful or
+// not, writes an audit entry with the caller's identity and user agent, the
+// SHA-256 of the decoded payload being signed, the sign mode and the result. mTLS
+// requests also record the client certificate's name and fingerprint. Requests
+// without an organization are not audited since they never reach a key.
+func auditSign(method string, auditRepo repository.AuditRepository, next MethodHandler) MethodHandler {
+	if auditRepo == nil {
+		return next
+	}
+	return func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		ctx, obs := withSignObservation(ctx)
+		result, rpcErr := next(ctx, params)
+
+		if entry := signAuditLog(ctx, method, obs, rpcErr); entry != nil {
+			// Write asynchronously so auditing doesn't add to sign latency
+			go func() {
+				_ = auditRepo.Create(context.Background(), entry)
+			}()
+		}
+
+		return result, rpcErr
+	}
+}
+
+// signAuditLog builds the audit entry for a sign request from what the sign
+// handler observed. Requests rejected before their payload was decoded have
+// no request hash.
+func signAuditLog(ctx context.Context, method string, obs *signObservation, rpcErr *Error) *models.AuditLog {
+	orgID := middleware.GetOrgIDFromContext(ctx)
+	if orgID == uuid.Nil {
+		return nil
+	}
+
+	signMode := method
+	result := signResult(ctx, rpcErr)
+
+	log := &models.AuditLog{
+		ID:       uuid.New(),
+		OrgID:    orgID,
+		Event:    models.AuditEventKeySigned,
+		SignMode: &signMode,
+		Result:   &result,
+	}
+	log.ActorType, log.ActorID = signActor(ctx)
+
+	if obs.keyID != uuid.Nil {
+		keyID := obs.keyID
+		resourceType := models.ResourceTypeKey
+		log.ResourceType = &resourceType
+		log.ResourceID = &keyID
+	}
+	if obs.payloadHash != "" {
+		requestHash := obs.payloadHash
+		log.RequestHash = &requestHash
+	}
+	if ua, ok := ctx.Value(UserAgentKey).(string); ok && ua != "" {
+		log.UserAgent = &ua
+	}
+
+	metadata := map[string]any{}
+	if cert := middleware.GetCertificateFromContext(ctx); cert != nil {
+		// Kept alongside the actor ID so the entry stays readable after the
+		// certificate is deleted
+		metadata["certificate_name"] = cert.Name
+		metadata["certificate_fingerprint"] = cert.Fingerprint
+	}
+	if rpcErr != nil {
+		log.Event = models.AuditEventKeySignFailed
+		metadata["error_code"] = rpcErr.Code
+		metadata["error_message"] = rpcErr.Message
+	}
+	if len(metadata) > 0 {
+		log.Metadata, _ = json.Marshal(metadata)
+	}
+
+	return log
+}
+
+// signActor resolves who made a sign request: the client certificate or API
+// key the gateway authenticated, or the dashboard user.
+func signActor(ctx context.Context) (models.ActorType, *uuid.UUID) {
+	if id, err := uuid.Parse(middleware.GetCertificateIDFromContext(ctx)); err == nil {
+		return models.ActorTypeCertificate, &id
+	}
+	if id, err := uuid.Parse(middleware.GetAPIKeyIDFromContext(ctx)); err == nil {
+		return models.ActorTypeAPIKey, &id
+	}
+	if id := middleware.GetUserIDFromContext(ctx); id != uuid.Nil {
+		return models.ActorTypeUser, &id
+	}
+	return models.ActorTypeAPIKey, nil
+}
@@ -0,0 +1,187 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+// recordingAuditRepo implements repository.AuditRepository and hands each
+// created entry to the test.
+type recordingAuditRepo struct {
+	created chan *models.AuditLog
+}
+
+func newRecordingAuditRepo() *recordingAuditRepo {
+	return &recordingAuditRepo{created: make(chan *models.AuditLog, 10)}
+}
+
+func (r *recordingAuditRepo) Create(ctx context.Context, log *models.AuditLog) error {
+	r.created <- log
+	return nil
+}
+
+func (r *recordingAuditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog, error) {
+	return nil, nil
+}
+
+func (r *recordingAuditRepo) List(ctx context.Context, q models.AuditLogQuery) ([]*models.AuditLog, error) {
+	return nil, nil
+}
+
+func (r *recordingAuditRepo) CountByOrgAndPeriod(ctx context.Context, orgID uuid.UUID, start, end time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *recordingAuditRepo) CountByResourceAndPeriod(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceID uuid.UUID, start, end time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *recordingAuditRepo) DeleteBefore(ctx context.Context, orgID uuid.UUID, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *recordingAuditRepo) next(t *testing.T) *models.AuditLog {
+	t.Helper()
+	select {
+	case log := <-r.created:
+		return log
+	case <-time.After(time.Second):
+		t.Fatal("no audit entry written")
+		return nil
+	}
+}
+
+func TestServer_SignAudit(t *testing.T) {
+	const ethAddr = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	const params = `["0x742d35Cc6634C0532925a3b844Bc454e4438f44e","0x48656c6c6f"]`
+
+	bao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"r":"` + strings.Repeat("ab", 32) + `","s":"` + strings.Repeat("cd", 32) + `","v_int":27}}`))
+	}))
+	defer bao.Close()
+
+	orgID := uuid.New()
+	apiKeyID := uuid.New()
+	keyID := uuid.New()
+	addr := ethAddr
+
+	sign := func(t *testing.T, keyRepo *MockKeyRepository, auditRepo *recordingAuditRepo) *Response {
+		t.Helper()
+		server := NewServer(ServerConfig{
+			KeyRepo:   keyRepo,
+			AuditRepo: auditRepo,
+			BaoClient: openbao.NewClient(&config.OpenBaoConfig{Address: bao.URL, Token: "test"}),
+		})
+
+		body := `{"jsonrpc":"2.0","method":"eth_sign","params":` + params + `,"id":1}`
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "op-batcher/v1.9.0")
+		req.RemoteAddr = "203.0.113.7:4242"
+		ctx := context.WithValue(contextWithOrgID(orgID), middleware.APIKeyIDKey, apiKeyID.String())
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return &resp
+	}
+
+	// The hash covers the decoded message, not the JSON-RPC params
+	payloadHash := sha256.Sum256([]byte("Hello"))
+	wantHash := hex.EncodeToString(payloadHash[:])
+
+	t.Run("successful sign", func(t *testing.T) {
+		keyRepo := new(MockKeyRepository)
+		keyRepo.On("GetByEthAddress", mock.Anything, orgID, ethAddr).Return(&models.Key{
+			ID:         keyID,
+			OrgID:      orgID,
+			EthAddress: &addr,
+			BaoKeyPath: "test-key",
+		}, nil)
+		auditRepo := newRecordingAuditRepo()
+
+		resp := sign(t, keyRepo, auditRepo)
+		require.Nil(t, resp.Error)
+
+		log := auditRepo.next(t)
+		assert.Equal(t, orgID, log.OrgID)
+		assert.Equal(t, models.AuditEventKeySigned, log.Event)
+		assert.Equal(t, models.ActorTypeAPIKey, log.ActorType)
+		require.NotNil(t, log.ActorID)
+		assert.Equal(t, apiKeyID, *log.ActorID)
+		require.NotNil(t, log.ResourceID)
+		assert.Equal(t, keyID, *log.ResourceID)
+		assert.Nil(t, log.IPAddress)
+		require.NotNil(t, log.UserAgent)
+		assert.Equal(t, "op-batcher/v1.9.0", *log.UserAgent)
+		require.NotNil(t, log.RequestHash)
+		assert.Equal(t, wantHash, *log.RequestHash)
+		require.NotNil(t, log.SignMode)
+		assert.Equal(t, "eth_sign", *log.SignMode)
+		require.NotNil(t, log.Result)
+		assert.Equal(t, signResultSuccess, *log.Result)
+		assert.Empty(t, log.Metadata)
+	})
+
+	t.Run("denied sign", func(t *testing.T) {
+		// The address isn't a key of this org
+		keyRepo := new(MockKeyRepository)
+		keyRepo.On("GetByEthAddress", mock.Anything, orgID, ethAddr).Return(nil, nil)
+		auditRepo := newRecordingAuditRepo()
+
+		resp := sign(t, keyRepo, auditRepo)
+		require.NotNil(t, resp.Error)
+
+		log := auditRepo.next(t)
+		assert.Equal(t, orgID, log.OrgID)
+		assert.Equal(t, models.AuditEventKeySignFailed, log.Event)
+		assert.Equal(t, models.ActorTypeAPIKey, log.ActorType)
+		require.NotNil(t, log.ActorID)
+		assert.Equal(t, apiKeyID, *log.ActorID)
+		assert.Nil(t, log.ResourceID)
+		assert.Nil(t, log.IPAddress)
+		require.NotNil(t, log.UserAgent)
+		assert.Equal(t, "op-batcher/v1.9.0", *log.UserAgent)
+		require.NotNil(t, log.RequestHash)
+		assert.Equal(t, wantHash, *log.RequestHash)
+		require.NotNil(t, log.SignMode)
+		assert.Equal(t, "eth_sign", *log.SignMode)
+		require.NotNil(t, log.Result)
+		assert.Equal(t, signResultError, *log.Result)
+		var metadata map[string]any
+		require.NoError(t, json.Unmarshal(log.Metadata, &metadata))
+		assert.EqualValues(t, ResourceNotFound, metadata["error_code"])
+		assert.Equal(t, resp.Error.Message, metadata["error_message"])
+	})
+}
+
+func TestSignActor(t *testing.T) {
+	certID := uuid.New()
+	ctx := context.WithValue(context.Background(), middleware.CertificateIDKey, certID.String())
+
+	actorType, actorID := signActor(ctx)
+	assert.Equal(t, models.ActorTypeCertificate, actorType)
+	require.NotNil(t, actorID)
+	assert.Equal(t, certID, *actorID)
+}
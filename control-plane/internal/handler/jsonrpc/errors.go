@@ -0,0 +1,72 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/ethereum"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+// ErrorData is the data attached to signing errors. It lets clients
+// correlate a failure with gateway logs by request ID.
+type ErrorData struct {
+	RequestID string `json:"request_id,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// newErrorData builds ErrorData for the request in ctx.
+func newErrorData(ctx context.Context, reason string) *ErrorData {
+	reqID, _ := ctx.Value(RPCRequestIDKey).(string)
+	return &ErrorData{RequestID: reqID, Reason: reason}
+}
+
+// signingError maps an OpenBao signing failure to a JSON-RPC error whose code
+// tells clients whether to retry later (ResourceUnavail) or fix their setup.
+func signingError(ctx context.Context, err error) *Error {
+	data := newErrorData(ctx, err.Error())
+	switch {
+	case errors.Is(err, openbao.ErrUnavailable):
+		return NewError(ResourceUnavail, "Signing backend unavailable", data)
+	case errors.Is(err, openbao.ErrUnauthorized):
+		return NewError(BackendAuthError, "Signing backend rejected credentials", data)
+	case errors.Is(err, openbao.ErrPermissionDenied):
+		return NewError(BackendPolicyError, "Signing backend policy denied the request", data)
+	case errors.Is(err, openbao.ErrKeyNotFound):
+		return NewError(ResourceNotFound, "Key not found in signing backend", data)
+	case errors.Is(err, openbao.ErrInvalidDigest):
+		return NewError(InvalidParams, "Invalid params", data)
+	default:
+		return NewError(SigningError, "Signing failed", data)
+	}
+}
+
+// errKeyOutOfScope reports a key outside the signing scope of the request's
+// API key or client certificate, and marks the request as policy denied.
+func errKeyOutOfScope(ctx context.Context, address string) *Error {
+	observeSignOutOfScope(ctx)
+	return ErrUnauthorized(fmt.Sprintf("Credentials are not allowed to sign with %s", address))
+}
+
+// malformedSignatureError reports a signature from OpenBao that can't be used.
+func malformedSignatureError(ctx context.Context, err error) *Error {
+	return NewError(MalformedSignatureError, "Malformed signature from signing backend", newErrorData(ctx, err.Error()))
+}
+
+// decodeSignatureRS decodes r and s from an OpenBao sign response, rejecting
+// values that don't fit a 64-byte r || s signature.
+func decodeSignatureRS(resp *openbao.SignEVMResponse) (r, s []byte, err error) {
+	r, err = ethereum.DecodeBytes("0x" + resp.R)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode r: %w", err)
+	}
+	s, err = ethereum.DecodeBytes("0x" + resp.S)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode s: %w", err)
+	}
+	if len(r) == 0 || len(r) > 32 || len(s) == 0 || len(s) > 32 {
+		return nil, nil, fmt.Errorf("signature r/s are %d/%d bytes, want at most 32 each", len(r), len(s))
+	}
+	return r, s, nil
+}
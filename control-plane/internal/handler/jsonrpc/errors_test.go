@@ -0,0 +1,109 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+func TestEthSignHandler_BackendErrorCodes(t *testing.T) {
+	const ethAddr = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		noKey    bool
+		wantCode int
+	}{
+		{name: "sealed", status: http.StatusServiceUnavailable, body: `{"errors":["Vault is sealed"]}`, wantCode: ResourceUnavail},
+		{name: "bad token", status: http.StatusUnauthorized, body: `{"errors":["invalid token"]}`, wantCode: BackendAuthError},
+		{name: "permission denied", status: http.StatusForbidden, body: `{"errors":["permission denied"]}`, wantCode: BackendPolicyError},
+		{name: "key missing in backend", status: http.StatusBadRequest, body: `{"errors":["key not found"]}`, wantCode: ResourceNotFound},
+		{name: "other backend failure", status: http.StatusInternalServerError, body: `{"errors":["signing failed"]}`, wantCode: SigningError},
+		{
+			name:     "signature wider than 64 bytes",
+			status:   http.StatusOK,
+			body:     `{"data":{"r":"01` + strings.Repeat("ab", 32) + `","s":"` + strings.Repeat("cd", 32) + `","v_int":27}}`,
+			wantCode: MalformedSignatureError,
+		},
+		{name: "key missing in database", noKey: true, wantCode: ResourceNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			orgID := uuid.New()
+			addr := ethAddr
+			mockRepo := new(MockKeyRepository)
+			if tt.noKey {
+				mockRepo.On("GetByEthAddress", mock.Anything, orgID, ethAddr).Return(nil, nil)
+			} else {
+				mockRepo.On("GetByEthAddress", mock.Anything, orgID, ethAddr).Return(&models.Key{
+					ID:         uuid.New(),
+					OrgID:      orgID,
+					EthAddress: &addr,
+					BaoKeyPath: "test-key",
+				}, nil)
+			}
+
+			handler := NewEthSignHandler(mockRepo, openbao.NewClient(&config.OpenBaoConfig{Address: srv.URL, Token: "test"}), nil)
+
+			ctx := context.WithValue(contextWithOrgID(orgID), RPCRequestIDKey, "req-123")
+			_, rpcErr := handler.HandleEthSign(ctx, json.RawMessage(`["`+ethAddr+`", "0x48656c6c6f"]`))
+
+			require.NotNil(t, rpcErr)
+			assert.Equal(t, tt.wantCode, rpcErr.Code)
+			if data, ok := rpcErr.Data.(*ErrorData); ok {
+				assert.Equal(t, "req-123", data.RequestID)
+				assert.NotEmpty(t, data.Reason)
+			} else if !tt.noKey {
+				t.Errorf("expected *ErrorData, got %T", rpcErr.Data)
+			}
+		})
+	}
+}
+
+func TestSigningError_Codes(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, ResourceUnavail, signingError(ctx, &openbao.StatusError{StatusCode: http.StatusBadGateway}).Code)
+	assert.Equal(t, ResourceUnavail, signingError(ctx, openbao.ErrUnavailable).Code)
+	assert.Equal(t, BackendAuthError, signingError(ctx, &openbao.StatusError{StatusCode: http.StatusUnauthorized}).Code)
+	assert.Equal(t, BackendPolicyError, signingError(ctx, &openbao.StatusError{StatusCode: http.StatusForbidden}).Code)
+	assert.Equal(t, ResourceNotFound, signingError(ctx, &openbao.StatusError{StatusCode: http.StatusNotFound}).Code)
+	assert.Equal(t, InvalidParams, signingError(ctx, fmt.Errorf("%w: got 4 bytes", openbao.ErrInvalidDigest)).Code)
+	assert.Equal(t, SigningError, signingError(ctx, errors.New("failed to parse response")).Code)
+}
+
+func TestDecodeSignatureRS(t *testing.T) {
+	r, s, err := decodeSignatureRS(&openbao.SignEVMResponse{R: strings.Repeat("ab", 32), S: strings.Repeat("cd", 31)})
+	require.NoError(t, err)
+	assert.Len(t, r, 32)
+	assert.Len(t, s, 31)
+
+	_, _, err = decodeSignatureRS(&openbao.SignEVMResponse{R: strings.Repeat("ab", 33), S: strings.Repeat("cd", 32)})
+	assert.Error(t, err)
+
+	_, _, err = decodeSignatureRS(&openbao.SignEVMResponse{R: "", S: strings.Repeat("cd", 32)})
+	assert.Error(t, err)
+}
@@ -11,7 +11,6 @@ import (
 
 	"github.com/Bidon15/popsigner/control-plane/internal/ethereum"
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
-	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
@@ -20,16 +19,14 @@ import (
 type EthSignHandler struct {
 	keyRepo   repository.KeyRepository
 	baoClient *openbao.Client
-	auditRepo repository.AuditRepository
 	usageRepo repository.UsageRepository
 }
 
 // NewEthSignHandler creates a new eth_sign handler.
-func NewEthSignHandler(keyRepo repository.KeyRepository, baoClient *openbao.Client, auditRepo repository.AuditRepository, usageRepo repository.UsageRepository) *EthSignHandler {
+func NewEthSignHandler(keyRepo repository.KeyRepository, baoClient *openbao.Client, usageRepo repository.UsageRepository) *EthSignHandler {
 	return &EthSignHandler{
 		keyRepo:   keyRepo,
 		baoClient: baoClient,
-		auditRepo: auditRepo,
 		usageRepo: usageRepo,
 	}
 }
@@ -76,6 +73,13 @@ func (h *EthSignHandler) signMessage(ctx context.Context, params json.RawMessage
 		dataHex = args[1]
 	}
 
+	// Decode the data
+	data, err := ethereum.DecodeBytes(dataHex)
+	if err != nil {
+		return nil, ErrInvalidParams(fmt.Sprintf("invalid data hex: %v", err))
+	}
+	observeSignPayload(ctx, data)
+
 	// Lookup key by address
 	key, err := h.keyRepo.GetByEthAddress(ctx, orgID, addressHex)
 	if err != nil {
@@ -84,11 +88,9 @@ func (h *EthSignHandler) signMessage(ctx context.Context, params json.RawMessage
 	if key == nil {
 		return nil, ErrResourceNotFound(fmt.Sprintf("no key found for address %s", addressHex))
 	}
-
-	// Decode the data
-	data, err := ethereum.DecodeBytes(dataHex)
-	if err != nil {
-		return nil, ErrInvalidParams(fmt.Sprintf("invalid data hex: %v", err))
+	observeSignKey(ctx, key.ID)
+	if !middleware.AllowsSigningKey(ctx, key) {
+		return nil, errKeyOutOfScope(ctx, addressHex)
 	}
 
 	// Apply Ethereum signed message prefix
@@ -103,14 +105,16 @@ func (h *EthSignHandler) signMessage(ctx context.Context, params json.RawMessage
 
 	// Sign via OpenBao (use legacy signing, v=27/28, chainID=0)
 	hashB64 := base64.StdEncoding.EncodeToString(hash)
-	signResp, err := h.baoClient.SignEVM(key.BaoKeyPath, hashB64, 0)
+	signResp, err := h.baoClient.SignEVM(ctx, key.BaoKeyPath, hashB64, 0)
 	if err != nil {
-		return nil, ErrSigningFailed(err.Error())
+		return nil, signingError(ctx, err)
 	}
 
 	// Construct signature in Ethereum format: r (32 bytes) || s (32 bytes) || v (1 byte)
-	rBytes, _ := ethereum.DecodeBytes("0x" + signResp.R)
-	sBytes, _ := ethereum.DecodeBytes("0x" + signResp.S)
+	rBytes, sBytes, err := decodeSignatureRS(signResp)
+	if err != nil {
+		return nil, malformedSignatureError(ctx, err)
+	}
 
 	// Pad r and s to 32 bytes
 	sig := make([]byte, 65)
@@ -118,32 +122,18 @@ func (h *EthSignHandler) signMessage(ctx context.Context, params json.RawMessage
 	copy(sig[64-len(sBytes):64], sBytes)
 	sig[64] = byte(signResp.VInt)
 
-	// Log audit and increment usage asynchronously
-	go h.recordSignature(orgID, key.ID)
+	// Increment usage asynchronously
+	go h.recordSignature(orgID)
 
 	return ethereum.EncodeBytes(sig), nil
 }
 
-// recordSignature logs the signing operation and increments usage counters.
-func (h *EthSignHandler) recordSignature(orgID, keyID uuid.UUID) {
-	ctx := context.Background()
-
-	// Create audit log
-	if h.auditRepo != nil {
-		resourceType := models.ResourceTypeKey
-		_ = h.auditRepo.Create(ctx, &models.AuditLog{
-			ID:           uuid.New(),
-			OrgID:        orgID,
-			Event:        models.AuditEventKeySigned,
-			ActorType:    models.ActorTypeAPIKey,
-			ResourceType: &resourceType,
-			ResourceID:   &keyID,
-		})
-	}
-
+// recordSignature increments usage counters. The audit entry is written by
+// auditSign.
+func (h *EthSignHandler) recordSignature(orgID uuid.UUID) {
 	// Increment signature usage
 	if h.usageRepo != nil {
-		_ = h.usageRepo.Increment(ctx, orgID, "signatures", 1)
+		_ = h.usageRepo.Increment(context.Background(), orgID, "signatures", 1)
 	}
 }
 
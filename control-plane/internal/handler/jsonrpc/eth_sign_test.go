@@ -3,7 +3,10 @@ package jsonrpc
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/google/uuid"
@@ -11,6 +14,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 )
@@ -185,3 +190,71 @@ func TestEthSignHandler_KeyNotFound(t *testing.T) {
 	_ = ethAddr
 }
 
+func TestEthSignHandler_SigningScope(t *testing.T) {
+	var signs int32
+	bao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&signs, 1)
+		w.Write([]byte(`{"data":{"r":"` + strings.Repeat("ab", 32) + `","s":"` + strings.Repeat("cd", 32) + `","v_int":27}}`))
+	}))
+	defer bao.Close()
+
+	orgID := uuid.New()
+	batcherAddr := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	proposerAddr := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	batcher := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), EthAddress: &batcherAddr, BaoKeyPath: "batcher"}
+	proposer := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), EthAddress: &proposerAddr, BaoKeyPath: "proposer"}
+
+	mockRepo := new(MockKeyRepository)
+	mockRepo.On("GetByEthAddress", mock.Anything, orgID, batcherAddr).Return(batcher, nil)
+	mockRepo.On("GetByEthAddress", mock.Anything, orgID, proposerAddr).Return(proposer, nil)
+	handler := NewEthSignHandler(mockRepo, openbao.NewClient(&config.OpenBaoConfig{Address: bao.URL, Token: "test"}), nil)
+
+	sign := func(apiKey *models.APIKey, addr string) *Error {
+		ctx := contextWithOrgID(orgID)
+		if apiKey != nil {
+			ctx = context.WithValue(ctx, middleware.APIKeyContextKey, apiKey)
+		}
+		_, rpcErr := handler.HandleEthSign(ctx, json.RawMessage(`["`+addr+`", "0x48656c6c6f"]`))
+		return rpcErr
+	}
+
+	t.Run("key scope", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, AllowedKeyIDs: []uuid.UUID{batcher.ID}}
+
+		assert.Nil(t, sign(apiKey, batcherAddr))
+
+		before := atomic.LoadInt32(&signs)
+		rpcErr := sign(apiKey, proposerAddr)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, UnauthorizedError, rpcErr.Code)
+		assert.Equal(t, before, atomic.LoadInt32(&signs), "out-of-scope request must not reach OpenBao")
+	})
+
+	t.Run("namespace scope", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, AllowedNamespaceIDs: []uuid.UUID{proposer.NamespaceID}}
+
+		assert.Nil(t, sign(apiKey, proposerAddr))
+		rpcErr := sign(apiKey, batcherAddr)
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, UnauthorizedError, rpcErr.Code)
+	})
+
+	t.Run("client certificate scope", func(t *testing.T) {
+		cert := &models.Certificate{ID: uuid.New(), OrgID: orgID, AllowedKeyIDs: []uuid.UUID{batcher.ID}}
+		ctx := context.WithValue(contextWithOrgID(orgID), middleware.CertificateContextKey, cert)
+
+		_, rpcErr := handler.HandleEthSign(ctx, json.RawMessage(`["`+batcherAddr+`", "0x48656c6c6f"]`))
+		assert.Nil(t, rpcErr)
+		_, rpcErr = handler.HandleEthSign(ctx, json.RawMessage(`["`+proposerAddr+`", "0x48656c6c6f"]`))
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, UnauthorizedError, rpcErr.Code)
+	})
+
+	t.Run("unscoped", func(t *testing.T) {
+		apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID}
+
+		assert.Nil(t, sign(apiKey, batcherAddr))
+		assert.Nil(t, sign(apiKey, proposerAddr))
+		assert.Nil(t, sign(nil, proposerAddr))
+	})
+}
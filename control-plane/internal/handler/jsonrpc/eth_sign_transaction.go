@@ -11,7 +11,6 @@ import (
 
 	"github.com/Bidon15/popsigner/control-plane/internal/ethereum"
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
-	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
@@ -20,16 +19,14 @@ import (
 type EthSignTransactionHandler struct {
 	keyRepo   repository.KeyRepository
 	baoClient *openbao.Client
-	auditRepo repository.AuditRepository
 	usageRepo repository.UsageRepository
 }
 
 // NewEthSignTransactionHandler creates a new eth_signTransaction handler.
-func NewEthSignTransactionHandler(keyRepo repository.KeyRepository, baoClient *openbao.Client, auditRepo repository.AuditRepository, usageRepo repository.UsageRepository) *EthSignTransactionHandler {
+func NewEthSignTransactionHandler(keyRepo repository.KeyRepository, baoClient *openbao.Client, usageRepo repository.UsageRepository) *EthSignTransactionHandler {
 	return &EthSignTransactionHandler{
 		keyRepo:   keyRepo,
 		baoClient: baoClient,
-		auditRepo: auditRepo,
 		usageRepo: usageRepo,
 	}
 }
@@ -59,16 +56,6 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 		return nil, ErrInvalidParams(err.Error())
 	}
 
-	// Lookup key by from address
-	fromAddr := ethereum.EncodeAddress(*txArgs.From)
-	key, err := h.keyRepo.GetByEthAddress(ctx, orgID, fromAddr)
-	if err != nil {
-		return nil, ErrInternal(fmt.Sprintf("failed to lookup key: %v", err))
-	}
-	if key == nil {
-		return nil, ErrResourceNotFound(fmt.Sprintf("no key found for address %s", fromAddr))
-	}
-
 	// Determine transaction type and construct unsigned transaction
 	var unsignedTx *ethereum.UnsignedTransaction
 	if txArgs.MaxFeePerGas != nil {
@@ -82,6 +69,21 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 	// Compute transaction hash for signing
 	chainID := txArgs.ChainID.ToBig()
 	txHash := unsignedTx.SigningHash(chainID)
+	observeSignPayload(ctx, txHash)
+
+	// Lookup key by from address
+	fromAddr := ethereum.EncodeAddress(*txArgs.From)
+	key, err := h.keyRepo.GetByEthAddress(ctx, orgID, fromAddr)
+	if err != nil {
+		return nil, ErrInternal(fmt.Sprintf("failed to lookup key: %v", err))
+	}
+	if key == nil {
+		return nil, ErrResourceNotFound(fmt.Sprintf("no key found for address %s", fromAddr))
+	}
+	observeSignKey(ctx, key.ID)
+	if !middleware.AllowsSigningKey(ctx, key) {
+		return nil, errKeyOutOfScope(ctx, fromAddr)
+	}
 
 	// Sign via OpenBao
 	// For EIP-1559 (type 2) transactions, v should be just 0 or 1 (yParity)
@@ -92,15 +94,15 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 		// EIP-1559 uses raw yParity (0 or 1), not EIP-155 encoded v
 		signChainID = 0
 	}
-	signResp, err := h.baoClient.SignEVM(key.BaoKeyPath, hashB64, signChainID)
+	signResp, err := h.baoClient.SignEVM(ctx, key.BaoKeyPath, hashB64, signChainID)
 	if err != nil {
-		return nil, ErrSigningFailed(err.Error())
+		return nil, signingError(ctx, err)
 	}
 
 	// Parse v, r, s from response
 	v, r, s, parseErr := parseSignatureResponse(signResp)
 	if parseErr != nil {
-		return nil, ErrInternal(fmt.Sprintf("failed to parse signature: %v", parseErr))
+		return nil, malformedSignatureError(ctx, parseErr)
 	}
 
 	// For legacy (chainID=0) signing, v is 27 or 28
@@ -118,33 +120,19 @@ func (h *EthSignTransactionHandler) Handle(ctx context.Context, params json.RawM
 		return nil, ErrInternal(fmt.Sprintf("failed to encode transaction: %v", encodeErr))
 	}
 
-	// Log audit and increment usage asynchronously
-	go h.recordSignature(orgID, key.ID)
+	// Increment usage asynchronously
+	go h.recordSignature(orgID)
 
 	// Return hex-encoded signed transaction
 	return ethereum.EncodeBytes(encodedTx), nil
 }
 
-// recordSignature logs the signing operation and increments usage counters.
-func (h *EthSignTransactionHandler) recordSignature(orgID, keyID uuid.UUID) {
-	ctx := context.Background()
-
-	// Create audit log
-	if h.auditRepo != nil {
-		resourceType := models.ResourceTypeKey
-		_ = h.auditRepo.Create(ctx, &models.AuditLog{
-			ID:           uuid.New(),
-			OrgID:        orgID,
-			Event:        models.AuditEventKeySigned,
-			ActorType:    models.ActorTypeAPIKey,
-			ResourceType: &resourceType,
-			ResourceID:   &keyID,
-		})
-	}
-
+// recordSignature increments usage counters. The audit entry is written by
+// auditSign.
+func (h *EthSignTransactionHandler) recordSignature(orgID uuid.UUID) {
 	// Increment signature usage
 	if h.usageRepo != nil {
-		_ = h.usageRepo.Increment(ctx, orgID, "signatures", 1)
+		_ = h.usageRepo.Increment(context.Background(), orgID, "signatures", 1)
 	}
 }
 
@@ -153,19 +141,12 @@ func parseSignatureResponse(resp *openbao.SignEVMResponse) (*big.Int, *big.Int,
 	v := new(big.Int)
 	v.SetInt64(resp.VInt)
 
-	r := new(big.Int)
-	rBytes, err := ethereum.DecodeBytes("0x" + resp.R)
-	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to decode r: %w", err)
-	}
-	r.SetBytes(rBytes)
-
-	s := new(big.Int)
-	sBytes, err := ethereum.DecodeBytes("0x" + resp.S)
+	rBytes, sBytes, err := decodeSignatureRS(resp)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to decode s: %w", err)
+		return nil, nil, nil, err
 	}
-	s.SetBytes(sBytes)
+	r := new(big.Int).SetBytes(rBytes)
+	s := new(big.Int).SetBytes(sBytes)
 
 	return v, r, s, nil
 }
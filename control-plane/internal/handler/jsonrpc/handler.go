@@ -3,6 +3,8 @@ package jsonrpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -17,16 +19,23 @@ type ContextKey string
 const (
 	// RPCRequestIDKey is the context key for the RPC request ID.
 	RPCRequestIDKey ContextKey = "rpc_request_id"
+	// UserAgentKey is the context key for the User-Agent of the HTTP request.
+	UserAgentKey ContextKey = "user_agent"
 )
 
 // MethodHandler is the function signature for JSON-RPC method handlers.
 type MethodHandler func(ctx context.Context, params json.RawMessage) (interface{}, *Error)
 
+// DefaultMaxRequestBodySize is the largest request body the handler reads
+// unless configured otherwise.
+const DefaultMaxRequestBodySize = 2 << 20 // 2 MiB
+
 // Handler handles JSON-RPC 2.0 requests.
 type Handler struct {
-	methods map[string]MethodHandler
-	mu      sync.RWMutex
-	logger  *slog.Logger
+	methods     map[string]MethodHandler
+	mu          sync.RWMutex
+	logger      *slog.Logger
+	maxBodySize int64
 }
 
 // NewHandler creates a new JSON-RPC handler.
@@ -35,11 +44,22 @@ func NewHandler(logger *slog.Logger) *Handler {
 		logger = slog.Default()
 	}
 	return &Handler{
-		methods: make(map[string]MethodHandler),
-		logger:  logger,
+		methods:     make(map[string]MethodHandler),
+		logger:      logger,
+		maxBodySize: DefaultMaxRequestBodySize,
 	}
 }
 
+// SetMaxBodySize sets the largest request body accepted, in bytes. Larger
+// requests are rejected before they are parsed. Zero or less restores
+// DefaultMaxRequestBodySize.
+func (h *Handler) SetMaxBodySize(n int64) {
+	if n <= 0 {
+		n = DefaultMaxRequestBodySize
+	}
+	h.maxBodySize = n
+}
+
 // RegisterMethod registers a handler for a JSON-RPC method.
 func (h *Handler) RegisterMethod(name string, handler MethodHandler) {
 	h.mu.Lock()
@@ -81,9 +101,14 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Read body
-	body, err := io.ReadAll(r.Body)
+	// Read body, refusing oversized payloads before anything is parsed or signed
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize))
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeError(w, nil, ErrInvalidRequest(fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit)))
+			return
+		}
 		h.writeError(w, nil, ErrParseError("failed to read request body"))
 		return
 	}
@@ -94,6 +119,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Carry the caller's details through to method handlers for auditing
+	ctx := context.WithValue(r.Context(), UserAgentKey, r.UserAgent())
+	r = r.WithContext(ctx)
+
 	// Detect batch vs single request
 	if body[0] == '[' {
 		h.handleBatch(w, r, body)
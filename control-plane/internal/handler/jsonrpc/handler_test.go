@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -241,6 +242,37 @@ func TestHandler_HTTPMethods(t *testing.T) {
 	})
 }
 
+func TestHandler_MaxBodySize(t *testing.T) {
+	h := NewHandler(nil)
+	h.SetMaxBodySize(256)
+
+	called := false
+	h.RegisterMethod("eth_sign", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		called = true
+		return "0x", nil
+	})
+
+	send := func(message string) Response {
+		body := `{"jsonrpc":"2.0","method":"eth_sign","params":["0x0000000000000000000000000000000000000000","0x` + message + `"],"id":1}`
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/", bytes.NewBufferString(body)))
+
+		var resp Response
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		return resp
+	}
+
+	resp := send(strings.Repeat("ab", 1024))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, InvalidRequest, resp.Error.Code)
+	assert.Contains(t, resp.Error.Data, "exceeds 256 bytes")
+	assert.False(t, called, "oversized request reached the method")
+
+	resp = send("abcd")
+	assert.Nil(t, resp.Error)
+	assert.True(t, called)
+}
+
 func TestHandler_MethodReturnsError(t *testing.T) {
 	h := NewHandler(nil)
 	h.RegisterMethod("test_fail", func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
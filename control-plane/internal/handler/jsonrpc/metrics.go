@@ -0,0 +1,145 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Sign results used as the "result" label on sign metrics.
+const (
+	signResultSuccess      = "success"
+	signResultAuthError    = "auth_error"
+	signResultPolicyDenied = "policy_denied"
+	signResultError        = "error"
+)
+
+var (
+	signDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "popsigner_rpc_sign_duration_seconds",
+			Help:    "JSON-RPC signing duration in seconds by method",
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		},
+		[]string{"method"},
+	)
+
+	signsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "popsigner_rpc_signs_total",
+			Help: "Total JSON-RPC sign requests by method and result",
+		},
+		[]string{"method", "result"},
+	)
+
+	keySignsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "popsigner_rpc_key_signs_total",
+			Help: "Total JSON-RPC sign requests by key and result",
+		},
+		[]string{"key_id", "result"},
+	)
+
+	signsInFlight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "popsigner_rpc_sign_in_flight",
+			Help: "Number of JSON-RPC sign requests currently being processed",
+		},
+	)
+)
+
+type signObservationKey struct{}
+
+// signObservation carries what a sign handler learned about the request back
+// to instrumentSign and auditSign.
+type signObservation struct {
+	keyID uuid.UUID
+	// outOfScope is set when the key exists but the caller's credentials are
+	// not allowed to sign with it.
+	outOfScope bool
+	// payloadHash is the hex SHA-256 of the decoded payload being signed.
+	payloadHash string
+}
+
+// withSignObservation returns ctx carrying a signObservation, reusing the one
+// an outer wrapper already added.
+func withSignObservation(ctx context.Context) (context.Context, *signObservation) {
+	if obs, ok := ctx.Value(signObservationKey{}).(*signObservation); ok {
+		return ctx, obs
+	}
+	obs := &signObservation{}
+	return context.WithValue(ctx, signObservationKey{}, obs), obs
+}
+
+// observeSignKey records which key a sign request resolved to.
+// It is a no-op outside of an instrumented method.
+func observeSignKey(ctx context.Context, keyID uuid.UUID) {
+	if obs, ok := ctx.Value(signObservationKey{}).(*signObservation); ok {
+		obs.keyID = keyID
+	}
+}
+
+// observeSignPayload records the decoded payload a sign request signs, for
+// the audit log. It is a no-op outside of an instrumented method.
+func observeSignPayload(ctx context.Context, payload []byte) {
+	if obs, ok := ctx.Value(signObservationKey{}).(*signObservation); ok {
+		hash := sha256.Sum256(payload)
+		obs.payloadHash = hex.EncodeToString(hash[:])
+	}
+}
+
+// observeSignOutOfScope records that a sign request was rejected by the
+// caller's signing scope. It is a no-op outside of an instrumented method.
+func observeSignOutOfScope(ctx context.Context) {
+	if obs, ok := ctx.Value(signObservationKey{}).(*signObservation); ok {
+		obs.outOfScope = true
+	}
+}
+
+// instrumentSign wraps a signing method handler with latency, result and in-flight metrics.
+func instrumentSign(method string, next MethodHandler) MethodHandler {
+	return func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		signsInFlight.Inc()
+		defer signsInFlight.Dec()
+
+		ctx, obs := withSignObservation(ctx)
+
+		start := time.Now()
+		result, rpcErr := next(ctx, params)
+		signDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+
+		outcome := signResult(ctx, rpcErr)
+		signsTotal.WithLabelValues(method, outcome).Inc()
+		if obs.keyID != uuid.Nil {
+			keySignsTotal.WithLabelValues(obs.keyID.String(), outcome).Inc()
+		}
+
+		return result, rpcErr
+	}
+}
+
+// signResult maps a handler error to a sign result label. Scope rejections
+// and backend policy denials are policy_denied; a missing organization or
+// credentials OpenBao rejected are auth_error.
+func signResult(ctx context.Context, err *Error) string {
+	if err == nil {
+		return signResultSuccess
+	}
+	if obs, ok := ctx.Value(signObservationKey{}).(*signObservation); ok && obs.outOfScope {
+		return signResultPolicyDenied
+	}
+	switch err.Code {
+	case UnauthorizedError, BackendAuthError:
+		return signResultAuthError
+	case BackendPolicyError:
+		return signResultPolicyDenied
+	default:
+		return signResultError
+	}
+}
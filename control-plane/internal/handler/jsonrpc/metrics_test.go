@@ -0,0 +1,84 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentSign_RecordsResults(t *testing.T) {
+	keyID := uuid.New()
+	method := "test_sign_" + uuid.NewString()
+
+	var inFlight float64
+	handler := instrumentSign(method, func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		inFlight = testutil.ToFloat64(signsInFlight)
+		observeSignKey(ctx, keyID)
+		switch string(params) {
+		case `"unauthorized"`:
+			return nil, ErrUnauthorized("missing organization context")
+		case `"out_of_scope"`:
+			return nil, errKeyOutOfScope(ctx, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e")
+		case `"backend_denied"`:
+			return nil, NewError(BackendPolicyError, "Signing backend policy denied the request", nil)
+		case `"failed"`:
+			return nil, ErrSigningFailed("openbao unavailable")
+		}
+		return "0xsig", nil
+	})
+
+	ctx := context.Background()
+	for _, p := range []string{`"ok"`, `"ok"`, `"unauthorized"`, `"out_of_scope"`, `"backend_denied"`, `"failed"`} {
+		_, _ = handler(ctx, json.RawMessage(p))
+	}
+
+	assert.Equal(t, float64(1), inFlight)
+	assert.Equal(t, float64(0), testutil.ToFloat64(signsInFlight))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(signsTotal.WithLabelValues(method, signResultSuccess)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(signsTotal.WithLabelValues(method, signResultAuthError)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(signsTotal.WithLabelValues(method, signResultPolicyDenied)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(signsTotal.WithLabelValues(method, signResultError)))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(keySignsTotal.WithLabelValues(keyID.String(), signResultSuccess)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(keySignsTotal.WithLabelValues(keyID.String(), signResultAuthError)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(keySignsTotal.WithLabelValues(keyID.String(), signResultPolicyDenied)))
+
+	var m dto.Metric
+	require.NoError(t, signDuration.WithLabelValues(method).(prometheus.Histogram).Write(&m))
+	assert.Equal(t, uint64(6), m.GetHistogram().GetSampleCount())
+}
+
+func TestServer_SignMetrics(t *testing.T) {
+	server := NewServer(ServerConfig{KeyRepo: &mockKeyRepoForServer{}})
+
+	before := testutil.ToFloat64(signsTotal.WithLabelValues("eth_sign", signResultAuthError))
+
+	body, _ := json.Marshal(Request{
+		JSONRPC: "2.0",
+		Method:  "eth_sign",
+		Params:  json.RawMessage(`["0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "0xdeadbeef"]`),
+		ID:      1,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, UnauthorizedError, resp.Error.Code)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(signsTotal.WithLabelValues("eth_sign", signResultAuthError)))
+}
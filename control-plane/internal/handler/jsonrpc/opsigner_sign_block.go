@@ -112,6 +112,7 @@ func (h *SignBlockPayloadHandler) Handle(ctx context.Context, params json.RawMes
 	signingInput = append(signingInput, chainIDBytes...)
 	signingInput = append(signingInput, arg.PayloadHash...)
 	signingHash := crypto.Keccak256(signingInput)
+	observeSignPayload(ctx, signingInput)
 
 	// Lookup key by sender address
 	senderAddr := arg.SenderAddress.Hex()
@@ -122,18 +123,22 @@ func (h *SignBlockPayloadHandler) Handle(ctx context.Context, params json.RawMes
 	if key == nil {
 		return nil, ErrResourceNotFound(fmt.Sprintf("no key found for address %s", senderAddr))
 	}
+	observeSignKey(ctx, key.ID)
+	if !middleware.AllowsSigningKey(ctx, key) {
+		return nil, errKeyOutOfScope(ctx, senderAddr)
+	}
 
 	// Sign via OpenBao (use chainID=0 for raw yParity)
 	hashB64 := base64.StdEncoding.EncodeToString(signingHash)
-	signResp, err := h.baoClient.SignEVM(key.BaoKeyPath, hashB64, 0)
+	signResp, err := h.baoClient.SignEVM(ctx, key.BaoKeyPath, hashB64, 0)
 	if err != nil {
-		return nil, ErrSigningFailed(err.Error())
+		return nil, signingError(ctx, err)
 	}
 
 	// Build 65-byte signature: r (32) + s (32) + v (1)
 	signature, err := buildSignature65(signResp)
 	if err != nil {
-		return nil, ErrInternal(fmt.Sprintf("failed to build signature: %v", err))
+		return nil, malformedSignatureError(ctx, err)
 	}
 
 	return hexutil.Encode(signature), nil
@@ -178,6 +183,7 @@ func (h *SignBlockPayloadHandler) HandleV2(ctx context.Context, params json.RawM
 	signingInput = append(signingInput, arg.ChainID...)
 	signingInput = append(signingInput, arg.PayloadHash...)
 	signingHash := crypto.Keccak256(signingInput)
+	observeSignPayload(ctx, signingInput)
 
 	// Lookup key by sender address
 	senderAddr := arg.SenderAddress.Hex()
@@ -188,18 +194,22 @@ func (h *SignBlockPayloadHandler) HandleV2(ctx context.Context, params json.RawM
 	if key == nil {
 		return nil, ErrResourceNotFound(fmt.Sprintf("no key found for address %s", senderAddr))
 	}
+	observeSignKey(ctx, key.ID)
+	if !middleware.AllowsSigningKey(ctx, key) {
+		return nil, errKeyOutOfScope(ctx, senderAddr)
+	}
 
 	// Sign via OpenBao
 	hashB64 := base64.StdEncoding.EncodeToString(signingHash)
-	signResp, err := h.baoClient.SignEVM(key.BaoKeyPath, hashB64, 0)
+	signResp, err := h.baoClient.SignEVM(ctx, key.BaoKeyPath, hashB64, 0)
 	if err != nil {
-		return nil, ErrSigningFailed(err.Error())
+		return nil, signingError(ctx, err)
 	}
 
 	// Build 65-byte signature
 	signature, err := buildSignature65(signResp)
 	if err != nil {
-		return nil, ErrInternal(fmt.Sprintf("failed to build signature: %v", err))
+		return nil, malformedSignatureError(ctx, err)
 	}
 
 	return hexutil.Encode(signature), nil
@@ -208,14 +218,9 @@ func (h *SignBlockPayloadHandler) HandleV2(ctx context.Context, params json.RawM
 // buildSignature65 constructs a 65-byte signature from OpenBao response.
 // Format: r (32 bytes) + s (32 bytes) + v (1 byte)
 func buildSignature65(signResp *openbao.SignEVMResponse) ([]byte, error) {
-	// Parse r and s from hex strings
-	rBytes, err := hexutil.Decode("0x" + signResp.R)
-	if err != nil {
-		return nil, fmt.Errorf("decode r: %w", err)
-	}
-	sBytes, err := hexutil.Decode("0x" + signResp.S)
+	rBytes, sBytes, err := decodeSignatureRS(signResp)
 	if err != nil {
-		return nil, fmt.Errorf("decode s: %w", err)
+		return nil, err
 	}
 
 	// Convert v to single byte (should be 0 or 1 for yParity)
@@ -0,0 +1,97 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Defaults for SignRetryConfig fields left at zero.
+const (
+	DefaultSignRetryInterval = 200 * time.Millisecond
+	DefaultSignRetryMaxQueue = 256
+)
+
+// SignRetryConfig controls how sign requests ride out brief OpenBao outages.
+type SignRetryConfig struct {
+	// GracePeriod is how long a sign request may be held waiting for OpenBao
+	// to recover. Zero disables retries.
+	GracePeriod time.Duration
+	// Interval is the delay between attempts. Defaults to DefaultSignRetryInterval.
+	Interval time.Duration
+	// MaxQueue caps how many sign requests may be held at once; requests
+	// beyond it fail immediately. Defaults to DefaultSignRetryMaxQueue.
+	MaxQueue int
+}
+
+// signRetryQueue holds sign requests that failed because OpenBao was
+// unavailable and retries them until it recovers or the grace period ends.
+type signRetryQueue struct {
+	grace    time.Duration
+	interval time.Duration
+	slots    chan struct{}
+}
+
+// newSignRetryQueue returns a retry queue for cfg, or nil if retries are disabled.
+func newSignRetryQueue(cfg SignRetryConfig) *signRetryQueue {
+	if cfg.GracePeriod <= 0 {
+		return nil
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultSignRetryInterval
+	}
+	if cfg.MaxQueue <= 0 {
+		cfg.MaxQueue = DefaultSignRetryMaxQueue
+	}
+	return &signRetryQueue{
+		grace:    cfg.GracePeriod,
+		interval: cfg.Interval,
+		slots:    make(chan struct{}, cfg.MaxQueue),
+	}
+}
+
+// wrap retries next while it fails with ResourceUnavail. The last error is
+// returned once the grace period expires, the request context is done, or
+// the queue is full. A nil queue returns next unchanged.
+func (q *signRetryQueue) wrap(next MethodHandler) MethodHandler {
+	if q == nil {
+		return next
+	}
+	return func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		result, rpcErr := next(ctx, params)
+		if !retriable(rpcErr) {
+			return result, rpcErr
+		}
+
+		select {
+		case q.slots <- struct{}{}:
+			defer func() { <-q.slots }()
+		default:
+			return nil, rpcErr
+		}
+
+		deadline := time.NewTimer(q.grace)
+		defer deadline.Stop()
+		ticker := time.NewTicker(q.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, rpcErr
+			case <-deadline.C:
+				return nil, rpcErr
+			case <-ticker.C:
+				result, rpcErr = next(ctx, params)
+				if !retriable(rpcErr) {
+					return result, rpcErr
+				}
+			}
+		}
+	}
+}
+
+// retriable reports whether a sign handler error may clear on its own.
+func retriable(err *Error) bool {
+	return err != nil && err.Code == ResourceUnavail
+}
@@ -0,0 +1,100 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+// flakySigner fails with an OpenBao outage until it has been called succeedOn times.
+func flakySigner(succeedOn int, calls *int) MethodHandler {
+	return func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		*calls++
+		if succeedOn > 0 && *calls >= succeedOn {
+			return "0xsig", nil
+		}
+		return nil, signingError(ctx, &openbao.StatusError{StatusCode: 503, Body: "sealed"})
+	}
+}
+
+func TestSignRetry_SucceedsWithinGrace(t *testing.T) {
+	q := newSignRetryQueue(SignRetryConfig{GracePeriod: 2 * time.Second, Interval: 10 * time.Millisecond})
+
+	var calls int
+	result, rpcErr := q.wrap(flakySigner(3, &calls))(context.Background(), nil)
+
+	require.Nil(t, rpcErr)
+	assert.Equal(t, "0xsig", result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestSignRetry_FailsAfterGrace(t *testing.T) {
+	q := newSignRetryQueue(SignRetryConfig{GracePeriod: 50 * time.Millisecond, Interval: 10 * time.Millisecond})
+
+	var calls int
+	start := time.Now()
+	_, rpcErr := q.wrap(flakySigner(0, &calls))(context.Background(), nil)
+
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ResourceUnavail, rpcErr.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Greater(t, calls, 1)
+}
+
+func TestSignRetry_RespectsContext(t *testing.T) {
+	q := newSignRetryQueue(SignRetryConfig{GracePeriod: time.Minute, Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	var calls int
+	start := time.Now()
+	_, rpcErr := q.wrap(flakySigner(0, &calls))(ctx, nil)
+
+	require.NotNil(t, rpcErr)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSignRetry_QueueFull(t *testing.T) {
+	q := newSignRetryQueue(SignRetryConfig{GracePeriod: time.Minute, MaxQueue: 1})
+	q.slots <- struct{}{}
+
+	var calls int
+	_, rpcErr := q.wrap(flakySigner(2, &calls))(context.Background(), nil)
+
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ResourceUnavail, rpcErr.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSignRetry_NonRetriableErrors(t *testing.T) {
+	q := newSignRetryQueue(SignRetryConfig{GracePeriod: time.Minute})
+
+	var calls int
+	_, rpcErr := q.wrap(func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		calls++
+		return nil, signingError(ctx, errors.New("signing failed: bad curve point"))
+	})(context.Background(), nil)
+
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, SigningError, rpcErr.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestSignRetry_DisabledByDefault(t *testing.T) {
+	assert.Nil(t, newSignRetryQueue(SignRetryConfig{}))
+
+	var calls int
+	var q *signRetryQueue
+	_, rpcErr := q.wrap(flakySigner(2, &calls))(context.Background(), nil)
+
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, 1, calls)
+}
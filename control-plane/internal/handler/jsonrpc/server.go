@@ -1,9 +1,12 @@
 package jsonrpc
 
 import (
+	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
@@ -15,6 +18,19 @@ type ServerConfig struct {
 	UsageRepo repository.UsageRepository
 	BaoClient *openbao.Client
 	Logger    *slog.Logger
+
+	// SignRetry lets sign requests wait out brief OpenBao outages.
+	// The zero value fails them immediately.
+	SignRetry SignRetryConfig
+
+	// Drainer, if set, stops new sign requests once the server starts
+	// draining for shutdown. Signs already in flight complete.
+	Drainer *middleware.Drainer
+
+	// MaxRequestBodySize caps the size of a request body in bytes, so huge
+	// messages are rejected before they reach the signer. Zero means
+	// DefaultMaxRequestBodySize.
+	MaxRequestBodySize int64
 }
 
 // Server is the JSON-RPC server with all methods registered.
@@ -26,6 +42,8 @@ type Server struct {
 // NewServer creates a new JSON-RPC server with all Ethereum methods registered.
 func NewServer(cfg ServerConfig) *Server {
 	handler := NewHandler(cfg.Logger)
+	handler.SetMaxBodySize(cfg.MaxRequestBodySize)
+	retry := newSignRetryQueue(cfg.SignRetry)
 
 	// Register health_status (required for OP Stack signer client initialization)
 	healthHandler := NewHealthStatusHandler()
@@ -36,20 +54,20 @@ func NewServer(cfg ServerConfig) *Server {
 	handler.RegisterMethod("eth_accounts", ethAccountsHandler.Handle)
 
 	// Register eth_signTransaction (required for op-batcher and op-proposer)
-	ethSignTxHandler := NewEthSignTransactionHandler(cfg.KeyRepo, cfg.BaoClient, cfg.AuditRepo, cfg.UsageRepo)
-	handler.RegisterMethod("eth_signTransaction", ethSignTxHandler.Handle)
+	ethSignTxHandler := NewEthSignTransactionHandler(cfg.KeyRepo, cfg.BaoClient, cfg.UsageRepo)
+	handler.RegisterMethod("eth_signTransaction", drainSign(cfg.Drainer, instrumentSign("eth_signTransaction", auditSign("eth_signTransaction", cfg.AuditRepo, retry.wrap(ethSignTxHandler.Handle)))))
 
 	// Register eth_sign
-	ethSignHandler := NewEthSignHandler(cfg.KeyRepo, cfg.BaoClient, cfg.AuditRepo, cfg.UsageRepo)
-	handler.RegisterMethod("eth_sign", ethSignHandler.HandleEthSign)
+	ethSignHandler := NewEthSignHandler(cfg.KeyRepo, cfg.BaoClient, cfg.UsageRepo)
+	handler.RegisterMethod("eth_sign", drainSign(cfg.Drainer, instrumentSign("eth_sign", auditSign("eth_sign", cfg.AuditRepo, retry.wrap(ethSignHandler.HandleEthSign)))))
 
 	// Register personal_sign
-	handler.RegisterMethod("personal_sign", ethSignHandler.HandlePersonalSign)
+	handler.RegisterMethod("personal_sign", drainSign(cfg.Drainer, instrumentSign("personal_sign", auditSign("personal_sign", cfg.AuditRepo, retry.wrap(ethSignHandler.HandlePersonalSign)))))
 
 	// Register OP Stack signer methods (required for op-node P2P sequencer)
 	signBlockHandler := NewSignBlockPayloadHandler(cfg.KeyRepo, cfg.BaoClient)
-	handler.RegisterMethod("opsigner_signBlockPayload", signBlockHandler.Handle)
-	handler.RegisterMethod("opsigner_signBlockPayloadV2", signBlockHandler.HandleV2)
+	handler.RegisterMethod("opsigner_signBlockPayload", drainSign(cfg.Drainer, instrumentSign("opsigner_signBlockPayload", auditSign("opsigner_signBlockPayload", cfg.AuditRepo, retry.wrap(signBlockHandler.Handle)))))
+	handler.RegisterMethod("opsigner_signBlockPayloadV2", drainSign(cfg.Drainer, instrumentSign("opsigner_signBlockPayloadV2", auditSign("opsigner_signBlockPayloadV2", cfg.AuditRepo, retry.wrap(signBlockHandler.HandleV2)))))
 
 	// Log registered methods
 	if cfg.Logger != nil {
@@ -93,3 +111,17 @@ func (s *Server) RegisteredMethods() []string {
 	return s.handler.RegisteredMethods()
 }
 
+// drainSign rejects new signs with ResourceUnavail while d is draining and
+// tracks the rest as in flight. A nil Drainer returns next unchanged.
+func drainSign(d *middleware.Drainer, next MethodHandler) MethodHandler {
+	if d == nil {
+		return next
+	}
+	return func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		if !d.Begin() {
+			return nil, NewError(ResourceUnavail, "Server is shutting down", nil)
+		}
+		defer d.Done()
+		return next(ctx, params)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,10 @@ func (m *mockKeyRepoForServer) Create(ctx context.Context, key *models.Key) erro
 	return nil
 }
 
+func (m *mockKeyRepoForServer) CreateBatch(ctx context.Context, keys []*models.Key) error {
+	return nil
+}
+
 func (m *mockKeyRepoForServer) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
 	return nil, nil
 }
@@ -46,6 +51,14 @@ func (m *mockKeyRepoForServer) ListByNamespace(ctx context.Context, namespaceID
 	return nil, nil
 }
 
+func (m *mockKeyRepoForServer) ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error) {
+	return nil, nil
+}
+
+func (m *mockKeyRepoForServer) ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error) {
+	return nil, 0, nil
+}
+
 func (m *mockKeyRepoForServer) CountByOrg(ctx context.Context, orgID uuid.UUID) (int, error) {
 	return 0, nil
 }
@@ -54,10 +67,30 @@ func (m *mockKeyRepoForServer) Update(ctx context.Context, key *models.Key) erro
 	return nil
 }
 
+func (m *mockKeyRepoForServer) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	return nil
+}
+
+func (m *mockKeyRepoForServer) MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	return nil
+}
+
+func (m *mockKeyRepoForServer) GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error) {
+	return nil, nil
+}
+
 func (m *mockKeyRepoForServer) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockKeyRepoForServer) Restore(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *mockKeyRepoForServer) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error) {
+	return nil, nil
+}
+
 func (m *mockKeyRepoForServer) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
@@ -182,3 +215,42 @@ func TestServer_IntegrationWithBaoClient(t *testing.T) {
 	require.NotNil(t, server)
 }
 
+
+func TestDrainSign(t *testing.T) {
+	d := middleware.NewDrainer()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	h := drainSign(d, func(ctx context.Context, params json.RawMessage) (interface{}, *Error) {
+		close(started)
+		<-release
+		return "0xsig", nil
+	})
+
+	type result struct {
+		res interface{}
+		err *Error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		res, err := h(context.Background(), nil)
+		inFlight <- result{res, err}
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- d.Drain(context.Background()) }()
+	require.Eventually(t, d.Draining, time.Second, time.Millisecond)
+
+	// New signs are rejected while draining
+	_, rpcErr := h(context.Background(), nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ResourceUnavail, rpcErr.Code)
+
+	// The in-flight sign completes and drain finishes after it
+	close(release)
+	r := <-inFlight
+	assert.Nil(t, r.err)
+	assert.Equal(t, "0xsig", r.res)
+	require.NoError(t, <-drained)
+}
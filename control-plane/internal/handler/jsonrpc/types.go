@@ -23,6 +23,12 @@ const (
 	SigningError      = -32020 // Signing operation failed
 	UnauthorizedError = -32021 // Not authorized for this operation
 	RateLimitError    = -32029 // Rate limit exceeded
+
+	// Signing backend failures. Backend outages use ResourceUnavail and keys
+	// missing from the backend use ResourceNotFound.
+	BackendAuthError        = -32022 // Backend rejected the gateway's credentials
+	BackendPolicyError      = -32023 // Backend policy denied the operation
+	MalformedSignatureError = -32024 // Backend returned a signature that isn't 64-byte r || s
 )
 
 // Request represents a JSON-RPC 2.0 request.
@@ -102,6 +108,11 @@ func ErrSigningFailed(data interface{}) *Error {
 	return NewError(SigningError, "Signing failed", data)
 }
 
+// ErrResourceUnavailable creates a resource temporarily unavailable error.
+func ErrResourceUnavailable(data interface{}) *Error {
+	return NewError(ResourceUnavail, "Resource temporarily unavailable", data)
+}
+
 // ErrRateLimit creates a rate limit error.
 func ErrRateLimit(data interface{}) *Error {
 	return NewError(RateLimitError, "Rate limit exceeded", data)
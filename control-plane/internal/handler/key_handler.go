@@ -2,10 +2,14 @@
 package handler
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -13,6 +17,7 @@ import (
 
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/address"
 	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
@@ -20,35 +25,49 @@ import (
 
 // KeyHandler handles key-related HTTP requests.
 type KeyHandler struct {
-	keyService service.KeyService
-	validate   *validator.Validate
+	keyService       service.KeyService
+	validate         *validator.Validate
+	signMiddleware   []func(http.Handler) http.Handler
+	createMiddleware []func(http.Handler) http.Handler
 }
 
-// NewKeyHandler creates a new key handler.
-func NewKeyHandler(keyService service.KeyService) *KeyHandler {
+// NewKeyHandler creates a new key handler. signMiddleware is applied to the
+// sign route only, e.g. to drain in-flight signs on shutdown.
+func NewKeyHandler(keyService service.KeyService, signMiddleware ...func(http.Handler) http.Handler) *KeyHandler {
 	return &KeyHandler{
-		keyService: keyService,
-		validate:   validator.New(),
+		keyService:     keyService,
+		validate:       validator.New(),
+		signMiddleware: signMiddleware,
 	}
 }
 
+// SetCreateMiddleware sets middleware applied to the key create routes only,
+// after their scope and role checks, e.g. idempotent retries.
+func (h *KeyHandler) SetCreateMiddleware(mw ...func(http.Handler) http.Handler) {
+	h.createMiddleware = mw
+}
+
 // Routes returns a chi router with key routes.
 func (h *KeyHandler) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	// Key CRUD operations
 	r.With(middleware.RequireScope("keys:read")).Get("/", h.List)
-	r.With(middleware.RequireScope("keys:write")).Post("/", h.Create)
-	r.With(middleware.RequireScope("keys:write")).Post("/batch", h.CreateBatch)
-	r.With(middleware.RequireScope("keys:read")).Get("/{id}", h.Get)
-	r.With(middleware.RequireScope("keys:write")).Delete("/{id}", h.Delete)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleOperator)).With(h.createMiddleware...).Post("/", h.Create)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleOperator)).With(h.createMiddleware...).Post("/batch", h.CreateBatch)
+	r.With(middleware.RequireScope("keys:read"), h.keyScope).Get("/{id}", h.Get)
+	r.With(middleware.RequireScope("keys:read"), h.keyScope).Get("/{id}/pubkey", h.PubKey)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleAdmin), h.keyScope).Delete("/{id}", h.Delete)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleAdmin), h.keyScope).Post("/{id}/restore", h.Restore)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleAdmin), h.keyScope).Post("/{id}/rotate", h.Rotate)
 
 	// Signing operations
-	r.With(middleware.RequireScope("keys:sign")).Post("/{id}/sign", h.Sign)
+	r.With(middleware.RequireScope("keys:sign"), middleware.RequireRole(models.RoleOperator)).With(h.signMiddleware...).Post("/{id}/sign", h.Sign)
 
 	// Import/Export operations
-	r.With(middleware.RequireScope("keys:write")).Post("/import", h.Import)
-	r.With(middleware.RequireScope("keys:export")).Post("/{id}/export", h.Export)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleOperator)).Post("/import", h.Import)
+	r.With(middleware.RequireScope("keys:export"), middleware.RequireRole(models.RoleAdmin), h.keyScope).Post("/{id}/export", h.Export)
+	r.With(middleware.RequireScope("keys:export"), middleware.RequireRole(models.RoleAdmin), h.keyScope).Post("/{id}/export-encrypted", h.ExportEncrypted)
 
 	return r
 }
@@ -112,6 +131,7 @@ func (h *KeyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, err)
 		return
 	}
+	middleware.AddKeysCreated(1)
 
 	response.Created(w, toKeyResponse(key))
 }
@@ -160,7 +180,7 @@ func (h *KeyHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	keys, err := h.keyService.CreateBatch(r.Context(), service.CreateBatchKeyRequest{
+	result, err := h.keyService.CreateBatch(r.Context(), service.CreateBatchKeyRequest{
 		OrgID:       orgID,
 		NamespaceID: namespaceID,
 		Prefix:      req.Prefix,
@@ -172,13 +192,19 @@ func (h *KeyHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.AddKeysCreated(len(result.Keys))
+
 	// Convert to response format
-	keyResponses := make([]*KeyResponse, len(keys))
-	for i, key := range keys {
+	keyResponses := make([]*KeyResponse, len(result.Keys))
+	for i, key := range result.Keys {
 		keyResponses[i] = toKeyResponse(key)
 	}
 
-	response.Created(w, map[string]any{"keys": keyResponses, "count": len(keyResponses)})
+	resp := map[string]any{"keys": keyResponses, "count": len(keyResponses)}
+	if len(result.Failed) > 0 {
+		resp["failed"] = result.Failed
+	}
+	response.Created(w, resp)
 }
 
 // List handles GET /v1/keys
@@ -207,11 +233,19 @@ func (h *KeyHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Paginated listing is opt-in so existing clients keep receiving all keys
+	q := r.URL.Query()
+	if q.Has("limit") || q.Has("cursor") || q.Has("name_prefix") || q.Has("label") {
+		h.listPage(w, r, orgID, nsID, networkType)
+		return
+	}
+
 	keys, err := h.keyService.List(r.Context(), orgID, nsID, networkType)
 	if err != nil {
 		response.Error(w, err)
 		return
 	}
+	keys = filterSigningScope(r.Context(), keys)
 
 	// Convert to response format
 	keyResponses := make([]*KeyResponse, len(keys))
@@ -222,6 +256,44 @@ func (h *KeyHandler) List(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, keyResponses)
 }
 
+// listPage handles GET /v1/keys with pagination and filter query parameters.
+func (h *KeyHandler) listPage(w http.ResponseWriter, r *http.Request, orgID uuid.UUID, nsID *uuid.UUID, networkType *models.NetworkType) {
+	q := r.URL.Query()
+	filter := service.KeyListFilter{
+		NamespaceID: nsID,
+		NetworkType: networkType,
+		NamePrefix:  q.Get("name_prefix"),
+		Label:       q.Get("label"),
+		Cursor:      q.Get("cursor"),
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > 100 {
+			response.Error(w, apierrors.NewValidationError("limit", "limit must be between 1 and 100"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	keys, nextCursor, err := h.keyService.ListPage(r.Context(), orgID, filter)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+	// Filtered after paging, so a page can come back short of the limit
+	// while there are still more keys
+	keys = filterSigningScope(r.Context(), keys)
+
+	// Convert to response format
+	keyResponses := make([]*KeyResponse, len(keys))
+	for i, key := range keys {
+		keyResponses[i] = toKeyResponse(key)
+	}
+
+	response.JSONWithMeta(w, http.StatusOK, keyResponses, &response.Meta{NextCursor: nextCursor})
+}
+
 // Get handles GET /v1/keys/{id}
 func (h *KeyHandler) Get(w http.ResponseWriter, r *http.Request) {
 	orgID := middleware.GetOrgIDFromContext(r.Context())
@@ -245,6 +317,70 @@ func (h *KeyHandler) Get(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, toKeyResponse(key))
 }
 
+// KeyAddressesResponse lists the encodings derived from a key's public key.
+type KeyAddressesResponse struct {
+	ID           uuid.UUID `json:"id"`
+	PublicKey    string    `json:"public_key"` // Hex encoded, compressed
+	Bech32Prefix string    `json:"bech32_prefix"`
+	Address      string    `json:"address"`     // Cosmos bech32 address
+	EthAddress   string    `json:"eth_address"` // EIP-55 checksummed
+}
+
+// PubKey handles GET /v1/keys/{id}/pubkey
+// The optional ?prefix= query parameter selects the bech32 prefix.
+func (h *KeyHandler) PubKey(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid key ID"))
+		return
+	}
+
+	prefix := r.URL.Query().Get("prefix")
+	if prefix == "" {
+		prefix = models.DefaultBech32Prefix
+	}
+	if err := h.validate.Var(prefix, "max=83,lowercase,alphanum"); err != nil {
+		response.Error(w, apierrors.NewValidationError("prefix", "must be lowercase alphanumeric, at most 83 characters"))
+		return
+	}
+
+	key, err := h.keyService.Get(r.Context(), orgID, keyID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	if key.Algorithm != models.AlgorithmSecp256k1 {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Address derivation is only supported for secp256k1 keys"))
+		return
+	}
+
+	cosmosAddr, err := address.Cosmos(prefix, key.PublicKey)
+	if err != nil {
+		response.Error(w, apierrors.ErrInternal.WithMessage("Stored public key is invalid"))
+		return
+	}
+	ethAddr, err := address.Ethereum(key.PublicKey)
+	if err != nil {
+		response.Error(w, apierrors.ErrInternal.WithMessage("Stored public key is invalid"))
+		return
+	}
+
+	response.OK(w, &KeyAddressesResponse{
+		ID:           key.ID,
+		PublicKey:    hex.EncodeToString(key.PublicKey),
+		Bech32Prefix: prefix,
+		Address:      cosmosAddr,
+		EthAddress:   ethAddr,
+	})
+}
+
 // Delete handles DELETE /v1/keys/{id}
 func (h *KeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	orgID := middleware.GetOrgIDFromContext(r.Context())
@@ -267,10 +403,74 @@ func (h *KeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w)
 }
 
+// Restore handles POST /v1/keys/{id}/restore
+func (h *KeyHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid key ID"))
+		return
+	}
+
+	key, err := h.keyService.Restore(r.Context(), orgID, keyID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, toKeyResponse(key))
+}
+
+// RotateHTTPRequest is the HTTP request body for rotating a key.
+type RotateHTTPRequest struct {
+	AllowPreviousVersionSigning bool `json:"allow_previous_version_signing"`
+}
+
+// Rotate handles POST /v1/keys/{id}/rotate
+func (h *KeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid key ID"))
+		return
+	}
+
+	// The body is optional; an empty body rotates with the default policy
+	var req RotateHTTPRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+			return
+		}
+	}
+
+	key, err := h.keyService.Rotate(r.Context(), orgID, keyID, service.RotateKeyOptions{
+		AllowPreviousVersionSigning: req.AllowPreviousVersionSigning,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, toKeyResponse(key))
+}
+
 // SignHTTPRequest is the HTTP request body for signing.
 type SignHTTPRequest struct {
 	Data      string `json:"data"`      // base64 encoded
 	Prehashed bool   `json:"prehashed"` // true if data is already hashed
+	// KeyVersion signs with a retired version; omit to use the current version.
+	KeyVersion int `json:"key_version,omitempty"`
 }
 
 // Sign handles POST /v1/keys/{id}/sign
@@ -304,7 +504,12 @@ func (h *KeyHandler) Sign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.keyService.Sign(r.Context(), orgID, keyID, data, req.Prehashed)
+	if err := checkSigningScope(r.Context(), h.keyService, orgID, keyID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	result, err := h.keyService.SignVersion(r.Context(), orgID, keyID, req.KeyVersion, data, req.Prehashed)
 	if err != nil {
 		response.Error(w, err)
 		return
@@ -313,6 +518,67 @@ func (h *KeyHandler) Sign(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, result)
 }
 
+// keyScope rejects requests for a key outside the signing scope of the
+// calling API key, so a scoped API key can only read and manage the keys it
+// may sign with. Requests without a valid key ID are left to the handler.
+// Soft-deleted keys can't be looked up, so only API keys listing them by ID
+// can restore them.
+func (h *KeyHandler) keyScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgID := middleware.GetOrgIDFromContext(r.Context())
+		keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if orgID == uuid.Nil || err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := checkSigningScope(r.Context(), h.keyService, orgID, keyID); err != nil {
+			response.Error(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// filterSigningScope drops keys outside the signing scope of the API key in
+// ctx, if any.
+func filterSigningScope(ctx context.Context, keys []*models.Key) []*models.Key {
+	apiKey := middleware.GetAPIKeyFromContext(ctx)
+	if apiKey == nil || !apiKey.RestrictsKeys() {
+		return keys
+	}
+	allowed := make([]*models.Key, 0, len(keys))
+	for _, key := range keys {
+		if apiKey.AllowsKey(key) {
+			allowed = append(allowed, key)
+		}
+	}
+	return allowed
+}
+
+// checkSigningScope rejects using a key outside the signing scope of
+// the request's API key. The key is only looked up for API keys scoped by
+// namespace.
+func checkSigningScope(ctx context.Context, keyService service.KeyService, orgID, keyID uuid.UUID) error {
+	apiKey := middleware.GetAPIKeyFromContext(ctx)
+	if apiKey == nil || !apiKey.RestrictsKeys() {
+		return nil
+	}
+	for _, id := range apiKey.AllowedKeyIDs {
+		if id == keyID {
+			return nil
+		}
+	}
+	key, err := keyService.Get(ctx, orgID, keyID)
+	if err != nil {
+		return err
+	}
+	if !apiKey.AllowsKey(key) {
+		return apierrors.ErrForbidden.WithMessage("API key is not allowed to use key " + keyID.String())
+	}
+	return nil
+}
+
 // ImportHTTPRequest is the HTTP request body for importing a key.
 type ImportHTTPRequest struct {
 	NamespaceID string `json:"namespace_id"`
@@ -374,6 +640,7 @@ func (h *KeyHandler) Import(w http.ResponseWriter, r *http.Request) {
 		response.Error(w, err)
 		return
 	}
+	middleware.AddKeysCreated(1)
 
 	response.Created(w, toKeyResponse(key))
 }
@@ -404,6 +671,48 @@ func (h *KeyHandler) Export(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ExportEncryptedHTTPRequest is the HTTP request body for an encrypted export.
+type ExportEncryptedHTTPRequest struct {
+	Recipient string `json:"recipient"` // age X25519 public key (age1...)
+}
+
+// ExportEncrypted handles POST /v1/keys/{id}/export-encrypted
+func (h *KeyHandler) ExportEncrypted(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid key ID"))
+		return
+	}
+
+	var req ExportEncryptedHTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	if req.Recipient == "" {
+		response.Error(w, apierrors.NewValidationError("recipient", "recipient is required"))
+		return
+	}
+
+	ciphertext, err := h.keyService.ExportEncrypted(r.Context(), orgID, keyID, req.Recipient)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, map[string]string{
+		"ciphertext": ciphertext,
+		"format":     "age",
+	})
+}
+
 // KeyResponse is the API response format for keys.
 type KeyResponse struct {
 	ID          uuid.UUID              `json:"id"`
@@ -414,10 +723,13 @@ type KeyResponse struct {
 	EthAddress  string                 `json:"eth_address,omitempty"` // 0x... address
 	NetworkType models.NetworkType     `json:"network_type"`
 	Algorithm   models.Algorithm       `json:"algorithm"`
+	Curve       string                 `json:"curve,omitempty"`
 	Exportable  bool                   `json:"exportable"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	Version     int                    `json:"version"`
 	CreatedAt   string                 `json:"created_at"`
+	RotatedAt   *string                `json:"rotated_at,omitempty"`
+	LastUsedAt  *string                `json:"last_used_at,omitempty"`
 }
 
 // toKeyResponse converts a Key model to a KeyResponse.
@@ -441,10 +753,22 @@ func toKeyResponse(key *models.Key) *KeyResponse {
 		EthAddress:  ethAddr,
 		NetworkType: key.NetworkType,
 		Algorithm:   key.Algorithm,
+		Curve:       key.Algorithm.Curve(),
 		Exportable:  key.Exportable,
 		Metadata:    metadata,
 		Version:     key.Version,
 		CreatedAt:   key.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		RotatedAt:   formatOptionalTime(key.RotatedAt),
+		LastUsedAt:  formatOptionalTime(key.LastUsedAt),
+	}
+}
+
+// formatOptionalTime formats t like KeyResponse.CreatedAt, preserving nil.
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
+	s := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &s
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -22,14 +23,19 @@ import (
 // mockKeyService is a mock implementation of KeyService for testing.
 type mockKeyService struct {
 	createFunc      func(ctx context.Context, req service.CreateKeyRequest) (*models.Key, error)
-	createBatchFunc func(ctx context.Context, req service.CreateBatchKeyRequest) ([]*models.Key, error)
+	createBatchFunc func(ctx context.Context, req service.CreateBatchKeyRequest) (*service.CreateBatchKeyResult, error)
 	getFunc         func(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error)
 	listFunc        func(ctx context.Context, orgID uuid.UUID, namespaceID *uuid.UUID, networkType *models.NetworkType) ([]*models.Key, error)
+	listPageFunc    func(ctx context.Context, orgID uuid.UUID, filter service.KeyListFilter) ([]*models.Key, string, error)
 	deleteFunc      func(ctx context.Context, orgID, keyID uuid.UUID) error
+	restoreFunc     func(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error)
 	signFunc        func(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*service.SignKeyResponse, error)
+	signVersionFunc func(ctx context.Context, orgID, keyID uuid.UUID, version int, data []byte, prehashed bool) (*service.SignKeyResponse, error)
+	rotateFunc      func(ctx context.Context, orgID, keyID uuid.UUID, opts service.RotateKeyOptions) (*models.Key, error)
 	signBatchFunc   func(ctx context.Context, req service.SignBatchKeyRequest) ([]*service.SignKeyResponse, error)
 	importFunc      func(ctx context.Context, req service.ImportKeyRequest) (*models.Key, error)
 	exportFunc      func(ctx context.Context, orgID, keyID uuid.UUID) (string, error)
+	exportEncFunc   func(ctx context.Context, orgID, keyID uuid.UUID, recipient string) (string, error)
 }
 
 func (m *mockKeyService) Create(ctx context.Context, req service.CreateKeyRequest) (*models.Key, error) {
@@ -39,7 +45,7 @@ func (m *mockKeyService) Create(ctx context.Context, req service.CreateKeyReques
 	return nil, nil
 }
 
-func (m *mockKeyService) CreateBatch(ctx context.Context, req service.CreateBatchKeyRequest) ([]*models.Key, error) {
+func (m *mockKeyService) CreateBatch(ctx context.Context, req service.CreateBatchKeyRequest) (*service.CreateBatchKeyResult, error) {
 	if m.createBatchFunc != nil {
 		return m.createBatchFunc(ctx, req)
 	}
@@ -60,6 +66,17 @@ func (m *mockKeyService) List(ctx context.Context, orgID uuid.UUID, namespaceID
 	return nil, nil
 }
 
+func (m *mockKeyService) ListPage(ctx context.Context, orgID uuid.UUID, filter service.KeyListFilter) ([]*models.Key, string, error) {
+	if m.listPageFunc != nil {
+		return m.listPageFunc(ctx, orgID, filter)
+	}
+	return nil, "", nil
+}
+
+func (m *mockKeyService) ListByOrgPaged(ctx context.Context, orgID uuid.UUID, filter service.KeyPageFilter) (*service.KeyPage, error) {
+	return &service.KeyPage{Page: 1, Size: service.DefaultKeyPageSize}, nil
+}
+
 func (m *mockKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, orgID, keyID)
@@ -67,6 +84,17 @@ func (m *mockKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) err
 	return nil
 }
 
+func (m *mockKeyService) Restore(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error) {
+	if m.restoreFunc != nil {
+		return m.restoreFunc(ctx, orgID, keyID)
+	}
+	return nil, nil
+}
+
+func (m *mockKeyService) PurgeDeletedKeys(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockKeyService) Sign(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*service.SignKeyResponse, error) {
 	if m.signFunc != nil {
 		return m.signFunc(ctx, orgID, keyID, data, prehashed)
@@ -74,6 +102,20 @@ func (m *mockKeyService) Sign(ctx context.Context, orgID, keyID uuid.UUID, data
 	return nil, nil
 }
 
+func (m *mockKeyService) SignVersion(ctx context.Context, orgID, keyID uuid.UUID, version int, data []byte, prehashed bool) (*service.SignKeyResponse, error) {
+	if m.signVersionFunc != nil {
+		return m.signVersionFunc(ctx, orgID, keyID, version, data, prehashed)
+	}
+	return m.Sign(ctx, orgID, keyID, data, prehashed)
+}
+
+func (m *mockKeyService) Rotate(ctx context.Context, orgID, keyID uuid.UUID, opts service.RotateKeyOptions) (*models.Key, error) {
+	if m.rotateFunc != nil {
+		return m.rotateFunc(ctx, orgID, keyID, opts)
+	}
+	return nil, nil
+}
+
 func (m *mockKeyService) SignBatch(ctx context.Context, req service.SignBatchKeyRequest) ([]*service.SignKeyResponse, error) {
 	if m.signBatchFunc != nil {
 		return m.signBatchFunc(ctx, req)
@@ -95,6 +137,13 @@ func (m *mockKeyService) Export(ctx context.Context, orgID, keyID uuid.UUID) (st
 	return "", nil
 }
 
+func (m *mockKeyService) ExportEncrypted(ctx context.Context, orgID, keyID uuid.UUID, recipient string) (string, error) {
+	if m.exportEncFunc != nil {
+		return m.exportEncFunc(ctx, orgID, keyID, recipient)
+	}
+	return "", nil
+}
+
 // createKeyTestRequest creates a request with org ID in context
 func createKeyTestRequest(t *testing.T, method, path string, body interface{}, orgID uuid.UUID) *http.Request {
 	t.Helper()
@@ -246,7 +295,7 @@ func TestKeyHandler_CreateBatch(t *testing.T) {
 				Count:       4,
 			},
 			mockService: &mockKeyService{
-				createBatchFunc: func(ctx context.Context, req service.CreateBatchKeyRequest) ([]*models.Key, error) {
+				createBatchFunc: func(ctx context.Context, req service.CreateBatchKeyRequest) (*service.CreateBatchKeyResult, error) {
 					keys := make([]*models.Key, req.Count)
 					for i := 0; i < req.Count; i++ {
 						keys[i] = &models.Key{
@@ -261,7 +310,7 @@ func TestKeyHandler_CreateBatch(t *testing.T) {
 							CreatedAt:   time.Now(),
 						}
 					}
-					return keys, nil
+					return &service.CreateBatchKeyResult{Keys: keys}, nil
 				},
 			},
 			expectedStatus: http.StatusCreated,
@@ -328,6 +377,48 @@ func TestKeyHandler_CreateBatch(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_CreateBatch_PartialFailure(t *testing.T) {
+	orgID := uuid.New()
+	mockService := &mockKeyService{
+		createBatchFunc: func(ctx context.Context, req service.CreateBatchKeyRequest) (*service.CreateBatchKeyResult, error) {
+			return &service.CreateBatchKeyResult{
+				Keys: []*models.Key{
+					{ID: uuid.New(), OrgID: req.OrgID, Name: "worker-1", Address: "celestia1one"},
+					{ID: uuid.New(), OrgID: req.OrgID, Name: "worker-3", Address: "celestia1three"},
+				},
+				Failed: []service.CreateBatchKeyFailure{{Name: "worker-2", Error: "failed to create key in OpenBao"}},
+			}, nil
+		},
+	}
+	handler := NewKeyHandler(mockService)
+
+	body := CreateBatchHTTPRequest{NamespaceID: uuid.New().String(), Prefix: "worker", Count: 3}
+	req := createKeyTestRequest(t, http.MethodPost, "/v1/keys/batch", body, orgID)
+	rec := httptest.NewRecorder()
+	handler.CreateBatch(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Status = %d, want %d. Body: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			Keys   []*KeyResponse                  `json:"keys"`
+			Count  int                             `json:"count"`
+			Failed []service.CreateBatchKeyFailure `json:"failed"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Data.Count != 2 || len(resp.Data.Keys) != 2 {
+		t.Errorf("Count = %d, want 2", resp.Data.Count)
+	}
+	if len(resp.Data.Failed) != 1 || resp.Data.Failed[0].Name != "worker-2" {
+		t.Errorf("Failed = %v, want [worker-2]", resp.Data.Failed)
+	}
+}
+
 func TestKeyHandler_List(t *testing.T) {
 	orgID := uuid.New()
 
@@ -399,6 +490,70 @@ func TestKeyHandler_List(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_ListPage(t *testing.T) {
+	orgID := uuid.New()
+
+	var gotFilter service.KeyListFilter
+	handler := NewKeyHandler(&mockKeyService{
+		listPageFunc: func(ctx context.Context, oID uuid.UUID, filter service.KeyListFilter) ([]*models.Key, string, error) {
+			gotFilter = filter
+			return []*models.Key{
+				{ID: uuid.New(), Name: "worker-1", PublicKey: []byte{0x02}, CreatedAt: time.Now()},
+			}, "next-page", nil
+		},
+	})
+
+	req := createKeyTestRequest(t, http.MethodGet, "/v1/keys?limit=1&cursor=abc&name_prefix=worker-&label=env%3Dprod", nil, orgID)
+	rec := httptest.NewRecorder()
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotFilter.Limit != 1 || gotFilter.Cursor != "abc" || gotFilter.NamePrefix != "worker-" || gotFilter.Label != "env=prod" {
+		t.Errorf("unexpected filter: %+v", gotFilter)
+	}
+
+	var resp struct {
+		Data []*KeyResponse `json:"data"`
+		Meta struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(resp.Data) != 1 {
+		t.Errorf("Response count = %d, want 1", len(resp.Data))
+	}
+	if resp.Meta.NextCursor != "next-page" {
+		t.Errorf("NextCursor = %q, want %q", resp.Meta.NextCursor, "next-page")
+	}
+
+	t.Run("applies network filter to pages", func(t *testing.T) {
+		req := createKeyTestRequest(t, http.MethodGet, "/v1/keys?network=evm&limit=50", nil, orgID)
+		rec := httptest.NewRecorder()
+		handler.List(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if gotFilter.NetworkType == nil || *gotFilter.NetworkType != models.NetworkTypeEVM {
+			t.Errorf("NetworkType = %v, want %q", gotFilter.NetworkType, models.NetworkTypeEVM)
+		}
+	})
+
+	t.Run("rejects invalid limit", func(t *testing.T) {
+		req := createKeyTestRequest(t, http.MethodGet, "/v1/keys?limit=500", nil, orgID)
+		rec := httptest.NewRecorder()
+		handler.List(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
+
 func TestKeyHandler_Get(t *testing.T) {
 	orgID := uuid.New()
 	keyID := uuid.New()
@@ -467,6 +622,135 @@ func TestKeyHandler_Get(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_PubKey(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+
+	// Public key of the secp256k1 private key 1
+	pubKey, _ := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	svc := &mockKeyService{
+		getFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+			return &models.Key{ID: kID, OrgID: oID, PublicKey: pubKey, Algorithm: models.AlgorithmSecp256k1}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		mockService    *mockKeyService
+		expectedStatus int
+		wantAddress    string
+	}{
+		{
+			name:           "defaults to the celestia prefix",
+			mockService:    svc,
+			expectedStatus: http.StatusOK,
+			wantAddress:    "celestia1w508d6qejxtdg4y5r3zarvary0c5xw7kthx244",
+		},
+		{
+			name:           "uses the requested prefix",
+			query:          "?prefix=cosmos",
+			mockService:    svc,
+			expectedStatus: http.StatusOK,
+			wantAddress:    "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c",
+		},
+		{
+			name:           "rejects an invalid prefix",
+			query:          "?prefix=Cosmos",
+			mockService:    svc,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "rejects ed25519 keys",
+			mockService: &mockKeyService{
+				getFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+					return &models.Key{ID: kID, Algorithm: models.AlgorithmEd25519}, nil
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewKeyHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, http.MethodGet, "/v1/keys/"+keyID.String()+"/pubkey"+tt.query, nil, orgID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", keyID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.PubKey(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Status = %d, want %d: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Data KeyAddressesResponse `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Data.Address != tt.wantAddress {
+				t.Errorf("Address = %s, want %s", resp.Data.Address, tt.wantAddress)
+			}
+			if resp.Data.EthAddress != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+				t.Errorf("EthAddress = %s", resp.Data.EthAddress)
+			}
+			if resp.Data.PublicKey != hex.EncodeToString(pubKey) {
+				t.Errorf("PublicKey = %s", resp.Data.PublicKey)
+			}
+		})
+	}
+}
+
+func TestToKeyResponse_UsageTimestamps(t *testing.T) {
+	key := &models.Key{ID: uuid.New(), Name: "test-key", CreatedAt: time.Now()}
+
+	resp := toKeyResponse(key)
+	if resp.RotatedAt != nil || resp.LastUsedAt != nil {
+		t.Errorf("RotatedAt = %v, LastUsedAt = %v, want nil", resp.RotatedAt, resp.LastUsedAt)
+	}
+
+	rotated := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	used := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	key.RotatedAt = &rotated
+	key.LastUsedAt = &used
+
+	resp = toKeyResponse(key)
+	if resp.RotatedAt == nil || *resp.RotatedAt != "2024-02-01T00:00:00Z" {
+		t.Errorf("RotatedAt = %v, want 2024-02-01T00:00:00Z", resp.RotatedAt)
+	}
+	if resp.LastUsedAt == nil || *resp.LastUsedAt != "2024-03-01T12:30:00Z" {
+		t.Errorf("LastUsedAt = %v, want 2024-03-01T12:30:00Z", resp.LastUsedAt)
+	}
+}
+
+func TestToKeyResponse_AlgorithmAndCurve(t *testing.T) {
+	key := &models.Key{ID: uuid.New(), Name: "test-key", Algorithm: models.AlgorithmSecp256k1, CreatedAt: time.Now()}
+
+	body, err := json.Marshal(toKeyResponse(key))
+	if err != nil {
+		t.Fatalf("marshal response: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got["algorithm"] != "secp256k1" {
+		t.Errorf("algorithm = %v, want secp256k1", got["algorithm"])
+	}
+	if got["curve"] != "secp256k1" {
+		t.Errorf("curve = %v, want secp256k1", got["curve"])
+	}
+}
+
 func TestKeyHandler_Delete(t *testing.T) {
 	orgID := uuid.New()
 	keyID := uuid.New()
@@ -525,6 +809,161 @@ func TestKeyHandler_Delete(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_Restore(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		keyIDParam     string
+		mockService    *mockKeyService
+		expectedStatus int
+	}{
+		{
+			name:       "restores key successfully",
+			keyIDParam: keyID.String(),
+			mockService: &mockKeyService{
+				restoreFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+					return &models.Key{ID: kID, OrgID: oID, Name: "restored"}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "returns 404 for key past its grace period",
+			keyIDParam: keyID.String(),
+			mockService: &mockKeyService{
+				restoreFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+					return nil, apierrors.NewNotFoundError("Key")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:       "returns 409 when the name was reused",
+			keyIDParam: keyID.String(),
+			mockService: &mockKeyService{
+				restoreFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+					return nil, apierrors.NewConflictError("a key named 'restored' already exists in this namespace")
+				},
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "rejects invalid UUID",
+			keyIDParam:     "not-a-uuid",
+			mockService:    &mockKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewKeyHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, http.MethodPost, "/v1/keys/"+tt.keyIDParam+"/restore", nil, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.keyIDParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.Restore(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestKeyHandler_Rotate(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		keyIDParam     string
+		body           interface{}
+		mockService    *mockKeyService
+		expectedStatus int
+	}{
+		{
+			name:       "rotates key and forwards signing policy",
+			keyIDParam: keyID.String(),
+			body:       RotateHTTPRequest{AllowPreviousVersionSigning: true},
+			mockService: &mockKeyService{
+				rotateFunc: func(ctx context.Context, oID, kID uuid.UUID, opts service.RotateKeyOptions) (*models.Key, error) {
+					if !opts.AllowPreviousVersionSigning {
+						return nil, apierrors.ErrBadRequest
+					}
+					return &models.Key{ID: kID, OrgID: oID, Name: "rotated", Version: 2}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "rotates key without a body",
+			keyIDParam: keyID.String(),
+			mockService: &mockKeyService{
+				rotateFunc: func(ctx context.Context, oID, kID uuid.UUID, opts service.RotateKeyOptions) (*models.Key, error) {
+					if opts.AllowPreviousVersionSigning {
+						return nil, apierrors.ErrBadRequest
+					}
+					return &models.Key{ID: kID, OrgID: oID, Name: "rotated", Version: 2}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "returns 404 for nonexistent key",
+			keyIDParam: uuid.New().String(),
+			mockService: &mockKeyService{
+				rotateFunc: func(ctx context.Context, oID, kID uuid.UUID, opts service.RotateKeyOptions) (*models.Key, error) {
+					return nil, apierrors.NewNotFoundError("Key")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid UUID",
+			keyIDParam:     "not-a-uuid",
+			mockService:    &mockKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewKeyHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, http.MethodPost, "/v1/keys/"+tt.keyIDParam+"/rotate", tt.body, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.keyIDParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.Rotate(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d, body = %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusOK {
+				var resp struct {
+					Data KeyResponse `json:"data"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("Failed to decode response: %v", err)
+				}
+				if resp.Data.Version != 2 {
+					t.Errorf("Version = %d, want 2", resp.Data.Version)
+				}
+			}
+		})
+	}
+}
+
 func TestKeyHandler_Sign(t *testing.T) {
 	orgID := uuid.New()
 	keyID := uuid.New()
@@ -613,6 +1052,58 @@ func TestKeyHandler_Sign(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_SignSigningScope(t *testing.T) {
+	orgID := uuid.New()
+	batcher := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New()}
+	proposer := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New()}
+	keys := map[uuid.UUID]*models.Key{batcher.ID: batcher, proposer.ID: proposer}
+
+	signed := 0
+	mockService := &mockKeyService{
+		getFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+			return keys[kID], nil
+		},
+		signFunc: func(ctx context.Context, oID, kID uuid.UUID, data []byte, prehashed bool) (*service.SignKeyResponse, error) {
+			signed++
+			return &service.SignKeyResponse{KeyID: kID}, nil
+		},
+	}
+	handler := NewKeyHandler(mockService)
+	apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, Scopes: []string{"keys:sign"}, AllowedKeyIDs: []uuid.UUID{batcher.ID}}
+
+	tests := []struct {
+		name           string
+		key            *models.Key
+		expectedStatus int
+	}{
+		{"in-scope key signs", batcher, http.StatusOK},
+		{"out-of-scope key is rejected", proposer, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed = 0
+			body := SignHTTPRequest{Data: base64.StdEncoding.EncodeToString([]byte("hello world"))}
+			req := createKeyTestRequest(t, http.MethodPost, "/v1/keys/"+tt.key.ID.String()+"/sign", body, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.key.ID.String())
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, middleware.APIKeyContextKey, apiKey))
+
+			rec := httptest.NewRecorder()
+			handler.Sign(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if wantSigned := tt.expectedStatus == http.StatusOK; (signed == 1) != wantSigned {
+				t.Errorf("signed %d times, want signed = %v", signed, wantSigned)
+			}
+		})
+	}
+}
+
 func TestKeyHandler_Import(t *testing.T) {
 	orgID := uuid.New()
 	namespaceID := uuid.New()
@@ -757,6 +1248,64 @@ func TestKeyHandler_Export(t *testing.T) {
 	}
 }
 
+func TestKeyHandler_ExportEncrypted(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockService    *mockKeyService
+		expectedStatus int
+	}{
+		{
+			name: "exports encrypted key successfully",
+			body: ExportEncryptedHTTPRequest{Recipient: "age1recipient"},
+			mockService: &mockKeyService{
+				exportEncFunc: func(ctx context.Context, oID, kID uuid.UUID, recipient string) (string, error) {
+					return "-----BEGIN AGE ENCRYPTED FILE-----", nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "returns 400 without recipient",
+			body:           ExportEncryptedHTTPRequest{},
+			mockService:    &mockKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "returns 403 for non-exportable key",
+			body: ExportEncryptedHTTPRequest{Recipient: "age1recipient"},
+			mockService: &mockKeyService{
+				exportEncFunc: func(ctx context.Context, oID, kID uuid.UUID, recipient string) (string, error) {
+					return "", apierrors.ErrForbidden.WithMessage("Key is not exportable")
+				},
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewKeyHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, http.MethodPost, "/v1/keys/"+keyID.String()+"/export-encrypted", tt.body, orgID)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", keyID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.ExportEncrypted(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
 func TestKeyHandler_Routes(t *testing.T) {
 	mockService := &mockKeyService{}
 	handler := NewKeyHandler(mockService)
@@ -781,3 +1330,210 @@ func TestKeyHandler_Unauthorized(t *testing.T) {
 	}
 }
 
+
+// staticMembers is a middleware.MemberLookup with fixed roles.
+type staticMembers map[uuid.UUID]models.Role
+
+func (m staticMembers) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMember, error) {
+	role, ok := m[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &models.OrgMember{OrgID: orgID, UserID: userID, Role: role}, nil
+}
+
+func TestKeyHandler_Roles(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+	namespaceID := uuid.New()
+	viewerID, operatorID, adminID, formerID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	members := staticMembers{
+		viewerID:   models.RoleViewer,
+		operatorID: models.RoleOperator,
+		adminID:    models.RoleAdmin,
+	}
+
+	mockService := &mockKeyService{
+		createFunc: func(ctx context.Context, req service.CreateKeyRequest) (*models.Key, error) {
+			return &models.Key{ID: keyID, OrgID: req.OrgID, NamespaceID: req.NamespaceID, Name: req.Name, Version: 1}, nil
+		},
+		listFunc: func(ctx context.Context, orgID uuid.UUID, namespaceID *uuid.UUID, networkType *models.NetworkType) ([]*models.Key, error) {
+			return []*models.Key{}, nil
+		},
+		deleteFunc: func(ctx context.Context, orgID, keyID uuid.UUID) error {
+			return nil
+		},
+		signFunc: func(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*service.SignKeyResponse, error) {
+			return &service.SignKeyResponse{KeyID: keyID, Signature: "c2ln", PublicKey: "00"}, nil
+		},
+	}
+
+	router := chi.NewRouter()
+	router.Use(middleware.ResolveOrgRole(members))
+	router.Mount("/v1/keys", NewKeyHandler(mockService).Routes())
+
+	createBody := CreateKeyHTTPRequest{NamespaceID: namespaceID.String(), Name: "rbac-key"}
+	signBody := SignHTTPRequest{Data: "aGVsbG8="}
+	keyPath := "/v1/keys/" + keyID.String()
+
+	tests := []struct {
+		name           string
+		userID         *uuid.UUID
+		method         string
+		path           string
+		body           interface{}
+		expectedStatus int
+	}{
+		{"viewer can list", &viewerID, http.MethodGet, "/v1/keys", nil, http.StatusOK},
+		{"viewer cannot create", &viewerID, http.MethodPost, "/v1/keys", createBody, http.StatusForbidden},
+		{"viewer cannot delete", &viewerID, http.MethodDelete, keyPath, nil, http.StatusForbidden},
+		{"viewer cannot sign", &viewerID, http.MethodPost, keyPath + "/sign", signBody, http.StatusForbidden},
+		{"operator can sign", &operatorID, http.MethodPost, keyPath + "/sign", signBody, http.StatusOK},
+		{"operator can create", &operatorID, http.MethodPost, "/v1/keys", createBody, http.StatusCreated},
+		{"operator cannot delete", &operatorID, http.MethodDelete, keyPath, nil, http.StatusForbidden},
+		{"admin can create", &adminID, http.MethodPost, "/v1/keys", createBody, http.StatusCreated},
+		{"admin can delete", &adminID, http.MethodDelete, keyPath, nil, http.StatusNoContent},
+		{"former member is rejected", &formerID, http.MethodGet, "/v1/keys", nil, http.StatusForbidden},
+		{"ownerless key can sign", nil, http.MethodPost, keyPath + "/sign", signBody, http.StatusOK},
+		{"ownerless key cannot delete", nil, http.MethodDelete, keyPath, nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := createKeyTestRequest(t, tt.method, tt.path, tt.body, orgID)
+			apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, UserID: tt.userID, Scopes: []string{"*"}}
+			req = req.WithContext(context.WithValue(req.Context(), middleware.APIKeyContextKey, apiKey))
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d; body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestKeyHandler_CreateMiddleware(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+
+	mockService := &mockKeyService{
+		createFunc: func(ctx context.Context, req service.CreateKeyRequest) (*models.Key, error) {
+			return &models.Key{ID: keyID, OrgID: req.OrgID, NamespaceID: req.NamespaceID, Name: req.Name, Version: 1}, nil
+		},
+		signFunc: func(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*service.SignKeyResponse, error) {
+			return &service.SignKeyResponse{KeyID: keyID, Signature: "c2ln", PublicKey: "00"}, nil
+		},
+	}
+
+	var wrapped []string
+	h := NewKeyHandler(mockService)
+	h.SetCreateMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapped = append(wrapped, r.URL.Path)
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	adminID := uuid.New()
+	router := chi.NewRouter()
+	router.Use(middleware.ResolveOrgRole(staticMembers{adminID: models.RoleAdmin}))
+	router.Mount("/v1/keys", h.Routes())
+
+	createBody := CreateKeyHTTPRequest{NamespaceID: uuid.New().String(), Name: "idem-key"}
+	for _, tc := range []struct {
+		path string
+		body interface{}
+	}{
+		{"/v1/keys", createBody},
+		{"/v1/keys/" + keyID.String() + "/sign", SignHTTPRequest{Data: "aGVsbG8="}},
+		{"/v1/keys/" + keyID.String() + "/export", nil},
+	} {
+		req := createKeyTestRequest(t, http.MethodPost, tc.path, tc.body, orgID)
+		apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, UserID: &adminID, Scopes: []string{"*"}}
+		req = req.WithContext(context.WithValue(req.Context(), middleware.APIKeyContextKey, apiKey))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Only key creation is wrapped; sign and export responses must never be
+	// stored for replay
+	if len(wrapped) != 1 || wrapped[0] != "/v1/keys" {
+		t.Errorf("create middleware ran for %v, want only /v1/keys", wrapped)
+	}
+}
+
+func TestKeyHandler_SigningScopeOnKeyRoutes(t *testing.T) {
+	orgID := uuid.New()
+	adminID := uuid.New()
+	batcher := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), Name: "batcher", Version: 1}
+	proposer := &models.Key{ID: uuid.New(), OrgID: orgID, NamespaceID: uuid.New(), Name: "proposer", Version: 1}
+	keys := map[uuid.UUID]*models.Key{batcher.ID: batcher, proposer.ID: proposer}
+
+	deleted := 0
+	mockService := &mockKeyService{
+		getFunc: func(ctx context.Context, oID, kID uuid.UUID) (*models.Key, error) {
+			if key, ok := keys[kID]; ok {
+				return key, nil
+			}
+			return nil, apierrors.NewNotFoundError("Key")
+		},
+		listFunc: func(ctx context.Context, oID uuid.UUID, namespaceID *uuid.UUID, networkType *models.NetworkType) ([]*models.Key, error) {
+			return []*models.Key{batcher, proposer}, nil
+		},
+		deleteFunc: func(ctx context.Context, oID, kID uuid.UUID) error {
+			deleted++
+			return nil
+		},
+	}
+
+	router := chi.NewRouter()
+	router.Use(middleware.ResolveOrgRole(staticMembers{adminID: models.RoleAdmin}))
+	router.Mount("/v1/keys", NewKeyHandler(mockService).Routes())
+
+	apiKey := &models.APIKey{ID: uuid.New(), OrgID: orgID, UserID: &adminID, Scopes: []string{"*"}, AllowedKeyIDs: []uuid.UUID{batcher.ID}}
+	send := func(method, path string) *httptest.ResponseRecorder {
+		req := createKeyTestRequest(t, method, path, nil, orgID)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.APIKeyContextKey, apiKey))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, tc := range []struct {
+		method string
+		suffix string
+	}{
+		{http.MethodGet, ""},
+		{http.MethodGet, "/pubkey"},
+		{http.MethodDelete, ""},
+		{http.MethodPost, "/restore"},
+		{http.MethodPost, "/rotate"},
+		{http.MethodPost, "/export"},
+		{http.MethodPost, "/export-encrypted"},
+	} {
+		rec := send(tc.method, "/v1/keys/"+proposer.ID.String()+tc.suffix)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s %s: status = %d, want %d", tc.method, tc.suffix, rec.Code, http.StatusForbidden)
+		}
+	}
+	if deleted != 0 {
+		t.Errorf("out-of-scope key was deleted %d times", deleted)
+	}
+
+	if rec := send(http.MethodGet, "/v1/keys/"+batcher.ID.String()); rec.Code != http.StatusOK {
+		t.Errorf("in-scope Get status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// List only returns keys in scope
+	rec := send(http.MethodGet, "/v1/keys")
+	var resp struct {
+		Data []KeyResponse `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != batcher.ID {
+		t.Errorf("List returned %+v, want only %s", resp.Data, batcher.ID)
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+	"github.com/Bidon15/popsigner/control-plane/internal/service"
+)
+
+// NamespaceHandler handles namespace requests authenticated by API key.
+// The organization is taken from the API key rather than the URL.
+type NamespaceHandler struct {
+	namespaceService service.NamespaceService
+	validate         *validator.Validate
+}
+
+// NewNamespaceHandler creates a new namespace handler.
+func NewNamespaceHandler(namespaceService service.NamespaceService) *NamespaceHandler {
+	return &NamespaceHandler{
+		namespaceService: namespaceService,
+		validate:         validator.New(),
+	}
+}
+
+// Routes returns a chi router with namespace routes.
+// Namespaces are containers for keys, so they share the keys scopes.
+func (h *NamespaceHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(middleware.RequireScope("keys:read")).Get("/", h.List)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleAdmin)).Post("/", h.Create)
+	r.With(middleware.RequireScope("keys:read")).Get("/{id}", h.Get)
+	r.With(middleware.RequireScope("keys:write"), middleware.RequireRole(models.RoleAdmin)).Delete("/{id}", h.Delete)
+
+	return r
+}
+
+// CreateNamespaceHTTPRequest is the HTTP request body for creating a namespace.
+type CreateNamespaceHTTPRequest struct {
+	Name         string `json:"name" validate:"required,min=2,max=100"`
+	Description  string `json:"description,omitempty" validate:"max=500"`
+	Bech32Prefix string `json:"bech32_prefix,omitempty" validate:"omitempty,max=83,lowercase,alphanum"`
+}
+
+// List handles GET /v1/namespaces
+func (h *NamespaceHandler) List(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	namespaces, err := h.namespaceService.List(r.Context(), orgID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, namespaces)
+}
+
+// Create handles POST /v1/namespaces
+func (h *NamespaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	var req CreateNamespaceHTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		response.Error(w, apierrors.NewValidationErrors(formatValidationErrors(err)))
+		return
+	}
+
+	ns, err := h.namespaceService.Create(r.Context(), orgID, service.CreateNamespaceRequest{
+		Name:         req.Name,
+		Description:  req.Description,
+		Bech32Prefix: req.Bech32Prefix,
+	})
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.Created(w, ns)
+}
+
+// Get handles GET /v1/namespaces/{id}
+func (h *NamespaceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	nsID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("id", "invalid UUID format"))
+		return
+	}
+
+	ns, err := h.namespaceService.Get(r.Context(), orgID, nsID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, ns)
+}
+
+// Delete handles DELETE /v1/namespaces/{id}
+func (h *NamespaceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	nsID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("id", "invalid UUID format"))
+		return
+	}
+
+	if err := h.namespaceService.Delete(r.Context(), orgID, nsID); err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.NoContent(w)
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/service"
+)
+
+// mockNamespaceService is a mock implementation of NamespaceService for testing.
+type mockNamespaceService struct {
+	createFunc func(ctx context.Context, orgID uuid.UUID, req service.CreateNamespaceRequest) (*models.Namespace, error)
+	getFunc    func(ctx context.Context, orgID, nsID uuid.UUID) (*models.Namespace, error)
+	listFunc   func(ctx context.Context, orgID uuid.UUID) ([]*models.Namespace, error)
+	deleteFunc func(ctx context.Context, orgID, nsID uuid.UUID) error
+}
+
+func (m *mockNamespaceService) Create(ctx context.Context, orgID uuid.UUID, req service.CreateNamespaceRequest) (*models.Namespace, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, orgID, req)
+	}
+	return nil, nil
+}
+
+func (m *mockNamespaceService) Get(ctx context.Context, orgID, nsID uuid.UUID) (*models.Namespace, error) {
+	if m.getFunc != nil {
+		return m.getFunc(ctx, orgID, nsID)
+	}
+	return nil, nil
+}
+
+func (m *mockNamespaceService) List(ctx context.Context, orgID uuid.UUID) ([]*models.Namespace, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, orgID)
+	}
+	return nil, nil
+}
+
+func (m *mockNamespaceService) Delete(ctx context.Context, orgID, nsID uuid.UUID) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, orgID, nsID)
+	}
+	return nil
+}
+
+func (m *mockNamespaceService) EnsureDefault(ctx context.Context, orgID uuid.UUID) (*models.Namespace, error) {
+	return nil, nil
+}
+
+func TestNamespaceHandler_Create(t *testing.T) {
+	orgID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		mockService    *mockNamespaceService
+		expectedStatus int
+		checkResponse  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "creates namespace successfully",
+			body: CreateNamespaceHTTPRequest{Name: "staging", Bech32Prefix: "mocha"},
+			mockService: &mockNamespaceService{
+				createFunc: func(ctx context.Context, oID uuid.UUID, req service.CreateNamespaceRequest) (*models.Namespace, error) {
+					if oID != orgID {
+						t.Errorf("orgID = %v, want %v", oID, orgID)
+					}
+					return &models.Namespace{
+						ID:           uuid.New(),
+						OrgID:        oID,
+						Name:         req.Name,
+						Bech32Prefix: req.Bech32Prefix,
+						CreatedAt:    time.Now(),
+					}, nil
+				},
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var resp struct {
+					Data models.Namespace `json:"data"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				if resp.Data.Name != "staging" {
+					t.Errorf("Name = %v, want 'staging'", resp.Data.Name)
+				}
+				if resp.Data.Bech32Prefix != "mocha" {
+					t.Errorf("Bech32Prefix = %v, want 'mocha'", resp.Data.Bech32Prefix)
+				}
+			},
+		},
+		{
+			name:           "rejects missing name",
+			body:           CreateNamespaceHTTPRequest{},
+			mockService:    &mockNamespaceService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid bech32 prefix",
+			body:           CreateNamespaceHTTPRequest{Name: "staging", Bech32Prefix: "Not-Valid"},
+			mockService:    &mockNamespaceService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "returns conflict for duplicate name",
+			body: CreateNamespaceHTTPRequest{Name: "production"},
+			mockService: &mockNamespaceService{
+				createFunc: func(ctx context.Context, oID uuid.UUID, req service.CreateNamespaceRequest) (*models.Namespace, error) {
+					return nil, apierrors.NewConflictError("Namespace with this name already exists")
+				},
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNamespaceHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, http.MethodPost, "/v1/namespaces", tt.body, orgID)
+			rec := httptest.NewRecorder()
+			handler.Create(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, rec)
+			}
+		})
+	}
+}
+
+func TestNamespaceHandler_List(t *testing.T) {
+	orgID := uuid.New()
+
+	handler := NewNamespaceHandler(&mockNamespaceService{
+		listFunc: func(ctx context.Context, oID uuid.UUID) ([]*models.Namespace, error) {
+			return []*models.Namespace{
+				{ID: uuid.New(), OrgID: oID, Name: "production", Bech32Prefix: models.DefaultBech32Prefix},
+				{ID: uuid.New(), OrgID: oID, Name: "staging", Bech32Prefix: models.DefaultBech32Prefix},
+			}, nil
+		},
+	})
+
+	req := createKeyTestRequest(t, http.MethodGet, "/v1/namespaces", nil, orgID)
+	rec := httptest.NewRecorder()
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Data []models.Namespace `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Errorf("len(Data) = %d, want 2", len(resp.Data))
+	}
+}
+
+func TestNamespaceHandler_GetAndDelete(t *testing.T) {
+	orgID := uuid.New()
+	nsID := uuid.New()
+
+	tests := []struct {
+		name           string
+		method         string
+		idParam        string
+		mockService    *mockNamespaceService
+		expectedStatus int
+	}{
+		{
+			name:    "gets namespace successfully",
+			method:  http.MethodGet,
+			idParam: nsID.String(),
+			mockService: &mockNamespaceService{
+				getFunc: func(ctx context.Context, oID, id uuid.UUID) (*models.Namespace, error) {
+					return &models.Namespace{ID: id, OrgID: oID, Name: "production"}, nil
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "returns 404 for namespace in another org",
+			method:  http.MethodGet,
+			idParam: nsID.String(),
+			mockService: &mockNamespaceService{
+				getFunc: func(ctx context.Context, oID, id uuid.UUID) (*models.Namespace, error) {
+					return nil, apierrors.NewNotFoundError("Namespace")
+				},
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "rejects invalid UUID",
+			method:         http.MethodGet,
+			idParam:        "not-a-uuid",
+			mockService:    &mockNamespaceService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "deletes namespace successfully",
+			method:         http.MethodDelete,
+			idParam:        nsID.String(),
+			mockService:    &mockNamespaceService{},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:    "rejects deleting the last namespace",
+			method:  http.MethodDelete,
+			idParam: nsID.String(),
+			mockService: &mockNamespaceService{
+				deleteFunc: func(ctx context.Context, oID, id uuid.UUID) error {
+					return apierrors.ErrBadRequest.WithMessage("Cannot delete the last namespace")
+				},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNamespaceHandler(tt.mockService)
+
+			req := createKeyTestRequest(t, tt.method, "/v1/namespaces/"+tt.idParam, nil, orgID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.idParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			if tt.method == http.MethodDelete {
+				handler.Delete(rec, req)
+			} else {
+				handler.Get(rec, req)
+			}
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNamespaceHandler_Unauthorized(t *testing.T) {
+	handler := NewNamespaceHandler(&mockNamespaceService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/namespaces", nil)
+	rec := httptest.NewRecorder()
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
@@ -51,6 +51,7 @@ func (h *OrgHandler) Routes() chi.Router {
 	r.Patch("/{orgId}/members/{userId}", h.UpdateMemberRole)
 
 	// Invitation routes
+	r.Post("/{orgId}/invites", h.InviteMember)
 	r.Get("/{orgId}/invitations", h.ListInvitations)
 	r.Delete("/{orgId}/invitations/{invitationId}", h.CancelInvitation)
 
@@ -279,6 +280,7 @@ type InviteMemberRequest struct {
 
 // InviteMember handles inviting a new member to an organization.
 // POST /v1/organizations/{orgId}/members
+// POST /v1/organizations/{orgId}/invites
 func (h *OrgHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.getUserID(r)
 	if err != nil {
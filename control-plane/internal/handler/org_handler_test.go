@@ -233,6 +233,11 @@ func (m *MockAuthServiceForOrg) ResetPassword(ctx context.Context, token, newPas
 	return args.Error(0)
 }
 
+func (m *MockAuthServiceForOrg) SendEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func (m *MockAuthServiceForOrg) VerifyEmail(ctx context.Context, token string) error {
 	args := m.Called(ctx, token)
 	return args.Error(0)
@@ -557,6 +562,42 @@ func TestOrgHandler_InviteMember_Success(t *testing.T) {
 	authService.AssertExpectations(t)
 }
 
+func TestOrgHandler_InviteMember_InvitesRoute(t *testing.T) {
+	handler, orgService, authService := setupOrgTestHandler()
+
+	userID := uuid.New()
+	orgID := uuid.New()
+	sessionID := "test-session"
+	user := &models.User{ID: userID, Email: "test@example.com"}
+
+	inv := &models.Invitation{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		Email:     "signer@example.com",
+		Role:      models.RoleOperator,
+		Token:     "secret-token",
+		InvitedBy: userID,
+		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
+	}
+
+	authService.On("ValidateSession", mock.Anything, sessionID).Return(user, nil)
+	orgService.On("InviteMember", mock.Anything, orgID, "signer@example.com", models.RoleOperator, userID).Return(inv, nil)
+
+	req := createOrgTestRequest("POST", "/"+orgID.String()+"/invites", InviteMemberRequest{
+		Email: "signer@example.com",
+		Role:  models.RoleOperator,
+	}, sessionID)
+	w := httptest.NewRecorder()
+
+	handler.Routes().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret-token")
+
+	orgService.AssertExpectations(t)
+	authService.AssertExpectations(t)
+}
+
 func TestOrgHandler_RemoveMember_Success(t *testing.T) {
 	handler, orgService, authService := setupOrgTestHandler()
 
@@ -25,6 +25,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/opstack"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/popdeployer"
 	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	mainrepo "github.com/Bidon15/popsigner/control-plane/internal/repository"
@@ -1255,6 +1256,10 @@ func (h *Handler) DownloadBundle(w http.ResponseWriter, r *http.Request) {
 		case "docker-compose.yml":
 			path = bundlePrefix + "docker-compose.yml"
 			isPlainText = true
+		case "k8s.yaml":
+			// Kubernetes manifests (output_format: kubernetes)
+			path = bundlePrefix + "k8s.yaml"
+			isPlainText = true
 		case ".env.example":
 			path = bundlePrefix + ".env.example"
 			isPlainText = true
@@ -1291,6 +1296,9 @@ func (h *Handler) DownloadBundle(w http.ResponseWriter, r *http.Request) {
 		case "chain-info.json":
 			// Nitro chain configuration
 			path = bundlePrefix + "config/chain-info.json"
+		case "nitro-artifacts.json":
+			// Contract artifact version and checksum used for the deployment
+			path = bundlePrefix + "config/nitro-artifacts.json"
 		case "celestia-config.toml":
 			// Celestia DA server configuration
 			path = bundlePrefix + "config/celestia-config.toml"
@@ -1351,6 +1359,12 @@ func (h *Handler) DownloadBundle(w http.ResponseWriter, r *http.Request) {
 			path = bundlePrefix + "certs/ca.crt"
 			isPlainText = true
 		default:
+			if popdeployer.IsLogArtifact(artifact.ArtifactType) {
+				// Deployment logs (anvil.log, deployment.log) under logs/
+				path = bundlePrefix + artifact.ArtifactType
+				isPlainText = true
+				break
+			}
 			// Skip internal artifacts like deployment_state
 			slog.Debug("DownloadBundle: skipping artifact",
 				slog.String("type", artifact.ArtifactType),
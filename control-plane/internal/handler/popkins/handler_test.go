@@ -0,0 +1,127 @@
+package popkins
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/popdeployer"
+	"github.com/Bidon15/popsigner/control-plane/internal/bootstrap/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	mainrepo "github.com/Bidon15/popsigner/control-plane/internal/repository"
+	"github.com/Bidon15/popsigner/control-plane/internal/service"
+)
+
+type fakeSessionRepo struct {
+	mainrepo.SessionRepository
+	session *models.Session
+}
+
+func (r *fakeSessionRepo) Get(ctx context.Context, id string) (*models.Session, error) {
+	return r.session, nil
+}
+
+type fakeUserRepo struct {
+	mainrepo.UserRepository
+	user *models.User
+}
+
+func (r *fakeUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return r.user, nil
+}
+
+type fakeOrgService struct {
+	service.OrgService
+	org *models.Organization
+}
+
+func (s *fakeOrgService) ListUserOrgs(ctx context.Context, userID uuid.UUID) ([]*models.Organization, error) {
+	return []*models.Organization{s.org}, nil
+}
+
+type fakeDeployRepo struct {
+	repository.Repository
+	deployment *repository.Deployment
+	artifacts  []repository.Artifact
+}
+
+func (r *fakeDeployRepo) GetDeployment(ctx context.Context, id uuid.UUID) (*repository.Deployment, error) {
+	return r.deployment, nil
+}
+
+func (r *fakeDeployRepo) GetAllArtifacts(ctx context.Context, deploymentID uuid.UUID) ([]repository.Artifact, error) {
+	return r.artifacts, nil
+}
+
+func TestDownloadBundle_IncludesLogs(t *testing.T) {
+	user := &models.User{ID: uuid.New()}
+	org := &models.Organization{ID: uuid.New()}
+	deployment := &repository.Deployment{
+		ID:     uuid.New(),
+		OrgID:  org.ID,
+		Stack:  repository.StackPopBundle,
+		Status: repository.StatusCompleted,
+		Config: json.RawMessage(`{"chain_name":"devnet"}`),
+	}
+
+	wrapped, err := json.Marshal(map[string]string{
+		"_type": "base64",
+		"data":  base64.StdEncoding.EncodeToString([]byte("Listening on anvil.ipc\n")),
+	})
+	require.NoError(t, err)
+
+	h := NewHandler(
+		nil,
+		&fakeOrgService{org: org},
+		nil,
+		&fakeDeployRepo{
+			deployment: deployment,
+			artifacts: []repository.Artifact{
+				{ArtifactType: "genesis.json", Content: json.RawMessage(`{}`)},
+				{ArtifactType: popdeployer.AnvilLogArtifact, Content: wrapped},
+			},
+		},
+		nil,
+		&fakeSessionRepo{session: &models.Session{UserID: user.ID, ExpiresAt: time.Now().Add(time.Hour)}},
+		&fakeUserRepo{user: user},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/deployments/"+deployment.ID.String()+"/bundle", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "session"})
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", deployment.ID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rec := httptest.NewRecorder()
+	h.DownloadBundle(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[f.Name] = string(data)
+	}
+
+	prefix := "devnet-" + string(repository.StackPopBundle) + "-bundle/"
+	assert.Contains(t, files, prefix+"genesis.json")
+	assert.Equal(t, "Listening on anvil.ipc\n", files[prefix+"logs/anvil.log"])
+}
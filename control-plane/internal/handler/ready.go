@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+// Readiness check timeouts. OpenBao gets its own, shorter budget so a hung
+// server fails the check instead of blocking it.
+const (
+	readyTimeout        = 5 * time.Second
+	readyOpenBaoTimeout = 2 * time.Second
+)
+
+// Pinger verifies a connection. database.Postgres and database.Redis satisfy it.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// BaoHealthChecker checks OpenBao health. openbao.Client satisfies it.
+type BaoHealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// ReadyHandler returns a readiness check that verifies the database, Redis
+// and OpenBao. A sealed OpenBao fails readiness, since nothing can be signed.
+func ReadyHandler(db, redis Pinger, bao BaoHealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+
+		// Check database connection
+		if err := db.Ping(ctx); err != nil {
+			writeReady(w, http.StatusServiceUnavailable, map[string]string{"status": "error", "component": "database"})
+			return
+		}
+
+		// Check Redis connection
+		if err := redis.Ping(ctx); err != nil {
+			writeReady(w, http.StatusServiceUnavailable, map[string]string{"status": "error", "component": "redis"})
+			return
+		}
+
+		// Check OpenBao is reachable and unsealed
+		baoCtx, baoCancel := context.WithTimeout(ctx, readyOpenBaoTimeout)
+		defer baoCancel()
+		if err := bao.HealthCheck(baoCtx); err != nil {
+			writeReady(w, http.StatusServiceUnavailable, map[string]string{
+				"status":    "error",
+				"component": "openbao",
+				"openbao":   baoStatus(err),
+			})
+			return
+		}
+
+		writeReady(w, http.StatusOK, map[string]string{
+			"status":   "ok",
+			"database": "connected",
+			"redis":    "connected",
+			"openbao":  "unsealed",
+		})
+	}
+}
+
+// baoStatus describes a failed OpenBao health check.
+func baoStatus(err error) string {
+	switch {
+	case errors.Is(err, openbao.ErrSealed):
+		return "sealed"
+	case errors.Is(err, openbao.ErrNotInitialized):
+		return "uninitialized"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, openbao.ErrUnavailable):
+		return "unreachable"
+	default:
+		return "unhealthy"
+	}
+}
+
+func writeReady(w http.ResponseWriter, status int, body map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
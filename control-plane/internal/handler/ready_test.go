@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+var healthyPinger = pingerFunc(func(ctx context.Context) error { return nil })
+
+// baoServer starts a fake OpenBao answering health checks with status.
+func baoServer(t *testing.T, status int) *openbao.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return openbao.NewClient(&config.OpenBaoConfig{Address: srv.URL})
+}
+
+func serveReady(t *testing.T, h http.HandlerFunc) (int, map[string]string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestReadyHandler_Healthy(t *testing.T) {
+	code, body := serveReady(t, ReadyHandler(healthyPinger, healthyPinger, baoServer(t, http.StatusOK)))
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, "unsealed", body["openbao"])
+}
+
+func TestReadyHandler_SealedOpenBao(t *testing.T) {
+	code, body := serveReady(t, ReadyHandler(healthyPinger, healthyPinger, baoServer(t, http.StatusServiceUnavailable)))
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "openbao", body["component"])
+	assert.Equal(t, "sealed", body["openbao"])
+}
+
+func TestReadyHandler_HungOpenBao(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	bao := openbao.NewClient(&config.OpenBaoConfig{Address: srv.URL})
+	start := time.Now()
+	code, body := serveReady(t, ReadyHandler(healthyPinger, healthyPinger, bao))
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "timeout", body["openbao"])
+	assert.Less(t, time.Since(start), readyTimeout)
+}
+
+func TestReadyHandler_DependencyDown(t *testing.T) {
+	down := pingerFunc(func(ctx context.Context) error { return errors.New("connection refused") })
+	bao := baoServer(t, http.StatusOK)
+
+	code, body := serveReady(t, ReadyHandler(down, healthyPinger, bao))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "database", body["component"])
+
+	code, body = serveReady(t, ReadyHandler(healthyPinger, down, bao))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "redis", body["component"])
+}
@@ -2,19 +2,26 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
 )
 
-// SignHandler handles batch signing HTTP requests.
+// SignHandler handles batch signing and signature verification HTTP requests.
 type SignHandler struct {
 	keyService service.KeyService
 }
@@ -27,7 +34,7 @@ func NewSignHandler(keyService service.KeyService) *SignHandler {
 // Routes returns a chi router with sign routes.
 func (h *SignHandler) Routes() chi.Router {
 	r := chi.NewRouter()
-	r.With(middleware.RequireScope("keys:sign")).Post("/batch", h.SignBatch)
+	r.With(middleware.RequireScope("keys:sign"), middleware.RequireRole(models.RoleOperator)).Post("/batch", h.SignBatch)
 	return r
 }
 
@@ -38,9 +45,10 @@ type SignBatchHTTPRequest struct {
 
 // SignBatchItemHTTPRequest is a single item in a batch sign request.
 type SignBatchItemHTTPRequest struct {
-	KeyID     string `json:"key_id"`
-	Data      string `json:"data"`      // base64 encoded
-	Prehashed bool   `json:"prehashed"` // true if data is already hashed
+	KeyID      string `json:"key_id"`
+	Data       string `json:"data"`                  // base64 encoded
+	Prehashed  bool   `json:"prehashed"`             // true if data is already hashed
+	KeyVersion int    `json:"key_version,omitempty"` // retired version to sign with; 0 for current
 }
 
 // SignBatch handles POST /v1/sign/batch
@@ -87,10 +95,16 @@ func (h *SignHandler) SignBatch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := checkSigningScope(r.Context(), h.keyService, orgID, keyID); err != nil {
+			response.Error(w, err)
+			return
+		}
+
 		signRequests[i] = service.SignKeyRequest{
-			KeyID:     keyID,
-			Data:      item.Data,
-			Prehashed: item.Prehashed,
+			KeyID:      keyID,
+			Data:       item.Data,
+			Prehashed:  item.Prehashed,
+			KeyVersion: item.KeyVersion,
 		}
 	}
 
@@ -106,3 +120,72 @@ func (h *SignHandler) SignBatch(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, map[string]any{"signatures": results, "count": len(results)})
 }
 
+// VerifyHTTPRequest is the HTTP request body for signature verification.
+type VerifyHTTPRequest struct {
+	PublicKey string `json:"public_key"` // hex encoded, compressed or uncompressed
+	Data      string `json:"data"`       // base64 encoded
+	Prehashed bool   `json:"prehashed"`  // true if data is already a 32-byte digest
+	Signature string `json:"signature"`  // base64 encoded R||S, optionally followed by a recovery byte
+}
+
+// Verify handles POST /v1/verify. It checks a secp256k1 signature against a
+// public key using the same hashing as signing: data is hashed with SHA-256
+// unless prehashed is set. Malformed input is rejected; a well-formed
+// signature that doesn't match returns valid=false.
+func (h *SignHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	var req VerifyHTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, apierrors.ErrBadRequest.WithMessage("Invalid request body"))
+		return
+	}
+
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(req.PublicKey, "0x"))
+	if err != nil || len(pubKeyBytes) == 0 {
+		response.Error(w, apierrors.NewValidationError("public_key", "must be a hex-encoded public key"))
+		return
+	}
+	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("public_key", "not a valid secp256k1 public key"))
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(req.Data)
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("data", "must be base64 encoded"))
+		return
+	}
+	digest := data
+	if req.Prehashed {
+		if len(data) != 32 {
+			response.Error(w, apierrors.NewValidationError("data", "prehashed data must be 32 bytes"))
+			return
+		}
+	} else {
+		sum := sha256.Sum256(data)
+		digest = sum[:]
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(req.Signature)
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("signature", "must be base64 encoded"))
+		return
+	}
+	if len(sig) != 64 && len(sig) != 65 {
+		response.Error(w, apierrors.NewValidationError("signature", "must be 64 bytes (R||S) or 65 bytes with a recovery byte"))
+		return
+	}
+
+	var rs, ss secp256k1.ModNScalar
+	if overflow := rs.SetByteSlice(sig[:32]); overflow || rs.IsZero() {
+		response.OK(w, map[string]any{"valid": false})
+		return
+	}
+	if overflow := ss.SetByteSlice(sig[32:64]); overflow || ss.IsZero() {
+		response.OK(w, map[string]any{"valid": false})
+		return
+	}
+
+	valid := ecdsa.NewSignature(&rs, &ss).Verify(digest, pubKey)
+	response.OK(w, map[string]any{"valid": valid})
+}
@@ -3,12 +3,16 @@ package handler
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
@@ -214,3 +218,129 @@ func TestSignHandler_Unauthorized(t *testing.T) {
 	}
 }
 
+// signForVerify signs the SHA-256 digest of data and returns the R||S||V signature.
+func signForVerify(t *testing.T, priv *secp256k1.PrivateKey, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	compact := ecdsa.SignCompact(priv, digest[:], true)
+	// SignCompact returns V||R||S
+	return append(compact[1:], compact[0])
+}
+
+func TestSignHandler_Verify(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+	other, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("GeneratePrivateKey: %v", err)
+	}
+
+	data := []byte("transfer 10 TIA to celestia1abc")
+	sig := signForVerify(t, priv, data)
+	pubKey := hex.EncodeToString(priv.PubKey().SerializeCompressed())
+	digest := sha256.Sum256(data)
+
+	b64 := base64.StdEncoding.EncodeToString
+	tests := []struct {
+		name           string
+		body           interface{}
+		expectedStatus int
+		expectedValid  bool
+	}{
+		{
+			name:           "valid signature",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64(data), Signature: b64(sig[:64])},
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+		},
+		{
+			name:           "valid signature with recovery byte and uncompressed key",
+			body:           VerifyHTTPRequest{PublicKey: "0x" + hex.EncodeToString(priv.PubKey().SerializeUncompressed()), Data: b64(data), Signature: b64(sig)},
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+		},
+		{
+			name:           "valid prehashed signature",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64(digest[:]), Prehashed: true, Signature: b64(sig)},
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+		},
+		{
+			name:           "wrong key",
+			body:           VerifyHTTPRequest{PublicKey: hex.EncodeToString(other.PubKey().SerializeCompressed()), Data: b64(data), Signature: b64(sig)},
+			expectedStatus: http.StatusOK,
+			expectedValid:  false,
+		},
+		{
+			name:           "tampered data",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64([]byte("transfer 99 TIA to celestia1abc")), Signature: b64(sig)},
+			expectedStatus: http.StatusOK,
+			expectedValid:  false,
+		},
+		{
+			name:           "digest signed without prehashed flag",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64(digest[:]), Signature: b64(sig)},
+			expectedStatus: http.StatusOK,
+			expectedValid:  false,
+		},
+		{
+			name:           "rejects invalid public key",
+			body:           VerifyHTTPRequest{PublicKey: "02deadbeef", Data: b64(data), Signature: b64(sig)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects short prehashed data",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64(data), Prehashed: true, Signature: b64(sig)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects bad signature length",
+			body:           VerifyHTTPRequest{PublicKey: pubKey, Data: b64(data), Signature: b64(sig[:63])},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid JSON",
+			body:           "not json",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSignHandler(&mockKeyService{})
+
+			var reqBody []byte
+			if str, ok := tt.body.(string); ok {
+				reqBody = []byte(str)
+			} else {
+				reqBody, _ = json.Marshal(tt.body)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/verify", bytes.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.Verify(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp struct {
+				Data struct {
+					Valid bool `json:"valid"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if resp.Data.Valid != tt.expectedValid {
+				t.Errorf("Valid = %v, want %v", resp.Data.Valid, tt.expectedValid)
+			}
+		})
+	}
+}
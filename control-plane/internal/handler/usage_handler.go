@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/middleware"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+	"github.com/Bidon15/popsigner/control-plane/internal/service"
+)
+
+// UsageHandler handles usage and quota requests.
+type UsageHandler struct {
+	usageService service.UsageService
+}
+
+// NewUsageHandler creates a new usage handler.
+func NewUsageHandler(usageService service.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// Routes returns a chi router with usage routes.
+func (h *UsageHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.With(middleware.RequireScope("billing:read")).Get("/", h.Current)
+	r.With(middleware.RequireScope("billing:read")).Get("/keys/{id}", h.ByKey)
+
+	return r
+}
+
+// Current handles GET /v1/usage
+// @Summary Get current usage
+// @Description Usage for the current billing period alongside the plan's limits
+// @Tags usage
+// @Produce json
+// @Success 200 {object} response.Response{data=models.UsageSummary}
+// @Failure 401 {object} response.Response{error=apierrors.APIError}
+// @Router /v1/usage [get]
+func (h *UsageHandler) Current(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	summary, err := h.usageService.Current(r.Context(), orgID)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, summary)
+}
+
+// ByKey handles GET /v1/usage/keys/{id}
+// @Summary Get usage for a key
+// @Description Signatures made with a key within a window (defaults to the current billing period)
+// @Tags usage
+// @Produce json
+// @Param id path string true "Key ID (UUID)"
+// @Param start_time query string false "Window start (RFC3339)"
+// @Param end_time query string false "Window end (RFC3339)"
+// @Success 200 {object} response.Response{data=models.KeyUsage}
+// @Failure 400 {object} response.Response{error=apierrors.APIError}
+// @Failure 404 {object} response.Response{error=apierrors.APIError}
+// @Router /v1/usage/keys/{id} [get]
+func (h *UsageHandler) ByKey(w http.ResponseWriter, r *http.Request) {
+	orgID := middleware.GetOrgIDFromContext(r.Context())
+	if orgID == uuid.Nil {
+		response.Error(w, apierrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.Error(w, apierrors.NewValidationError("id", "invalid UUID format"))
+		return
+	}
+
+	var start, end time.Time
+	if s := r.URL.Query().Get("start_time"); s != "" {
+		if start, err = time.Parse(time.RFC3339, s); err != nil {
+			response.Error(w, apierrors.NewValidationError("start_time", "must be an RFC3339 timestamp"))
+			return
+		}
+	}
+	if s := r.URL.Query().Get("end_time"); s != "" {
+		if end, err = time.Parse(time.RFC3339, s); err != nil {
+			response.Error(w, apierrors.NewValidationError("end_time", "must be an RFC3339 timestamp"))
+			return
+		}
+	}
+
+	usage, err := h.usageService.ByKey(r.Context(), orgID, keyID, start, end)
+	if err != nil {
+		response.Error(w, err)
+		return
+	}
+
+	response.OK(w, usage)
+}
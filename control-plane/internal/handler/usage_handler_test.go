@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// mockUsageService is a mock implementation of UsageService for testing.
+type mockUsageService struct {
+	currentFunc func(ctx context.Context, orgID uuid.UUID) (*models.UsageSummary, error)
+	byKeyFunc   func(ctx context.Context, orgID, keyID uuid.UUID, start, end time.Time) (*models.KeyUsage, error)
+}
+
+func (m *mockUsageService) Current(ctx context.Context, orgID uuid.UUID) (*models.UsageSummary, error) {
+	if m.currentFunc != nil {
+		return m.currentFunc(ctx, orgID)
+	}
+	return nil, nil
+}
+
+func (m *mockUsageService) ByKey(ctx context.Context, orgID, keyID uuid.UUID, start, end time.Time) (*models.KeyUsage, error) {
+	if m.byKeyFunc != nil {
+		return m.byKeyFunc(ctx, orgID, keyID, start, end)
+	}
+	return nil, nil
+}
+
+func TestUsageHandler_Current(t *testing.T) {
+	orgID := uuid.New()
+
+	handler := NewUsageHandler(&mockUsageService{
+		currentFunc: func(ctx context.Context, oID uuid.UUID) (*models.UsageSummary, error) {
+			return &models.UsageSummary{
+				OrgID:           oID,
+				Plan:            models.PlanFree,
+				SignaturesMonth: 9000,
+				SignaturesLimit: 10000,
+			}, nil
+		},
+	})
+
+	req := createKeyTestRequest(t, http.MethodGet, "/v1/usage", nil, orgID)
+	rec := httptest.NewRecorder()
+	handler.Current(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Data models.UsageSummary `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if resp.Data.OrgID != orgID || resp.Data.SignaturesMonth != 9000 || resp.Data.SignaturesLimit != 10000 {
+		t.Errorf("unexpected summary: %+v", resp.Data)
+	}
+}
+
+func TestUsageHandler_ByKey(t *testing.T) {
+	orgID := uuid.New()
+	keyID := uuid.New()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		idParam        string
+		query          string
+		wantStart      time.Time
+		wantEnd        time.Time
+		expectedStatus int
+	}{
+		{
+			name:           "passes window through",
+			idParam:        keyID.String(),
+			query:          "?start_time=2024-01-01T00:00:00Z&end_time=2024-01-02T00:00:00Z",
+			wantStart:      start,
+			wantEnd:        end,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "leaves window unset when omitted",
+			idParam:        keyID.String(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "rejects invalid start_time",
+			idParam:        keyID.String(),
+			query:          "?start_time=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "rejects invalid UUID",
+			idParam:        "not-a-uuid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUsageHandler(&mockUsageService{
+				byKeyFunc: func(ctx context.Context, oID, kID uuid.UUID, s, e time.Time) (*models.KeyUsage, error) {
+					if !s.Equal(tt.wantStart) || !e.Equal(tt.wantEnd) {
+						t.Errorf("window = [%v, %v], want [%v, %v]", s, e, tt.wantStart, tt.wantEnd)
+					}
+					return &models.KeyUsage{KeyID: kID, Signatures: 42, PeriodStart: s, PeriodEnd: e}, nil
+				},
+			})
+
+			req := createKeyTestRequest(t, http.MethodGet, "/v1/usage/keys/"+tt.idParam+tt.query, nil, orgID)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.idParam)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rec := httptest.NewRecorder()
+			handler.ByKey(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d. Body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+		})
+	}
+}
@@ -68,7 +68,6 @@ func (h *WebHandler) AuditLog(w http.ResponseWriter, r *http.Request) {
 		OrgID:     oid,
 		StartTime: startTime,
 		Limit:     50,
-		Cursor:    cursor,
 	}
 
 	if event != "" {
@@ -85,7 +84,7 @@ func (h *WebHandler) AuditLog(w http.ResponseWriter, r *http.Request) {
 		Event:     query.Event,
 		StartTime: query.StartTime,
 		Limit:     query.Limit,
-		Cursor:    query.Cursor,
+		Cursor:    cursor,
 	}
 	logs, nextCursor, _ := h.auditService.Query(ctx, oid, filter)
 
@@ -31,81 +31,66 @@ func (h *WebHandler) KeysList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse query parameters
-	searchQuery := r.URL.Query().Get("q")
-	namespaceFilter := r.URL.Query().Get("namespace")
-	networkFilter := r.URL.Query().Get("network")
+	query := r.URL.Query()
+	searchQuery := query.Get("q")
+	namespaceFilter := query.Get("namespace")
+	networkFilter := query.Get("network")
 
-	var nsID *uuid.UUID
-	if namespaceFilter != "" {
-		id, err := uuid.Parse(namespaceFilter)
-		if err == nil {
-			nsID = &id
-		}
+	filter := service.KeyPageFilter{
+		Search: searchQuery,
+		Sort:   models.KeySort(query.Get("sort")),
 	}
-
-	// Parse network filter
-	var networkType *models.NetworkType
-	if networkFilter != "" && networkFilter != "all" {
-		nt := models.NetworkType(networkFilter)
-		if nt.Valid() {
-			networkType = &nt
-		}
+	filter.Page, _ = strconv.Atoi(query.Get("page"))
+	filter.Size, _ = strconv.Atoi(query.Get("size"))
+	if id, err := uuid.Parse(namespaceFilter); err == nil {
+		filter.NamespaceID = &id
+	}
+	if nt := models.NetworkType(networkFilter); nt.Valid() {
+		filter.NetworkType = &nt
 	}
 
-	// Fetch keys and namespaces with optional network filter
-	keys, err := h.keyService.List(ctx, org.ID, nsID, networkType)
+	// Fetch a page of keys and the namespaces
+	keyPage, err := h.keyService.ListByOrgPaged(ctx, org.ID, filter)
 	if err != nil {
 		h.handleError(w, r, err)
 		return
 	}
 
-	// Filter by search query if provided
-	if searchQuery != "" {
-		keys = filterKeys(keys, searchQuery)
-	}
-
 	namespaces, err := h.orgService.ListNamespaces(ctx, org.ID, user.ID)
 	if err != nil {
 		h.handleError(w, r, err)
 		return
 	}
 
-	// If HTMX request targeting #main-content, return full page content
-	if r.Header.Get("HX-Request") == "true" {
-		if r.Header.Get("HX-Target") == "#main-content" {
-			data := pages.KeysPageData{
-				UserName:      getUserName(user),
-				UserEmail:     user.Email,
-				AvatarURL:     getAvatarURL(user),
-				OrgName:       org.Name,
-				OrgPlan:       string(org.Plan),
-				Keys:          keys,
-				Namespaces:    namespaces,
-				SearchQuery:   searchQuery,
-				NamespaceID:   namespaceFilter,
-				NetworkFilter: networkFilter,
-			}
-			templ.Handler(pages.KeysPageContent(data)).ServeHTTP(w, r)
-			return
-		}
-		templ.Handler(pages.KeysList(keys, namespaces)).ServeHTTP(w, r)
-		return
-	}
-
-	// Full page render
 	data := pages.KeysPageData{
 		UserName:      getUserName(user),
 		UserEmail:     user.Email,
 		AvatarURL:     getAvatarURL(user),
 		OrgName:       org.Name,
 		OrgPlan:       string(org.Plan),
-		Keys:          keys,
+		Keys:          keyPage.Keys,
 		Namespaces:    namespaces,
 		SearchQuery:   searchQuery,
 		NamespaceID:   namespaceFilter,
 		NetworkFilter: networkFilter,
+		Sort:          string(keyPage.Sort),
+		Page:          keyPage.Page,
+		PageSize:      keyPage.Size,
+		Total:         keyPage.Total,
+		Pages:         keyPage.Pages(),
+	}
+
+	// If HTMX request targeting #main-content, return full page content
+	if r.Header.Get("HX-Request") == "true" {
+		if r.Header.Get("HX-Target") == "#main-content" {
+			templ.Handler(pages.KeysPageContent(data)).ServeHTTP(w, r)
+			return
+		}
+		templ.Handler(pages.KeysListPanel(data)).ServeHTTP(w, r)
+		return
 	}
 
+	// Full page render
 	templ.Handler(pages.KeysListPage(data)).ServeHTTP(w, r)
 }
 
@@ -313,22 +298,21 @@ func (h *WebHandler) WorkerKeysCreate(w http.ResponseWriter, r *http.Request) {
 
 	exportable := r.FormValue("exportable") == "true"
 
-	createdKeys, err := h.keyService.CreateBatch(ctx, service.CreateBatchKeyRequest{
+	result, err := h.keyService.CreateBatch(ctx, service.CreateBatchKeyRequest{
 		OrgID:       org.ID,
 		NamespaceID: nsID,
 		Prefix:      prefix,
 		Count:       count,
 		Exportable:  exportable,
 	})
-
-	created := len(createdKeys)
-	failed := count - created
-
-	if err != nil && created == 0 {
+	if err != nil {
 		h.renderToast(w, r, err.Error(), components.ToastError)
 		return
 	}
 
+	created := len(result.Keys)
+	failed := len(result.Failed)
+
 	// Return updated keys list with success message
 	keys, _ := h.keyService.List(ctx, org.ID, nil, nil)
 	namespaces, _ := h.orgService.ListNamespaces(ctx, org.ID, user.ID)
@@ -403,6 +387,35 @@ func (h *WebHandler) KeysSignTest(w http.ResponseWriter, r *http.Request) {
 	templ.Handler(partials.SignResult(result.Signature, result.PublicKey, 1, nil)).ServeHTTP(w, r)
 }
 
+// KeysRotate handles key rotation from the detail page.
+func (h *WebHandler) KeysRotate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, org, err := h.getUserAndOrg(r)
+	if err != nil {
+		h.handleError(w, r, err)
+		return
+	}
+
+	keyIDStr := chi.URLParam(r, "id")
+	keyID, err := uuid.Parse(keyIDStr)
+	if err != nil {
+		h.renderToast(w, r, "Invalid key ID", components.ToastError)
+		return
+	}
+
+	opts := service.RotateKeyOptions{
+		AllowPreviousVersionSigning: r.FormValue("allow_previous_version_signing") == "on",
+	}
+	if _, err := h.keyService.Rotate(ctx, org.ID, keyID, opts); err != nil {
+		h.renderToast(w, r, err.Error(), components.ToastError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", `{"toast": {"message": "Key rotated successfully", "type": "success"}}`)
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
+
 // KeysDelete handles key deletion.
 func (h *WebHandler) KeysDelete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -550,20 +563,6 @@ func (h *WebHandler) getSigningStats(ctx context.Context, keyID uuid.UUID) *page
 	}
 }
 
-// filterKeys filters keys by search query.
-func filterKeys(keys []*models.Key, query string) []*models.Key {
-	query = strings.ToLower(query)
-	var filtered []*models.Key
-	for _, key := range keys {
-		if strings.Contains(strings.ToLower(key.Name), query) ||
-			strings.Contains(strings.ToLower(key.Address), query) ||
-			(key.EthAddress != nil && strings.Contains(strings.ToLower(*key.EthAddress), query)) {
-			filtered = append(filtered, key)
-		}
-	}
-	return filtered
-}
-
 // getUserName returns the display name for a user.
 func getUserName(user *models.User) string {
 	if user.Name != nil && *user.Name != "" {
@@ -150,6 +150,7 @@ func (h *WebHandler) Routes() chi.Router {
 			r.Post("/workers", h.WorkerKeysCreate)
 			r.Get("/{id}", h.KeysDetail)
 			r.Post("/{id}/sign-test", h.KeysSignTest)
+			r.Post("/{id}/rotate", h.KeysRotate)
 			r.Delete("/{id}", h.KeysDelete)
 		})
 
@@ -309,7 +310,7 @@ func (h *WebHandler) LoginPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	errorMsg := r.URL.Query().Get("error")
-	component := pages.LoginPage(errorMsg)
+	component := pages.LoginPage(errorMsg, r.URL.Query().Get("success"))
 	templ.Handler(component).ServeHTTP(w, r)
 }
 
@@ -295,6 +295,7 @@ func (h *WebHandler) SettingsAPIKeysCreate(w http.ResponseWriter, r *http.Reques
 	req := service.CreateAPIKeyRequest{
 		Name:   name,
 		Scopes: scopes,
+		UserID: &user.ID,
 	}
 	if expiresAt != nil {
 		days := int(time.Until(*expiresAt).Hours() / 24)
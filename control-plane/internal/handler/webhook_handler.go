@@ -35,17 +35,17 @@ func (h *WebhookHandler) Routes() chi.Router {
 
 	// Webhook CRUD
 	r.With(middleware.RequireScope("webhooks:read")).Get("/", h.List)
-	r.With(middleware.RequireScope("webhooks:write")).Post("/", h.Create)
+	r.With(middleware.RequireScope("webhooks:write"), middleware.RequireRole(models.RoleAdmin)).Post("/", h.Create)
 	r.With(middleware.RequireScope("webhooks:read")).Get("/{id}", h.Get)
-	r.With(middleware.RequireScope("webhooks:write")).Patch("/{id}", h.Update)
-	r.With(middleware.RequireScope("webhooks:write")).Delete("/{id}", h.Delete)
+	r.With(middleware.RequireScope("webhooks:write"), middleware.RequireRole(models.RoleAdmin)).Patch("/{id}", h.Update)
+	r.With(middleware.RequireScope("webhooks:write"), middleware.RequireRole(models.RoleAdmin)).Delete("/{id}", h.Delete)
 
 	// Secret management
-	r.With(middleware.RequireScope("webhooks:write")).Post("/{id}/rotate-secret", h.RotateSecret)
+	r.With(middleware.RequireScope("webhooks:write"), middleware.RequireRole(models.RoleAdmin)).Post("/{id}/rotate-secret", h.RotateSecret)
 
 	// Deliveries
 	r.With(middleware.RequireScope("webhooks:read")).Get("/{id}/deliveries", h.ListDeliveries)
-	r.With(middleware.RequireScope("webhooks:write")).Post("/{id}/deliveries/{deliveryId}/retry", h.RetryDelivery)
+	r.With(middleware.RequireScope("webhooks:write"), middleware.RequireRole(models.RoleAdmin)).Post("/{id}/deliveries/{deliveryId}/retry", h.RetryDelivery)
 
 	return r
 }
@@ -79,15 +79,14 @@ type WebhookResponse struct {
 
 // WebhookDeliveryResponse is the API response format for webhook deliveries.
 type WebhookDeliveryResponse struct {
-	ID           uuid.UUID `json:"id"`
-	WebhookID    uuid.UUID `json:"webhook_id"`
-	Event        string    `json:"event"`
-	StatusCode   int       `json:"status_code,omitempty"`
-	ResponseBody string    `json:"response_body,omitempty"`
-	DurationMs   int64     `json:"duration_ms,omitempty"`
-	Success      bool      `json:"success"`
-	Error        string    `json:"error,omitempty"`
-	AttemptedAt  string    `json:"attempted_at"`
+	ID          uuid.UUID `json:"id"`
+	WebhookID   uuid.UUID `json:"webhook_id"`
+	Event       string    `json:"event"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	DurationMs  int64     `json:"duration_ms,omitempty"`
+	Success     bool      `json:"success"`
+	Error       string    `json:"error,omitempty"`
+	AttemptedAt string    `json:"attempted_at"`
 }
 
 // toWebhookResponse converts a Webhook model to a response.
@@ -125,15 +124,14 @@ func toWebhookResponse(webhook *models.Webhook, includeSecret bool) *WebhookResp
 // toDeliveryResponse converts a WebhookDelivery model to a response.
 func toDeliveryResponse(d *models.WebhookDelivery) *WebhookDeliveryResponse {
 	return &WebhookDeliveryResponse{
-		ID:           d.ID,
-		WebhookID:    d.WebhookID,
-		Event:        string(d.Event),
-		StatusCode:   d.StatusCode,
-		ResponseBody: d.ResponseBody,
-		DurationMs:   int64(d.Duration / time.Millisecond),
-		Success:      d.Success,
-		Error:        d.Error,
-		AttemptedAt:  d.AttemptedAt.Format(time.RFC3339),
+		ID:          d.ID,
+		WebhookID:   d.WebhookID,
+		Event:       string(d.Event),
+		StatusCode:  d.StatusCode,
+		DurationMs:  int64(d.Duration / time.Millisecond),
+		Success:     d.Success,
+		Error:       d.Error,
+		AttemptedAt: d.AttemptedAt.Format(time.RFC3339),
 	}
 }
 
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"strings"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
-	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
 )
 
@@ -20,10 +20,15 @@ const (
 	APIKeyContextKey contextKey = "api_key"
 	// ScopesContextKey is the context key for the API key scopes.
 	ScopesContextKey contextKey = "scopes"
+	// CertificateContextKey is the context key for the client certificate of
+	// mTLS-authenticated requests.
+	CertificateContextKey contextKey = "certificate"
 )
 
 // APIKeyAuth returns a middleware that authenticates requests using API keys.
 // It supports both "Bearer <key>" and "ApiKey <key>" authorization headers.
+// Keys with an IP allowlist are rejected from other client IPs; the client IP
+// is taken from RemoteAddr, so RealIP must run first behind a proxy.
 func APIKeyAuth(apiKeyService service.APIKeyService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -36,11 +41,17 @@ func APIKeyAuth(apiKeyService service.APIKeyService) func(http.Handler) http.Han
 
 			// Validate API key
 			apiKey, err := apiKeyService.Validate(r.Context(), rawKey)
-			if err != nil {
+			if err != nil || !apiKey.IsValid() {
 				response.Error(w, apierrors.ErrUnauthorized)
 				return
 			}
 
+			// Enforce the key's IP allowlist
+			if !apiKey.AllowsIP(ClientIP(r)) {
+				response.Error(w, apierrors.ErrForbidden.WithMessage("API key is not allowed from this IP address"))
+				return
+			}
+
 			// Add API key info to context
 			ctx := context.WithValue(r.Context(), APIKeyContextKey, apiKey)
 			ctx = context.WithValue(ctx, OrgIDKey, apiKey.OrgID.String())
@@ -52,6 +63,16 @@ func APIKeyAuth(apiKeyService service.APIKeyService) func(http.Handler) http.Han
 	}
 }
 
+// ClientIP returns the client IP from the request's RemoteAddr, which RealIP
+// rewrites from forwarding headers set by trusted proxies.
+func ClientIP(r *http.Request) net.IP {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return net.ParseIP(strings.TrimSpace(host))
+}
+
 // extractAPIKey extracts the API key from the request.
 // Supports Authorization header with "Bearer" or "ApiKey" scheme,
 // and X-API-Key header.
@@ -133,6 +154,26 @@ func GetAPIKeyFromContext(ctx context.Context) *models.APIKey {
 	return nil
 }
 
+// GetCertificateFromContext retrieves the client certificate from the context.
+func GetCertificateFromContext(ctx context.Context) *models.Certificate {
+	if cert, ok := ctx.Value(CertificateContextKey).(*models.Certificate); ok {
+		return cert
+	}
+	return nil
+}
+
+// AllowsSigningKey checks if the API key or client certificate in the
+// context, if any, may sign with key. Other requests are not restricted.
+func AllowsSigningKey(ctx context.Context, key *models.Key) bool {
+	if apiKey := GetAPIKeyFromContext(ctx); apiKey != nil && !apiKey.AllowsKey(key) {
+		return false
+	}
+	if cert := GetCertificateFromContext(ctx); cert != nil && !cert.AllowsKey(key) {
+		return false
+	}
+	return true
+}
+
 // GetOrgIDFromContext retrieves the organization ID from the context.
 // Returns the org ID as a UUID, or uuid.Nil if not present.
 func GetOrgIDFromContext(ctx context.Context) uuid.UUID {
@@ -160,6 +201,16 @@ func GetAPIKeyIDFromContext(ctx context.Context) string {
 	return ""
 }
 
+// GetCertificateIDFromContext retrieves the client certificate ID from the context.
+func GetCertificateIDFromContext(ctx context.Context) string {
+	if v := ctx.Value(CertificateIDKey); v != nil {
+		if certID, ok := v.(string); ok {
+			return certID
+		}
+	}
+	return ""
+}
+
 // GetScopesFromContext retrieves the scopes from the context.
 func GetScopesFromContext(ctx context.Context) []string {
 	if v := ctx.Value(ScopesContextKey); v != nil {
@@ -177,17 +228,15 @@ func SetOrgIDInContext(ctx context.Context, orgID uuid.UUID) context.Context {
 }
 
 // TrackAPIUsage returns a middleware that tracks API usage by incrementing
-// the "api_calls" metric for authenticated requests. Must be used after APIKeyAuth.
-func TrackAPIUsage(usageRepo repository.UsageRepository) func(http.Handler) http.Handler {
+// the "api_calls" metric for authenticated requests. Counts are buffered in
+// usage and written in batches. Must be used after APIKeyAuth.
+func TrackAPIUsage(usage *UsageAggregator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Get org ID from context (set by APIKeyAuth)
 			orgID := GetOrgIDFromContext(r.Context())
-			if orgID != uuid.Nil && usageRepo != nil {
-				// Increment API call counter asynchronously to not block the request
-				go func() {
-					_ = usageRepo.Increment(context.Background(), orgID, "api_calls", 1)
-				}()
+			if orgID != uuid.Nil && usage != nil {
+				usage.Add(orgID, string(models.MetricTypeAPICalls), 1)
 			}
 
 			next.ServeHTTP(w, r)
@@ -211,8 +260,8 @@ func OptionalAPIKeyAuth(apiKeyService service.APIKeyService) func(http.Handler)
 
 			// Try to validate API key
 			apiKey, err := apiKeyService.Validate(r.Context(), rawKey)
-			if err != nil {
-				// Invalid key, continue without auth
+			if err != nil || !apiKey.AllowsIP(ClientIP(r)) {
+				// Invalid key or disallowed IP, continue without auth
 				next.ServeHTTP(w, r)
 				return
 			}
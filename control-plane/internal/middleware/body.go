@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodyBytes bounds how much of a request body the RPC rate limiter
+// and sign quota buffer to inspect it. It matches the JSON-RPC handler's
+// default limit.
+const DefaultMaxBodyBytes = 2 << 20 // 2 MiB
+
+// bufferBody reads up to limit bytes of the request body and replaces it with
+// a copy, so handlers further down can read it again. Zero or less uses
+// DefaultMaxBodyBytes. Larger bodies fail with an *http.MaxBytesError.
+func bufferBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// isBodyTooLarge reports whether err is from a body over its size limit.
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
@@ -7,15 +7,50 @@ import (
 	"github.com/go-chi/cors"
 )
 
-// CORS returns a configured CORS middleware handler.
-func CORS() func(next http.Handler) http.Handler {
-	return cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:*", "https://*.popsigner.com"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-API-Key"},
-		ExposedHeaders:   []string{"X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
-		AllowCredentials: true,
-		MaxAge:           300, // Maximum value not ignored by any of major browsers
-	})
+// DefaultCORSMethods are the methods allowed cross-origin when none are configured.
+var DefaultCORSMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// DefaultCORSHeaders are the request headers allowed cross-origin when none
+// are configured.
+var DefaultCORSHeaders = []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-API-Key", IdempotencyKeyHeader}
+
+// CORSConfig configures cross-origin requests.
+type CORSConfig struct {
+	// AllowedOrigins lists origins allowed to make cross-origin requests. An
+	// origin may contain one "*" wildcard, e.g. "https://*.popsigner.com".
+	// If empty, no cross-origin requests are allowed.
+	AllowedOrigins []string
+	// AllowedMethods defaults to DefaultCORSMethods.
+	AllowedMethods []string
+	// AllowedHeaders defaults to DefaultCORSHeaders.
+	AllowedHeaders []string
+	// AllowCredentials lets allowed origins send cookies and read responses
+	// to credentialed requests.
+	AllowCredentials bool
 }
 
+// CORS returns a CORS middleware handler for cfg. Preflight (OPTIONS)
+// requests are answered by the middleware and not passed on. Requests from
+// origins that aren't allowed get no Access-Control-Allow-Origin header, so
+// browsers keep them same-origin.
+func CORS(cfg CORSConfig) func(next http.Handler) http.Handler {
+	opts := cors.Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   cfg.AllowedMethods,
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   []string{"X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", QuotaLimitHeader, QuotaRemainingHeader, QuotaResetHeader, QuotaWarningHeader, IdempotentReplayedHeader},
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           300, // Maximum value not ignored by any of major browsers
+	}
+	if len(opts.AllowedMethods) == 0 {
+		opts.AllowedMethods = DefaultCORSMethods
+	}
+	if len(opts.AllowedHeaders) == 0 {
+		opts.AllowedHeaders = DefaultCORSHeaders
+	}
+	if len(opts.AllowedOrigins) == 0 {
+		// The cors package allows every origin when the list is empty
+		opts.AllowOriginFunc = func(r *http.Request, origin string) bool { return false }
+	}
+	return cors.Handler(opts)
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func corsRequest(h http.Handler, method, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/v1/keys", nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "Content-Type, Idempotency-Key")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := CORS(CORSConfig{
+		AllowedOrigins:   []string{"https://dashboard.popsigner.com", "https://*.example.com"},
+		AllowCredentials: true,
+	})(next)
+
+	for _, origin := range []string{"https://dashboard.popsigner.com", "https://app.example.com"} {
+		rec := corsRequest(h, http.MethodGet, origin)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, origin, rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	}
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := CORS(CORSConfig{AllowedOrigins: []string{"https://dashboard.popsigner.com"}})(next)
+
+	rec := corsRequest(h, http.MethodGet, "https://evil.example")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = corsRequest(h, http.MethodOptions, "https://evil.example")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_NoOriginsIsSameOriginOnly(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := CORS(CORSConfig{})(next)
+
+	rec := corsRequest(h, http.MethodGet, "http://localhost:3000")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	h := CORS(CORSConfig{AllowedOrigins: []string{"https://dashboard.popsigner.com"}})(next)
+
+	rec := corsRequest(h, http.MethodOptions, "https://dashboard.popsigner.com")
+
+	assert.False(t, called, "preflight should not reach the handler")
+	assert.Equal(t, "https://dashboard.popsigner.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "Idempotency-Key")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// Drainer coordinates a graceful shutdown. Once Drain is called the server
+// reports itself not ready and stops accepting new sign requests, while sign
+// requests already in flight run to completion.
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	active   int
+	idle     chan struct{} // closed once draining with no active requests
+}
+
+// NewDrainer creates a Drainer that is accepting requests.
+func NewDrainer() *Drainer {
+	return &Drainer{idle: make(chan struct{})}
+}
+
+// Begin registers an in-flight request. It returns false if the server is
+// draining, in which case the request must be rejected and Done not called.
+func (d *Drainer) Begin() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.active++
+	return true
+}
+
+// Done marks a request registered with Begin as finished.
+func (d *Drainer) Done() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active--
+	if d.draining && d.active == 0 {
+		close(d.idle)
+	}
+}
+
+// Draining reports whether Drain has been called.
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// Drain stops new requests from being accepted and waits until in-flight
+// requests finish or ctx is done. Call it before http.Server.Shutdown.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		if d.active == 0 {
+			close(d.idle)
+		}
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Track rejects requests with 503 while draining and otherwise counts them
+// as in flight until they complete.
+func (d *Drainer) Track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.Begin() {
+			w.Header().Set("Retry-After", "1")
+			response.Error(w, apierrors.ErrServiceUnavailable.WithMessage("Server is shutting down"))
+			return
+		}
+		defer d.Done()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Ready wraps a readiness check so it fails with 503 while draining, taking
+// the server out of load balancer rotation.
+func (d *Drainer) Ready(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if d.Draining() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"status":"draining"}`))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainer_InFlightSignCompletes(t *testing.T) {
+	d := NewDrainer()
+
+	ready := d.Ready(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	sign := d.Track(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	ready.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// Start a sign and hold it in flight
+	signRec := httptest.NewRecorder()
+	signDone := make(chan struct{})
+	go func() {
+		sign.ServeHTTP(signRec, httptest.NewRequest(http.MethodPost, "/v1/keys/abc/sign", nil))
+		close(signDone)
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- d.Drain(context.Background()) }()
+	require.Eventually(t, d.Draining, time.Second, time.Millisecond)
+
+	// Not ready while draining
+	rec = httptest.NewRecorder()
+	ready.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"draining"}`, rec.Body.String())
+
+	// New signs are rejected
+	rec = httptest.NewRecorder()
+	sign.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/keys/abc/sign", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// Drain waits for the in-flight sign
+	select {
+	case <-drained:
+		t.Fatal("Drain returned with a sign in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-signDone
+	assert.Equal(t, http.StatusOK, signRec.Code)
+	require.NoError(t, <-drained)
+}
+
+func TestDrainer_DrainIdle(t *testing.T) {
+	d := NewDrainer()
+	require.NoError(t, d.Drain(context.Background()))
+	// A second call is a no-op
+	require.NoError(t, d.Drain(context.Background()))
+	assert.False(t, d.Begin())
+}
+
+func TestDrainer_DrainTimeout(t *testing.T) {
+	d := NewDrainer()
+	require.True(t, d.Begin())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, d.Drain(ctx), context.DeadlineExceeded)
+
+	d.Done()
+	require.NoError(t, d.Drain(context.Background()))
+}
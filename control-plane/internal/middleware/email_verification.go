@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// UserFunc returns the signed-in user for a request. It returns nil after
+// writing its own response, e.g. a redirect to the login page.
+type UserFunc func(w http.ResponseWriter, r *http.Request) *models.User
+
+// RequireVerifiedEmail returns a middleware that rejects requests from users
+// who haven't verified their email address yet. The message is also sent in
+// the X-Toast-Message header so the dashboard can show it.
+func RequireVerifiedEmail(currentUser UserFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := currentUser(w, r)
+			if user == nil {
+				return
+			}
+
+			if !user.EmailVerified {
+				w.Header().Set("X-Toast-Message", apierrors.ErrEmailNotVerified.Message)
+				w.Header().Set("X-Toast-Variant", "error")
+				response.Error(w, apierrors.ErrEmailNotVerified)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
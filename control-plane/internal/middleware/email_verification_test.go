@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	created := 0
+	handler := RequireVerifiedEmail(func(w http.ResponseWriter, r *http.Request) *models.User {
+		return user
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	createKey := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/keys", nil))
+		return rec
+	}
+
+	// Unverified users can't create keys and are told why
+	rec := createKey()
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	var body struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Error.Code != "email_not_verified" {
+		t.Errorf("Code = %q, want email_not_verified", body.Error.Code)
+	}
+	if body.Error.Message == "" || rec.Header().Get("X-Toast-Message") != body.Error.Message {
+		t.Errorf("X-Toast-Message = %q, want %q", rec.Header().Get("X-Toast-Message"), body.Error.Message)
+	}
+	if created != 0 {
+		t.Fatal("handler ran for an unverified user")
+	}
+
+	// Once verified, the same user can create keys
+	user.EmailVerified = true
+	rec = createKey()
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if created != 1 {
+		t.Fatalf("handler ran %d times, want 1", created)
+	}
+}
+
+func TestRequireVerifiedEmail_NoUser(t *testing.T) {
+	handler := RequireVerifiedEmail(func(w http.ResponseWriter, r *http.Request) *models.User {
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return nil
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler ran without a user")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/keys", nil))
+	if rec.Code != http.StatusFound {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusFound)
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client-chosen
+// idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed from a stored result.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long a stored result can be replayed.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLen bounds the header so it can't bloat Redis keys.
+const maxIdempotencyKeyLen = 255
+
+// maxIdempotentBodyBytes bounds how much of a request body is buffered to
+// fingerprint it.
+const maxIdempotentBodyBytes = 1 << 20
+
+// IdempotencyStore stores idempotency records.
+// database.Redis satisfies it.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// idempotencyRecord is the stored state of an idempotent request. Status is
+// zero while the first request is still being handled.
+type idempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first successful response for a key is stored for ttl and
+// replayed for later requests with the same key, instead of running the
+// handler again. Keys are scoped to the organization and API key.
+//
+// Stored responses are replayed verbatim, so only mount this on routes whose
+// responses are safe to keep, such as key creation, and after the auth and
+// role checks so a replay can't skip them.
+//
+// A key reused with a different method, path or body is rejected, as is a
+// retry that arrives while the first request is still in flight. Failed
+// requests are not stored, so they can be retried with the same key. If the
+// store is unavailable, requests are handled without idempotency.
+func Idempotency(store IdempotencyStore, ttl time.Duration) func(next http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idemKey := r.Header.Get(IdempotencyKeyHeader)
+			if idemKey == "" || r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idemKey) > maxIdempotencyKeyLen {
+				response.Error(w, apierrors.NewValidationError(IdempotencyKeyHeader, fmt.Sprintf("must be at most %d characters", maxIdempotencyKeyLen)))
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodyBytes+1))
+			if err != nil {
+				response.Error(w, apierrors.ErrBadRequest.WithMessage("Failed to read request body"))
+				return
+			}
+			if len(body) > maxIdempotentBodyBytes {
+				response.Error(w, apierrors.ErrBadRequest.WithMessage("Request body too large"))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			storeKey := fmt.Sprintf("idempotency:%s:%s:%s", GetOrgIDFromContext(ctx), GetAPIKeyIDFromContext(ctx), idemKey)
+			fingerprint := requestFingerprint(r.Method, r.URL.Path, body)
+
+			pending, _ := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+			claimed, err := store.SetNX(ctx, storeKey, pending, ttl)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !claimed {
+				raw, err := store.Get(ctx, storeKey)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				var rec idempotencyRecord
+				if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+					response.Error(w, apierrors.ErrInternal)
+					return
+				}
+
+				switch {
+				case rec.Fingerprint != fingerprint:
+					response.Error(w, apierrors.ErrBadRequest.WithMessage("Idempotency-Key was already used for a different request"))
+				case rec.Status == 0:
+					response.Error(w, apierrors.NewConflictError("A request with this Idempotency-Key is still being processed"))
+				default:
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set(IdempotentReplayedHeader, "true")
+					w.WriteHeader(rec.Status)
+					w.Write(rec.Body)
+				}
+				return
+			}
+
+			rec := &recordingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Don't let a cancelled client request stop the result being recorded
+			storeCtx := context.WithoutCancel(ctx)
+			if rec.status < 200 || rec.status >= 300 {
+				_ = store.Delete(storeCtx, storeKey)
+				return
+			}
+
+			done, _ := json.Marshal(idempotencyRecord{
+				Fingerprint: fingerprint,
+				Status:      rec.status,
+				Body:        rec.body.Bytes(),
+			})
+			_ = store.Set(storeCtx, storeKey, done, ttl)
+		})
+	}
+}
+
+// requestFingerprint identifies a request by method, path and body.
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingResponseWriter captures the status and body written through it.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = code
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
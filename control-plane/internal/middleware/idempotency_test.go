@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// memoryIdempotencyStore is an in-memory IdempotencyStore standing in for Redis.
+type memoryIdempotencyStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{values: make(map[string]string)}
+}
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return v, nil
+}
+
+func (s *memoryIdempotencyStore) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = string(value.([]byte))
+	return nil
+}
+
+func (s *memoryIdempotencyStore) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = string(value.([]byte))
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		delete(s.values, k)
+	}
+	return nil
+}
+
+// keyCreator stands in for the key create handler, minting a new key ID
+// per call.
+type keyCreator struct {
+	calls  int
+	status int
+}
+
+func (c *keyCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.calls++
+	if c.status != 0 {
+		w.WriteHeader(c.status)
+		return
+	}
+	response.Created(w, map[string]string{"id": uuid.New().String()})
+}
+
+func idempotentCreate(t *testing.T, h http.Handler, orgID uuid.UUID, idemKey, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/keys", strings.NewReader(body))
+	if idemKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idemKey)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), OrgIDKey, orgID.String()))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func createdKeyID(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp.Data.ID
+}
+
+func TestIdempotency_ReplaysCreate(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+	orgID := uuid.New()
+	body := `{"name":"sequencer","namespace_id":"` + uuid.New().String() + `"}`
+
+	first := idempotentCreate(t, h, orgID, "retry-1", body)
+	second := idempotentCreate(t, h, orgID, "retry-1", body)
+
+	require.Equal(t, http.StatusCreated, first.Code)
+	require.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, createdKeyID(t, first), createdKeyID(t, second))
+	assert.Equal(t, 1, creator.calls)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+	orgID := uuid.New()
+
+	first := idempotentCreate(t, h, orgID, "", `{"name":"a"}`)
+	second := idempotentCreate(t, h, orgID, "", `{"name":"a"}`)
+
+	assert.NotEqual(t, createdKeyID(t, first), createdKeyID(t, second))
+	assert.Equal(t, 2, creator.calls)
+}
+
+func TestIdempotency_ScopedToOrg(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+
+	first := idempotentCreate(t, h, uuid.New(), "shared", `{"name":"a"}`)
+	second := idempotentCreate(t, h, uuid.New(), "shared", `{"name":"a"}`)
+
+	assert.NotEqual(t, createdKeyID(t, first), createdKeyID(t, second))
+	assert.Equal(t, 2, creator.calls)
+}
+
+func TestIdempotency_ScopedToAPIKey(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+	orgID := uuid.New()
+
+	create := func(apiKeyID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/keys", strings.NewReader(`{"name":"a"}`))
+		req.Header.Set(IdempotencyKeyHeader, "shared")
+		ctx := context.WithValue(req.Context(), OrgIDKey, orgID.String())
+		ctx = context.WithValue(ctx, APIKeyIDKey, apiKeyID)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req.WithContext(ctx))
+		return rec
+	}
+
+	first := create(uuid.New().String())
+	second := create(uuid.New().String())
+
+	assert.NotEqual(t, createdKeyID(t, first), createdKeyID(t, second))
+	assert.Equal(t, 2, creator.calls)
+}
+
+func TestIdempotency_RejectsDifferentRequest(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+	orgID := uuid.New()
+
+	idempotentCreate(t, h, orgID, "retry-1", `{"name":"a"}`)
+	rec := idempotentCreate(t, h, orgID, "retry-1", `{"name":"b"}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 1, creator.calls)
+}
+
+func TestIdempotency_InFlight(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	orgID := uuid.New()
+	body := `{"name":"a"}`
+	record, _ := json.Marshal(idempotencyRecord{Fingerprint: requestFingerprint(http.MethodPost, "/v1/keys", []byte(body))})
+	store.values[fmt.Sprintf("idempotency:%s::retry-1", orgID)] = string(record)
+
+	creator := &keyCreator{}
+	rec := idempotentCreate(t, Idempotency(store, time.Hour)(creator), orgID, "retry-1", body)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 0, creator.calls)
+}
+
+func TestIdempotency_FailuresNotStored(t *testing.T) {
+	creator := &keyCreator{status: http.StatusInternalServerError}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+	orgID := uuid.New()
+
+	rec := idempotentCreate(t, h, orgID, "retry-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	creator.status = 0
+	rec = idempotentCreate(t, h, orgID, "retry-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, creator.calls)
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	creator := &keyCreator{}
+	h := Idempotency(newMemoryIdempotencyStore(), time.Hour)(creator)
+
+	rec := idempotentCreate(t, h, uuid.New(), strings.Repeat("k", maxIdempotencyKeyLen+1), `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 0, creator.calls)
+}
@@ -87,6 +87,14 @@ var (
 		},
 	)
 
+	// Session metrics
+	activeSessions = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "popsigner_sessions_active",
+			Help: "Number of unexpired dashboard sessions",
+		},
+	)
+
 	// Error metrics
 	errorsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -177,7 +185,11 @@ func Metrics() func(next http.Handler) http.Handler {
 				visitorsUnique.Inc()
 			}
 
-			// Get normalized path for metrics (avoid cardinality explosion)
+			// Execute handler
+			next.ServeHTTP(wrapped, r)
+
+			// Get normalized path for metrics (avoid cardinality explosion).
+			// The chi route pattern is only known once routing has run.
 			path := normalizePath(r)
 
 			// Track page views for web pages (not API or static)
@@ -190,9 +202,6 @@ func Metrics() func(next http.Handler) http.Handler {
 				apiCallsTotal.WithLabelValues(r.Method, path).Inc()
 			}
 
-			// Execute handler
-			next.ServeHTTP(wrapped, r)
-
 			// Record metrics
 			duration := time.Since(start).Seconds()
 			status := strconv.Itoa(wrapped.status)
@@ -204,9 +213,6 @@ func Metrics() func(next http.Handler) http.Handler {
 			if r.Method == "POST" && strings.Contains(r.URL.Path, "/sign") && wrapped.status == http.StatusOK {
 				signingOperationsTotal.Inc()
 			}
-			if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/keys") && wrapped.status == http.StatusCreated {
-				keysCreatedTotal.Inc()
-			}
 
 			// Track errors
 			if wrapped.status >= 400 {
@@ -238,14 +244,8 @@ func (w *metricsResponseWriter) WriteHeader(code int) {
 // getVisitorFingerprint creates a privacy-respecting fingerprint.
 // Uses IP + User-Agent hash, not tracking cookies.
 func getVisitorFingerprint(r *http.Request) string {
-	// Get real IP (respects X-Forwarded-For from middleware)
-	ip := r.RemoteAddr
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		ip = strings.Split(forwarded, ",")[0]
-	}
-	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-		ip = realIP
-	}
+	// Real IP as resolved by RealIP from trusted proxies
+	ip := getRealIP(r)
 
 	// Create fingerprint from IP + User-Agent
 	data := ip + "|" + r.UserAgent()
@@ -316,10 +316,15 @@ func IncrementSigningOps() {
 	signingOperationsTotal.Inc()
 }
 
-// IncrementKeysCreated increments the keys created counter.
-// Call this from key handlers for more accurate tracking.
-func IncrementKeysCreated() {
-	keysCreatedTotal.Inc()
+// AddKeysCreated adds n to the keys created counter.
+// Key handlers call this for single, batch and imported keys.
+func AddKeysCreated(n int) {
+	keysCreatedTotal.Add(float64(n))
+}
+
+// SetActiveSessions sets the active sessions gauge.
+func SetActiveSessions(n int64) {
+	activeSessions.Set(float64(n))
 }
 
 
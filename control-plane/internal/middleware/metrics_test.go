@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetrics_RecordsRouteMetrics(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Metrics())
+	r.Get("/v1/keys/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Post("/v1/keys", func(w http.ResponseWriter, r *http.Request) {
+		AddKeysCreated(1)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/v1/keys/550e8400-e29b-41d4-a716-446655440000", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/keys", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	SetActiveSessions(3)
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	byName := make(map[string]*dto.MetricFamily, len(families))
+	for _, f := range families {
+		byName[f.GetName()] = f
+	}
+
+	for _, name := range []string{
+		"popsigner_http_requests_total",
+		"popsigner_http_request_duration_seconds",
+		"popsigner_keys_created_total",
+		"popsigner_sessions_active",
+	} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("metric family %q not registered", name)
+		}
+	}
+
+	requests := byName["popsigner_http_requests_total"]
+	if requests == nil {
+		t.FailNow()
+	}
+	found := false
+	for _, m := range requests.GetMetric() {
+		labels := map[string]string{}
+		for _, l := range m.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["method"] == http.MethodGet && labels["path"] == "/v1/keys/{id}" && labels["status"] == "200" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected request counter labelled with the chi route pattern")
+	}
+
+	if got := byName["popsigner_sessions_active"].GetMetric()[0].GetGauge().GetValue(); got != 3 {
+		t.Errorf("popsigner_sessions_active = %v, want 3", got)
+	}
+	if got := byName["popsigner_keys_created_total"].GetMetric()[0].GetCounter().GetValue(); got < 1 {
+		t.Errorf("popsigner_keys_created_total = %v, want >= 1", got)
+	}
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// OrgLookup looks up organizations.
+// repository.OrgRepository satisfies it.
+type OrgLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+}
+
+// DefaultPlanCacheTTL is how long a resolved organization plan is cached.
+const DefaultPlanCacheTTL = time.Minute
+
+// PlanResolver resolves the plan of an organization for rate limiting.
+// Plans are cached in memory so the limiter doesn't hit the database on
+// every request; plan changes take effect once the cache entry expires.
+type PlanResolver struct {
+	orgs OrgLookup
+	ttl  time.Duration
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedPlan
+}
+
+type cachedPlan struct {
+	plan      models.Plan
+	expiresAt time.Time
+}
+
+// NewPlanResolver creates a plan resolver backed by the given organization lookup.
+func NewPlanResolver(orgs OrgLookup, ttl time.Duration) *PlanResolver {
+	return &PlanResolver{
+		orgs:  orgs,
+		ttl:   ttl,
+		cache: make(map[uuid.UUID]cachedPlan),
+	}
+}
+
+// Plan returns the plan of the organization. It returns false if the
+// organization cannot be resolved.
+func (p *PlanResolver) Plan(ctx context.Context, orgID uuid.UUID) (models.Plan, bool) {
+	if p == nil || orgID == uuid.Nil {
+		return "", false
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	if cached, ok := p.cache[orgID]; ok && now.Before(cached.expiresAt) {
+		p.mu.Unlock()
+		return cached.plan, true
+	}
+	p.mu.Unlock()
+
+	org, err := p.orgs.GetByID(ctx, orgID)
+	if err != nil || org == nil {
+		return "", false
+	}
+
+	p.mu.Lock()
+	p.cache[orgID] = cachedPlan{plan: org.Plan, expiresAt: now.Add(p.ttl)}
+	p.mu.Unlock()
+
+	return org.Plan, true
+}
+
+// PlanRateLimitConfig selects API rate limits by organization plan.
+type PlanRateLimitConfig struct {
+	// Default applies to requests without an organization or with an unknown plan.
+	Default RateLimitConfig
+	// Plans holds the limits for each plan.
+	Plans map[models.Plan]RateLimitConfig
+}
+
+// DefaultPlanRateLimitConfig returns the default plan rate limits.
+// The free plan keeps the default limits.
+func DefaultPlanRateLimitConfig() PlanRateLimitConfig {
+	return PlanRateLimitConfig{
+		Default: DefaultRateLimitConfig(),
+		Plans: map[models.Plan]RateLimitConfig{
+			models.PlanFree:       DefaultRateLimitConfig(),
+			models.PlanPro:        {RequestsPerMinute: 600, BurstSize: 100},
+			models.PlanEnterprise: {RequestsPerMinute: 6000, BurstSize: 1000},
+		},
+	}
+}
+
+// ForPlan returns the limits for a plan, falling back to the default.
+func (c PlanRateLimitConfig) ForPlan(plan models.Plan) RateLimitConfig {
+	if limits, ok := c.Plans[plan]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+// PlanRateLimit returns a rate limiting middleware that selects limits by the
+// plan of the authenticated organization. Requests are counted per API key,
+// or per client for other authentication methods. Must be used after the
+// authentication middleware that sets the organization in the context.
+func PlanRateLimit(counter RateLimitCounter, plans *PlanResolver, cfg PlanRateLimitConfig) func(next http.Handler) http.Handler {
+	clientID := func(r *http.Request) string {
+		if apiKeyID, ok := r.Context().Value(APIKeyIDKey).(string); ok && apiKeyID != "" {
+			return "apikey:" + apiKeyID
+		}
+		return getClientID(r)
+	}
+	limitsFor := func(r *http.Request) RateLimitConfig {
+		ctx := r.Context()
+		if plan, ok := plans.Plan(ctx, GetOrgIDFromContext(ctx)); ok {
+			return cfg.ForPlan(plan)
+		}
+		return cfg.Default
+	}
+	return limitRequests(counter, clientID, limitsFor)
+}
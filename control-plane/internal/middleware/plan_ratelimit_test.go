@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// memoryCounter is an in-memory RateLimitCounter and SignQuotaCounter
+// standing in for Redis.
+type memoryCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (c *memoryCounter) IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[key]++
+	return c.counts[key], nil
+}
+
+func (c *memoryCounter) IncrByWithExpire(ctx context.Context, key string, n int64, expiration time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int64)
+	}
+	c.counts[key] += n
+	return c.counts[key], nil
+}
+
+// staticOrgs is an OrgLookup backed by a map that counts lookups.
+type staticOrgs struct {
+	orgs    map[uuid.UUID]*models.Organization
+	lookups int
+}
+
+func (s *staticOrgs) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	s.lookups++
+	return s.orgs[id], nil
+}
+
+func TestPlanRateLimit_LimitsByPlan(t *testing.T) {
+	freeOrg := &models.Organization{ID: uuid.New(), Plan: models.PlanFree}
+	proOrg := &models.Organization{ID: uuid.New(), Plan: models.PlanPro}
+	orgs := &staticOrgs{orgs: map[uuid.UUID]*models.Organization{
+		freeOrg.ID: freeOrg,
+		proOrg.ID:  proOrg,
+	}}
+
+	cfg := PlanRateLimitConfig{
+		Default: RateLimitConfig{RequestsPerMinute: 5, BurstSize: 0},
+		Plans: map[models.Plan]RateLimitConfig{
+			models.PlanFree: {RequestsPerMinute: 2, BurstSize: 1},
+			models.PlanPro:  {RequestsPerMinute: 10, BurstSize: 0},
+		},
+	}
+	// Both keys share one limiter
+	limiter := PlanRateLimit(&memoryCounter{}, NewPlanResolver(orgs, time.Minute), cfg)
+	handler := limiter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(org *models.Organization, apiKeyID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/keys", nil)
+		ctx := context.WithValue(req.Context(), OrgIDKey, org.ID.String())
+		ctx = context.WithValue(ctx, APIKeyIDKey, apiKeyID)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+		return w
+	}
+
+	// Free: 2 requests plus a burst of 1
+	for i := 0; i < 3; i++ {
+		w := send(freeOrg, "free-key")
+		assert.Equal(t, http.StatusOK, w.Code, "free request %d", i+1)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, send(freeOrg, "free-key").Code)
+
+	// Pro: 10 requests
+	for i := 0; i < 10; i++ {
+		w := send(proOrg, "pro-key")
+		assert.Equal(t, http.StatusOK, w.Code, "pro request %d", i+1)
+		assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, send(proOrg, "pro-key").Code)
+
+	// Plans are cached after the first lookup
+	assert.Equal(t, 2, orgs.lookups)
+}
+
+func TestPlanRateLimit_FallsBackToDefault(t *testing.T) {
+	cfg := PlanRateLimitConfig{
+		Default: RateLimitConfig{RequestsPerMinute: 1, BurstSize: 0},
+		Plans: map[models.Plan]RateLimitConfig{
+			models.PlanPro: {RequestsPerMinute: 10, BurstSize: 0},
+		},
+	}
+	orgs := &staticOrgs{orgs: map[uuid.UUID]*models.Organization{}}
+	handler := PlanRateLimit(&memoryCounter{}, NewPlanResolver(orgs, time.Minute), cfg)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	// Unknown organization
+	req := httptest.NewRequest(http.MethodGet, "/v1/keys", nil)
+	req = req.WithContext(context.WithValue(req.Context(), OrgIDKey, uuid.New().String()))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestPlanRateLimitConfig_ForPlan(t *testing.T) {
+	cfg := DefaultPlanRateLimitConfig()
+
+	assert.Equal(t, DefaultRateLimitConfig(), cfg.ForPlan(models.PlanFree))
+	assert.Greater(t, cfg.ForPlan(models.PlanPro).RequestsPerMinute, cfg.ForPlan(models.PlanFree).RequestsPerMinute)
+	assert.Greater(t, cfg.ForPlan(models.PlanEnterprise).RequestsPerMinute, cfg.ForPlan(models.PlanPro).RequestsPerMinute)
+	assert.Equal(t, cfg.Default, cfg.ForPlan(models.Plan("unknown")))
+}
+
+func TestRPCRateLimitConfig_RequestsPerSecond(t *testing.T) {
+	freeOrg := &models.Organization{ID: uuid.New(), Plan: models.PlanFree}
+	enterpriseOrg := &models.Organization{ID: uuid.New(), Plan: models.PlanEnterprise}
+	orgs := &staticOrgs{orgs: map[uuid.UUID]*models.Organization{
+		freeOrg.ID:       freeOrg,
+		enterpriseOrg.ID: enterpriseOrg,
+	}}
+
+	cfg := RPCRateLimitConfig{
+		RequestsPerSecond: 50,
+		Plans: map[models.Plan]int{
+			models.PlanFree:       10,
+			models.PlanEnterprise: 1000,
+		},
+		PlanResolver: NewPlanResolver(orgs, time.Minute),
+	}
+
+	orgCtx := func(id uuid.UUID) context.Context {
+		return context.WithValue(context.Background(), OrgIDKey, id.String())
+	}
+
+	assert.Equal(t, 10, cfg.requestsPerSecond(orgCtx(freeOrg.ID)))
+	assert.Equal(t, 1000, cfg.requestsPerSecond(orgCtx(enterpriseOrg.ID)))
+	assert.Equal(t, 50, cfg.requestsPerSecond(context.Background()))
+
+	// Without a resolver every request gets the default
+	cfg.PlanResolver = nil
+	assert.Equal(t, 50, cfg.requestsPerSecond(orgCtx(enterpriseOrg.ID)))
+}
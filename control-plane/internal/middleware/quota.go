@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// Sign quota response headers.
+const (
+	QuotaLimitHeader     = "X-Quota-Limit"
+	QuotaRemainingHeader = "X-Quota-Remaining"
+	QuotaResetHeader     = "X-Quota-Reset"
+	// QuotaWarningHeader is set once an organization passes its soft limit.
+	QuotaWarningHeader = "X-Quota-Warning"
+)
+
+// DefaultSoftQuotaRatio is the share of the monthly sign quota after which
+// responses carry a warning.
+const DefaultSoftQuotaRatio = 0.8
+
+// SignQuotaCounter counts signs against the monthly quota.
+// database.Redis satisfies it.
+type SignQuotaCounter interface {
+	IncrByWithExpire(ctx context.Context, key string, n int64, expiration time.Duration) (int64, error)
+}
+
+// SignQuotaConfig configures monthly sign quota enforcement. The hard limit
+// is the plan's SignaturesPerMonth; plans with no limit are not counted.
+type SignQuotaConfig struct {
+	// PlanResolver resolves the plan of the authenticated organization.
+	// Requests whose plan can't be resolved are let through uncounted.
+	PlanResolver *PlanResolver
+	// SoftLimitRatio is the share of the hard limit after which responses
+	// carry QuotaWarningHeader. Defaults to DefaultSoftQuotaRatio.
+	SoftLimitRatio float64
+	// MaxBodyBytes bounds the request body read to count signs. Defaults to
+	// DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// SignQuota returns a middleware that counts signs against the
+// organization's monthly quota: one per request, or one per item for batch
+// requests with a "requests" array. Counters live in Redis under a
+// per-month key, so they roll over on the first of each month (UTC). Past
+// the soft limit responses carry a warning header; past the hard limit
+// requests are rejected with 402 quota_exceeded. Must be used after the
+// authentication middleware that sets the organization in the context.
+func SignQuota(counter SignQuotaCounter, cfg SignQuotaConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := bufferBody(w, r, cfg.MaxBodyBytes)
+			if err != nil {
+				if isBodyTooLarge(err) {
+					response.Error(w, apierrors.ErrBadRequest.WithMessage("Request body too large"))
+					return
+				}
+				response.Error(w, apierrors.ErrBadRequest.WithMessage("Failed to read request body"))
+				return
+			}
+
+			if !cfg.allow(counter, w, r, batchSignCount(body)) {
+				response.Error(w, apierrors.ErrQuotaExceeded.WithMessage("Monthly sign quota exceeded"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RPCSignQuota is SignQuota for the JSON-RPC endpoint. Each call to a signing
+// method counts as one sign, including each one in a batch, and rejections
+// are JSON-RPC errors.
+func RPCSignQuota(counter SignQuotaCounter, cfg SignQuotaConfig) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := bufferBody(w, r, cfg.MaxBodyBytes)
+			if err != nil {
+				if isBodyTooLarge(err) {
+					writeRPCError(w, -32600, "Request body too large")
+					return
+				}
+				writeRPCError(w, -32700, "Failed to read request")
+				return
+			}
+
+			if signs := rpcSignCount(body); signs > 0 && !cfg.allow(counter, w, r, signs) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusPaymentRequired)
+				w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32029,"message":"Monthly sign quota exceeded","data":"quota_exceeded"},"id":null}`))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allow counts signs against the organization's quota, sets the quota
+// headers and reports whether the request is within the hard limit.
+func (c SignQuotaConfig) allow(counter SignQuotaCounter, w http.ResponseWriter, r *http.Request, signs int64) bool {
+	ctx := r.Context()
+	orgID := GetOrgIDFromContext(ctx)
+	plan, ok := c.PlanResolver.Plan(ctx, orgID)
+	if !ok {
+		return true
+	}
+	limit := models.GetPlanLimits(plan).SignaturesPerMonth
+	if limit < 0 {
+		return true
+	}
+
+	now := time.Now().UTC()
+	reset := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	// Keep the counter a day past the month so late requests still see it
+	count, err := counter.IncrByWithExpire(ctx, signQuotaKey(orgID, now), signs, reset.Sub(now)+24*time.Hour)
+	if err != nil {
+		// Fail open, like the rate limiters
+		slog.Warn("Sign quota check failed",
+			slog.String("error", err.Error()),
+			slog.String("org_id", orgID.String()),
+		)
+		return true
+	}
+
+	w.Header().Set(QuotaLimitHeader, strconv.FormatInt(limit, 10))
+	w.Header().Set(QuotaRemainingHeader, strconv.FormatInt(max(limit-count, 0), 10))
+	w.Header().Set(QuotaResetHeader, strconv.FormatInt(reset.Unix(), 10))
+
+	if count > limit {
+		return false
+	}
+	if count > c.softLimit(limit) {
+		w.Header().Set(QuotaWarningHeader, fmt.Sprintf("%d of %d monthly signs used", count, limit))
+	}
+	return true
+}
+
+// softLimit returns the number of signs after which responses carry a warning.
+func (c SignQuotaConfig) softLimit(limit int64) int64 {
+	ratio := c.SoftLimitRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = DefaultSoftQuotaRatio
+	}
+	return int64(math.Floor(float64(limit) * ratio))
+}
+
+// signQuotaKey is the Redis key counting an organization's signs in the
+// month of t.
+func signQuotaKey(orgID uuid.UUID, t time.Time) string {
+	return fmt.Sprintf("sign_quota:%s:%s", orgID, t.Format("2006-01"))
+}
+
+// rpcSignMethods are the JSON-RPC methods that count against the sign quota.
+var rpcSignMethods = map[string]bool{
+	"eth_sign":                    true,
+	"eth_signTransaction":         true,
+	"personal_sign":               true,
+	"opsigner_signBlockPayload":   true,
+	"opsigner_signBlockPayloadV2": true,
+}
+
+// batchSignCount returns the number of signs a REST sign request makes: the
+// length of its "requests" array, or 1 for a single sign.
+func batchSignCount(body []byte) int64 {
+	var batch struct {
+		Requests []json.RawMessage `json:"requests"`
+	}
+	if err := json.Unmarshal(body, &batch); err != nil || len(batch.Requests) == 0 {
+		return 1
+	}
+	return int64(len(batch.Requests))
+}
+
+// rpcSignCount returns how many calls in a JSON-RPC request, or batch of
+// requests, call a signing method.
+func rpcSignCount(body []byte) int64 {
+	type rpcCall struct {
+		Method string `json:"method"`
+	}
+	var calls []rpcCall
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return 0
+		}
+	} else {
+		var call rpcCall
+		if err := json.Unmarshal(body, &call); err != nil {
+			return 0
+		}
+		calls = append(calls, call)
+	}
+	var signs int64
+	for _, call := range calls {
+		if rpcSignMethods[call.Method] {
+			signs++
+		}
+	}
+	return signs
+}
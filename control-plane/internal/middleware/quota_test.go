@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func newQuotaTest(plan models.Plan) (*models.Organization, *memoryCounter, SignQuotaConfig) {
+	org := &models.Organization{ID: uuid.New(), Plan: plan}
+	orgs := &staticOrgs{orgs: map[uuid.UUID]*models.Organization{org.ID: org}}
+	counter := &memoryCounter{counts: make(map[string]int64)}
+	return org, counter, SignQuotaConfig{PlanResolver: NewPlanResolver(orgs, time.Minute)}
+}
+
+func sendQuotaRequest(h http.Handler, orgID uuid.UUID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	req = req.WithContext(context.WithValue(req.Context(), OrgIDKey, orgID.String()))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSignQuota(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanFree)
+	limit := models.GetPlanLimits(models.PlanFree).SignaturesPerMonth
+	key := signQuotaKey(org.ID, time.Now().UTC())
+
+	h := SignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Below the soft limit
+	rec := sendQuotaRequest(h, org.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "10000", rec.Header().Get(QuotaLimitHeader))
+	assert.Equal(t, "9999", rec.Header().Get(QuotaRemainingHeader))
+	assert.NotEmpty(t, rec.Header().Get(QuotaResetHeader))
+	assert.Empty(t, rec.Header().Get(QuotaWarningHeader))
+
+	// Past the soft limit signs still pass but carry a warning
+	counter.counts[key] = limit * 9 / 10
+	rec = sendQuotaRequest(h, org.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(QuotaWarningHeader))
+
+	// The last sign within the quota passes
+	counter.counts[key] = limit - 1
+	rec = sendQuotaRequest(h, org.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(QuotaRemainingHeader))
+
+	// Past the hard limit signs are blocked
+	rec = sendQuotaRequest(h, org.ID, "")
+	assert.Equal(t, http.StatusPaymentRequired, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(QuotaRemainingHeader))
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "quota_exceeded", body.Error.Code)
+}
+
+func TestSignQuota_CountsBatchItems(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanFree)
+	key := signQuotaKey(org.ID, time.Now().UTC())
+
+	var gotBody string
+	h := SignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		gotBody = buf.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	batch := `{"requests":[{"key_id":"a","data":"aGk="},{"key_id":"b","data":"aGk="},{"key_id":"c","data":"aGk="}]}`
+	rec := sendQuotaRequest(h, org.ID, batch)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, batch, gotBody)
+	assert.EqualValues(t, 3, counter.counts[key])
+	assert.Equal(t, "9997", rec.Header().Get(QuotaRemainingHeader))
+
+	// A single sign counts once
+	sendQuotaRequest(h, org.ID, `{"data":"aGk="}`)
+	assert.EqualValues(t, 4, counter.counts[key])
+}
+
+func TestSignQuota_UnlimitedPlan(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanEnterprise)
+
+	h := SignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := sendQuotaRequest(h, org.ID, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(QuotaLimitHeader))
+	assert.Empty(t, counter.counts)
+}
+
+func TestRPCSignQuota(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanFree)
+	limit := models.GetPlanLimits(models.PlanFree).SignaturesPerMonth
+	key := signQuotaKey(org.ID, time.Now().UTC())
+
+	var gotBody string
+	h := RPCSignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(r.Body)
+		gotBody = buf.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	sign := `{"jsonrpc":"2.0","method":"eth_sign","params":["0x742d35Cc6634C0532925a3b844Bc454e4438f44e","0x48656c6c6f"],"id":1}`
+	accounts := `{"jsonrpc":"2.0","method":"eth_accounts","params":[],"id":1}`
+
+	// Signs are counted and the body reaches the handler intact
+	rec := sendQuotaRequest(h, org.ID, sign)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, sign, gotBody)
+	assert.Equal(t, "9999", rec.Header().Get(QuotaRemainingHeader))
+
+	// Other methods aren't
+	rec = sendQuotaRequest(h, org.ID, accounts)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(QuotaRemainingHeader))
+	assert.EqualValues(t, 1, counter.counts[key])
+
+	// Past the hard limit signs are blocked, other methods still work
+	counter.counts[key] = limit
+	rec = sendQuotaRequest(h, org.ID, "["+sign+"]")
+	assert.Equal(t, http.StatusPaymentRequired, rec.Code)
+	assert.Contains(t, rec.Body.String(), "quota_exceeded")
+
+	rec = sendQuotaRequest(h, org.ID, accounts)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRPCSignQuota_CountsBatchCalls(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanFree)
+	key := signQuotaKey(org.ID, time.Now().UTC())
+
+	h := RPCSignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	sign := `{"jsonrpc":"2.0","method":"eth_sign","params":[],"id":1}`
+	accounts := `{"jsonrpc":"2.0","method":"eth_accounts","params":[],"id":2}`
+
+	rec := sendQuotaRequest(h, org.ID, "["+sign+","+sign+","+accounts+","+sign+"]")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.EqualValues(t, 3, counter.counts[key])
+}
+
+func TestSignQuota_BodyTooLarge(t *testing.T) {
+	org, counter, cfg := newQuotaTest(models.PlanFree)
+	cfg.MaxBodyBytes = 16
+
+	body := `{"requests":[{"key_id":"a","data":"aGk="}]}`
+	h := SignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called for an oversized body")
+	}))
+	rec := sendQuotaRequest(h, org.ID, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	h = RPCSignQuota(counter, cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called for an oversized body")
+	}))
+	rec = sendQuotaRequest(h, org.ID, body)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, counter.counts)
+}
@@ -28,25 +28,37 @@ func DefaultRateLimitConfig() RateLimitConfig {
 	}
 }
 
+// RateLimitCounter counts requests in a fixed window.
+// database.Redis satisfies it.
+type RateLimitCounter interface {
+	IncrWithExpire(ctx context.Context, key string, expiration time.Duration) (int64, error)
+}
+
 // RateLimit returns a rate limiting middleware using Redis.
 func RateLimit(redis *database.Redis, cfg RateLimitConfig) func(next http.Handler) http.Handler {
+	return limitRequests(redis, getClientID, func(*http.Request) RateLimitConfig { return cfg })
+}
+
+// limitRequests returns a fixed-window rate limiting middleware. Requests are
+// counted per minute under the key from clientID and checked against the
+// limits selected for the request.
+func limitRequests(counter RateLimitCounter, clientID func(*http.Request) string, limitsFor func(*http.Request) RateLimitConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get client identifier (IP or API key)
-			clientID := getClientID(r)
-			key := fmt.Sprintf("ratelimit:%s", clientID)
+			key := fmt.Sprintf("ratelimit:%s", clientID(r))
 
 			ctx := r.Context()
 			windowDuration := time.Minute
 
 			// Increment counter and get current value
-			count, err := redis.IncrWithExpire(ctx, key, windowDuration)
+			count, err := counter.IncrWithExpire(ctx, key, windowDuration)
 			if err != nil {
 				// On Redis error, allow the request but log the error
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			cfg := limitsFor(r)
 			limit := cfg.RequestsPerMinute
 			remaining := limit - int(count)
 			if remaining < 0 {
@@ -88,61 +100,24 @@ func getClientID(r *http.Request) string {
 	return "ip:" + getRealIP(r)
 }
 
-// getRealIP extracts the real client IP, considering proxies.
+// getRealIP returns the client IP from RemoteAddr. Forwarding headers are
+// only honoured through RealIP, which rewrites RemoteAddr for trusted proxies.
 func getRealIP(r *http.Request) string {
-	// Check X-Forwarded-For header
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		return xff
-	}
-
-	// Check X-Real-IP header
-	if xrip := r.Header.Get("X-Real-IP"); xrip != "" {
-		return xrip
+	if ip := ClientIP(r); ip != nil {
+		return ip.String()
 	}
-
 	return r.RemoteAddr
 }
 
 // RateLimitByKey returns a rate limiter that uses a custom key extractor.
 func RateLimitByKey(redis *database.Redis, cfg RateLimitConfig, keyFunc func(*http.Request) string) func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			clientID := keyFunc(r)
-			if clientID == "" {
-				clientID = getClientID(r)
-			}
-
-			key := fmt.Sprintf("ratelimit:%s", clientID)
-			ctx := r.Context()
-			windowDuration := time.Minute
-
-			count, err := redis.IncrWithExpire(ctx, key, windowDuration)
-			if err != nil {
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			limit := cfg.RequestsPerMinute
-			remaining := limit - int(count)
-			if remaining < 0 {
-				remaining = 0
-			}
-
-			resetTime := time.Now().Add(windowDuration).Unix()
-
-			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit))
-			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
-			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
-
-			if int(count) > limit+cfg.BurstSize {
-				w.Header().Set("Retry-After", strconv.Itoa(60))
-				response.Error(w, apierrors.ErrRateLimited)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
+	clientID := func(r *http.Request) string {
+		if id := keyFunc(r); id != "" {
+			return id
+		}
+		return getClientID(r)
 	}
+	return limitRequests(redis, clientID, func(*http.Request) RateLimitConfig { return cfg })
 }
 
 // contextKey is a custom type for context keys to avoid collisions.
@@ -155,6 +130,9 @@ const (
 	UserIDKey contextKey = "user_id"
 	// APIKeyIDKey is the context key for API key ID.
 	APIKeyIDKey contextKey = "api_key_id"
+	// CertificateIDKey is the context key for the client certificate ID of
+	// mTLS-authenticated requests.
+	CertificateIDKey contextKey = "certificate_id"
 )
 
 // GetOrgID retrieves the organization ID from context.
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/response"
+)
+
+// RoleContextKey is the context key for the caller's role in the organization.
+const RoleContextKey contextKey = "org_role"
+
+// DefaultAPIKeyRole is the role of org-level API keys that were not created by
+// a member. It allows signing and creating keys but not deleting, rotating or
+// exporting them.
+const DefaultAPIKeyRole = models.RoleOperator
+
+// MemberLookup looks up organization memberships.
+// repository.OrgRepository satisfies it.
+type MemberLookup interface {
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMember, error)
+}
+
+// ResolveOrgRole returns a middleware that loads the caller's role in the
+// authenticated organization. The user is taken from the API key (the member
+// who created it) or, for user authentication, from the user ID in the context.
+// Requests from users who are no longer members are rejected. Org-level API
+// keys without a user act with DefaultAPIKeyRole.
+// Must be used after APIKeyAuth.
+func ResolveOrgRole(members MemberLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			orgID := GetOrgIDFromContext(ctx)
+
+			if orgID == uuid.Nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID := GetUserIDFromContext(ctx)
+			if apiKey := GetAPIKeyFromContext(ctx); apiKey != nil {
+				if apiKey.UserID == nil {
+					next.ServeHTTP(w, r.WithContext(SetRoleInContext(ctx, DefaultAPIKeyRole)))
+					return
+				}
+				userID = *apiKey.UserID
+			}
+			if userID == uuid.Nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			member, err := members.GetMember(ctx, orgID, userID)
+			if err != nil {
+				response.Error(w, apierrors.ErrInternal)
+				return
+			}
+			if member == nil {
+				response.Error(w, apierrors.ErrForbidden.WithMessage("Not a member of this organization"))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(SetRoleInContext(ctx, member.Role)))
+		})
+	}
+}
+
+// RequireRole returns a middleware that checks the caller has at least the
+// given role (owner > admin > operator > viewer). Requests without a resolved
+// role are rejected, so it must be used after ResolveOrgRole.
+func RequireRole(role models.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current, ok := GetRoleFromContext(r.Context())
+			if !ok || models.RoleLevel(current) < models.RoleLevel(role) {
+				response.Error(w, apierrors.ErrForbidden.WithMessage(
+					"This action requires the "+string(role)+" role",
+				))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetRoleFromContext retrieves the caller's organization role from the context.
+func GetRoleFromContext(ctx context.Context) (models.Role, bool) {
+	role, ok := ctx.Value(RoleContextKey).(models.Role)
+	return role, ok
+}
+
+// SetRoleInContext sets the caller's organization role in the context.
+func SetRoleInContext(ctx context.Context, role models.Role) context.Context {
+	return context.WithValue(ctx, RoleContextKey, role)
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+type memberLookupFunc func(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMember, error)
+
+func (f memberLookupFunc) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrgMember, error) {
+	return f(ctx, orgID, userID)
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           *models.Role
+		required       models.Role
+		expectedStatus int
+	}{
+		{"no role is forbidden", nil, models.RoleViewer, http.StatusForbidden},
+		{"higher role passes", ptrRole(models.RoleOwner), models.RoleAdmin, http.StatusOK},
+		{"equal role passes", ptrRole(models.RoleOperator), models.RoleOperator, http.StatusOK},
+		{"lower role is forbidden", ptrRole(models.RoleViewer), models.RoleOperator, http.StatusForbidden},
+		{"unknown role is forbidden", ptrRole(models.Role("guest")), models.RoleViewer, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireRole(tt.required)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.role != nil {
+				req = req.WithContext(SetRoleInContext(req.Context(), *tt.role))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+func TestResolveOrgRole(t *testing.T) {
+	orgID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("uses the API key's user", func(t *testing.T) {
+		var gotRole models.Role
+		lookup := memberLookupFunc(func(ctx context.Context, o, u uuid.UUID) (*models.OrgMember, error) {
+			if o != orgID || u != userID {
+				t.Errorf("GetMember(%s, %s), want (%s, %s)", o, u, orgID, userID)
+			}
+			return &models.OrgMember{OrgID: o, UserID: u, Role: models.RoleOperator}, nil
+		})
+		handler := ResolveOrgRole(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRole, _ = GetRoleFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx := SetOrgIDInContext(req.Context(), orgID)
+		ctx = context.WithValue(ctx, APIKeyContextKey, &models.APIKey{OrgID: orgID, UserID: &userID})
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+		if gotRole != models.RoleOperator {
+			t.Errorf("role = %q, want %q", gotRole, models.RoleOperator)
+		}
+	})
+
+	t.Run("ownerless API key gets the default role", func(t *testing.T) {
+		var gotRole models.Role
+		lookup := memberLookupFunc(func(ctx context.Context, o, u uuid.UUID) (*models.OrgMember, error) {
+			t.Error("GetMember should not be called")
+			return nil, nil
+		})
+		handler := ResolveOrgRole(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRole, _ = GetRoleFromContext(r.Context())
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx := SetOrgIDInContext(req.Context(), orgID)
+		ctx = context.WithValue(ctx, APIKeyContextKey, &models.APIKey{OrgID: orgID})
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+		if gotRole != DefaultAPIKeyRole {
+			t.Errorf("role = %q, want %q", gotRole, DefaultAPIKeyRole)
+		}
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		lookup := memberLookupFunc(func(ctx context.Context, o, u uuid.UUID) (*models.OrgMember, error) {
+			return nil, errors.New("db down")
+		})
+		handler := ResolveOrgRole(lookup)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("handler should not be called")
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx := context.WithValue(SetOrgIDInContext(req.Context(), orgID), UserIDKey, userID.String())
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(ctx))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("Status = %d, want %d", rec.Code, http.StatusInternalServerError)
+		}
+	})
+}
+
+func ptrRole(role models.Role) *models.Role {
+	return &role
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func realIPRequest(t *testing.T, trusted []string, remoteAddr string, headers map[string]string) string {
+	t.Helper()
+
+	prefixes, err := ParseTrustedProxies(trusted)
+	require.NoError(t, err)
+
+	var got string
+	h := RealIP(prefixes)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = ClientIP(r).String()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	return got
+}
+
+func TestRealIP_IgnoresHeadersFromUntrustedPeers(t *testing.T) {
+	headers := map[string]string{
+		"X-Forwarded-For": "192.0.2.1",
+		"X-Real-IP":       "192.0.2.2",
+	}
+
+	assert.Equal(t, "203.0.113.9", realIPRequest(t, nil, "203.0.113.9:4000", headers))
+	assert.Equal(t, "203.0.113.9", realIPRequest(t, []string{"10.0.0.0/8"}, "203.0.113.9:4000", headers))
+}
+
+func TestRealIP_TrustedProxy(t *testing.T) {
+	trusted := []string{"10.0.0.0/8", "172.16.0.1"}
+
+	// The spoofed leftmost entry is skipped in favour of the address the
+	// trusted proxy appended
+	got := realIPRequest(t, trusted, "10.1.2.3:4000", map[string]string{
+		"X-Forwarded-For": "192.0.2.1, 203.0.113.7, 172.16.0.1",
+	})
+	assert.Equal(t, "203.0.113.7", got)
+
+	got = realIPRequest(t, trusted, "172.16.0.1:4000", map[string]string{
+		"X-Real-IP": "203.0.113.8",
+	})
+	assert.Equal(t, "203.0.113.8", got)
+
+	// Without forwarding headers the proxy's own address is kept
+	assert.Equal(t, "10.1.2.3", realIPRequest(t, trusted, "10.1.2.3:4000", nil))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	prefixes, err := ParseTrustedProxies([]string{" 10.0.0.0/8 ", "192.0.2.10", "::1", ""})
+	require.NoError(t, err)
+	assert.Len(t, prefixes, 3)
+
+	_, err = ParseTrustedProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
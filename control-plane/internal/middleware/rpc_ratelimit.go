@@ -1,17 +1,16 @@
 package middleware
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/database"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
 )
 
 // RPCRateLimitConfig configures the RPC rate limiter.
@@ -20,25 +19,56 @@ type RPCRateLimitConfig struct {
 	RequestsPerSecond int
 	// BurstSize is the maximum burst size (unused in sliding window, kept for compatibility).
 	BurstSize int
+	// Plans overrides RequestsPerSecond for organizations on the given plan.
+	Plans map[models.Plan]int
+	// PlanResolver resolves the plan of the authenticated organization.
+	// Without it RequestsPerSecond applies to all requests.
+	PlanResolver *PlanResolver
+	// MaxBodyBytes bounds the request body read to find the address.
+	// Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// DefaultRPCPlanRequestsPerSecond returns the default per-address RPC limits
+// for each plan.
+func DefaultRPCPlanRequestsPerSecond() map[models.Plan]int {
+	return map[models.Plan]int{
+		models.PlanFree:       100,
+		models.PlanPro:        500,
+		models.PlanEnterprise: 2000,
+	}
+}
+
+// requestsPerSecond returns the limit for the organization in the context.
+func (c RPCRateLimitConfig) requestsPerSecond(ctx context.Context) int {
+	if plan, ok := c.PlanResolver.Plan(ctx, GetOrgIDFromContext(ctx)); ok {
+		if rps, ok := c.Plans[plan]; ok {
+			return rps
+		}
+	}
+	return c.RequestsPerSecond
 }
 
 // RPCRateLimit creates middleware that rate limits by Ethereum address.
 // It inspects the JSON-RPC request to extract the 'from' address for eth_signTransaction,
-// or the address parameter for eth_sign/personal_sign.
+// or the address parameter for eth_sign/personal_sign. The limit is selected by
+// the plan of the authenticated organization when a PlanResolver is configured.
 func RPCRateLimit(redis *database.Redis, cfg RPCRateLimitConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Read the body to extract the address
-			body, err := io.ReadAll(r.Body)
+			body, err := bufferBody(w, r, cfg.MaxBodyBytes)
 			if err != nil {
+				if isBodyTooLarge(err) {
+					writeRPCError(w, -32600, "Request body too large")
+					return
+				}
 				writeRPCError(w, -32700, "Failed to read request")
 				return
 			}
-			r.Body.Close()
 
 			if len(body) == 0 {
 				// Empty body, let the handler deal with it
-				r.Body = io.NopCloser(bytes.NewReader(body))
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -51,7 +81,8 @@ func RPCRateLimit(redis *database.Redis, cfg RPCRateLimitConfig) func(http.Handl
 			if address != "" && redis != nil {
 				// Check rate limit
 				key := fmt.Sprintf("rpc_ratelimit:%s", strings.ToLower(address))
-				allowed, err := checkSlidingWindowRateLimit(r.Context(), redis, key, cfg.RequestsPerSecond)
+				limit := cfg.requestsPerSecond(r.Context())
+				allowed, err := checkSlidingWindowRateLimit(r.Context(), redis, key, limit)
 				if err != nil {
 					// Log error but allow request (fail open)
 					slog.Warn("Rate limit check failed",
@@ -61,16 +92,13 @@ func RPCRateLimit(redis *database.Redis, cfg RPCRateLimitConfig) func(http.Handl
 				} else if !allowed {
 					slog.Info("Rate limit exceeded",
 						slog.String("address", address),
-						slog.Int("limit", cfg.RequestsPerSecond),
+						slog.Int("limit", limit),
 					)
 					writeRPCError(w, -32029, "Rate limit exceeded")
 					return
 				}
 			}
 
-			// Reconstruct body for downstream handlers
-			r.Body = io.NopCloser(bytes.NewReader(body))
-
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -145,6 +173,7 @@ func checkSlidingWindowRateLimit(ctx context.Context, redis *database.Redis, key
 	windowStart := now - int64(time.Second) // 1 second window
 
 	// Use Redis pipeline for atomic operations
+	key = redis.Key(key)
 	client := redis.Client()
 	pipe := client.Pipeline()
 
@@ -235,3 +235,26 @@ func TestRPCRateLimit_BodyPreserved(t *testing.T) {
 	assert.Equal(t, originalBody, string(receivedBody), "Body should be preserved for downstream handler")
 }
 
+func TestRPCRateLimit_BodyTooLarge(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware := RPCRateLimit(nil, RPCRateLimitConfig{
+		RequestsPerSecond: 100,
+		MaxBodyBytes:      32,
+	})
+
+	handler := middleware(next)
+
+	body := `{"jsonrpc":"2.0","method":"eth_sign","params":["0xABC","0x123"],"id":1}`
+	req := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.False(t, called, "Next handler should not be called for an oversized body")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Request body too large")
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of spans created by these middlewares.
+const tracerName = "github.com/Bidon15/popsigner/control-plane/internal/middleware"
+
+// Tracing returns a middleware that starts a server span for each request.
+// A trace propagated by the caller in a W3C traceparent header is continued.
+func Tracing(tp trace.TracerProvider) func(next http.Handler) http.Handler {
+	tracer := tp.Tracer(tracerName)
+	propagator := propagation.TraceContext{}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			wrapped := wrapResponseWriter(w)
+			next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				span.SetName(r.Method + " " + rctx.RoutePattern())
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", wrapped.status))
+			if wrapped.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(wrapped.status))
+			}
+		})
+	}
+}
+
+type traceStepKey struct{}
+
+// traceStep tracks the span of a middleware wrapped by TraceStep.
+type traceStep struct {
+	parent trace.Span
+	span   trace.Span
+	passed bool
+}
+
+// TraceStep wraps a middleware in a child span named name. The span covers
+// only the middleware's own work: it ends when the middleware passes the
+// request on, or, if the middleware rejects the request, when it returns.
+func TraceStep(tp trace.TracerProvider, name string, mw func(http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	tracer := tp.Tracer(tracerName)
+
+	return func(next http.Handler) http.Handler {
+		inner := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			step, _ := r.Context().Value(traceStepKey{}).(*traceStep)
+			if step == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			step.passed = true
+			step.span.End()
+
+			// Later spans are siblings of this step, not its children
+			next.ServeHTTP(w, r.WithContext(trace.ContextWithSpan(r.Context(), step.parent)))
+		}))
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := tracer.Start(r.Context(), name)
+			step := &traceStep{parent: trace.SpanFromContext(r.Context()), span: span}
+
+			wrapped := wrapResponseWriter(w)
+			inner.ServeHTTP(wrapped, r.WithContext(context.WithValue(ctx, traceStepKey{}, step)))
+
+			if !step.passed {
+				span.SetAttributes(attribute.Int("http.response.status_code", wrapped.status))
+				span.SetStatus(codes.Error, http.StatusText(wrapped.status))
+				span.End()
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/openbao"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func passThrough(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+
+func reject(status int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		})
+	}
+}
+
+func spansByName(spans []sdktrace.ReadOnlySpan) map[string]sdktrace.ReadOnlySpan {
+	byName := make(map[string]sdktrace.ReadOnlySpan, len(spans))
+	for _, s := range spans {
+		byName[s.Name()] = s
+	}
+	return byName
+}
+
+func TestTracing_ContinuesTraceThroughStepsAndOpenBao(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	// The OpenBao client traces through the global provider
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	bao := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"signature": "0x00", "v": "0x1b"},
+		})
+	}))
+	defer bao.Close()
+	baoClient := openbao.NewClient(&config.OpenBaoConfig{Address: bao.URL, Token: "test"})
+
+	handler := Tracing(tp)(
+		TraceStep(tp, "auth", passThrough)(
+			TraceStep(tp, "rate_limit", passThrough)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, err := baoClient.SignEVM(r.Context(), "key", "0EuY9I6Pi8wVxq5awFCAHNbc/UKPtfnmXE4W54BzQPo=", 1)
+					require.NoError(t, err)
+					w.WriteHeader(http.StatusOK)
+				}),
+			),
+		),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("traceparent", testTraceparent)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	spans := spansByName(recorder.Ended())
+	require.Len(t, spans, 4)
+
+	server := spans["POST"]
+	require.NotNil(t, server)
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.True(t, server.Parent().IsRemote())
+
+	for _, name := range []string{"auth", "rate_limit", "openbao.sign_evm"} {
+		span := spans[name]
+		require.NotNil(t, span, name)
+		assert.Equal(t, server.SpanContext().SpanID(), span.Parent().SpanID(), "%s parent", name)
+		assert.Equal(t, server.SpanContext().TraceID(), span.SpanContext().TraceID(), "%s trace", name)
+	}
+
+	// Steps end before the work they guard starts
+	assert.False(t, spans["auth"].EndTime().After(spans["openbao.sign_evm"].StartTime()))
+}
+
+func TestTraceStep_RecordsRejection(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	called := false
+	handler := Tracing(tp)(
+		TraceStep(tp, "rate_limit", reject(http.StatusTooManyRequests))(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}),
+		),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assert.False(t, called)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	spans := spansByName(recorder.Ended())
+	step := spans["rate_limit"]
+	require.NotNil(t, step)
+	assert.Equal(t, codes.Error, step.Status().Code)
+	assert.Equal(t, spans["POST"].SpanContext().SpanID(), step.Parent().SpanID())
+	assert.False(t, spans["POST"].Parent().IsValid(), "server span should be a root without traceparent")
+}
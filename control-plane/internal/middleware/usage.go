@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// DefaultUsageFlushInterval is how often a UsageAggregator writes its
+// buffered counts when no interval is given.
+const DefaultUsageFlushInterval = 10 * time.Second
+
+// usageKey identifies a buffered count: the granularity of the usage table.
+type usageKey struct {
+	orgID  uuid.UUID
+	metric string
+}
+
+// UsageAggregator buffers usage increments in memory and writes them to the
+// usage repository in batches, so counting a request costs no database
+// write. Counts that fail to flush are kept for the next flush.
+type UsageAggregator struct {
+	repo     repository.UsageRepository
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[usageKey]int64
+
+	flushMu  sync.Mutex // serializes flushes
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewUsageAggregator creates an aggregator that flushes to repo every
+// interval (DefaultUsageFlushInterval if zero) once started.
+func NewUsageAggregator(repo repository.UsageRepository, interval time.Duration) *UsageAggregator {
+	if interval <= 0 {
+		interval = DefaultUsageFlushInterval
+	}
+	return &UsageAggregator{
+		repo:     repo,
+		interval: interval,
+		pending:  make(map[usageKey]int64),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Add buffers an increment of metric for the organization.
+func (a *UsageAggregator) Add(orgID uuid.UUID, metric string, value int64) {
+	a.mu.Lock()
+	a.pending[usageKey{orgID: orgID, metric: metric}] += value
+	a.mu.Unlock()
+}
+
+// Start flushes periodically in the background until Close is called.
+func (a *UsageAggregator) Start() {
+	go func() {
+		ticker := time.NewTicker(a.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = a.Flush(context.Background())
+			case <-a.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Flush writes all buffered counts to the repository. Counts that fail to
+// write are buffered again and the errors returned.
+func (a *UsageAggregator) Flush(ctx context.Context) error {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.mu.Lock()
+	batch := a.pending
+	a.pending = make(map[usageKey]int64, len(batch))
+	a.mu.Unlock()
+
+	var errs []error
+	for key, value := range batch {
+		if err := a.repo.Increment(ctx, key.orgID, key.metric, value); err != nil {
+			a.Add(key.orgID, key.metric, value)
+			errs = append(errs, fmt.Errorf("flush %s for org %s: %w", key.metric, key.orgID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops periodic flushing, if started, and flushes what is left. Call
+// it after the HTTP server has shut down so no request goes uncounted.
+func (a *UsageAggregator) Close(ctx context.Context) error {
+	a.stopOnce.Do(func() { close(a.stop) })
+	return a.Flush(ctx)
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// usageRepoStub records increments; Increment fails while err is set.
+type usageRepoStub struct {
+	repository.UsageRepository
+
+	mu     sync.Mutex
+	totals map[string]int64 // orgID/metric -> value
+	writes int
+	err    error
+}
+
+func newUsageRepoStub() *usageRepoStub {
+	return &usageRepoStub{totals: make(map[string]int64)}
+}
+
+func (s *usageRepoStub) Increment(ctx context.Context, orgID uuid.UUID, metric string, value int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.totals[orgID.String()+"/"+metric] += value
+	s.writes++
+	return nil
+}
+
+func (s *usageRepoStub) total(orgID uuid.UUID, metric string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.totals[orgID.String()+"/"+metric]
+}
+
+func TestTrackAPIUsage_ConcurrentRequests(t *testing.T) {
+	repo := newUsageRepoStub()
+	usage := NewUsageAggregator(repo, time.Millisecond)
+	usage.Start()
+
+	orgs := []uuid.UUID{uuid.New(), uuid.New()}
+	handler := TrackAPIUsage(usage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	const requestsPerOrg = 500
+	var wg sync.WaitGroup
+	for _, orgID := range orgs {
+		for i := 0; i < requestsPerOrg; i++ {
+			wg.Add(1)
+			go func(orgID uuid.UUID) {
+				defer wg.Done()
+				req := httptest.NewRequest(http.MethodGet, "/keys", nil)
+				req = req.WithContext(SetOrgIDInContext(req.Context(), orgID))
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}(orgID)
+		}
+	}
+	wg.Wait()
+
+	// The final flush on shutdown writes whatever the ticker hasn't
+	require.NoError(t, usage.Close(context.Background()))
+
+	for _, orgID := range orgs {
+		assert.Equal(t, int64(requestsPerOrg), repo.total(orgID, string(models.MetricTypeAPICalls)))
+	}
+}
+
+func TestTrackAPIUsage_Unauthenticated(t *testing.T) {
+	repo := newUsageRepoStub()
+	usage := NewUsageAggregator(repo, 0)
+	handler := TrackAPIUsage(usage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/keys", nil))
+	require.NoError(t, usage.Close(context.Background()))
+
+	assert.Zero(t, repo.writes)
+}
+
+func TestUsageAggregator_BatchesWrites(t *testing.T) {
+	repo := newUsageRepoStub()
+	usage := NewUsageAggregator(repo, 0)
+	orgID := uuid.New()
+
+	for i := 0; i < 100; i++ {
+		usage.Add(orgID, "api_calls", 1)
+	}
+	usage.Add(orgID, "signatures", 3)
+	require.NoError(t, usage.Flush(context.Background()))
+
+	assert.Equal(t, 2, repo.writes)
+	assert.Equal(t, int64(100), repo.total(orgID, "api_calls"))
+	assert.Equal(t, int64(3), repo.total(orgID, "signatures"))
+}
+
+func TestUsageAggregator_KeepsCountsOnFailedFlush(t *testing.T) {
+	repo := newUsageRepoStub()
+	repo.err = errors.New("database unavailable")
+	usage := NewUsageAggregator(repo, 0)
+	orgID := uuid.New()
+
+	usage.Add(orgID, "api_calls", 5)
+	require.Error(t, usage.Flush(context.Background()))
+
+	usage.Add(orgID, "api_calls", 2)
+	repo.mu.Lock()
+	repo.err = nil
+	repo.mu.Unlock()
+	require.NoError(t, usage.Close(context.Background()))
+
+	assert.Equal(t, int64(7), repo.total(orgID, "api_calls"))
+}
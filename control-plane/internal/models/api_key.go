@@ -2,6 +2,9 @@
 package models
 
 import (
+	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,30 +12,37 @@ import (
 
 // APIKey represents an API key for programmatic access.
 type APIKey struct {
-	ID         uuid.UUID  `json:"id" db:"id"`
-	OrgID      uuid.UUID  `json:"org_id" db:"org_id"`
-	UserID     *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
-	Name       string     `json:"name" db:"name"`
-	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"` // bbr_live_xxxx (for display)
-	KeyHash    string     `json:"-" db:"key_hash"`            // Argon2 hash
-	Scopes     []string   `json:"scopes" db:"scopes"`
-	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
-	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
-	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
-	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ID           uuid.UUID  `json:"id" db:"id"`
+	OrgID        uuid.UUID  `json:"org_id" db:"org_id"`
+	UserID       *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Name         string     `json:"name" db:"name"`
+	KeyPrefix    string     `json:"key_prefix" db:"key_prefix"` // bbr_live_xxxx (for display)
+	KeyHash      string     `json:"-" db:"key_hash"`            // Argon2 hash
+	Scopes       []string   `json:"scopes" db:"scopes"`
+	AllowedCIDRs []string   `json:"allowed_cidrs,omitempty" db:"allowed_cidrs"` // Source IP allowlist, empty = any
+	// Signing scope: keys this API key may sign with, by ID or namespace.
+	// Both empty = any key in the org.
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty" db:"allowed_key_ids"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty" db:"allowed_namespace_ids"`
+	LastUsedAt          *time.Time  `json:"last_used_at,omitempty" db:"last_used_at"`
+	ExpiresAt           *time.Time  `json:"expires_at,omitempty" db:"expires_at"`
+	RevokedAt           *time.Time  `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt           time.Time   `json:"created_at" db:"created_at"`
 }
 
 // APIKeyScopes defines the available API key scopes.
 var APIKeyScopes = map[string]bool{
-	"keys:read":      true,
-	"keys:write":     true,
-	"keys:sign":      true,
-	"audit:read":     true,
-	"billing:read":   true,
-	"billing:write":  true,
-	"webhooks:read":  true,
-	"webhooks:write": true,
-	"*":              true, // Wildcard scope for full access
+	"keys:read":          true,
+	"keys:write":         true,
+	"keys:sign":          true,
+	"audit:read":         true,
+	"billing:read":       true,
+	"billing:write":      true,
+	"webhooks:read":      true,
+	"webhooks:write":     true,
+	"certificates:read":  true,
+	"certificates:write": true,
+	"*":                  true, // Wildcard scope for full access
 }
 
 // AllScopes returns all available scope names.
@@ -46,6 +56,8 @@ func AllScopes() []string {
 		"billing:write",
 		"webhooks:read",
 		"webhooks:write",
+		"certificates:read",
+		"certificates:write",
 	}
 }
 
@@ -96,33 +108,120 @@ func (k *APIKey) HasAnyScope(scopes ...string) bool {
 	return false
 }
 
+// AllowsIP checks if the API key may be used from the given client IP.
+// Keys without an allowlist may be used from any IP.
+func (k *APIKey) AllowsIP(ip net.IP) bool {
+	if len(k.AllowedCIDRs) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range k.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictsKeys reports whether the API key may only sign with some keys.
+func (k *APIKey) RestrictsKeys() bool {
+	return len(k.AllowedKeyIDs) > 0 || len(k.AllowedNamespaceIDs) > 0
+}
+
+// AllowsKey checks if the API key may sign with the given key: the key must
+// be listed itself or belong to a listed namespace. Keys without a signing
+// scope may sign with any key of their org.
+func (k *APIKey) AllowsKey(key *Key) bool {
+	return signingScopeAllows(k.AllowedKeyIDs, k.AllowedNamespaceIDs, key)
+}
+
+// signingScopeAllows checks key against a signing scope of key and namespace
+// IDs. An empty scope allows any key.
+func signingScopeAllows(keyIDs, namespaceIDs []uuid.UUID, key *Key) bool {
+	if len(keyIDs) == 0 && len(namespaceIDs) == 0 {
+		return true
+	}
+	for _, id := range keyIDs {
+		if id == key.ID {
+			return true
+		}
+	}
+	for _, id := range namespaceIDs {
+		if id == key.NamespaceID {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeCIDRs validates an IP allowlist and returns it in canonical CIDR
+// form. Single addresses are accepted and converted to /32 or /128 networks.
+func NormalizeCIDRs(entries []string) ([]string, error) {
+	cidrs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address or CIDR: %s", entry)
+			}
+			if ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address or CIDR: %s", entry)
+		}
+		cidrs = append(cidrs, network.String())
+	}
+	return cidrs, nil
+}
+
 // APIKeyResponse is the response format for API key operations.
 // It includes the full key only on creation.
 type APIKeyResponse struct {
-	ID         uuid.UUID  `json:"id"`
-	OrgID      uuid.UUID  `json:"org_id"`
-	Name       string     `json:"name"`
-	KeyPrefix  string     `json:"key_prefix"`
-	Key        string     `json:"key,omitempty"` // Only set on creation
-	Scopes     []string   `json:"scopes"`
-	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
-	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
-	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
+	ID                  uuid.UUID   `json:"id"`
+	OrgID               uuid.UUID   `json:"org_id"`
+	Name                string      `json:"name"`
+	KeyPrefix           string      `json:"key_prefix"`
+	Key                 string      `json:"key,omitempty"` // Only set on creation
+	Scopes              []string    `json:"scopes"`
+	AllowedCIDRs        []string    `json:"allowed_cidrs,omitempty"`
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
+	LastUsedAt          *time.Time  `json:"last_used_at,omitempty"`
+	ExpiresAt           *time.Time  `json:"expires_at,omitempty"`
+	RevokedAt           *time.Time  `json:"revoked_at,omitempty"`
+	CreatedAt           time.Time   `json:"created_at"`
 }
 
 // ToResponse converts an APIKey to an APIKeyResponse.
 func (k *APIKey) ToResponse() *APIKeyResponse {
 	return &APIKeyResponse{
-		ID:         k.ID,
-		OrgID:      k.OrgID,
-		Name:       k.Name,
-		KeyPrefix:  k.KeyPrefix,
-		Scopes:     k.Scopes,
-		LastUsedAt: k.LastUsedAt,
-		ExpiresAt:  k.ExpiresAt,
-		RevokedAt:  k.RevokedAt,
-		CreatedAt:  k.CreatedAt,
+		ID:                  k.ID,
+		OrgID:               k.OrgID,
+		Name:                k.Name,
+		KeyPrefix:           k.KeyPrefix,
+		Scopes:              k.Scopes,
+		AllowedCIDRs:        k.AllowedCIDRs,
+		AllowedKeyIDs:       k.AllowedKeyIDs,
+		AllowedNamespaceIDs: k.AllowedNamespaceIDs,
+		LastUsedAt:          k.LastUsedAt,
+		ExpiresAt:           k.ExpiresAt,
+		RevokedAt:           k.RevokedAt,
+		CreatedAt:           k.CreatedAt,
 	}
 }
 
@@ -133,3 +232,13 @@ type CreateAPIKeyResponse struct {
 	Key     string          `json:"key"`
 	Warning string          `json:"warning"`
 }
+
+// RotateAPIKeyResponse is the response for API key rotation. It contains the
+// successor's full key, which will not be shown again. The previous key keeps
+// working until its expires_at.
+type RotateAPIKeyResponse struct {
+	APIKey   *APIKeyResponse `json:"api_key"`
+	Key      string          `json:"key"`
+	Previous *APIKeyResponse `json:"previous"`
+	Warning  string          `json:"warning"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"net"
 	"testing"
 	"time"
 
@@ -150,6 +151,143 @@ func TestAPIKey_HasScope(t *testing.T) {
 	}
 }
 
+func TestAPIKey_AllowsIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		cidrs    []string
+		ip       string
+		expected bool
+	}{
+		{
+			name:     "empty allowlist allows any IP",
+			cidrs:    nil,
+			ip:       "198.51.100.1",
+			expected: true,
+		},
+		{
+			name:     "IPv4 in range",
+			cidrs:    []string{"10.0.0.0/8"},
+			ip:       "10.1.2.3",
+			expected: true,
+		},
+		{
+			name:     "IPv4 out of range",
+			cidrs:    []string{"10.0.0.0/8"},
+			ip:       "11.0.0.1",
+			expected: false,
+		},
+		{
+			name:     "IPv6 in range",
+			cidrs:    []string{"10.0.0.0/8", "2001:db8::/32"},
+			ip:       "2001:db8:1::1",
+			expected: true,
+		},
+		{
+			name:     "IPv6 out of range",
+			cidrs:    []string{"2001:db8::/32"},
+			ip:       "2001:db9::1",
+			expected: false,
+		},
+		{
+			name:     "unparseable client IP is rejected",
+			cidrs:    []string{"10.0.0.0/8"},
+			ip:       "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := &APIKey{AllowedCIDRs: tt.cidrs}
+			got := key.AllowsIP(net.ParseIP(tt.ip))
+			if got != tt.expected {
+				t.Errorf("AllowsIP(%q) = %v, want %v", tt.ip, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAPIKey_AllowsKey(t *testing.T) {
+	batcher := &Key{ID: uuid.New(), NamespaceID: uuid.New()}
+	proposer := &Key{ID: uuid.New(), NamespaceID: uuid.New()}
+
+	tests := []struct {
+		name         string
+		keyIDs       []uuid.UUID
+		namespaceIDs []uuid.UUID
+		key          *Key
+		expected     bool
+	}{
+		{
+			name:     "no signing scope allows any key",
+			key:      proposer,
+			expected: true,
+		},
+		{
+			name:     "listed key",
+			keyIDs:   []uuid.UUID{batcher.ID},
+			key:      batcher,
+			expected: true,
+		},
+		{
+			name:     "unlisted key",
+			keyIDs:   []uuid.UUID{batcher.ID},
+			key:      proposer,
+			expected: false,
+		},
+		{
+			name:         "key in listed namespace",
+			namespaceIDs: []uuid.UUID{proposer.NamespaceID},
+			key:          proposer,
+			expected:     true,
+		},
+		{
+			name:         "key outside listed namespace",
+			namespaceIDs: []uuid.UUID{proposer.NamespaceID},
+			key:          batcher,
+			expected:     false,
+		},
+		{
+			name:         "listed key outside listed namespace",
+			keyIDs:       []uuid.UUID{batcher.ID},
+			namespaceIDs: []uuid.UUID{proposer.NamespaceID},
+			key:          batcher,
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey := &APIKey{AllowedKeyIDs: tt.keyIDs, AllowedNamespaceIDs: tt.namespaceIDs}
+			if got := apiKey.AllowsKey(tt.key); got != tt.expected {
+				t.Errorf("AllowsKey() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeCIDRs(t *testing.T) {
+	got, err := NormalizeCIDRs([]string{" 10.1.2.3/8 ", "192.0.2.1", "2001:db8::1", ""})
+	if err != nil {
+		t.Fatalf("NormalizeCIDRs() error = %v", err)
+	}
+	want := []string{"10.0.0.0/8", "192.0.2.1/32", "2001:db8::1/128"}
+	if len(got) != len(want) {
+		t.Fatalf("NormalizeCIDRs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("NormalizeCIDRs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	for _, invalid := range []string{"not-an-ip", "10.0.0.0/33", "2001:db8::/129"} {
+		if _, err := NormalizeCIDRs([]string{invalid}); err == nil {
+			t.Errorf("NormalizeCIDRs(%q) expected error", invalid)
+		}
+	}
+}
+
 func TestAPIKey_HasAnyScope(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -318,6 +456,8 @@ func TestAllScopes(t *testing.T) {
 		"billing:write",
 		"webhooks:read",
 		"webhooks:write",
+		"certificates:read",
+		"certificates:write",
 	}
 
 	if len(scopes) != len(expectedScopes) {
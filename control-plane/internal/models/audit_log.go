@@ -12,9 +12,10 @@ import (
 type ActorType string
 
 const (
-	ActorTypeUser   ActorType = "user"
-	ActorTypeAPIKey ActorType = "api_key"
-	ActorTypeSystem ActorType = "system"
+	ActorTypeUser        ActorType = "user"
+	ActorTypeAPIKey      ActorType = "api_key"
+	ActorTypeCertificate ActorType = "certificate"
+	ActorTypeSystem      ActorType = "system"
 )
 
 // AuditEvent represents the type of audit event.
@@ -22,11 +23,14 @@ type AuditEvent string
 
 const (
 	// Key events
-	AuditEventKeyCreated  AuditEvent = "key.created"
-	AuditEventKeyDeleted  AuditEvent = "key.deleted"
-	AuditEventKeySigned   AuditEvent = "key.signed"
-	AuditEventKeyExported AuditEvent = "key.exported"
-	AuditEventKeyRotated  AuditEvent = "key.rotated"
+	AuditEventKeyCreated    AuditEvent = "key.created"
+	AuditEventKeyDeleted    AuditEvent = "key.deleted"
+	AuditEventKeySigned     AuditEvent = "key.signed"
+	AuditEventKeySignFailed AuditEvent = "key.sign_failed"
+	AuditEventKeyExported   AuditEvent = "key.exported"
+	AuditEventKeyRotated    AuditEvent = "key.rotated"
+	AuditEventKeyRestored   AuditEvent = "key.restored"
+	AuditEventKeyPurged     AuditEvent = "key.purged"
 
 	// Auth events
 	AuditEventAuthLogin      AuditEvent = "auth.login"
@@ -79,19 +83,27 @@ type AuditLog struct {
 	IPAddress    *net.IP         `json:"ip_address,omitempty" db:"ip_address"`
 	UserAgent    *string         `json:"user_agent,omitempty" db:"user_agent"`
 	Metadata     json.RawMessage `json:"metadata,omitempty" db:"metadata"`
-	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	// RequestHash is the hex SHA-256 of the signed request payload. The
+	// payload itself is never stored.
+	RequestHash *string   `json:"request_hash,omitempty" db:"request_hash"`
+	SignMode    *string   `json:"sign_mode,omitempty" db:"sign_mode"`
+	Result      *string   `json:"result,omitempty" db:"result"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
 // AuditLogQuery represents query parameters for fetching audit logs.
+// Results are ordered by (created_at DESC, id DESC); AfterCreatedAt and
+// AfterID identify the last entry of the previous page.
 type AuditLogQuery struct {
-	OrgID        uuid.UUID
-	Event        *AuditEvent
-	ActorID      *uuid.UUID
-	ResourceType *ResourceType
-	ResourceID   *uuid.UUID
-	StartTime    *time.Time
-	EndTime      *time.Time
-	Limit        int
-	Cursor       string
+	OrgID          uuid.UUID
+	Event          *AuditEvent
+	ActorID        *uuid.UUID
+	ResourceType   *ResourceType
+	ResourceID     *uuid.UUID
+	StartTime      *time.Time
+	EndTime        *time.Time
+	AfterCreatedAt *time.Time
+	AfterID        *uuid.UUID
+	Limit          int
 }
 
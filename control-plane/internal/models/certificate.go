@@ -14,18 +14,22 @@ import (
 
 // Certificate represents a client certificate issued by POPSigner CA.
 type Certificate struct {
-	ID               uuid.UUID  `json:"id" db:"id"`                               // UUID
-	OrgID            uuid.UUID  `json:"org_id" db:"org_id"`                       // Organization owner
-	Name             string     `json:"name" db:"name"`                           // User-friendly name
-	Fingerprint      string     `json:"fingerprint" db:"fingerprint"`             // SHA256 of DER-encoded cert
-	CommonName       string     `json:"common_name" db:"common_name"`             // CN from certificate
-	SerialNumber     string     `json:"serial_number" db:"serial_number"`         // Certificate serial
-	IssuedAt         time.Time  `json:"issued_at" db:"issued_at"`                 // When cert was issued
-	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`               // Expiration time
-	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`     // NULL if not revoked
+	ID               uuid.UUID  `json:"id" db:"id"`                           // UUID
+	OrgID            uuid.UUID  `json:"org_id" db:"org_id"`                   // Organization owner
+	Name             string     `json:"name" db:"name"`                       // User-friendly name
+	Fingerprint      string     `json:"fingerprint" db:"fingerprint"`         // SHA256 of DER-encoded cert
+	CommonName       string     `json:"common_name" db:"common_name"`         // CN from certificate
+	SerialNumber     string     `json:"serial_number" db:"serial_number"`     // Certificate serial
+	IssuedAt         time.Time  `json:"issued_at" db:"issued_at"`             // When cert was issued
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`           // Expiration time
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"` // NULL if not revoked
 	RevocationReason *string    `json:"revocation_reason,omitempty" db:"revocation_reason"`
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 	CertificatePEM   *string    `json:"certificate_pem,omitempty" db:"certificate_pem"` // PEM-encoded cert for re-download
+
+	// Signing scope: keys the certificate may sign with (empty = any key in the org)
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty" db:"allowed_key_ids"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty" db:"allowed_namespace_ids"`
 }
 
 // IsRevoked returns true if the certificate has been revoked.
@@ -43,6 +47,12 @@ func (c *Certificate) IsValid() bool {
 	return !c.IsRevoked() && !c.IsExpired()
 }
 
+// AllowsKey checks if an mTLS client presenting the certificate may sign with
+// the given key. It follows the same rules as APIKey.AllowsKey.
+func (c *Certificate) AllowsKey(key *Key) bool {
+	return signingScopeAllows(c.AllowedKeyIDs, c.AllowedNamespaceIDs, key)
+}
+
 // CertificateStatus represents the current status of a certificate.
 type CertificateStatus string
 
@@ -131,6 +141,30 @@ func (r *CreateCertificateRequest) Validate() error {
 	return nil
 }
 
+// EnrollCertificateRequest represents a request to sign a client-generated
+// CSR. The private key never leaves the client.
+type EnrollCertificateRequest struct {
+	OrgID               uuid.UUID     `json:"org_id" validate:"required"`
+	Name                string        `json:"name" validate:"required,min=1,max=255"`
+	CSRPEM              string        `json:"csr" validate:"required"`
+	ValidityPeriod      time.Duration `json:"validity_period,omitempty"` // Default: 365 days
+	AllowedKeyIDs       []uuid.UUID   `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID   `json:"allowed_namespace_ids,omitempty"`
+}
+
+// Validate validates the enroll certificate request.
+func (r *EnrollCertificateRequest) Validate() error {
+	if r.CSRPEM == "" {
+		return fmt.Errorf("csr is required")
+	}
+	create := CreateCertificateRequest{OrgID: r.OrgID, Name: r.Name, ValidityPeriod: r.ValidityPeriod}
+	if err := create.Validate(); err != nil {
+		return err
+	}
+	r.ValidityPeriod = create.ValidityPeriod
+	return nil
+}
+
 // RevokeCertificateRequest represents a request to revoke a certificate.
 type RevokeCertificateRequest struct {
 	CertificateID string `json:"certificate_id" validate:"required"`
@@ -139,12 +173,12 @@ type RevokeCertificateRequest struct {
 
 // CertificateBundle represents a downloadable certificate bundle.
 type CertificateBundle struct {
-	ClientCert     []byte `json:"client_cert"`      // PEM-encoded client certificate
-	ClientKey      []byte `json:"client_key"`       // PEM-encoded private key
-	CACert         []byte `json:"ca_cert"`          // PEM-encoded CA certificate
-	Fingerprint    string `json:"fingerprint"`      // Certificate fingerprint
-	ExpiresAt      string `json:"expires_at"`       // ISO8601 expiration
-	NitroConfigTip string `json:"nitro_config_tip"` // Configuration hint for Nitro
+	ClientCert     []byte `json:"client_cert"`          // PEM-encoded client certificate
+	ClientKey      []byte `json:"client_key,omitempty"` // PEM-encoded private key (not set for CSR enrollment)
+	CACert         []byte `json:"ca_cert"`              // PEM-encoded CA certificate
+	Fingerprint    string `json:"fingerprint"`          // Certificate fingerprint
+	ExpiresAt      string `json:"expires_at"`           // ISO8601 expiration
+	NitroConfigTip string `json:"nitro_config_tip"`     // Configuration hint for Nitro
 }
 
 // CertificateListResponse represents a list of certificates.
@@ -167,6 +201,9 @@ type CertificateResponse struct {
 	RevokedAt        *time.Time        `json:"revoked_at,omitempty"`
 	RevocationReason *string           `json:"revocation_reason,omitempty"`
 	CreatedAt        time.Time         `json:"created_at"`
+
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
 }
 
 // ToResponse converts a Certificate to a CertificateResponse.
@@ -184,6 +221,8 @@ func (c *Certificate) ToResponse() *CertificateResponse {
 		RevokedAt:        c.RevokedAt,
 		RevocationReason: c.RevocationReason,
 		CreatedAt:        c.CreatedAt,
+
+		AllowedKeyIDs:       c.AllowedKeyIDs,
+		AllowedNamespaceIDs: c.AllowedNamespaceIDs,
 	}
 }
-
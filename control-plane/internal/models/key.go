@@ -15,6 +15,19 @@ const (
 	AlgorithmEd25519   Algorithm = "ed25519"
 )
 
+// Curve returns the name of the elliptic curve the algorithm signs over,
+// or an empty string for an unknown algorithm.
+func (a Algorithm) Curve() string {
+	switch a {
+	case AlgorithmSecp256k1:
+		return "secp256k1"
+	case AlgorithmEd25519:
+		return "edwards25519"
+	default:
+		return ""
+	}
+}
+
 // NetworkType represents the primary network for a key
 type NetworkType string
 
@@ -55,6 +68,8 @@ type Key struct {
 	Exportable  bool            `json:"exportable" db:"exportable"`
 	Metadata    json.RawMessage `json:"metadata,omitempty" db:"metadata"`
 	Version     int             `json:"version" db:"version"`
+	RotatedAt   *time.Time      `json:"rotated_at,omitempty" db:"rotated_at"`     // nil if never rotated
+	LastUsedAt  *time.Time      `json:"last_used_at,omitempty" db:"last_used_at"` // nil if never used to sign
 	DeletedAt   *time.Time      `json:"deleted_at,omitempty" db:"deleted_at"`
 	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
@@ -78,6 +93,66 @@ func (k *Key) GetEthAddress() string {
 	return ""
 }
 
+// KeyVersion is a previous version of a key's material, retired by rotation.
+// The current version is always the one stored on Key.
+type KeyVersion struct {
+	KeyID          uuid.UUID `json:"key_id" db:"key_id"`
+	Version        int       `json:"version" db:"version"`
+	BaoKeyPath     string    `json:"-" db:"bao_key_path"`
+	PublicKey      []byte    `json:"public_key" db:"public_key"`
+	Address        string    `json:"address" db:"address"`
+	EthAddress     *string   `json:"eth_address,omitempty" db:"eth_address"`
+	SigningAllowed bool      `json:"signing_allowed" db:"signing_allowed"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	RetiredAt      time.Time `json:"retired_at" db:"retired_at"`
+}
+
+// KeyListQuery represents query parameters for paging through keys.
+// Results are ordered by (created_at DESC, id DESC); AfterCreatedAt and
+// AfterID identify the last key of the previous page.
+type KeyListQuery struct {
+	OrgID          uuid.UUID
+	NamespaceID    *uuid.UUID
+	NetworkType    *NetworkType // also matches keys with NetworkTypeAll
+	NamePrefix     string
+	LabelKey       string
+	LabelValue     string
+	AfterCreatedAt *time.Time
+	AfterID        *uuid.UUID
+	Limit          int
+}
+
+// KeySort is the order of a page of keys.
+type KeySort string
+
+const (
+	KeySortCreated  KeySort = "created"   // newest first
+	KeySortName     KeySort = "name"      // alphabetical
+	KeySortLastUsed KeySort = "last_used" // most recently used first, unused last
+)
+
+// Valid checks if the sort order is valid.
+func (s KeySort) Valid() bool {
+	switch s {
+	case KeySortCreated, KeySortName, KeySortLastUsed:
+		return true
+	default:
+		return false
+	}
+}
+
+// KeyPageQuery represents query parameters for listing keys by page number.
+// Search matches the name, address or Ethereum address, case-insensitively.
+type KeyPageQuery struct {
+	OrgID       uuid.UUID
+	NamespaceID *uuid.UUID
+	NetworkType *NetworkType // also matches keys with NetworkTypeAll
+	Search      string
+	Sort        KeySort
+	Offset      int
+	Limit       int
+}
+
 // KeyResponse is the API response format for keys.
 type KeyResponse struct {
 	ID          uuid.UUID              `json:"id"`
@@ -73,13 +73,17 @@ func ValidRole(role Role) bool {
 	return false
 }
 
+// DefaultBech32Prefix is the bech32 prefix used for namespaces that don't set one.
+const DefaultBech32Prefix = "celestia"
+
 // Namespace represents an environment within an organization.
 type Namespace struct {
-	ID          uuid.UUID `json:"id" db:"id"`
-	OrgID       uuid.UUID `json:"org_id" db:"org_id"`
-	Name        string    `json:"name" db:"name"`
-	Description *string   `json:"description,omitempty" db:"description"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID           uuid.UUID `json:"id" db:"id"`
+	OrgID        uuid.UUID `json:"org_id" db:"org_id"`
+	Name         string    `json:"name" db:"name"`
+	Description  *string   `json:"description,omitempty" db:"description"`
+	Bech32Prefix string    `json:"bech32_prefix" db:"bech32_prefix"` // Prefix for Cosmos addresses of keys in this namespace
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
 // Invitation represents a pending invitation to join an organization.
@@ -42,3 +42,18 @@ type UsageSummary struct {
 	PeriodStart      time.Time `json:"period_start"`
 	PeriodEnd        time.Time `json:"period_end"`
 }
+
+// KeyUsage represents signing activity for a single key within a time window.
+type KeyUsage struct {
+	KeyID       uuid.UUID `json:"key_id"`
+	Signatures  int64     `json:"signatures"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// BillingPeriod returns the monthly billing period containing t.
+func BillingPeriod(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
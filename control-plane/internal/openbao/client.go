@@ -8,124 +8,90 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	"github.com/Bidon15/popsigner/control-plane/internal/pkg/address"
 	"github.com/Bidon15/popsigner/control-plane/internal/service"
-	"golang.org/x/crypto/sha3"
 )
 
-// deriveEthAddressFromPubKey derives an Ethereum address from a compressed secp256k1 public key.
-// Returns the address as a 0x-prefixed hex string.
-func deriveEthAddressFromPubKey(compressedPubKey []byte) (string, error) {
-	if len(compressedPubKey) != 33 {
-		return "", fmt.Errorf("invalid compressed public key length: got %d, expected 33", len(compressedPubKey))
-	}
-
-	// Parse the compressed public key
-	// First byte: 0x02 (even y) or 0x03 (odd y)
-	// Remaining 32 bytes: x coordinate
-	prefix := compressedPubKey[0]
-	if prefix != 0x02 && prefix != 0x03 {
-		return "", fmt.Errorf("invalid public key prefix: %x", prefix)
-	}
-
-	// For Ethereum address derivation, we need to decompress the public key
-	// This requires secp256k1 curve operations. For simplicity, we'll use a pure Go approach.
-	// The uncompressed key is 65 bytes: 0x04 + x (32 bytes) + y (32 bytes)
-	
-	// Decompress the public key using the secp256k1 curve
-	uncompressedPubKey, err := decompressSecp256k1PubKey(compressedPubKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to decompress public key: %w", err)
-	}
+// tracerName is the instrumentation scope of spans around OpenBao calls.
+const tracerName = "github.com/Bidon15/popsigner/control-plane/internal/openbao"
+
+// digestSize is the length of a prehashed input or EVM hash.
+const digestSize = 32
+
+// Failure classes of OpenBao requests, matched with errors.Is.
+var (
+	// ErrUnavailable marks failures caused by OpenBao being unreachable, sealed or
+	// overloaded. Requests that fail with it may succeed if retried shortly after.
+	ErrUnavailable = errors.New("openbao unavailable")
+	// ErrUnauthorized means OpenBao rejected the client token.
+	ErrUnauthorized = errors.New("openbao unauthorized")
+	// ErrPermissionDenied means the token's policies don't allow the request.
+	ErrPermissionDenied = errors.New("openbao permission denied")
+	// ErrKeyNotFound means the requested key doesn't exist in the plugin.
+	ErrKeyNotFound = errors.New("openbao key not found")
+	// ErrSealed means HealthCheck found OpenBao sealed.
+	ErrSealed = errors.New("openbao sealed")
+	// ErrNotInitialized means HealthCheck found OpenBao not initialized.
+	ErrNotInitialized = errors.New("openbao not initialized")
+	// ErrInvalidDigest means a prehashed input or EVM hash isn't a 32-byte
+	// digest. It is returned before anything is sent to OpenBao.
+	ErrInvalidDigest = errors.New("openbao input is not a 32-byte digest")
+)
 
-	// Ethereum address = last 20 bytes of Keccak256(uncompressed_pubkey[1:])
-	// Skip the 0x04 prefix (first byte)
-	hash := sha3.NewLegacyKeccak256()
-	hash.Write(uncompressedPubKey[1:]) // Skip the 0x04 prefix
-	fullHash := hash.Sum(nil)
-	
-	// Take last 20 bytes
-	address := fullHash[12:]
-	
-	return "0x" + hex.EncodeToString(address), nil
+// StatusError is a non-200 response from OpenBao.
+type StatusError struct {
+	StatusCode int
+	Body       string
 }
 
-// decompressSecp256k1PubKey decompresses a 33-byte compressed secp256k1 public key to 65-byte uncompressed.
-func decompressSecp256k1PubKey(compressed []byte) ([]byte, error) {
-	// Use crypto/elliptic with secp256k1 curve parameters
-	// secp256k1 parameters
-	curve := secp256k1Curve()
-	
-	x, y := curve.UnmarshalCompressed(compressed)
-	if x == nil {
-		return nil, fmt.Errorf("failed to unmarshal compressed public key")
-	}
-	
-	// Create uncompressed format: 0x04 + x + y
-	uncompressed := make([]byte, 65)
-	uncompressed[0] = 0x04
-	xBytes := x.Bytes()
-	yBytes := y.Bytes()
-	
-	// Pad to 32 bytes each
-	copy(uncompressed[1+32-len(xBytes):33], xBytes)
-	copy(uncompressed[33+32-len(yBytes):65], yBytes)
-	
-	return uncompressed, nil
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("OpenBao error (status %d): %s", e.StatusCode, e.Body)
 }
 
-// secp256k1Curve returns the secp256k1 elliptic curve.
-// We use a custom implementation since Go's crypto/elliptic doesn't include secp256k1.
-func secp256k1Curve() *secp256k1 {
-	return &secp256k1{}
+// Is maps the response status to the failure class sentinels.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrUnavailable:
+		switch e.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPermissionDenied:
+		return e.StatusCode == http.StatusForbidden
+	case ErrKeyNotFound:
+		// The plugin reports missing keys as a 400 error response
+		return e.StatusCode == http.StatusNotFound ||
+			(e.StatusCode == http.StatusBadRequest && strings.Contains(e.Body, "key not found"))
+	}
+	return false
 }
 
-// secp256k1 implements elliptic.Curve for secp256k1.
-// This is a minimal implementation for decompressing public keys.
-type secp256k1 struct{}
-
-func (secp256k1) UnmarshalCompressed(data []byte) (*big.Int, *big.Int) {
-	if len(data) != 33 || (data[0] != 0x02 && data[0] != 0x03) {
-		return nil, nil
-	}
-
-	// secp256k1 curve parameters
-	p, _ := new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
-	
-	x := new(big.Int).SetBytes(data[1:33])
-	
-	// y² = x³ + 7 (mod p)
-	x3 := new(big.Int).Mul(x, x)
-	x3.Mul(x3, x)
-	x3.Mod(x3, p)
-	
-	y2 := new(big.Int).Add(x3, big.NewInt(7))
-	y2.Mod(y2, p)
-	
-	// Compute y = sqrt(y²) mod p using Tonelli-Shanks
-	y := new(big.Int).ModSqrt(y2, p)
-	if y == nil {
-		return nil, nil
-	}
-	
-	// Choose the correct y based on the prefix (0x02 = even, 0x03 = odd)
-	if data[0] == 0x02 {
-		if y.Bit(0) == 1 { // y is odd, we need even
-			y.Sub(p, y)
-		}
-	} else {
-		if y.Bit(0) == 0 { // y is even, we need odd
-			y.Sub(p, y)
-		}
+// deriveEthAddressFromPubKey derives an Ethereum address from a compressed secp256k1 public key.
+// Returns the address as a lowercase 0x-prefixed hex string, the form keys are stored with.
+func deriveEthAddressFromPubKey(compressedPubKey []byte) (string, error) {
+	addr, err := address.Ethereum(compressedPubKey)
+	if err != nil {
+		return "", err
 	}
-	
-	return x, y
+	return strings.ToLower(addr), nil
 }
 
 // Client implements service.BaoKeyringInterface for the secp256k1 plugin.
@@ -134,6 +100,7 @@ type Client struct {
 	token     string
 	mountPath string
 	client    *http.Client
+	tracer    trace.Tracer
 }
 
 // NewClient creates a new OpenBao client.
@@ -156,6 +123,7 @@ func NewClient(cfg *config.OpenBaoConfig) *Client {
 			Transport: transport,
 			Timeout:   30 * time.Second,
 		},
+		tracer: otel.Tracer(tracerName),
 	}
 }
 
@@ -217,7 +185,7 @@ func (c *Client) NewAccountWithOptions(uid string, opts service.KeyOptions) (pub
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return nil, "", "", fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, "", "", &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var keyResp keyResponse
@@ -251,12 +219,17 @@ func (c *Client) NewAccountWithOptions(uid string, opts service.KeyOptions) (pub
 }
 
 // Sign signs a message with the given key.
-func (c *Client) Sign(uid string, msg []byte) (signature []byte, pubKey []byte, err error) {
+// If prehashed is true, msg must be a 32-byte digest and is signed without hashing.
+func (c *Client) Sign(uid string, msg []byte, prehashed bool) (signature []byte, pubKey []byte, err error) {
+	if prehashed && len(msg) != digestSize {
+		return nil, nil, fmt.Errorf("%w: got %d bytes", ErrInvalidDigest, len(msg))
+	}
+
 	url := fmt.Sprintf("%s/v1/%s/sign/%s", c.address, c.mountPath, uid)
 	
 	body := map[string]interface{}{
 		"input":     base64.StdEncoding.EncodeToString(msg),
-		"prehashed": false,
+		"prehashed": prehashed,
 	}
 	
 	jsonBody, err := json.Marshal(body)
@@ -284,7 +257,7 @@ func (c *Client) Sign(uid string, msg []byte) (signature []byte, pubKey []byte,
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var signResp signResponse
@@ -306,7 +279,7 @@ func (c *Client) Sign(uid string, msg []byte) (signature []byte, pubKey []byte,
 		return nil, nil, fmt.Errorf("failed to decode public key: %w", err)
 	}
 
-	return sig, pubKeyBytes, nil
+	return normalizeCompactLowS(sig), pubKeyBytes, nil
 }
 
 // Delete removes a key from OpenBao.
@@ -329,7 +302,7 @@ func (c *Client) Delete(uid string) error {
 	// Treat 404 as success - key is already gone (e.g., legacy keys not in OpenBao)
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(body))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return nil
@@ -358,7 +331,7 @@ func (c *Client) GetMetadata(uid string) (*service.KeyMetadata, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -386,12 +359,15 @@ func (c *Client) GetMetadata(uid string) (*service.KeyMetadata, error) {
 		}
 	}
 
+	// The plugin mount only holds secp256k1 keys
 	return &service.KeyMetadata{
 		UID:         uid,
 		Name:        keyResp.Data.Name,
 		PubKeyBytes: pubKeyBytes,
 		Address:     keyResp.Data.Address,
 		EthAddress:  ethAddr,
+		Algorithm:   models.AlgorithmSecp256k1,
+		Curve:       models.AlgorithmSecp256k1.Curve(),
 	}, nil
 }
 
@@ -429,7 +405,7 @@ func (c *Client) ImportKey(uid string, ciphertext string, exportable bool) (pubK
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, "", "", fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, "", "", &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var keyResp keyResponse
@@ -474,7 +450,7 @@ func (c *Client) ExportKey(uid string) (string, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(body))
+		return "", &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -507,7 +483,29 @@ type SignEVMResponse struct {
 // SignEVM signs a hash with EIP-155 format via the plugin.
 // The hash should be base64-encoded.
 // chainID of 0 means legacy signing (v = 27/28).
-func (c *Client) SignEVM(keyName, hashB64 string, chainID int64) (*SignEVMResponse, error) {
+// The call is traced as a child of any span in ctx.
+func (c *Client) SignEVM(ctx context.Context, keyName, hashB64 string, chainID int64) (_ *SignEVMResponse, err error) {
+	ctx, span := c.tracer.Start(ctx, "openbao.sign_evm",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("openbao.key", keyName),
+			attribute.Int64("openbao.chain_id", chainID),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if hash, decodeErr := base64.StdEncoding.DecodeString(hashB64); decodeErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDigest, decodeErr)
+	} else if len(hash) != digestSize {
+		return nil, fmt.Errorf("%w: got %d bytes", ErrInvalidDigest, len(hash))
+	}
+
 	url := fmt.Sprintf("%s/v1/%s/sign-evm/%s", c.address, c.mountPath, keyName)
 
 	body := map[string]interface{}{
@@ -520,7 +518,7 @@ func (c *Client) SignEVM(keyName, hashB64 string, chainID int64) (*SignEVMRespon
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -530,7 +528,10 @@ func (c *Client) SignEVM(keyName, hashB64 string, chainID int64) (*SignEVMRespon
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+		return nil, fmt.Errorf("request failed: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -540,7 +541,7 @@ func (c *Client) SignEVM(keyName, hashB64 string, chainID int64) (*SignEVMRespon
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var signResp struct {
@@ -549,16 +550,61 @@ func (c *Client) SignEVM(keyName, hashB64 string, chainID int64) (*SignEVMRespon
 	if err := json.Unmarshal(respBody, &signResp); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	signResp.Data.normalizeLowS()
 
 	return &signResp.Data, nil
 }
 
+// secp256k1N is the order of the secp256k1 group. Signatures with S above
+// half of it are malleable and rejected by Ethereum (EIP-2).
+var (
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// normalizeLowS replaces a high S with N - S, the equivalent canonical value,
+// and flips the recovery parity in V to match. Both legacy (27 + parity) and
+// EIP-155 (35 + 2*chainID + parity) V values have an odd base, so an odd V
+// becomes the next even one and vice versa. Malformed values are left for the
+// caller to reject.
+func (r *SignEVMResponse) normalizeLowS() {
+	s, ok := new(big.Int).SetString(r.S, 16)
+	if !ok || s.Cmp(secp256k1HalfN) <= 0 || s.Cmp(secp256k1N) >= 0 {
+		return
+	}
+	r.S = fmt.Sprintf("%064x", s.Sub(secp256k1N, s))
+	if r.VInt%2 == 1 {
+		r.VInt++
+	} else {
+		r.VInt--
+	}
+	r.V = fmt.Sprintf("%x", r.VInt)
+}
+
+// normalizeCompactLowS returns a 64-byte R||S signature with S in the lower
+// half of the group order. Other lengths are returned unchanged.
+func normalizeCompactLowS(sig []byte) []byte {
+	if len(sig) != 64 {
+		return sig
+	}
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(secp256k1HalfN) <= 0 || s.Cmp(secp256k1N) >= 0 {
+		return sig
+	}
+	normalized := make([]byte, 64)
+	copy(normalized, sig[:32])
+	s.Sub(secp256k1N, s).FillBytes(normalized[32:])
+	return normalized
+}
+
 // PKI returns the PKI client for certificate authority operations.
 func (c *Client) PKI() *PKIClient {
 	return NewPKIClient(c)
 }
 
-// HealthCheck checks the health of the OpenBao server.
+// HealthCheck checks the health of the OpenBao server. A sealed or
+// uninitialized server fails with ErrSealed or ErrNotInitialized, an
+// unreachable one with ErrUnavailable.
 func (c *Client) HealthCheck(ctx context.Context) error {
 	url := fmt.Sprintf("%s/v1/sys/health", c.address)
 
@@ -570,7 +616,7 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	// Health check doesn't require auth
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("request failed: %w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
@@ -589,7 +635,14 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	}
 
 	body, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("OpenBao unhealthy (status %d): %s", resp.StatusCode, string(body))
+	statusErr := &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	switch resp.StatusCode {
+	case http.StatusServiceUnavailable:
+		return fmt.Errorf("%w: %w", ErrSealed, statusErr)
+	case http.StatusNotImplemented:
+		return fmt.Errorf("%w: %w", ErrNotInitialized, statusErr)
+	}
+	return fmt.Errorf("OpenBao unhealthy: %w", statusErr)
 }
 
 // ===============================================
@@ -636,7 +689,7 @@ func (c *Client) ReadKVSecret(path string) (map[string]interface{}, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -687,7 +740,7 @@ func (c *Client) WriteKVSecret(path string, data map[string]interface{}) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	return nil
@@ -714,7 +767,7 @@ func (c *Client) DeleteKVSecret(path string) error {
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("OpenBao error (status %d): %s", resp.StatusCode, string(respBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	return nil
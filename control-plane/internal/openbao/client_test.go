@@ -0,0 +1,238 @@
+package openbao
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/config"
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// testHash is a base64-encoded 32-byte digest.
+const testHash = "0EuY9I6Pi8wVxq5awFCAHNbc/UKPtfnmXE4W54BzQPo="
+
+func TestClient_SignEVM_StatusErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"sealed", http.StatusServiceUnavailable, `{"errors":["Vault is sealed"]}`, ErrUnavailable},
+		{"bad gateway", http.StatusBadGateway, `{"errors":["boom"]}`, ErrUnavailable},
+		{"rate limited", http.StatusTooManyRequests, `{"errors":["rate limited"]}`, ErrUnavailable},
+		{"bad token", http.StatusUnauthorized, `{"errors":["invalid token"]}`, ErrUnauthorized},
+		{"permission denied", http.StatusForbidden, `{"errors":["permission denied"]}`, ErrPermissionDenied},
+		{"key not found", http.StatusBadRequest, `{"errors":["key not found"]}`, ErrKeyNotFound},
+		{"no route", http.StatusNotFound, `{"errors":[]}`, ErrKeyNotFound},
+		{"bad request", http.StatusBadRequest, `{"errors":["hash must be 32 bytes, got 4"]}`, nil},
+	}
+	classes := []error{ErrUnavailable, ErrUnauthorized, ErrPermissionDenied, ErrKeyNotFound}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewClient(&config.OpenBaoConfig{Address: srv.URL, Token: "test"})
+			_, err := client.SignEVM(context.Background(), "key", testHash, 1)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Errorf("expected StatusError with status %d, got %v", tt.status, err)
+			}
+			for _, class := range classes {
+				if got, want := errors.Is(err, class), class == tt.want; got != want {
+					t.Errorf("errors.Is(err, %v) = %v, want %v", class, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_SignEVM_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.URL
+	srv.Close()
+
+	client := NewClient(&config.OpenBaoConfig{Address: addr, Token: "test"})
+	_, err := client.SignEVM(context.Background(), "key", testHash, 1)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestClient_HealthCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"active", http.StatusOK, nil},
+		{"standby", http.StatusTooManyRequests, nil},
+		{"sealed", http.StatusServiceUnavailable, ErrSealed},
+		{"not initialized", http.StatusNotImplemented, ErrNotInitialized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/sys/health" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			client := NewClient(&config.OpenBaoConfig{Address: srv.URL})
+			err := client.HealthCheck(context.Background())
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("HealthCheck() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("HealthCheck() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetMetadata_Algorithm(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"name":"key","address":"abcd","public_key":"0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(&config.OpenBaoConfig{Address: srv.URL, Token: "test"})
+	meta, err := client.GetMetadata("key")
+	if err != nil {
+		t.Fatalf("GetMetadata: %v", err)
+	}
+	if meta.Algorithm != models.AlgorithmSecp256k1 {
+		t.Errorf("Algorithm = %q, want secp256k1", meta.Algorithm)
+	}
+	if meta.Curve != "secp256k1" {
+		t.Errorf("Curve = %q, want secp256k1", meta.Curve)
+	}
+}
+
+func TestClient_InvalidDigest(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"signature": "c2ln", "public_key": "02ab"},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(&config.OpenBaoConfig{Address: srv.URL, Token: "test"})
+
+	for _, hash := range []string{"aGFzaA==", "not base64!", ""} {
+		if _, err := client.SignEVM(context.Background(), "key", hash, 1); !errors.Is(err, ErrInvalidDigest) {
+			t.Errorf("SignEVM(%q) error = %v, want ErrInvalidDigest", hash, err)
+		}
+	}
+	for _, n := range []int{0, 10, 31, 33} {
+		if _, _, err := client.Sign("key", make([]byte, n), true); !errors.Is(err, ErrInvalidDigest) {
+			t.Errorf("Sign() with %d-byte prehashed input error = %v, want ErrInvalidDigest", n, err)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("expected no requests to OpenBao, got %d", calls)
+	}
+
+	if _, _, err := client.Sign("key", make([]byte, 32), true); err != nil {
+		t.Errorf("Sign() with a 32-byte digest error = %v", err)
+	}
+	if _, _, err := client.Sign("key", []byte("any length message"), false); err != nil {
+		t.Errorf("Sign() without prehashing error = %v", err)
+	}
+}
+
+func TestClient_SignEVM_NormalizesHighS(t *testing.T) {
+	r := strings.Repeat("ab", 32)
+	lowS := big.NewInt(42)
+	highS := new(big.Int).Sub(secp256k1N, lowS)
+
+	tests := []struct {
+		name    string
+		chainID int64
+		vInt    int64
+		wantV   int64
+	}{
+		{"legacy parity 0", 0, 27, 28},
+		{"legacy parity 1", 0, 28, 27},
+		{"eip155 parity 0", 10, 35 + 2*10, 36 + 2*10},
+		{"eip155 parity 1", 10, 36 + 2*10, 35 + 2*10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The backend returns a signature with S in the high half
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{
+						"r":     r,
+						"s":     fmt.Sprintf("%064x", highS),
+						"v":     fmt.Sprintf("%x", tt.vInt),
+						"v_int": tt.vInt,
+					},
+				})
+			}))
+			defer srv.Close()
+
+			client := NewClient(&config.OpenBaoConfig{Address: srv.URL, Token: "test"})
+			resp, err := client.SignEVM(context.Background(), "key", testHash, tt.chainID)
+			if err != nil {
+				t.Fatalf("SignEVM() error = %v", err)
+			}
+
+			if resp.R != r {
+				t.Errorf("R = %s, want %s", resp.R, r)
+			}
+			if want := fmt.Sprintf("%064x", lowS); resp.S != want {
+				t.Errorf("S = %s, want low-S %s", resp.S, want)
+			}
+			if resp.VInt != tt.wantV || resp.V != fmt.Sprintf("%x", tt.wantV) {
+				t.Errorf("V = %s (%d), want %d", resp.V, resp.VInt, tt.wantV)
+			}
+		})
+	}
+}
+
+func TestNormalizeCompactLowS(t *testing.T) {
+	sig := make([]byte, 64)
+	sig[31] = 1
+	lowS := big.NewInt(42)
+	new(big.Int).Sub(secp256k1N, lowS).FillBytes(sig[32:])
+
+	got := normalizeCompactLowS(sig)
+	if new(big.Int).SetBytes(got[32:]).Cmp(lowS) != 0 {
+		t.Errorf("S = %x, want %x", got[32:], lowS)
+	}
+	if !bytes.Equal(got[:32], sig[:32]) {
+		t.Error("R changed")
+	}
+
+	// Low-S signatures are returned as is
+	if again := normalizeCompactLowS(got); !bytes.Equal(again, got) {
+		t.Error("low-S signature changed")
+	}
+}
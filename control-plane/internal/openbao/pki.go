@@ -67,6 +67,13 @@ type IssueCertRequest struct {
 	TTL        string `json:"ttl,omitempty"`
 }
 
+// SignCSRRequest represents a request to sign a client-generated CSR.
+type SignCSRRequest struct {
+	CSRPEM     string `json:"csr"`
+	CommonName string `json:"common_name"` // Overrides the CN in the CSR
+	TTL        string `json:"ttl,omitempty"`
+}
+
 // IssuedCertificate represents an issued certificate.
 type IssuedCertificate struct {
 	CertificatePEM string    `json:"certificate_pem"`
@@ -298,6 +305,65 @@ func (p *PKIClient) IssueCertificate(ctx context.Context, req *IssueCertRequest)
 	}, nil
 }
 
+// SignCertificateRequest signs a CSR with the client certificate role. The CN
+// is always taken from the request, never from the CSR, so a client cannot
+// enroll a certificate for another organization. The returned certificate
+// has no private key.
+func (p *PKIClient) SignCertificateRequest(ctx context.Context, req *SignCSRRequest) (*IssuedCertificate, error) {
+	path := fmt.Sprintf("/v1/%s/sign/%s", p.mount, ClientCertRoleName)
+
+	ttl := DefaultCertTTL
+	if req.TTL != "" {
+		ttl = req.TTL
+	}
+
+	data := map[string]interface{}{
+		"csr":                 req.CSRPEM,
+		"common_name":         req.CommonName,
+		"use_csr_common_name": false,
+		"use_csr_sans":        false,
+		"ttl":                 ttl,
+	}
+
+	resp, err := p.doRequest(ctx, "POST", path, data)
+	if err != nil {
+		return nil, fmt.Errorf("signing certificate request: %w", err)
+	}
+
+	certPEM, ok := resp.Data["certificate"].(string)
+	if !ok {
+		return nil, fmt.Errorf("no certificate in response")
+	}
+
+	caPEM, ok := resp.Data["issuing_ca"].(string)
+	if !ok {
+		return nil, fmt.Errorf("no issuing CA in response")
+	}
+
+	serialNumber, ok := resp.Data["serial_number"].(string)
+	if !ok {
+		return nil, fmt.Errorf("no serial number in response")
+	}
+
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+
+	return &IssuedCertificate{
+		CertificatePEM: certPEM,
+		CACertPEM:      caPEM,
+		SerialNumber:   serialNumber,
+		IssuedAt:       cert.NotBefore,
+		ExpiresAt:      cert.NotAfter,
+	}, nil
+}
+
 // RevokeCertificate revokes a certificate by serial number.
 func (p *PKIClient) RevokeCertificate(ctx context.Context, serialNumber string) error {
 	path := fmt.Sprintf("/v1/%s/revoke", p.mount)
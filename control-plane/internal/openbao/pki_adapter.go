@@ -45,6 +45,26 @@ func (a *PKIAdapter) IssueCertificate(ctx context.Context, req *service.IssueCer
 	}, nil
 }
 
+// SignCertificateRequest signs a client-generated CSR.
+func (a *PKIAdapter) SignCertificateRequest(ctx context.Context, req *service.SignCSRRequest) (*service.IssuedCertificate, error) {
+	result, err := a.client.SignCertificateRequest(ctx, &SignCSRRequest{
+		CSRPEM:     req.CSRPEM,
+		CommonName: req.CommonName,
+		TTL:        req.TTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.IssuedCertificate{
+		CertificatePEM: result.CertificatePEM,
+		CACertPEM:      result.CACertPEM,
+		SerialNumber:   result.SerialNumber,
+		IssuedAt:       result.IssuedAt,
+		ExpiresAt:      result.ExpiresAt,
+	}, nil
+}
+
 // RevokeCertificate revokes a certificate by serial number.
 func (a *PKIAdapter) RevokeCertificate(ctx context.Context, serialNumber string) error {
 	return a.client.RevokeCertificate(ctx, serialNumber)
@@ -2,6 +2,12 @@ package openbao
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"os"
 	"testing"
 	"time"
@@ -178,6 +184,62 @@ func TestPKIClient_IssueCertificate_DefaultTTL(t *testing.T) {
 	}
 }
 
+func TestPKIClient_SignCertificateRequest(t *testing.T) {
+	client := newTestClient()
+	if client == nil {
+		t.Skip("OpenBao not available (set OPENBAO_ADDR and OPENBAO_TOKEN)")
+	}
+
+	ctx := context.Background()
+	pki := client.PKI()
+
+	// Initialize CA if needed
+	_, err := pki.InitializeCA(ctx)
+	if err != nil {
+		t.Fatalf("InitializeCA failed: %v", err)
+	}
+
+	// The CSR asks for a different CN, which must be ignored
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "org_someone_else"},
+	}, key)
+	if err != nil {
+		t.Fatalf("creating CSR: %v", err)
+	}
+
+	cert, err := pki.SignCertificateRequest(ctx, &SignCSRRequest{
+		CSRPEM:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})),
+		CommonName: "org_test_csr",
+		TTL:        "24h",
+	})
+	if err != nil {
+		t.Fatalf("SignCertificateRequest failed: %v", err)
+	}
+
+	if cert.PrivateKeyPEM != "" {
+		t.Error("PrivateKeyPEM should be empty for a signed CSR")
+	}
+	if cert.SerialNumber == "" {
+		t.Error("SerialNumber is empty")
+	}
+
+	block, _ := pem.Decode([]byte(cert.CertificatePEM))
+	if block == nil {
+		t.Fatal("failed to decode certificate PEM")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	if parsed.Subject.CommonName != "org_test_csr" {
+		t.Errorf("CommonName = %q, want org_test_csr", parsed.Subject.CommonName)
+	}
+}
+
 func TestPKIClient_RevokeCertificate(t *testing.T) {
 	client := newTestClient()
 	if client == nil {
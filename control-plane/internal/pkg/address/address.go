@@ -0,0 +1,176 @@
+// Package address derives account addresses from secp256k1 public keys.
+package address
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // Cosmos addresses are defined over RIPEMD-160
+	"golang.org/x/crypto/sha3"
+)
+
+// CompressedPubKeyLen is the length of a compressed secp256k1 public key.
+const CompressedPubKeyLen = 33
+
+// ErrInvalidPubKey is returned for keys that are not compressed secp256k1 points.
+var ErrInvalidPubKey = errors.New("invalid compressed secp256k1 public key")
+
+// secp256k1P is the field prime of secp256k1.
+var secp256k1P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+
+// Cosmos returns the bech32 account address of pubKey with the given prefix:
+// bech32(hrp, RIPEMD160(SHA256(pubKey))).
+func Cosmos(hrp string, pubKey []byte) (string, error) {
+	if len(pubKey) != CompressedPubKeyLen || (pubKey[0] != 0x02 && pubKey[0] != 0x03) {
+		return "", ErrInvalidPubKey
+	}
+	sum := sha256.Sum256(pubKey)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	return Bech32(hrp, h.Sum(nil))
+}
+
+// Ethereum returns the EIP-55 checksummed Ethereum address of pubKey.
+func Ethereum(pubKey []byte) (string, error) {
+	uncompressed, err := Decompress(pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	// Address = last 20 bytes of Keccak256 over the 64-byte X||Y
+	h := sha3.NewLegacyKeccak256()
+	h.Write(uncompressed[1:])
+	return checksumHex(h.Sum(nil)[12:]), nil
+}
+
+// Decompress returns the 65-byte uncompressed form (0x04 || X || Y) of a
+// compressed secp256k1 public key.
+func Decompress(pubKey []byte) ([]byte, error) {
+	if len(pubKey) != CompressedPubKeyLen || (pubKey[0] != 0x02 && pubKey[0] != 0x03) {
+		return nil, ErrInvalidPubKey
+	}
+
+	x := new(big.Int).SetBytes(pubKey[1:])
+	if x.Cmp(secp256k1P) >= 0 {
+		return nil, ErrInvalidPubKey
+	}
+
+	// y² = x³ + 7 (mod p)
+	y2 := new(big.Int).Exp(x, big.NewInt(3), secp256k1P)
+	y2.Add(y2, big.NewInt(7))
+	y2.Mod(y2, secp256k1P)
+
+	y := new(big.Int).ModSqrt(y2, secp256k1P)
+	if y == nil {
+		return nil, ErrInvalidPubKey
+	}
+	// 0x02 selects the even root, 0x03 the odd one
+	if y.Bit(0) != uint(pubKey[0]&1) {
+		y.Sub(secp256k1P, y)
+	}
+
+	out := make([]byte, 65)
+	out[0] = 0x04
+	x.FillBytes(out[1:33])
+	y.FillBytes(out[33:])
+	return out, nil
+}
+
+// checksumHex encodes addr as 0x-prefixed hex with EIP-55 mixed-case checksum.
+func checksumHex(addr []byte) string {
+	lower := hex.EncodeToString(addr)
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	hash := h.Sum(nil)
+
+	var b strings.Builder
+	b.WriteString("0x")
+	for i, c := range lower {
+		// Uppercase letters whose matching hash nibble is >= 8
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if c >= 'a' && nibble&0x0f >= 8 {
+			c -= 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Bech32 encodes data to bech32 format with the given human-readable prefix.
+func Bech32(hrp string, data []byte) (string, error) {
+	if hrp == "" || len(hrp) > 83 {
+		return "", fmt.Errorf("invalid bech32 prefix length %d", len(hrp))
+	}
+	for _, c := range hrp {
+		if c < 33 || c > 126 || (c >= 'A' && c <= 'Z') {
+			return "", fmt.Errorf("invalid bech32 prefix %q", hrp)
+		}
+	}
+
+	// Convert 8-bit data to 5-bit groups
+	converted := make([]byte, 0, len(data)*8/5+1)
+	acc := 0
+	bits := 0
+	for _, b := range data {
+		acc = (acc << 8) | int(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			converted = append(converted, byte((acc>>bits)&0x1f))
+		}
+	}
+	if bits > 0 {
+		converted = append(converted, byte((acc<<(5-bits))&0x1f))
+	}
+
+	// Create checksum
+	values := append(expandHRP(hrp), converted...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	polymod := bech32Polymod(values) ^ 1
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range converted {
+		b.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&0x1f])
+	}
+	return b.String(), nil
+}
+
+func expandHRP(hrp string) []byte {
+	result := make([]byte, len(hrp)*2+1)
+	for i, c := range hrp {
+		result[i] = byte(c >> 5)
+		result[i+len(hrp)+1] = byte(c & 0x1f)
+	}
+	result[len(hrp)] = 0
+	return result
+}
+
+func bech32Polymod(values []byte) int {
+	gen := []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 25
+		chk = ((chk & 0x1ffffff) << 5) ^ int(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
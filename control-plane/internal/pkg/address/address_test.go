@@ -0,0 +1,84 @@
+package address
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// Public keys of the secp256k1 private keys 1 and 2.
+const (
+	pubKeyOne = "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	pubKeyTwo = "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEthereum(t *testing.T) {
+	tests := []struct {
+		pubKey string
+		want   string
+	}{
+		{pubKeyOne, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"},
+		{pubKeyTwo, "0x2B5AD5c4795c026514f8317c7a215E218DcCD6cF"},
+	}
+	for _, tt := range tests {
+		got, err := Ethereum(mustHex(t, tt.pubKey))
+		if err != nil {
+			t.Fatalf("Ethereum(%s): %v", tt.pubKey, err)
+		}
+		if got != tt.want {
+			t.Errorf("Ethereum(%s) = %s, want %s", tt.pubKey, got, tt.want)
+		}
+	}
+}
+
+func TestCosmos(t *testing.T) {
+	// RIPEMD160(SHA256(pubKeyOne)) = 751e76e8199196d454941c45d1b3a323f1433bd6
+	got, err := Cosmos("cosmos", mustHex(t, pubKeyOne))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c"; got != want {
+		t.Errorf("Cosmos = %s, want %s", got, want)
+	}
+
+	celestia, err := Cosmos("celestia", mustHex(t, pubKeyOne))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(celestia, "celestia1") || celestia[len("celestia1"):len(celestia)-6] != got[len("cosmos1"):len(got)-6] {
+		t.Errorf("prefixes encode different payloads: %s vs %s", celestia, got)
+	}
+}
+
+func TestInvalidPubKey(t *testing.T) {
+	for _, pk := range [][]byte{
+		nil,
+		make([]byte, 33),
+		append([]byte{0x04}, make([]byte, 32)...),
+		mustHex(t, pubKeyOne)[:32],
+	} {
+		if _, err := Ethereum(pk); err == nil {
+			t.Errorf("Ethereum(%x) expected error", pk)
+		}
+		if _, err := Cosmos("cosmos", pk); err == nil {
+			t.Errorf("Cosmos(%x) expected error", pk)
+		}
+	}
+}
+
+func TestBech32_InvalidPrefix(t *testing.T) {
+	for _, hrp := range []string{"", "Cosmos", strings.Repeat("a", 84)} {
+		if _, err := Bech32(hrp, []byte{1, 2, 3}); err == nil {
+			t.Errorf("Bech32(%q) expected error", hrp)
+		}
+	}
+}
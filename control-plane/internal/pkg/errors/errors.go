@@ -55,6 +55,13 @@ var (
 		StatusCode: http.StatusForbidden,
 	}
 
+	// ErrEmailNotVerified is returned when an action requires a verified email address.
+	ErrEmailNotVerified = &APIError{
+		Code:       "email_not_verified",
+		Message:    "Verify your email address before creating keys. Check your inbox for the verification link or resend it from your profile.",
+		StatusCode: http.StatusForbidden,
+	}
+
 	// ErrNotFound is returned when a resource is not found.
 	ErrNotFound = &APIError{
 		Code:       "not_found",
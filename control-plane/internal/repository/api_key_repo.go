@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,6 +22,8 @@ type APIKeyRepository interface {
 	ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.APIKey, error)
 	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
 	Revoke(ctx context.Context, id uuid.UUID) error
+	SetSigningScope(ctx context.Context, id uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) error
+	ExpireAt(ctx context.Context, id uuid.UUID, at time.Time) (time.Time, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
@@ -36,8 +39,9 @@ func NewAPIKeyRepository(pool *pgxpool.Pool) APIKeyRepository {
 // Create inserts a new API key into the database.
 func (r *apiKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
 	query := `
-		INSERT INTO api_keys (id, org_id, user_id, name, key_prefix, key_hash, scopes, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO api_keys (id, org_id, user_id, name, key_prefix, key_hash, scopes, allowed_cidrs,
+		                      allowed_key_ids, allowed_namespace_ids, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at`
 
 	key.ID = uuid.New()
@@ -49,6 +53,9 @@ func (r *apiKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
 		key.KeyPrefix,
 		key.KeyHash,
 		key.Scopes,
+		allowedCIDRs(key.AllowedCIDRs),
+		allowedIDs(key.AllowedKeyIDs),
+		allowedIDs(key.AllowedNamespaceIDs),
 		key.ExpiresAt,
 	).Scan(&key.CreatedAt)
 }
@@ -56,8 +63,8 @@ func (r *apiKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
 // GetByID retrieves an API key by its UUID.
 func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
 	query := `
-		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes,
-		       last_used_at, expires_at, revoked_at, created_at
+		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes, allowed_cidrs,
+		       allowed_key_ids, allowed_namespace_ids, last_used_at, expires_at, revoked_at, created_at
 		FROM api_keys WHERE id = $1`
 
 	var key models.APIKey
@@ -69,6 +76,9 @@ func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey,
 		&key.KeyPrefix,
 		&key.KeyHash,
 		&key.Scopes,
+		&key.AllowedCIDRs,
+		&key.AllowedKeyIDs,
+		&key.AllowedNamespaceIDs,
 		&key.LastUsedAt,
 		&key.ExpiresAt,
 		&key.RevokedAt,
@@ -87,8 +97,8 @@ func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey,
 // Used for quick lookup during validation.
 func (r *apiKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
 	query := `
-		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes,
-		       last_used_at, expires_at, revoked_at, created_at
+		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes, allowed_cidrs,
+		       allowed_key_ids, allowed_namespace_ids, last_used_at, expires_at, revoked_at, created_at
 		FROM api_keys WHERE key_prefix = $1`
 
 	var key models.APIKey
@@ -100,6 +110,9 @@ func (r *apiKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*models.AP
 		&key.KeyPrefix,
 		&key.KeyHash,
 		&key.Scopes,
+		&key.AllowedCIDRs,
+		&key.AllowedKeyIDs,
+		&key.AllowedNamespaceIDs,
 		&key.LastUsedAt,
 		&key.ExpiresAt,
 		&key.RevokedAt,
@@ -118,8 +131,8 @@ func (r *apiKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*models.AP
 // Used for exact key validation.
 func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
 	query := `
-		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes,
-		       last_used_at, expires_at, revoked_at, created_at
+		SELECT id, org_id, user_id, name, key_prefix, key_hash, scopes, allowed_cidrs,
+		       allowed_key_ids, allowed_namespace_ids, last_used_at, expires_at, revoked_at, created_at
 		FROM api_keys WHERE key_hash = $1`
 
 	var key models.APIKey
@@ -131,6 +144,9 @@ func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*models.APIKey
 		&key.KeyPrefix,
 		&key.KeyHash,
 		&key.Scopes,
+		&key.AllowedCIDRs,
+		&key.AllowedKeyIDs,
+		&key.AllowedNamespaceIDs,
 		&key.LastUsedAt,
 		&key.ExpiresAt,
 		&key.RevokedAt,
@@ -149,8 +165,8 @@ func (r *apiKeyRepo) GetByHash(ctx context.Context, hash string) (*models.APIKey
 // Does not return the key hash for security.
 func (r *apiKeyRepo) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.APIKey, error) {
 	query := `
-		SELECT id, org_id, user_id, name, key_prefix, scopes,
-		       last_used_at, expires_at, revoked_at, created_at
+		SELECT id, org_id, user_id, name, key_prefix, scopes, allowed_cidrs,
+		       allowed_key_ids, allowed_namespace_ids, last_used_at, expires_at, revoked_at, created_at
 		FROM api_keys WHERE org_id = $1 ORDER BY created_at DESC`
 
 	rows, err := r.pool.Query(ctx, query, orgID)
@@ -169,6 +185,9 @@ func (r *apiKeyRepo) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.
 			&key.Name,
 			&key.KeyPrefix,
 			&key.Scopes,
+			&key.AllowedCIDRs,
+			&key.AllowedKeyIDs,
+			&key.AllowedNamespaceIDs,
 			&key.LastUsedAt,
 			&key.ExpiresAt,
 			&key.RevokedAt,
@@ -207,6 +226,34 @@ func (r *apiKeyRepo) Revoke(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// SetSigningScope replaces the keys and namespaces an API key may sign with.
+func (r *apiKeyRepo) SetSigningScope(ctx context.Context, id uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) error {
+	query := `UPDATE api_keys SET allowed_key_ids = $2, allowed_namespace_ids = $3 WHERE id = $1 AND revoked_at IS NULL`
+	result, err := r.pool.Exec(ctx, query, id, allowedIDs(keyIDs), allowedIDs(namespaceIDs))
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ExpireAt makes an API key expire at the given time, unless it already
+// expires earlier. It returns the key's resulting expiry.
+func (r *apiKeyRepo) ExpireAt(ctx context.Context, id uuid.UUID, at time.Time) (time.Time, error) {
+	query := `
+		UPDATE api_keys SET expires_at = LEAST(COALESCE(expires_at, $2), $2)
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING expires_at`
+
+	var expiresAt time.Time
+	if err := r.pool.QueryRow(ctx, query, id, at).Scan(&expiresAt); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
+}
+
 // Delete removes an API key from the database.
 func (r *apiKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM api_keys WHERE id = $1`
@@ -220,3 +267,18 @@ func (r *apiKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// allowedCIDRs stores a missing allowlist as an empty array.
+func allowedCIDRs(cidrs []string) []string {
+	if cidrs == nil {
+		return []string{}
+	}
+	return cidrs
+}
+
+// allowedIDs stores a missing signing scope list as an empty array.
+func allowedIDs(ids []uuid.UUID) []uuid.UUID {
+	if ids == nil {
+		return []uuid.UUID{}
+	}
+	return ids
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,9 +20,14 @@ type AuditRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog, error)
 	List(ctx context.Context, query models.AuditLogQuery) ([]*models.AuditLog, error)
 	CountByOrgAndPeriod(ctx context.Context, orgID uuid.UUID, start, end time.Time) (int64, error)
+	CountByResourceAndPeriod(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceID uuid.UUID, start, end time.Time) (int64, error)
 	DeleteBefore(ctx context.Context, orgID uuid.UUID, before time.Time) (int64, error)
 }
 
+// maxAuditListLimit caps the rows returned by List: a 100-entry page plus one
+// extra row the service uses to detect whether there is a next page.
+const maxAuditListLimit = 101
+
 type auditRepo struct {
 	pool *pgxpool.Pool
 }
@@ -34,8 +40,8 @@ func NewAuditRepository(pool *pgxpool.Pool) AuditRepository {
 // Create inserts a new audit log entry.
 func (r *auditRepo) Create(ctx context.Context, log *models.AuditLog) error {
 	query := `
-		INSERT INTO audit_logs (id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO audit_logs (id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata, request_hash, sign_mode, result)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING created_at`
 
 	if log.ID == uuid.Nil {
@@ -53,13 +59,16 @@ func (r *auditRepo) Create(ctx context.Context, log *models.AuditLog) error {
 		log.IPAddress,
 		log.UserAgent,
 		log.Metadata,
+		log.RequestHash,
+		log.SignMode,
+		log.Result,
 	).Scan(&log.CreatedAt)
 }
 
 // GetByID retrieves an audit log by ID.
 func (r *auditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog, error) {
 	query := `
-		SELECT id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata, created_at
+		SELECT id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata, request_hash, sign_mode, result, created_at
 		FROM audit_logs WHERE id = $1`
 
 	var log models.AuditLog
@@ -74,6 +83,9 @@ func (r *auditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog
 		&log.IPAddress,
 		&log.UserAgent,
 		&log.Metadata,
+		&log.RequestHash,
+		&log.SignMode,
+		&log.Result,
 		&log.CreatedAt,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -89,57 +101,55 @@ func (r *auditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog
 func (r *auditRepo) List(ctx context.Context, q models.AuditLogQuery) ([]*models.AuditLog, error) {
 	// Build dynamic query
 	baseQuery := `
-		SELECT id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata, created_at
+		SELECT id, org_id, event, actor_id, actor_type, resource_type, resource_id, ip_address, user_agent, metadata, request_hash, sign_mode, result, created_at
 		FROM audit_logs 
 		WHERE org_id = $1`
 
 	args := []any{q.OrgID}
-	argIndex := 2
 
 	if q.Event != nil {
-		baseQuery += ` AND event = $` + string(rune('0'+argIndex))
 		args = append(args, *q.Event)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND event = $%d`, len(args))
 	}
 
 	if q.ActorID != nil {
-		baseQuery += ` AND actor_id = $` + string(rune('0'+argIndex))
 		args = append(args, *q.ActorID)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND actor_id = $%d`, len(args))
 	}
 
 	if q.ResourceType != nil {
-		baseQuery += ` AND resource_type = $` + string(rune('0'+argIndex))
 		args = append(args, *q.ResourceType)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND resource_type = $%d`, len(args))
 	}
 
 	if q.ResourceID != nil {
-		baseQuery += ` AND resource_id = $` + string(rune('0'+argIndex))
 		args = append(args, *q.ResourceID)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND resource_id = $%d`, len(args))
 	}
 
 	if q.StartTime != nil {
-		baseQuery += ` AND created_at >= $` + string(rune('0'+argIndex))
 		args = append(args, *q.StartTime)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND created_at >= $%d`, len(args))
 	}
 
 	if q.EndTime != nil {
-		baseQuery += ` AND created_at <= $` + string(rune('0'+argIndex))
 		args = append(args, *q.EndTime)
-		argIndex++
+		baseQuery += fmt.Sprintf(` AND created_at <= $%d`, len(args))
+	}
+
+	if q.AfterCreatedAt != nil && q.AfterID != nil {
+		args = append(args, *q.AfterCreatedAt, *q.AfterID)
+		baseQuery += fmt.Sprintf(` AND (created_at, id) < ($%d, $%d)`, len(args)-1, len(args))
 	}
 
-	baseQuery += ` ORDER BY created_at DESC`
+	baseQuery += ` ORDER BY created_at DESC, id DESC`
 
 	limit := q.Limit
-	if limit == 0 || limit > 100 {
-		limit = 100
+	if limit <= 0 || limit > maxAuditListLimit {
+		limit = maxAuditListLimit
 	}
-	baseQuery += ` LIMIT $` + string(rune('0'+argIndex))
 	args = append(args, limit)
+	baseQuery += fmt.Sprintf(` LIMIT $%d`, len(args))
 
 	rows, err := r.pool.Query(ctx, baseQuery, args...)
 	if err != nil {
@@ -161,6 +171,9 @@ func (r *auditRepo) List(ctx context.Context, q models.AuditLogQuery) ([]*models
 			&log.IPAddress,
 			&log.UserAgent,
 			&log.Metadata,
+			&log.RequestHash,
+			&log.SignMode,
+			&log.Result,
 			&log.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -181,6 +194,23 @@ func (r *auditRepo) CountByOrgAndPeriod(ctx context.Context, orgID uuid.UUID, st
 	return count, nil
 }
 
+// CountByResourceAndPeriod counts successful events of a type on a single
+// resource within a time period. Entries recorded with result "error" are
+// excluded.
+func (r *auditRepo) CountByResourceAndPeriod(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceID uuid.UUID, start, end time.Time) (int64, error) {
+	query := `
+		SELECT COUNT(*) FROM audit_logs
+		WHERE org_id = $1 AND event = $2 AND resource_id = $3
+		  AND created_at >= $4 AND created_at <= $5
+		  AND (metadata ->> 'result') IS DISTINCT FROM 'error'`
+	var count int64
+	err := r.pool.QueryRow(ctx, query, orgID, event, resourceID, start, end).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // DeleteBefore deletes audit logs older than the given time for an organization.
 // Used for retention policy enforcement.
 func (r *auditRepo) DeleteBefore(ctx context.Context, orgID uuid.UUID, before time.Time) (int64, error) {
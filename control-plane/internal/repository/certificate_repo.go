@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -37,6 +38,7 @@ type CertificateRepository interface {
 	ListActiveByOrg(ctx context.Context, orgID string) ([]*models.Certificate, error)
 	CountByOrg(ctx context.Context, orgID string) (int, error)
 	Revoke(ctx context.Context, id string, reason string) error
+	SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error
 	Delete(ctx context.Context, id string) error
 	IsValid(ctx context.Context, fingerprint string) (*models.Certificate, error)
 	ListExpiringSoon(ctx context.Context, within time.Duration) ([]*models.Certificate, error)
@@ -56,9 +58,10 @@ func (r *certificateRepo) Create(ctx context.Context, cert *models.Certificate)
 	query := `
 		INSERT INTO client_certificates (
 			id, org_id, name, fingerprint, common_name, serial_number,
-			issued_at, expires_at, revoked_at, revocation_reason, created_at, certificate_pem
+			issued_at, expires_at, revoked_at, revocation_reason, created_at, certificate_pem,
+			allowed_key_ids, allowed_namespace_ids
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 		)`
 
 	if cert.CreatedAt.IsZero() {
@@ -78,6 +81,8 @@ func (r *certificateRepo) Create(ctx context.Context, cert *models.Certificate)
 		cert.RevocationReason,
 		cert.CreatedAt,
 		cert.CertificatePEM,
+		allowedIDs(cert.AllowedKeyIDs),
+		allowedIDs(cert.AllowedNamespaceIDs),
 	)
 	return err
 }
@@ -86,7 +91,8 @@ func (r *certificateRepo) Create(ctx context.Context, cert *models.Certificate)
 func (r *certificateRepo) GetByID(ctx context.Context, id string) (*models.Certificate, error) {
 	query := `
 		SELECT id, org_id, name, fingerprint, common_name, serial_number,
-		       issued_at, expires_at, revoked_at, revocation_reason, created_at, certificate_pem
+		       issued_at, expires_at, revoked_at, revocation_reason, created_at, certificate_pem,
+		       allowed_key_ids, allowed_namespace_ids
 		FROM client_certificates WHERE id = $1`
 
 	var cert models.Certificate
@@ -103,6 +109,8 @@ func (r *certificateRepo) GetByID(ctx context.Context, id string) (*models.Certi
 		&cert.RevocationReason,
 		&cert.CreatedAt,
 		&cert.CertificatePEM,
+		&cert.AllowedKeyIDs,
+		&cert.AllowedNamespaceIDs,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -118,7 +126,8 @@ func (r *certificateRepo) GetByID(ctx context.Context, id string) (*models.Certi
 func (r *certificateRepo) GetByFingerprint(ctx context.Context, fingerprint string) (*models.Certificate, error) {
 	query := `
 		SELECT id, org_id, name, fingerprint, common_name, serial_number,
-		       issued_at, expires_at, revoked_at, revocation_reason, created_at
+		       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+		       allowed_key_ids, allowed_namespace_ids
 		FROM client_certificates WHERE fingerprint = $1`
 
 	var cert models.Certificate
@@ -134,6 +143,8 @@ func (r *certificateRepo) GetByFingerprint(ctx context.Context, fingerprint stri
 		&cert.RevokedAt,
 		&cert.RevocationReason,
 		&cert.CreatedAt,
+		&cert.AllowedKeyIDs,
+		&cert.AllowedNamespaceIDs,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -148,7 +159,8 @@ func (r *certificateRepo) GetByFingerprint(ctx context.Context, fingerprint stri
 func (r *certificateRepo) GetBySerialNumber(ctx context.Context, serialNumber string) (*models.Certificate, error) {
 	query := `
 		SELECT id, org_id, name, fingerprint, common_name, serial_number,
-		       issued_at, expires_at, revoked_at, revocation_reason, created_at
+		       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+		       allowed_key_ids, allowed_namespace_ids
 		FROM client_certificates WHERE serial_number = $1`
 
 	var cert models.Certificate
@@ -164,6 +176,8 @@ func (r *certificateRepo) GetBySerialNumber(ctx context.Context, serialNumber st
 		&cert.RevokedAt,
 		&cert.RevocationReason,
 		&cert.CreatedAt,
+		&cert.AllowedKeyIDs,
+		&cert.AllowedNamespaceIDs,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -178,7 +192,8 @@ func (r *certificateRepo) GetBySerialNumber(ctx context.Context, serialNumber st
 func (r *certificateRepo) GetByOrgAndName(ctx context.Context, orgID, name string) (*models.Certificate, error) {
 	query := `
 		SELECT id, org_id, name, fingerprint, common_name, serial_number,
-		       issued_at, expires_at, revoked_at, revocation_reason, created_at
+		       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+		       allowed_key_ids, allowed_namespace_ids
 		FROM client_certificates WHERE org_id = $1 AND name = $2`
 
 	var cert models.Certificate
@@ -194,6 +209,8 @@ func (r *certificateRepo) GetByOrgAndName(ctx context.Context, orgID, name strin
 		&cert.RevokedAt,
 		&cert.RevocationReason,
 		&cert.CreatedAt,
+		&cert.AllowedKeyIDs,
+		&cert.AllowedNamespaceIDs,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -212,28 +229,32 @@ func (r *certificateRepo) ListByOrg(ctx context.Context, orgID string, filter Ce
 	case CertificateFilterActive:
 		query = `
 			SELECT id, org_id, name, fingerprint, common_name, serial_number,
-			       issued_at, expires_at, revoked_at, revocation_reason, created_at
+			       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+			       allowed_key_ids, allowed_namespace_ids
 			FROM client_certificates 
 			WHERE org_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
 			ORDER BY created_at DESC`
 	case CertificateFilterRevoked:
 		query = `
 			SELECT id, org_id, name, fingerprint, common_name, serial_number,
-			       issued_at, expires_at, revoked_at, revocation_reason, created_at
+			       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+			       allowed_key_ids, allowed_namespace_ids
 			FROM client_certificates 
 			WHERE org_id = $1 AND revoked_at IS NOT NULL
 			ORDER BY created_at DESC`
 	case CertificateFilterExpired:
 		query = `
 			SELECT id, org_id, name, fingerprint, common_name, serial_number,
-			       issued_at, expires_at, revoked_at, revocation_reason, created_at
+			       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+			       allowed_key_ids, allowed_namespace_ids
 			FROM client_certificates 
 			WHERE org_id = $1 AND expires_at <= NOW() AND revoked_at IS NULL
 			ORDER BY created_at DESC`
 	default: // CertificateFilterAll or unknown
 		query = `
 			SELECT id, org_id, name, fingerprint, common_name, serial_number,
-			       issued_at, expires_at, revoked_at, revocation_reason, created_at
+			       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+			       allowed_key_ids, allowed_namespace_ids
 			FROM client_certificates 
 			WHERE org_id = $1
 			ORDER BY created_at DESC`
@@ -260,6 +281,8 @@ func (r *certificateRepo) ListByOrg(ctx context.Context, orgID string, filter Ce
 			&cert.RevokedAt,
 			&cert.RevocationReason,
 			&cert.CreatedAt,
+			&cert.AllowedKeyIDs,
+			&cert.AllowedNamespaceIDs,
 		); err != nil {
 			return nil, err
 		}
@@ -304,6 +327,26 @@ func (r *certificateRepo) Revoke(ctx context.Context, id string, reason string)
 	return nil
 }
 
+// SetSigningScope replaces the keys and namespaces a certificate may sign
+// with. Revoked certificates are not updated.
+func (r *certificateRepo) SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error {
+	query := `
+		UPDATE client_certificates
+		SET allowed_key_ids = $2, allowed_namespace_ids = $3
+		WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.pool.Exec(ctx, query, id, allowedIDs(keyIDs), allowedIDs(namespaceIDs))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
 // Delete removes a certificate record permanently.
 func (r *certificateRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM client_certificates WHERE id = $1`
@@ -342,7 +385,8 @@ func (r *certificateRepo) IsValid(ctx context.Context, fingerprint string) (*mod
 func (r *certificateRepo) ListExpiringSoon(ctx context.Context, within time.Duration) ([]*models.Certificate, error) {
 	query := `
 		SELECT id, org_id, name, fingerprint, common_name, serial_number,
-		       issued_at, expires_at, revoked_at, revocation_reason, created_at
+		       issued_at, expires_at, revoked_at, revocation_reason, created_at,
+		       allowed_key_ids, allowed_namespace_ids
 		FROM client_certificates 
 		WHERE revoked_at IS NULL 
 		  AND expires_at > NOW()
@@ -370,6 +414,8 @@ func (r *certificateRepo) ListExpiringSoon(ctx context.Context, within time.Dura
 			&cert.RevokedAt,
 			&cert.RevocationReason,
 			&cert.CreatedAt,
+			&cert.AllowedKeyIDs,
+			&cert.AllowedNamespaceIDs,
 		); err != nil {
 			return nil, err
 		}
@@ -380,4 +426,3 @@ func (r *certificateRepo) ListExpiringSoon(ctx context.Context, within time.Dura
 
 // Compile-time check to ensure certificateRepo implements CertificateRepository.
 var _ CertificateRepository = (*certificateRepo)(nil)
-
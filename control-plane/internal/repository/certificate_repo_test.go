@@ -83,6 +83,11 @@ func (m *MockCertificateRepository) Revoke(ctx context.Context, id string, reaso
 	return args.Error(0)
 }
 
+func (m *MockCertificateRepository) SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error {
+	args := m.Called(ctx, id, keyIDs, namespaceIDs)
+	return args.Error(0)
+}
+
 func (m *MockCertificateRepository) Delete(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// EmailVerificationRepository defines methods for email verification token data access.
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *models.EmailVerificationToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	// MarkUsed marks an unused token as used. It returns false if the token
+	// was already used, so a token can only be redeemed once.
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	// CountCreatedSince counts the tokens issued to a user since the given
+	// time. It is used to rate limit verification emails.
+	CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	DeleteForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type emailVerificationRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository instance.
+func NewEmailVerificationRepository(pool *pgxpool.Pool) EmailVerificationRepository {
+	return &emailVerificationRepo{pool: pool}
+}
+
+func (r *emailVerificationRepo) Create(ctx context.Context, token *models.EmailVerificationToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO email_verification_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.pool.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+func (r *emailVerificationRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens WHERE token_hash = $1`
+
+	var token models.EmailVerificationToken
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *emailVerificationRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `UPDATE email_verification_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *emailVerificationRepo) CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM email_verification_tokens WHERE user_id = $1 AND created_at >= $2`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, userID, since).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteForUser removes all of a user's verification tokens, used or not.
+func (r *emailVerificationRepo) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM email_verification_tokens WHERE user_id = $1`
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
+
+// Compile-time check to ensure emailVerificationRepo implements EmailVerificationRepository.
+var _ EmailVerificationRepository = (*emailVerificationRepo)(nil)
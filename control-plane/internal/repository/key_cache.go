@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// KeyInvalidationChannel is the pub/sub channel on which key changes are announced
+// so that every replica evicts its cached copy.
+const KeyInvalidationChannel = "popsigner:keys:invalidate"
+
+// DefaultKeyCacheTTL is how long key metadata stays cached when no TTL is given.
+const DefaultKeyCacheTTL = 5 * time.Minute
+
+// KeyCacheStore is the subset of database.Redis used by the key cache.
+type KeyCacheStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	Publish(ctx context.Context, channel string, message interface{}) error
+}
+
+// KeyInvalidation is the message published on KeyInvalidationChannel.
+type KeyInvalidation struct {
+	KeyID      uuid.UUID `json:"key_id"`
+	OrgID      uuid.UUID `json:"org_id"`
+	EthAddress string    `json:"eth_address,omitempty"`
+}
+
+// cachedKey is the cached form of models.Key. models.Key hides BaoKeyPath
+// from JSON, so it cannot be cached directly.
+type cachedKey struct {
+	ID          uuid.UUID          `json:"id"`
+	OrgID       uuid.UUID          `json:"org_id"`
+	NamespaceID uuid.UUID          `json:"namespace_id"`
+	Name        string             `json:"name"`
+	PublicKey   []byte             `json:"public_key"`
+	Address     string             `json:"address"`
+	EthAddress  *string            `json:"eth_address,omitempty"`
+	NetworkType models.NetworkType `json:"network_type"`
+	Algorithm   models.Algorithm   `json:"algorithm"`
+	BaoKeyPath  string             `json:"bao_key_path"`
+	Exportable  bool               `json:"exportable"`
+	Metadata    json.RawMessage    `json:"metadata,omitempty"`
+	Version     int                `json:"version"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// CachedKeyRepository is a KeyRepository that keeps key metadata in a shared
+// Redis cache so gateway replicas do not hit Postgres on every cold request.
+// Lookups by ID and Ethereum address are cached; changes to a key, including
+// rotation, evict it and publish a KeyInvalidation.
+type CachedKeyRepository struct {
+	KeyRepository
+	store  KeyCacheStore
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+// NewCachedKeyRepository wraps a KeyRepository with a Redis-backed cache.
+func NewCachedKeyRepository(inner KeyRepository, store KeyCacheStore, ttl time.Duration, logger *slog.Logger) *CachedKeyRepository {
+	if ttl <= 0 {
+		ttl = DefaultKeyCacheTTL
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &CachedKeyRepository{
+		KeyRepository: inner,
+		store:         store,
+		ttl:           ttl,
+		logger:        logger,
+	}
+}
+
+// GetByID returns a key by ID, consulting the cache first.
+func (r *CachedKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
+	if key := r.load(ctx, keyCacheIDKey(id)); key != nil {
+		return key, nil
+	}
+	key, err := r.KeyRepository.GetByID(ctx, id)
+	if err != nil || key == nil {
+		return key, err
+	}
+	r.save(ctx, key)
+	return key, nil
+}
+
+// GetByEthAddress returns a key by Ethereum address, consulting the cache first.
+func (r *CachedKeyRepository) GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddress string) (*models.Key, error) {
+	if key := r.load(ctx, keyCacheAddressKey(orgID, ethAddress)); key != nil {
+		return key, nil
+	}
+	key, err := r.KeyRepository.GetByEthAddress(ctx, orgID, ethAddress)
+	if err != nil || key == nil {
+		return key, err
+	}
+	r.save(ctx, key)
+	return key, nil
+}
+
+// Update updates a key and invalidates its cache entries.
+func (r *CachedKeyRepository) Update(ctx context.Context, key *models.Key) error {
+	if err := r.KeyRepository.Update(ctx, key); err != nil {
+		return err
+	}
+	r.publish(ctx, key)
+	return nil
+}
+
+// Rotate replaces a key's material and invalidates the cache entries for
+// both the new and the retired address.
+func (r *CachedKeyRepository) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	if err := r.KeyRepository.Rotate(ctx, key, previous); err != nil {
+		return err
+	}
+	r.publish(ctx, &models.Key{ID: previous.KeyID, OrgID: key.OrgID, EthAddress: previous.EthAddress})
+	r.publish(ctx, key)
+	return nil
+}
+
+// SoftDelete marks a key as deleted and invalidates its cache entries.
+func (r *CachedKeyRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
+	key, err := r.KeyRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.KeyRepository.SoftDelete(ctx, id); err != nil {
+		return err
+	}
+	if key != nil {
+		r.publish(ctx, key)
+	}
+	return nil
+}
+
+// Restore clears the deleted mark on a key and invalidates its cache entries.
+func (r *CachedKeyRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := r.KeyRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	key, err := r.KeyRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		r.publish(ctx, key)
+	}
+	return nil
+}
+
+// Delete permanently removes a key and invalidates its cache entries.
+func (r *CachedKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	key, err := r.KeyRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.KeyRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if key != nil {
+		r.publish(ctx, key)
+	}
+	return nil
+}
+
+// Invalidate evicts the cache entries described by inv.
+func (r *CachedKeyRepository) Invalidate(ctx context.Context, inv KeyInvalidation) error {
+	keys := []string{keyCacheIDKey(inv.KeyID)}
+	if inv.EthAddress != "" {
+		keys = append(keys, keyCacheAddressKey(inv.OrgID, inv.EthAddress))
+	}
+	return r.store.Delete(ctx, keys...)
+}
+
+// HandleInvalidation evicts the key described by a KeyInvalidationChannel message.
+func (r *CachedKeyRepository) HandleInvalidation(ctx context.Context, payload string) error {
+	var inv KeyInvalidation
+	if err := json.Unmarshal([]byte(payload), &inv); err != nil {
+		return fmt.Errorf("invalid key invalidation message: %w", err)
+	}
+	return r.Invalidate(ctx, inv)
+}
+
+// load returns the cached key for cacheKey, or nil on a miss or cache error.
+func (r *CachedKeyRepository) load(ctx context.Context, cacheKey string) *models.Key {
+	raw, err := r.store.Get(ctx, cacheKey)
+	if err != nil || raw == "" {
+		return nil
+	}
+	var c cachedKey
+	if err := json.Unmarshal([]byte(raw), &c); err != nil {
+		return nil
+	}
+	return &models.Key{
+		ID:          c.ID,
+		OrgID:       c.OrgID,
+		NamespaceID: c.NamespaceID,
+		Name:        c.Name,
+		PublicKey:   c.PublicKey,
+		Address:     c.Address,
+		EthAddress:  c.EthAddress,
+		NetworkType: c.NetworkType,
+		Algorithm:   c.Algorithm,
+		BaoKeyPath:  c.BaoKeyPath,
+		Exportable:  c.Exportable,
+		Metadata:    c.Metadata,
+		Version:     c.Version,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// save caches a live key under its ID and Ethereum address.
+// Cache errors are logged and otherwise ignored; the database remains the source of truth.
+func (r *CachedKeyRepository) save(ctx context.Context, key *models.Key) {
+	if key.DeletedAt != nil {
+		return
+	}
+	raw, err := json.Marshal(cachedKey{
+		ID:          key.ID,
+		OrgID:       key.OrgID,
+		NamespaceID: key.NamespaceID,
+		Name:        key.Name,
+		PublicKey:   key.PublicKey,
+		Address:     key.Address,
+		EthAddress:  key.EthAddress,
+		NetworkType: key.NetworkType,
+		Algorithm:   key.Algorithm,
+		BaoKeyPath:  key.BaoKeyPath,
+		Exportable:  key.Exportable,
+		Metadata:    key.Metadata,
+		Version:     key.Version,
+		CreatedAt:   key.CreatedAt,
+		UpdatedAt:   key.UpdatedAt,
+	})
+	if err != nil {
+		return
+	}
+	if err := r.store.Set(ctx, keyCacheIDKey(key.ID), raw, r.ttl); err != nil {
+		r.logger.Warn("Failed to cache key", slog.String("key_id", key.ID.String()), slog.String("error", err.Error()))
+		return
+	}
+	if eth := key.GetEthAddress(); eth != "" {
+		_ = r.store.Set(ctx, keyCacheAddressKey(key.OrgID, eth), raw, r.ttl)
+	}
+}
+
+// publish evicts a changed key locally and announces it to other replicas.
+func (r *CachedKeyRepository) publish(ctx context.Context, key *models.Key) {
+	inv := KeyInvalidation{KeyID: key.ID, OrgID: key.OrgID, EthAddress: key.GetEthAddress()}
+	if err := r.Invalidate(ctx, inv); err != nil {
+		r.logger.Warn("Failed to evict cached key", slog.String("key_id", key.ID.String()), slog.String("error", err.Error()))
+	}
+	msg, err := json.Marshal(inv)
+	if err != nil {
+		return
+	}
+	if err := r.store.Publish(ctx, KeyInvalidationChannel, msg); err != nil {
+		r.logger.Warn("Failed to publish key invalidation", slog.String("key_id", key.ID.String()), slog.String("error", err.Error()))
+	}
+}
+
+func keyCacheIDKey(id uuid.UUID) string {
+	return "keycache:id:" + id.String()
+}
+
+// keyCacheAddressKey is case-insensitive, matching keyRepo.GetByEthAddress.
+func keyCacheAddressKey(orgID uuid.UUID, ethAddress string) string {
+	return "keycache:addr:" + orgID.String() + ":" + strings.ToLower(ethAddress)
+}
+
+// Compile-time check to ensure CachedKeyRepository implements KeyRepository.
+var _ KeyRepository = (*CachedKeyRepository)(nil)
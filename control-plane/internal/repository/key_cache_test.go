@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+var errCacheMiss = errors.New("cache miss")
+
+// memoryKeyCacheStore is an in-memory KeyCacheStore that records published messages.
+type memoryKeyCacheStore struct {
+	mu        sync.Mutex
+	values    map[string]string
+	published []string
+}
+
+func newMemoryKeyCacheStore() *memoryKeyCacheStore {
+	return &memoryKeyCacheStore{values: make(map[string]string)}
+}
+
+func (s *memoryKeyCacheStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		return "", errCacheMiss
+	}
+	return v, nil
+}
+
+func (s *memoryKeyCacheStore) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = fmt.Sprintf("%s", value)
+	return nil
+}
+
+func (s *memoryKeyCacheStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		delete(s.values, k)
+	}
+	return nil
+}
+
+func (s *memoryKeyCacheStore) Publish(ctx context.Context, channel string, message interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.published = append(s.published, fmt.Sprintf("%s", message))
+	return nil
+}
+
+func newCachedTestKey() *models.Key {
+	eth := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	return &models.Key{
+		ID:         uuid.New(),
+		OrgID:      uuid.New(),
+		Name:       "sequencer",
+		PublicKey:  []byte{0x02, 0x01},
+		EthAddress: &eth,
+		BaoKeyPath: "popsigner/keys/sequencer",
+	}
+}
+
+func TestCachedKeyRepository_CacheHitSkipsDatabase(t *testing.T) {
+	ctx := context.Background()
+	inner := new(MockKeyRepository)
+	key := newCachedTestKey()
+	inner.On("GetByEthAddress", ctx, key.OrgID, *key.EthAddress).Return(key, nil).Once()
+
+	repo := NewCachedKeyRepository(inner, newMemoryKeyCacheStore(), time.Minute, nil)
+
+	first, err := repo.GetByEthAddress(ctx, key.OrgID, *key.EthAddress)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+
+	// Second lookup, with different casing, is served from the cache.
+	second, err := repo.GetByEthAddress(ctx, key.OrgID, "0x742D35CC6634C0532925A3B844BC454E4438F44E")
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	assert.Equal(t, key.ID, second.ID)
+	assert.Equal(t, key.BaoKeyPath, second.BaoKeyPath)
+	assert.Equal(t, key.PublicKey, second.PublicKey)
+
+	// Lookup by ID is populated by the address lookup.
+	byID, err := repo.GetByID(ctx, key.ID)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, byID.ID)
+
+	inner.AssertExpectations(t)
+	inner.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestCachedKeyRepository_NotFoundIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	inner := new(MockKeyRepository)
+	orgID := uuid.New()
+	inner.On("GetByEthAddress", ctx, orgID, "0xabc").Return(nil, nil).Twice()
+
+	repo := NewCachedKeyRepository(inner, newMemoryKeyCacheStore(), time.Minute, nil)
+
+	for i := 0; i < 2; i++ {
+		key, err := repo.GetByEthAddress(ctx, orgID, "0xabc")
+		require.NoError(t, err)
+		assert.Nil(t, key)
+	}
+	inner.AssertExpectations(t)
+}
+
+func TestCachedKeyRepository_InvalidationEvictsEntry(t *testing.T) {
+	ctx := context.Background()
+	inner := new(MockKeyRepository)
+	key := newCachedTestKey()
+	inner.On("GetByEthAddress", ctx, key.OrgID, *key.EthAddress).Return(key, nil).Twice()
+
+	store := newMemoryKeyCacheStore()
+	repo := NewCachedKeyRepository(inner, store, time.Minute, nil)
+
+	_, err := repo.GetByEthAddress(ctx, key.OrgID, *key.EthAddress)
+	require.NoError(t, err)
+	assert.Len(t, store.values, 2)
+
+	msg, err := json.Marshal(KeyInvalidation{KeyID: key.ID, OrgID: key.OrgID, EthAddress: *key.EthAddress})
+	require.NoError(t, err)
+	require.NoError(t, repo.HandleInvalidation(ctx, string(msg)))
+	assert.Empty(t, store.values)
+
+	// The next lookup goes back to the database.
+	_, err = repo.GetByEthAddress(ctx, key.OrgID, *key.EthAddress)
+	require.NoError(t, err)
+	inner.AssertExpectations(t)
+}
+
+func TestCachedKeyRepository_SoftDeletePublishesInvalidation(t *testing.T) {
+	ctx := context.Background()
+	inner := new(MockKeyRepository)
+	key := newCachedTestKey()
+	inner.On("GetByID", ctx, key.ID).Return(key, nil)
+	inner.On("SoftDelete", ctx, key.ID).Return(nil)
+
+	store := newMemoryKeyCacheStore()
+	repo := NewCachedKeyRepository(inner, store, time.Minute, nil)
+
+	_, err := repo.GetByID(ctx, key.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, store.values)
+
+	require.NoError(t, repo.SoftDelete(ctx, key.ID))
+	assert.Empty(t, store.values)
+
+	require.Len(t, store.published, 1)
+	var inv KeyInvalidation
+	require.NoError(t, json.Unmarshal([]byte(store.published[0]), &inv))
+	assert.Equal(t, key.ID, inv.KeyID)
+	assert.Equal(t, key.OrgID, inv.OrgID)
+	assert.Equal(t, *key.EthAddress, inv.EthAddress)
+}
+
+func TestCachedKeyRepository_HandleInvalidation_InvalidPayload(t *testing.T) {
+	repo := NewCachedKeyRepository(new(MockKeyRepository), newMemoryKeyCacheStore(), time.Minute, nil)
+	assert.Error(t, repo.HandleInvalidation(context.Background(), "not-json"))
+}
@@ -4,6 +4,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -17,20 +18,31 @@ import (
 // KeyRepository defines the interface for cryptographic key data operations.
 type KeyRepository interface {
 	Create(ctx context.Context, key *models.Key) error
+	CreateBatch(ctx context.Context, keys []*models.Key) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error)
 	GetByName(ctx context.Context, orgID, namespaceID uuid.UUID, name string) (*models.Key, error)
 	GetByAddress(ctx context.Context, orgID uuid.UUID, address string) (*models.Key, error)
 	GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddress string) (*models.Key, error)
 	ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.Key, error)
 	ListByNamespace(ctx context.Context, namespaceID uuid.UUID) ([]*models.Key, error)
+	ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error)
+	ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error)
 	ListByEthAddresses(ctx context.Context, orgID uuid.UUID, ethAddresses []string) (map[string]*models.Key, error)
 	ListEthAddresses(ctx context.Context, orgID uuid.UUID) ([]string, error)
 	CountByOrg(ctx context.Context, orgID uuid.UUID) (int, error)
 	Update(ctx context.Context, key *models.Key) error
+	Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error
+	MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error
+	GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
+// KeyLastUsedResolution is the granularity of a key's last_used_at.
+const KeyLastUsedResolution = time.Minute
+
 type keyRepo struct {
 	pool *pgxpool.Pool
 }
@@ -71,11 +83,56 @@ func (r *keyRepo) Create(ctx context.Context, key *models.Key) error {
 	).Scan(&key.CreatedAt, &key.UpdatedAt)
 }
 
+// CreateBatch inserts keys in a single transaction: either all are
+// created or none are.
+func (r *keyRepo) CreateBatch(ctx context.Context, keys []*models.Key) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO keys (id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, bao_key_path, exportable, metadata, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at, updated_at`
+
+	for _, key := range keys {
+		if key.ID == uuid.Nil {
+			key.ID = uuid.New()
+		}
+		if key.Version == 0 {
+			key.Version = 1
+		}
+
+		err := tx.QueryRow(ctx, query,
+			key.ID,
+			key.OrgID,
+			key.NamespaceID,
+			key.Name,
+			key.PublicKey,
+			key.Address,
+			key.EthAddress,
+			key.NetworkType,
+			key.Algorithm,
+			key.BaoKeyPath,
+			key.Exportable,
+			key.Metadata,
+			key.Version,
+		).Scan(&key.CreatedAt, &key.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert key %q: %w", key.Name, err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
 // GetByID retrieves a key by its UUID.
 func (r *keyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys WHERE id = $1`
 
 	var key models.Key
@@ -93,6 +150,8 @@ func (r *keyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error
 		&key.Exportable,
 		&key.Metadata,
 		&key.Version,
+		&key.RotatedAt,
+		&key.LastUsedAt,
 		&key.DeletedAt,
 		&key.CreatedAt,
 		&key.UpdatedAt,
@@ -110,7 +169,7 @@ func (r *keyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error
 func (r *keyRepo) GetByName(ctx context.Context, orgID, namespaceID uuid.UUID, name string) (*models.Key, error) {
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE org_id = $1 AND namespace_id = $2 AND name = $3 AND deleted_at IS NULL`
 
@@ -129,6 +188,8 @@ func (r *keyRepo) GetByName(ctx context.Context, orgID, namespaceID uuid.UUID, n
 		&key.Exportable,
 		&key.Metadata,
 		&key.Version,
+		&key.RotatedAt,
+		&key.LastUsedAt,
 		&key.DeletedAt,
 		&key.CreatedAt,
 		&key.UpdatedAt,
@@ -146,7 +207,7 @@ func (r *keyRepo) GetByName(ctx context.Context, orgID, namespaceID uuid.UUID, n
 func (r *keyRepo) GetByAddress(ctx context.Context, orgID uuid.UUID, address string) (*models.Key, error) {
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE org_id = $1 AND address = $2 AND deleted_at IS NULL`
 
@@ -165,6 +226,8 @@ func (r *keyRepo) GetByAddress(ctx context.Context, orgID uuid.UUID, address str
 		&key.Exportable,
 		&key.Metadata,
 		&key.Version,
+		&key.RotatedAt,
+		&key.LastUsedAt,
 		&key.DeletedAt,
 		&key.CreatedAt,
 		&key.UpdatedAt,
@@ -186,7 +249,7 @@ func (r *keyRepo) GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddre
 
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE org_id = $1 AND LOWER(eth_address) = $2 AND deleted_at IS NULL`
 
@@ -205,6 +268,8 @@ func (r *keyRepo) GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddre
 		&key.Exportable,
 		&key.Metadata,
 		&key.Version,
+		&key.RotatedAt,
+		&key.LastUsedAt,
 		&key.DeletedAt,
 		&key.CreatedAt,
 		&key.UpdatedAt,
@@ -222,7 +287,7 @@ func (r *keyRepo) GetByEthAddress(ctx context.Context, orgID uuid.UUID, ethAddre
 func (r *keyRepo) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.Key, error) {
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE org_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC`
@@ -250,6 +315,8 @@ func (r *keyRepo) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.Key
 			&key.Exportable,
 			&key.Metadata,
 			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
 			&key.DeletedAt,
 			&key.CreatedAt,
 			&key.UpdatedAt,
@@ -265,7 +332,7 @@ func (r *keyRepo) ListByOrg(ctx context.Context, orgID uuid.UUID) ([]*models.Key
 func (r *keyRepo) ListByNamespace(ctx context.Context, namespaceID uuid.UUID) ([]*models.Key, error) {
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE namespace_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC`
@@ -293,6 +360,8 @@ func (r *keyRepo) ListByNamespace(ctx context.Context, namespaceID uuid.UUID) ([
 			&key.Exportable,
 			&key.Metadata,
 			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
 			&key.DeletedAt,
 			&key.CreatedAt,
 			&key.UpdatedAt,
@@ -304,6 +373,185 @@ func (r *keyRepo) ListByNamespace(ctx context.Context, namespaceID uuid.UUID) ([
 	return keys, rows.Err()
 }
 
+// ListPage retrieves a page of non-deleted keys using keyset pagination.
+// Keys are ordered by (created_at DESC, id DESC) so the position of the last
+// returned key is a stable cursor even while new keys are being created.
+func (r *keyRepo) ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error) {
+	query := `
+		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
+		FROM keys 
+		WHERE org_id = $1 AND deleted_at IS NULL`
+
+	args := []any{q.OrgID}
+
+	if q.NamespaceID != nil {
+		args = append(args, *q.NamespaceID)
+		query += fmt.Sprintf(` AND namespace_id = $%d`, len(args))
+	}
+
+	if q.NetworkType != nil && *q.NetworkType != models.NetworkTypeAll {
+		args = append(args, *q.NetworkType)
+		query += fmt.Sprintf(` AND network_type IN ($%d, '%s')`, len(args), models.NetworkTypeAll)
+	}
+
+	if q.NamePrefix != "" {
+		// Escape LIKE wildcards so the prefix is matched literally
+		prefix := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q.NamePrefix)
+		args = append(args, prefix+"%")
+		query += fmt.Sprintf(` AND name LIKE $%d`, len(args))
+	}
+
+	if q.LabelKey != "" {
+		args = append(args, q.LabelKey)
+		keyIdx := len(args)
+		if q.LabelValue != "" {
+			args = append(args, q.LabelValue)
+			query += fmt.Sprintf(` AND metadata ->> $%d = $%d`, keyIdx, len(args))
+		} else {
+			query += fmt.Sprintf(` AND metadata ? $%d`, keyIdx)
+		}
+	}
+
+	if q.AfterCreatedAt != nil && q.AfterID != nil {
+		args = append(args, *q.AfterCreatedAt, *q.AfterID)
+		query += fmt.Sprintf(` AND (created_at, id) < ($%d, $%d)`, len(args)-1, len(args))
+	}
+
+	query += ` ORDER BY created_at DESC, id DESC`
+
+	if q.Limit > 0 {
+		args = append(args, q.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.Key
+	for rows.Next() {
+		var key models.Key
+		if err := rows.Scan(
+			&key.ID,
+			&key.OrgID,
+			&key.NamespaceID,
+			&key.Name,
+			&key.PublicKey,
+			&key.Address,
+			&key.EthAddress,
+			&key.NetworkType,
+			&key.Algorithm,
+			&key.BaoKeyPath,
+			&key.Exportable,
+			&key.Metadata,
+			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
+			&key.DeletedAt,
+			&key.CreatedAt,
+			&key.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, rows.Err()
+}
+
+// keyPageOrder maps a key sort to its ORDER BY clause. The id tiebreaker
+// keeps pages stable between requests.
+var keyPageOrder = map[models.KeySort]string{
+	models.KeySortCreated:  `created_at DESC, id DESC`,
+	models.KeySortName:     `name ASC, id ASC`,
+	models.KeySortLastUsed: `last_used_at DESC NULLS LAST, id DESC`,
+}
+
+// ListByOrgPaged retrieves a page of non-deleted keys for an organization by
+// offset, along with the number of keys matching the filters on all pages.
+func (r *keyRepo) ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error) {
+	where := ` WHERE org_id = $1 AND deleted_at IS NULL`
+	args := []any{q.OrgID}
+
+	if q.NamespaceID != nil {
+		args = append(args, *q.NamespaceID)
+		where += fmt.Sprintf(` AND namespace_id = $%d`, len(args))
+	}
+
+	if q.NetworkType != nil && *q.NetworkType != models.NetworkTypeAll {
+		args = append(args, *q.NetworkType)
+		where += fmt.Sprintf(` AND network_type IN ($%d, '%s')`, len(args), models.NetworkTypeAll)
+	}
+
+	if q.Search != "" {
+		// Escape LIKE wildcards so the search is matched literally
+		search := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q.Search)
+		args = append(args, "%"+search+"%")
+		where += fmt.Sprintf(` AND (name ILIKE $%d OR address ILIKE $%d OR eth_address ILIKE $%d)`, len(args), len(args), len(args))
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM keys`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order, ok := keyPageOrder[q.Sort]
+	if !ok {
+		order = keyPageOrder[models.KeySortCreated]
+	}
+
+	query := `
+		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm,
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
+		FROM keys` + where + ` ORDER BY ` + order
+
+	if q.Limit > 0 {
+		args = append(args, q.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+	if q.Offset > 0 {
+		args = append(args, q.Offset)
+		query += fmt.Sprintf(` OFFSET $%d`, len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var keys []*models.Key
+	for rows.Next() {
+		var key models.Key
+		if err := rows.Scan(
+			&key.ID,
+			&key.OrgID,
+			&key.NamespaceID,
+			&key.Name,
+			&key.PublicKey,
+			&key.Address,
+			&key.EthAddress,
+			&key.NetworkType,
+			&key.Algorithm,
+			&key.BaoKeyPath,
+			&key.Exportable,
+			&key.Metadata,
+			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
+			&key.DeletedAt,
+			&key.CreatedAt,
+			&key.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, total, rows.Err()
+}
+
 // ListByEthAddresses retrieves keys by multiple Ethereum addresses within an organization.
 // Returns a map of lowercase eth_address -> Key for efficient lookup.
 func (r *keyRepo) ListByEthAddresses(ctx context.Context, orgID uuid.UUID, ethAddresses []string) (map[string]*models.Key, error) {
@@ -319,7 +567,7 @@ func (r *keyRepo) ListByEthAddresses(ctx context.Context, orgID uuid.UUID, ethAd
 
 	query := `
 		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm, 
-		       bao_key_path, exportable, metadata, version, deleted_at, created_at, updated_at
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
 		FROM keys 
 		WHERE org_id = $1 AND LOWER(eth_address) = ANY($2) AND deleted_at IS NULL`
 
@@ -346,6 +594,8 @@ func (r *keyRepo) ListByEthAddresses(ctx context.Context, orgID uuid.UUID, ethAd
 			&key.Exportable,
 			&key.Metadata,
 			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
 			&key.DeletedAt,
 			&key.CreatedAt,
 			&key.UpdatedAt,
@@ -398,21 +648,113 @@ func (r *keyRepo) CountByOrg(ctx context.Context, orgID uuid.UUID) (int, error)
 	return count, nil
 }
 
-// Update updates a key's metadata.
+// Update updates a key's metadata. The key version is only changed by Rotate.
 func (r *keyRepo) Update(ctx context.Context, key *models.Key) error {
 	query := `
 		UPDATE keys 
-		SET name = $2, metadata = $3, version = version + 1
+		SET name = $2, metadata = $3, updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING version, updated_at`
+		RETURNING updated_at`
 
-	err := r.pool.QueryRow(ctx, query, key.ID, key.Name, key.Metadata).Scan(&key.Version, &key.UpdatedAt)
+	err := r.pool.QueryRow(ctx, query, key.ID, key.Name, key.Metadata).Scan(&key.UpdatedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return pgx.ErrNoRows
 	}
 	return err
 }
 
+// Rotate replaces a key's material with the new material on key and records
+// the material it replaces as previous, in a single transaction.
+// key.Version must already be set to the new version.
+func (r *keyRepo) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	versionQuery := `
+		INSERT INTO key_versions (key_id, version, bao_key_path, public_key, address, eth_address, signing_allowed, created_at, retired_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING retired_at`
+
+	err = tx.QueryRow(ctx, versionQuery,
+		previous.KeyID,
+		previous.Version,
+		previous.BaoKeyPath,
+		previous.PublicKey,
+		previous.Address,
+		previous.EthAddress,
+		previous.SigningAllowed,
+		previous.CreatedAt,
+	).Scan(&previous.RetiredAt)
+	if err != nil {
+		return err
+	}
+
+	keyQuery := `
+		UPDATE keys
+		SET public_key = $2, address = $3, eth_address = $4, bao_key_path = $5, version = $6,
+		    rotated_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND version = $7 AND deleted_at IS NULL
+		RETURNING rotated_at, updated_at`
+
+	err = tx.QueryRow(ctx, keyQuery,
+		key.ID,
+		key.PublicKey,
+		key.Address,
+		key.EthAddress,
+		key.BaoKeyPath,
+		key.Version,
+		previous.Version,
+	).Scan(&key.RotatedAt, &key.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// MarkUsed records that a key signed at t. Signing is frequent, so the
+// column is only written when the stored value is older than
+// KeyLastUsedResolution; last_used_at is accurate to that resolution.
+func (r *keyRepo) MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	query := `
+		UPDATE keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)`
+	_, err := r.pool.Exec(ctx, query, id, t, t.Add(-KeyLastUsedResolution))
+	return err
+}
+
+// GetVersion retrieves a retired version of a key. It returns nil if the
+// version does not exist; the current version is not stored in key_versions.
+func (r *keyRepo) GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error) {
+	query := `
+		SELECT key_id, version, bao_key_path, public_key, address, eth_address, signing_allowed, created_at, retired_at
+		FROM key_versions
+		WHERE key_id = $1 AND version = $2`
+
+	var v models.KeyVersion
+	err := r.pool.QueryRow(ctx, query, keyID, version).Scan(
+		&v.KeyID,
+		&v.Version,
+		&v.BaoKeyPath,
+		&v.PublicKey,
+		&v.Address,
+		&v.EthAddress,
+		&v.SigningAllowed,
+		&v.CreatedAt,
+		&v.RetiredAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
 // SoftDelete marks a key as deleted.
 func (r *keyRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE keys SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`
@@ -426,6 +768,65 @@ func (r *keyRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// Restore clears the deleted mark on a soft-deleted key.
+func (r *keyRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE keys SET deleted_at = NULL, updated_at = NOW() WHERE id = $1 AND deleted_at IS NOT NULL`
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ListDeletedBefore retrieves keys soft-deleted before the given time, across
+// all organizations.
+func (r *keyRepo) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error) {
+	query := `
+		SELECT id, org_id, namespace_id, name, public_key, address, eth_address, network_type, algorithm,
+		       bao_key_path, exportable, metadata, version, rotated_at, last_used_at, deleted_at, created_at, updated_at
+		FROM keys
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		ORDER BY deleted_at`
+
+	rows, err := r.pool.Query(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.Key
+	for rows.Next() {
+		var key models.Key
+		if err := rows.Scan(
+			&key.ID,
+			&key.OrgID,
+			&key.NamespaceID,
+			&key.Name,
+			&key.PublicKey,
+			&key.Address,
+			&key.EthAddress,
+			&key.NetworkType,
+			&key.Algorithm,
+			&key.BaoKeyPath,
+			&key.Exportable,
+			&key.Metadata,
+			&key.Version,
+			&key.RotatedAt,
+			&key.LastUsedAt,
+			&key.DeletedAt,
+			&key.CreatedAt,
+			&key.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, rows.Err()
+}
+
 // Delete permanently removes a key.
 func (r *keyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM keys WHERE id = $1`
@@ -30,6 +30,11 @@ func (m *MockKeyRepository) Create(ctx context.Context, key *models.Key) error {
 	return args.Error(0)
 }
 
+func (m *MockKeyRepository) CreateBatch(ctx context.Context, keys []*models.Key) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
 func (m *MockKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -78,6 +83,22 @@ func (m *MockKeyRepository) ListByNamespace(ctx context.Context, namespaceID uui
 	return args.Get(0).([]*models.Key), args.Error(1)
 }
 
+func (m *MockKeyRepository) ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Key), args.Error(1)
+}
+
+func (m *MockKeyRepository) ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Key), args.Int(1), args.Error(2)
+}
+
 func (m *MockKeyRepository) ListByEthAddresses(ctx context.Context, orgID uuid.UUID, ethAddresses []string) (map[string]*models.Key, error) {
 	args := m.Called(ctx, orgID, ethAddresses)
 	if args.Get(0) == nil {
@@ -104,11 +125,42 @@ func (m *MockKeyRepository) Update(ctx context.Context, key *models.Key) error {
 	return args.Error(0)
 }
 
+func (m *MockKeyRepository) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	args := m.Called(ctx, key, previous)
+	return args.Error(0)
+}
+
+func (m *MockKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	args := m.Called(ctx, id, t)
+	return args.Error(0)
+}
+
+func (m *MockKeyRepository) GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error) {
+	args := m.Called(ctx, keyID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KeyVersion), args.Error(1)
+}
+
 func (m *MockKeyRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
+func (m *MockKeyRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockKeyRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Key), args.Error(1)
+}
+
 func (m *MockKeyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
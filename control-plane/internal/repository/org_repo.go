@@ -302,23 +302,26 @@ func (r *orgRepo) CreateNamespace(ctx context.Context, ns *models.Namespace) err
 	if ns.ID == uuid.Nil {
 		ns.ID = uuid.New()
 	}
+	if ns.Bech32Prefix == "" {
+		ns.Bech32Prefix = models.DefaultBech32Prefix
+	}
 	ns.CreatedAt = time.Now()
 
 	query := `
-		INSERT INTO namespaces (id, org_id, name, description, created_at)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO namespaces (id, org_id, name, description, bech32_prefix, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
 
-	_, err := r.pool.Exec(ctx, query, ns.ID, ns.OrgID, ns.Name, ns.Description, ns.CreatedAt)
+	_, err := r.pool.Exec(ctx, query, ns.ID, ns.OrgID, ns.Name, ns.Description, ns.Bech32Prefix, ns.CreatedAt)
 	return err
 }
 
 // GetNamespace retrieves a namespace by its ID.
 func (r *orgRepo) GetNamespace(ctx context.Context, id uuid.UUID) (*models.Namespace, error) {
-	query := `SELECT id, org_id, name, description, created_at FROM namespaces WHERE id = $1`
+	query := `SELECT id, org_id, name, description, bech32_prefix, created_at FROM namespaces WHERE id = $1`
 
 	var ns models.Namespace
 	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.CreatedAt,
+		&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.Bech32Prefix, &ns.CreatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -332,11 +335,11 @@ func (r *orgRepo) GetNamespace(ctx context.Context, id uuid.UUID) (*models.Names
 
 // GetNamespaceByName retrieves a namespace by org ID and name.
 func (r *orgRepo) GetNamespaceByName(ctx context.Context, orgID uuid.UUID, name string) (*models.Namespace, error) {
-	query := `SELECT id, org_id, name, description, created_at FROM namespaces WHERE org_id = $1 AND name = $2`
+	query := `SELECT id, org_id, name, description, bech32_prefix, created_at FROM namespaces WHERE org_id = $1 AND name = $2`
 
 	var ns models.Namespace
 	err := r.pool.QueryRow(ctx, query, orgID, name).Scan(
-		&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.CreatedAt,
+		&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.Bech32Prefix, &ns.CreatedAt,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -350,7 +353,7 @@ func (r *orgRepo) GetNamespaceByName(ctx context.Context, orgID uuid.UUID, name
 
 // ListNamespaces lists all namespaces in an organization.
 func (r *orgRepo) ListNamespaces(ctx context.Context, orgID uuid.UUID) ([]*models.Namespace, error) {
-	query := `SELECT id, org_id, name, description, created_at FROM namespaces WHERE org_id = $1 ORDER BY name`
+	query := `SELECT id, org_id, name, description, bech32_prefix, created_at FROM namespaces WHERE org_id = $1 ORDER BY name`
 
 	rows, err := r.pool.Query(ctx, query, orgID)
 	if err != nil {
@@ -361,7 +364,7 @@ func (r *orgRepo) ListNamespaces(ctx context.Context, orgID uuid.UUID) ([]*model
 	var namespaces []*models.Namespace
 	for rows.Next() {
 		var ns models.Namespace
-		if err := rows.Scan(&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.CreatedAt); err != nil {
+		if err := rows.Scan(&ns.ID, &ns.OrgID, &ns.Name, &ns.Description, &ns.Bech32Prefix, &ns.CreatedAt); err != nil {
 			return nil, err
 		}
 		namespaces = append(namespaces, &ns)
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// PasswordResetRepository defines methods for password reset token data access.
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	// MarkUsed marks an unused token as used. It returns false if the token
+	// was already used, so a token can only be redeemed once.
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	DeleteForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type passwordResetRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository instance.
+func NewPasswordResetRepository(pool *pgxpool.Pool) PasswordResetRepository {
+	return &passwordResetRepo{pool: pool}
+}
+
+func (r *passwordResetRepo) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.CreatedAt = time.Now()
+
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.pool.Exec(ctx, query,
+		token.ID,
+		token.UserID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+func (r *passwordResetRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens WHERE token_hash = $1`
+
+	var token models.PasswordResetToken
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `UPDATE password_reset_tokens SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// DeleteForUser removes all of a user's reset tokens, used or not.
+func (r *passwordResetRepo) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = $1`
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
+
+// Compile-time check to ensure passwordResetRepo implements PasswordResetRepository.
+var _ PasswordResetRepository = (*passwordResetRepo)(nil)
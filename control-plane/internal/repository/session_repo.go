@@ -17,9 +17,11 @@ import (
 type SessionRepository interface {
 	Create(ctx context.Context, session *models.Session) error
 	Get(ctx context.Context, id string) (*models.Session, error)
+	UpdateData(ctx context.Context, id string, data map[string]interface{}) error
 	Delete(ctx context.Context, id string) error
 	DeleteAllForUser(ctx context.Context, userID uuid.UUID) error
 	CleanupExpired(ctx context.Context) (int64, error)
+	CountActive(ctx context.Context) (int64, error)
 }
 
 type sessionRepo struct {
@@ -88,6 +90,18 @@ func (r *sessionRepo) Get(ctx context.Context, id string) (*models.Session, erro
 	return &session, nil
 }
 
+// UpdateData replaces the data stored with a session.
+func (r *sessionRepo) UpdateData(ctx context.Context, id string, data map[string]interface{}) error {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE sessions SET data = $2 WHERE id = $1`
+	_, err = r.pool.Exec(ctx, query, id, dataJSON)
+	return err
+}
+
 func (r *sessionRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM sessions WHERE id = $1`
 	_, err := r.pool.Exec(ctx, query, id)
@@ -109,5 +123,15 @@ func (r *sessionRepo) CleanupExpired(ctx context.Context) (int64, error) {
 	return result.RowsAffected(), nil
 }
 
+// CountActive returns the number of sessions that have not expired.
+func (r *sessionRepo) CountActive(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM sessions WHERE expires_at > NOW()`
+	var count int64
+	if err := r.pool.QueryRow(ctx, query).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // Compile-time check to ensure sessionRepo implements SessionRepository.
 var _ SessionRepository = (*sessionRepo)(nil)
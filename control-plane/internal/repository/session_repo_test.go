@@ -45,6 +45,16 @@ func (m *MockSessionRepository) CleanupExpired(ctx context.Context) (int64, erro
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockSessionRepository) UpdateData(ctx context.Context, id string, data map[string]interface{}) error {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) CountActive(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // Verify MockSessionRepository implements SessionRepository
 var _ SessionRepository = (*MockSessionRepository)(nil)
 
@@ -42,14 +42,14 @@ func NewUsageRepository(pool *pgxpool.Pool) UsageRepository {
 
 // currentPeriodStart returns the start of the current monthly billing period.
 func currentPeriodStart() time.Time {
-	now := time.Now().UTC()
-	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start, _ := models.BillingPeriod(time.Now())
+	return start
 }
 
 // currentPeriodEnd returns the end of the current monthly billing period.
 func currentPeriodEnd() time.Time {
-	start := currentPeriodStart()
-	return start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	_, end := models.BillingPeriod(time.Now())
+	return end
 }
 
 // Increment adds to a metric's value for the current period.
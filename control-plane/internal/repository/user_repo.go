@@ -22,6 +22,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user *models.User) error
 	UpdateOAuth(ctx context.Context, userID uuid.UUID, provider, providerID string) error
 	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	ClearPassword(ctx context.Context, id uuid.UUID) error
 	SetEmailVerified(ctx context.Context, id uuid.UUID) error
 	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
 }
@@ -91,11 +92,13 @@ func (r *userRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.User, err
 	return &user, nil
 }
 
+// GetByEmail retrieves a user by email address, ignoring case.
 func (r *userRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, name, avatar_url, email_verified,
 		       oauth_provider, oauth_provider_id, last_login_at, created_at, updated_at
-		FROM users WHERE email = $1`
+		FROM users WHERE LOWER(email) = LOWER($1)
+		ORDER BY created_at LIMIT 1`
 
 	var user models.User
 	err := r.pool.QueryRow(ctx, query, email).Scan(
@@ -169,6 +172,12 @@ func (r *userRepo) UpdatePassword(ctx context.Context, id uuid.UUID, hash string
 	return err
 }
 
+func (r *userRepo) ClearPassword(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE users SET password_hash = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, id)
+	return err
+}
+
 func (r *userRepo) SetEmailVerified(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE users SET email_verified = true, updated_at = NOW() WHERE id = $1`
 	_, err := r.pool.Exec(ctx, query, id)
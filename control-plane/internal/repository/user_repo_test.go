@@ -47,6 +47,11 @@ func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, p
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ClearPassword(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) SetEmailVerified(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -177,6 +182,19 @@ func TestMockUserRepository_UpdatePassword(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMockUserRepository_ClearPassword(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	ctx := context.Background()
+
+	userID := uuid.New()
+
+	mockRepo.On("ClearPassword", ctx, userID).Return(nil)
+
+	err := mockRepo.ClearPassword(ctx, userID)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestMockUserRepository_SetEmailVerified(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	ctx := context.Background()
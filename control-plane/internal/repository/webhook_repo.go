@@ -195,7 +195,7 @@ func (r *webhookRepo) Update(ctx context.Context, webhook *models.Webhook) error
 
 	query := `
 		UPDATE webhooks 
-		SET url = $2, events = $3, enabled = $4, updated_at = $5
+		SET url = $2, events = $3, enabled = $4, secret = $5, updated_at = $6
 		WHERE id = $1`
 
 	_, err = r.pool.Exec(ctx, query,
@@ -203,6 +203,7 @@ func (r *webhookRepo) Update(ctx context.Context, webhook *models.Webhook) error
 		webhook.URL,
 		eventsJSON,
 		webhook.Enabled,
+		webhook.Secret,
 		webhook.UpdatedAt,
 	)
 	return err
@@ -7,6 +7,7 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -30,13 +31,21 @@ const (
 
 // API key format constants.
 const (
-	keyPrefixBanhbao = "bbr"   // banhbaoring prefix
-	keyEnvLive       = "live"  // Production environment
-	keyEnvTest       = "test"  // Test environment
-	keySecretLen     = 24      // Random bytes for secret (produces ~32 base62 chars)
-	keyPrefixDisplay = 8       // Characters of secret to show in prefix
+	keyEnvLive       = "live" // Production environment
+	keyEnvTest       = "test" // Test environment
+	keySecretLen     = 32     // Base62 characters of secret (~190 bits of entropy)
+	keyPrefixDisplay = 8      // Characters of secret to show in prefix
 )
 
+// DefaultAPIKeyPrefix is the prefix of generated API keys unless configured
+// otherwise. Keys with this prefix are always accepted, so keys issued before
+// the prefix was changed keep working.
+const DefaultAPIKeyPrefix = "bbr"
+
+// maxSigningScopeIDs caps the keys and the namespaces in an API key's signing
+// scope.
+const maxSigningScopeIDs = 100
+
 // APIKeyService defines the interface for API key operations.
 type APIKeyService interface {
 	Create(ctx context.Context, orgID uuid.UUID, req CreateAPIKeyRequest) (*models.APIKey, string, error)
@@ -44,24 +53,68 @@ type APIKeyService interface {
 	List(ctx context.Context, orgID uuid.UUID) ([]*models.APIKey, error)
 	Get(ctx context.Context, orgID, keyID uuid.UUID) (*models.APIKey, error)
 	Revoke(ctx context.Context, orgID, keyID uuid.UUID) error
+	Rotate(ctx context.Context, orgID, keyID uuid.UUID, grace time.Duration) (*APIKeyRotation, error)
+	SetSigningScope(ctx context.Context, orgID, keyID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error)
 	Delete(ctx context.Context, orgID, keyID uuid.UUID) error
 }
 
+// Rotation grace periods: how long a rotated key keeps working alongside its
+// successor, so clients can switch over without downtime.
+const (
+	DefaultAPIKeyRotationGrace = 24 * time.Hour
+	MaxAPIKeyRotationGrace     = 30 * 24 * time.Hour
+)
+
+// APIKeyRotation is the result of rotating an API key.
+type APIKeyRotation struct {
+	// Key is the successor key and RawKey its plaintext, shown only once.
+	Key    *models.APIKey
+	RawKey string
+	// Previous is the rotated key. It stays valid until Previous.ExpiresAt.
+	Previous *models.APIKey
+}
+
 // CreateAPIKeyRequest is the request for creating a new API key.
 type CreateAPIKeyRequest struct {
 	Name         string   `json:"name" validate:"required,min=1,max=255"`
 	Scopes       []string `json:"scopes" validate:"required,min=1"`
 	ExpiresInDays *int    `json:"expires_in_days,omitempty"` // Days until expiry, nil = no expiry
 	Environment  string   `json:"environment,omitempty"`     // "live" or "test", defaults to "live"
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`   // Source IPs/CIDRs allowed to use the key, empty = any
+	// AllowedKeyIDs and AllowedNamespaceIDs limit which keys the API key may
+	// sign with; both empty = any key in the org.
+	AllowedKeyIDs       []uuid.UUID `json:"allowed_key_ids,omitempty"`
+	AllowedNamespaceIDs []uuid.UUID `json:"allowed_namespace_ids,omitempty"`
+	// UserID is the member creating the key; the key acts with that member's role.
+	UserID       *uuid.UUID `json:"-"`
 }
 
 type apiKeyService struct {
 	keyRepo repository.APIKeyRepository
+	prefix  string
+}
+
+// APIKeyServiceOption configures optional API key service settings.
+type APIKeyServiceOption func(*apiKeyService)
+
+// WithAPIKeyPrefix sets the prefix of generated keys, e.g. "psk" for keys like
+// psk_live_<secret>, so leaked keys can be recognized. An empty prefix keeps
+// DefaultAPIKeyPrefix. The prefix must not contain underscores.
+func WithAPIKeyPrefix(prefix string) APIKeyServiceOption {
+	return func(s *apiKeyService) {
+		if prefix != "" {
+			s.prefix = prefix
+		}
+	}
 }
 
 // NewAPIKeyService creates a new API key service.
-func NewAPIKeyService(keyRepo repository.APIKeyRepository) APIKeyService {
-	return &apiKeyService{keyRepo: keyRepo}
+func NewAPIKeyService(keyRepo repository.APIKeyRepository, opts ...APIKeyServiceOption) APIKeyService {
+	s := &apiKeyService{keyRepo: keyRepo, prefix: DefaultAPIKeyPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Create generates a new API key for an organization.
@@ -91,6 +144,18 @@ func (s *apiKeyService) Create(ctx context.Context, orgID uuid.UUID, req CreateA
 		env = req.Environment
 	}
 
+	// Validate IP allowlist
+	cidrs, err := models.NormalizeCIDRs(req.AllowedCIDRs)
+	if err != nil {
+		return nil, "", apierrors.NewValidationError("allowed_cidrs", err.Error())
+	}
+
+	// Validate signing scope
+	keyIDs, namespaceIDs, err := normalizeSigningScope(req.AllowedKeyIDs, req.AllowedNamespaceIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
 	// Generate raw key
 	rawKey, prefix, err := s.generateKey(env)
 	if err != nil {
@@ -109,7 +174,13 @@ func (s *apiKeyService) Create(ctx context.Context, orgID uuid.UUID, req CreateA
 		KeyPrefix: prefix,
 		KeyHash:   hash,
 		Scopes:    req.Scopes,
+		UserID:    req.UserID,
 	}
+	if len(cidrs) > 0 {
+		key.AllowedCIDRs = cidrs
+	}
+	key.AllowedKeyIDs = keyIDs
+	key.AllowedNamespaceIDs = namespaceIDs
 
 	// Set expiration if specified
 	if req.ExpiresInDays != nil && *req.ExpiresInDays > 0 {
@@ -127,13 +198,13 @@ func (s *apiKeyService) Create(ctx context.Context, orgID uuid.UUID, req CreateA
 
 // Validate validates an API key and returns the associated key model.
 func (s *apiKeyService) Validate(ctx context.Context, rawKey string) (*models.APIKey, error) {
-	// Parse the key format: bbr_<env>_<secret>
+	// Parse the key format: <prefix>_<env>_<secret>
 	parts := strings.Split(rawKey, "_")
 	if len(parts) != 3 {
 		return nil, apierrors.ErrUnauthorized
 	}
 
-	if parts[0] != keyPrefixBanhbao {
+	if parts[0] != s.prefix && parts[0] != DefaultAPIKeyPrefix {
 		return nil, apierrors.ErrUnauthorized
 	}
 
@@ -146,7 +217,7 @@ func (s *apiKeyService) Validate(ctx context.Context, rawKey string) (*models.AP
 		return nil, apierrors.ErrUnauthorized
 	}
 
-	// Build prefix for lookup: bbr_<env>_<first 8 chars>
+	// Build prefix for lookup: <prefix>_<env>_<first 8 chars>
 	prefix := fmt.Sprintf("%s_%s_%s", parts[0], parts[1], secret[:keyPrefixDisplay])
 
 	// Lookup by prefix
@@ -215,6 +286,119 @@ func (s *apiKeyService) Revoke(ctx context.Context, orgID, keyID uuid.UUID) erro
 	return nil
 }
 
+// Rotate mints a successor for an API key with the same name, scopes, IP
+// allowlist, signing scope, environment and lifetime, and makes the old key expire after
+// grace. Both keys work until then; a zero grace expires the old key now.
+func (s *apiKeyService) Rotate(ctx context.Context, orgID, keyID uuid.UUID, grace time.Duration) (*APIKeyRotation, error) {
+	if grace < 0 || grace > MaxAPIKeyRotationGrace {
+		return nil, apierrors.NewValidationError("grace_period", fmt.Sprintf("must be between 0 and %s", MaxAPIKeyRotationGrace))
+	}
+
+	old, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	if old == nil || old.OrgID != orgID {
+		return nil, apierrors.NewNotFoundError("API key")
+	}
+	if !old.IsValid() {
+		return nil, apierrors.NewConflictError("API key is revoked or expired")
+	}
+
+	req := CreateAPIKeyRequest{
+		Name:                old.Name,
+		Scopes:              old.Scopes,
+		Environment:         keyEnvironment(old.KeyPrefix),
+		AllowedCIDRs:        old.AllowedCIDRs,
+		AllowedKeyIDs:       old.AllowedKeyIDs,
+		AllowedNamespaceIDs: old.AllowedNamespaceIDs,
+		UserID:              old.UserID,
+	}
+	if old.ExpiresAt != nil {
+		days := int(math.Ceil(old.ExpiresAt.Sub(old.CreatedAt).Hours() / 24))
+		req.ExpiresInDays = &days
+	}
+	key, rawKey, err := s.Create(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := s.keyRepo.ExpireAt(ctx, old.ID, time.Now().Add(grace))
+	if err != nil {
+		// Don't leave an unannounced successor behind
+		_ = s.keyRepo.Delete(ctx, key.ID)
+		return nil, fmt.Errorf("failed to expire rotated key: %w", err)
+	}
+	old.ExpiresAt = &expiresAt
+
+	return &APIKeyRotation{Key: key, RawKey: rawKey, Previous: old}, nil
+}
+
+// SetSigningScope replaces the keys and namespaces an API key may sign with.
+// Empty lists let it sign with any key of the org again.
+func (s *apiKeyService) SetSigningScope(ctx context.Context, orgID, keyID uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) (*models.APIKey, error) {
+	keyIDs, namespaceIDs, err := normalizeSigningScope(keyIDs, namespaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	if key == nil || key.OrgID != orgID {
+		return nil, apierrors.NewNotFoundError("API key")
+	}
+	if key.RevokedAt != nil {
+		return nil, apierrors.NewConflictError("API key is revoked")
+	}
+	if err := s.keyRepo.SetSigningScope(ctx, keyID, keyIDs, namespaceIDs); err != nil {
+		return nil, fmt.Errorf("failed to update signing scope: %w", err)
+	}
+
+	key.AllowedKeyIDs = keyIDs
+	key.AllowedNamespaceIDs = namespaceIDs
+	return key, nil
+}
+
+// normalizeSigningScope drops duplicate and nil IDs from a signing scope and
+// checks its size. IDs outside the org are harmless: they never match a key
+// the org can sign with.
+func normalizeSigningScope(keyIDs, namespaceIDs []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	keyIDs = uniqueIDs(keyIDs)
+	if len(keyIDs) > maxSigningScopeIDs {
+		return nil, nil, apierrors.NewValidationError("allowed_key_ids", fmt.Sprintf("at most %d keys allowed", maxSigningScopeIDs))
+	}
+	namespaceIDs = uniqueIDs(namespaceIDs)
+	if len(namespaceIDs) > maxSigningScopeIDs {
+		return nil, nil, apierrors.NewValidationError("allowed_namespace_ids", fmt.Sprintf("at most %d namespaces allowed", maxSigningScopeIDs))
+	}
+	return keyIDs, namespaceIDs, nil
+}
+
+// uniqueIDs returns ids without duplicates or nil IDs, in order.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	var out []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+// keyEnvironment returns the environment in a display prefix such as
+// bbr_test_abcd1234, defaulting to live.
+func keyEnvironment(prefix string) string {
+	if parts := strings.Split(prefix, "_"); len(parts) == 3 && parts[1] == keyEnvTest {
+		return keyEnvTest
+	}
+	return keyEnvLive
+}
+
 // Delete permanently removes an API key.
 func (s *apiKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 	key, err := s.keyRepo.GetByID(ctx, keyID)
@@ -230,27 +414,19 @@ func (s *apiKeyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) erro
 	return nil
 }
 
-// generateKey generates a new API key in the format bbr_<env>_<secret>.
+// generateKey generates a new API key in the format <prefix>_<env>_<secret>.
 // Returns the full raw key and the display prefix.
 func (s *apiKeyService) generateKey(env string) (rawKey, prefix string, err error) {
-	// Generate random bytes for the secret
-	secretBytes := make([]byte, keySecretLen)
-	if _, err := rand.Read(secretBytes); err != nil {
+	secret, err := randomBase62(keySecretLen)
+	if err != nil {
 		return "", "", fmt.Errorf("failed to generate random bytes: %w", err)
 	}
 
-	// Encode to base62 for URL-safe string
-	secret := base62Encode(secretBytes)
+	// Build the full key: <prefix>_<env>_<secret>
+	rawKey = fmt.Sprintf("%s_%s_%s", s.prefix, env, secret)
 
-	// Build the full key: bbr_<env>_<secret>
-	rawKey = fmt.Sprintf("%s_%s_%s", keyPrefixBanhbao, env, secret)
-
-	// Build the display prefix: bbr_<env>_<first 8 chars>
-	displaySecret := secret
-	if len(displaySecret) > keyPrefixDisplay {
-		displaySecret = displaySecret[:keyPrefixDisplay]
-	}
-	prefix = fmt.Sprintf("%s_%s_%s", keyPrefixBanhbao, env, displaySecret)
+	// Build the display prefix: <prefix>_<env>_<first 8 chars>
+	prefix = fmt.Sprintf("%s_%s_%s", s.prefix, env, secret[:keyPrefixDisplay])
 
 	return rawKey, prefix, nil
 }
@@ -322,19 +498,28 @@ func (s *apiKeyService) verifyKey(rawKey, encodedHash string) bool {
 // base62Alphabet for URL-safe encoding.
 const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 
-// base62Encode encodes bytes to a base62 string.
-// This produces a URL-safe string without special characters.
-func base62Encode(data []byte) string {
-	if len(data) == 0 {
-		return ""
-	}
-
-	// Simple encoding: map each byte to base62
-	result := make([]byte, 0, len(data)*4/3+1)
-	for _, b := range data {
-		result = append(result, base62Alphabet[int(b)%62])
+// randomBase62 returns a random string of n base62 characters.
+// Random bytes at or above the largest multiple of 62 are discarded, so every
+// character is uniformly distributed.
+func randomBase62(n int) (string, error) {
+	const maxByte = 256 - 256%len(base62Alphabet)
+
+	result := make([]byte, 0, n)
+	buf := make([]byte, n)
+	for len(result) < n {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if int(b) >= maxByte {
+				continue
+			}
+			result = append(result, base62Alphabet[int(b)%len(base62Alphabet)])
+			if len(result) == n {
+				break
+			}
+		}
 	}
 
-	return string(result)
+	return string(result), nil
 }
-
@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 )
 
 // mockAPIKeyRepo is a mock implementation of APIKeyRepository for testing.
@@ -73,6 +78,27 @@ func (m *mockAPIKeyRepo) Revoke(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockAPIKeyRepo) SetSigningScope(ctx context.Context, id uuid.UUID, keyIDs, namespaceIDs []uuid.UUID) error {
+	key, ok := m.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return pgx.ErrNoRows
+	}
+	key.AllowedKeyIDs = keyIDs
+	key.AllowedNamespaceIDs = namespaceIDs
+	return nil
+}
+
+func (m *mockAPIKeyRepo) ExpireAt(ctx context.Context, id uuid.UUID, at time.Time) (time.Time, error) {
+	key, ok := m.keys[id]
+	if !ok || key.RevokedAt != nil {
+		return time.Time{}, pgx.ErrNoRows
+	}
+	if key.ExpiresAt == nil || at.Before(*key.ExpiresAt) {
+		key.ExpiresAt = &at
+	}
+	return *key.ExpiresAt, nil
+}
+
 func (m *mockAPIKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	if key, ok := m.keys[id]; ok {
 		delete(m.byPrefix, key.KeyPrefix)
@@ -201,6 +227,37 @@ func TestAPIKeyService_Create(t *testing.T) {
 		}
 	})
 
+	t.Run("creates key with IP allowlist", func(t *testing.T) {
+		req := CreateAPIKeyRequest{
+			Name:         "Restricted Key",
+			Scopes:       []string{"keys:sign"},
+			AllowedCIDRs: []string{"10.0.0.0/8", "203.0.113.7", "2001:db8::/32"},
+		}
+
+		key, _, err := svc.Create(ctx, orgID, req)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		want := []string{"10.0.0.0/8", "203.0.113.7/32", "2001:db8::/32"}
+		if strings.Join(key.AllowedCIDRs, ",") != strings.Join(want, ",") {
+			t.Errorf("AllowedCIDRs = %v, want %v", key.AllowedCIDRs, want)
+		}
+	})
+
+	t.Run("rejects invalid CIDR", func(t *testing.T) {
+		req := CreateAPIKeyRequest{
+			Name:         "Test Key",
+			Scopes:       []string{"keys:read"},
+			AllowedCIDRs: []string{"10.0.0.0/33"},
+		}
+
+		_, _, err := svc.Create(ctx, orgID, req)
+		if err == nil {
+			t.Error("Create() expected error for invalid CIDR")
+		}
+	})
+
 	t.Run("rejects invalid environment", func(t *testing.T) {
 		req := CreateAPIKeyRequest{
 			Name:        "Test Key",
@@ -215,6 +272,96 @@ func TestAPIKeyService_Create(t *testing.T) {
 	})
 }
 
+func TestAPIKeyService_Prefix(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	req := CreateAPIKeyRequest{Name: "Prefixed Key", Scopes: []string{"keys:read"}}
+
+	repo := newMockAPIKeyRepo()
+	svc := NewAPIKeyService(repo, WithAPIKeyPrefix("psk"))
+
+	t.Run("generated keys carry the configured prefix", func(t *testing.T) {
+		for env, want := range map[string]string{"": "psk_live_", "live": "psk_live_", "test": "psk_test_"} {
+			r := req
+			r.Environment = env
+			key, rawKey, err := svc.Create(ctx, orgID, r)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if !strings.HasPrefix(rawKey, want) || !strings.HasPrefix(key.KeyPrefix, want) {
+				t.Errorf("rawKey = %v, KeyPrefix = %v, want prefix %q", rawKey, key.KeyPrefix, want)
+			}
+			if len(rawKey) != len(want)+keySecretLen {
+				t.Errorf("rawKey length = %v, want %v", len(rawKey), len(want)+keySecretLen)
+			}
+			if _, err := svc.Validate(ctx, rawKey); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+		}
+	})
+
+	t.Run("keys are high-entropy", func(t *testing.T) {
+		// 32 uniform base62 characters carry about 190 bits
+		if bits := float64(keySecretLen) * math.Log2(62); bits < 128 {
+			t.Errorf("secret entropy = %.0f bits, want at least 128", bits)
+		}
+
+		seen := make(map[string]bool)
+		for i := 0; i < 50; i++ {
+			key, rawKey, err := svc.Create(ctx, orgID, req)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if seen[rawKey] || seen[key.KeyPrefix] {
+				t.Fatalf("duplicate key or display prefix: %s", key.KeyPrefix)
+			}
+			seen[rawKey] = true
+			seen[key.KeyPrefix] = true
+		}
+	})
+
+	t.Run("only the hash is stored", func(t *testing.T) {
+		key, rawKey, err := svc.Create(ctx, orgID, req)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		stored := repo.keys[key.ID]
+		if !strings.HasPrefix(stored.KeyHash, "$argon2id$") {
+			t.Errorf("KeyHash = %v, want an argon2id hash", stored.KeyHash)
+		}
+		secret := strings.TrimPrefix(rawKey, "psk_live_")
+		if strings.Contains(stored.KeyHash, secret) || strings.Contains(stored.KeyPrefix, secret) {
+			t.Error("stored key contains the secret")
+		}
+		if !svc.(*apiKeyService).verifyKey(rawKey, stored.KeyHash) {
+			t.Error("stored hash does not verify the raw key")
+		}
+	})
+
+	t.Run("keys with the default prefix stay valid", func(t *testing.T) {
+		_, legacyKey, err := NewAPIKeyService(repo).Create(ctx, orgID, req)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if !strings.HasPrefix(legacyKey, DefaultAPIKeyPrefix+"_live_") {
+			t.Fatalf("legacyKey = %v, want prefix %q", legacyKey, DefaultAPIKeyPrefix+"_live_")
+		}
+		if _, err := svc.Validate(ctx, legacyKey); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
+	t.Run("rejects other prefixes", func(t *testing.T) {
+		_, rawKey, err := svc.Create(ctx, orgID, req)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if _, err := svc.Validate(ctx, "sk"+strings.TrimPrefix(rawKey, "psk")); err == nil {
+			t.Error("Validate() expected error for unknown prefix")
+		}
+	})
+}
+
 func TestAPIKeyService_Validate(t *testing.T) {
 	repo := newMockAPIKeyRepo()
 	svc := NewAPIKeyService(repo)
@@ -279,6 +426,210 @@ func TestAPIKeyService_Validate(t *testing.T) {
 	})
 }
 
+func TestAPIKeyService_Expiry(t *testing.T) {
+	repo := newMockAPIKeyRepo()
+	svc := NewAPIKeyService(repo)
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	key, rawKey, err := svc.Create(ctx, orgID, CreateAPIKeyRequest{Name: "Expiring Key", Scopes: []string{"keys:read"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := svc.Validate(ctx, rawKey); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	expired := time.Now().Add(-time.Second)
+	repo.keys[key.ID].ExpiresAt = &expired
+
+	_, err = svc.Validate(ctx, rawKey)
+	var apiErr *apierrors.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Validate() error = %v, want 401 for an expired key", err)
+	}
+}
+
+func TestAPIKeyService_SigningScope(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	batcher, proposer, namespace := uuid.New(), uuid.New(), uuid.New()
+
+	errorCode := func(err error) string {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) {
+			return apiErr.Code
+		}
+		return ""
+	}
+
+	repo := newMockAPIKeyRepo()
+	svc := NewAPIKeyService(repo)
+	key, _, err := svc.Create(ctx, orgID, CreateAPIKeyRequest{
+		Name:          "Batcher",
+		Scopes:        []string{"keys:sign"},
+		AllowedKeyIDs: []uuid.UUID{batcher, uuid.Nil, batcher},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if len(key.AllowedKeyIDs) != 1 || key.AllowedKeyIDs[0] != batcher || len(key.AllowedNamespaceIDs) != 0 {
+		t.Errorf("Create() signing scope = %v / %v, want just the batcher key", key.AllowedKeyIDs, key.AllowedNamespaceIDs)
+	}
+
+	t.Run("replaces the scope", func(t *testing.T) {
+		updated, err := svc.SetSigningScope(ctx, orgID, key.ID, []uuid.UUID{proposer}, []uuid.UUID{namespace})
+		if err != nil {
+			t.Fatalf("SetSigningScope() error = %v", err)
+		}
+		stored := repo.keys[key.ID]
+		if len(stored.AllowedKeyIDs) != 1 || stored.AllowedKeyIDs[0] != proposer ||
+			len(stored.AllowedNamespaceIDs) != 1 || stored.AllowedNamespaceIDs[0] != namespace {
+			t.Errorf("stored signing scope = %v / %v", stored.AllowedKeyIDs, stored.AllowedNamespaceIDs)
+		}
+		if !updated.AllowsKey(&models.Key{ID: uuid.New(), NamespaceID: namespace}) {
+			t.Error("updated key should allow keys in the namespace")
+		}
+	})
+
+	t.Run("empty scope allows any key", func(t *testing.T) {
+		updated, err := svc.SetSigningScope(ctx, orgID, key.ID, nil, nil)
+		if err != nil {
+			t.Fatalf("SetSigningScope() error = %v", err)
+		}
+		if updated.RestrictsKeys() {
+			t.Errorf("signing scope = %v / %v, want none", updated.AllowedKeyIDs, updated.AllowedNamespaceIDs)
+		}
+	})
+
+	t.Run("other org", func(t *testing.T) {
+		_, err := svc.SetSigningScope(ctx, uuid.New(), key.ID, []uuid.UUID{batcher}, nil)
+		if errorCode(err) != "not_found" {
+			t.Errorf("SetSigningScope() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("too many keys", func(t *testing.T) {
+		ids := make([]uuid.UUID, maxSigningScopeIDs+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+		_, err := svc.SetSigningScope(ctx, orgID, key.ID, ids, nil)
+		if errorCode(err) != "validation_error" {
+			t.Errorf("SetSigningScope() error = %v, want validation error", err)
+		}
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		if err := svc.Revoke(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Revoke() error = %v", err)
+		}
+		_, err := svc.SetSigningScope(ctx, orgID, key.ID, []uuid.UUID{batcher}, nil)
+		if errorCode(err) != "conflict" {
+			t.Errorf("SetSigningScope() error = %v, want conflict", err)
+		}
+	})
+}
+
+func TestAPIKeyService_Rotate(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	days := 90
+	createReq := CreateAPIKeyRequest{
+		Name:          "CI Key",
+		Scopes:        []string{"keys:sign"},
+		Environment:   "test",
+		AllowedCIDRs:  []string{"10.0.0.0/8"},
+		ExpiresInDays: &days,
+		AllowedKeyIDs: []uuid.UUID{uuid.New()},
+	}
+
+	t.Run("both keys work during the overlap window", func(t *testing.T) {
+		repo := newMockAPIKeyRepo()
+		svc := NewAPIKeyService(repo)
+		old, oldRaw, err := svc.Create(ctx, orgID, createReq)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		rotation, err := svc.Rotate(ctx, orgID, old.ID, time.Hour)
+		if err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		if rotation.Key.ID == old.ID || rotation.RawKey == oldRaw {
+			t.Fatal("Rotate() returned the old key")
+		}
+		if !strings.HasPrefix(rotation.RawKey, "bbr_test_") {
+			t.Errorf("successor = %v, want the old key's environment", rotation.RawKey)
+		}
+		if rotation.Key.Name != old.Name || strings.Join(rotation.Key.Scopes, ",") != "keys:sign" ||
+			strings.Join(rotation.Key.AllowedCIDRs, ",") != "10.0.0.0/8" ||
+			len(rotation.Key.AllowedKeyIDs) != 1 || rotation.Key.AllowedKeyIDs[0] != createReq.AllowedKeyIDs[0] {
+			t.Errorf("successor = %+v, want the old key's settings", rotation.Key)
+		}
+		if rotation.Key.ExpiresAt == nil || time.Until(*rotation.Key.ExpiresAt) < 89*24*time.Hour {
+			t.Errorf("successor ExpiresAt = %v, want the old key's 90-day lifetime", rotation.Key.ExpiresAt)
+		}
+		if exp := rotation.Previous.ExpiresAt; exp == nil || time.Until(*exp) > time.Hour || time.Until(*exp) < 59*time.Minute {
+			t.Errorf("previous ExpiresAt = %v, want about an hour from now", exp)
+		}
+
+		for name, raw := range map[string]string{"old": oldRaw, "successor": rotation.RawKey} {
+			if _, err := svc.Validate(ctx, raw); err != nil {
+				t.Errorf("Validate(%s key) error = %v", name, err)
+			}
+		}
+
+		// Once the overlap ends only the successor works
+		ended := time.Now().Add(-time.Second)
+		repo.keys[old.ID].ExpiresAt = &ended
+		if _, err := svc.Validate(ctx, oldRaw); err == nil {
+			t.Error("Validate() accepted the old key after the overlap window")
+		}
+		if _, err := svc.Validate(ctx, rotation.RawKey); err != nil {
+			t.Errorf("Validate(successor) error = %v", err)
+		}
+	})
+
+	t.Run("does not extend an earlier expiry", func(t *testing.T) {
+		repo := newMockAPIKeyRepo()
+		svc := NewAPIKeyService(repo)
+		old, _, _ := svc.Create(ctx, orgID, createReq)
+		soon := time.Now().Add(10 * time.Minute)
+		repo.keys[old.ID].ExpiresAt = &soon
+
+		rotation, err := svc.Rotate(ctx, orgID, old.ID, DefaultAPIKeyRotationGrace)
+		if err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if !rotation.Previous.ExpiresAt.Equal(soon) {
+			t.Errorf("previous ExpiresAt = %v, want %v", rotation.Previous.ExpiresAt, soon)
+		}
+	})
+
+	t.Run("rejects revoked keys and other orgs", func(t *testing.T) {
+		repo := newMockAPIKeyRepo()
+		svc := NewAPIKeyService(repo)
+		old, _, _ := svc.Create(ctx, orgID, createReq)
+
+		if _, err := svc.Rotate(ctx, uuid.New(), old.ID, time.Hour); err == nil {
+			t.Error("Rotate() expected error for another org's key")
+		}
+		if _, err := svc.Rotate(ctx, orgID, old.ID, MaxAPIKeyRotationGrace+time.Hour); err == nil {
+			t.Error("Rotate() expected error for a too long grace period")
+		}
+
+		_ = svc.Revoke(ctx, orgID, old.ID)
+		if _, err := svc.Rotate(ctx, orgID, old.ID, time.Hour); err == nil {
+			t.Error("Rotate() expected error for a revoked key")
+		}
+		if len(repo.keys) != 1 {
+			t.Errorf("keys = %d, want no successor for rejected rotations", len(repo.keys))
+		}
+	})
+}
+
 func TestAPIKeyService_List(t *testing.T) {
 	repo := newMockAPIKeyRepo()
 	svc := NewAPIKeyService(repo)
@@ -417,39 +768,41 @@ func TestAPIKeyService_Delete(t *testing.T) {
 	})
 }
 
-func TestBase62Encode(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    []byte
-		expected int // expected output length
-	}{
-		{
-			name:     "24 bytes produces expected length",
-			input:    make([]byte, 24),
-			expected: 24,
-		},
-		{
-			name:     "empty input produces empty output",
-			input:    []byte{},
-			expected: 0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := base62Encode(tt.input)
-			if len(result) != tt.expected {
-				t.Errorf("base62Encode() length = %v, want %v", len(result), tt.expected)
+func TestRandomBase62(t *testing.T) {
+	t.Run("produces n base62 characters", func(t *testing.T) {
+		for _, n := range []int{0, 1, keySecretLen, 100} {
+			result, err := randomBase62(n)
+			if err != nil {
+				t.Fatalf("randomBase62(%d) error = %v", n, err)
+			}
+			if len(result) != n {
+				t.Errorf("randomBase62(%d) length = %v", n, len(result))
 			}
-
-			// Check all characters are in base62 alphabet
 			for _, c := range result {
 				if !strings.ContainsRune(base62Alphabet, c) {
-					t.Errorf("base62Encode() contains invalid character: %c", c)
+					t.Errorf("randomBase62() contains invalid character: %c", c)
 				}
 			}
-		})
-	}
+		}
+	})
+
+	t.Run("uses the whole alphabet", func(t *testing.T) {
+		result, err := randomBase62(62 * 100)
+		if err != nil {
+			t.Fatalf("randomBase62() error = %v", err)
+		}
+		counts := make(map[rune]int)
+		for _, c := range result {
+			counts[c]++
+		}
+		// Each character is expected 100 times; a biased encoding would
+		// over-represent the first characters of the alphabet.
+		for _, c := range base62Alphabet {
+			if counts[c] < 40 || counts[c] > 180 {
+				t.Errorf("character %c appeared %d times, want about 100", c, counts[c])
+			}
+		}
+	})
 }
 
 func TestAPIKeyService_HashAndVerify(t *testing.T) {
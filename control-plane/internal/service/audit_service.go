@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
 
@@ -128,7 +129,15 @@ func (s *auditService) Query(ctx context.Context, orgID uuid.UUID, filter AuditF
 		StartTime:    filter.StartTime,
 		EndTime:      filter.EndTime,
 		Limit:        limit + 1, // Fetch one extra to determine if there's a next page
-		Cursor:       filter.Cursor,
+	}
+
+	if filter.Cursor != "" {
+		createdAt, id, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", apierrors.NewValidationError("cursor", "invalid cursor")
+		}
+		query.AfterCreatedAt = &createdAt
+		query.AfterID = &id
 	}
 
 	logs, err := s.auditRepo.List(ctx, query)
@@ -139,9 +148,9 @@ func (s *auditService) Query(ctx context.Context, orgID uuid.UUID, filter AuditF
 	// Determine next cursor
 	var nextCursor string
 	if len(logs) > limit {
-		// There's more data - set the cursor to the last item's ID
-		nextCursor = logs[limit-1].ID.String()
 		logs = logs[:limit] // Trim to requested limit
+		last := logs[limit-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.ID)
 	}
 
 	return logs, nextCursor, nil
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 )
 
 // MockAuditRepository is a mock implementation of repository.AuditRepository.
@@ -50,6 +52,11 @@ func (m *MockAuditRepository) CountByOrgAndPeriod(ctx context.Context, orgID uui
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockAuditRepository) CountByResourceAndPeriod(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceID uuid.UUID, start, end time.Time) (int64, error) {
+	args := m.Called(ctx, orgID, event, resourceID, start, end)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockOrgRepositoryForAudit is a mock implementation of repository.OrgRepository for audit tests.
 type MockOrgRepositoryForAudit struct {
 	mock.Mock
@@ -354,6 +361,64 @@ func TestAuditService_QueryWithPagination(t *testing.T) {
 	mockAuditRepo.AssertExpectations(t)
 }
 
+func TestAuditService_QueryCursorRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	mockAuditRepo := new(MockAuditRepository)
+	mockOrgRepo := new(MockOrgRepositoryForAudit)
+
+	svc := NewAuditService(mockAuditRepo, mockOrgRepo)
+
+	orgID := uuid.New()
+	now := time.Now().UTC()
+
+	logs := make([]*models.AuditLog, 3)
+	for i := range logs {
+		logs[i] = &models.AuditLog{
+			ID:        uuid.New(),
+			OrgID:     orgID,
+			Event:     models.AuditEventKeySigned,
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+
+	// First page: the repo returns limit+1 rows
+	mockAuditRepo.On("List", ctx, mock.MatchedBy(func(q models.AuditLogQuery) bool {
+		return q.AfterID == nil
+	})).Return(logs, nil).Once()
+
+	page, nextCursor, err := svc.Query(ctx, orgID, AuditFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.NotEmpty(t, nextCursor)
+
+	// Second page: the cursor resolves to the last entry of the first page
+	mockAuditRepo.On("List", ctx, mock.MatchedBy(func(q models.AuditLogQuery) bool {
+		return q.AfterID != nil && *q.AfterID == logs[1].ID &&
+			q.AfterCreatedAt != nil && q.AfterCreatedAt.Equal(logs[1].CreatedAt)
+	})).Return(logs[2:], nil).Once()
+
+	page, nextCursor, err = svc.Query(ctx, orgID, AuditFilter{Limit: 2, Cursor: nextCursor})
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Empty(t, nextCursor)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_QueryInvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	mockAuditRepo := new(MockAuditRepository)
+	mockOrgRepo := new(MockOrgRepositoryForAudit)
+
+	svc := NewAuditService(mockAuditRepo, mockOrgRepo)
+
+	_, _, err := svc.Query(ctx, uuid.New(), AuditFilter{Cursor: "not-a-cursor"})
+
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	mockAuditRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
+
 func TestAuditService_QueryWithFilters(t *testing.T) {
 	ctx := context.Background()
 	mockAuditRepo := new(MockAuditRepository)
@@ -4,12 +4,13 @@ package service
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
 	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
@@ -26,8 +27,17 @@ type AuthService interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*models.User, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, oldPassword, newPassword string) error
+	// RequestPasswordReset issues a single-use reset token and sends the
+	// reset link to the user. It returns an empty token and no error for
+	// unknown emails, so callers can't tell which accounts exist.
 	RequestPasswordReset(ctx context.Context, email string) (string, error)
+	// ResetPassword sets a new password using a reset token and signs the
+	// user out everywhere.
 	ResetPassword(ctx context.Context, token, newPassword string) error
+	// SendEmailVerification emails the user a new verification link. It is
+	// rate limited and fails for users whose email is already verified.
+	SendEmailVerification(ctx context.Context, userID uuid.UUID) error
+	// VerifyEmail marks the email of the token's user as verified.
 	VerifyEmail(ctx context.Context, token string) error
 }
 
@@ -46,26 +56,63 @@ type UpdateProfileRequest struct {
 
 // AuthServiceConfig holds configuration for the auth service.
 type AuthServiceConfig struct {
-	BCryptCost     int
-	SessionExpiry  time.Duration
+	PasswordHash        Argon2Params
+	SessionExpiry       time.Duration
 	PasswordResetExpiry time.Duration
 	EmailVerifyExpiry   time.Duration
+	// DashboardURL is the dashboard URL that password reset and email
+	// verification links point to.
+	DashboardURL string
+	// VerificationResendInterval is the minimum time between two verification
+	// emails to the same user.
+	VerificationResendInterval time.Duration
+	// MaxVerificationEmailsPerDay caps the verification emails sent to a user
+	// in 24 hours.
+	MaxVerificationEmailsPerDay int
 }
 
 // DefaultAuthServiceConfig returns sensible default configuration.
 func DefaultAuthServiceConfig() AuthServiceConfig {
 	return AuthServiceConfig{
-		BCryptCost:          12,
+		PasswordHash:        DefaultArgon2Params(),
 		SessionExpiry:       7 * 24 * time.Hour, // 7 days
 		PasswordResetExpiry: 1 * time.Hour,
 		EmailVerifyExpiry:   24 * time.Hour,
+
+		VerificationResendInterval:  time.Minute,
+		MaxVerificationEmailsPerDay: 5,
 	}
 }
 
 type authService struct {
-	userRepo    repository.UserRepository
-	sessionRepo repository.SessionRepository
-	config      AuthServiceConfig
+	userRepo     repository.UserRepository
+	sessionRepo  repository.SessionRepository
+	config       AuthServiceConfig
+	resetRepo    repository.PasswordResetRepository
+	resetSender  PasswordResetSender
+	verifyRepo   repository.EmailVerificationRepository
+	verifySender EmailVerificationSender
+}
+
+// AuthServiceOption configures optional auth service dependencies.
+type AuthServiceOption func(*authService)
+
+// WithPasswordResets stores password reset tokens in repo and delivers reset
+// links with sender. Without it, password resets are unavailable.
+func WithPasswordResets(repo repository.PasswordResetRepository, sender PasswordResetSender) AuthServiceOption {
+	return func(s *authService) {
+		s.resetRepo = repo
+		s.resetSender = sender
+	}
+}
+
+// WithEmailVerification stores email verification tokens in repo and delivers
+// verification links with sender. Without it, new users can't verify their email.
+func WithEmailVerification(repo repository.EmailVerificationRepository, sender EmailVerificationSender) AuthServiceOption {
+	return func(s *authService) {
+		s.verifyRepo = repo
+		s.verifySender = sender
+	}
 }
 
 // NewAuthService creates a new authentication service.
@@ -73,18 +120,28 @@ func NewAuthService(
 	userRepo repository.UserRepository,
 	sessionRepo repository.SessionRepository,
 	config AuthServiceConfig,
+	opts ...AuthServiceOption,
 ) AuthService {
-	return &authService{
+	s := &authService{
 		userRepo:    userRepo,
 		sessionRepo: sessionRepo,
 		config:      config,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Register creates a new user account.
 func (s *authService) Register(ctx context.Context, req RegisterRequest) (*models.User, error) {
-	// Check if email already exists
-	existing, err := s.userRepo.GetByEmail(ctx, req.Email)
+	email := normalizeEmail(req.Email)
+	if err := checkPasswordStrength(req.Password, email); err != nil {
+		return nil, err
+	}
+
+	// Check if email already exists, including accounts created through OAuth
+	existing, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing user: %w", err)
 	}
@@ -92,16 +149,14 @@ func (s *authService) Register(ctx context.Context, req RegisterRequest) (*model
 		return nil, apierrors.NewConflictError("Email already registered")
 	}
 
-	// Hash password
-	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), s.config.BCryptCost)
+	hash, err := hashPassword(req.Password, s.config.PasswordHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	hashStr := string(hash)
 	user := &models.User{
-		Email:         req.Email,
-		PasswordHash:  &hashStr,
+		Email:         email,
+		PasswordHash:  &hash,
 		Name:          &req.Name,
 		EmailVerified: false,
 	}
@@ -110,14 +165,15 @@ func (s *authService) Register(ctx context.Context, req RegisterRequest) (*model
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// TODO: Send verification email (would be implemented with email service)
+	// The account is usable without it; the user can ask for another email
+	_ = s.sendEmailVerification(ctx, user)
 
 	return user, nil
 }
 
 // Login authenticates a user and creates a session.
 func (s *authService) Login(ctx context.Context, email, password string) (*models.User, string, error) {
-	user, err := s.userRepo.GetByEmail(ctx, email)
+	user, err := s.userRepo.GetByEmail(ctx, normalizeEmail(email))
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to get user: %w", err)
 	}
@@ -131,10 +187,23 @@ func (s *authService) Login(ctx context.Context, email, password string) (*model
 	}
 
 	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(password)); err != nil {
+	ok, err := verifyPassword(password, *user.PasswordHash)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
 		return nil, "", apierrors.ErrUnauthorized.WithMessage("Invalid email or password")
 	}
 
+	// Upgrade legacy bcrypt or outdated argon2id hashes (best effort)
+	if needsRehash(*user.PasswordHash, s.config.PasswordHash) {
+		if hash, err := hashPassword(password, s.config.PasswordHash); err == nil {
+			if err := s.userRepo.UpdatePassword(ctx, user.ID, hash); err == nil {
+				user.PasswordHash = &hash
+			}
+		}
+	}
+
 	// Create session
 	sessionID, err := s.createSession(ctx, user.ID)
 	if err != nil {
@@ -240,17 +309,24 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oldP
 		return apierrors.ErrBadRequest.WithMessage("Cannot change password for OAuth accounts")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(oldPassword)); err != nil {
+	ok, err := verifyPassword(oldPassword, *user.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	if !ok {
 		return apierrors.ErrUnauthorized.WithMessage("Current password is incorrect")
 	}
 
-	// Hash new password
-	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), s.config.BCryptCost)
+	if err := checkPasswordStrength(newPassword, user.Email); err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(newPassword, s.config.PasswordHash)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	if err := s.userRepo.UpdatePassword(ctx, userID, string(hash)); err != nil {
+	if err := s.userRepo.UpdatePassword(ctx, userID, hash); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
 
@@ -260,10 +336,13 @@ func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, oldP
 	return nil
 }
 
-// RequestPasswordReset generates a password reset token.
-// In a real implementation, this would send an email with the token.
+// RequestPasswordReset issues a password reset token and sends the reset link.
 func (s *authService) RequestPasswordReset(ctx context.Context, email string) (string, error) {
-	user, err := s.userRepo.GetByEmail(ctx, email)
+	if s.resetRepo == nil {
+		return "", apierrors.ErrBadRequest.WithMessage("Password reset is not available")
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, normalizeEmail(email))
 	if err != nil {
 		return "", fmt.Errorf("failed to get user: %w", err)
 	}
@@ -273,29 +352,180 @@ func (s *authService) RequestPasswordReset(ctx context.Context, email string) (s
 		return "", nil
 	}
 
-	// Generate reset token
 	token, err := generateSecureToken(32)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// TODO: Store token in Redis with expiry and send email
-	// For now, return the token (in production, this would only be sent via email)
+	reset := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(s.config.PasswordResetExpiry),
+	}
+	if err := s.resetRepo.Create(ctx, reset); err != nil {
+		return "", fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	if s.resetSender != nil {
+		resetURL := PasswordResetURL(s.config.DashboardURL, token)
+		if err := s.resetSender.SendPasswordReset(ctx, user, resetURL, reset.ExpiresAt); err != nil {
+			return "", fmt.Errorf("failed to send password reset: %w", err)
+		}
+	}
 
 	return token, nil
 }
 
 // ResetPassword resets a user's password using a reset token.
 func (s *authService) ResetPassword(ctx context.Context, token, newPassword string) error {
-	// TODO: Implement token lookup from Redis
-	// For now, return not implemented
-	return apierrors.ErrBadRequest.WithMessage("Password reset not yet implemented")
+	if s.resetRepo == nil {
+		return apierrors.ErrBadRequest.WithMessage("Password reset is not available")
+	}
+
+	invalid := apierrors.ErrBadRequest.WithMessage("Invalid or expired reset link")
+
+	reset, err := s.resetRepo.GetByTokenHash(ctx, hashResetToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to get reset token: %w", err)
+	}
+	if reset == nil || reset.UsedAt != nil || reset.ExpiresAt.Before(time.Now()) {
+		return invalid
+	}
+
+	user, err := s.userRepo.GetByID(ctx, reset.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return invalid
+	}
+
+	if err := checkPasswordStrength(newPassword, user.Email); err != nil {
+		return err
+	}
+
+	// Claim the token before changing anything so it can't be redeemed twice
+	claimed, err := s.resetRepo.MarkUsed(ctx, reset.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark reset token used: %w", err)
+	}
+	if !claimed {
+		return invalid
+	}
+
+	hash, err := hashPassword(newPassword, s.config.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, hash); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	// Outstanding reset links and existing sessions are no longer valid
+	_ = s.resetRepo.DeleteForUser(ctx, user.ID)
+	_ = s.sessionRepo.DeleteAllForUser(ctx, user.ID)
+
+	return nil
+}
+
+// SendEmailVerification sends a new verification link to a user.
+func (s *authService) SendEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	if s.verifyRepo == nil {
+		return apierrors.ErrBadRequest.WithMessage("Email verification is not available")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return apierrors.NewNotFoundError("User")
+	}
+	if user.EmailVerified {
+		return apierrors.ErrBadRequest.WithMessage("Your email is already verified")
+	}
+
+	now := time.Now()
+	recent, err := s.verifyRepo.CountCreatedSince(ctx, user.ID, now.Add(-s.config.VerificationResendInterval))
+	if err != nil {
+		return fmt.Errorf("failed to count verification emails: %w", err)
+	}
+	if recent > 0 {
+		return apierrors.ErrRateLimited.WithMessage("A verification email was just sent. Please wait a minute before asking for another one.")
+	}
+	daily, err := s.verifyRepo.CountCreatedSince(ctx, user.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		return fmt.Errorf("failed to count verification emails: %w", err)
+	}
+	if daily >= s.config.MaxVerificationEmailsPerDay {
+		return apierrors.ErrRateLimited.WithMessage("Too many verification emails today. Please try again tomorrow.")
+	}
+
+	return s.sendEmailVerification(ctx, user)
 }
 
 // VerifyEmail verifies a user's email using a verification token.
 func (s *authService) VerifyEmail(ctx context.Context, token string) error {
-	// TODO: Implement token lookup from Redis and call SetEmailVerified
-	return apierrors.ErrBadRequest.WithMessage("Email verification not yet implemented")
+	if s.verifyRepo == nil {
+		return apierrors.ErrBadRequest.WithMessage("Email verification is not available")
+	}
+
+	invalid := apierrors.ErrBadRequest.WithMessage("Invalid or expired verification link")
+
+	verification, err := s.verifyRepo.GetByTokenHash(ctx, hashResetToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to get verification token: %w", err)
+	}
+	if verification == nil || verification.UsedAt != nil || verification.ExpiresAt.Before(time.Now()) {
+		return invalid
+	}
+
+	claimed, err := s.verifyRepo.MarkUsed(ctx, verification.ID)
+	if err != nil {
+		return fmt.Errorf("failed to mark verification token used: %w", err)
+	}
+	if !claimed {
+		return invalid
+	}
+
+	if err := s.userRepo.SetEmailVerified(ctx, verification.UserID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	// Other outstanding links are no longer needed
+	_ = s.verifyRepo.DeleteForUser(ctx, verification.UserID)
+
+	return nil
+}
+
+// sendEmailVerification issues a verification token and emails the link.
+func (s *authService) sendEmailVerification(ctx context.Context, user *models.User) error {
+	if s.verifyRepo == nil {
+		return nil
+	}
+
+	token, err := generateSecureToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	verification := &models.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(s.config.EmailVerifyExpiry),
+	}
+	if err := s.verifyRepo.Create(ctx, verification); err != nil {
+		return fmt.Errorf("failed to store verification token: %w", err)
+	}
+
+	if s.verifySender != nil {
+		verifyURL := EmailVerificationURL(s.config.DashboardURL, token)
+		if err := s.verifySender.SendEmailVerification(ctx, user, verifyURL, verification.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to send verification email: %w", err)
+		}
+	}
+
+	return nil
 }
 
 // createSession creates a new session for a user.
@@ -319,6 +549,13 @@ func (s *authService) createSession(ctx context.Context, userID uuid.UUID) (stri
 	return sessionID, nil
 }
 
+// hashResetToken returns the hex SHA-256 digest under which a reset or
+// verification token is stored.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // generateSecureToken generates a cryptographically secure random token.
 func generateSecureToken(length int) (string, error) {
 	b := make([]byte, length)
@@ -327,4 +564,3 @@ func generateSecureToken(length int) (string, error) {
 	}
 	return base64.URLEncoding.EncodeToString(b), nil
 }
-
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
@@ -55,6 +59,11 @@ func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, p
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ClearPassword(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) SetEmailVerified(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -114,12 +123,32 @@ func (m *MockSessionRepository) CleanupExpired(ctx context.Context) (int64, erro
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockSessionRepository) UpdateData(ctx context.Context, id string, data map[string]interface{}) error {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) CountActive(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func newTestAuthService(userRepo *MockUserRepository, sessionRepo *MockSessionRepository) AuthService {
-	config := AuthServiceConfig{
-		BCryptCost:    4, // Low cost for tests
-		SessionExpiry: 7 * 24 * time.Hour,
+	return NewAuthService(userRepo, sessionRepo, testAuthServiceConfig(), WithPasswordResets(newMockPasswordResetRepo(), nil))
+}
+
+func testAuthServiceConfig() AuthServiceConfig {
+	return AuthServiceConfig{
+		// Low cost for tests
+		PasswordHash:        Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		SessionExpiry:       7 * 24 * time.Hour,
+		PasswordResetExpiry: time.Hour,
+		EmailVerifyExpiry:   24 * time.Hour,
+		DashboardURL:        "https://popsigner.com",
+
+		VerificationResendInterval:  time.Minute,
+		MaxVerificationEmailsPerDay: 3,
 	}
-	return NewAuthService(userRepo, sessionRepo, config)
 }
 
 func TestAuthService_Register_Success(t *testing.T) {
@@ -130,7 +159,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 	ctx := context.Background()
 	req := RegisterRequest{
 		Email:    "newuser@example.com",
-		Password: "password123",
+		Password: "correct-horse-42",
 		Name:     "New User",
 	}
 
@@ -156,7 +185,7 @@ func TestAuthService_Register_EmailExists(t *testing.T) {
 	ctx := context.Background()
 	req := RegisterRequest{
 		Email:    "existing@example.com",
-		Password: "password123",
+		Password: "correct-horse-42",
 		Name:     "New User",
 	}
 
@@ -189,7 +218,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 	email := "user@example.com"
 	password := "password123"
 
-	// Create a bcrypt hash for the password
+	// Accounts created before argon2id have bcrypt hashes
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), 4)
 	hashStr := string(hash)
 	name := "Test User"
@@ -202,6 +231,10 @@ func TestAuthService_Login_Success(t *testing.T) {
 	}
 
 	userRepo.On("GetByEmail", ctx, email).Return(existingUser, nil)
+	// The bcrypt hash is upgraded to argon2id on login
+	userRepo.On("UpdatePassword", ctx, existingUser.ID, mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$")
+	})).Return(nil)
 	sessionRepo.On("Create", ctx, mock.AnythingOfType("*models.Session")).Return(nil)
 	userRepo.On("UpdateLastLogin", ctx, existingUser.ID).Return(nil)
 
@@ -476,7 +509,7 @@ func TestAuthService_ChangePassword_Success(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.New()
 	oldPassword := "oldpassword"
-	newPassword := "newpassword"
+	newPassword := "new-passw0rd!"
 
 	oldHash, _ := bcrypt.GenerateFromPassword([]byte(oldPassword), 4)
 	oldHashStr := string(oldHash)
@@ -576,3 +609,346 @@ func TestAuthService_RequestPasswordReset_UserNotExists(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+// mockPasswordResetRepo is an in-memory PasswordResetRepository.
+type mockPasswordResetRepo struct {
+	tokens map[uuid.UUID]*models.PasswordResetToken
+}
+
+func newMockPasswordResetRepo() *mockPasswordResetRepo {
+	return &mockPasswordResetRepo{tokens: make(map[uuid.UUID]*models.PasswordResetToken)}
+}
+
+func (m *mockPasswordResetRepo) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockPasswordResetRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPasswordResetRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return true, nil
+}
+
+func (m *mockPasswordResetRepo) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	for id, t := range m.tokens {
+		if t.UserID == userID {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+// mockPasswordResetSender records the reset links it is asked to send.
+type mockPasswordResetSender struct {
+	urls []string
+}
+
+func (m *mockPasswordResetSender) SendPasswordReset(ctx context.Context, user *models.User, resetURL string, expiresAt time.Time) error {
+	m.urls = append(m.urls, resetURL)
+	return nil
+}
+
+func TestAuthService_SignupLoginSession(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	sessionRepo := newMockSessionRepo()
+	svc := NewAuthService(userRepo, sessionRepo, testAuthServiceConfig())
+
+	registered, err := svc.Register(ctx, RegisterRequest{
+		Email:    "  Alice@Example.com ",
+		Password: "correct-horse-42",
+		Name:     "Alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", registered.Email)
+	require.NotNil(t, registered.PasswordHash)
+	assert.True(t, strings.HasPrefix(*registered.PasswordHash, "$argon2id$"))
+
+	user, sessionID, err := svc.Login(ctx, "ALICE@example.com", "correct-horse-42")
+	require.NoError(t, err)
+	assert.Equal(t, registered.ID, user.ID)
+	require.NotEmpty(t, sessionID)
+
+	sessionUser, err := svc.ValidateSession(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, registered.ID, sessionUser.ID)
+
+	// A wrong password is rejected without creating a session
+	_, wrongSessionID, err := svc.Login(ctx, "alice@example.com", "correct-horse-43")
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Empty(t, wrongSessionID)
+	assert.Len(t, sessionRepo.sessions, 1)
+}
+
+func TestAuthService_Register_DuplicateEmail(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig())
+
+	// An account created through OAuth has no password but owns the email
+	provider, providerID := "github", "12345"
+	require.NoError(t, userRepo.Create(ctx, &models.User{
+		Email:           "bob@example.com",
+		OAuthProvider:   &provider,
+		OAuthProviderID: &providerID,
+	}))
+
+	_, err := svc.Register(ctx, RegisterRequest{Email: "Bob@example.com", Password: "correct-horse-42", Name: "Bob"})
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "conflict", apiErr.Code)
+}
+
+func TestAuthService_Register_WeakPassword(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig())
+
+	for _, password := range []string{"short1!", "onlyletters", "12345678901", "Password123", "carol-secret-1"} {
+		_, err := svc.Register(ctx, RegisterRequest{Email: "carol@example.com", Password: password, Name: "Carol"})
+		apiErr, ok := err.(*apierrors.APIError)
+		if assert.True(t, ok, "Register(%q) err = %v", password, err) {
+			assert.Equal(t, "validation_error", apiErr.Code, "Register(%q)", password)
+		}
+	}
+	assert.Empty(t, userRepo.users)
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	sessionRepo := newMockSessionRepo()
+	sender := &mockPasswordResetSender{}
+	svc := NewAuthService(userRepo, sessionRepo, testAuthServiceConfig(), WithPasswordResets(newMockPasswordResetRepo(), sender))
+
+	_, err := svc.Register(ctx, RegisterRequest{Email: "dave@example.com", Password: "correct-horse-42", Name: "Dave"})
+	require.NoError(t, err)
+	_, oldSessionID, err := svc.Login(ctx, "dave@example.com", "correct-horse-42")
+	require.NoError(t, err)
+
+	token, err := svc.RequestPasswordReset(ctx, "Dave@example.com")
+	require.NoError(t, err)
+	require.Len(t, sender.urls, 1)
+	assert.Equal(t, PasswordResetURL("https://popsigner.com", token), sender.urls[0])
+
+	// Weak passwords are rejected and leave the token usable
+	err = svc.ResetPassword(ctx, token, "password")
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "validation_error", apiErr.Code)
+
+	require.NoError(t, svc.ResetPassword(ctx, token, "battery-staple-7"))
+
+	// Existing sessions are signed out and only the new password works
+	_, err = svc.ValidateSession(ctx, oldSessionID)
+	assert.Error(t, err)
+	_, _, err = svc.Login(ctx, "dave@example.com", "correct-horse-42")
+	assert.Error(t, err)
+	_, _, err = svc.Login(ctx, "dave@example.com", "battery-staple-7")
+	assert.NoError(t, err)
+
+	// The token is single use
+	err = svc.ResetPassword(ctx, token, "another-pass-8")
+	apiErr, ok = err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "bad_request", apiErr.Code)
+}
+
+func TestAuthService_ResetPassword_ExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	resetRepo := newMockPasswordResetRepo()
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig(), WithPasswordResets(resetRepo, nil))
+
+	_, err := svc.Register(ctx, RegisterRequest{Email: "erin@example.com", Password: "correct-horse-42", Name: "Erin"})
+	require.NoError(t, err)
+	token, err := svc.RequestPasswordReset(ctx, "erin@example.com")
+	require.NoError(t, err)
+
+	for _, reset := range resetRepo.tokens {
+		reset.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+
+	err = svc.ResetPassword(ctx, token, "battery-staple-7")
+	assert.Error(t, err)
+	_, _, err = svc.Login(ctx, "erin@example.com", "correct-horse-42")
+	assert.NoError(t, err)
+}
+
+// mockEmailVerificationRepo is an in-memory EmailVerificationRepository.
+type mockEmailVerificationRepo struct {
+	tokens map[uuid.UUID]*models.EmailVerificationToken
+}
+
+func newMockEmailVerificationRepo() *mockEmailVerificationRepo {
+	return &mockEmailVerificationRepo{tokens: make(map[uuid.UUID]*models.EmailVerificationToken)}
+}
+
+func (m *mockEmailVerificationRepo) Create(ctx context.Context, token *models.EmailVerificationToken) error {
+	token.ID = uuid.New()
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockEmailVerificationRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockEmailVerificationRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return true, nil
+}
+
+func (m *mockEmailVerificationRepo) CountCreatedSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count := 0
+	for _, t := range m.tokens {
+		if t.UserID == userID && !t.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockEmailVerificationRepo) DeleteForUser(ctx context.Context, userID uuid.UUID) error {
+	for id, t := range m.tokens {
+		if t.UserID == userID {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+// mockEmailVerificationSender records the verification links it is asked to send.
+type mockEmailVerificationSender struct {
+	urls []string
+}
+
+func (m *mockEmailVerificationSender) SendEmailVerification(ctx context.Context, user *models.User, verifyURL string, expiresAt time.Time) error {
+	m.urls = append(m.urls, verifyURL)
+	return nil
+}
+
+// tokenFromURL returns the token query parameter of a link.
+func tokenFromURL(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func TestAuthService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	sender := &mockEmailVerificationSender{}
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig(), WithEmailVerification(newMockEmailVerificationRepo(), sender))
+
+	user, err := svc.Register(ctx, RegisterRequest{Email: "frank@example.com", Password: "correct-horse-42", Name: "Frank"})
+	require.NoError(t, err)
+	assert.False(t, user.EmailVerified)
+
+	// Signing up sends the first verification email
+	require.Len(t, sender.urls, 1)
+	token := tokenFromURL(t, sender.urls[0])
+	assert.Equal(t, EmailVerificationURL("https://popsigner.com", token), sender.urls[0])
+
+	err = svc.VerifyEmail(ctx, "not-a-token")
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "bad_request", apiErr.Code)
+
+	require.NoError(t, svc.VerifyEmail(ctx, token))
+	verified, err := svc.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, verified.EmailVerified)
+
+	// The token is single use and verified users can't ask for another email
+	assert.Error(t, svc.VerifyEmail(ctx, token))
+	err = svc.SendEmailVerification(ctx, user.ID)
+	apiErr, ok = err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "bad_request", apiErr.Code)
+	assert.Len(t, sender.urls, 1)
+}
+
+func TestAuthService_VerifyEmail_ExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	verifyRepo := newMockEmailVerificationRepo()
+	sender := &mockEmailVerificationSender{}
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig(), WithEmailVerification(verifyRepo, sender))
+
+	user, err := svc.Register(ctx, RegisterRequest{Email: "grace@example.com", Password: "correct-horse-42", Name: "Grace"})
+	require.NoError(t, err)
+	for _, verification := range verifyRepo.tokens {
+		verification.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+
+	assert.Error(t, svc.VerifyEmail(ctx, tokenFromURL(t, sender.urls[0])))
+	assert.False(t, userRepo.users[user.ID].EmailVerified)
+}
+
+func TestAuthService_SendEmailVerification_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	userRepo := newMockUserRepo()
+	verifyRepo := newMockEmailVerificationRepo()
+	sender := &mockEmailVerificationSender{}
+	svc := NewAuthService(userRepo, newMockSessionRepo(), testAuthServiceConfig(), WithEmailVerification(verifyRepo, sender))
+
+	user, err := svc.Register(ctx, RegisterRequest{Email: "heidi@example.com", Password: "correct-horse-42", Name: "Heidi"})
+	require.NoError(t, err)
+
+	// Resending right after signup is too soon
+	err = svc.SendEmailVerification(ctx, user.ID)
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "rate_limited", apiErr.Code)
+
+	// Once the interval has passed, resends work up to the daily cap
+	for i := 0; i < 2; i++ {
+		for _, verification := range verifyRepo.tokens {
+			verification.CreatedAt = verification.CreatedAt.Add(-2 * time.Minute)
+		}
+		require.NoError(t, svc.SendEmailVerification(ctx, user.ID))
+	}
+	assert.Len(t, sender.urls, 3)
+
+	for _, verification := range verifyRepo.tokens {
+		verification.CreatedAt = verification.CreatedAt.Add(-2 * time.Minute)
+	}
+	err = svc.SendEmailVerification(ctx, user.ID)
+	apiErr, ok = err.(*apierrors.APIError)
+	require.True(t, ok, "err = %v", err)
+	assert.Equal(t, "rate_limited", apiErr.Code)
+
+	// Any of the links verifies the email
+	require.NoError(t, svc.VerifyEmail(ctx, tokenFromURL(t, sender.urls[1])))
+	assert.True(t, userRepo.users[user.ID].EmailVerified)
+}
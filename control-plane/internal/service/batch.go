@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// maxBatchConcurrency bounds how many sub-operations of a batch call
+// OpenBao at once.
+const maxBatchConcurrency = 16
+
+// runBatch calls fn for each index in [0, n) on up to maxBatchConcurrency
+// goroutines and waits for the started calls to finish. Once ctx is done no
+// further calls are started; the indexes that never ran are returned so the
+// caller can report them as cancelled.
+func runBatch(ctx context.Context, n int, fn func(idx int)) (skipped []int) {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(n, maxBatchConcurrency); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range work {
+				fn(idx)
+			}
+		}()
+	}
+
+	for idx := 0; idx < n; idx++ {
+		if ctx.Err() != nil {
+			skipped = append(skipped, idx)
+			continue
+		}
+		select {
+		case work <- idx:
+		case <-ctx.Done():
+			skipped = append(skipped, idx)
+		}
+	}
+	close(work)
+	wg.Wait()
+	return skipped
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestKeyService_SignBatchCancelled(t *testing.T) {
+	ts := newTestKeyService()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	key, err := ts.svc.Create(context.Background(), CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "batch-key"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	keyring := newBlockingKeyring(ts.baoKeyring)
+	svc := NewKeyService(ts.keyRepo, ts.orgRepo, ts.auditRepo, ts.usageRepo, keyring)
+
+	const total = maxBatchConcurrency + 10
+	requests := make([]SignKeyRequest, total)
+	for i := range requests {
+		requests[i] = SignKeyRequest{KeyID: key.ID, Data: base64.StdEncoding.EncodeToString([]byte("data"))}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan []*SignKeyResponse)
+	go func() {
+		results, err := svc.SignBatch(ctx, SignBatchKeyRequest{OrgID: orgID, Requests: requests})
+		if err != nil {
+			t.Errorf("SignBatch() error = %v", err)
+		}
+		done <- results
+	}()
+
+	// Cancel while the first signs are in flight
+	keyring.waitStarted(t, key.BaoKeyPath, maxBatchConcurrency)
+	cancel()
+	close(keyring.release)
+
+	var results []*SignKeyResponse
+	select {
+	case results = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SignBatch() did not return after cancellation")
+	}
+
+	if got := keyring.startedCount(key.BaoKeyPath); got != maxBatchConcurrency {
+		t.Errorf("signs sent to OpenBao = %d, want %d", got, maxBatchConcurrency)
+	}
+	var signed, cancelled int
+	for _, r := range results {
+		switch r.Error {
+		case "":
+			signed++
+		case context.Canceled.Error():
+			cancelled++
+		default:
+			t.Errorf("unexpected result error %q", r.Error)
+		}
+	}
+	if signed != maxBatchConcurrency || cancelled != total-maxBatchConcurrency {
+		t.Errorf("signed %d and cancelled %d, want %d and %d", signed, cancelled, maxBatchConcurrency, total-maxBatchConcurrency)
+	}
+}
+
+func TestKeyService_CreateBatchCancelled(t *testing.T) {
+	ts := newTestKeyService()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+
+	var started int32
+	var once sync.Once
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	ts.baoKeyring.newAccountErr = func(uid string) error {
+		if atomic.AddInt32(&started, 1) == maxBatchConcurrency {
+			once.Do(func() { close(inFlight) })
+		}
+		<-release
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const total = maxBatchConcurrency + 10
+	done := make(chan *CreateBatchKeyResult)
+	go func() {
+		result, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       total,
+		})
+		if err != nil {
+			t.Errorf("CreateBatch() error = %v", err)
+		}
+		done <- result
+	}()
+
+	select {
+	case <-inFlight:
+	case <-time.After(time.Second):
+		t.Fatal("batch did not start provisioning keys")
+	}
+	cancel()
+	close(release)
+
+	var result *CreateBatchKeyResult
+	select {
+	case result = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("CreateBatch() did not return after cancellation")
+	}
+
+	if got := atomic.LoadInt32(&started); got != maxBatchConcurrency {
+		t.Errorf("keys provisioned in OpenBao = %d, want %d", got, maxBatchConcurrency)
+	}
+	if result == nil {
+		return
+	}
+	if len(result.Failed) != total-maxBatchConcurrency {
+		t.Errorf("failed = %d, want %d", len(result.Failed), total-maxBatchConcurrency)
+	}
+	for _, f := range result.Failed {
+		if f.Error != context.Canceled.Error() {
+			t.Errorf("failure for %s = %q, want %q", f.Name, f.Error, context.Canceled.Error())
+		}
+	}
+}
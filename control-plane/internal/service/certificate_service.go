@@ -3,6 +3,8 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
@@ -22,6 +24,8 @@ import (
 type PKIInterface interface {
 	// IssueCertificate issues a new client certificate.
 	IssueCertificate(ctx context.Context, req *IssueCertRequest) (*IssuedCertificate, error)
+	// SignCertificateRequest signs a client-generated CSR.
+	SignCertificateRequest(ctx context.Context, req *SignCSRRequest) (*IssuedCertificate, error)
 	// RevokeCertificate revokes a certificate by serial number.
 	RevokeCertificate(ctx context.Context, serialNumber string) error
 	// GetCACertificate retrieves the CA certificate.
@@ -34,10 +38,17 @@ type IssueCertRequest struct {
 	TTL        string // e.g., "8760h" for 1 year
 }
 
+// SignCSRRequest represents a request to sign a CSR.
+type SignCSRRequest struct {
+	CSRPEM     string
+	CommonName string // Format: org_{org_id}, overrides the CN in the CSR
+	TTL        string
+}
+
 // IssuedCertificate represents an issued certificate.
 type IssuedCertificate struct {
 	CertificatePEM string
-	PrivateKeyPEM  string
+	PrivateKeyPEM  string // Empty for signed CSRs
 	CACertPEM      string
 	SerialNumber   string
 	IssuedAt       time.Time
@@ -54,8 +65,12 @@ type CACertificate struct {
 type CertificateService interface {
 	// Issue creates a new client certificate for an organization.
 	Issue(ctx context.Context, req *models.CreateCertificateRequest) (*models.CertificateBundle, error)
+	// Enroll signs a client-generated CSR and records the resulting certificate.
+	Enroll(ctx context.Context, req *models.EnrollCertificateRequest) (*models.CertificateBundle, error)
 	// Revoke revokes a certificate.
 	Revoke(ctx context.Context, orgID, certID, reason string) error
+	// SetSigningScope replaces the keys and namespaces a certificate may sign with.
+	SetSigningScope(ctx context.Context, orgID, certID string, keyIDs, namespaceIDs []uuid.UUID) (*models.Certificate, error)
 	// Get retrieves a certificate by ID.
 	Get(ctx context.Context, orgID, certID string) (*models.Certificate, error)
 	// List retrieves all certificates for an organization.
@@ -70,6 +85,15 @@ type CertificateService interface {
 	DownloadBundle(ctx context.Context, orgID, certID string) (*models.CertificateBundle, error)
 }
 
+// nitroMTLSConfigTip is the configuration hint returned with new certificates.
+const nitroMTLSConfigTip = `# Arbitrum Nitro configuration (mTLS)
+--node.batch-poster.data-poster.external-signer.url=https://rpc-mtls.popsigner.com
+--node.batch-poster.data-poster.external-signer.address=YOUR_ETH_ADDRESS
+--node.batch-poster.data-poster.external-signer.method=eth_signTransaction
+--node.batch-poster.data-poster.external-signer.root-ca=/path/to/popsigner-ca.crt
+--node.batch-poster.data-poster.external-signer.client-cert=/path/to/client.crt
+--node.batch-poster.data-poster.external-signer.client-private-key=/path/to/client.key`
+
 type certificateService struct {
 	repo      repository.CertificateRepository
 	pki       PKIInterface
@@ -99,24 +123,8 @@ func (s *certificateService) Issue(ctx context.Context, req *models.CreateCertif
 		return nil, apierrors.ErrBadRequest.WithMessage(err.Error())
 	}
 
-	// Check if org exists
-	org, err := s.orgRepo.GetByID(ctx, req.OrgID)
-	if err != nil {
-		return nil, fmt.Errorf("checking organization: %w", err)
-	}
-	if org == nil {
-		return nil, apierrors.NewNotFoundError("Organization")
-	}
-
-	// Check for duplicate name
-	existing, err := s.repo.GetByOrgAndName(ctx, req.OrgID.String(), req.Name)
-	if err != nil {
-		return nil, fmt.Errorf("checking existing certificate: %w", err)
-	}
-	if existing != nil {
-		return nil, apierrors.ErrBadRequest.WithMessage(
-			fmt.Sprintf("certificate with name '%s' already exists", req.Name),
-		)
+	if err := s.checkNewCertificate(ctx, req.OrgID, req.Name); err != nil {
+		return nil, err
 	}
 
 	// Issue certificate via OpenBao PKI
@@ -131,7 +139,104 @@ func (s *certificateService) Issue(ctx context.Context, req *models.CreateCertif
 		return nil, fmt.Errorf("issuing certificate: %w", err)
 	}
 
-	// Calculate fingerprint
+	cert, err := s.store(ctx, &models.Certificate{
+		OrgID:      req.OrgID,
+		Name:       req.Name,
+		CommonName: cn,
+	}, issued)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CertificateBundle{
+		ClientCert:     []byte(issued.CertificatePEM),
+		ClientKey:      []byte(issued.PrivateKeyPEM),
+		CACert:         []byte(issued.CACertPEM),
+		Fingerprint:    cert.Fingerprint,
+		ExpiresAt:      issued.ExpiresAt.Format(time.RFC3339),
+		NitroConfigTip: nitroMTLSConfigTip,
+	}, nil
+}
+
+// Enroll signs a client-generated CSR and records the resulting certificate.
+// The CN is always set to the organization, whatever the CSR asks for, and
+// the private key never reaches POPSigner.
+func (s *certificateService) Enroll(ctx context.Context, req *models.EnrollCertificateRequest) (*models.CertificateBundle, error) {
+	if err := req.Validate(); err != nil {
+		return nil, apierrors.ErrBadRequest.WithMessage(err.Error())
+	}
+
+	keyIDs, namespaceIDs, err := normalizeSigningScope(req.AllowedKeyIDs, req.AllowedNamespaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCSR(req.CSRPEM); err != nil {
+		return nil, apierrors.NewValidationError("csr", err.Error())
+	}
+
+	if err := s.checkNewCertificate(ctx, req.OrgID, req.Name); err != nil {
+		return nil, err
+	}
+
+	cn := models.CNFromOrgID(req.OrgID.String())
+	issued, err := s.pki.SignCertificateRequest(ctx, &SignCSRRequest{
+		CSRPEM:     req.CSRPEM,
+		CommonName: cn,
+		TTL:        fmt.Sprintf("%dh", int(req.ValidityPeriod.Hours())),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing certificate request: %w", err)
+	}
+
+	cert, err := s.store(ctx, &models.Certificate{
+		OrgID:               req.OrgID,
+		Name:                req.Name,
+		CommonName:          cn,
+		AllowedKeyIDs:       keyIDs,
+		AllowedNamespaceIDs: namespaceIDs,
+	}, issued)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CertificateBundle{
+		ClientCert:     []byte(issued.CertificatePEM),
+		CACert:         []byte(issued.CACertPEM),
+		Fingerprint:    cert.Fingerprint,
+		ExpiresAt:      issued.ExpiresAt.Format(time.RFC3339),
+		NitroConfigTip: nitroMTLSConfigTip,
+	}, nil
+}
+
+// checkNewCertificate verifies the organization exists and has no
+// certificate with the given name yet.
+func (s *certificateService) checkNewCertificate(ctx context.Context, orgID uuid.UUID, name string) error {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("checking organization: %w", err)
+	}
+	if org == nil {
+		return apierrors.NewNotFoundError("Organization")
+	}
+
+	existing, err := s.repo.GetByOrgAndName(ctx, orgID.String(), name)
+	if err != nil {
+		return fmt.Errorf("checking existing certificate: %w", err)
+	}
+	if existing != nil {
+		return apierrors.ErrBadRequest.WithMessage(
+			fmt.Sprintf("certificate with name '%s' already exists", name),
+		)
+	}
+
+	return nil
+}
+
+// store records an issued certificate, filling in the fields taken from the
+// PKI result. The certificate is revoked again if it cannot be stored, so
+// every valid certificate has a database record.
+func (s *certificateService) store(ctx context.Context, cert *models.Certificate, issued *IssuedCertificate) (*models.Certificate, error) {
 	fingerprint, err := calculateFingerprint(issued.CertificatePEM)
 	if err != nil {
 		return nil, fmt.Errorf("calculating fingerprint: %w", err)
@@ -139,18 +244,13 @@ func (s *certificateService) Issue(ctx context.Context, req *models.CreateCertif
 
 	// Store certificate metadata (including PEM for re-download)
 	certPEM := issued.CertificatePEM
-	cert := &models.Certificate{
-		ID:             uuid.New(),
-		OrgID:          req.OrgID,
-		Name:           req.Name,
-		Fingerprint:    fingerprint,
-		CommonName:     cn,
-		SerialNumber:   issued.SerialNumber,
-		IssuedAt:       issued.IssuedAt,
-		ExpiresAt:      issued.ExpiresAt,
-		CreatedAt:      time.Now(),
-		CertificatePEM: &certPEM, // Store for re-download (private key is NOT stored)
-	}
+	cert.ID = uuid.New()
+	cert.Fingerprint = fingerprint
+	cert.SerialNumber = issued.SerialNumber
+	cert.IssuedAt = issued.IssuedAt
+	cert.ExpiresAt = issued.ExpiresAt
+	cert.CreatedAt = time.Now()
+	cert.CertificatePEM = &certPEM // Store for re-download (private key is NOT stored)
 
 	if err := s.repo.Create(ctx, cert); err != nil {
 		// Revoke the certificate if we can't store metadata
@@ -159,25 +259,33 @@ func (s *certificateService) Issue(ctx context.Context, req *models.CreateCertif
 	}
 
 	// Audit log (using key event type as placeholder until certificate events are added)
-	s.auditLog(ctx, req.OrgID, models.AuditEventKeyCreated, "certificate")
+	s.auditLog(ctx, cert.OrgID, models.AuditEventKeyCreated, "certificate")
 
-	// Build configuration hint for Nitro
-	nitroTip := `# Arbitrum Nitro configuration (mTLS)
---node.batch-poster.data-poster.external-signer.url=https://rpc-mtls.popsigner.com
---node.batch-poster.data-poster.external-signer.address=YOUR_ETH_ADDRESS
---node.batch-poster.data-poster.external-signer.method=eth_signTransaction
---node.batch-poster.data-poster.external-signer.root-ca=/path/to/popsigner-ca.crt
---node.batch-poster.data-poster.external-signer.client-cert=/path/to/client.crt
---node.batch-poster.data-poster.external-signer.client-private-key=/path/to/client.key`
+	return cert, nil
+}
 
-	return &models.CertificateBundle{
-		ClientCert:     []byte(issued.CertificatePEM),
-		ClientKey:      []byte(issued.PrivateKeyPEM),
-		CACert:         []byte(issued.CACertPEM),
-		Fingerprint:    fingerprint,
-		ExpiresAt:      issued.ExpiresAt.Format(time.RFC3339),
-		NitroConfigTip: nitroTip,
-	}, nil
+// validateCSR checks that csrPEM holds a self-signed CSR for a P-256 key,
+// the only key type the client certificate role accepts.
+func validateCSR(csrPEM string) error {
+	block, _ := pem.Decode([]byte(csrPEM))
+	if block == nil || (block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST") {
+		return fmt.Errorf("must be a PEM-encoded certificate request")
+	}
+
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid certificate request: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid certificate request signature")
+	}
+
+	pub, ok := csr.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.Curve != elliptic.P256() {
+		return fmt.Errorf("key must be ECDSA P-256")
+	}
+
+	return nil
 }
 
 // Revoke revokes a certificate.
@@ -218,6 +326,35 @@ func (s *certificateService) Revoke(ctx context.Context, orgID, certID, reason s
 	return nil
 }
 
+// SetSigningScope replaces the keys and namespaces a certificate may sign
+// with. Empty lists let it sign with any key in the org. The change applies
+// to the next request; the certificate itself is not reissued.
+func (s *certificateService) SetSigningScope(ctx context.Context, orgID, certID string, keyIDs, namespaceIDs []uuid.UUID) (*models.Certificate, error) {
+	keyIDs, namespaceIDs, err := normalizeSigningScope(keyIDs, namespaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := s.Get(ctx, orgID, certID)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, apierrors.NewNotFoundError("Certificate")
+	}
+	if cert.IsRevoked() {
+		return nil, apierrors.NewConflictError("certificate is revoked")
+	}
+
+	if err := s.repo.SetSigningScope(ctx, certID, keyIDs, namespaceIDs); err != nil {
+		return nil, fmt.Errorf("updating signing scope: %w", err)
+	}
+
+	cert.AllowedKeyIDs = keyIDs
+	cert.AllowedNamespaceIDs = namespaceIDs
+	return cert, nil
+}
+
 // Get retrieves a certificate by ID.
 func (s *certificateService) Get(ctx context.Context, orgID, certID string) (*models.Certificate, error) {
 	cert, err := s.repo.GetByID(ctx, certID)
@@ -5,9 +5,11 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 	"github.com/Bidon15/popsigner/control-plane/internal/repository"
 )
 
@@ -33,11 +36,27 @@ func (m *MockCertificateService) Issue(ctx context.Context, req *models.CreateCe
 	return args.Get(0).(*models.CertificateBundle), args.Error(1)
 }
 
+func (m *MockCertificateService) Enroll(ctx context.Context, req *models.EnrollCertificateRequest) (*models.CertificateBundle, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificateBundle), args.Error(1)
+}
+
 func (m *MockCertificateService) Revoke(ctx context.Context, orgID, certID, reason string) error {
 	args := m.Called(ctx, orgID, certID, reason)
 	return args.Error(0)
 }
 
+func (m *MockCertificateService) SetSigningScope(ctx context.Context, orgID, certID string, keyIDs, namespaceIDs []uuid.UUID) (*models.Certificate, error) {
+	args := m.Called(ctx, orgID, certID, keyIDs, namespaceIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certificate), args.Error(1)
+}
+
 func (m *MockCertificateService) Get(ctx context.Context, orgID, certID string) (*models.Certificate, error) {
 	args := m.Called(ctx, orgID, certID)
 	if args.Get(0) == nil {
@@ -470,3 +489,280 @@ func TestCertificateRequestValidation(t *testing.T) {
 	}
 }
 
+// memCertRepo is an in-memory CertificateRepository covering what Enroll and
+// SetSigningScope use.
+type memCertRepo struct {
+	repository.CertificateRepository
+	certs []*models.Certificate
+}
+
+func (m *memCertRepo) Create(ctx context.Context, cert *models.Certificate) error {
+	m.certs = append(m.certs, cert)
+	return nil
+}
+
+func (m *memCertRepo) GetByOrgAndName(ctx context.Context, orgID, name string) (*models.Certificate, error) {
+	for _, cert := range m.certs {
+		if cert.OrgID.String() == orgID && cert.Name == name {
+			return cert, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memCertRepo) GetByID(ctx context.Context, id string) (*models.Certificate, error) {
+	for _, cert := range m.certs {
+		if cert.ID.String() == id {
+			return cert, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memCertRepo) SetSigningScope(ctx context.Context, id string, keyIDs, namespaceIDs []uuid.UUID) error {
+	cert, _ := m.GetByID(ctx, id)
+	if cert == nil || cert.IsRevoked() {
+		return errors.New("no rows")
+	}
+	cert.AllowedKeyIDs = keyIDs
+	cert.AllowedNamespaceIDs = namespaceIDs
+	return nil
+}
+
+// localCA signs CSRs like the OpenBao client certificate role.
+type localCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newLocalCA(t *testing.T) *localCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return &localCA{key: key, cert: cert}
+}
+
+func (ca *localCA) IssueCertificate(ctx context.Context, req *IssueCertRequest) (*IssuedCertificate, error) {
+	return nil, errors.New("not supported")
+}
+
+func (ca *localCA) SignCertificateRequest(ctx context.Context, req *SignCSRRequest) (*IssuedCertificate, error) {
+	block, _ := pem.Decode([]byte(req.CSRPEM))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := time.ParseDuration(req.TTL)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: req.CommonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(ttl),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, csr.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	return &IssuedCertificate{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		CACertPEM:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})),
+		SerialNumber:   template.SerialNumber.String(),
+		IssuedAt:       template.NotBefore,
+		ExpiresAt:      template.NotAfter,
+	}, nil
+}
+
+func (ca *localCA) RevokeCertificate(ctx context.Context, serialNumber string) error {
+	return nil
+}
+
+func (ca *localCA) GetCACertificate(ctx context.Context) (*CACertificate, error) {
+	return &CACertificate{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})),
+		ExpiresAt:      ca.cert.NotAfter,
+	}, nil
+}
+
+// generateTestCSR creates a PEM-encoded CSR for key with the given CN.
+func generateTestCSR(t *testing.T, key any, cn string) string {
+	t.Helper()
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: cn},
+	}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+}
+
+func TestCertificateService_Enroll(t *testing.T) {
+	errorCode := func(err error) string {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) {
+			return apiErr.Code
+		}
+		return ""
+	}
+
+	ctx := context.Background()
+	orgs := newMockOrgRepo()
+	org := &models.Organization{ID: uuid.New(), Name: "Test Org"}
+	orgs.orgs[org.ID] = org
+	repo := &memCertRepo{}
+	svc := NewCertificateService(repo, newLocalCA(t), orgs, newMockAuditRepo())
+
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate client key: %v", err)
+	}
+
+	t.Run("valid CSR", func(t *testing.T) {
+		keyID := uuid.New()
+		bundle, err := svc.Enroll(ctx, &models.EnrollCertificateRequest{
+			OrgID: org.ID,
+			Name:  "batch-poster",
+			// The CN is forced to the enrolling org
+			CSRPEM:        generateTestCSR(t, clientKey, "org_someone_else"),
+			AllowedKeyIDs: []uuid.UUID{keyID, keyID},
+		})
+		if err != nil {
+			t.Fatalf("Enroll() error = %v", err)
+		}
+		assert.Empty(t, bundle.ClientKey)
+		assert.NotEmpty(t, bundle.CACert)
+
+		block, _ := pem.Decode(bundle.ClientCert)
+		if block == nil {
+			t.Fatal("failed to decode client certificate")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("failed to parse client certificate: %v", err)
+		}
+		assert.Equal(t, models.CNFromOrgID(org.ID.String()), cert.Subject.CommonName)
+		assert.True(t, cert.PublicKey.(*ecdsa.PublicKey).Equal(&clientKey.PublicKey))
+
+		if assert.Len(t, repo.certs, 1) {
+			stored := repo.certs[0]
+			assert.Equal(t, models.FingerprintFromCert(cert), stored.Fingerprint)
+			assert.Equal(t, stored.Fingerprint, bundle.Fingerprint)
+			assert.Equal(t, org.ID, stored.OrgID)
+			assert.Equal(t, []uuid.UUID{keyID}, stored.AllowedKeyIDs)
+		}
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		_, err := svc.Enroll(ctx, &models.EnrollCertificateRequest{
+			OrgID:  org.ID,
+			Name:   "batch-poster",
+			CSRPEM: generateTestCSR(t, clientKey, ""),
+		})
+		assert.Equal(t, "bad_request", errorCode(err))
+	})
+
+	t.Run("not a CSR", func(t *testing.T) {
+		_, err := svc.Enroll(ctx, &models.EnrollCertificateRequest{
+			OrgID:  org.ID,
+			Name:   "staker",
+			CSRPEM: generateTestCertificate(t),
+		})
+		assert.Equal(t, "validation_error", errorCode(err))
+	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("failed to generate RSA key: %v", err)
+		}
+		_, err = svc.Enroll(ctx, &models.EnrollCertificateRequest{
+			OrgID:  org.ID,
+			Name:   "staker",
+			CSRPEM: generateTestCSR(t, rsaKey, ""),
+		})
+		assert.Equal(t, "validation_error", errorCode(err))
+	})
+
+	t.Run("unknown org", func(t *testing.T) {
+		_, err := svc.Enroll(ctx, &models.EnrollCertificateRequest{
+			OrgID:  uuid.New(),
+			Name:   "staker",
+			CSRPEM: generateTestCSR(t, clientKey, ""),
+		})
+		assert.Equal(t, "not_found", errorCode(err))
+	})
+
+	assert.Len(t, repo.certs, 1)
+}
+
+func TestCertificateService_SetSigningScope(t *testing.T) {
+	errorCode := func(err error) string {
+		var apiErr *apierrors.APIError
+		if errors.As(err, &apiErr) {
+			return apiErr.Code
+		}
+		return ""
+	}
+
+	ctx := context.Background()
+	orgID := uuid.New()
+	revokedAt := time.Now()
+	active := &models.Certificate{ID: uuid.New(), OrgID: orgID, Name: "staker"}
+	revoked := &models.Certificate{ID: uuid.New(), OrgID: orgID, Name: "old", RevokedAt: &revokedAt}
+	repo := &memCertRepo{certs: []*models.Certificate{active, revoked}}
+	svc := NewCertificateService(repo, newLocalCA(t), newMockOrgRepo(), newMockAuditRepo())
+
+	t.Run("sets scope", func(t *testing.T) {
+		namespaceID := uuid.New()
+		cert, err := svc.SetSigningScope(ctx, orgID.String(), active.ID.String(), nil, []uuid.UUID{namespaceID, namespaceID, uuid.Nil})
+		if err != nil {
+			t.Fatalf("SetSigningScope() error = %v", err)
+		}
+		assert.Equal(t, []uuid.UUID{namespaceID}, cert.AllowedNamespaceIDs)
+		assert.Equal(t, []uuid.UUID{namespaceID}, active.AllowedNamespaceIDs)
+		assert.Empty(t, active.AllowedKeyIDs)
+	})
+
+	t.Run("clears scope", func(t *testing.T) {
+		_, err := svc.SetSigningScope(ctx, orgID.String(), active.ID.String(), nil, nil)
+		if err != nil {
+			t.Fatalf("SetSigningScope() error = %v", err)
+		}
+		assert.True(t, active.AllowsKey(&models.Key{ID: uuid.New(), NamespaceID: uuid.New()}))
+	})
+
+	t.Run("other org", func(t *testing.T) {
+		_, err := svc.SetSigningScope(ctx, uuid.New().String(), active.ID.String(), []uuid.UUID{uuid.New()}, nil)
+		assert.Equal(t, "not_found", errorCode(err))
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		_, err := svc.SetSigningScope(ctx, orgID.String(), revoked.ID.String(), []uuid.UUID{uuid.New()}, nil)
+		assert.Equal(t, "conflict", errorCode(err))
+	})
+}
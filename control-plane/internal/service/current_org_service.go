@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// SessionCurrentOrgKey is the session data key holding the ID of the
+// organization a dashboard session works in.
+const SessionCurrentOrgKey = "current_org_id"
+
+// CurrentOrgService tracks which organization a dashboard session works in,
+// for users who belong to more than one.
+type CurrentOrgService interface {
+	// Current returns the session's selected organization. Without a
+	// selection, or once the user is no longer a member of it, it falls back
+	// to the user's first organization. It returns nil if the user has none.
+	Current(ctx context.Context, session *models.Session) (*models.Organization, error)
+
+	// Switch selects the organization for the session. The session user must
+	// be a member of it.
+	Switch(ctx context.Context, session *models.Session, orgID uuid.UUID) (*models.Organization, error)
+}
+
+type currentOrgService struct {
+	orgRepo     repository.OrgRepository
+	sessionRepo repository.SessionRepository
+}
+
+// NewCurrentOrgService creates a new current organization service.
+func NewCurrentOrgService(orgRepo repository.OrgRepository, sessionRepo repository.SessionRepository) CurrentOrgService {
+	return &currentOrgService{
+		orgRepo:     orgRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// Current returns the session's selected organization.
+func (s *currentOrgService) Current(ctx context.Context, session *models.Session) (*models.Organization, error) {
+	if id, ok := session.Data[SessionCurrentOrgKey].(string); ok {
+		if orgID, err := uuid.Parse(id); err == nil {
+			org, err := s.memberOrg(ctx, orgID, session.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if org != nil {
+				return org, nil
+			}
+		}
+	}
+
+	orgs, err := s.orgRepo.ListUserOrgs(ctx, session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	if len(orgs) == 0 {
+		return nil, nil
+	}
+	return orgs[0], nil
+}
+
+// Switch selects the organization for the session.
+func (s *currentOrgService) Switch(ctx context.Context, session *models.Session, orgID uuid.UUID) (*models.Organization, error) {
+	org, err := s.memberOrg(ctx, orgID, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, apierrors.ErrForbidden.WithMessage("You are not a member of this organization")
+	}
+
+	data := make(map[string]interface{}, len(session.Data)+1)
+	for k, v := range session.Data {
+		data[k] = v
+	}
+	data[SessionCurrentOrgKey] = org.ID.String()
+
+	if err := s.sessionRepo.UpdateData(ctx, session.ID, data); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	session.Data = data
+
+	return org, nil
+}
+
+// memberOrg returns the organization if the user is a member of it, or nil.
+func (s *currentOrgService) memberOrg(ctx context.Context, orgID, userID uuid.UUID) (*models.Organization, error) {
+	member, err := s.orgRepo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	if member == nil {
+		return nil, nil
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+// Compile-time check to ensure currentOrgService implements CurrentOrgService.
+var _ CurrentOrgService = (*currentOrgService)(nil)
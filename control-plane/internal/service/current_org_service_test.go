@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+)
+
+func TestCurrentOrgService_Switch(t *testing.T) {
+	ctx := context.Background()
+	orgRepo := newMockOrgRepo()
+	keyRepo := newMockKeyRepo()
+	sessionRepo := newMockSessionRepo()
+	svc := NewCurrentOrgService(orgRepo, sessionRepo)
+
+	userID := uuid.New()
+	orgA := &models.Organization{Name: "Org A"}
+	orgB := &models.Organization{Name: "Org B"}
+	orgRepo.Create(ctx, orgA, userID)
+	orgRepo.Create(ctx, orgB, userID)
+
+	keyA := &models.Key{ID: uuid.New(), OrgID: orgA.ID, Name: "key-a"}
+	keyB := &models.Key{ID: uuid.New(), OrgID: orgB.ID, Name: "key-b"}
+	keyRepo.keys[keyA.ID] = keyA
+	keyRepo.keys[keyB.ID] = keyB
+
+	session := &models.Session{ID: "session-1", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+	sessionRepo.sessions[session.ID] = session
+
+	// listKeys lists the keys of the session's current org, as the keys page does
+	listKeys := func() []*models.Key {
+		t.Helper()
+		org, err := svc.Current(ctx, session)
+		if err != nil {
+			t.Fatalf("Current() error = %v", err)
+		}
+		keys, _ := keyRepo.ListByOrg(ctx, org.ID)
+		return keys
+	}
+
+	for _, tt := range []struct {
+		org     *models.Organization
+		wantKey *models.Key
+	}{
+		{orgA, keyA},
+		{orgB, keyB},
+		{orgA, keyA},
+	} {
+		org, err := svc.Switch(ctx, session, tt.org.ID)
+		if err != nil {
+			t.Fatalf("Switch(%s) error = %v", tt.org.Name, err)
+		}
+		if org.ID != tt.org.ID {
+			t.Errorf("Switch(%s) returned org %s", tt.org.Name, org.Name)
+		}
+
+		keys := listKeys()
+		if len(keys) != 1 || keys[0].ID != tt.wantKey.ID {
+			t.Errorf("after switching to %s, keys = %v, want [%s]", tt.org.Name, keys, tt.wantKey.Name)
+		}
+	}
+
+	// The selection is persisted with the session
+	stored, _ := sessionRepo.Get(ctx, session.ID)
+	if got := stored.Data[SessionCurrentOrgKey]; got != orgA.ID.String() {
+		t.Errorf("stored current org = %v, want %s", got, orgA.ID)
+	}
+}
+
+func TestCurrentOrgService_SwitchRejectsNonMember(t *testing.T) {
+	ctx := context.Background()
+	orgRepo := newMockOrgRepo()
+	sessionRepo := newMockSessionRepo()
+	svc := NewCurrentOrgService(orgRepo, sessionRepo)
+
+	userID := uuid.New()
+	own := &models.Organization{Name: "Own"}
+	other := &models.Organization{Name: "Other"}
+	orgRepo.Create(ctx, own, userID)
+	orgRepo.Create(ctx, other, uuid.New())
+
+	session := &models.Session{ID: "session-1", UserID: userID}
+	sessionRepo.sessions[session.ID] = session
+
+	_, err := svc.Switch(ctx, session, other.ID)
+	apiErr, ok := err.(*apierrors.APIError)
+	if !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Switch() to non-member org error = %v, want forbidden", err)
+	}
+	if _, ok := session.Data[SessionCurrentOrgKey]; ok {
+		t.Error("session should not select an org the user isn't a member of")
+	}
+
+	org, err := svc.Current(ctx, session)
+	if err != nil {
+		t.Fatalf("Current() error = %v", err)
+	}
+	if org.ID != own.ID {
+		t.Errorf("Current() = %s, want %s", org.Name, own.Name)
+	}
+}
+
+func TestCurrentOrgService_CurrentAfterLeavingOrg(t *testing.T) {
+	ctx := context.Background()
+	orgRepo := newMockOrgRepo()
+	sessionRepo := newMockSessionRepo()
+	svc := NewCurrentOrgService(orgRepo, sessionRepo)
+
+	userID := uuid.New()
+	own := &models.Organization{Name: "Own"}
+	left := &models.Organization{Name: "Left"}
+	orgRepo.Create(ctx, own, userID)
+	orgRepo.Create(ctx, left, uuid.New())
+	orgRepo.AddMember(ctx, left.ID, userID, models.RoleViewer, nil)
+
+	session := &models.Session{ID: "session-1", UserID: userID}
+	sessionRepo.sessions[session.ID] = session
+	if _, err := svc.Switch(ctx, session, left.ID); err != nil {
+		t.Fatalf("Switch() error = %v", err)
+	}
+
+	orgRepo.RemoveMember(ctx, left.ID, userID)
+
+	org, err := svc.Current(ctx, session)
+	if err != nil {
+		t.Fatalf("Current() error = %v", err)
+	}
+	if org.ID != own.ID {
+		t.Errorf("Current() = %s, want fallback to %s", org.Name, own.Name)
+	}
+}
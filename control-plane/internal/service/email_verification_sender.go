@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// EmailVerificationSender delivers email verification links to users.
+type EmailVerificationSender interface {
+	SendEmailVerification(ctx context.Context, user *models.User, verifyURL string, expiresAt time.Time) error
+}
+
+// EmailVerificationURL returns the link a user opens to verify their email address.
+func EmailVerificationURL(baseURL, token string) string {
+	return strings.TrimRight(baseURL, "/") + "/verify-email?token=" + url.QueryEscape(token)
+}
+
+// logEmailVerificationSender logs verification links instead of emailing them.
+// It is used when no SMTP server is configured.
+type logEmailVerificationSender struct {
+	logger *slog.Logger
+}
+
+// NewLogEmailVerificationSender creates a verification sender that only logs the verification link.
+func NewLogEmailVerificationSender(logger *slog.Logger) EmailVerificationSender {
+	return &logEmailVerificationSender{logger: logger}
+}
+
+// SendEmailVerification logs the verification link.
+func (s *logEmailVerificationSender) SendEmailVerification(ctx context.Context, user *models.User, verifyURL string, expiresAt time.Time) error {
+	s.logger.Info("Email verification requested (email delivery disabled)",
+		slog.String("user_id", user.ID.String()),
+		slog.String("verify_url", verifyURL),
+	)
+	return nil
+}
+
+// smtpEmailVerificationSender emails verification links over SMTP.
+type smtpEmailVerificationSender struct {
+	config SMTPConfig
+	send   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPEmailVerificationSender creates a verification sender that emails the verification link.
+func NewSMTPEmailVerificationSender(config SMTPConfig) EmailVerificationSender {
+	return &smtpEmailVerificationSender{config: config, send: smtp.SendMail}
+}
+
+// SendEmailVerification emails the verification link to the user.
+func (s *smtpEmailVerificationSender) SendEmailVerification(ctx context.Context, user *models.User, verifyURL string, expiresAt time.Time) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(user.Email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	msg := buildEmailVerificationEmail(from, to, verifyURL, expiresAt)
+	if err := s.send(addr, auth, from.Address, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// buildEmailVerificationEmail renders a plain-text email verification email.
+func buildEmailVerificationEmail(from, to *mail.Address, verifyURL string, expiresAt time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	b.WriteString("Subject: Verify your POPSigner email address\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString("Welcome to POPSigner. Confirm your email address to start creating keys.\r\n\r\n")
+	fmt.Fprintf(&b, "Verify your email:\r\n%s\r\n\r\n", verifyURL)
+	fmt.Fprintf(&b, "This link expires on %s.\r\n", expiresAt.UTC().Format(time.RFC1123))
+	b.WriteString("If you didn't create a POPSigner account, you can ignore this email.\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestEmailVerificationURL(t *testing.T) {
+	assert.Equal(t, "https://popsigner.com/verify-email?token=abc-_", EmailVerificationURL("https://popsigner.com/", "abc-_"))
+	assert.Equal(t, "https://popsigner.com/verify-email?token=a%3D%3D", EmailVerificationURL("https://popsigner.com", "a=="))
+}
+
+func TestSMTPEmailVerificationSender_SendEmailVerification(t *testing.T) {
+	var (
+		gotTo  []string
+		gotMsg string
+	)
+	sender := NewSMTPEmailVerificationSender(SMTPConfig{
+		Host: "smtp.example.com",
+		Port: 587,
+		From: "POPSigner <noreply@popsigner.com>",
+	}).(*smtpEmailVerificationSender)
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotTo, gotMsg = to, string(msg)
+		return nil
+	}
+
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	err := sender.SendEmailVerification(context.Background(), user, "https://popsigner.com/verify-email?token=t", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: Verify your POPSigner email address")
+	assert.Contains(t, gotMsg, "https://popsigner.com/verify-email?token=t")
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// InvitationSender delivers organization invitations to the invitee.
+type InvitationSender interface {
+	SendInvitation(ctx context.Context, inv *models.Invitation, acceptURL string) error
+}
+
+// InvitationAcceptURL returns the link an invitee opens to accept an invitation.
+func InvitationAcceptURL(baseURL, token string) string {
+	return strings.TrimRight(baseURL, "/") + "/invites/" + token
+}
+
+// logInvitationSender logs invitation links instead of emailing them.
+// It is used when no SMTP server is configured.
+type logInvitationSender struct {
+	logger *slog.Logger
+}
+
+// NewLogInvitationSender creates an invitation sender that only logs the accept link.
+func NewLogInvitationSender(logger *slog.Logger) InvitationSender {
+	return &logInvitationSender{logger: logger}
+}
+
+// SendInvitation logs the invitation.
+func (s *logInvitationSender) SendInvitation(ctx context.Context, inv *models.Invitation, acceptURL string) error {
+	s.logger.Info("Organization invitation created (email delivery disabled)",
+		slog.String("org_id", inv.OrgID.String()),
+		slog.String("email", inv.Email),
+		slog.String("role", string(inv.Role)),
+		slog.String("accept_url", acceptURL),
+	)
+	return nil
+}
+
+// SMTPConfig holds SMTP settings for outgoing emails.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// smtpInvitationSender emails invitations over SMTP.
+type smtpInvitationSender struct {
+	config SMTPConfig
+	send   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPInvitationSender creates an invitation sender that emails the accept link.
+func NewSMTPInvitationSender(config SMTPConfig) InvitationSender {
+	return &smtpInvitationSender{config: config, send: smtp.SendMail}
+}
+
+// SendInvitation emails the invitation to the invitee.
+func (s *smtpInvitationSender) SendInvitation(ctx context.Context, inv *models.Invitation, acceptURL string) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(inv.Email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	msg := buildInvitationEmail(from, to, inv, acceptURL)
+	if err := s.send(addr, auth, from.Address, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+	return nil
+}
+
+// buildInvitationEmail renders a plain-text invitation email.
+func buildInvitationEmail(from, to *mail.Address, inv *models.Invitation, acceptURL string) []byte {
+	orgName := "a POPSigner organization"
+	if inv.Organization != nil && inv.Organization.Name != "" {
+		orgName = inv.Organization.Name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: You're invited to join %s on POPSigner\r\n", orgName)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "You have been invited to join %s on POPSigner as %s.\r\n\r\n", orgName, inv.Role)
+	fmt.Fprintf(&b, "Accept the invitation:\r\n%s\r\n\r\n", acceptURL)
+	fmt.Fprintf(&b, "This invitation expires on %s.\r\n", inv.ExpiresAt.UTC().Format(time.RFC1123))
+	return []byte(b.String())
+}
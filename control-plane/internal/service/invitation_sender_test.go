@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestInvitationAcceptURL(t *testing.T) {
+	assert.Equal(t, "https://popsigner.com/invites/abc", InvitationAcceptURL("https://popsigner.com", "abc"))
+	assert.Equal(t, "https://popsigner.com/invites/abc", InvitationAcceptURL("https://popsigner.com/", "abc"))
+}
+
+func TestSMTPInvitationSender_SendInvitation(t *testing.T) {
+	var (
+		gotAddr string
+		gotFrom string
+		gotTo   []string
+		gotMsg  string
+	)
+	sender := NewSMTPInvitationSender(SMTPConfig{
+		Host: "smtp.example.com",
+		Port: 587,
+		From: "POPSigner <noreply@popsigner.com>",
+	}).(*smtpInvitationSender)
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, string(msg)
+		return nil
+	}
+
+	inv := &models.Invitation{
+		ID:           uuid.New(),
+		Email:        "teammate@example.com",
+		Role:         models.RoleOperator,
+		Token:        "token123",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		Organization: &models.Organization{Name: "Acme"},
+	}
+
+	err := sender.SendInvitation(context.Background(), inv, "https://popsigner.com/invites/token123")
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, "noreply@popsigner.com", gotFrom)
+	assert.Equal(t, []string{"teammate@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: You're invited to join Acme on POPSigner")
+	assert.Contains(t, gotMsg, "as operator")
+	assert.Contains(t, gotMsg, "https://popsigner.com/invites/token123")
+}
+
+func TestSMTPInvitationSender_InvalidRecipient(t *testing.T) {
+	sender := NewSMTPInvitationSender(SMTPConfig{
+		Host: "smtp.example.com",
+		Port: 587,
+		From: "noreply@popsigner.com",
+	})
+
+	err := sender.SendInvitation(context.Background(), &models.Invitation{Email: "not-an-email"}, "https://popsigner.com/invites/x")
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+)
+
+// ErrKeyBusy is returned when a sign waits too long for a free slot on a key
+// that is at its concurrency limit.
+var ErrKeyBusy = apierrors.ErrRateLimited.WithMessage("Too many concurrent signing requests for this key, retry shortly")
+
+// keyLimiter caps in-flight signs per OpenBao key. Signs beyond the limit
+// wait up to maxWait for a slot; signs on different keys don't affect each
+// other.
+type keyLimiter struct {
+	max     int
+	maxWait time.Duration
+
+	mu   sync.Mutex
+	keys map[string]*keySlots
+}
+
+// keySlots is the semaphore of one key, dropped once no sign holds or waits
+// for it.
+type keySlots struct {
+	slots chan struct{}
+	refs  int
+}
+
+// newKeyLimiter returns a limiter allowing max in-flight signs per key, or
+// nil if max is not positive.
+func newKeyLimiter(max int, maxWait time.Duration) *keyLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return &keyLimiter{
+		max:     max,
+		maxWait: maxWait,
+		keys:    make(map[string]*keySlots),
+	}
+}
+
+// acquire takes a slot for key, waiting up to maxWait, and returns the
+// function that releases it. A nil limiter never blocks.
+func (l *keyLimiter) acquire(ctx context.Context, key string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	k, ok := l.keys[key]
+	if !ok {
+		k = &keySlots{slots: make(chan struct{}, l.max)}
+		l.keys[key] = k
+	}
+	k.refs++
+	l.mu.Unlock()
+
+	if err := l.wait(ctx, k); err != nil {
+		l.unref(key, k)
+		return nil, err
+	}
+	return func() {
+		<-k.slots
+		l.unref(key, k)
+	}, nil
+}
+
+// wait blocks until a slot of k is free, maxWait passes or ctx is done.
+func (l *keyLimiter) wait(ctx context.Context, k *keySlots) error {
+	select {
+	case k.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if l.maxWait <= 0 {
+		return ErrKeyBusy
+	}
+
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+	select {
+	case k.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrKeyBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *keyLimiter) unref(key string, k *keySlots) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	k.refs--
+	if k.refs == 0 {
+		delete(l.keys, key)
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// blockingKeyring holds signs until released and counts them per key.
+type blockingKeyring struct {
+	*mockBaoKeyring
+
+	release chan struct{}
+
+	mu      sync.Mutex
+	started map[string]int
+	cond    *sync.Cond
+}
+
+func newBlockingKeyring(inner *mockBaoKeyring) *blockingKeyring {
+	k := &blockingKeyring{
+		mockBaoKeyring: inner,
+		release:        make(chan struct{}),
+		started:        make(map[string]int),
+	}
+	k.cond = sync.NewCond(&k.mu)
+	return k
+}
+
+func (k *blockingKeyring) Sign(uid string, msg []byte, prehashed bool) ([]byte, []byte, error) {
+	k.mu.Lock()
+	k.started[uid]++
+	k.cond.Broadcast()
+	k.mu.Unlock()
+
+	<-k.release
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.mockBaoKeyring.Sign(uid, msg, prehashed)
+}
+
+// waitStarted waits until n signs on uid have reached OpenBao.
+func (k *blockingKeyring) waitStarted(t *testing.T, uid string, n int) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		k.mu.Lock()
+		for k.started[uid] < n {
+			k.cond.Wait()
+		}
+		k.mu.Unlock()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("%d signs on %s did not start", n, uid)
+	}
+}
+
+func (k *blockingKeyring) startedCount(uid string) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.started[uid]
+}
+
+func TestKeyService_SignConcurrencyLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestKeyService()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	var keys []*models.Key
+	for _, name := range []string{"busy-key", "other-key"} {
+		key, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: name})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		keys = append(keys, key)
+	}
+	busy, other := keys[0], keys[1]
+
+	keyring := newBlockingKeyring(ts.baoKeyring)
+	svc := NewKeyService(ts.keyRepo, ts.orgRepo, ts.auditRepo, ts.usageRepo, keyring,
+		WithKeyConcurrencyLimit(2, 5*time.Second))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	sign := func(keyID uuid.UUID) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Sign(ctx, orgID, keyID, []byte("data"), false)
+			errs <- err
+		}()
+	}
+
+	// Two signs fill the busy key; the third waits for a slot
+	sign(busy.ID)
+	sign(busy.ID)
+	keyring.waitStarted(t, busy.BaoKeyPath, 2)
+	sign(busy.ID)
+
+	// Another key isn't blocked by the busy one
+	sign(other.ID)
+	keyring.waitStarted(t, other.BaoKeyPath, 1)
+
+	time.Sleep(50 * time.Millisecond)
+	if got := keyring.startedCount(busy.BaoKeyPath); got != 2 {
+		t.Errorf("in-flight signs on busy key = %d, want 2", got)
+	}
+
+	close(keyring.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Sign() error = %v", err)
+		}
+	}
+	if got := keyring.startedCount(busy.BaoKeyPath); got != 3 {
+		t.Errorf("signs on busy key = %d, want 3", got)
+	}
+}
+
+func TestKeyLimiter_RejectsAfterMaxWait(t *testing.T) {
+	l := newKeyLimiter(1, 20*time.Millisecond)
+	ctx := context.Background()
+
+	release, err := l.acquire(ctx, "key")
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	if _, err := l.acquire(ctx, "key"); !errors.Is(err, ErrKeyBusy) {
+		t.Errorf("acquire() error = %v, want ErrKeyBusy", err)
+	}
+
+	release()
+	release, err = l.acquire(ctx, "key")
+	if err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+	release()
+
+	if len(l.keys) != 0 {
+		t.Errorf("limiter keeps %d idle keys, want 0", len(l.keys))
+	}
+}
+
+func TestKeyLimiter_Disabled(t *testing.T) {
+	l := newKeyLimiter(0, time.Second)
+	if l != nil {
+		t.Fatal("newKeyLimiter(0) should disable the limit")
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := l.acquire(context.Background(), "key"); err != nil {
+			t.Fatalf("acquire() error = %v", err)
+		}
+	}
+}
@@ -2,13 +2,20 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"sync"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
 
+	"filippo.io/age"
+	"filippo.io/age/armor"
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
@@ -23,9 +30,10 @@ type BaoKeyringInterface interface {
 	// Returns the public key bytes, address, and Ethereum address.
 	NewAccountWithOptions(uid string, opts KeyOptions) (pubKey []byte, address string, ethAddress string, err error)
 
-	// Sign signs a message with the given key.
+	// Sign signs a message with the given key. If prehashed is true, msg is
+	// signed as-is instead of being hashed first.
 	// Returns the signature and public key.
-	Sign(uid string, msg []byte) (signature []byte, pubKey []byte, err error)
+	Sign(uid string, msg []byte, prehashed bool) (signature []byte, pubKey []byte, err error)
 
 	// Delete removes a key from OpenBao.
 	Delete(uid string) error
@@ -53,6 +61,8 @@ type KeyMetadata struct {
 	PubKeyBytes []byte
 	Address     string
 	EthAddress  string
+	Algorithm   models.Algorithm
+	Curve       string
 }
 
 // KeyService defines the interface for key management operations.
@@ -61,16 +71,28 @@ type KeyService interface {
 	Create(ctx context.Context, req CreateKeyRequest) (*models.Key, error)
 	Get(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error)
 	List(ctx context.Context, orgID uuid.UUID, namespaceID *uuid.UUID, networkType *models.NetworkType) ([]*models.Key, error)
+	ListPage(ctx context.Context, orgID uuid.UUID, filter KeyListFilter) ([]*models.Key, string, error)
+	ListByOrgPaged(ctx context.Context, orgID uuid.UUID, filter KeyPageFilter) (*KeyPage, error)
 	Delete(ctx context.Context, orgID, keyID uuid.UUID) error
 	Sign(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*SignKeyResponse, error)
+	SignVersion(ctx context.Context, orgID, keyID uuid.UUID, version int, data []byte, prehashed bool) (*SignKeyResponse, error)
+
+	// Restore undoes a Delete within the deletion grace period
+	Restore(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error)
+	// PurgeDeletedKeys permanently removes keys whose grace period has passed
+	PurgeDeletedKeys(ctx context.Context) (int64, error)
+
+	// Rotate replaces the key material while keeping the key ID
+	Rotate(ctx context.Context, orgID, keyID uuid.UUID, opts RotateKeyOptions) (*models.Key, error)
 
 	// Batch operations for parallel workers
-	CreateBatch(ctx context.Context, req CreateBatchKeyRequest) ([]*models.Key, error)
+	CreateBatch(ctx context.Context, req CreateBatchKeyRequest) (*CreateBatchKeyResult, error)
 	SignBatch(ctx context.Context, req SignBatchKeyRequest) ([]*SignKeyResponse, error)
 
 	// Import/Export
 	Import(ctx context.Context, req ImportKeyRequest) (*models.Key, error)
 	Export(ctx context.Context, orgID, keyID uuid.UUID) (string, error)
+	ExportEncrypted(ctx context.Context, orgID, keyID uuid.UUID, recipient string) (string, error)
 }
 
 // CreateKeyRequest is the request for creating a new key.
@@ -93,11 +115,26 @@ type CreateBatchKeyRequest struct {
 	Exportable  bool      `json:"exportable"`
 }
 
+// CreateBatchKeyResult is the result of a batch key creation. Keys that could
+// not be provisioned are reported in Failed; the others are still created.
+type CreateBatchKeyResult struct {
+	Keys   []*models.Key           `json:"keys"`
+	Failed []CreateBatchKeyFailure `json:"failed,omitempty"`
+}
+
+// CreateBatchKeyFailure is a key of a batch that could not be created.
+type CreateBatchKeyFailure struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
 // SignKeyRequest is a single signing request.
 type SignKeyRequest struct {
 	KeyID     uuid.UUID `json:"key_id" validate:"required"`
 	Data      string    `json:"data" validate:"required"` // base64
 	Prehashed bool      `json:"prehashed"`
+	// KeyVersion selects a retired key version; 0 signs with the current version.
+	KeyVersion int `json:"key_version,omitempty"`
 }
 
 // SignBatchKeyRequest is the request for batch signing.
@@ -108,10 +145,61 @@ type SignBatchKeyRequest struct {
 
 // SignKeyResponse is the response from a signing operation.
 type SignKeyResponse struct {
-	KeyID     uuid.UUID `json:"key_id"`
-	Signature string    `json:"signature"`  // base64
-	PublicKey string    `json:"public_key"` // hex
-	Error     string    `json:"error,omitempty"`
+	KeyID      uuid.UUID `json:"key_id"`
+	Signature  string    `json:"signature"`  // base64
+	PublicKey  string    `json:"public_key"` // hex
+	KeyVersion int       `json:"key_version,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// RotateKeyOptions configures key rotation.
+type RotateKeyOptions struct {
+	// AllowPreviousVersionSigning keeps the retired version usable for signing
+	// when requested explicitly by version. By default it can no longer sign.
+	AllowPreviousVersionSigning bool `json:"allow_previous_version_signing"`
+}
+
+// KeyListFilter contains filter and pagination options for listing keys.
+type KeyListFilter struct {
+	NamespaceID *uuid.UUID
+	NetworkType *models.NetworkType
+	NamePrefix  string
+	// Label filters on key metadata, either "key" (present) or "key=value".
+	Label  string
+	Limit  int
+	Cursor string
+}
+
+// Key page sizes for ListByOrgPaged.
+const (
+	DefaultKeyPageSize = 25
+	MaxKeyPageSize     = 100
+)
+
+// KeyPageFilter filters a numbered page of keys. Page is 1-based; Page and
+// Size fall back to the first page of DefaultKeyPageSize keys, and Sort to
+// newest first.
+type KeyPageFilter struct {
+	NamespaceID *uuid.UUID
+	NetworkType *models.NetworkType
+	Search      string
+	Sort        models.KeySort
+	Page        int
+	Size        int
+}
+
+// KeyPage is a numbered page of keys.
+type KeyPage struct {
+	Keys  []*models.Key
+	Total int // keys matching the filter on all pages
+	Page  int
+	Size  int
+	Sort  models.KeySort
+}
+
+// Pages returns the number of pages, at least one.
+func (p *KeyPage) Pages() int {
+	return max((p.Total+p.Size-1)/p.Size, 1)
 }
 
 // ImportKeyRequest is the request for importing a key.
@@ -129,6 +217,75 @@ type keyService struct {
 	auditRepo  repository.AuditRepository
 	usageRepo  repository.UsageRepository
 	baoKeyring BaoKeyringInterface
+	events     EventPublisher
+	signLimit  *keyLimiter
+	signCache  *signCache
+
+	deletionGracePeriod time.Duration
+	maxSignMessageSize  int
+}
+
+// DefaultKeyDeletionGracePeriod is how long a deleted key can be restored
+// before it is purged.
+const DefaultKeyDeletionGracePeriod = 7 * 24 * time.Hour
+
+// DefaultMaxSignMessageSize is the largest message Sign accepts unless
+// configured otherwise.
+const DefaultMaxSignMessageSize = 1 << 20 // 1 MiB
+
+// prehashedSize is the length of a digest signed without hashing.
+const prehashedSize = 32
+
+// ErrInvalidDigest is returned by Sign when prehashed data is not a 32-byte
+// digest.
+var ErrInvalidDigest = apierrors.NewValidationError("data", fmt.Sprintf("prehashed data must be a %d-byte digest", prehashedSize))
+
+// KeyServiceOption configures optional key service dependencies.
+type KeyServiceOption func(*keyService)
+
+// WithEventPublisher publishes key, signing and quota events, e.g. to webhooks.
+func WithEventPublisher(events EventPublisher) KeyServiceOption {
+	return func(s *keyService) {
+		s.events = events
+	}
+}
+
+// WithDeletionGracePeriod sets how long a deleted key can be restored before
+// PurgeDeletedKeys removes it.
+func WithDeletionGracePeriod(d time.Duration) KeyServiceOption {
+	return func(s *keyService) {
+		s.deletionGracePeriod = d
+	}
+}
+
+// WithKeyConcurrencyLimit caps concurrent signs per OpenBao key at max, so a
+// burst against one key can't overwhelm the plugin. Signs beyond the limit
+// wait up to maxWait for a slot and then fail with ErrKeyBusy; signs on other
+// keys are unaffected. A max of zero disables the limit.
+func WithKeyConcurrencyLimit(max int, maxWait time.Duration) KeyServiceOption {
+	return func(s *keyService) {
+		s.signLimit = newKeyLimiter(max, maxWait)
+	}
+}
+
+// WithSignatureCache answers exact repeats of a sign request (same key
+// version, data and mode) within ttl with the previous signature instead of
+// calling OpenBao again. Repeats are audited but not counted as new
+// signatures. A ttl of zero disables the cache.
+func WithSignatureCache(ttl time.Duration) KeyServiceOption {
+	return func(s *keyService) {
+		s.signCache = newSignCache(ttl)
+	}
+}
+
+// WithMaxSignMessageSize rejects sign requests for messages larger than max
+// bytes before they are hashed. Zero or less keeps DefaultMaxSignMessageSize.
+func WithMaxSignMessageSize(max int) KeyServiceOption {
+	return func(s *keyService) {
+		if max > 0 {
+			s.maxSignMessageSize = max
+		}
+	}
 }
 
 // NewKeyService creates a new key service.
@@ -138,14 +295,22 @@ func NewKeyService(
 	auditRepo repository.AuditRepository,
 	usageRepo repository.UsageRepository,
 	baoKeyring BaoKeyringInterface,
+	opts ...KeyServiceOption,
 ) KeyService {
-	return &keyService{
+	s := &keyService{
 		keyRepo:    keyRepo,
 		orgRepo:    orgRepo,
 		auditRepo:  auditRepo,
 		usageRepo:  usageRepo,
 		baoKeyring: baoKeyring,
+
+		deletionGracePeriod: DefaultKeyDeletionGracePeriod,
+		maxSignMessageSize:  DefaultMaxSignMessageSize,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Create creates a new key.
@@ -165,7 +330,8 @@ func (s *keyService) Create(ctx context.Context, req CreateKeyRequest) (*models.
 	}
 
 	// Generate unique OpenBao key name
-	baoKeyName := fmt.Sprintf("%s_%s_%s", req.OrgID, req.NamespaceID, req.Name)
+	keyID := uuid.New()
+	baoKeyName := baoKeyNameFor(req.OrgID, req.NamespaceID, keyID, req.Name)
 
 	// Create in BaoKeyring
 	pubKey, address, ethAddress, err := s.baoKeyring.NewAccountWithOptions(baoKeyName, KeyOptions{
@@ -196,7 +362,7 @@ func (s *keyService) Create(ctx context.Context, req CreateKeyRequest) (*models.
 
 	// Save metadata to database
 	key := &models.Key{
-		ID:          uuid.New(),
+		ID:          keyID,
 		OrgID:       req.OrgID,
 		NamespaceID: req.NamespaceID,
 		Name:        req.Name,
@@ -218,12 +384,18 @@ func (s *keyService) Create(ctx context.Context, req CreateKeyRequest) (*models.
 
 	// Audit log
 	s.auditLog(ctx, req.OrgID, models.AuditEventKeyCreated, models.ResourceTypeKey, key.ID)
+	s.publish(req.OrgID, models.WebhookEventKeyCreated, keyEventData(key))
 
 	return key, nil
 }
 
-// CreateBatch creates multiple keys in parallel.
-func (s *keyService) CreateBatch(ctx context.Context, req CreateBatchKeyRequest) ([]*models.Key, error) {
+// CreateBatch creates Count keys named {prefix}-1 to {prefix}-{count}. The
+// keys are provisioned in OpenBao concurrently and their metadata is saved in
+// a single transaction. Keys that fail to provision are reported in the
+// result; the batch only fails if none could be provisioned or saving fails.
+// If ctx is cancelled, no further keys are provisioned and the skipped ones
+// are reported as failed.
+func (s *keyService) CreateBatch(ctx context.Context, req CreateBatchKeyRequest) (*CreateBatchKeyResult, error) {
 	// Check quota for all keys
 	org, err := s.orgRepo.GetByID(ctx, req.OrgID)
 	if err != nil {
@@ -240,48 +412,89 @@ func (s *keyService) CreateBatch(ctx context.Context, req CreateBatchKeyRequest)
 	}
 
 	if limits.Keys > 0 && currentCount+req.Count > limits.Keys {
+		s.publishQuotaExceeded(req.OrgID, "keys", int64(limits.Keys))
 		return nil, apierrors.ErrQuotaExceeded.WithMessage(
 			fmt.Sprintf("Creating %d keys would exceed your limit of %d keys", req.Count, limits.Keys),
 		)
 	}
 
-	// Create keys in parallel
+	// Verify namespace belongs to org
+	ns, err := s.orgRepo.GetNamespace(ctx, req.NamespaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	if ns == nil || ns.OrgID != req.OrgID {
+		return nil, apierrors.NewNotFoundError("Namespace")
+	}
+
+	// Provision keys in OpenBao in parallel
 	keys := make([]*models.Key, req.Count)
 	errs := make([]error, req.Count)
-	var wg sync.WaitGroup
-
-	for i := 0; i < req.Count; i++ {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-			name := fmt.Sprintf("%s-%d", req.Prefix, idx+1)
-			key, err := s.Create(ctx, CreateKeyRequest{
-				OrgID:       req.OrgID,
-				NamespaceID: req.NamespaceID,
-				Name:        name,
-				Exportable:  req.Exportable,
-			})
-			keys[idx] = key
-			errs[idx] = err
-		}(i)
+
+	skipped := runBatch(ctx, req.Count, func(idx int) {
+		name := fmt.Sprintf("%s-%d", req.Prefix, idx+1)
+		keyID := uuid.New()
+		baoKeyName := baoKeyNameFor(req.OrgID, req.NamespaceID, keyID, name)
+
+		pubKey, address, ethAddress, err := s.baoKeyring.NewAccountWithOptions(baoKeyName, KeyOptions{
+			Exportable: req.Exportable,
+		})
+		if err != nil {
+			errs[idx] = fmt.Errorf("failed to create key in OpenBao: %w", err)
+			return
+		}
+
+		keys[idx] = &models.Key{
+			ID:          keyID,
+			OrgID:       req.OrgID,
+			NamespaceID: req.NamespaceID,
+			Name:        name,
+			PublicKey:   pubKey,
+			Address:     address,
+			EthAddress:  &ethAddress,
+			NetworkType: models.NetworkTypeAll,
+			Algorithm:   models.AlgorithmSecp256k1,
+			BaoKeyPath:  baoKeyName,
+			Exportable:  req.Exportable,
+		}
+	})
+	for _, idx := range skipped {
+		errs[idx] = ctx.Err()
 	}
-	wg.Wait()
 
 	// Collect results (partial success is possible)
-	var result []*models.Key
+	result := &CreateBatchKeyResult{}
+	var firstErr error
 	for i, key := range keys {
-		if errs[i] == nil && key != nil {
-			result = append(result, key)
+		if errs[i] != nil {
+			result.Failed = append(result.Failed, CreateBatchKeyFailure{
+				Name:  fmt.Sprintf("%s-%d", req.Prefix, i+1),
+				Error: errs[i].Error(),
+			})
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
 		}
+		result.Keys = append(result.Keys, key)
 	}
 
-	if len(result) == 0 && len(errs) > 0 {
-		// All failed, return first error
-		for _, err := range errs {
-			if err != nil {
-				return nil, fmt.Errorf("batch create failed: %w", err)
-			}
+	if len(result.Keys) == 0 {
+		return nil, fmt.Errorf("batch create failed: %w", firstErr)
+	}
+
+	// Save metadata for all provisioned keys at once
+	if err := s.keyRepo.CreateBatch(ctx, result.Keys); err != nil {
+		// Cleanup OpenBao keys on failure
+		for _, key := range result.Keys {
+			_ = s.baoKeyring.Delete(key.BaoKeyPath)
 		}
+		return nil, fmt.Errorf("failed to save key metadata: %w", err)
+	}
+
+	for _, key := range result.Keys {
+		s.auditLog(ctx, req.OrgID, models.AuditEventKeyCreated, models.ResourceTypeKey, key.ID)
+		s.publish(req.OrgID, models.WebhookEventKeyCreated, keyEventData(key))
 	}
 
 	return result, nil
@@ -336,16 +549,204 @@ func (s *keyService) List(ctx context.Context, orgID uuid.UUID, namespaceID *uui
 	return keys, nil
 }
 
-// Delete deletes a key.
+// ListPage lists a page of keys for an organization.
+// It returns the keys and an opaque cursor for the next page, which is empty
+// when there are no more results.
+func (s *keyService) ListPage(ctx context.Context, orgID uuid.UUID, filter KeyListFilter) ([]*models.Key, string, error) {
+	// Set default and max limits
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	if filter.NamespaceID != nil {
+		// Verify namespace belongs to org
+		ns, err := s.orgRepo.GetNamespace(ctx, *filter.NamespaceID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get namespace: %w", err)
+		}
+		if ns == nil || ns.OrgID != orgID {
+			return nil, "", apierrors.NewNotFoundError("Namespace")
+		}
+	}
+
+	query := models.KeyListQuery{
+		OrgID:       orgID,
+		NamespaceID: filter.NamespaceID,
+		NetworkType: filter.NetworkType,
+		NamePrefix:  filter.NamePrefix,
+		Limit:       limit + 1, // Fetch one extra to determine if there's a next page
+	}
+
+	if filter.Label != "" {
+		query.LabelKey, query.LabelValue, _ = strings.Cut(filter.Label, "=")
+	}
+
+	if filter.Cursor != "" {
+		createdAt, id, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, "", apierrors.NewValidationError("cursor", "invalid cursor")
+		}
+		query.AfterCreatedAt = &createdAt
+		query.AfterID = &id
+	}
+
+	keys, err := s.keyRepo.ListPage(ctx, query)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	// Determine next cursor
+	var nextCursor string
+	if len(keys) > limit {
+		keys = keys[:limit]
+		last := keys[limit-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.ID)
+	}
+
+	return keys, nextCursor, nil
+}
+
+// ListByOrgPaged lists a numbered page of keys for an organization, for
+// the dashboard keys page.
+func (s *keyService) ListByOrgPaged(ctx context.Context, orgID uuid.UUID, filter KeyPageFilter) (*KeyPage, error) {
+	size := filter.Size
+	if size <= 0 || size > MaxKeyPageSize {
+		size = DefaultKeyPageSize
+	}
+	page := max(filter.Page, 1)
+	order := filter.Sort
+	if !order.Valid() {
+		order = models.KeySortCreated
+	}
+
+	if filter.NamespaceID != nil {
+		// Verify namespace belongs to org
+		ns, err := s.orgRepo.GetNamespace(ctx, *filter.NamespaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get namespace: %w", err)
+		}
+		if ns == nil || ns.OrgID != orgID {
+			return nil, apierrors.NewNotFoundError("Namespace")
+		}
+	}
+
+	query := models.KeyPageQuery{
+		OrgID:       orgID,
+		NamespaceID: filter.NamespaceID,
+		NetworkType: filter.NetworkType,
+		Search:      strings.TrimSpace(filter.Search),
+		Sort:        order,
+		Offset:      (page - 1) * size,
+		Limit:       size,
+	}
+	keys, total, err := s.keyRepo.ListByOrgPaged(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+
+	// Past the last page, e.g. after its keys were deleted, show the last page
+	if len(keys) == 0 && total > 0 {
+		page = (total + size - 1) / size
+		query.Offset = (page - 1) * size
+		keys, total, err = s.keyRepo.ListByOrgPaged(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keys: %w", err)
+		}
+	}
+
+	return &KeyPage{Keys: keys, Total: total, Page: page, Size: size, Sort: order}, nil
+}
+
+// Delete soft-deletes a key. The key stops appearing in lists and can no
+// longer sign, but its OpenBao material is kept so it can be restored until
+// the deletion grace period passes and PurgeDeletedKeys removes it.
 func (s *keyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 	key, err := s.keyRepo.GetByID(ctx, keyID)
 	if err != nil {
 		return fmt.Errorf("failed to get key: %w", err)
 	}
-	if key == nil || key.OrgID != orgID {
+	if key == nil || key.OrgID != orgID || key.DeletedAt != nil {
 		return apierrors.NewNotFoundError("Key")
 	}
 
+	// Soft delete in database
+	if err := s.keyRepo.SoftDelete(ctx, keyID); err != nil {
+		return fmt.Errorf("failed to delete key metadata: %w", err)
+	}
+
+	// Audit log
+	s.auditLog(ctx, orgID, models.AuditEventKeyDeleted, models.ResourceTypeKey, keyID)
+	s.publish(orgID, models.WebhookEventKeyDeleted, keyEventData(key))
+
+	return nil
+}
+
+// Restore undoes the deletion of a key within the deletion grace period.
+func (s *keyService) Restore(ctx context.Context, orgID, keyID uuid.UUID) (*models.Key, error) {
+	key, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	if key == nil || key.OrgID != orgID || key.DeletedAt == nil {
+		return nil, apierrors.NewNotFoundError("Key")
+	}
+	if time.Since(*key.DeletedAt) >= s.deletionGracePeriod {
+		// Past the grace period the key is awaiting purge
+		return nil, apierrors.NewNotFoundError("Key")
+	}
+
+	// The name or address may have been reused since the key was deleted
+	existing, err := s.keyRepo.GetByName(ctx, orgID, key.NamespaceID, key.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing key: %w", err)
+	}
+	if existing != nil {
+		return nil, apierrors.NewConflictError(fmt.Sprintf("a key named '%s' already exists in this namespace", key.Name))
+	}
+	if key.EthAddress != nil && *key.EthAddress != "" {
+		existing, err := s.keyRepo.GetByEthAddress(ctx, orgID, *key.EthAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing key: %w", err)
+		}
+		if existing != nil {
+			return nil, apierrors.NewConflictError("a key with this address already exists")
+		}
+	}
+
+	if err := s.keyRepo.Restore(ctx, keyID); err != nil {
+		return nil, fmt.Errorf("failed to restore key: %w", err)
+	}
+	key.DeletedAt = nil
+
+	s.auditLog(ctx, orgID, models.AuditEventKeyRestored, models.ResourceTypeKey, keyID)
+
+	return key, nil
+}
+
+// PurgeDeletedKeys permanently removes keys deleted longer ago than the
+// deletion grace period, including their OpenBao material. It returns the
+// number of keys purged.
+func (s *keyService) PurgeDeletedKeys(ctx context.Context) (int64, error) {
+	keys, err := s.keyRepo.ListDeletedBefore(ctx, time.Now().Add(-s.deletionGracePeriod))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list deleted keys: %w", err)
+	}
+
+	var purged int64
+	for _, key := range keys {
+		if err := s.purgeKey(ctx, key); err != nil {
+			return purged, fmt.Errorf("failed to purge key %s: %w", key.ID, err)
+		}
+		s.auditLog(ctx, key.OrgID, models.AuditEventKeyPurged, models.ResourceTypeKey, key.ID)
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeKey deletes a key's OpenBao material, for every version, and then
+// its database row.
+func (s *keyService) purgeKey(ctx context.Context, key *models.Key) error {
 	// Delete from OpenBao (skip if no BaoKeyPath - legacy key)
 	if key.BaoKeyPath != "" {
 		if err := s.baoKeyring.Delete(key.BaoKeyPath); err != nil {
@@ -353,19 +754,40 @@ func (s *keyService) Delete(ctx context.Context, orgID, keyID uuid.UUID) error {
 		}
 	}
 
-	// Soft delete in database
-	if err := s.keyRepo.SoftDelete(ctx, keyID); err != nil {
-		return fmt.Errorf("failed to delete key metadata: %w", err)
+	// Delete material retired by rotation
+	for v := 1; v < key.Version; v++ {
+		prev, err := s.keyRepo.GetVersion(ctx, key.ID, v)
+		if err != nil {
+			return fmt.Errorf("failed to get key version: %w", err)
+		}
+		if prev == nil {
+			continue
+		}
+		if err := s.baoKeyring.Delete(prev.BaoKeyPath); err != nil {
+			return fmt.Errorf("failed to delete version %d from OpenBao: %w", v, err)
+		}
 	}
 
-	// Audit log
-	s.auditLog(ctx, orgID, models.AuditEventKeyDeleted, models.ResourceTypeKey, keyID)
-
+	// Retired versions go with the row via ON DELETE CASCADE
+	if err := s.keyRepo.Delete(ctx, key.ID); err != nil {
+		return fmt.Errorf("failed to delete key metadata: %w", err)
+	}
 	return nil
 }
 
-// Sign signs data using a key.
+// Sign signs data using the current version of a key.
 func (s *keyService) Sign(ctx context.Context, orgID, keyID uuid.UUID, data []byte, prehashed bool) (*SignKeyResponse, error) {
+	return s.SignVersion(ctx, orgID, keyID, 0, data, prehashed)
+}
+
+// SignVersion signs data using a specific version of a key. Version 0 or the
+// current version signs with the current key material; a retired version may
+// only sign if it was rotated with AllowPreviousVersionSigning.
+func (s *keyService) SignVersion(ctx context.Context, orgID, keyID uuid.UUID, version int, data []byte, prehashed bool) (*SignKeyResponse, error) {
+	if err := s.validateSignData(data, prehashed); err != nil {
+		return nil, err
+	}
+
 	// Get key
 	key, err := s.keyRepo.GetByID(ctx, keyID)
 	if err != nil {
@@ -375,31 +797,171 @@ func (s *keyService) Sign(ctx context.Context, orgID, keyID uuid.UUID, data []by
 		return nil, apierrors.NewNotFoundError("Key")
 	}
 
+	// Resolve the key material to sign with
+	baoKeyPath := key.BaoKeyPath
+	if version == 0 {
+		version = key.Version
+	}
+	if version != key.Version {
+		prev, err := s.keyRepo.GetVersion(ctx, keyID, version)
+		if err != nil {
+			return nil, apierrors.NewInternalError(fmt.Sprintf("failed to get key version: %v", err))
+		}
+		if prev == nil {
+			return nil, apierrors.NewNotFoundError("Key version")
+		}
+		if !prev.SigningAllowed {
+			return nil, apierrors.ErrForbidden.WithMessage(
+				fmt.Sprintf("Key version %d was rotated and can no longer sign", version),
+			)
+		}
+		baoKeyPath = prev.BaoKeyPath
+	}
+
 	// Check signature quota
 	if err := s.checkSignatureQuota(ctx, orgID); err != nil {
 		return nil, err
 	}
 
-	// Sign via BaoKeyring
-	sig, pubKey, err := s.baoKeyring.Sign(key.BaoKeyPath, data)
-	if err != nil {
-		return nil, apierrors.NewInternalError(fmt.Sprintf("signing failed: %v", err))
+	// Record what was signed so audit entries can be matched to requests
+	requestHash := sha256.Sum256(data)
+	metadata := map[string]any{
+		"request_hash": hex.EncodeToString(requestHash[:]),
+		"prehashed":    prehashed,
+		"key_version":  version,
+	}
+
+	// Exact repeats, e.g. client retries, reuse the previous signature
+	cacheKey := signCacheKey(baoKeyPath, version, requestHash, prehashed)
+	sig, pubKey, cached := s.signCache.get(cacheKey)
+	if cached {
+		metadata["cached"] = true
+	} else {
+		// Wait for a free slot on the key, if signs per key are limited
+		release, err := s.signLimit.acquire(ctx, baoKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// Sign via BaoKeyring
+		sig, pubKey, err = s.baoKeyring.Sign(baoKeyPath, data, prehashed)
+		release()
+		if err != nil {
+			metadata["result"] = "error"
+			s.auditLogWithMetadata(ctx, orgID, models.AuditEventKeySignFailed, models.ResourceTypeKey, keyID, metadata)
+			return nil, apierrors.NewInternalError(fmt.Sprintf("signing failed: %v", err))
+		}
+		s.signCache.put(cacheKey, sig, pubKey)
+
+		// Increment usage counter
+		s.incrementUsage(ctx, orgID, "signatures", 1)
 	}
 
-	// Increment usage counter
-	s.incrementUsage(ctx, orgID, "signatures", 1)
+	// A failed last-used write must not fail the signature
+	if err := s.keyRepo.MarkUsed(ctx, keyID, time.Now()); err != nil {
+		slog.Warn("Failed to record key use",
+			slog.String("key_id", keyID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
 
 	// Audit log
-	s.auditLog(ctx, orgID, models.AuditEventKeySigned, models.ResourceTypeKey, keyID)
+	metadata["result"] = "success"
+	s.auditLogWithMetadata(ctx, orgID, models.AuditEventKeySigned, models.ResourceTypeKey, keyID, metadata)
+	s.publish(orgID, models.WebhookEventSignatureCompleted, map[string]any{
+		"key_id":       keyID,
+		"key_version":  version,
+		"request_hash": metadata["request_hash"],
+	})
 
 	return &SignKeyResponse{
-		KeyID:     keyID,
-		Signature: base64.StdEncoding.EncodeToString(sig),
-		PublicKey: hex.EncodeToString(pubKey),
+		KeyID:      keyID,
+		Signature:  base64.StdEncoding.EncodeToString(sig),
+		PublicKey:  hex.EncodeToString(pubKey),
+		KeyVersion: version,
 	}, nil
 }
 
-// SignBatch signs multiple messages in parallel.
+// validateSignData rejects oversized messages and prehashed input that isn't
+// a 32-byte digest, so bad requests never reach OpenBao.
+func (s *keyService) validateSignData(data []byte, prehashed bool) error {
+	if prehashed {
+		if len(data) != prehashedSize {
+			return ErrInvalidDigest
+		}
+		return nil
+	}
+	if len(data) > s.maxSignMessageSize {
+		return apierrors.NewValidationError("data", fmt.Sprintf("message is %d bytes, the maximum is %d", len(data), s.maxSignMessageSize))
+	}
+	return nil
+}
+
+// Rotate generates new key material in OpenBao and makes it the current
+// version of the key. The key ID, name and namespace are preserved; the
+// public key and addresses change because they derive from the new material.
+// The previous material is kept so it can be audited and, if allowed, still sign.
+func (s *keyService) Rotate(ctx context.Context, orgID, keyID uuid.UUID, opts RotateKeyOptions) (*models.Key, error) {
+	key, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	if key == nil || key.OrgID != orgID || key.DeletedAt != nil {
+		return nil, apierrors.NewNotFoundError("Key")
+	}
+
+	// The current version was created with the key, or by the last rotation
+	versionCreatedAt := key.CreatedAt
+	if key.RotatedAt != nil {
+		versionCreatedAt = *key.RotatedAt
+	}
+
+	previous := &models.KeyVersion{
+		KeyID:          key.ID,
+		Version:        key.Version,
+		BaoKeyPath:     key.BaoKeyPath,
+		PublicKey:      key.PublicKey,
+		Address:        key.Address,
+		EthAddress:     key.EthAddress,
+		SigningAllowed: opts.AllowPreviousVersionSigning,
+		CreatedAt:      versionCreatedAt,
+	}
+
+	// Each version gets its own OpenBao key
+	newVersion := key.Version + 1
+	baoKeyName := fmt.Sprintf("%s_v%d", baoKeyNameFor(key.OrgID, key.NamespaceID, key.ID, key.Name), newVersion)
+
+	pubKey, address, ethAddress, err := s.baoKeyring.NewAccountWithOptions(baoKeyName, KeyOptions{
+		Exportable: key.Exportable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key in OpenBao: %w", err)
+	}
+
+	key.PublicKey = pubKey
+	key.Address = address
+	key.EthAddress = &ethAddress
+	key.BaoKeyPath = baoKeyName
+	key.Version = newVersion
+
+	if err := s.keyRepo.Rotate(ctx, key, previous); err != nil {
+		// Cleanup OpenBao key on failure
+		_ = s.baoKeyring.Delete(baoKeyName)
+		return nil, fmt.Errorf("failed to save rotated key: %w", err)
+	}
+
+	s.auditLogWithMetadata(ctx, orgID, models.AuditEventKeyRotated, models.ResourceTypeKey, keyID, map[string]any{
+		"previous_version":         previous.Version,
+		"version":                  key.Version,
+		"previous_signing_allowed": previous.SigningAllowed,
+	})
+
+	return key, nil
+}
+
+// SignBatch signs multiple messages in parallel. If ctx is cancelled, the
+// signs already started finish and the rest are not sent to OpenBao; their
+// results carry the context error.
 func (s *keyService) SignBatch(ctx context.Context, req SignBatchKeyRequest) ([]*SignKeyResponse, error) {
 	// Check quota for all signatures
 	if err := s.checkSignatureQuota(ctx, req.OrgID); err != nil {
@@ -408,28 +970,25 @@ func (s *keyService) SignBatch(ctx context.Context, req SignBatchKeyRequest) ([]
 
 	// Sign in parallel (no head-of-line blocking!)
 	results := make([]*SignKeyResponse, len(req.Requests))
-	var wg sync.WaitGroup
-
-	for i, signReq := range req.Requests {
-		wg.Add(1)
-		go func(idx int, r SignKeyRequest) {
-			defer wg.Done()
 
-			data, err := base64.StdEncoding.DecodeString(r.Data)
-			if err != nil {
-				results[idx] = &SignKeyResponse{KeyID: r.KeyID, Error: "invalid base64"}
-				return
-			}
+	skipped := runBatch(ctx, len(req.Requests), func(idx int) {
+		r := req.Requests[idx]
+		data, err := base64.StdEncoding.DecodeString(r.Data)
+		if err != nil {
+			results[idx] = &SignKeyResponse{KeyID: r.KeyID, Error: "invalid base64"}
+			return
+		}
 
-			resp, err := s.Sign(ctx, req.OrgID, r.KeyID, data, r.Prehashed)
-			if err != nil {
-				results[idx] = &SignKeyResponse{KeyID: r.KeyID, Error: err.Error()}
-				return
-			}
-			results[idx] = resp
-		}(i, signReq)
+		resp, err := s.SignVersion(ctx, req.OrgID, r.KeyID, r.KeyVersion, data, r.Prehashed)
+		if err != nil {
+			results[idx] = &SignKeyResponse{KeyID: r.KeyID, Error: err.Error()}
+			return
+		}
+		results[idx] = resp
+	})
+	for _, idx := range skipped {
+		results[idx] = &SignKeyResponse{KeyID: req.Requests[idx].KeyID, Error: ctx.Err().Error()}
 	}
-	wg.Wait()
 
 	return results, nil
 }
@@ -451,7 +1010,8 @@ func (s *keyService) Import(ctx context.Context, req ImportKeyRequest) (*models.
 	}
 
 	// Generate unique OpenBao key name
-	baoKeyName := fmt.Sprintf("%s_%s_%s", req.OrgID, req.NamespaceID, req.Name)
+	keyID := uuid.New()
+	baoKeyName := baoKeyNameFor(req.OrgID, req.NamespaceID, keyID, req.Name)
 
 	// Import into BaoKeyring
 	pubKey, address, ethAddress, err := s.baoKeyring.ImportKey(baoKeyName, req.PrivateKey, req.Exportable)
@@ -461,7 +1021,7 @@ func (s *keyService) Import(ctx context.Context, req ImportKeyRequest) (*models.
 
 	// Save metadata to database
 	key := &models.Key{
-		ID:          uuid.New(),
+		ID:          keyID,
 		OrgID:       req.OrgID,
 		NamespaceID: req.NamespaceID,
 		Name:        req.Name,
@@ -481,12 +1041,67 @@ func (s *keyService) Import(ctx context.Context, req ImportKeyRequest) (*models.
 
 	// Audit log
 	s.auditLog(ctx, req.OrgID, models.AuditEventKeyCreated, models.ResourceTypeKey, key.ID)
+	s.publish(req.OrgID, models.WebhookEventKeyCreated, keyEventData(key))
 
 	return key, nil
 }
 
 // Export exports a key if it's exportable.
 func (s *keyService) Export(ctx context.Context, orgID, keyID uuid.UUID) (string, error) {
+	privateKey, err := s.exportKey(ctx, orgID, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	// Audit log
+	s.auditLog(ctx, orgID, models.AuditEventKeyExported, models.ResourceTypeKey, keyID)
+
+	return privateKey, nil
+}
+
+// ExportEncrypted exports an exportable key encrypted to an age X25519
+// recipient ("age1..."), for offline disaster-recovery storage. The private
+// key, in the same encoding Export returns, is encrypted before it leaves
+// the service, so only the holder of the recipient's identity can read the
+// returned ASCII-armored age file.
+func (s *keyService) ExportEncrypted(ctx context.Context, orgID, keyID uuid.UUID, recipient string) (string, error) {
+	ageRecipient, err := age.ParseX25519Recipient(strings.TrimSpace(recipient))
+	if err != nil {
+		return "", apierrors.NewValidationError("recipient", "must be an age X25519 public key (age1...)")
+	}
+
+	privateKey, err := s.exportKey(ctx, orgID, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	armorWriter := armor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, ageRecipient)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt exported key: %w", err)
+	}
+	if _, err := io.WriteString(w, privateKey); err != nil {
+		return "", fmt.Errorf("failed to encrypt exported key: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to encrypt exported key: %w", err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to encrypt exported key: %w", err)
+	}
+
+	// Audit log, recording who can decrypt the export
+	s.auditLogWithMetadata(ctx, orgID, models.AuditEventKeyExported, models.ResourceTypeKey, keyID, map[string]any{
+		"encryption": "age",
+		"recipient":  ageRecipient.String(),
+	})
+
+	return buf.String(), nil
+}
+
+// exportKey reads the private key of an exportable key from OpenBao.
+func (s *keyService) exportKey(ctx context.Context, orgID, keyID uuid.UUID) (string, error) {
 	key, err := s.keyRepo.GetByID(ctx, keyID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get key: %w", err)
@@ -504,10 +1119,6 @@ func (s *keyService) Export(ctx context.Context, orgID, keyID uuid.UUID) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to export key: %w", err)
 	}
-
-	// Audit log
-	s.auditLog(ctx, orgID, models.AuditEventKeyExported, models.ResourceTypeKey, keyID)
-
 	return privateKey, nil
 }
 
@@ -533,6 +1144,7 @@ func (s *keyService) checkKeyQuota(ctx context.Context, orgID uuid.UUID) error {
 	}
 
 	if count >= limits.Keys {
+		s.publishQuotaExceeded(orgID, "keys", int64(limits.Keys))
 		return apierrors.ErrQuotaExceeded.WithMessage(
 			fmt.Sprintf("You've reached your limit of %d keys", limits.Keys),
 		)
@@ -560,6 +1172,7 @@ func (s *keyService) checkSignatureQuota(ctx context.Context, orgID uuid.UUID) e
 	}
 
 	if usage >= limits.SignaturesPerMonth {
+		s.publishQuotaExceeded(orgID, "signatures_per_month", limits.SignaturesPerMonth)
 		return apierrors.ErrQuotaExceeded.WithMessage(
 			fmt.Sprintf("You've reached your limit of %d signatures per month", limits.SignaturesPerMonth),
 		)
@@ -575,6 +1188,16 @@ func (s *keyService) incrementUsage(ctx context.Context, orgID uuid.UUID, metric
 }
 
 func (s *keyService) auditLog(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceType models.ResourceType, resourceID uuid.UUID) {
+	s.auditLogWithMetadata(ctx, orgID, event, resourceType, resourceID, nil)
+}
+
+// auditLogWithMetadata creates an audit log entry carrying additional metadata.
+func (s *keyService) auditLogWithMetadata(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceType models.ResourceType, resourceID uuid.UUID, metadata map[string]any) {
+	var raw json.RawMessage
+	if len(metadata) > 0 {
+		raw, _ = json.Marshal(metadata)
+	}
+
 	// Run asynchronously to not block the request
 	go func() {
 		_ = s.auditRepo.Create(context.Background(), &models.AuditLog{
@@ -583,9 +1206,49 @@ func (s *keyService) auditLog(ctx context.Context, orgID uuid.UUID, event models
 			ActorType:    models.ActorTypeAPIKey, // Default to API key, can be overridden
 			ResourceType: &resourceType,
 			ResourceID:   &resourceID,
+			Metadata:     raw,
 		})
 	}()
 }
 
+// publish sends an event to subscribers, if an event publisher is configured.
+func (s *keyService) publish(orgID uuid.UUID, event models.WebhookEvent, data any) {
+	if s.events == nil {
+		return
+	}
+	// Run asynchronously to not block the request
+	go func() {
+		_ = s.events.Deliver(context.Background(), orgID, event, data)
+	}()
+}
+
+func (s *keyService) publishQuotaExceeded(orgID uuid.UUID, quota string, limit int64) {
+	s.publish(orgID, models.WebhookEventQuotaExceeded, map[string]any{
+		"quota": quota,
+		"limit": limit,
+	})
+}
+
+// baoKeyNameFor returns the OpenBao key name for a key. It includes the key
+// ID, so a name can be reused while a soft-deleted key holding it still has
+// its OpenBao key.
+func baoKeyNameFor(orgID, namespaceID, keyID uuid.UUID, name string) string {
+	return fmt.Sprintf("%s_%s_%s_%s", orgID, namespaceID, keyID, name)
+}
+
+// keyEventData is the webhook payload describing a key.
+func keyEventData(key *models.Key) map[string]any {
+	data := map[string]any{
+		"key_id":       key.ID,
+		"name":         key.Name,
+		"namespace_id": key.NamespaceID,
+		"address":      key.Address,
+	}
+	if key.EthAddress != nil {
+		data["eth_address"] = *key.EthAddress
+	}
+	return data
+}
+
 // Compile-time check to ensure keyService implements KeyService.
 var _ KeyService = (*keyService)(nil)
@@ -2,11 +2,21 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"filippo.io/age"
+	"filippo.io/age/armor"
 	"github.com/google/uuid"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
@@ -17,13 +27,17 @@ import (
 
 type mockKeyRepo struct {
 	keys     map[uuid.UUID]*models.Key
-	byOrgKey map[string]*models.Key // orgID_namespaceID_name -> key
+	byOrgKey map[string]*models.Key        // orgID_namespaceID_name -> key
+	versions map[string]*models.KeyVersion // keyID_version -> retired version
+
+	createBatchErr error // returned by CreateBatch when set
 }
 
 func newMockKeyRepo() *mockKeyRepo {
 	return &mockKeyRepo{
 		keys:     make(map[uuid.UUID]*models.Key),
 		byOrgKey: make(map[string]*models.Key),
+		versions: make(map[string]*models.KeyVersion),
 	}
 }
 
@@ -31,6 +45,9 @@ func (m *mockKeyRepo) Create(ctx context.Context, key *models.Key) error {
 	if key.ID == uuid.Nil {
 		key.ID = uuid.New()
 	}
+	if key.Version == 0 {
+		key.Version = 1
+	}
 	key.CreatedAt = time.Now()
 	key.UpdatedAt = key.CreatedAt
 	m.keys[key.ID] = key
@@ -39,13 +56,26 @@ func (m *mockKeyRepo) Create(ctx context.Context, key *models.Key) error {
 	return nil
 }
 
+func (m *mockKeyRepo) CreateBatch(ctx context.Context, keys []*models.Key) error {
+	if m.createBatchErr != nil {
+		return m.createBatchErr
+	}
+	for _, key := range keys {
+		_ = m.Create(ctx, key)
+	}
+	return nil
+}
+
 func (m *mockKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Key, error) {
 	return m.keys[id], nil
 }
 
 func (m *mockKeyRepo) GetByName(ctx context.Context, orgID, namespaceID uuid.UUID, name string) (*models.Key, error) {
 	keyStr := orgID.String() + "_" + namespaceID.String() + "_" + name
-	return m.byOrgKey[keyStr], nil
+	if key, ok := m.byOrgKey[keyStr]; ok && key.DeletedAt == nil {
+		return key, nil
+	}
+	return nil, nil
 }
 
 func (m *mockKeyRepo) GetByAddress(ctx context.Context, orgID uuid.UUID, address string) (*models.Key, error) {
@@ -110,6 +140,101 @@ func (m *mockKeyRepo) ListByNamespace(ctx context.Context, namespaceID uuid.UUID
 	return result, nil
 }
 
+func (m *mockKeyRepo) ListPage(ctx context.Context, q models.KeyListQuery) ([]*models.Key, error) {
+	var result []*models.Key
+	for _, key := range m.keys {
+		if key.OrgID != q.OrgID || key.DeletedAt != nil {
+			continue
+		}
+		if q.NamespaceID != nil && key.NamespaceID != *q.NamespaceID {
+			continue
+		}
+		if q.NetworkType != nil && *q.NetworkType != models.NetworkTypeAll &&
+			key.NetworkType != *q.NetworkType && key.NetworkType != models.NetworkTypeAll {
+			continue
+		}
+		if q.NamePrefix != "" && !strings.HasPrefix(key.Name, q.NamePrefix) {
+			continue
+		}
+		if q.LabelKey != "" {
+			var metadata map[string]string
+			_ = json.Unmarshal(key.Metadata, &metadata)
+			v, ok := metadata[q.LabelKey]
+			if !ok || (q.LabelValue != "" && v != q.LabelValue) {
+				continue
+			}
+		}
+		if q.AfterCreatedAt != nil && q.AfterID != nil {
+			if key.CreatedAt.After(*q.AfterCreatedAt) ||
+				(key.CreatedAt.Equal(*q.AfterCreatedAt) && key.ID.String() >= q.AfterID.String()) {
+				continue
+			}
+		}
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].ID.String() > result[j].ID.String()
+	})
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result, nil
+}
+
+func (m *mockKeyRepo) ListByOrgPaged(ctx context.Context, q models.KeyPageQuery) ([]*models.Key, int, error) {
+	search := strings.ToLower(q.Search)
+	var result []*models.Key
+	for _, key := range m.keys {
+		if key.OrgID != q.OrgID || key.DeletedAt != nil {
+			continue
+		}
+		if q.NamespaceID != nil && key.NamespaceID != *q.NamespaceID {
+			continue
+		}
+		if q.NetworkType != nil && *q.NetworkType != models.NetworkTypeAll &&
+			key.NetworkType != *q.NetworkType && key.NetworkType != models.NetworkTypeAll {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(key.Name), search) &&
+			!strings.Contains(strings.ToLower(key.Address), search) &&
+			(key.EthAddress == nil || !strings.Contains(strings.ToLower(*key.EthAddress), search)) {
+			continue
+		}
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch q.Sort {
+		case models.KeySortName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID.String() < b.ID.String()
+		case models.KeySortLastUsed:
+			if (a.LastUsedAt == nil) != (b.LastUsedAt == nil) {
+				return a.LastUsedAt != nil
+			}
+			if a.LastUsedAt != nil && !a.LastUsedAt.Equal(*b.LastUsedAt) {
+				return a.LastUsedAt.After(*b.LastUsedAt)
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+		return a.ID.String() > b.ID.String()
+	})
+	total := len(result)
+	result = result[min(q.Offset, total):]
+	if q.Limit > 0 && len(result) > q.Limit {
+		result = result[:q.Limit]
+	}
+	return result, total, nil
+}
+
 func (m *mockKeyRepo) CountByOrg(ctx context.Context, orgID uuid.UUID) (int, error) {
 	count := 0
 	for _, key := range m.keys {
@@ -125,6 +250,26 @@ func (m *mockKeyRepo) Update(ctx context.Context, key *models.Key) error {
 	return nil
 }
 
+func (m *mockKeyRepo) Rotate(ctx context.Context, key *models.Key, previous *models.KeyVersion) error {
+	previous.RetiredAt = time.Now()
+	m.versions[fmt.Sprintf("%s_%d", previous.KeyID, previous.Version)] = previous
+	key.UpdatedAt = previous.RetiredAt
+	key.RotatedAt = &previous.RetiredAt
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *mockKeyRepo) MarkUsed(ctx context.Context, id uuid.UUID, t time.Time) error {
+	if key, ok := m.keys[id]; ok {
+		key.LastUsedAt = &t
+	}
+	return nil
+}
+
+func (m *mockKeyRepo) GetVersion(ctx context.Context, keyID uuid.UUID, version int) (*models.KeyVersion, error) {
+	return m.versions[fmt.Sprintf("%s_%d", keyID, version)], nil
+}
+
 func (m *mockKeyRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	if key, ok := m.keys[id]; ok {
 		now := time.Now()
@@ -133,6 +278,23 @@ func (m *mockKeyRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (m *mockKeyRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	if key, ok := m.keys[id]; ok {
+		key.DeletedAt = nil
+	}
+	return nil
+}
+
+func (m *mockKeyRepo) ListDeletedBefore(ctx context.Context, before time.Time) ([]*models.Key, error) {
+	var result []*models.Key
+	for _, key := range m.keys {
+		if key.DeletedAt != nil && key.DeletedAt.Before(before) {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockKeyRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	delete(m.keys, id)
 	return nil
@@ -396,7 +558,10 @@ func (m *mockOrgRepo) UpdatePlan(ctx context.Context, orgID uuid.UUID, plan mode
 	return nil
 }
 
+// mockAuditRepo is safe for concurrent use because the key service writes
+// audit logs asynchronously.
 type mockAuditRepo struct {
+	mu   sync.Mutex
 	logs []*models.AuditLog
 }
 
@@ -405,6 +570,9 @@ func newMockAuditRepo() *mockAuditRepo {
 }
 
 func (m *mockAuditRepo) Create(ctx context.Context, log *models.AuditLog) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if log.ID == uuid.Nil {
 		log.ID = uuid.New()
 	}
@@ -414,6 +582,9 @@ func (m *mockAuditRepo) Create(ctx context.Context, log *models.AuditLog) error
 }
 
 func (m *mockAuditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.AuditLog, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	for _, log := range m.logs {
 		if log.ID == id {
 			return log, nil
@@ -423,6 +594,9 @@ func (m *mockAuditRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Audi
 }
 
 func (m *mockAuditRepo) List(ctx context.Context, query models.AuditLogQuery) ([]*models.AuditLog, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	var result []*models.AuditLog
 	for _, log := range m.logs {
 		if log.OrgID == query.OrgID {
@@ -437,6 +611,9 @@ func (m *mockAuditRepo) DeleteBefore(ctx context.Context, orgID uuid.UUID, befor
 }
 
 func (m *mockAuditRepo) CountByOrgAndPeriod(ctx context.Context, orgID uuid.UUID, start, end time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	var count int64
 	for _, log := range m.logs {
 		if log.OrgID == orgID && !log.CreatedAt.Before(start) && !log.CreatedAt.After(end) {
@@ -446,6 +623,37 @@ func (m *mockAuditRepo) CountByOrgAndPeriod(ctx context.Context, orgID uuid.UUID
 	return count, nil
 }
 
+func (m *mockAuditRepo) CountByResourceAndPeriod(ctx context.Context, orgID uuid.UUID, event models.AuditEvent, resourceID uuid.UUID, start, end time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for _, log := range m.logs {
+		if log.OrgID == orgID && log.Event == event && log.ResourceID != nil && *log.ResourceID == resourceID &&
+			!log.CreatedAt.Before(start) && !log.CreatedAt.After(end) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// waitForEvent waits briefly for an audit log with the given event.
+func (m *mockAuditRepo) waitForEvent(event models.AuditEvent) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.mu.Lock()
+		for _, log := range m.logs {
+			if log.Event == event {
+				m.mu.Unlock()
+				return true
+			}
+		}
+		m.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
 type mockUsageRepo struct {
 	usage map[string]int64 // orgID_metric -> value
 }
@@ -474,14 +682,27 @@ func (m *mockUsageRepo) ListByOrg(ctx context.Context, orgID uuid.UUID, periodSt
 }
 
 func (m *mockUsageRepo) GetSummary(ctx context.Context, orgID uuid.UUID, plan models.Plan) (*models.UsageSummary, error) {
-	return nil, nil
+	limits := models.GetPlanLimits(plan)
+	start, end := models.BillingPeriod(time.Now())
+	return &models.UsageSummary{
+		OrgID:           orgID,
+		Plan:            plan,
+		KeysLimit:       limits.Keys,
+		SignaturesMonth: m.usage[orgID.String()+"_signatures"],
+		SignaturesLimit: limits.SignaturesPerMonth,
+		PeriodStart:     start,
+		PeriodEnd:       end,
+	}, nil
 }
 
 // --- Mock BaoKeyring ---
 
 type mockBaoKeyring struct {
+	mu        sync.Mutex
 	keys      map[string]*mockBaoKey
 	signCount int
+
+	newAccountErr func(uid string) error // fails NewAccountWithOptions when it returns an error
 }
 
 type mockBaoKey struct {
@@ -498,6 +719,12 @@ func newMockBaoKeyring() *mockBaoKeyring {
 }
 
 func (m *mockBaoKeyring) NewAccountWithOptions(uid string, opts KeyOptions) ([]byte, string, string, error) {
+	if m.newAccountErr != nil {
+		if err := m.newAccountErr(uid); err != nil {
+			return nil, "", "", err
+		}
+	}
+
 	// Generate a mock 33-byte compressed secp256k1 public key
 	pubKey := make([]byte, 33)
 	pubKey[0] = 0x02 // compressed format prefix
@@ -520,12 +747,14 @@ func (m *mockBaoKeyring) NewAccountWithOptions(uid string, opts KeyOptions) ([]b
 		exportable: opts.Exportable,
 		privateKey: base64.StdEncoding.EncodeToString([]byte("mock_private_key_" + uid)),
 	}
+	m.mu.Lock()
 	m.keys[uid] = key
+	m.mu.Unlock()
 
 	return pubKey, address, ethAddress, nil
 }
 
-func (m *mockBaoKeyring) Sign(uid string, msg []byte) ([]byte, []byte, error) {
+func (m *mockBaoKeyring) Sign(uid string, msg []byte, prehashed bool) ([]byte, []byte, error) {
 	key, ok := m.keys[uid]
 	if !ok {
 		return nil, nil, apierrors.NewNotFoundError("Key")
@@ -542,6 +771,8 @@ func (m *mockBaoKeyring) Sign(uid string, msg []byte) ([]byte, []byte, error) {
 }
 
 func (m *mockBaoKeyring) Delete(uid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.keys, uid)
 	return nil
 }
@@ -932,6 +1163,204 @@ func TestKeyService_List(t *testing.T) {
 	})
 }
 
+func TestKeyService_ListPage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pages through all keys without duplicates", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		const total, pageSize = 23, 5
+		for i := 0; i < total; i++ {
+			if _, err := ts.svc.Create(ctx, CreateKeyRequest{
+				OrgID:       orgID,
+				NamespaceID: nsID,
+				Name:        fmt.Sprintf("key-%d", i),
+			}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		seen := make(map[uuid.UUID]bool)
+		cursor := ""
+		pages := 0
+		for {
+			keys, next, err := ts.svc.ListPage(ctx, orgID, KeyListFilter{Limit: pageSize, Cursor: cursor})
+			if err != nil {
+				t.Fatalf("ListPage() error = %v", err)
+			}
+			pages++
+			if len(keys) > pageSize {
+				t.Fatalf("ListPage() returned %d keys, want at most %d", len(keys), pageSize)
+			}
+			for _, k := range keys {
+				if seen[k.ID] {
+					t.Errorf("key %s returned twice", k.ID)
+				}
+				seen[k.ID] = true
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		if len(seen) != total {
+			t.Errorf("saw %d keys, want %d", len(seen), total)
+		}
+		if pages != 5 {
+			t.Errorf("pages = %d, want 5", pages)
+		}
+	})
+
+	t.Run("filters by name prefix and label", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "worker-1", Metadata: map[string]string{"env": "prod"}})
+		ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "worker-2", Metadata: map[string]string{"env": "dev"}})
+		ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "sequencer", Metadata: map[string]string{"env": "prod"}})
+
+		keys, _, err := ts.svc.ListPage(ctx, orgID, KeyListFilter{NamePrefix: "worker-"})
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if len(keys) != 2 {
+			t.Errorf("name prefix returned %d keys, want 2", len(keys))
+		}
+
+		keys, _, err = ts.svc.ListPage(ctx, orgID, KeyListFilter{NamePrefix: "worker-", Label: "env=prod"})
+		if err != nil {
+			t.Fatalf("ListPage() error = %v", err)
+		}
+		if len(keys) != 1 || keys[0].Name != "worker-1" {
+			t.Errorf("prefix+label returned %v, want [worker-1]", keys)
+		}
+	})
+
+	t.Run("rejects invalid cursor", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, _ := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		_, _, err := ts.svc.ListPage(ctx, orgID, KeyListFilter{Cursor: "not-a-cursor"})
+		if err == nil {
+			t.Fatal("ListPage() expected error for invalid cursor")
+		}
+	})
+}
+
+func TestKeyService_ListByOrgPaged(t *testing.T) {
+	ctx := context.Background()
+
+	names := func(keys []*models.Key) []string {
+		var out []string
+		for _, k := range keys {
+			out = append(out, k.Name)
+		}
+		return out
+	}
+
+	ts := newTestKeyService()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("worker-%02d", i)
+		if i%3 == 0 {
+			name = fmt.Sprintf("sequencer-%02d", i)
+		}
+		if _, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: name}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	t.Run("pages by name", func(t *testing.T) {
+		want := [][]string{
+			{"sequencer-00", "sequencer-03", "sequencer-06", "sequencer-09", "worker-01"},
+			{"worker-02", "worker-04", "worker-05", "worker-07", "worker-08"},
+			{"worker-10", "worker-11"},
+		}
+		for i, wantNames := range want {
+			page, err := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Sort: models.KeySortName, Page: i + 1, Size: 5})
+			if err != nil {
+				t.Fatalf("ListByOrgPaged() error = %v", err)
+			}
+			if got := names(page.Keys); fmt.Sprint(got) != fmt.Sprint(wantNames) {
+				t.Errorf("page %d = %v, want %v", i+1, got, wantNames)
+			}
+			if page.Total != 12 || page.Pages() != 3 {
+				t.Errorf("page %d total = %d (%d pages), want 12 (3 pages)", i+1, page.Total, page.Pages())
+			}
+		}
+	})
+
+	t.Run("searches by name", func(t *testing.T) {
+		page, err := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Search: " SEQUENCER ", Sort: models.KeySortName, Size: 3})
+		if err != nil {
+			t.Fatalf("ListByOrgPaged() error = %v", err)
+		}
+		if got := names(page.Keys); fmt.Sprint(got) != "[sequencer-00 sequencer-03 sequencer-06]" {
+			t.Errorf("first page = %v, want the first 3 sequencers", got)
+		}
+		if page.Total != 4 {
+			t.Errorf("total = %d, want 4", page.Total)
+		}
+
+		page, err = ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Search: "sequencer", Sort: models.KeySortName, Page: 2, Size: 3})
+		if err != nil {
+			t.Fatalf("ListByOrgPaged() error = %v", err)
+		}
+		if got := names(page.Keys); fmt.Sprint(got) != "[sequencer-09]" {
+			t.Errorf("second page = %v, want [sequencer-09]", got)
+		}
+
+		// A page past the end falls back to the last page
+		page, err = ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Search: "sequencer", Sort: models.KeySortName, Page: 5, Size: 3})
+		if err != nil {
+			t.Fatalf("ListByOrgPaged() error = %v", err)
+		}
+		if got := names(page.Keys); page.Page != 2 || fmt.Sprint(got) != "[sequencer-09]" {
+			t.Errorf("page 5 = page %d %v, want page 2 [sequencer-09]", page.Page, got)
+		}
+	})
+
+	t.Run("defaults page, size and sort", func(t *testing.T) {
+		page, err := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Sort: "bogus", Page: -1, Size: MaxKeyPageSize + 1})
+		if err != nil {
+			t.Fatalf("ListByOrgPaged() error = %v", err)
+		}
+		if page.Page != 1 || page.Size != DefaultKeyPageSize || page.Sort != models.KeySortCreated {
+			t.Errorf("page = %d, size = %d, sort = %q; want 1, %d, %q", page.Page, page.Size, page.Sort, DefaultKeyPageSize, models.KeySortCreated)
+		}
+		if len(page.Keys) != 12 {
+			t.Errorf("returned %d keys, want 12", len(page.Keys))
+		}
+	})
+
+	t.Run("sorts by last used", func(t *testing.T) {
+		all, _ := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Sort: models.KeySortName, Size: MaxKeyPageSize})
+		now := time.Now()
+		older, newer := now.Add(-time.Hour), now
+		all.Keys[5].LastUsedAt = &older
+		all.Keys[7].LastUsedAt = &newer
+
+		page, err := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{Sort: models.KeySortLastUsed, Size: 2})
+		if err != nil {
+			t.Fatalf("ListByOrgPaged() error = %v", err)
+		}
+		if got := names(page.Keys); fmt.Sprint(got) != "[worker-05 worker-02]" {
+			t.Errorf("last used page = %v, want [worker-05 worker-02]", got)
+		}
+	})
+
+	t.Run("rejects namespace of another org", func(t *testing.T) {
+		_, otherNS := ts.createTestOrgAndNamespace(models.PlanPro)
+		_, err := ts.svc.ListByOrgPaged(ctx, orgID, KeyPageFilter{NamespaceID: &otherNS})
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("ListByOrgPaged() error = %v, want not found", err)
+		}
+	})
+}
+
 func TestKeyService_Sign(t *testing.T) {
 	ctx := context.Background()
 
@@ -962,6 +1391,80 @@ func TestKeyService_Sign(t *testing.T) {
 		if resp.PublicKey == "" {
 			t.Error("PublicKey is empty")
 		}
+
+		got, _ := ts.keyRepo.GetByID(ctx, key.ID)
+		if got.LastUsedAt == nil {
+			t.Error("LastUsedAt was not recorded")
+		}
+	})
+
+	t.Run("rejects oversized messages before signing", func(t *testing.T) {
+		ts := newTestKeyService()
+		svc := NewKeyService(ts.keyRepo, ts.orgRepo, ts.auditRepo, ts.usageRepo, ts.baoKeyring,
+			WithMaxSignMessageSize(1024))
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+		key, _ := svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "size-key"})
+
+		_, err := svc.Sign(ctx, orgID, key.ID, make([]byte, 1025), false)
+		var apiErr *apierrors.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Sign() error = %v, want validation error", err)
+		}
+		if ts.baoKeyring.signCount != 0 {
+			t.Errorf("backend signs = %d, want 0", ts.baoKeyring.signCount)
+		}
+
+		if _, err := svc.Sign(ctx, orgID, key.ID, make([]byte, 1024), false); err != nil {
+			t.Errorf("Sign() at the limit error = %v", err)
+		}
+	})
+
+	t.Run("requires 32-byte prehashed input", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "digest-key"})
+
+		for _, n := range []int{0, 31, 33, 64} {
+			if _, err := ts.svc.Sign(ctx, orgID, key.ID, make([]byte, n), true); !errors.Is(err, ErrInvalidDigest) {
+				t.Errorf("Sign() with %d-byte prehashed input error = %v, want ErrInvalidDigest", n, err)
+			}
+		}
+		if ts.baoKeyring.signCount != 0 {
+			t.Errorf("backend signs = %d, want 0", ts.baoKeyring.signCount)
+		}
+
+		digest := sha256.Sum256([]byte("hello world"))
+		if _, err := ts.svc.Sign(ctx, orgID, key.ID, digest[:], true); err != nil {
+			t.Errorf("Sign() with 32-byte digest error = %v", err)
+		}
+	})
+
+	t.Run("does not record use on failure", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "fail-key"})
+		delete(ts.baoKeyring.keys, key.BaoKeyPath)
+
+		if _, err := ts.svc.Sign(ctx, orgID, key.ID, []byte("data"), false); err == nil {
+			t.Fatal("Sign() expected error")
+		}
+
+		got, _ := ts.keyRepo.GetByID(ctx, key.ID)
+		if got.LastUsedAt != nil {
+			t.Errorf("LastUsedAt = %v, want nil", got.LastUsedAt)
+		}
+
+		if !ts.auditRepo.waitForEvent(models.AuditEventKeySignFailed) {
+			t.Errorf("failed sign was not audited as %q", models.AuditEventKeySignFailed)
+		}
+		ts.auditRepo.mu.Lock()
+		defer ts.auditRepo.mu.Unlock()
+		for _, log := range ts.auditRepo.logs {
+			if log.Event == models.AuditEventKeySigned {
+				t.Errorf("failed sign was audited as %q", log.Event)
+			}
+		}
 	})
 
 	t.Run("enforces signature quota", func(t *testing.T) {
@@ -1042,68 +1545,578 @@ func TestKeyService_Delete(t *testing.T) {
 			t.Error("Get() expected error for deleted key")
 		}
 	})
-}
-
-func TestKeyService_BatchCreate(t *testing.T) {
-	ctx := context.Background()
 
-	t.Run("batch creates keys", func(t *testing.T) {
+	t.Run("deleted key cannot sign", func(t *testing.T) {
 		ts := newTestKeyService()
-		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
 
-		keys, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
-			OrgID:       orgID,
-			NamespaceID: nsID,
-			Prefix:      "worker",
-			Count:       4,
-		})
-		if err != nil {
-			t.Fatalf("CreateBatch() error = %v", err)
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "delete-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
 		}
 
-		if len(keys) != 4 {
-			t.Errorf("CreateBatch() created %d keys, want 4", len(keys))
+		_, err := ts.svc.Sign(ctx, orgID, key.ID, []byte("hello world"), false)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Sign() error = %v, want not found", err)
 		}
-
-		// Verify names
-		for i, key := range keys {
-			expectedName := "worker-" + string(rune('1'+i))
-			if key.Name != expectedName {
-				t.Errorf("Key %d name = %v, want %v", i, key.Name, expectedName)
-			}
+		if ts.baoKeyring.signCount != 0 {
+			t.Errorf("OpenBao signed %d times for a deleted key", ts.baoKeyring.signCount)
 		}
 	})
 
-	t.Run("enforces quota in batch", func(t *testing.T) {
+	t.Run("name can be reused while deleted key awaits purge", func(t *testing.T) {
 		ts := newTestKeyService()
-		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanFree) // 3 key limit
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
 
-		_, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
-			OrgID:       orgID,
-			NamespaceID: nsID,
-			Prefix:      "worker",
-			Count:       5,
-		})
-		if err == nil {
-			t.Error("CreateBatch() expected quota error")
+		deleted, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "reused"})
+		if err := ts.svc.Delete(ctx, orgID, deleted.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
 		}
-	})
-}
 
-func TestKeyService_SignBatch(t *testing.T) {
-	ctx := context.Background()
+		ts.baoKeyring.newAccountErr = func(uid string) error {
+			if _, ok := ts.baoKeyring.keys[uid]; ok {
+				return errors.New("key already exists")
+			}
+			return nil
+		}
+
+		key, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "reused"})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if key.BaoKeyPath == deleted.BaoKeyPath {
+			t.Error("re-created key shares the deleted key's OpenBao key")
+		}
+		if _, ok := ts.baoKeyring.keys[deleted.BaoKeyPath]; !ok {
+			t.Error("deleted key's OpenBao key was replaced before purge")
+		}
+	})
+
+	t.Run("rejects deleting a deleted key", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "delete-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		err := ts.svc.Delete(ctx, orgID, key.ID)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Delete() error = %v, want not found", err)
+		}
+	})
+}
+
+func TestKeyService_Restore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("restores key before purge", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		// Purging inside the grace period leaves the key alone
+		purged, err := ts.svc.PurgeDeletedKeys(ctx)
+		if err != nil {
+			t.Fatalf("PurgeDeletedKeys() error = %v", err)
+		}
+		if purged != 0 {
+			t.Errorf("PurgeDeletedKeys() = %d, want 0", purged)
+		}
+
+		restored, err := ts.svc.Restore(ctx, orgID, key.ID)
+		if err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if restored.DeletedAt != nil {
+			t.Error("DeletedAt is set after restore")
+		}
+
+		keys, _ := ts.svc.List(ctx, orgID, nil, nil)
+		if len(keys) != 1 {
+			t.Errorf("List() returned %d keys after restore, want 1", len(keys))
+		}
+		if _, err := ts.svc.Sign(ctx, orgID, key.ID, []byte("hello world"), false); err != nil {
+			t.Errorf("Sign() after restore error = %v", err)
+		}
+	})
+
+	t.Run("rejects restore after grace period", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		deletedAt := time.Now().Add(-DefaultKeyDeletionGracePeriod - time.Minute)
+		ts.keyRepo.keys[key.ID].DeletedAt = &deletedAt
+
+		_, err := ts.svc.Restore(ctx, orgID, key.ID)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Restore() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("rejects restore of a key that is not deleted", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"})
+
+		_, err := ts.svc.Restore(ctx, orgID, key.ID)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("Restore() error = %v, want not found", err)
+		}
+	})
+
+	t.Run("rejects restore for wrong org", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+		otherOrgID, _ := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		if _, err := ts.svc.Restore(ctx, otherOrgID, key.ID); err == nil {
+			t.Error("Restore() expected error for wrong org")
+		}
+	})
+
+	t.Run("rejects restore when the name was reused", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"})
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "restore-key"}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		_, err := ts.svc.Restore(ctx, orgID, key.ID)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusConflict {
+			t.Errorf("Restore() error = %v, want conflict", err)
+		}
+	})
+}
+
+func TestKeyService_PurgeDeletedKeys(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestKeyService()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	expired, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "expired-key"})
+	recent, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "recent-key"})
+	for _, key := range []*models.Key{expired, recent} {
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	deletedAt := time.Now().Add(-DefaultKeyDeletionGracePeriod - time.Minute)
+	ts.keyRepo.keys[expired.ID].DeletedAt = &deletedAt
+
+	purged, err := ts.svc.PurgeDeletedKeys(ctx)
+	if err != nil {
+		t.Fatalf("PurgeDeletedKeys() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeDeletedKeys() = %d, want 1", purged)
+	}
+	if _, ok := ts.keyRepo.keys[expired.ID]; ok {
+		t.Error("expired key still in database after purge")
+	}
+	if _, ok := ts.baoKeyring.keys[expired.BaoKeyPath]; ok {
+		t.Error("expired key still in OpenBao after purge")
+	}
+	if _, ok := ts.keyRepo.keys[recent.ID]; !ok {
+		t.Error("recently deleted key was purged")
+	}
+	if _, ok := ts.baoKeyring.keys[recent.BaoKeyPath]; !ok {
+		t.Error("recently deleted key material was purged")
+	}
+}
+
+// recordingPublisher collects published events.
+type recordingPublisher struct {
+	events chan models.WebhookEvent
+}
+
+func (p *recordingPublisher) Deliver(ctx context.Context, orgID uuid.UUID, event models.WebhookEvent, payload any) error {
+	p.events <- event
+	return nil
+}
+
+func (p *recordingPublisher) next(t *testing.T) models.WebhookEvent {
+	t.Helper()
+	select {
+	case event := <-p.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return ""
+	}
+}
+
+func TestKeyService_Events(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestKeyService()
+	publisher := &recordingPublisher{events: make(chan models.WebhookEvent, 10)}
+	ts.svc = NewKeyService(ts.keyRepo, ts.orgRepo, ts.auditRepo, ts.usageRepo, ts.baoKeyring, WithEventPublisher(publisher))
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanFree) // 3 key limit
+
+	key, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "events-key"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := publisher.next(t); got != models.WebhookEventKeyCreated {
+		t.Errorf("event = %v, want %v", got, models.WebhookEventKeyCreated)
+	}
+
+	if _, err := ts.svc.Sign(ctx, orgID, key.ID, []byte("data"), false); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if got := publisher.next(t); got != models.WebhookEventSignatureCompleted {
+		t.Errorf("event = %v, want %v", got, models.WebhookEventSignatureCompleted)
+	}
+
+	if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := publisher.next(t); got != models.WebhookEventKeyDeleted {
+		t.Errorf("event = %v, want %v", got, models.WebhookEventKeyDeleted)
+	}
+
+	if _, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{OrgID: orgID, NamespaceID: nsID, Prefix: "batch", Count: 4}); err == nil {
+		t.Fatal("CreateBatch() expected quota error")
+	}
+	if got := publisher.next(t); got != models.WebhookEventQuotaExceeded {
+		t.Errorf("event = %v, want %v", got, models.WebhookEventQuotaExceeded)
+	}
+}
+
+func TestKeyService_BatchCreate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("batch creates keys", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+
+		result, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       4,
+		})
+		if err != nil {
+			t.Fatalf("CreateBatch() error = %v", err)
+		}
+
+		if len(result.Keys) != 4 {
+			t.Errorf("CreateBatch() created %d keys, want 4", len(result.Keys))
+		}
+		if len(result.Failed) != 0 {
+			t.Errorf("CreateBatch() failed = %v, want none", result.Failed)
+		}
+
+		// Verify names and addresses
+		for i, key := range result.Keys {
+			expectedName := "worker-" + string(rune('1'+i))
+			if key.Name != expectedName {
+				t.Errorf("Key %d name = %v, want %v", i, key.Name, expectedName)
+			}
+			if key.Address == "" || key.EthAddress == nil || *key.EthAddress == "" {
+				t.Errorf("Key %d has no address", i)
+			}
+		}
+
+		// All keys are saved
+		if count, _ := ts.keyRepo.CountByOrg(ctx, orgID); count != 4 {
+			t.Errorf("saved %d keys, want 4", count)
+		}
+	})
+
+	t.Run("reports keys that failed to provision", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+		ts.baoKeyring.newAccountErr = func(uid string) error {
+			if strings.HasSuffix(uid, "_worker-3") {
+				return fmt.Errorf("openbao unavailable")
+			}
+			return nil
+		}
+
+		result, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       4,
+		})
+		if err != nil {
+			t.Fatalf("CreateBatch() error = %v", err)
+		}
+
+		var created []string
+		for _, key := range result.Keys {
+			created = append(created, key.Name)
+		}
+		if want := []string{"worker-1", "worker-2", "worker-4"}; strings.Join(created, ",") != strings.Join(want, ",") {
+			t.Errorf("created = %v, want %v", created, want)
+		}
+		if len(result.Failed) != 1 || result.Failed[0].Name != "worker-3" {
+			t.Fatalf("failed = %v, want [worker-3]", result.Failed)
+		}
+		if !strings.Contains(result.Failed[0].Error, "openbao unavailable") {
+			t.Errorf("failure error = %q", result.Failed[0].Error)
+		}
+
+		if count, _ := ts.keyRepo.CountByOrg(ctx, orgID); count != 3 {
+			t.Errorf("saved %d keys, want 3", count)
+		}
+	})
+
+	t.Run("fails when no key can be provisioned", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+		ts.baoKeyring.newAccountErr = func(uid string) error {
+			return fmt.Errorf("openbao unavailable")
+		}
+
+		if _, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       2,
+		}); err == nil {
+			t.Error("CreateBatch() expected error")
+		}
+	})
+
+	t.Run("removes provisioned keys when saving fails", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanEnterprise)
+		ts.keyRepo.createBatchErr = fmt.Errorf("connection reset")
+
+		if _, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       4,
+		}); err == nil {
+			t.Fatal("CreateBatch() expected error")
+		}
+		if n := len(ts.baoKeyring.keys); n != 0 {
+			t.Errorf("%d OpenBao keys left behind, want 0", n)
+		}
+	})
+
+	t.Run("enforces quota in batch", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanFree) // 3 key limit
+
+		_, err := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Prefix:      "worker",
+			Count:       5,
+		})
+		if err == nil {
+			t.Error("CreateBatch() expected quota error")
+		}
+	})
+}
+
+func TestKeyService_Rotate(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("hello world")
+
+	t.Run("increments version and replaces material", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "rotate-key"})
+		originalPath := key.BaoKeyPath
+
+		rotated, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{})
+		if err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if rotated.ID != key.ID {
+			t.Errorf("ID = %v, want %v", rotated.ID, key.ID)
+		}
+		if rotated.Version != 2 {
+			t.Errorf("Version = %d, want 2", rotated.Version)
+		}
+		if rotated.BaoKeyPath == originalPath {
+			t.Error("BaoKeyPath was not replaced")
+		}
+		if rotated.RotatedAt == nil {
+			t.Error("RotatedAt was not set")
+		}
+		if _, ok := ts.baoKeyring.keys[originalPath]; !ok {
+			t.Error("previous OpenBao key should be retained")
+		}
+
+		prev, _ := ts.keyRepo.GetVersion(ctx, key.ID, 1)
+		if prev == nil {
+			t.Fatal("previous version not recorded")
+		}
+		if prev.BaoKeyPath != originalPath || prev.SigningAllowed {
+			t.Errorf("previous version = %+v, want path %q and signing disallowed", prev, originalPath)
+		}
+
+		resp, err := ts.svc.Sign(ctx, orgID, key.ID, data, false)
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		if resp.KeyVersion != 2 {
+			t.Errorf("KeyVersion = %d, want 2", resp.KeyVersion)
+		}
+
+		again, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{})
+		if err != nil {
+			t.Fatalf("second Rotate() error = %v", err)
+		}
+		if again.Version != 3 {
+			t.Errorf("Version after second rotation = %d, want 3", again.Version)
+		}
+	})
+
+	t.Run("records when each retired version was created", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "history-key"})
+		createdAt := key.CreatedAt
+
+		// A metadata edit must not change when the version was created
+		key.UpdatedAt = createdAt.Add(time.Hour)
+
+		if _, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{}); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if _, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{}); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		v1, _ := ts.keyRepo.GetVersion(ctx, key.ID, 1)
+		v2, _ := ts.keyRepo.GetVersion(ctx, key.ID, 2)
+		if v1 == nil || v2 == nil {
+			t.Fatal("retired versions not recorded")
+		}
+		if !v1.CreatedAt.Equal(createdAt) {
+			t.Errorf("v1 CreatedAt = %v, want key creation time %v", v1.CreatedAt, createdAt)
+		}
+		if !v2.CreatedAt.Equal(v1.RetiredAt) {
+			t.Errorf("v2 CreatedAt = %v, want v1 retirement time %v", v2.CreatedAt, v1.RetiredAt)
+		}
+	})
+
+	t.Run("previous version cannot sign by default", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "rotate-key"})
+		if _, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{}); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		_, err := ts.svc.SignVersion(ctx, orgID, key.ID, 1, data, false)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusForbidden {
+			t.Errorf("SignVersion() error = %v, want forbidden", err)
+		}
+	})
+
+	t.Run("previous version signs when allowed", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "rotate-key"})
+		if _, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{AllowPreviousVersionSigning: true}); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+
+		resp, err := ts.svc.SignVersion(ctx, orgID, key.ID, 1, data, false)
+		if err != nil {
+			t.Fatalf("SignVersion() error = %v", err)
+		}
+		if resp.KeyVersion != 1 {
+			t.Errorf("KeyVersion = %d, want 1", resp.KeyVersion)
+		}
+
+		_, err = ts.svc.SignVersion(ctx, orgID, key.ID, 7, data, false)
+		apiErr, ok := err.(*apierrors.APIError)
+		if !ok || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("SignVersion() unknown version error = %v, want not found", err)
+		}
+	})
+
+	t.Run("purge removes every version from OpenBao", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "rotate-key"})
+		if _, err := ts.svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{}); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+		if err := ts.svc.Delete(ctx, orgID, key.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if len(ts.baoKeyring.keys) != 2 {
+			t.Errorf("OpenBao holds %d keys after delete, want 2 kept for restore", len(ts.baoKeyring.keys))
+		}
+
+		deletedAt := time.Now().Add(-DefaultKeyDeletionGracePeriod - time.Minute)
+		ts.keyRepo.keys[key.ID].DeletedAt = &deletedAt
+		if _, err := ts.svc.PurgeDeletedKeys(ctx); err != nil {
+			t.Fatalf("PurgeDeletedKeys() error = %v", err)
+		}
+		if len(ts.baoKeyring.keys) != 0 {
+			t.Errorf("OpenBao still holds %d keys after purge", len(ts.baoKeyring.keys))
+		}
+	})
+
+	t.Run("rejects rotate for wrong org", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+		otherOrgID, _ := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "rotate-key"})
+		if _, err := ts.svc.Rotate(ctx, otherOrgID, key.ID, RotateKeyOptions{}); err == nil {
+			t.Error("Rotate() expected error for wrong org")
+		}
+	})
+}
+
+func TestKeyService_SignBatch(t *testing.T) {
+	ctx := context.Background()
 
 	t.Run("batch signs successfully", func(t *testing.T) {
 		ts := newTestKeyService()
 		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
 
 		// Create keys
-		keys, _ := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
+		result, _ := ts.svc.CreateBatch(ctx, CreateBatchKeyRequest{
 			OrgID:       orgID,
 			NamespaceID: nsID,
 			Prefix:      "sign-worker",
 			Count:       3,
 		})
+		keys := result.Keys
 
 		// Sign with all keys
 		requests := make([]SignKeyRequest, len(keys))
@@ -1190,6 +2203,104 @@ func TestKeyService_ImportExport(t *testing.T) {
 	})
 }
 
+func TestKeyService_ExportEncrypted(t *testing.T) {
+	ctx := context.Background()
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("GenerateX25519Identity() error = %v", err)
+	}
+	recipient := identity.Recipient().String()
+
+	t.Run("encrypts exported key to recipient", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		privateKey := base64.StdEncoding.EncodeToString([]byte("test_private_key"))
+		key, err := ts.svc.Import(ctx, ImportKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Name:        "recovery-key",
+			PrivateKey:  privateKey,
+			Exportable:  true,
+		})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+
+		ciphertext, err := ts.svc.ExportEncrypted(ctx, orgID, key.ID, recipient)
+		if err != nil {
+			t.Fatalf("ExportEncrypted() error = %v", err)
+		}
+		if strings.Contains(ciphertext, privateKey) {
+			t.Error("ExportEncrypted() output contains the plaintext key")
+		}
+
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(ciphertext)), identity)
+		if err != nil {
+			t.Fatalf("Decrypt() error = %v", err)
+		}
+		decrypted, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if string(decrypted) != privateKey {
+			t.Errorf("decrypted key = %q, want %q", decrypted, privateKey)
+		}
+
+		if !ts.auditRepo.waitForEvent(models.AuditEventKeyExported) {
+			t.Fatalf("encrypted export was not audited as %q", models.AuditEventKeyExported)
+		}
+		ts.auditRepo.mu.Lock()
+		defer ts.auditRepo.mu.Unlock()
+		for _, log := range ts.auditRepo.logs {
+			if log.Event == models.AuditEventKeyExported && !strings.Contains(string(log.Metadata), recipient) {
+				t.Errorf("audit metadata = %s, want recipient %s", log.Metadata, recipient)
+			}
+		}
+	})
+
+	t.Run("rejects non-exportable key", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Name:        "non-exportable",
+			Exportable:  false,
+		})
+
+		_, err := ts.svc.ExportEncrypted(ctx, orgID, key.ID, recipient)
+		if err == nil {
+			t.Fatal("ExportEncrypted() expected error for non-exportable key")
+		}
+		if apiErr, ok := err.(*apierrors.APIError); !ok || apiErr.StatusCode != http.StatusForbidden {
+			t.Errorf("ExportEncrypted() error = %v, want forbidden", err)
+		}
+	})
+
+	t.Run("rejects invalid recipient", func(t *testing.T) {
+		ts := newTestKeyService()
+		orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+		key, _ := ts.svc.Create(ctx, CreateKeyRequest{
+			OrgID:       orgID,
+			NamespaceID: nsID,
+			Name:        "exportable",
+			Exportable:  true,
+		})
+
+		_, err := ts.svc.ExportEncrypted(ctx, orgID, key.ID, "not-a-recipient")
+		if err == nil {
+			t.Fatal("ExportEncrypted() expected error for invalid recipient")
+		}
+		if apiErr, ok := err.(*apierrors.APIError); !ok || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("ExportEncrypted() error = %v, want bad request", err)
+		}
+	})
+}
+
 func TestKeyService_Metadata(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// NamespaceService defines the interface for namespace operations scoped to an
+// organization. Callers are expected to have authorized access to the org
+// already (e.g. via API key scopes); see OrgService for member-checked variants.
+type NamespaceService interface {
+	Create(ctx context.Context, orgID uuid.UUID, req CreateNamespaceRequest) (*models.Namespace, error)
+	Get(ctx context.Context, orgID, nsID uuid.UUID) (*models.Namespace, error)
+	List(ctx context.Context, orgID uuid.UUID) ([]*models.Namespace, error)
+	Delete(ctx context.Context, orgID, nsID uuid.UUID) error
+
+	// EnsureDefault returns the organization's first namespace, creating
+	// DefaultNamespaceName if it has none. It is safe to call repeatedly.
+	EnsureDefault(ctx context.Context, orgID uuid.UUID) (*models.Namespace, error)
+}
+
+// DefaultNamespaceName is the namespace created for organizations that have none.
+const DefaultNamespaceName = "default"
+
+// CreateNamespaceRequest is the request for creating a namespace.
+type CreateNamespaceRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description,omitempty"`
+	Bech32Prefix string `json:"bech32_prefix,omitempty"` // Defaults to models.DefaultBech32Prefix
+}
+
+type namespaceService struct {
+	orgRepo repository.OrgRepository
+}
+
+// NewNamespaceService creates a new namespace service.
+func NewNamespaceService(orgRepo repository.OrgRepository) NamespaceService {
+	return &namespaceService{orgRepo: orgRepo}
+}
+
+// Create creates a new namespace in an organization.
+func (s *namespaceService) Create(ctx context.Context, orgID uuid.UUID, req CreateNamespaceRequest) (*models.Namespace, error) {
+	// Validate name
+	if req.Name == "" {
+		return nil, apierrors.NewValidationError("name", "Namespace name is required")
+	}
+
+	// Check plan limits
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil {
+		return nil, apierrors.NewNotFoundError("Organization")
+	}
+	limits := models.GetPlanLimits(org.Plan)
+
+	count, err := s.orgRepo.CountNamespaces(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count namespaces: %w", err)
+	}
+
+	if limits.Namespaces > 0 && count >= limits.Namespaces {
+		return nil, apierrors.ErrQuotaExceeded.WithMessage(
+			fmt.Sprintf("Namespace limit reached (%d namespaces). Please upgrade your plan.", limits.Namespaces),
+		)
+	}
+
+	// Check for duplicate name
+	existing, err := s.orgRepo.GetNamespaceByName(ctx, orgID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check namespace: %w", err)
+	}
+	if existing != nil {
+		return nil, apierrors.NewConflictError("Namespace with this name already exists")
+	}
+
+	var desc *string
+	if req.Description != "" {
+		desc = &req.Description
+	}
+
+	prefix := req.Bech32Prefix
+	if prefix == "" {
+		prefix = models.DefaultBech32Prefix
+	}
+
+	ns := &models.Namespace{
+		ID:           uuid.New(),
+		OrgID:        orgID,
+		Name:         req.Name,
+		Description:  desc,
+		Bech32Prefix: prefix,
+	}
+
+	if err := s.orgRepo.CreateNamespace(ctx, ns); err != nil {
+		return nil, fmt.Errorf("failed to create namespace: %w", err)
+	}
+
+	return ns, nil
+}
+
+// Get retrieves a namespace belonging to an organization.
+func (s *namespaceService) Get(ctx context.Context, orgID, nsID uuid.UUID) (*models.Namespace, error) {
+	ns, err := s.orgRepo.GetNamespace(ctx, nsID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+	if ns == nil || ns.OrgID != orgID {
+		return nil, apierrors.NewNotFoundError("Namespace")
+	}
+	return ns, nil
+}
+
+// List lists all namespaces in an organization.
+func (s *namespaceService) List(ctx context.Context, orgID uuid.UUID) ([]*models.Namespace, error) {
+	namespaces, err := s.orgRepo.ListNamespaces(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return namespaces, nil
+}
+
+// Delete removes a namespace. The last namespace of an organization cannot be deleted.
+func (s *namespaceService) Delete(ctx context.Context, orgID, nsID uuid.UUID) error {
+	if _, err := s.Get(ctx, orgID, nsID); err != nil {
+		return err
+	}
+
+	count, err := s.orgRepo.CountNamespaces(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to count namespaces: %w", err)
+	}
+	if count <= 1 {
+		return apierrors.ErrBadRequest.WithMessage("Cannot delete the last namespace")
+	}
+
+	if err := s.orgRepo.DeleteNamespace(ctx, nsID); err != nil {
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureDefault returns the organization's first namespace, creating the
+// default one if there are none.
+func (s *namespaceService) EnsureDefault(ctx context.Context, orgID uuid.UUID) (*models.Namespace, error) {
+	namespaces, err := s.orgRepo.ListNamespaces(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	if len(namespaces) > 0 {
+		return namespaces[0], nil
+	}
+
+	ns := &models.Namespace{
+		ID:           uuid.New(),
+		OrgID:        orgID,
+		Name:         DefaultNamespaceName,
+		Bech32Prefix: models.DefaultBech32Prefix,
+	}
+	if err := s.orgRepo.CreateNamespace(ctx, ns); err != nil {
+		// A concurrent request may have created it first
+		existing, getErr := s.orgRepo.GetNamespaceByName(ctx, orgID, DefaultNamespaceName)
+		if getErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("failed to create default namespace: %w", err)
+	}
+
+	return ns, nil
+}
+
+// Compile-time check to ensure namespaceService implements NamespaceService.
+var _ NamespaceService = (*namespaceService)(nil)
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+)
+
+func TestNamespaceService_Create_DefaultPrefix(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	svc := NewNamespaceService(orgRepo)
+
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	orgRepo.On("GetByID", ctx, orgID).Return(&models.Organization{ID: orgID, Plan: models.PlanPro}, nil)
+	orgRepo.On("CountNamespaces", ctx, orgID).Return(1, nil)
+	orgRepo.On("GetNamespaceByName", ctx, orgID, "staging").Return(nil, nil)
+	orgRepo.On("CreateNamespace", ctx, mock.AnythingOfType("*models.Namespace")).Return(nil)
+
+	ns, err := svc.Create(ctx, orgID, CreateNamespaceRequest{Name: "staging"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, orgID, ns.OrgID)
+	assert.Equal(t, models.DefaultBech32Prefix, ns.Bech32Prefix)
+	orgRepo.AssertExpectations(t)
+}
+
+func TestNamespaceService_Create_CustomPrefix(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	svc := NewNamespaceService(orgRepo)
+
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	orgRepo.On("GetByID", ctx, orgID).Return(&models.Organization{ID: orgID, Plan: models.PlanPro}, nil)
+	orgRepo.On("CountNamespaces", ctx, orgID).Return(1, nil)
+	orgRepo.On("GetNamespaceByName", ctx, orgID, "cosmoshub").Return(nil, nil)
+	orgRepo.On("CreateNamespace", ctx, mock.AnythingOfType("*models.Namespace")).Return(nil)
+
+	ns, err := svc.Create(ctx, orgID, CreateNamespaceRequest{Name: "cosmoshub", Bech32Prefix: "cosmos"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cosmos", ns.Bech32Prefix)
+	orgRepo.AssertExpectations(t)
+}
+
+func TestNamespaceService_Get_OtherOrg(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	svc := NewNamespaceService(orgRepo)
+
+	ctx := context.Background()
+	nsID := uuid.New()
+
+	orgRepo.On("GetNamespace", ctx, nsID).Return(&models.Namespace{ID: nsID, OrgID: uuid.New()}, nil)
+
+	ns, err := svc.Get(ctx, uuid.New(), nsID)
+
+	assert.Nil(t, ns)
+	apiErr, ok := err.(*apierrors.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", apiErr.Code)
+}
+
+func TestNamespaceService_Delete_OtherOrg(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	svc := NewNamespaceService(orgRepo)
+
+	ctx := context.Background()
+	nsID := uuid.New()
+
+	orgRepo.On("GetNamespace", ctx, nsID).Return(&models.Namespace{ID: nsID, OrgID: uuid.New()}, nil)
+
+	err := svc.Delete(ctx, uuid.New(), nsID)
+
+	apiErr, ok := err.(*apierrors.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", apiErr.Code)
+	orgRepo.AssertNotCalled(t, "DeleteNamespace", mock.Anything, mock.Anything)
+}
+
+func TestNamespaceService_EnsureDefault(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	t.Run("creates the default namespace", func(t *testing.T) {
+		orgRepo := new(MockOrgRepository)
+		svc := NewNamespaceService(orgRepo)
+
+		orgRepo.On("ListNamespaces", ctx, orgID).Return([]*models.Namespace{}, nil)
+		orgRepo.On("CreateNamespace", ctx, mock.AnythingOfType("*models.Namespace")).Return(nil)
+
+		ns, err := svc.EnsureDefault(ctx, orgID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, orgID, ns.OrgID)
+		assert.Equal(t, DefaultNamespaceName, ns.Name)
+		assert.Equal(t, models.DefaultBech32Prefix, ns.Bech32Prefix)
+		orgRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns the existing namespace", func(t *testing.T) {
+		orgRepo := new(MockOrgRepository)
+		svc := NewNamespaceService(orgRepo)
+
+		existing := &models.Namespace{ID: uuid.New(), OrgID: orgID, Name: "production"}
+		orgRepo.On("ListNamespaces", ctx, orgID).Return([]*models.Namespace{existing}, nil)
+
+		ns, err := svc.EnsureDefault(ctx, orgID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing, ns)
+		orgRepo.AssertNotCalled(t, "CreateNamespace", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns the namespace created concurrently", func(t *testing.T) {
+		orgRepo := new(MockOrgRepository)
+		svc := NewNamespaceService(orgRepo)
+
+		existing := &models.Namespace{ID: uuid.New(), OrgID: orgID, Name: DefaultNamespaceName}
+		orgRepo.On("ListNamespaces", ctx, orgID).Return([]*models.Namespace{}, nil)
+		orgRepo.On("CreateNamespace", ctx, mock.AnythingOfType("*models.Namespace")).Return(assert.AnError)
+		orgRepo.On("GetNamespaceByName", ctx, orgID, DefaultNamespaceName).Return(existing, nil)
+
+		ns, err := svc.EnsureDefault(ctx, orgID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing, ns)
+	})
+}
+
+func TestNamespaceService_EnsureDefault_NewUserCreatesKey(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestKeyService()
+	namespaces := NewNamespaceService(ts.orgRepo)
+
+	// A brand-new user's org, with no namespace yet
+	org := &models.Organization{Name: "New User's Workspace", Plan: models.PlanFree}
+	ts.orgRepo.Create(ctx, org, uuid.New())
+
+	ns, err := namespaces.EnsureDefault(ctx, org.ID)
+	assert.NoError(t, err)
+
+	key, err := ts.svc.Create(ctx, CreateKeyRequest{OrgID: org.ID, NamespaceID: ns.ID, Name: "first-key"})
+	assert.NoError(t, err)
+	assert.Equal(t, ns.ID, key.NamespaceID)
+
+	// Provisioning again keeps the same single namespace
+	again, err := namespaces.EnsureDefault(ctx, org.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ns.ID, again.ID)
+	all, _ := ts.orgRepo.ListNamespaces(ctx, org.ID)
+	assert.Len(t, all, 1)
+}
@@ -300,12 +300,34 @@ func (s *oauthService) findOrCreateUser(ctx context.Context, provider string, in
 			return nil, err
 		}
 		if user != nil {
+			// An unverified account may have been registered by someone else
+			// before the address's owner signed in. Drop its password and
+			// sessions so only the provider's user keeps access.
+			if !user.EmailVerified {
+				if user.PasswordHash != nil {
+					if err := s.userRepo.ClearPassword(ctx, user.ID); err != nil {
+						return nil, err
+					}
+					user.PasswordHash = nil
+				}
+				if err := s.sessionRepo.DeleteAllForUser(ctx, user.ID); err != nil {
+					return nil, err
+				}
+			}
+
 			// Link OAuth provider to existing account
 			if err := s.userRepo.UpdateOAuth(ctx, user.ID, provider, info.ID); err != nil {
 				return nil, err
 			}
 			user.OAuthProvider = &provider
 			user.OAuthProviderID = &info.ID
+			// The provider has confirmed the address
+			if !user.EmailVerified {
+				if err := s.userRepo.SetEmailVerified(ctx, user.ID); err != nil {
+					return nil, err
+				}
+				user.EmailVerified = true
+			}
 			return user, nil
 		}
 	}
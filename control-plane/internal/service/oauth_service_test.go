@@ -33,6 +33,9 @@ func (m *mockUserRepo) Create(ctx context.Context, user *models.User) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
 	m.users[user.ID] = user
 	if user.Email != "" {
 		m.byEmail[user.Email] = user
@@ -73,10 +76,23 @@ func (m *mockUserRepo) UpdateOAuth(ctx context.Context, userID uuid.UUID, provid
 }
 
 func (m *mockUserRepo) UpdatePassword(ctx context.Context, id uuid.UUID, hash string) error {
+	if user, ok := m.users[id]; ok {
+		user.PasswordHash = &hash
+	}
+	return nil
+}
+
+func (m *mockUserRepo) ClearPassword(ctx context.Context, id uuid.UUID) error {
+	if user, ok := m.users[id]; ok {
+		user.PasswordHash = nil
+	}
 	return nil
 }
 
 func (m *mockUserRepo) SetEmailVerified(ctx context.Context, id uuid.UUID) error {
+	if user, ok := m.users[id]; ok {
+		user.EmailVerified = true
+	}
 	return nil
 }
 
@@ -107,6 +123,13 @@ func (m *mockSessionRepo) Get(ctx context.Context, id string) (*models.Session,
 	return m.sessions[id], nil
 }
 
+func (m *mockSessionRepo) UpdateData(ctx context.Context, id string, data map[string]interface{}) error {
+	if s, ok := m.sessions[id]; ok {
+		s.Data = data
+	}
+	return nil
+}
+
 func (m *mockSessionRepo) Delete(ctx context.Context, id string) error {
 	delete(m.sessions, id)
 	return nil
@@ -125,6 +148,10 @@ func (m *mockSessionRepo) CleanupExpired(ctx context.Context) (int64, error) {
 	return 0, nil
 }
 
+func (m *mockSessionRepo) CountActive(ctx context.Context) (int64, error) {
+	return int64(len(m.sessions)), nil
+}
+
 func TestNewOAuthService(t *testing.T) {
 	cfg := &config.AuthConfig{
 		OAuthGitHubID:     "github-id",
@@ -472,6 +499,71 @@ func TestFindOrCreateUser_LinkByEmail(t *testing.T) {
 	if user.OAuthProvider == nil || *user.OAuthProvider != "github" {
 		t.Error("expected OAuth provider to be linked")
 	}
+
+	if !user.EmailVerified || !existingUser.EmailVerified {
+		t.Error("expected linking to verify the email")
+	}
+}
+
+func TestFindOrCreateUser_LinkUnverifiedAccount(t *testing.T) {
+	cfg := &config.AuthConfig{
+		OAuthGitHubID:     "github-id",
+		OAuthGitHubSecret: "github-secret",
+		OAuthCallbackURL:  "http://localhost:8080",
+	}
+	ctx := context.Background()
+	info := &OAuthUserInfo{ID: "github-99999", Email: "victim@example.com", Name: "Victim"}
+
+	t.Run("drops the password and sessions of an unverified account", func(t *testing.T) {
+		userRepo := newMockUserRepo()
+		sessionRepo := newMockSessionRepo()
+
+		// Registered by someone else before the address's owner signed in
+		hash := "attacker-hash"
+		preRegistered := &models.User{ID: uuid.New(), Email: info.Email, PasswordHash: &hash}
+		userRepo.users[preRegistered.ID] = preRegistered
+		userRepo.byEmail[info.Email] = preRegistered
+		sessionRepo.sessions["attacker-session"] = &models.Session{ID: "attacker-session", UserID: preRegistered.ID}
+
+		svc := NewOAuthService(cfg, userRepo, sessionRepo).(*oauthService)
+		user, err := svc.findOrCreateUser(ctx, "github", info)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if user.ID != preRegistered.ID {
+			t.Error("expected to link to the existing user")
+		}
+		if preRegistered.PasswordHash != nil {
+			t.Error("expected the password to be cleared")
+		}
+		if _, ok := sessionRepo.sessions["attacker-session"]; ok {
+			t.Error("expected existing sessions to be revoked")
+		}
+	})
+
+	t.Run("keeps the password of a verified account", func(t *testing.T) {
+		userRepo := newMockUserRepo()
+		sessionRepo := newMockSessionRepo()
+
+		hash := "owner-hash"
+		owner := &models.User{ID: uuid.New(), Email: info.Email, PasswordHash: &hash, EmailVerified: true}
+		userRepo.users[owner.ID] = owner
+		userRepo.byEmail[info.Email] = owner
+		sessionRepo.sessions["owner-session"] = &models.Session{ID: "owner-session", UserID: owner.ID}
+
+		svc := NewOAuthService(cfg, userRepo, sessionRepo).(*oauthService)
+		if _, err := svc.findOrCreateUser(ctx, "github", info); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if owner.PasswordHash == nil || *owner.PasswordHash != "owner-hash" {
+			t.Error("expected the password to be kept")
+		}
+		if _, ok := sessionRepo.sessions["owner-session"]; !ok {
+			t.Error("expected existing sessions to be kept")
+		}
+	})
 }
 
 func TestCreateSession(t *testing.T) {
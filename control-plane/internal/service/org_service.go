@@ -4,6 +4,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -47,6 +48,8 @@ type OrgService interface {
 // OrgServiceConfig holds configuration for the org service.
 type OrgServiceConfig struct {
 	InvitationExpiry time.Duration
+	// InvitationBaseURL is the dashboard URL invitation links point to.
+	InvitationBaseURL string
 }
 
 // DefaultOrgServiceConfig returns sensible default configuration.
@@ -57,9 +60,21 @@ func DefaultOrgServiceConfig() OrgServiceConfig {
 }
 
 type orgService struct {
-	orgRepo  repository.OrgRepository
-	userRepo repository.UserRepository
-	config   OrgServiceConfig
+	orgRepo     repository.OrgRepository
+	userRepo    repository.UserRepository
+	namespaces  NamespaceService
+	config      OrgServiceConfig
+	invitations InvitationSender
+}
+
+// OrgServiceOption configures optional org service dependencies.
+type OrgServiceOption func(*orgService)
+
+// WithInvitationSender delivers new invitations, e.g. by email.
+func WithInvitationSender(sender InvitationSender) OrgServiceOption {
+	return func(s *orgService) {
+		s.invitations = sender
+	}
 }
 
 // NewOrgService creates a new organization service.
@@ -67,12 +82,18 @@ func NewOrgService(
 	orgRepo repository.OrgRepository,
 	userRepo repository.UserRepository,
 	config OrgServiceConfig,
+	opts ...OrgServiceOption,
 ) OrgService {
-	return &orgService{
-		orgRepo:  orgRepo,
-		userRepo: userRepo,
-		config:   config,
+	s := &orgService{
+		orgRepo:    orgRepo,
+		userRepo:   userRepo,
+		namespaces: NewNamespaceService(orgRepo),
+		config:     config,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Create creates a new organization with the specified owner.
@@ -187,10 +208,14 @@ func (s *orgService) InviteMember(ctx context.Context, orgID uuid.UUID, email st
 	}
 
 	// Check plan limits for team members
-	limits, err := s.GetLimits(ctx, orgID)
+	org, err := s.orgRepo.GetByID(ctx, orgID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get organization: %w", err)
 	}
+	if org == nil {
+		return nil, apierrors.NewNotFoundError("Organization")
+	}
+	limits := models.GetPlanLimits(org.Plan)
 
 	memberCount, err := s.orgRepo.CountMembers(ctx, orgID)
 	if err != nil {
@@ -251,8 +276,19 @@ func (s *orgService) InviteMember(ctx context.Context, orgID uuid.UUID, email st
 	if err := s.orgRepo.CreateInvitation(ctx, inv); err != nil {
 		return nil, fmt.Errorf("failed to create invitation: %w", err)
 	}
-
-	// TODO: Send invitation email
+	inv.Organization = org
+
+	// Deliver the invitation. A delivery failure doesn't invalidate it; admins
+	// can still share the accept link from the dashboard.
+	if s.invitations != nil {
+		if err := s.invitations.SendInvitation(ctx, inv, InvitationAcceptURL(s.config.InvitationBaseURL, inv.Token)); err != nil {
+			slog.Warn("Failed to deliver invitation",
+				slog.String("org_id", orgID.String()),
+				slog.String("invitation_id", inv.ID.String()),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
 
 	return inv, nil
 }
@@ -290,6 +326,15 @@ func (s *orgService) AcceptInvitation(ctx context.Context, token string, userID
 		return nil, apierrors.ErrForbidden.WithMessage("This invitation is for a different email address")
 	}
 
+	// Check if user already joined, e.g. through another invitation
+	member, err := s.orgRepo.GetMember(ctx, inv.OrgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if member != nil {
+		return nil, apierrors.NewConflictError("You are already a member of this organization")
+	}
+
 	// Accept invitation
 	if err := s.orgRepo.AcceptInvitation(ctx, token, userID); err != nil {
 		return nil, fmt.Errorf("failed to accept invitation: %w", err)
@@ -485,54 +530,10 @@ func (s *orgService) CreateNamespace(ctx context.Context, orgID uuid.UUID, name,
 		return nil, err
 	}
 
-	// Validate name
-	if name == "" {
-		return nil, apierrors.NewValidationError("name", "Namespace name is required")
-	}
-
-	// Check plan limits
-	limits, err := s.GetLimits(ctx, orgID)
-	if err != nil {
-		return nil, err
-	}
-
-	count, err := s.orgRepo.CountNamespaces(ctx, orgID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count namespaces: %w", err)
-	}
-
-	if limits.Namespaces > 0 && count >= limits.Namespaces {
-		return nil, apierrors.ErrQuotaExceeded.WithMessage(
-			fmt.Sprintf("Namespace limit reached (%d namespaces). Please upgrade your plan.", limits.Namespaces),
-		)
-	}
-
-	// Check for duplicate name
-	existing, err := s.orgRepo.GetNamespaceByName(ctx, orgID, name)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check namespace: %w", err)
-	}
-	if existing != nil {
-		return nil, apierrors.NewConflictError("Namespace with this name already exists")
-	}
-
-	var desc *string
-	if description != "" {
-		desc = &description
-	}
-
-	ns := &models.Namespace{
-		ID:          uuid.New(),
-		OrgID:       orgID,
+	return s.namespaces.Create(ctx, orgID, CreateNamespaceRequest{
 		Name:        name,
-		Description: desc,
-	}
-
-	if err := s.orgRepo.CreateNamespace(ctx, ns); err != nil {
-		return nil, fmt.Errorf("failed to create namespace: %w", err)
-	}
-
-	return ns, nil
+		Description: description,
+	})
 }
 
 // GetNamespace retrieves a namespace.
@@ -542,20 +543,7 @@ func (s *orgService) GetNamespace(ctx context.Context, orgID, nsID uuid.UUID, ac
 		return nil, err
 	}
 
-	ns, err := s.orgRepo.GetNamespace(ctx, nsID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get namespace: %w", err)
-	}
-	if ns == nil {
-		return nil, apierrors.NewNotFoundError("Namespace")
-	}
-
-	// Verify namespace belongs to the org
-	if ns.OrgID != orgID {
-		return nil, apierrors.NewNotFoundError("Namespace")
-	}
-
-	return ns, nil
+	return s.namespaces.Get(ctx, orgID, nsID)
 }
 
 // ListNamespaces lists all namespaces in an organization.
@@ -565,11 +553,7 @@ func (s *orgService) ListNamespaces(ctx context.Context, orgID uuid.UUID, actorI
 		return nil, err
 	}
 
-	namespaces, err := s.orgRepo.ListNamespaces(ctx, orgID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
-	}
-	return namespaces, nil
+	return s.namespaces.List(ctx, orgID)
 }
 
 // DeleteNamespace removes a namespace.
@@ -579,32 +563,6 @@ func (s *orgService) DeleteNamespace(ctx context.Context, orgID, nsID uuid.UUID,
 		return err
 	}
 
-	ns, err := s.orgRepo.GetNamespace(ctx, nsID)
-	if err != nil {
-		return fmt.Errorf("failed to get namespace: %w", err)
-	}
-	if ns == nil {
-		return apierrors.NewNotFoundError("Namespace")
-	}
-
-	// Verify namespace belongs to the org
-	if ns.OrgID != orgID {
-		return apierrors.NewNotFoundError("Namespace")
-	}
-
-	// Prevent deleting the last namespace
-	count, err := s.orgRepo.CountNamespaces(ctx, orgID)
-	if err != nil {
-		return fmt.Errorf("failed to count namespaces: %w", err)
-	}
-	if count <= 1 {
-		return apierrors.ErrBadRequest.WithMessage("Cannot delete the last namespace")
-	}
-
-	if err := s.orgRepo.DeleteNamespace(ctx, nsID); err != nil {
-		return fmt.Errorf("failed to delete namespace: %w", err)
-	}
-
-	return nil
+	return s.namespaces.Delete(ctx, orgID, nsID)
 }
 
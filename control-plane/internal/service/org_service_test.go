@@ -715,6 +715,58 @@ func TestOrgService_InviteMember_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+// recordingInvitationSender records delivered invitations.
+type recordingInvitationSender struct {
+	invitations []*models.Invitation
+	urls        []string
+}
+
+func (r *recordingInvitationSender) SendInvitation(ctx context.Context, inv *models.Invitation, acceptURL string) error {
+	r.invitations = append(r.invitations, inv)
+	r.urls = append(r.urls, acceptURL)
+	return nil
+}
+
+func TestOrgService_InviteMember_SendsInvitation(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	userRepo := new(MockUserRepository)
+	sender := &recordingInvitationSender{}
+	config := OrgServiceConfig{
+		InvitationExpiry:  24 * time.Hour,
+		InvitationBaseURL: "https://dashboard.example.com/",
+	}
+	svc := NewOrgService(orgRepo, userRepo, config, WithInvitationSender(sender))
+
+	ctx := context.Background()
+	orgID := uuid.New()
+	inviterID := uuid.New()
+
+	org := &models.Organization{ID: orgID, Name: "Acme", Plan: models.PlanPro}
+	member := &models.OrgMember{OrgID: orgID, UserID: inviterID, Role: models.RoleOwner}
+
+	orgRepo.On("GetMember", ctx, orgID, inviterID).Return(member, nil)
+	orgRepo.On("GetByID", ctx, orgID).Return(org, nil)
+	orgRepo.On("CountMembers", ctx, orgID).Return(1, nil)
+	orgRepo.On("ListPendingInvitations", ctx, orgID).Return([]*models.Invitation{}, nil)
+	userRepo.On("GetByEmail", ctx, "teammate@example.com").Return(nil, nil)
+	orgRepo.On("GetInvitationByEmail", ctx, orgID, "teammate@example.com").Return(nil, nil)
+	orgRepo.On("CreateInvitation", ctx, mock.AnythingOfType("*models.Invitation")).Return(nil)
+
+	before := time.Now()
+	inv, err := svc.InviteMember(ctx, orgID, "teammate@example.com", models.RoleOperator, inviterID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, inv.Token)
+	assert.Equal(t, models.RoleOperator, inv.Role)
+	assert.WithinDuration(t, before.Add(24*time.Hour), inv.ExpiresAt, time.Minute)
+	assert.Equal(t, org, inv.Organization)
+
+	assert.Len(t, sender.invitations, 1)
+	assert.Equal(t, inv, sender.invitations[0])
+	assert.Equal(t, "https://dashboard.example.com/invites/"+inv.Token, sender.urls[0])
+	orgRepo.AssertExpectations(t)
+}
+
 func TestOrgService_InviteMember_AlreadyMember(t *testing.T) {
 	orgRepo := new(MockOrgRepository)
 	userRepo := new(MockUserRepository)
@@ -918,6 +970,7 @@ func TestOrgService_AcceptInvitation_Success(t *testing.T) {
 
 	orgRepo.On("GetInvitationByToken", ctx, "test-token").Return(inv, nil)
 	userRepo.On("GetByID", ctx, userID).Return(user, nil)
+	orgRepo.On("GetMember", ctx, orgID, userID).Return(nil, nil)
 	orgRepo.On("AcceptInvitation", ctx, "test-token", userID).Return(nil)
 
 	resultOrg, err := svc.AcceptInvitation(ctx, "test-token", userID)
@@ -928,6 +981,41 @@ func TestOrgService_AcceptInvitation_Success(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestOrgService_AcceptInvitation_AlreadyMember(t *testing.T) {
+	orgRepo := new(MockOrgRepository)
+	userRepo := new(MockUserRepository)
+	svc := newTestOrgService(orgRepo, userRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	orgID := uuid.New()
+
+	user := &models.User{ID: userID, Email: "invited@example.com"}
+	inv := &models.Invitation{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		Email:     "invited@example.com",
+		Role:      models.RoleViewer,
+		Token:     "test-token",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	member := &models.OrgMember{OrgID: orgID, UserID: userID, Role: models.RoleOperator}
+
+	orgRepo.On("GetInvitationByToken", ctx, "test-token").Return(inv, nil)
+	userRepo.On("GetByID", ctx, userID).Return(user, nil)
+	orgRepo.On("GetMember", ctx, orgID, userID).Return(member, nil)
+
+	resultOrg, err := svc.AcceptInvitation(ctx, "test-token", userID)
+
+	assert.Error(t, err)
+	assert.Nil(t, resultOrg)
+
+	apiErr, ok := err.(*apierrors.APIError)
+	assert.True(t, ok)
+	assert.Equal(t, "conflict", apiErr.Code)
+	orgRepo.AssertNotCalled(t, "AcceptInvitation", ctx, "test-token", userID)
+}
+
 func TestOrgService_AcceptInvitation_Expired(t *testing.T) {
 	orgRepo := new(MockOrgRepository)
 	userRepo := new(MockUserRepository)
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+)
+
+// Password length limits. The upper bound keeps hashing cost bounded.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 128
+)
+
+// Argon2Params are the argon2id cost parameters used for new password hashes.
+type Argon2Params struct {
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2Params returns the OWASP-recommended argon2id parameters.
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+var errInvalidPasswordHash = errors.New("invalid password hash")
+
+// hashPassword hashes a password with argon2id, encoded in the PHC string
+// format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>.
+func hashPassword(password string, p Argon2Params) (string, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyPassword reports whether password matches the stored hash. Besides
+// argon2id it accepts bcrypt hashes created before the switch to argon2id.
+func verifyPassword(password, encoded string) (bool, error) {
+	if isBcryptHash(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	p, salt, key, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// needsRehash reports whether a stored hash should be replaced by one using
+// the current parameters.
+func needsRehash(encoded string, current Argon2Params) bool {
+	if isBcryptHash(encoded) {
+		return true
+	}
+	p, _, _, err := decodeArgon2Hash(encoded)
+	if err != nil {
+		return true
+	}
+	return p.Memory != current.Memory || p.Iterations != current.Iterations ||
+		p.Parallelism != current.Parallelism || p.KeyLength != current.KeyLength
+}
+
+func isBcryptHash(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// decodeArgon2Hash parses a PHC-format argon2id hash.
+func decodeArgon2Hash(encoded string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, errInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, errInvalidPasswordHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, errInvalidPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, errInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, errInvalidPasswordHash
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
+
+// commonPasswords are rejected outright regardless of their composition.
+var commonPasswords = map[string]bool{
+	"password1":   true,
+	"password123": true,
+	"passw0rd":    true,
+	"12345678":    true,
+	"123456789":   true,
+	"1234567890":  true,
+	"qwerty123":   true,
+	"qwertyuiop":  true,
+	"iloveyou1":   true,
+	"letmein1":    true,
+	"welcome1":    true,
+	"welcome123":  true,
+	"admin123":    true,
+	"abc12345":    true,
+	"popsigner1":  true,
+}
+
+// checkPasswordStrength rejects passwords that are too short or long, made of
+// letters only, commonly used, or that contain the account's email name.
+func checkPasswordStrength(password, email string) error {
+	n := utf8.RuneCountInString(password)
+	if n < MinPasswordLength {
+		return apierrors.NewValidationError("password", fmt.Sprintf("Password must be at least %d characters", MinPasswordLength))
+	}
+	if n > MaxPasswordLength {
+		return apierrors.NewValidationError("password", fmt.Sprintf("Password must be at most %d characters", MaxPasswordLength))
+	}
+
+	var hasLetter, hasOther bool
+	for _, r := range password {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		} else if !unicode.IsSpace(r) {
+			hasOther = true
+		}
+	}
+	if !hasLetter || !hasOther {
+		return apierrors.NewValidationError("password", "Password must contain a letter and a number or symbol")
+	}
+
+	lower := strings.ToLower(password)
+	if commonPasswords[lower] {
+		return apierrors.NewValidationError("password", "Password is too common")
+	}
+	if local, _, ok := strings.Cut(strings.ToLower(email), "@"); ok && len(local) >= 4 && strings.Contains(lower, local) {
+		return apierrors.NewValidationError("password", "Password must not contain your email address")
+	}
+
+	return nil
+}
+
+// normalizeEmail trims and lowercases an email address for lookups.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
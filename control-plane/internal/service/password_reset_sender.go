@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+// PasswordResetSender delivers password reset links to users.
+type PasswordResetSender interface {
+	SendPasswordReset(ctx context.Context, user *models.User, resetURL string, expiresAt time.Time) error
+}
+
+// PasswordResetURL returns the link a user opens to choose a new password.
+func PasswordResetURL(baseURL, token string) string {
+	return strings.TrimRight(baseURL, "/") + "/reset-password?token=" + url.QueryEscape(token)
+}
+
+// logPasswordResetSender logs that a reset was requested instead of emailing
+// the link. It is used when no SMTP server is configured.
+type logPasswordResetSender struct {
+	logger *slog.Logger
+}
+
+// NewLogPasswordResetSender creates a password reset sender that only logs the reset link.
+func NewLogPasswordResetSender(logger *slog.Logger) PasswordResetSender {
+	return &logPasswordResetSender{logger: logger}
+}
+
+// SendPasswordReset logs the password reset link.
+func (s *logPasswordResetSender) SendPasswordReset(ctx context.Context, user *models.User, resetURL string, expiresAt time.Time) error {
+	s.logger.Info("Password reset requested (email delivery disabled)",
+		slog.String("user_id", user.ID.String()),
+		slog.String("reset_url", resetURL),
+	)
+	return nil
+}
+
+// smtpPasswordResetSender emails password reset links over SMTP.
+type smtpPasswordResetSender struct {
+	config SMTPConfig
+	send   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPPasswordResetSender creates a password reset sender that emails the reset link.
+func NewSMTPPasswordResetSender(config SMTPConfig) PasswordResetSender {
+	return &smtpPasswordResetSender{config: config, send: smtp.SendMail}
+}
+
+// SendPasswordReset emails the reset link to the user.
+func (s *smtpPasswordResetSender) SendPasswordReset(ctx context.Context, user *models.User, resetURL string, expiresAt time.Time) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(user.Email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	msg := buildPasswordResetEmail(from, to, resetURL, expiresAt)
+	if err := s.send(addr, auth, from.Address, []string{to.Address}, msg); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+	return nil
+}
+
+// buildPasswordResetEmail renders a plain-text password reset email.
+func buildPasswordResetEmail(from, to *mail.Address, resetURL string, expiresAt time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	b.WriteString("Subject: Reset your POPSigner password\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString("Someone asked to reset the password for your POPSigner account.\r\n\r\n")
+	fmt.Fprintf(&b, "Choose a new password:\r\n%s\r\n\r\n", resetURL)
+	fmt.Fprintf(&b, "This link expires on %s and can only be used once.\r\n", expiresAt.UTC().Format(time.RFC1123))
+	b.WriteString("If you didn't ask for this, you can ignore this email.\r\n")
+	return []byte(b.String())
+}
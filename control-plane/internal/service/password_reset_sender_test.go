@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestPasswordResetURL(t *testing.T) {
+	assert.Equal(t, "https://popsigner.com/reset-password?token=abc-_", PasswordResetURL("https://popsigner.com/", "abc-_"))
+	assert.Equal(t, "https://popsigner.com/reset-password?token=a%3D%3D", PasswordResetURL("https://popsigner.com", "a=="))
+}
+
+func TestSMTPPasswordResetSender_SendPasswordReset(t *testing.T) {
+	var (
+		gotTo  []string
+		gotMsg string
+	)
+	sender := NewSMTPPasswordResetSender(SMTPConfig{
+		Host: "smtp.example.com",
+		Port: 587,
+		From: "POPSigner <noreply@popsigner.com>",
+	}).(*smtpPasswordResetSender)
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotTo, gotMsg = to, string(msg)
+		return nil
+	}
+
+	user := &models.User{ID: uuid.New(), Email: "user@example.com"}
+	err := sender.SendPasswordReset(context.Background(), user, "https://popsigner.com/reset-password?token=t", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"user@example.com"}, gotTo)
+	assert.Contains(t, gotMsg, "Subject: Reset your POPSigner password")
+	assert.Contains(t, gotMsg, "https://popsigner.com/reset-password?token=t")
+}
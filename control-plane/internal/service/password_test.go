@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPassword_Verify(t *testing.T) {
+	params := testAuthServiceConfig().PasswordHash
+
+	hash, err := hashPassword("correct-horse-42", params)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\$argon2id\$v=19\$m=1024,t=1,p=1\$[^$]+\$[^$]+$`, hash)
+
+	ok, err := verifyPassword("correct-horse-42", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifyPassword("correct-horse-43", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Salts are random
+	other, err := hashPassword("correct-horse-42", params)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	assert.False(t, needsRehash(hash, params))
+	assert.True(t, needsRehash(hash, DefaultArgon2Params()))
+}
+
+func TestVerifyPassword_Bcrypt(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("legacy-pass-1"), 4)
+	require.NoError(t, err)
+
+	ok, err := verifyPassword("legacy-pass-1", string(hash))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifyPassword("legacy-pass-2", string(hash))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.True(t, needsRehash(string(hash), DefaultArgon2Params()))
+}
+
+func TestVerifyPassword_InvalidHash(t *testing.T) {
+	for _, hash := range []string{"", "plaintext", "$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=x$c2FsdA$a2V5"} {
+		_, err := verifyPassword("password", hash)
+		assert.Error(t, err, "verifyPassword(%q)", hash)
+	}
+}
+
+func TestCheckPasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		wantErr  bool
+	}{
+		{"correct-horse-42", false},
+		{"Tr0ub4dor&3", false},
+		{"short1!", true},
+		{"alllettersonly", true},
+		{"1234567890", true},
+		{"Password123", true},
+		{"frank2024!", true}, // contains the email name
+		{string(make([]byte, MaxPasswordLength+1)), true},
+	}
+	for _, tt := range tests {
+		err := checkPasswordStrength(tt.password, "Frank@example.com")
+		if tt.wantErr {
+			assert.Error(t, err, "checkPasswordStrength(%q)", tt.password)
+		} else {
+			assert.NoError(t, err, "checkPasswordStrength(%q)", tt.password)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// maxSignCacheEntries bounds the signature cache; once full, expired entries
+// are swept and new signatures aren't cached until there is room.
+const maxSignCacheEntries = 10000
+
+// signCache remembers recent signatures so exact repeats of a sign request,
+// e.g. client retries, are answered without another OpenBao call. ECDSA
+// signing with RFC 6979 nonces is deterministic, so the cached signature is
+// the one OpenBao would return again.
+type signCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[[32]byte]signCacheEntry
+}
+
+type signCacheEntry struct {
+	signature []byte
+	publicKey []byte
+	expiresAt time.Time
+}
+
+// newSignCache returns a cache keeping signatures for ttl, or nil if ttl is
+// not positive.
+func newSignCache(ttl time.Duration) *signCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &signCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[[32]byte]signCacheEntry),
+	}
+}
+
+// signCacheKey identifies a sign request by the SHA-256 of its data. It
+// includes the OpenBao key path and version, so a rotated key never gets a
+// signature made by its previous key material.
+func signCacheKey(baoKeyPath string, version int, dataHash [32]byte, prehashed bool) [32]byte {
+	h := sha256.New()
+	h.Write([]byte(baoKeyPath))
+	h.Write([]byte{0})
+	binary.Write(h, binary.BigEndian, int64(version))
+	if prehashed {
+		h.Write([]byte{1})
+	} else {
+		h.Write([]byte{0})
+	}
+	h.Write(dataHash[:])
+
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+// get returns the cached signature and public key for key. A nil cache
+// never hits.
+func (c *signCache) get(key [32]byte) (signature, publicKey []byte, ok bool) {
+	if c == nil {
+		return nil, nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, nil, false
+	}
+	return append([]byte(nil), entry.signature...), append([]byte(nil), entry.publicKey...), true
+}
+
+// put caches a signature for key.
+func (c *signCache) put(key [32]byte, signature, publicKey []byte) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= maxSignCacheEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxSignCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = signCacheEntry{
+		signature: append([]byte(nil), signature...),
+		publicKey: append([]byte(nil), publicKey...),
+		expiresAt: now.Add(c.ttl),
+	}
+}
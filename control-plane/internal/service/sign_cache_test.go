@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+)
+
+func TestKeyService_SignatureCache(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestKeyService()
+	svc := NewKeyService(ts.keyRepo, ts.orgRepo, ts.auditRepo, ts.usageRepo, ts.baoKeyring,
+		WithSignatureCache(time.Minute))
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	key, err := svc.Create(ctx, CreateKeyRequest{OrgID: orgID, NamespaceID: nsID, Name: "cached-key"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 32 bytes, so the same data can also be signed as a prehashed digest
+	data := []byte("0123456789abcdef0123456789abcdef")
+
+	first, err := svc.Sign(ctx, orgID, key.ID, data, false)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	second, err := svc.Sign(ctx, orgID, key.ID, data, false)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if ts.baoKeyring.signCount != 1 {
+		t.Errorf("backend signs = %d, want 1 for identical requests", ts.baoKeyring.signCount)
+	}
+	if second.Signature != first.Signature || second.PublicKey != first.PublicKey {
+		t.Error("repeat returned a different signature")
+	}
+
+	// Different data or mode is a different request
+	if _, err := svc.Sign(ctx, orgID, key.ID, []byte("other"), false); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if _, err := svc.Sign(ctx, orgID, key.ID, data, true); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if ts.baoKeyring.signCount != 3 {
+		t.Errorf("backend signs = %d, want 3", ts.baoKeyring.signCount)
+	}
+
+	// A rotated key signs with its new material
+	if _, err := svc.Rotate(ctx, orgID, key.ID, RotateKeyOptions{}); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	rotated, err := svc.Sign(ctx, orgID, key.ID, data, false)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if ts.baoKeyring.signCount != 4 {
+		t.Errorf("backend signs = %d, want 4: cached signature served across rotation", ts.baoKeyring.signCount)
+	}
+	if rotated.KeyVersion != 2 {
+		t.Errorf("rotated sign used version %d, want 2", rotated.KeyVersion)
+	}
+}
+
+func TestSignCache_Expires(t *testing.T) {
+	c := newSignCache(time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := signCacheKey("path", 1, sha256.Sum256([]byte("data")), false)
+	c.put(key, []byte("sig"), []byte("pub"))
+
+	if sig, _, ok := c.get(key); !ok || string(sig) != "sig" {
+		t.Fatalf("get() = %q, %v; want cached signature", sig, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, _, ok := c.get(key); ok {
+		t.Error("get() hit after the TTL expired")
+	}
+}
+
+func TestSignCache_Disabled(t *testing.T) {
+	c := newSignCache(0)
+	key := signCacheKey("path", 1, sha256.Sum256([]byte("data")), false)
+	c.put(key, []byte("sig"), []byte("pub"))
+
+	if _, _, ok := c.get(key); ok {
+		t.Error("disabled cache returned a signature")
+	}
+}
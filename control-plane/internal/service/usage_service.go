@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+	"github.com/Bidon15/popsigner/control-plane/internal/repository"
+)
+
+// UsageService defines the interface for reporting consumption against plan limits.
+type UsageService interface {
+	// Current returns the organization's usage for the current billing period.
+	Current(ctx context.Context, orgID uuid.UUID) (*models.UsageSummary, error)
+
+	// ByKey returns the number of signatures made with a key within a window.
+	// A zero start or end defaults to the current billing period's bound.
+	ByKey(ctx context.Context, orgID, keyID uuid.UUID, start, end time.Time) (*models.KeyUsage, error)
+}
+
+type usageService struct {
+	usageRepo repository.UsageRepository
+	orgRepo   repository.OrgRepository
+	keyRepo   repository.KeyRepository
+	auditRepo repository.AuditRepository
+}
+
+// NewUsageService creates a new usage service.
+func NewUsageService(
+	usageRepo repository.UsageRepository,
+	orgRepo repository.OrgRepository,
+	keyRepo repository.KeyRepository,
+	auditRepo repository.AuditRepository,
+) UsageService {
+	return &usageService{
+		usageRepo: usageRepo,
+		orgRepo:   orgRepo,
+		keyRepo:   keyRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// Current returns the organization's usage for the current billing period.
+func (s *usageService) Current(ctx context.Context, orgID uuid.UUID) (*models.UsageSummary, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if org == nil {
+		return nil, apierrors.NewNotFoundError("Organization")
+	}
+
+	summary, err := s.usageRepo.GetSummary(ctx, orgID, org.Plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage summary: %w", err)
+	}
+	return summary, nil
+}
+
+// ByKey returns the number of signatures made with a key within a window.
+// Per-key counts are derived from key.signed audit entries, so they are only
+// available for as long as the plan's audit retention.
+func (s *usageService) ByKey(ctx context.Context, orgID, keyID uuid.UUID, start, end time.Time) (*models.KeyUsage, error) {
+	key, err := s.keyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key: %w", err)
+	}
+	if key == nil || key.OrgID != orgID || key.DeletedAt != nil {
+		return nil, apierrors.NewNotFoundError("Key")
+	}
+
+	periodStart, periodEnd := models.BillingPeriod(time.Now())
+	if start.IsZero() {
+		start = periodStart
+	}
+	if end.IsZero() {
+		end = periodEnd
+	}
+	if !start.Before(end) {
+		return nil, apierrors.NewValidationError("start_time", "must be before end_time")
+	}
+
+	count, err := s.auditRepo.CountByResourceAndPeriod(ctx, orgID, models.AuditEventKeySigned, keyID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count signatures: %w", err)
+	}
+
+	return &models.KeyUsage{
+		KeyID:       keyID,
+		Signatures:  count,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}, nil
+}
+
+// Compile-time check to ensure usageService implements UsageService.
+var _ UsageService = (*usageService)(nil)
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
+)
+
+func newTestUsageService() (*testKeyService, UsageService) {
+	ts := newTestKeyService()
+	return ts, NewUsageService(ts.usageRepo, ts.orgRepo, ts.keyRepo, ts.auditRepo)
+}
+
+func TestUsageService_Current(t *testing.T) {
+	ts, svc := newTestUsageService()
+	ctx := context.Background()
+	orgID, _ := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	ts.usageRepo.usage[orgID.String()+"_signatures"] = 1234
+
+	summary, err := svc.Current(ctx, orgID)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.PlanPro, summary.Plan)
+	assert.Equal(t, int64(1234), summary.SignaturesMonth)
+	assert.Equal(t, int64(500000), summary.SignaturesLimit)
+	assert.True(t, summary.PeriodStart.Before(summary.PeriodEnd))
+}
+
+func TestUsageService_Current_UnknownOrg(t *testing.T) {
+	_, svc := newTestUsageService()
+
+	_, err := svc.Current(context.Background(), uuid.New())
+
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "not_found", apiErr.Code)
+}
+
+func TestUsageService_ByKey(t *testing.T) {
+	ts, svc := newTestUsageService()
+	ctx := context.Background()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanPro)
+
+	keyID := uuid.New()
+	ts.keyRepo.keys[keyID] = &models.Key{ID: keyID, OrgID: orgID, NamespaceID: nsID, Name: "sequencer"}
+
+	now := time.Now().UTC()
+	for _, ago := range []time.Duration{time.Hour, 2 * time.Hour, 48 * time.Hour} {
+		resourceID := keyID
+		ts.auditRepo.logs = append(ts.auditRepo.logs, &models.AuditLog{
+			ID:         uuid.New(),
+			OrgID:      orgID,
+			Event:      models.AuditEventKeySigned,
+			ResourceID: &resourceID,
+			CreatedAt:  now.Add(-ago),
+		})
+	}
+
+	usage, err := svc.ByKey(ctx, orgID, keyID, now.Add(-24*time.Hour), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, keyID, usage.KeyID)
+	assert.Equal(t, int64(2), usage.Signatures)
+	assert.Equal(t, now.Add(-24*time.Hour), usage.PeriodStart)
+	assert.Equal(t, now, usage.PeriodEnd)
+}
+
+func TestUsageService_ByKey_DefaultsToBillingPeriod(t *testing.T) {
+	ts, svc := newTestUsageService()
+	ctx := context.Background()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanFree)
+
+	keyID := uuid.New()
+	ts.keyRepo.keys[keyID] = &models.Key{ID: keyID, OrgID: orgID, NamespaceID: nsID, Name: "sequencer"}
+
+	usage, err := svc.ByKey(ctx, orgID, keyID, time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	start, end := models.BillingPeriod(time.Now())
+	assert.Equal(t, start, usage.PeriodStart)
+	assert.Equal(t, end, usage.PeriodEnd)
+}
+
+func TestUsageService_ByKey_Errors(t *testing.T) {
+	ts, svc := newTestUsageService()
+	ctx := context.Background()
+	orgID, nsID := ts.createTestOrgAndNamespace(models.PlanFree)
+
+	keyID := uuid.New()
+	ts.keyRepo.keys[keyID] = &models.Key{ID: keyID, OrgID: orgID, NamespaceID: nsID, Name: "sequencer"}
+
+	now := time.Now()
+
+	_, err := svc.ByKey(ctx, uuid.New(), keyID, time.Time{}, time.Time{})
+	apiErr, ok := err.(*apierrors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "not_found", apiErr.Code)
+
+	_, err = svc.ByKey(ctx, orgID, keyID, now, now.Add(-time.Hour))
+	apiErr, ok = err.(*apierrors.APIError)
+	require.True(t, ok)
+	assert.Equal(t, "validation_error", apiErr.Code)
+}
@@ -10,10 +10,15 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
@@ -45,6 +50,12 @@ type WebhookService interface {
 	RotateSecret(ctx context.Context, orgID, webhookID uuid.UUID) (*models.Webhook, error)
 }
 
+// EventPublisher publishes organization events to subscribers.
+// WebhookService implements it.
+type EventPublisher interface {
+	Deliver(ctx context.Context, orgID uuid.UUID, event models.WebhookEvent, payload any) error
+}
+
 // CreateWebhookRequest is the request to create a webhook.
 type CreateWebhookRequest struct {
 	URL    string                 `json:"url" validate:"required,url"`
@@ -70,6 +81,9 @@ type WebhookServiceConfig struct {
 	MaxFailures int
 	// UserAgent for webhook requests
 	UserAgent string
+	// AllowPrivateTargets permits http:// URLs and loopback, private and
+	// link-local targets. Only for local development and tests.
+	AllowPrivateTargets bool
 }
 
 // DefaultWebhookServiceConfig returns sensible defaults.
@@ -91,15 +105,77 @@ type webhookService struct {
 
 // NewWebhookService creates a new webhook service.
 func NewWebhookService(webhookRepo repository.WebhookRepository, config WebhookServiceConfig) WebhookService {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	if !config.AllowPrivateTargets {
+		// Checked on the resolved address at connect time, so DNS names
+		// that point (or rebind) to internal addresses are refused too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublicWebhookAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s", errWebhookTargetBlocked, address)
+			}
+			return nil
+		}
+	}
+
 	return &webhookService{
 		webhookRepo: webhookRepo,
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				// No proxy: the dial check must see the webhook's own address
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: config.Timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
 		},
 		config: config,
 	}
 }
 
+// errWebhookTargetBlocked is returned when a webhook resolves to an address
+// that deliveries may not reach.
+var errWebhookTargetBlocked = errors.New("webhook target address is not allowed")
+
+// isPublicWebhookAddr reports whether webhooks may be delivered to addr.
+// Loopback, private, link-local (including cloud metadata endpoints),
+// shared, multicast and unspecified addresses are refused.
+func isPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	// Carrier-grade NAT space is internal to many cloud networks
+	return !netip.MustParsePrefix("100.64.0.0/10").Contains(addr)
+}
+
+// validateWebhookURL checks that raw is an https URL whose host is not an
+// internal address. Hostnames are checked again when a delivery connects.
+func (s *webhookService) validateWebhookURL(raw string) error {
+	u, err := url.ParseRequestURI(raw)
+	if err != nil || u.Host == "" {
+		return apierrors.NewValidationError("url", "Invalid URL format")
+	}
+	if s.config.AllowPrivateTargets {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return apierrors.NewValidationError("url", "URL must use https")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return apierrors.NewValidationError("url", "URL must not point to an internal address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicWebhookAddr(addr) {
+		return apierrors.NewValidationError("url", "URL must not point to an internal address")
+	}
+	return nil
+}
+
 // validWebhookEvents contains all valid webhook events.
 var validWebhookEvents = map[models.WebhookEvent]bool{
 	models.WebhookEventKeyCreated:         true,
@@ -117,8 +193,8 @@ func (s *webhookService) Create(ctx context.Context, orgID uuid.UUID, req Create
 	if req.URL == "" {
 		return nil, apierrors.NewValidationError("url", "URL is required")
 	}
-	if _, err := url.ParseRequestURI(req.URL); err != nil {
-		return nil, apierrors.NewValidationError("url", "Invalid URL format")
+	if err := s.validateWebhookURL(req.URL); err != nil {
+		return nil, err
 	}
 
 	// Validate events
@@ -190,8 +266,8 @@ func (s *webhookService) Update(ctx context.Context, orgID, webhookID uuid.UUID,
 
 	// Apply updates
 	if req.URL != nil {
-		if _, err := url.ParseRequestURI(*req.URL); err != nil {
-			return nil, apierrors.NewValidationError("url", "Invalid URL format")
+		if err := s.validateWebhookURL(*req.URL); err != nil {
+			return nil, err
 		}
 		webhook.URL = *req.URL
 	}
@@ -245,6 +321,9 @@ func (s *webhookService) Deliver(ctx context.Context, orgID uuid.UUID, event mod
 
 	// Deliver to each webhook asynchronously
 	for _, webhook := range webhooks {
+		if !webhook.Enabled {
+			continue
+		}
 		go s.deliverToWebhook(context.Background(), webhook, event, payload)
 	}
 
@@ -271,7 +350,6 @@ func (s *webhookService) deliverToWebhook(ctx context.Context, webhook *models.W
 	// Attempt delivery with retries
 	var lastErr error
 	var lastStatusCode int
-	var lastResponse string
 	var duration time.Duration
 
 	for attempt := 0; attempt <= s.config.MaxRetries; attempt++ {
@@ -281,15 +359,14 @@ func (s *webhookService) deliverToWebhook(ctx context.Context, webhook *models.W
 			time.Sleep(backoff)
 		}
 
-		statusCode, response, dur, err := s.sendWebhook(ctx, webhook, event, body)
+		statusCode, dur, err := s.sendWebhook(ctx, webhook, event, body)
 		duration = dur
 		lastStatusCode = statusCode
-		lastResponse = response
 		lastErr = err
 
 		// Success if we got a 2xx response
 		if err == nil && statusCode >= 200 && statusCode < 300 {
-			s.recordDeliverySuccess(ctx, webhook, event, string(body), statusCode, response, duration)
+			s.recordDeliverySuccess(ctx, webhook, event, string(body), statusCode, duration)
 			return
 		}
 
@@ -300,7 +377,7 @@ func (s *webhookService) deliverToWebhook(ctx context.Context, webhook *models.W
 	}
 
 	// All retries failed
-	s.recordDeliveryFailure(ctx, webhook, event, string(body), fmt.Errorf("delivery failed: status=%d, response=%s, err=%v", lastStatusCode, lastResponse, lastErr))
+	s.recordDeliveryFailure(ctx, webhook, event, string(body), fmt.Errorf("delivery failed: status=%d, err=%v", lastStatusCode, lastErr))
 	
 	// Update failure count
 	_ = s.webhookRepo.IncrementFailureCount(ctx, webhook.ID)
@@ -313,8 +390,9 @@ func (s *webhookService) deliverToWebhook(ctx context.Context, webhook *models.W
 	}
 }
 
-// sendWebhook performs the actual HTTP request.
-func (s *webhookService) sendWebhook(ctx context.Context, webhook *models.Webhook, event models.WebhookEvent, body []byte) (statusCode int, response string, duration time.Duration, err error) {
+// sendWebhook performs the actual HTTP request. The response body is
+// discarded so deliveries can't be used to read internal services.
+func (s *webhookService) sendWebhook(ctx context.Context, webhook *models.Webhook, event models.WebhookEvent, body []byte) (statusCode int, duration time.Duration, err error) {
 	// Calculate signature
 	timestamp := time.Now().Unix()
 	signature := s.calculateSignature(webhook.Secret, timestamp, body)
@@ -322,7 +400,7 @@ func (s *webhookService) sendWebhook(ctx context.Context, webhook *models.Webhoo
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
 	if err != nil {
-		return 0, "", 0, fmt.Errorf("failed to create request: %w", err)
+		return 0, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
@@ -339,16 +417,14 @@ func (s *webhookService) sendWebhook(ctx context.Context, webhook *models.Webhoo
 	duration = time.Since(start)
 
 	if err != nil {
-		return 0, "", duration, fmt.Errorf("request failed: %w", err)
+		return 0, duration, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response body (limited)
-	respBody := make([]byte, 1024)
-	n, _ := io.ReadFull(resp.Body, respBody)
-	response = string(respBody[:n])
+	// Drain a little so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
 
-	return resp.StatusCode, response, duration, nil
+	return resp.StatusCode, duration, nil
 }
 
 // calculateSignature generates the HMAC signature for a webhook payload.
@@ -390,16 +466,15 @@ func (s *webhookService) VerifySignature(secret, signature string, body []byte)
 }
 
 // recordDeliverySuccess records a successful webhook delivery.
-func (s *webhookService) recordDeliverySuccess(ctx context.Context, webhook *models.Webhook, event models.WebhookEvent, payload string, statusCode int, response string, duration time.Duration) {
+func (s *webhookService) recordDeliverySuccess(ctx context.Context, webhook *models.Webhook, event models.WebhookEvent, payload string, statusCode int, duration time.Duration) {
 	delivery := &models.WebhookDelivery{
-		ID:           uuid.New(),
-		WebhookID:    webhook.ID,
-		Event:        event,
-		Payload:      payload,
-		StatusCode:   statusCode,
-		ResponseBody: response,
-		Duration:     duration,
-		Success:      true,
+		ID:         uuid.New(),
+		WebhookID:  webhook.ID,
+		Event:      event,
+		Payload:    payload,
+		StatusCode: statusCode,
+		Duration:   duration,
+		Success:    true,
 	}
 
 	_ = s.webhookRepo.CreateDelivery(ctx, delivery)
@@ -507,7 +582,7 @@ func (s *webhookService) RotateSecret(ctx context.Context, orgID, webhookID uuid
 	webhook.Secret = newSecret
 	webhook.UpdatedAt = time.Now()
 
-	// Update in database (need to use a raw update for the secret)
+	// Update in database
 	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
 		return nil, fmt.Errorf("failed to update webhook: %w", err)
 	}
@@ -2,10 +2,15 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +20,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Bidon15/popsigner/control-plane/internal/models"
+	apierrors "github.com/Bidon15/popsigner/control-plane/internal/pkg/errors"
 )
 
 // MockWebhookRepository is a mock implementation of repository.WebhookRepository.
@@ -140,6 +146,31 @@ func TestWebhookService_Create_InvalidURL(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid URL")
 }
 
+func TestWebhookService_Create_RejectsInternalURLs(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockWebhookRepository)
+	svc := NewWebhookService(mockRepo, DefaultWebhookServiceConfig())
+
+	for _, u := range []string{
+		"http://example.com/webhook",
+		"https://localhost/webhook",
+		"https://127.0.0.1/webhook",
+		"https://10.0.0.5/webhook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/webhook",
+		"https://[::ffff:192.168.1.1]/webhook",
+	} {
+		_, err := svc.Create(ctx, uuid.New(), CreateWebhookRequest{
+			URL:    u,
+			Events: []models.WebhookEvent{models.WebhookEventKeyCreated},
+		})
+		var apiErr *apierrors.APIError
+		require.ErrorAs(t, err, &apiErr, u)
+		assert.Equal(t, "validation_error", apiErr.Code, u)
+	}
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestWebhookService_Create_EmptyURL(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockWebhookRepository)
@@ -444,6 +475,8 @@ func TestWebhookService_Deliver(t *testing.T) {
 	mockRepo := new(MockWebhookRepository)
 
 	config := DefaultWebhookServiceConfig()
+	// httptest servers listen on loopback
+	config.AllowPrivateTargets = true
 	config.MaxRetries = 0 // No retries for test
 	svc := NewWebhookService(mockRepo, config)
 
@@ -489,6 +522,8 @@ func TestWebhookService_Deliver_Failure(t *testing.T) {
 	mockRepo := new(MockWebhookRepository)
 
 	config := DefaultWebhookServiceConfig()
+	// httptest servers listen on loopback
+	config.AllowPrivateTargets = true
 	config.MaxRetries = 0 // No retries for faster test
 	config.MaxFailures = 5
 	svc := NewWebhookService(mockRepo, config)
@@ -526,6 +561,205 @@ func TestWebhookService_Deliver_Failure(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 }
 
+func TestWebhookService_CalculateSignature(t *testing.T) {
+	mockRepo := new(MockWebhookRepository)
+	svc := NewWebhookService(mockRepo, DefaultWebhookServiceConfig()).(*webhookService)
+
+	secret := "whsec_test_secret"
+	body := []byte(`{"event":"key.created","data":{}}`)
+	timestamp := int64(1700000000)
+
+	// Signature is HMAC-SHA256 over "timestamp.body"
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(fmt.Sprintf("%d.%s", timestamp, body)))
+	expected := fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(h.Sum(nil)))
+
+	assert.Equal(t, expected, svc.calculateSignature(secret, timestamp, body))
+	assert.NotEqual(t, expected, svc.calculateSignature("whsec_other", timestamp, body))
+}
+
+func TestWebhookService_Deliver_SignedPayload(t *testing.T) {
+	secret := "whsec_test"
+	received := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		verifier := &webhookService{}
+		valid, err := verifier.VerifySignature(secret, r.Header.Get("X-Webhook-Signature"), body)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		w.WriteHeader(http.StatusOK)
+		received <- true
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := new(MockWebhookRepository)
+
+	config := DefaultWebhookServiceConfig()
+	// httptest servers listen on loopback
+	config.AllowPrivateTargets = true
+	config.MaxRetries = 0
+	svc := NewWebhookService(mockRepo, config)
+
+	orgID := uuid.New()
+	webhook := &models.Webhook{
+		ID:      uuid.New(),
+		OrgID:   orgID,
+		URL:     server.URL,
+		Secret:  secret,
+		Events:  []models.WebhookEvent{models.WebhookEventKeyDeleted},
+		Enabled: true,
+	}
+
+	mockRepo.On("ListByOrgAndEvent", ctx, orgID, models.WebhookEventKeyDeleted).Return([]*models.Webhook{webhook}, nil)
+	mockRepo.On("CreateDelivery", mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("UpdateLastTriggered", mock.Anything, webhook.ID).Return(nil)
+	mockRepo.On("ResetFailureCount", mock.Anything, webhook.ID).Return(nil)
+
+	require.NoError(t, svc.Deliver(ctx, orgID, models.WebhookEventKeyDeleted, map[string]string{"key": "value"}))
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook not received within timeout")
+	}
+}
+
+func TestWebhookService_Deliver_RetriesOn5xx(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := new(MockWebhookRepository)
+
+	config := DefaultWebhookServiceConfig()
+	// httptest servers listen on loopback
+	config.AllowPrivateTargets = true
+	config.MaxRetries = 3
+	config.RetryBackoff = time.Millisecond
+	svc := NewWebhookService(mockRepo, config)
+
+	orgID := uuid.New()
+	webhook := &models.Webhook{
+		ID:      uuid.New(),
+		OrgID:   orgID,
+		URL:     server.URL,
+		Secret:  "whsec_test",
+		Events:  []models.WebhookEvent{models.WebhookEventSignatureCompleted},
+		Enabled: true,
+	}
+
+	delivered := make(chan *models.WebhookDelivery, 1)
+	mockRepo.On("ListByOrgAndEvent", ctx, orgID, models.WebhookEventSignatureCompleted).Return([]*models.Webhook{webhook}, nil)
+	mockRepo.On("CreateDelivery", mock.Anything, mock.AnythingOfType("*models.WebhookDelivery")).
+		Run(func(args mock.Arguments) {
+			delivered <- args.Get(1).(*models.WebhookDelivery)
+		}).Return(nil)
+	mockRepo.On("UpdateLastTriggered", mock.Anything, webhook.ID).Return(nil)
+	mockRepo.On("ResetFailureCount", mock.Anything, webhook.ID).Return(nil)
+
+	require.NoError(t, svc.Deliver(ctx, orgID, models.WebhookEventSignatureCompleted, map[string]string{"key": "value"}))
+
+	select {
+	case delivery := <-delivered:
+		assert.True(t, delivery.Success)
+		assert.Equal(t, http.StatusOK, delivery.StatusCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook delivery not recorded within timeout")
+	}
+	assert.Equal(t, int32(3), attempts.Load())
+	mockRepo.AssertNotCalled(t, "IncrementFailureCount", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_Deliver_SkipsDisabled(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := new(MockWebhookRepository)
+	svc := NewWebhookService(mockRepo, DefaultWebhookServiceConfig())
+
+	orgID := uuid.New()
+	webhook := &models.Webhook{
+		ID:      uuid.New(),
+		OrgID:   orgID,
+		URL:     server.URL,
+		Secret:  "whsec_test",
+		Events:  []models.WebhookEvent{models.WebhookEventQuotaExceeded},
+		Enabled: false,
+	}
+
+	mockRepo.On("ListByOrgAndEvent", ctx, orgID, models.WebhookEventQuotaExceeded).Return([]*models.Webhook{webhook}, nil)
+
+	require.NoError(t, svc.Deliver(ctx, orgID, models.WebhookEventQuotaExceeded, map[string]string{"quota": "keys"}))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Zero(t, attempts.Load())
+	mockRepo.AssertNotCalled(t, "CreateDelivery", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_Deliver_BlocksInternalTargets(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	mockRepo := new(MockWebhookRepository)
+
+	config := DefaultWebhookServiceConfig()
+	config.MaxRetries = 0
+	svc := NewWebhookService(mockRepo, config)
+
+	orgID := uuid.New()
+	webhookID := uuid.New()
+	webhook := &models.Webhook{
+		ID:      webhookID,
+		OrgID:   orgID,
+		URL:     server.URL,
+		Secret:  "whsec_test",
+		Events:  []models.WebhookEvent{models.WebhookEventKeyCreated},
+		Enabled: true,
+	}
+
+	recorded := make(chan *models.WebhookDelivery, 1)
+	mockRepo.On("ListByOrgAndEvent", ctx, orgID, models.WebhookEventKeyCreated).Return([]*models.Webhook{webhook}, nil)
+	mockRepo.On("CreateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded <- args.Get(1).(*models.WebhookDelivery)
+	}).Return(nil)
+	mockRepo.On("UpdateLastTriggered", mock.Anything, webhookID).Return(nil)
+	mockRepo.On("IncrementFailureCount", mock.Anything, webhookID).Return(nil)
+	mockRepo.On("GetByID", mock.Anything, webhookID).Return(webhook, nil)
+
+	require.NoError(t, svc.Deliver(ctx, orgID, models.WebhookEventKeyCreated, map[string]string{"key": "value"}))
+
+	select {
+	case d := <-recorded:
+		assert.False(t, d.Success)
+		assert.Contains(t, d.Error, errWebhookTargetBlocked.Error())
+		assert.Empty(t, d.ResponseBody)
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not recorded within timeout")
+	}
+	assert.Zero(t, attempts.Load())
+}
+
 func TestWebhookService_RotateSecret(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockWebhookRepository)
@@ -87,6 +87,11 @@ document.addEventListener('htmx:configRequest', (event) => {
 
 // Handle HTMX errors
 document.addEventListener('htmx:responseError', (event) => {
+  // The server's own message is shown by the afterRequest handler below
+  if (event.detail.xhr.getResponseHeader('X-Toast-Message')) {
+    return;
+  }
+
   const status = event.detail.xhr.status;
   let message = 'Something went wrong. Please try again.';
 
@@ -176,7 +176,7 @@ func ResetPasswordPage(token, errorMsg string) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\"><div><label for=\"password\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">NEW_PASSWORD:</label> <input type=\"password\" id=\"password\" name=\"password\" required autofocus autocomplete=\"new-password\" minlength=\"8\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"><p class=\"mt-1.5 text-xs text-[#666600] uppercase\">MUST BE AT LEAST 8 CHARACTERS</p></div><div><label for=\"password_confirm\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">CONFIRM_PASSWORD:</label> <input type=\"password\" id=\"password_confirm\" name=\"password_confirm\" required autocomplete=\"new-password\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"></div><div class=\"pt-2\"><button type=\"submit\" class=\"w-full bg-[#FFB000] text-black font-bold py-3 uppercase hover:bg-[#FFCC00] hover:shadow-[0_0_20px_#FFB000] transition-all\">[ RESET PASSWORD ]</button></div></form><p class=\"mt-6 text-center text-sm text-[#666600] uppercase\"><a href=\"/login\" class=\"text-[#FFB000] hover:drop-shadow-[0_0_8px_#FFB000] font-medium transition-all\">BACK TO LOGIN</a></p>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "\"><div><label for=\"password\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">NEW_PASSWORD:</label> <input type=\"password\" id=\"password\" name=\"password\" required autofocus autocomplete=\"new-password\" minlength=\"8\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"><p class=\"mt-1.5 text-xs text-[#666600] uppercase\">AT LEAST 8 CHARACTERS, WITH A NUMBER OR SYMBOL</p></div><div><label for=\"password_confirm\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">CONFIRM_PASSWORD:</label> <input type=\"password\" id=\"password_confirm\" name=\"password_confirm\" required autocomplete=\"new-password\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"></div><div class=\"pt-2\"><button type=\"submit\" class=\"w-full bg-[#FFB000] text-black font-bold py-3 uppercase hover:bg-[#FFCC00] hover:shadow-[0_0_20px_#FFB000] transition-all\">[ RESET PASSWORD ]</button></div></form><p class=\"mt-6 text-center text-sm text-[#666600] uppercase\"><a href=\"/login\" class=\"text-[#FFB000] hover:drop-shadow-[0_0_8px_#FFB000] font-medium transition-all\">BACK TO LOGIN</a></p>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -670,20 +670,59 @@ func KeyDetailPage(data KeyDetailData) templ.Component {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "<div class=\"flex gap-3 pt-4 border-t border-[#333300]\"><a href=\"/docs\" class=\"px-4 py-2 border border-[#FFB000] text-[#FFB000] hover:bg-[#FFB000]/10 transition-colors uppercase\">📚 FULL DOCS</a> <a href=\"/docs#celestia-client\" class=\"px-4 py-2 border border-[#33FF00] text-[#33FF00] hover:bg-[#33FF00]/10 transition-colors uppercase\">🌌 CELESTIA</a> <a href=\"https://github.com/Bidon15/popsigner/tree/main/examples\" target=\"_blank\" class=\"px-4 py-2 border border-[#666600] text-[#666600] hover:text-[#FFB000] hover:border-[#FFB000] transition-colors uppercase\">💡 EXAMPLES ↗</a></div></div></div><!-- Danger Zone --><div class=\"bg-black border border-[#FF3333] p-6\"><h2 class=\"text-lg text-[#FF3333] mb-4 uppercase\">&gt; DANGER_ZONE</h2><div class=\"flex flex-col sm:flex-row sm:items-center justify-between gap-4 p-4 bg-[#FF3333]/5 border border-[#FF3333]/20\"><div><p class=\"text-[#FF3333] font-medium uppercase\">DELETE THIS KEY PERMANENTLY</p><p class=\"text-sm text-[#666600] mt-1 uppercase\">THIS ACTION CANNOT BE UNDONE. KEY MATERIAL WILL BE DESTROYED.</p></div><button hx-delete=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 61, "<div class=\"flex gap-3 pt-4 border-t border-[#333300]\"><a href=\"/docs\" class=\"px-4 py-2 border border-[#FFB000] text-[#FFB000] hover:bg-[#FFB000]/10 transition-colors uppercase\">📚 FULL DOCS</a> <a href=\"/docs#celestia-client\" class=\"px-4 py-2 border border-[#33FF00] text-[#33FF00] hover:bg-[#33FF00]/10 transition-colors uppercase\">🌌 CELESTIA</a> <a href=\"https://github.com/Bidon15/popsigner/tree/main/examples\" target=\"_blank\" class=\"px-4 py-2 border border-[#666600] text-[#666600] hover:text-[#FFB000] hover:border-[#FFB000] transition-colors uppercase\">💡 EXAMPLES ↗</a></div></div></div><!-- Danger Zone --><div class=\"bg-black border border-[#FF3333] p-6\"><h2 class=\"text-lg text-[#FF3333] mb-4 uppercase\">&gt; DANGER_ZONE</h2><form hx-post=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var39 string
-			templ_7745c5c3_Var39, templ_7745c5c3_Err = templ.JoinStringErrs("/keys/" + data.Key.ID.String())
+			templ_7745c5c3_Var39, templ_7745c5c3_Err = templ.JoinStringErrs("/keys/" + data.Key.ID.String() + "/rotate")
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 389, Col: 56}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 384, Col: 63}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var39))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "\" hx-confirm=\"Are you absolutely sure you want to delete this key? This action cannot be undone and the key material will be permanently destroyed.\" hx-target=\"#main-content\" hx-push-url=\"/keys\" class=\"px-4 py-2.5 bg-[#FF3333]/10 border border-[#FF3333] text-[#FF3333] hover:bg-[#FF3333]/20 transition-colors font-medium shrink-0 uppercase\">🗑️ DELETE_KEY</button></div></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 62, "\" hx-confirm=\"Rotate this key? New key material will be generated and its addresses will change.\" hx-target=\"#toast-container\" class=\"flex flex-col sm:flex-row sm:items-center justify-between gap-4 p-4 mb-4 bg-[#FFB000]/5 border border-[#FFB000]/20\"><div><p class=\"text-[#FFB000] font-medium uppercase\">ROTATE KEY MATERIAL</p><p class=\"text-sm text-[#666600] mt-1 uppercase\">GENERATES v")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var40 string
+			templ_7745c5c3_Var40, templ_7745c5c3_Err = templ.JoinStringErrs(formatVersion(data.Key.Version + 1))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 390, Col: 103}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var40))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, ". THE KEY ID STAYS THE SAME; PUBLIC KEY AND ADDRESSES CHANGE.</p><label class=\"flex items-center gap-2 mt-2 text-sm text-[#666600] uppercase\"><input type=\"checkbox\" name=\"allow_previous_version_signing\" class=\"accent-[#FFB000]\"> KEEP v")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var41 string
+			templ_7745c5c3_Var41, templ_7745c5c3_Err = templ.JoinStringErrs(formatVersion(data.Key.Version))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 393, Col: 46}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var41))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, " AVAILABLE FOR SIGNING</label></div><button type=\"submit\" class=\"px-4 py-2.5 bg-[#FFB000]/10 border border-[#FFB000] text-[#FFB000] hover:bg-[#FFB000]/20 transition-colors font-medium shrink-0 uppercase\">🔄 ROTATE_KEY</button></form><div class=\"flex flex-col sm:flex-row sm:items-center justify-between gap-4 p-4 bg-[#FF3333]/5 border border-[#FF3333]/20\"><div><p class=\"text-[#FF3333] font-medium uppercase\">DELETE THIS KEY</p><p class=\"text-sm text-[#666600] mt-1 uppercase\">THE KEY CAN BE RESTORED VIA THE API FOR 7 DAYS. KEY MATERIAL IS DESTROYED AFTER THAT.</p></div><button hx-delete=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var42 string
+			templ_7745c5c3_Var42, templ_7745c5c3_Err = templ.JoinStringErrs("/keys/" + data.Key.ID.String())
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 406, Col: 56}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var42))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "\" hx-confirm=\"Are you sure you want to delete this key? It can be restored for 7 days, after which the key material will be permanently destroyed.\" hx-target=\"#main-content\" hx-push-url=\"/keys\" class=\"px-4 py-2.5 bg-[#FF3333]/10 border border-[#FF3333] text-[#FF3333] hover:bg-[#FF3333]/20 transition-colors font-medium shrink-0 uppercase\">🗑️ DELETE_KEY</button></div></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -721,12 +760,12 @@ func signingChartScript(stats *SigningStats) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var40 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var40 == nil {
-			templ_7745c5c3_Var40 = templ.NopComponent
+		templ_7745c5c3_Var43 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var43 == nil {
+			templ_7745c5c3_Var43 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 63, "<script>\n\t\t(function() {\n\t\t\tconst ctx = document.getElementById('signing-chart');\n\t\t\tif (!ctx) return;\n\t\t\t\n\t\t\tconst labels = JSON.parse('{ templ.EscapeString(mustMarshalJSON(stats.Labels)) }');\n\t\t\tconst values = JSON.parse('{ templ.EscapeString(mustMarshalJSON(stats.Values)) }');\n\t\t\t\n\t\t\tnew Chart(ctx, {\n\t\t\t\ttype: 'line',\n\t\t\t\tdata: {\n\t\t\t\t\tlabels: labels,\n\t\t\t\t\tdatasets: [{\n\t\t\t\t\t\tdata: values,\n\t\t\t\t\t\tborderColor: '#FFB000',\n\t\t\t\t\t\tbackgroundColor: 'rgba(255, 176, 0, 0.1)',\n\t\t\t\t\t\tborderWidth: 2,\n\t\t\t\t\t\tfill: true,\n\t\t\t\t\t\ttension: 0.4,\n\t\t\t\t\t\tpointBackgroundColor: '#FFB000',\n\t\t\t\t\t\tpointBorderColor: '#000000',\n\t\t\t\t\t\tpointBorderWidth: 2,\n\t\t\t\t\t\tpointRadius: 0,\n\t\t\t\t\t\tpointHoverRadius: 6\n\t\t\t\t\t}]\n\t\t\t\t},\n\t\t\t\toptions: {\n\t\t\t\t\tresponsive: true,\n\t\t\t\t\tmaintainAspectRatio: false,\n\t\t\t\t\tinteraction: {\n\t\t\t\t\t\tintersect: false,\n\t\t\t\t\t\tmode: 'index'\n\t\t\t\t\t},\n\t\t\t\t\tplugins: {\n\t\t\t\t\t\tlegend: { display: false },\n\t\t\t\t\t\ttooltip: {\n\t\t\t\t\t\t\tbackgroundColor: '#000000',\n\t\t\t\t\t\t\tborderColor: '#333300',\n\t\t\t\t\t\t\tborderWidth: 1,\n\t\t\t\t\t\t\ttitleColor: '#FFB000',\n\t\t\t\t\t\t\tbodyColor: '#33FF00',\n\t\t\t\t\t\t\tpadding: 12,\n\t\t\t\t\t\t\tdisplayColors: false,\n\t\t\t\t\t\t\ttitleFont: { family: 'monospace' },\n\t\t\t\t\t\t\tbodyFont: { family: 'monospace' },\n\t\t\t\t\t\t\tcallbacks: {\n\t\t\t\t\t\t\t\tlabel: function(context) {\n\t\t\t\t\t\t\t\t\treturn context.parsed.y + ' SIGNATURES';\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t},\n\t\t\t\t\tscales: {\n\t\t\t\t\t\tx: {\n\t\t\t\t\t\t\tgrid: { color: 'rgba(51, 51, 0, 0.5)', drawBorder: false },\n\t\t\t\t\t\t\tticks: { color: '#666600', font: { size: 11, family: 'monospace' }, maxRotation: 0 }\n\t\t\t\t\t\t},\n\t\t\t\t\t\ty: {\n\t\t\t\t\t\t\tgrid: { color: 'rgba(51, 51, 0, 0.5)', drawBorder: false },\n\t\t\t\t\t\t\tticks: { color: '#666600', font: { size: 11, family: 'monospace' }, precision: 0 },\n\t\t\t\t\t\t\tbeginAtZero: true\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t});\n\t\t})();\n\t</script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 66, "<script>\n\t\t(function() {\n\t\t\tconst ctx = document.getElementById('signing-chart');\n\t\t\tif (!ctx) return;\n\t\t\t\n\t\t\tconst labels = JSON.parse('{ templ.EscapeString(mustMarshalJSON(stats.Labels)) }');\n\t\t\tconst values = JSON.parse('{ templ.EscapeString(mustMarshalJSON(stats.Values)) }');\n\t\t\t\n\t\t\tnew Chart(ctx, {\n\t\t\t\ttype: 'line',\n\t\t\t\tdata: {\n\t\t\t\t\tlabels: labels,\n\t\t\t\t\tdatasets: [{\n\t\t\t\t\t\tdata: values,\n\t\t\t\t\t\tborderColor: '#FFB000',\n\t\t\t\t\t\tbackgroundColor: 'rgba(255, 176, 0, 0.1)',\n\t\t\t\t\t\tborderWidth: 2,\n\t\t\t\t\t\tfill: true,\n\t\t\t\t\t\ttension: 0.4,\n\t\t\t\t\t\tpointBackgroundColor: '#FFB000',\n\t\t\t\t\t\tpointBorderColor: '#000000',\n\t\t\t\t\t\tpointBorderWidth: 2,\n\t\t\t\t\t\tpointRadius: 0,\n\t\t\t\t\t\tpointHoverRadius: 6\n\t\t\t\t\t}]\n\t\t\t\t},\n\t\t\t\toptions: {\n\t\t\t\t\tresponsive: true,\n\t\t\t\t\tmaintainAspectRatio: false,\n\t\t\t\t\tinteraction: {\n\t\t\t\t\t\tintersect: false,\n\t\t\t\t\t\tmode: 'index'\n\t\t\t\t\t},\n\t\t\t\t\tplugins: {\n\t\t\t\t\t\tlegend: { display: false },\n\t\t\t\t\t\ttooltip: {\n\t\t\t\t\t\t\tbackgroundColor: '#000000',\n\t\t\t\t\t\t\tborderColor: '#333300',\n\t\t\t\t\t\t\tborderWidth: 1,\n\t\t\t\t\t\t\ttitleColor: '#FFB000',\n\t\t\t\t\t\t\tbodyColor: '#33FF00',\n\t\t\t\t\t\t\tpadding: 12,\n\t\t\t\t\t\t\tdisplayColors: false,\n\t\t\t\t\t\t\ttitleFont: { family: 'monospace' },\n\t\t\t\t\t\t\tbodyFont: { family: 'monospace' },\n\t\t\t\t\t\t\tcallbacks: {\n\t\t\t\t\t\t\t\tlabel: function(context) {\n\t\t\t\t\t\t\t\t\treturn context.parsed.y + ' SIGNATURES';\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t}\n\t\t\t\t\t},\n\t\t\t\t\tscales: {\n\t\t\t\t\t\tx: {\n\t\t\t\t\t\t\tgrid: { color: 'rgba(51, 51, 0, 0.5)', drawBorder: false },\n\t\t\t\t\t\t\tticks: { color: '#666600', font: { size: 11, family: 'monospace' }, maxRotation: 0 }\n\t\t\t\t\t\t},\n\t\t\t\t\t\ty: {\n\t\t\t\t\t\t\tgrid: { color: 'rgba(51, 51, 0, 0.5)', drawBorder: false },\n\t\t\t\t\t\t\tticks: { color: '#666600', font: { size: 11, family: 'monospace' }, precision: 0 },\n\t\t\t\t\t\t\tbeginAtZero: true\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t});\n\t\t})();\n\t</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -841,25 +880,25 @@ func codeBlock(lang string, code string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var41 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var41 == nil {
-			templ_7745c5c3_Var41 = templ.NopComponent
+		templ_7745c5c3_Var44 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var44 == nil {
+			templ_7745c5c3_Var44 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 64, "<pre class=\"p-4 bg-black border border-[#1A4D1A] text-sm overflow-x-auto font-mono\"><code class=\"text-[#33FF00]\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 67, "<pre class=\"p-4 bg-black border border-[#1A4D1A] text-sm overflow-x-auto font-mono\"><code class=\"text-[#33FF00]\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var42 string
-		templ_7745c5c3_Var42, templ_7745c5c3_Err = templ.JoinStringErrs(code)
+		var templ_7745c5c3_Var45 string
+		templ_7745c5c3_Var45, templ_7745c5c3_Err = templ.JoinStringErrs(code)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 565, Col: 120}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/keys_detail.templ`, Line: 582, Col: 120}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var42))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var45))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 65, "</code></pre>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 68, "</code></pre>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
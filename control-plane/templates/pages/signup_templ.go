@@ -87,7 +87,41 @@ func SignupPage(errorMsg string, formValues SignupFormValues) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div><!-- Features --> <div class=\"mt-8 p-4 bg-black border border-[#1A4D1A]\"><p class=\"text-sm font-medium text-[#FFB000] mb-3 uppercase\">&gt; POPSIGNER INCLUDES:</p><ul class=\"space-y-2 text-sm text-[#33FF00]\"><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>VAULT-GRADE KEY SECURITY</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>PARALLEL WORKER SUPPORT</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>FULL AUDIT TRAIL</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>EXIT GUARANTEE - YOUR KEYS, ALWAYS</span></li></ul></div><p class=\"mt-6 text-center text-sm text-[#666600] uppercase\">ALREADY DEPLOYED? <a href=\"/login\" class=\"text-[#FFB000] hover:drop-shadow-[0_0_8px_#FFB000] font-medium transition-all\">LOGIN</a></p><p class=\"mt-4 text-center text-xs text-[#666600] uppercase\">BY SIGNING UP, YOU AGREE TO OUR  <a href=\"/terms\" class=\"text-[#FFB000] hover:underline\">TERMS</a> AND  <a href=\"/privacy\" class=\"text-[#FFB000] hover:underline\">PRIVACY POLICY</a></p>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = EmailDivider().Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<form action=\"/signup\" method=\"POST\" class=\"space-y-4\"><div><label for=\"name\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">NAME:</label> <input type=\"text\" id=\"name\" name=\"name\" required autocomplete=\"name\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var4 string
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(formValues.Name)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/signup.templ`, Line: 44, Col: 28}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"Ada Lovelace\"></div><div><label for=\"email\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">EMAIL:</label> <input type=\"email\" id=\"email\" name=\"email\" required autocomplete=\"email\" value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(formValues.Email)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `templates/pages/signup.templ`, Line: 58, Col: 29}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"user@example.com\"></div><div><label for=\"password\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">PASSWORD:</label> <input type=\"password\" id=\"password\" name=\"password\" required autocomplete=\"new-password\" minlength=\"8\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"><p class=\"mt-1.5 text-xs text-[#666600] uppercase\">AT LEAST 8 CHARACTERS, WITH A NUMBER OR SYMBOL</p></div><div><label for=\"password_confirm\" class=\"block text-sm text-[#228B22] mb-1.5 uppercase\">CONFIRM_PASSWORD:</label> <input type=\"password\" id=\"password_confirm\" name=\"password_confirm\" required autocomplete=\"new-password\" class=\"w-full px-4 py-3 bg-black border border-[#1A4D1A] text-[#33FF00] placeholder-[#336633] focus:border-[#33FF00] focus:shadow-[0_0_10px_rgba(51,255,0,0.3)] focus:outline-none transition-colors font-mono\" placeholder=\"••••••••\"></div><div class=\"pt-2\"><button type=\"submit\" class=\"w-full bg-[#FFB000] text-black font-bold py-3 uppercase hover:bg-[#FFCC00] hover:shadow-[0_0_20px_#FFB000] transition-all\">[ CREATE ACCOUNT ]</button></div></form><!-- Features --> <div class=\"mt-8 p-4 bg-black border border-[#1A4D1A]\"><p class=\"text-sm font-medium text-[#FFB000] mb-3 uppercase\">&gt; POPSIGNER INCLUDES:</p><ul class=\"space-y-2 text-sm text-[#33FF00]\"><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>VAULT-GRADE KEY SECURITY</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>PARALLEL WORKER SUPPORT</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>FULL AUDIT TRAIL</span></li><li class=\"flex items-center gap-2 uppercase\"><span class=\"text-[#33FF00]\">✓</span> <span>EXIT GUARANTEE - YOUR KEYS, ALWAYS</span></li></ul></div><p class=\"mt-6 text-center text-sm text-[#666600] uppercase\">ALREADY DEPLOYED? <a href=\"/login\" class=\"text-[#FFB000] hover:drop-shadow-[0_0_8px_#FFB000] font-medium transition-all\">LOGIN</a></p><p class=\"mt-4 text-center text-xs text-[#666600] uppercase\">BY SIGNING UP, YOU AGREE TO OUR  <a href=\"/terms\" class=\"text-[#FFB000] hover:underline\">TERMS</a> AND  <a href=\"/privacy\" class=\"text-[#FFB000] hover:underline\">PRIVACY POLICY</a></p>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
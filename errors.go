@@ -8,8 +8,11 @@ import (
 // Sentinel errors - Configuration
 var (
 	ErrMissingBaoAddr   = errors.New("popsigner: BaoAddr is required")
-	ErrMissingBaoToken  = errors.New("popsigner: BaoToken is required")
+	ErrMissingBaoToken  = errors.New("popsigner: BaoToken or BaoTokenFile is required")
 	ErrMissingStorePath = errors.New("popsigner: StorePath is required")
+
+	ErrMissingKubernetesRole = errors.New("popsigner: KubernetesRole is required for Kubernetes auth")
+	ErrUnsupportedAuthMethod = errors.New("popsigner: unsupported AuthMethod")
 )
 
 // Sentinel errors - Keys
@@ -29,11 +32,18 @@ var (
 
 // Sentinel errors - Operations
 var (
-	ErrSigningFailed    = errors.New("popsigner: signing failed")
-	ErrInvalidSignature = errors.New("popsigner: invalid signature")
-	ErrUnsupportedAlgo  = errors.New("popsigner: unsupported algorithm")
-	ErrStorePersist     = errors.New("popsigner: failed to persist")
-	ErrStoreCorrupted   = errors.New("popsigner: store corrupted")
+	ErrSigningFailed       = errors.New("popsigner: signing failed")
+	ErrInvalidSignature    = errors.New("popsigner: invalid signature")
+	ErrInvalidDigest       = errors.New("popsigner: prehashed data must be a 32-byte digest")
+	ErrUnsupportedAlgo     = errors.New("popsigner: unsupported algorithm")
+	ErrUnsupportedSignMode = errors.New("popsigner: unsupported sign mode")
+	ErrUnsupportedFormat   = errors.New("popsigner: unsupported signature format")
+	ErrStorePersist        = errors.New("popsigner: failed to persist")
+	ErrStoreCorrupted      = errors.New("popsigner: store corrupted")
+	ErrStoreEncrypted      = errors.New("popsigner: store is encrypted but no key is configured")
+	ErrStoreDecrypt        = errors.New("popsigner: failed to decrypt store")
+	ErrInvalidStoreKey     = errors.New("popsigner: invalid store encryption key")
+	ErrUnsupportedBackend  = errors.New("popsigner: operation not supported by signing backend")
 )
 
 // BaoError represents an OpenBao API error.
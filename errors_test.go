@@ -268,6 +268,8 @@ func TestSentinelErrors(t *testing.T) {
 		ErrMissingBaoAddr,
 		ErrMissingBaoToken,
 		ErrMissingStorePath,
+		ErrMissingKubernetesRole,
+		ErrUnsupportedAuthMethod,
 		ErrKeyNotFound,
 		ErrKeyExists,
 		ErrKeyNotExportable,
@@ -278,8 +280,14 @@ func TestSentinelErrors(t *testing.T) {
 		ErrSigningFailed,
 		ErrInvalidSignature,
 		ErrUnsupportedAlgo,
+		ErrUnsupportedSignMode,
+		ErrUnsupportedFormat,
 		ErrStorePersist,
 		ErrStoreCorrupted,
+		ErrStoreEncrypted,
+		ErrStoreDecrypt,
+		ErrInvalidStoreKey,
+		ErrUnsupportedBackend,
 	}
 
 	for i, err1 := range sentinelErrors {
@@ -301,6 +309,8 @@ func TestSentinelErrorMessages(t *testing.T) {
 		{ErrMissingBaoAddr, "BaoAddr"},
 		{ErrMissingBaoToken, "BaoToken"},
 		{ErrMissingStorePath, "StorePath"},
+		{ErrMissingKubernetesRole, "KubernetesRole"},
+		{ErrUnsupportedAuthMethod, "AuthMethod"},
 		{ErrKeyNotFound, "key not found"},
 		{ErrKeyExists, "key already exists"},
 		{ErrKeyNotExportable, "not exportable"},
@@ -311,8 +321,14 @@ func TestSentinelErrorMessages(t *testing.T) {
 		{ErrSigningFailed, "signing"},
 		{ErrInvalidSignature, "signature"},
 		{ErrUnsupportedAlgo, "algorithm"},
+		{ErrUnsupportedSignMode, "sign mode"},
+		{ErrUnsupportedFormat, "signature format"},
 		{ErrStorePersist, "persist"},
 		{ErrStoreCorrupted, "corrupted"},
+		{ErrStoreEncrypted, "encrypted"},
+		{ErrStoreDecrypt, "decrypt"},
+		{ErrInvalidStoreKey, "encryption key"},
+		{ErrUnsupportedBackend, "backend"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.err.Error(), func(t *testing.T) {
@@ -0,0 +1,189 @@
+package popsigner
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// KMSAPI is the subset of the AWS KMS client used by KMSClient.
+// *kms.Client satisfies it.
+type KMSAPI interface {
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+}
+
+// KMSClient is a Signer backed by AWS KMS asymmetric ECC_SECG_P256K1 keys.
+//
+// Key names are passed to KMS as key IDs. Names that are not already a key
+// ID, ARN or alias are looked up as "alias/<name>", so a keyring UID can be
+// used directly when the KMS key has a matching alias.
+type KMSClient struct {
+	api KMSAPI
+}
+
+// NewKMSClient creates a KMS signing backend.
+func NewKMSClient(api KMSAPI) *KMSClient {
+	return &KMSClient{api: api}
+}
+
+// secp256k1 curve identifiers used in KMS SubjectPublicKeyInfo encodings.
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// Sign signs data with the KMS key using ECDSA_SHA_256 and returns a
+// 64-byte R||S signature normalized to low-S.
+func (c *KMSClient) Sign(ctx context.Context, keyName string, data []byte, prehashed bool) ([]byte, error) {
+	digest := data
+	if !prehashed {
+		sum := sha256.Sum256(data)
+		digest = sum[:]
+	}
+	if len(digest) != sha256.Size {
+		return nil, WrapKeyError("sign", keyName, fmt.Errorf("%w: %w, got %d bytes", ErrSigningFailed, ErrInvalidDigest, len(digest)))
+	}
+
+	out, err := c.api.Sign(ctx, &kms.SignInput{
+		KeyId:            kmsKeyID(keyName),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, WrapKeyError("sign", keyName, fmt.Errorf("%w: %v", ErrSigningFailed, err))
+	}
+
+	sig, err := normalizeDERSignature(out.Signature)
+	if err != nil {
+		return nil, WrapKeyError("sign", keyName, err)
+	}
+	return sig, nil
+}
+
+// PublicKey returns the compressed public key of the KMS key.
+func (c *KMSClient) PublicKey(ctx context.Context, keyName string) ([]byte, error) {
+	out, err := c.api.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: kmsKeyID(keyName)})
+	if err != nil {
+		return nil, WrapKeyError("get", keyName, err)
+	}
+	if out.KeySpec != "" && out.KeySpec != kmstypes.KeySpecEccSecgP256k1 {
+		return nil, WrapKeyError("get", keyName, fmt.Errorf("%w: key spec %s", ErrUnsupportedAlgo, out.KeySpec))
+	}
+
+	pubKey, err := parseSecp256k1SPKI(out.PublicKey)
+	if err != nil {
+		return nil, WrapKeyError("get", keyName, err)
+	}
+	return pubKey, nil
+}
+
+// Address returns the hex-encoded Cosmos address of the KMS key.
+func (c *KMSClient) Address(ctx context.Context, keyName string) (string, error) {
+	pubKey, err := c.PublicKey(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+	return cosmosAddressHex(pubKey), nil
+}
+
+// kmsKeyID maps a key name to a KMS key identifier.
+func kmsKeyID(keyName string) *string {
+	id := keyName
+	if !strings.HasPrefix(id, "alias/") && !strings.HasPrefix(id, "arn:") && !isKMSKeyUUID(id) {
+		id = "alias/" + id
+	}
+	return &id
+}
+
+// isKMSKeyUUID reports whether s looks like a bare KMS key ID
+// (or a multi-Region key ID prefixed with "mrk-").
+func isKMSKeyUUID(s string) bool {
+	s = strings.TrimPrefix(s, "mrk-")
+	if len(s) == 32 {
+		return isHex(s)
+	}
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return false
+	}
+	return isHex(strings.ReplaceAll(s, "-", ""))
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSecp256k1SPKI parses a DER SubjectPublicKeyInfo for a secp256k1 key,
+// as returned by KMS GetPublicKey, into a compressed public key.
+// crypto/x509 does not support secp256k1, so the structure is decoded directly.
+func parseSecp256k1SPKI(der []byte) ([]byte, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: invalid public key encoding", ErrUnsupportedAlgo)
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidECPublicKey) {
+		return nil, fmt.Errorf("%w: not an EC public key", ErrUnsupportedAlgo)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("%w: curve is not secp256k1", ErrUnsupportedAlgo)
+	}
+
+	pubKey, err := secp256k1.ParsePubKey(spki.PublicKey.RightAlign())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedAlgo, err)
+	}
+	return pubKey.SerializeCompressed(), nil
+}
+
+// normalizeDERSignature converts a DER-encoded ECDSA signature into the
+// 64-byte R||S format used by Cosmos, negating S if needed (BIP-62 low-S).
+func normalizeDERSignature(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil || len(rest) != 0 || sig.R == nil || sig.S == nil {
+		return nil, fmt.Errorf("%w: malformed DER signature", ErrInvalidSignature)
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: signature values out of range", ErrInvalidSignature)
+	}
+
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(sig.R.Bytes()); overflow || r.IsZero() {
+		return nil, fmt.Errorf("%w: R out of range", ErrInvalidSignature)
+	}
+	if overflow := s.SetByteSlice(sig.S.Bytes()); overflow || s.IsZero() {
+		return nil, fmt.Errorf("%w: S out of range", ErrInvalidSignature)
+	}
+	if s.IsOverHalfOrder() {
+		s.Negate()
+	}
+
+	result := make([]byte, 64)
+	r.PutBytesUnchecked(result[:32])
+	s.PutBytesUnchecked(result[32:])
+	return result, nil
+}
+
+// Compile-time check to ensure KMSClient implements Signer.
+var _ Signer = (*KMSClient)(nil)
@@ -0,0 +1,228 @@
+package popsigner
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	cosmossecp "github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKMS is an in-memory KMSAPI backed by a local secp256k1 key.
+type mockKMS struct {
+	priv     *secp256k1.PrivateKey
+	keyID    string
+	highS    bool // return signatures with a high S value
+	lastSign *kms.SignInput
+}
+
+func newMockKMS(t *testing.T, keyID string) *mockKMS {
+	priv, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	return &mockKMS{priv: priv, keyID: keyID}
+}
+
+func (m *mockKMS) Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error) {
+	m.lastSign = params
+	if *params.KeyId != m.keyID {
+		return nil, errors.New("NotFoundException: key not found")
+	}
+
+	sig := ecdsa.Sign(m.priv, params.Message)
+	r, s := sig.R(), sig.S()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	rInt := new(big.Int).SetBytes(rBytes[:])
+	sInt := new(big.Int).SetBytes(sBytes[:])
+	if m.highS {
+		sInt.Sub(secp256k1.S256().N, sInt)
+	}
+
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{rInt, sInt})
+	if err != nil {
+		return nil, err
+	}
+	return &kms.SignOutput{
+		KeyId:            params.KeyId,
+		Signature:        der,
+		SigningAlgorithm: params.SigningAlgorithm,
+	}, nil
+}
+
+func (m *mockKMS) GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	if *params.KeyId != m.keyID {
+		return nil, errors.New("NotFoundException: key not found")
+	}
+	der, err := marshalSecp256k1SPKI(m.priv.PubKey())
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GetPublicKeyOutput{
+		KeyId:     params.KeyId,
+		KeySpec:   kmstypes.KeySpecEccSecgP256k1,
+		KeyUsage:  kmstypes.KeyUsageTypeSignVerify,
+		PublicKey: der,
+	}, nil
+}
+
+// marshalSecp256k1SPKI encodes a public key the way KMS returns it.
+func marshalSecp256k1SPKI(pub *secp256k1.PublicKey) ([]byte, error) {
+	params, err := asn1.Marshal(oidSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	point := pub.SerializeUncompressed()
+	return asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidECPublicKey, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+func TestKMSClient_PublicKeyAndAddress(t *testing.T) {
+	api := newMockKMS(t, "alias/sequencer")
+	client := NewKMSClient(api)
+
+	pubKey, err := client.PublicKey(context.Background(), "sequencer")
+	require.NoError(t, err)
+	assert.Equal(t, api.priv.PubKey().SerializeCompressed(), pubKey)
+
+	addr, err := client.Address(context.Background(), "sequencer")
+	require.NoError(t, err)
+	assert.Equal(t, cosmosAddressHex(pubKey), addr)
+	assert.Len(t, addr, 40)
+}
+
+func TestKMSClient_Sign(t *testing.T) {
+	for _, highS := range []bool{false, true} {
+		api := newMockKMS(t, "alias/sequencer")
+		api.highS = highS
+		client := NewKMSClient(api)
+		msg := []byte("transaction data")
+
+		sig, err := client.Sign(context.Background(), "sequencer", msg, false)
+		require.NoError(t, err)
+		require.Len(t, sig, 64)
+
+		// S must be normalized to the lower half of the curve order.
+		var s secp256k1.ModNScalar
+		s.SetByteSlice(sig[32:])
+		assert.False(t, s.IsOverHalfOrder(), "highS=%v", highS)
+
+		// The signature verifies against the key's public key.
+		pubKey := &cosmossecp.PubKey{Key: api.priv.PubKey().SerializeCompressed()}
+		assert.True(t, pubKey.VerifySignature(msg, sig), "highS=%v", highS)
+
+		digest := sha256.Sum256(msg)
+		assert.Equal(t, digest[:], api.lastSign.Message)
+		assert.Equal(t, kmstypes.MessageTypeDigest, api.lastSign.MessageType)
+		assert.Equal(t, kmstypes.SigningAlgorithmSpecEcdsaSha256, api.lastSign.SigningAlgorithm)
+	}
+}
+
+func TestKMSClient_Sign_Errors(t *testing.T) {
+	client := NewKMSClient(newMockKMS(t, "alias/sequencer"))
+
+	_, err := client.Sign(context.Background(), "unknown", []byte("msg"), false)
+	assert.ErrorIs(t, err, ErrSigningFailed)
+
+	_, err = client.Sign(context.Background(), "sequencer", []byte("not a digest"), true)
+	assert.ErrorIs(t, err, ErrSigningFailed)
+	assert.ErrorIs(t, err, ErrInvalidDigest)
+}
+
+func TestKMSKeyID(t *testing.T) {
+	tests := map[string]string{
+		"sequencer":                            "alias/sequencer",
+		"alias/batcher":                        "alias/batcher",
+		"1234abcd-12ab-34cd-56ef-1234567890ab": "1234abcd-12ab-34cd-56ef-1234567890ab",
+		"mrk-1234abcd12ab34cd56ef1234567890ab": "mrk-1234abcd12ab34cd56ef1234567890ab",
+		"arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab": "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, *kmsKeyID(name), name)
+	}
+}
+
+func TestNormalizeDERSignature_Invalid(t *testing.T) {
+	_, err := normalizeDERSignature([]byte{0x30, 0x01})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	zero, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(0), big.NewInt(1)})
+	require.NoError(t, err)
+	_, err = normalizeDERSignature(zero)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestParseSecp256k1SPKI_RejectsOtherCurves(t *testing.T) {
+	params, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}) // P-256
+	require.NoError(t, err)
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidECPublicKey, Parameters: asn1.RawValue{FullBytes: params}},
+		PublicKey: asn1.BitString{Bytes: []byte{0x04}, BitLength: 8},
+	})
+	require.NoError(t, err)
+
+	_, err = parseSecp256k1SPKI(der)
+	assert.ErrorIs(t, err, ErrUnsupportedAlgo)
+}
+
+func TestBaoKeyring_KMSBackend(t *testing.T) {
+	api := newMockKMS(t, "alias/sequencer")
+	api.highS = true
+
+	kr, err := New(context.Background(), Config{
+		StorePath: filepath.Join(t.TempDir(), "store.json"),
+		Backend:   NewKMSClient(api),
+	})
+	require.NoError(t, err)
+	defer func() { _ = kr.Close() }()
+
+	record, err := kr.AddBackendKey("sequencer")
+	require.NoError(t, err)
+	assert.Equal(t, "sequencer", record.Name)
+
+	msg := []byte("blob data")
+	sig, pubKey, err := kr.Sign("sequencer", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+
+	// The recorded address matches the signing key.
+	meta, err := kr.GetMetadata("sequencer")
+	require.NoError(t, err)
+	assert.Equal(t, cosmosAddressHex(pubKey.Bytes()), meta.Address)
+	assert.Equal(t, SourceSynced, meta.Source)
+
+	// OpenBao-only operations are rejected.
+	_, err = kr.NewAccountWithOptions("other", KeyOptions{})
+	assert.ErrorIs(t, err, ErrUnsupportedBackend)
+
+	// Delete only removes local metadata.
+	require.NoError(t, kr.Delete("sequencer"))
+	_, err = kr.Key("sequencer")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestConfig_Validate_Backend(t *testing.T) {
+	cfg := Config{Backend: NewKMSClient(nil)}
+	assert.ErrorIs(t, cfg.Validate(), ErrMissingStorePath)
+
+	cfg.StorePath = "/tmp/store.json"
+	assert.NoError(t, cfg.Validate())
+}
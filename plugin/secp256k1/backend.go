@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/go-metrics"
 	"github.com/openbao/openbao/sdk/v2/framework"
 	"github.com/openbao/openbao/sdk/v2/logical"
 )
@@ -14,6 +15,7 @@ import (
 func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend, error) {
 	b := &backend{
 		keyCache: make(map[string]*keyEntry),
+		metrics:  metrics.Default(),
 	}
 
 	b.Backend = &framework.Backend{
@@ -26,9 +28,11 @@ func Factory(ctx context.Context, conf *logical.BackendConfig) (logical.Backend,
 			pathVerify(b),
 			pathImport(b),
 			pathExport(b),
+			pathRotate(b),
+			pathDerive(b),
 		),
 		PathsSpecial: &logical.Paths{
-			SealWrapStorage: []string{"keys/"},
+			SealWrapStorage: []string{"keys/", "hd/"},
 		},
 		Invalidate: b.invalidate,
 		Clean:      b.cleanup,
@@ -47,6 +51,9 @@ type backend struct {
 	*framework.Backend
 	cacheMu  sync.RWMutex
 	keyCache map[string]*keyEntry
+	rotateMu sync.Mutex
+	seedMu   sync.Mutex
+	metrics  *metrics.Metrics
 }
 
 // invalidate is called when a watched key is modified.
@@ -163,6 +170,8 @@ Features:
   - Sign messages with ECDSA (Cosmos-compatible R||S format)
   - Verify signatures
   - Import/export keys (when marked exportable)
+  - Rotate keys, keeping old versions verifiable
+  - Derive keys deterministically from a BIP-39/BIP-32 seed
   - Derive Cosmos addresses from public keys
 
 Paths:
@@ -172,4 +181,7 @@ Paths:
   verify/:name    - Verify a signature with a named key
   import/:name    - Import an external key
   export/:name    - Export a key (if marked exportable)
+  rotate/:name    - Rotate a key to a new version
+  hd/seed         - Configure the seed for HD derivation (write once)
+  derive/:name    - Derive a named key from the seed at a BIP-32 path
 `
@@ -46,6 +46,7 @@ func TestFactory_SealWrapConfigured(t *testing.T) {
 	backend := b.(*backend)
 	require.NotNil(t, backend.PathsSpecial)
 	assert.Contains(t, backend.PathsSpecial.SealWrapStorage, "keys/")
+	assert.Contains(t, backend.PathsSpecial.SealWrapStorage, "hd/")
 }
 
 func TestBackend_HelpString(t *testing.T) {
@@ -0,0 +1,137 @@
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// hardenedKeyStart is the index of the first hardened BIP-32 child.
+const hardenedKeyStart uint32 = 0x80000000
+
+// maxDerivationDepth is the deepest path BIP-32 can serialize.
+const maxDerivationDepth = 255
+
+// errInvalidHDKey is returned in the (astronomically unlikely) case that a
+// derivation step yields an invalid key. BIP-32 says to skip to the next index.
+var errInvalidHDKey = errors.New("derived key is invalid, use the next index")
+
+// seedFromMnemonic converts a BIP-39 mnemonic and optional passphrase into a
+// 64-byte seed. Words are split on whitespace and rejoined with single spaces.
+// The word list checksum is not checked; a mistyped mnemonic derives
+// different keys rather than failing.
+func seedFromMnemonic(mnemonic, passphrase string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("mnemonic must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+
+	return pbkdf2.Key(sha512.New, strings.Join(words, " "), []byte("mnemonic"+passphrase), 2048, 64)
+}
+
+// parseDerivationPath parses a BIP-32 path such as m/44'/60'/0'/0/0 into
+// child indices. Hardened components may be marked with ' or h.
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("derivation path must start with m/")
+	}
+	parts = parts[1:]
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("derivation path must have at least one component")
+	}
+	if len(parts) > maxDerivationDepth {
+		return nil, fmt.Errorf("derivation path is deeper than %d levels", maxDerivationDepth)
+	}
+
+	indices := make([]uint32, len(parts))
+	for i, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path component %q", parts[i])
+		}
+		if uint32(index) >= hardenedKeyStart {
+			return nil, fmt.Errorf("derivation path component %q is out of range", parts[i])
+		}
+
+		indices[i] = uint32(index)
+		if hardened {
+			indices[i] += hardenedKeyStart
+		}
+	}
+	return indices, nil
+}
+
+// deriveHDKey derives the private key at path from a BIP-32 seed.
+func deriveHDKey(seed []byte, path []uint32) (*btcec.PrivateKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("seed must be 16 to 64 bytes, got %d", len(seed))
+	}
+
+	key, chainCode, err := hdStep([]byte("Bitcoin seed"), seed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range path {
+		data := make([]byte, 0, 37)
+		if index >= hardenedKeyStart {
+			data = append(data, 0x00)
+			data = append(data, key...)
+		} else {
+			priv, _ := btcec.PrivKeyFromBytes(key)
+			data = append(data, priv.PubKey().SerializeCompressed()...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		next, nextChainCode, err := hdStep(chainCode, data, key)
+		secureZero(key)
+		if err != nil {
+			return nil, err
+		}
+		key, chainCode = next, nextChainCode
+	}
+
+	priv, _ := btcec.PrivKeyFromBytes(key)
+	secureZero(key)
+	return priv, nil
+}
+
+// hdStep computes HMAC-SHA512(hmacKey, data) and returns the resulting key
+// and chain code. When parent is set the key is tweaked by it (mod n), as in
+// BIP-32 child derivation; otherwise it is the master key.
+func hdStep(hmacKey, data, parent []byte) (key, chainCode []byte, err error) {
+	mac := hmac.New(sha512.New, hmacKey)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	var k btcec.ModNScalar
+	if overflow := k.SetByteSlice(sum[:32]); overflow {
+		return nil, nil, errInvalidHDKey
+	}
+	if parent != nil {
+		var p btcec.ModNScalar
+		p.SetByteSlice(parent)
+		k.Add(&p)
+	}
+	if k.IsZero() {
+		return nil, nil, errInvalidHDKey
+	}
+
+	out := k.Bytes()
+	return out[:], sum[32:], nil
+}
@@ -0,0 +1,77 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anvilMnemonic is the default mnemonic of Anvil and Hardhat dev nodes.
+const anvilMnemonic = "test test test test test test test test test test test junk"
+
+func TestSeedFromMnemonic(t *testing.T) {
+	// BIP-39 reference vector
+	mnemonic := strings.TrimSpace(strings.Repeat("abandon ", 11)) + " about"
+	seed, err := seedFromMnemonic(mnemonic, "TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	// Extra whitespace doesn't change the seed
+	spaced, err := seedFromMnemonic("  "+strings.ReplaceAll(mnemonic, " ", "\n  ")+" ", "TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, seed, spaced)
+
+	_, err = seedFromMnemonic("test test test", "")
+	assert.Error(t, err)
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	path, err := parseDerivationPath("m/44'/60'/0'/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{hardenedKeyStart + 44, hardenedKeyStart + 60, hardenedKeyStart, 0, 7}, path)
+
+	path, err = parseDerivationPath("m/44h/118H/0")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{hardenedKeyStart + 44, hardenedKeyStart + 118, 0}, path)
+
+	for _, invalid := range []string{"", "m", "m/", "44'/60'", "m/44'/x", "m/-1", "m/+1", "m//0", "m/2147483648", "m/0''"} {
+		_, err := parseDerivationPath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestDeriveHDKey(t *testing.T) {
+	t.Run("BIP-32 test vector 1", func(t *testing.T) {
+		seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+		path, err := parseDerivationPath("m/0'/1/2'/2/1000000000")
+		require.NoError(t, err)
+
+		priv, err := deriveHDKey(seed, path)
+		require.NoError(t, err)
+		assert.Equal(t, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8", hex.EncodeToString(priv.Serialize()))
+	})
+
+	t.Run("Anvil accounts", func(t *testing.T) {
+		seed, err := seedFromMnemonic(anvilMnemonic, "")
+		require.NoError(t, err)
+
+		for path, want := range map[string]string{
+			"m/44'/60'/0'/0/0": "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			"m/44'/60'/0'/0/1": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		} {
+			indices, err := parseDerivationPath(path)
+			require.NoError(t, err)
+			priv, err := deriveHDKey(seed, indices)
+			require.NoError(t, err)
+			assert.Equal(t, want, formatEthereumAddress(deriveEthereumAddress(priv.PubKey())), path)
+		}
+	})
+
+	t.Run("rejects bad seed length", func(t *testing.T) {
+		_, err := deriveHDKey(make([]byte, 8), []uint32{0})
+		assert.Error(t, err)
+	})
+}
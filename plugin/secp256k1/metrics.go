@@ -0,0 +1,46 @@
+package secp256k1
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-metrics"
+	"github.com/openbao/openbao/sdk/v2/framework"
+	"github.com/openbao/openbao/sdk/v2/logical"
+)
+
+// Metric keys emitted by the backend. Each is labelled with the operation.
+var (
+	metricRequests = []string{"secp256k1", "requests"}
+	metricErrors   = []string{"secp256k1", "errors"}
+	metricLatency  = []string{"secp256k1", "latency"}
+)
+
+// instrument wraps a path callback to record request count, error count
+// and latency for op, and to log the operation with the key name.
+// Request data is never logged, so key material can't leak into logs.
+func (b *backend) instrument(op string, callback framework.OperationFunc) framework.OperationFunc {
+	return func(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+		start := time.Now()
+		resp, err := callback(ctx, req, data)
+		elapsed := time.Since(start)
+
+		labels := []metrics.Label{{Name: "operation", Value: op}}
+		b.metrics.IncrCounterWithLabels(metricRequests, 1, labels)
+		b.metrics.MeasureSinceWithLabels(metricLatency, start, labels)
+
+		name, _ := data.GetOk("name")
+		switch {
+		case err != nil:
+			b.metrics.IncrCounterWithLabels(metricErrors, 1, labels)
+			b.Logger().Error("operation failed", "operation", op, "key", name, "duration", elapsed, "error", err)
+		case resp != nil && resp.IsError():
+			b.metrics.IncrCounterWithLabels(metricErrors, 1, labels)
+			b.Logger().Debug("operation rejected", "operation", op, "key", name, "duration", elapsed, "error", resp.Error())
+		default:
+			b.Logger().Debug("operation completed", "operation", op, "key", name, "duration", elapsed)
+		}
+
+		return resp, err
+	}
+}
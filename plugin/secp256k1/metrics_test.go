@@ -0,0 +1,101 @@
+package secp256k1
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-metrics"
+	"github.com/openbao/openbao/sdk/v2/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSink records counter increments by key and operation label.
+type countingSink struct {
+	metrics.BlackholeSink
+	counters map[string]float32
+	samples  map[string]int
+}
+
+func (s *countingSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.counters[metricName(key, labels)] += val
+}
+
+func (s *countingSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.samples[metricName(key, labels)]++
+}
+
+func metricName(key []string, labels []metrics.Label) string {
+	name := strings.Join(key, ".")
+	for _, l := range labels {
+		name += ";" + l.Name + "=" + l.Value
+	}
+	return name
+}
+
+// useCountingSink points b's metrics at a new countingSink.
+func useCountingSink(t *testing.T, b *backend) *countingSink {
+	t.Helper()
+
+	sink := &countingSink{counters: map[string]float32{}, samples: map[string]int{}}
+	conf := metrics.DefaultConfig("")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+
+	m, err := metrics.New(conf, sink)
+	require.NoError(t, err)
+	b.metrics = m
+	return sink
+}
+
+func TestInstrument_Sign(t *testing.T) {
+	ctx := context.Background()
+	b, storage := getTestBackend(t)
+	sink := useCountingSink(t, b)
+	createTestKey(t, b, storage, "testkey", false)
+
+	sign := func(input string) *logical.Response {
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "sign/testkey",
+			Storage:   storage,
+			Data: map[string]interface{}{
+				"input": input,
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	resp := sign(base64.StdEncoding.EncodeToString([]byte("hello")))
+	require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+
+	assert.Equal(t, float32(1), sink.counters["secp256k1.requests;operation=sign"])
+	assert.Equal(t, float32(0), sink.counters["secp256k1.errors;operation=sign"])
+	assert.Equal(t, 1, sink.samples["secp256k1.latency;operation=sign"])
+
+	resp = sign("not base64!")
+	require.True(t, resp.IsError())
+
+	assert.Equal(t, float32(2), sink.counters["secp256k1.requests;operation=sign"])
+	assert.Equal(t, float32(1), sink.counters["secp256k1.errors;operation=sign"])
+}
+
+func TestInstrument_Create(t *testing.T) {
+	ctx := context.Background()
+	b, storage := getTestBackend(t)
+	sink := useCountingSink(t, b)
+
+	_, err := b.HandleRequest(ctx, &logical.Request{
+		Operation: logical.UpdateOperation,
+		Path:      "keys/newkey",
+		Storage:   storage,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, float32(1), sink.counters["secp256k1.requests;operation=create"])
+	assert.Zero(t, sink.counters["secp256k1.requests;operation=sign"])
+}
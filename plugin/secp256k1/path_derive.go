@@ -0,0 +1,420 @@
+package secp256k1
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openbao/openbao/sdk/v2/framework"
+	"github.com/openbao/openbao/sdk/v2/logical"
+)
+
+// seedStoragePath is where the HD seed is stored.
+const seedStoragePath = "hd/seed"
+
+// derivedPathsPrefix is where used derivation paths are recorded, keyed by
+// their child indices. Records outlive the keys they were derived for.
+const derivedPathsPrefix = "hd/paths/"
+
+// derivedPathEntry records which key a derivation path was used for.
+type derivedPathEntry struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// derivedPathStorageKey returns the storage key recording indices as used.
+// Indices are used rather than the path string so 44' and 44h match.
+func derivedPathStorageKey(indices []uint32) string {
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = strconv.FormatUint(uint64(index), 10)
+	}
+	return derivedPathsPrefix + strings.Join(parts, "/")
+}
+
+// pathDerive returns the path definitions for HD seed configuration and
+// key derivation.
+func pathDerive(b *backend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "hd/seed",
+			Fields: map[string]*framework.FieldSchema{
+				"mnemonic": {
+					Type:        framework.TypeString,
+					Description: "BIP-39 mnemonic to derive the seed from",
+				},
+				"passphrase": {
+					Type:        framework.TypeString,
+					Description: "Optional BIP-39 passphrase used with mnemonic",
+				},
+				"seed": {
+					Type:        framework.TypeString,
+					Description: "Hex-encoded BIP-32 seed (16 to 64 bytes), instead of mnemonic",
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.CreateOperation: &framework.PathOperation{Callback: b.pathSeedWrite},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.pathSeedWrite},
+				logical.ReadOperation:   &framework.PathOperation{Callback: b.pathSeedRead},
+			},
+			ExistenceCheck:  b.pathSeedExistenceCheck,
+			HelpSynopsis:    pathSeedHelpSyn,
+			HelpDescription: pathSeedHelpDesc,
+		},
+		{
+			Pattern: "derive/" + framework.GenericNameRegex("name"),
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type:        framework.TypeString,
+					Description: "Name of the key to create",
+					Required:    true,
+				},
+				"path": {
+					Type:        framework.TypeString,
+					Description: "BIP-32 derivation path, e.g. m/44'/60'/0'/0/0",
+					Required:    true,
+				},
+				"exportable": {
+					Type:        framework.TypeBool,
+					Description: "Whether the key can be exported",
+					Default:     false,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback:    b.instrument("derive", b.pathKeyDerive),
+					Summary:     "Derive a secp256k1 key from the HD seed",
+					Description: "Derives a key from the configured seed at a BIP-32 path and stores it under name.",
+				},
+			},
+			HelpSynopsis:    pathDeriveHelpSyn,
+			HelpDescription: pathDeriveHelpDesc,
+		},
+	}
+}
+
+// getSeed loads the HD seed, or nil if none is configured.
+func (b *backend) getSeed(ctx context.Context, storage logical.Storage) (*seedEntry, error) {
+	raw, err := storage.Get(ctx, seedStoragePath)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var entry seedEntry
+	if err := raw.DecodeJSON(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// pathSeedExistenceCheck checks if a seed is configured.
+func (b *backend) pathSeedExistenceCheck(ctx context.Context, req *logical.Request, data *framework.FieldData) (bool, error) {
+	entry, err := b.getSeed(ctx, req.Storage)
+	if err != nil {
+		return false, err
+	}
+	return entry != nil, nil
+}
+
+// pathSeedWrite handles configuring the HD seed. The seed can be set once;
+// replacing it would silently change every key derived afterwards.
+func (b *backend) pathSeedWrite(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	mnemonic := data.Get("mnemonic").(string)
+	passphrase := data.Get("passphrase").(string)
+	seedHex := data.Get("seed").(string)
+
+	if (mnemonic == "") == (seedHex == "") {
+		return logical.ErrorResponse("exactly one of mnemonic or seed is required"), nil
+	}
+	if passphrase != "" && mnemonic == "" {
+		return logical.ErrorResponse("passphrase requires mnemonic"), nil
+	}
+
+	b.seedMu.Lock()
+	defer b.seedMu.Unlock()
+
+	existing, err := b.getSeed(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return logical.ErrorResponse("seed already configured"), nil
+	}
+
+	var seed []byte
+	if mnemonic != "" {
+		seed, err = seedFromMnemonic(mnemonic, passphrase)
+		if err != nil {
+			return logical.ErrorResponse("invalid mnemonic: %s", err), nil
+		}
+	} else {
+		seed, err = hex.DecodeString(seedHex)
+		if err != nil {
+			return logical.ErrorResponse("invalid seed: not valid hex"), nil
+		}
+		if len(seed) < 16 || len(seed) > 64 {
+			secureZero(seed)
+			return logical.ErrorResponse("seed must be 16 to 64 bytes"), nil
+		}
+	}
+
+	entry := &seedEntry{
+		Seed:      seed,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	storageEntry, err := logical.StorageEntryJSON(seedStoragePath, entry)
+	secureZero(seed)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, storageEntry); err != nil {
+		return nil, err
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"configured": true,
+			"created_at": entry.CreatedAt.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// pathSeedRead reports whether a seed is configured. The seed itself is
+// never returned.
+func (b *backend) pathSeedRead(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	entry, err := b.getSeed(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return &logical.Response{
+			Data: map[string]interface{}{
+				"configured": false,
+			},
+		}, nil
+	}
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"configured": true,
+			"created_at": entry.CreatedAt.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// pathKeyDerive handles deriving a key from the HD seed.
+func (b *backend) pathKeyDerive(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if name == "" {
+		return logical.ErrorResponse("missing key name"), nil
+	}
+
+	path := data.Get("path").(string)
+	if path == "" {
+		return logical.ErrorResponse("missing path"), nil
+	}
+	indices, err := parseDerivationPath(path)
+	if err != nil {
+		return logical.ErrorResponse(err.Error()), nil
+	}
+
+	exportable := data.Get("exportable").(bool)
+
+	// Holding seedMu serialises derivations so two requests can't claim the
+	// same path at once
+	b.seedMu.Lock()
+	defer b.seedMu.Unlock()
+
+	// Check if key already exists
+	existing, err := req.Storage.Get(ctx, "keys/"+name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return logical.ErrorResponse("key already exists"), nil
+	}
+
+	// A path can only be derived once. Otherwise a non-exportable key could
+	// be re-derived under another name with exportable=true.
+	usedBy, err := b.derivedPathOwner(ctx, req.Storage, indices)
+	if err != nil {
+		return nil, err
+	}
+	if usedBy != "" {
+		return logical.ErrorResponse("derivation path already used by key %q", usedBy), nil
+	}
+
+	seed, err := b.getSeed(ctx, req.Storage)
+	if err != nil {
+		return nil, err
+	}
+	if seed == nil {
+		return logical.ErrorResponse("no seed configured, write one to hd/seed first"), nil
+	}
+
+	privKey, err := deriveHDKey(seed.Seed, indices)
+	secureZero(seed.Seed)
+	if err != nil {
+		return logical.ErrorResponse("failed to derive key: %s", err), nil
+	}
+
+	entry := &keyEntry{
+		PrivateKey:            privKey.Serialize(),
+		PublicKey:             privKey.PubKey().SerializeCompressed(),
+		PublicKeyUncompressed: privKey.PubKey().SerializeUncompressed(),
+		Exportable:            exportable,
+		CreatedAt:             time.Now().UTC(),
+		Imported:              false,
+		DerivationPath:        path,
+	}
+
+	// Record the path before storing the key, so a failure in between
+	// leaves the path unusable rather than derivable twice
+	pathEntry, err := logical.StorageEntryJSON(derivedPathStorageKey(indices), &derivedPathEntry{
+		Name:      name,
+		CreatedAt: entry.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, pathEntry); err != nil {
+		return nil, err
+	}
+
+	// Store the key
+	storageEntry, err := logical.StorageEntryJSON("keys/"+name, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, storageEntry); err != nil {
+		return nil, err
+	}
+
+	b.setKeyInCache(name, entry)
+
+	cosmosAddr := deriveCosmosAddress(entry.PublicKey)
+	ethAddr := deriveEthereumAddress(privKey.PubKey())
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"name":            name,
+			"public_key":      hex.EncodeToString(entry.PublicKey),
+			"address":         hex.EncodeToString(cosmosAddr),
+			"eth_address":     formatEthereumAddress(ethAddr),
+			"exportable":      exportable,
+			"created_at":      entry.CreatedAt.Format(time.RFC3339),
+			"derivation_path": path,
+		},
+	}, nil
+}
+
+// derivedPathOwner returns the name of the key indices were derived for, or
+// "" if the path is unused. Keys derived before paths were recorded are
+// found by scanning the stored keys.
+func (b *backend) derivedPathOwner(ctx context.Context, storage logical.Storage, indices []uint32) (string, error) {
+	raw, err := storage.Get(ctx, derivedPathStorageKey(indices))
+	if err != nil {
+		return "", err
+	}
+	if raw != nil {
+		var record derivedPathEntry
+		if err := raw.DecodeJSON(&record); err != nil {
+			return "", err
+		}
+		return record.Name, nil
+	}
+
+	names, err := storage.List(ctx, "keys/")
+	if err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		raw, err := storage.Get(ctx, "keys/"+name)
+		if err != nil {
+			return "", err
+		}
+		if raw == nil {
+			continue
+		}
+
+		var entry keyEntry
+		if err := raw.DecodeJSON(&entry); err != nil {
+			return "", err
+		}
+		if entry.DerivationPath == "" {
+			continue
+		}
+		other, err := parseDerivationPath(entry.DerivationPath)
+		if err == nil && equalIndices(indices, other) {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// equalIndices reports whether two derivation paths are the same.
+func equalIndices(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+const pathSeedHelpSyn = `Configure the HD seed keys are derived from`
+
+const pathSeedHelpDesc = `
+This endpoint sets the BIP-32 seed used by the derive endpoint. Provide
+either a BIP-39 mnemonic (with an optional passphrase) or a raw hex seed.
+
+The seed can only be set once and is stored seal wrapped. Reading this
+path reports whether a seed is configured; the seed is never returned.
+
+The mnemonic's word list checksum is not validated. A mistyped mnemonic
+yields a different seed rather than an error.
+
+Example:
+  $ bao write secp256k1/hd/seed \
+      mnemonic="test test test test test test test test test test test junk"
+`
+
+const pathDeriveHelpSyn = `Derive a secp256k1 key from the HD seed`
+
+const pathDeriveHelpDesc = `
+This endpoint derives a private key from the configured seed at a BIP-32
+path and stores it under the given name, as if it had been created with
+keys/:name. The same seed and path always give the same key, so dev
+environments can recreate known addresses without importing raw keys.
+
+Hardened path components may be written as 44' or 44h. Deriving into a
+name that already exists is rejected. Each path can only be derived once,
+even after the key derived from it is deleted, so a key's exportable
+setting can't be changed by deriving it again under another name.
+
+Parameters:
+  path       - BIP-32 derivation path, e.g. m/44'/60'/0'/0/0
+  exportable - Whether the key can be exported (default: false)
+
+Example:
+  # First Anvil/Hardhat dev account
+  $ bao write secp256k1/derive/anvil-0 path="m/44'/60'/0'/0/0"
+
+Response:
+  name            - Key name
+  public_key      - Hex-encoded compressed public key (33 bytes)
+  address         - Hex-encoded Cosmos address
+  eth_address     - EIP-55 checksummed Ethereum address
+  exportable      - Whether the key can be exported
+  created_at      - Creation time (RFC 3339)
+  derivation_path - The path the key was derived at
+`
@@ -0,0 +1,187 @@
+package secp256k1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openbao/openbao/sdk/v2/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathDerive(t *testing.T) {
+	t.Run("returns correct path configuration", func(t *testing.T) {
+		b, _ := getTestBackend(t)
+
+		paths := pathDerive(b)
+		require.Len(t, paths, 2)
+		assert.Equal(t, "hd/seed", paths[0].Pattern)
+		assert.Contains(t, paths[0].Fields, "mnemonic")
+		assert.Contains(t, paths[0].Fields, "seed")
+		assert.Contains(t, paths[1].Pattern, "derive/")
+		assert.Contains(t, paths[1].Fields, "path")
+		assert.Contains(t, paths[1].Fields, "exportable")
+	})
+}
+
+func TestPathKeyDerive(t *testing.T) {
+	ctx := context.Background()
+
+	writeSeed := func(t *testing.T, b *backend, storage logical.Storage, data map[string]interface{}) *logical.Response {
+		t.Helper()
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "hd/seed",
+			Storage:   storage,
+			Data:      data,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	derive := func(t *testing.T, b *backend, storage logical.Storage, name, path string) *logical.Response {
+		t.Helper()
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "derive/" + name,
+			Storage:   storage,
+			Data: map[string]interface{}{
+				"path": path,
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	t.Run("derives the first Anvil account", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+
+		resp := writeSeed(t, b, storage, map[string]interface{}{"mnemonic": anvilMnemonic})
+		require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+
+		resp = derive(t, b, storage, "anvil-0", "m/44'/60'/0'/0/0")
+		require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+		assert.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", resp.Data["eth_address"])
+		assert.Equal(t, "m/44'/60'/0'/0/0", resp.Data["derivation_path"])
+
+		// The derived key is a normal key
+		readResp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "keys/anvil-0",
+			Storage:   storage,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resp.Data["public_key"], readResp.Data["public_key"])
+		assert.Equal(t, "m/44'/60'/0'/0/0", readResp.Data["derivation_path"])
+	})
+
+	t.Run("rejects reusing an existing name", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		createTestKey(t, b, storage, "taken", false)
+		writeSeed(t, b, storage, map[string]interface{}{"mnemonic": anvilMnemonic})
+
+		resp := derive(t, b, storage, "taken", "m/44'/60'/0'/0/0")
+		assert.True(t, resp.IsError())
+		assert.Contains(t, resp.Error().Error(), "key already exists")
+	})
+
+	t.Run("rejects re-deriving a used path", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		writeSeed(t, b, storage, map[string]interface{}{"mnemonic": anvilMnemonic})
+
+		resp := derive(t, b, storage, "locked", "m/44'/60'/0'/0/0")
+		require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+
+		reDerive := func(name, path string) *logical.Response {
+			resp, err := b.HandleRequest(ctx, &logical.Request{
+				Operation: logical.UpdateOperation,
+				Path:      "derive/" + name,
+				Storage:   storage,
+				Data: map[string]interface{}{
+					"path":       path,
+					"exportable": true,
+				},
+			})
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			return resp
+		}
+
+		for _, path := range []string{"m/44'/60'/0'/0/0", "m/44h/60h/0h/0/0"} {
+			resp = reDerive("exportable", path)
+			assert.True(t, resp.IsError(), path)
+			assert.Contains(t, resp.Error().Error(), "derivation path already used")
+		}
+
+		// Deleting the key doesn't free the path
+		_, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.DeleteOperation,
+			Path:      "keys/locked",
+			Storage:   storage,
+		})
+		require.NoError(t, err)
+
+		resp = reDerive("exportable", "m/44'/60'/0'/0/0")
+		assert.True(t, resp.IsError())
+
+		// Other paths are still derivable
+		resp = reDerive("other", "m/44'/60'/0'/0/1")
+		assert.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+	})
+
+	t.Run("rejects invalid paths", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		writeSeed(t, b, storage, map[string]interface{}{"mnemonic": anvilMnemonic})
+
+		for _, path := range []string{"44'/60'/0'/0/0", "m/44'/abc", "m/4294967296"} {
+			resp := derive(t, b, storage, "bad", path)
+			assert.True(t, resp.IsError(), path)
+		}
+	})
+
+	t.Run("requires a seed", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+
+		resp := derive(t, b, storage, "anvil-0", "m/44'/60'/0'/0/0")
+		assert.True(t, resp.IsError())
+		assert.Contains(t, resp.Error().Error(), "no seed configured")
+	})
+
+	t.Run("seed can only be set once and is never returned", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+
+		resp := writeSeed(t, b, storage, map[string]interface{}{"seed": "000102030405060708090a0b0c0d0e0f"})
+		require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+
+		resp = writeSeed(t, b, storage, map[string]interface{}{"mnemonic": anvilMnemonic})
+		assert.True(t, resp.IsError())
+		assert.Contains(t, resp.Error().Error(), "seed already configured")
+
+		readResp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "hd/seed",
+			Storage:   storage,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, true, readResp.Data["configured"])
+		assert.NotContains(t, readResp.Data, "seed")
+	})
+
+	t.Run("validates seed input", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+
+		for _, data := range []map[string]interface{}{
+			{},
+			{"mnemonic": anvilMnemonic, "seed": "000102030405060708090a0b0c0d0e0f"},
+			{"mnemonic": "test test test"},
+			{"seed": "not-hex"},
+			{"seed": "0001"},
+			{"seed": "000102030405060708090a0b0c0d0e0f", "passphrase": "x"},
+		} {
+			resp := writeSeed(t, b, storage, data)
+			assert.True(t, resp.IsError(), "%v", data)
+		}
+	})
+}
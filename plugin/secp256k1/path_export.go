@@ -24,7 +24,7 @@ func pathExport(b *backend) []*framework.Path {
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.ReadOperation: &framework.PathOperation{
-					Callback:    b.pathKeyExport,
+					Callback:    b.instrument("export", b.pathKeyExport),
 					Summary:     "Export a secp256k1 private key",
 					Description: "Export a private key if it was created with exportable=true.",
 				},
@@ -35,7 +35,7 @@ func pathImport(b *backend) []*framework.Path {
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.UpdateOperation: &framework.PathOperation{
-					Callback:    b.pathKeyImport,
+					Callback:    b.instrument("import", b.pathKeyImport),
 					Summary:     "Import an existing secp256k1 private key",
 					Description: "Import an existing private key into OpenBao. The key material should be base64-encoded.",
 				},
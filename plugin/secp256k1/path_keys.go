@@ -28,10 +28,10 @@ func pathKeys(b *backend) []*framework.Path {
 				},
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
-				logical.CreateOperation: &framework.PathOperation{Callback: b.pathKeyCreate},
-				logical.UpdateOperation: &framework.PathOperation{Callback: b.pathKeyCreate},
+				logical.CreateOperation: &framework.PathOperation{Callback: b.instrument("create", b.pathKeyCreate)},
+				logical.UpdateOperation: &framework.PathOperation{Callback: b.instrument("create", b.pathKeyCreate)},
 				logical.ReadOperation:   &framework.PathOperation{Callback: b.pathKeyRead},
-				logical.DeleteOperation: &framework.PathOperation{Callback: b.pathKeyDelete},
+				logical.DeleteOperation: &framework.PathOperation{Callback: b.instrument("delete", b.pathKeyDelete)},
 			},
 			ExistenceCheck:  b.pathKeyExistenceCheck,
 			HelpSynopsis:    pathKeysHelpSyn,
@@ -150,7 +150,7 @@ func (b *backend) pathKeyRead(ctx context.Context, req *logical.Request, data *f
 	}
 	ethAddr := deriveEthereumAddress(pubKey)
 
-	return &logical.Response{
+	resp := &logical.Response{
 		Data: map[string]interface{}{
 			"name":        name,
 			"public_key":  hex.EncodeToString(entry.PublicKey),
@@ -159,8 +159,15 @@ func (b *backend) pathKeyRead(ctx context.Context, req *logical.Request, data *f
 			"exportable":  entry.Exportable,
 			"created_at":  entry.CreatedAt.Format(time.RFC3339),
 			"imported":    entry.Imported,
+			"version":     entry.currentVersion(),
 		},
-	}, nil
+	}
+
+	if entry.DerivationPath != "" {
+		resp.Data["derivation_path"] = entry.DerivationPath
+	}
+
+	return resp, nil
 }
 
 // pathKeyDelete handles key deletion.
@@ -0,0 +1,136 @@
+package secp256k1
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/openbao/openbao/sdk/v2/framework"
+	"github.com/openbao/openbao/sdk/v2/logical"
+)
+
+// pathRotate returns the path definitions for key rotation.
+func pathRotate(b *backend) []*framework.Path {
+	return []*framework.Path{
+		{
+			Pattern: "rotate/" + framework.GenericNameRegex("name"),
+			Fields: map[string]*framework.FieldSchema{
+				"name": {
+					Type:        framework.TypeString,
+					Description: "Name of the key to rotate",
+					Required:    true,
+				},
+			},
+			Operations: map[logical.Operation]framework.OperationHandler{
+				logical.UpdateOperation: &framework.PathOperation{
+					Callback:    b.instrument("rotate", b.pathKeyRotate),
+					Summary:     "Rotate a secp256k1 key",
+					Description: "Generate new key material under the same name and increment the key version.",
+				},
+			},
+			HelpSynopsis:    pathRotateHelpSyn,
+			HelpDescription: pathRotateHelpDesc,
+		},
+	}
+}
+
+// pathKeyRotate handles key rotation.
+func (b *backend) pathKeyRotate(ctx context.Context, req *logical.Request, data *framework.FieldData) (*logical.Response, error) {
+	name := data.Get("name").(string)
+	if name == "" {
+		return logical.ErrorResponse("missing key name"), nil
+	}
+
+	// Serialize rotations so concurrent requests can't claim the same version
+	b.rotateMu.Lock()
+	defer b.rotateMu.Unlock()
+
+	entry, err := b.getKey(ctx, req.Storage, name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return logical.ErrorResponse("key not found"), nil
+	}
+
+	privKey, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the replaced public key so its signatures stay verifiable
+	current := entry.currentVersion()
+	versionCreatedAt := entry.CreatedAt
+	if entry.RotatedAt != nil {
+		versionCreatedAt = *entry.RotatedAt
+	}
+	previous := make(map[int]*keyVersion, len(entry.PreviousVersions)+1)
+	for v, kv := range entry.PreviousVersions {
+		previous[v] = kv
+	}
+	previous[current] = &keyVersion{
+		PublicKey: entry.PublicKey,
+		CreatedAt: versionCreatedAt,
+	}
+
+	now := time.Now().UTC()
+	rotated := &keyEntry{
+		PrivateKey:            privKey.Serialize(),
+		PublicKey:             privKey.PubKey().SerializeCompressed(),
+		PublicKeyUncompressed: privKey.PubKey().SerializeUncompressed(),
+		Exportable:            entry.Exportable,
+		CreatedAt:             entry.CreatedAt,
+		Imported:              false,
+		Version:               current + 1,
+		RotatedAt:             &now,
+		PreviousVersions:      previous,
+	}
+
+	storageEntry, err := logical.StorageEntryJSON("keys/"+name, rotated)
+	if err != nil {
+		return nil, err
+	}
+	if err := req.Storage.Put(ctx, storageEntry); err != nil {
+		return nil, err
+	}
+
+	b.setKeyInCache(name, rotated)
+
+	cosmosAddr := deriveCosmosAddress(rotated.PublicKey)
+	ethAddr := deriveEthereumAddress(privKey.PubKey())
+
+	return &logical.Response{
+		Data: map[string]interface{}{
+			"name":        name,
+			"public_key":  hex.EncodeToString(rotated.PublicKey),
+			"address":     hex.EncodeToString(cosmosAddr),
+			"eth_address": formatEthereumAddress(ethAddr),
+			"version":     rotated.Version,
+			"rotated_at":  now.Format(time.RFC3339),
+		},
+	}, nil
+}
+
+const pathRotateHelpSyn = `Rotate a secp256k1 key`
+
+const pathRotateHelpDesc = `
+This endpoint replaces a key's private key with newly generated material
+under the same name and increments its version.
+
+New signatures use the new version. The public keys of earlier versions
+are kept so signatures made before the rotation can still be checked by
+passing key_version to the verify endpoint. Earlier private keys are
+discarded and can no longer sign.
+
+Example:
+  $ bao write -f secp256k1/rotate/mykey
+
+Response:
+  name        - Key name
+  public_key  - Hex-encoded compressed public key of the new version
+  address     - Hex-encoded Cosmos address of the new version
+  eth_address - EIP-55 checksummed Ethereum address of the new version
+  version     - New key version
+  rotated_at  - Time of the rotation (RFC 3339)
+`
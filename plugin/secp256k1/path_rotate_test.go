@@ -0,0 +1,140 @@
+package secp256k1
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/openbao/openbao/sdk/v2/logical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathRotate(t *testing.T) {
+	t.Run("returns correct path configuration", func(t *testing.T) {
+		b, _ := getTestBackend(t)
+
+		paths := pathRotate(b)
+		require.Len(t, paths, 1)
+		assert.Contains(t, paths[0].Pattern, "rotate/")
+		assert.Contains(t, paths[0].Fields, "name")
+	})
+}
+
+func TestPathKeyRotate(t *testing.T) {
+	ctx := context.Background()
+
+	rotate := func(t *testing.T, b *backend, storage logical.Storage, name string) *logical.Response {
+		t.Helper()
+		resp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "rotate/" + name,
+			Storage:   storage,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		return resp
+	}
+
+	t.Run("changes public key and bumps version", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		entry := createTestKey(t, b, storage, "testkey", true)
+
+		resp := rotate(t, b, storage, "testkey")
+		require.False(t, resp.IsError(), "unexpected error: %v", resp.Error())
+
+		newPubKey := resp.Data["public_key"].(string)
+		assert.NotEqual(t, hex.EncodeToString(entry.PublicKey), newPubKey)
+		assert.Equal(t, 2, resp.Data["version"])
+		assert.NotEmpty(t, resp.Data["address"])
+		assert.NotEmpty(t, resp.Data["eth_address"])
+		assert.NotEmpty(t, resp.Data["rotated_at"])
+
+		resp = rotate(t, b, storage, "testkey")
+		require.False(t, resp.IsError())
+		assert.Equal(t, 3, resp.Data["version"])
+
+		// Read reflects the rotated key
+		readResp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.ReadOperation,
+			Path:      "keys/testkey",
+			Storage:   storage,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, resp.Data["public_key"], readResp.Data["public_key"])
+		assert.Equal(t, 3, readResp.Data["version"])
+		assert.Equal(t, true, readResp.Data["exportable"])
+	})
+
+	t.Run("persists rotation to storage", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		createTestKey(t, b, storage, "testkey", false)
+
+		resp := rotate(t, b, storage, "testkey")
+		require.False(t, resp.IsError())
+
+		b.clearCache()
+		stored, err := b.getKey(ctx, storage, "testkey")
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, 2, stored.Version)
+		assert.Equal(t, resp.Data["public_key"], hex.EncodeToString(stored.PublicKey))
+		assert.Contains(t, stored.PreviousVersions, 1)
+	})
+
+	t.Run("signs with new version and verifies old signatures", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+		entry := createTestKey(t, b, storage, "testkey", false)
+
+		message := []byte("signed before rotation")
+		privKey, err := ParsePrivateKey(entry.PrivateKey)
+		require.NoError(t, err)
+		oldSig, err := SignMessage(privKey, hashSHA256(message))
+		require.NoError(t, err)
+
+		resp := rotate(t, b, storage, "testkey")
+		require.False(t, resp.IsError())
+
+		verify := func(version int) *logical.Response {
+			resp, err := b.HandleRequest(ctx, &logical.Request{
+				Operation: logical.UpdateOperation,
+				Path:      "verify/testkey",
+				Storage:   storage,
+				Data: map[string]interface{}{
+					"input":       base64.StdEncoding.EncodeToString(message),
+					"signature":   base64.StdEncoding.EncodeToString(oldSig),
+					"key_version": version,
+				},
+			})
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			return resp
+		}
+
+		assert.Equal(t, false, verify(0).Data["valid"])
+		assert.Equal(t, true, verify(1).Data["valid"])
+		assert.True(t, verify(5).IsError())
+
+		signResp, err := b.HandleRequest(ctx, &logical.Request{
+			Operation: logical.UpdateOperation,
+			Path:      "sign/testkey",
+			Storage:   storage,
+			Data: map[string]interface{}{
+				"input": base64.StdEncoding.EncodeToString(message),
+			},
+		})
+		require.NoError(t, err)
+		require.False(t, signResp.IsError())
+		assert.Equal(t, 2, signResp.Data["key_version"])
+		assert.Equal(t, resp.Data["public_key"], signResp.Data["public_key"])
+	})
+
+	t.Run("returns error for non-existent key", func(t *testing.T) {
+		b, storage := getTestBackend(t)
+
+		resp := rotate(t, b, storage, "nonexistent")
+		assert.True(t, resp.IsError())
+		assert.Contains(t, resp.Error().Error(), "key not found")
+	})
+}
@@ -45,7 +45,7 @@ func pathSign(b *backend) []*framework.Path {
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.UpdateOperation: &framework.PathOperation{
-					Callback:    b.pathSignWrite,
+					Callback:    b.instrument("sign", b.pathSignWrite),
 					Summary:     "Sign data with a secp256k1 key",
 					Description: "Signs data using the specified key.",
 				},
@@ -136,7 +136,7 @@ func (b *backend) pathSignWrite(ctx context.Context, req *logical.Request, data
 		Data: map[string]interface{}{
 			"signature":   base64.StdEncoding.EncodeToString(sigOut),
 			"public_key":  hex.EncodeToString(entry.PublicKey),
-			"key_version": 1,
+			"key_version": entry.currentVersion(),
 		},
 	}, nil
 }
@@ -191,5 +191,5 @@ Examples:
 Response:
   signature   - Base64-encoded signature (64 bytes R||S for cosmos, DER for der)
   public_key  - Hex-encoded compressed public key (33 bytes)
-  key_version - Key version (always 1)
+  key_version - Key version used to sign
 `
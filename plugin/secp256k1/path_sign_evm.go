@@ -35,7 +35,7 @@ func pathSignEVM(b *backend) []*framework.Path {
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.UpdateOperation: &framework.PathOperation{
-					Callback:    b.pathSignEVMWrite,
+					Callback:    b.instrument("sign_evm", b.pathSignEVMWrite),
 					Summary:     "Sign a hash with EIP-155 format for Ethereum transactions",
 					Description: "Signs a 32-byte hash and returns v, r, s values suitable for Ethereum transaction signing.",
 				},
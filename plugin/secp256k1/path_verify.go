@@ -40,10 +40,15 @@ func pathVerify(b *backend) []*framework.Path {
 					Description: "Hash algorithm used: sha256 (default) or keccak256",
 					Default:     "sha256",
 				},
+				"key_version": {
+					Type:        framework.TypeInt,
+					Description: "Key version to verify against. Defaults to the current version.",
+					Default:     0,
+				},
 			},
 			Operations: map[logical.Operation]framework.OperationHandler{
 				logical.UpdateOperation: &framework.PathOperation{
-					Callback:    b.pathVerifyWrite,
+					Callback:    b.instrument("verify", b.pathVerifyWrite),
 					Summary:     "Verify a signature with a secp256k1 key",
 					Description: "Verifies a signature against data using the specified key.",
 				},
@@ -73,6 +78,7 @@ func (b *backend) pathVerifyWrite(ctx context.Context, req *logical.Request, dat
 
 	prehashed := data.Get("prehashed").(bool)
 	hashAlgo := data.Get("hash_algorithm").(string)
+	keyVersion := data.Get("key_version").(int)
 
 	// Decode the input
 	input, err := base64.StdEncoding.DecodeString(inputB64)
@@ -113,8 +119,14 @@ func (b *backend) pathVerifyWrite(ctx context.Context, req *logical.Request, dat
 		return logical.ErrorResponse("key not found"), nil
 	}
 
+	// Select the public key of the requested version
+	pubKeyBytes, ok := entry.publicKeyForVersion(keyVersion)
+	if !ok {
+		return logical.ErrorResponse("unknown key version: %d", keyVersion), nil
+	}
+
 	// Parse the public key
-	pubKey, err := ParsePublicKey(entry.PublicKey)
+	pubKey, err := ParsePublicKey(pubKeyBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +146,7 @@ func (b *backend) pathVerifyWrite(ctx context.Context, req *logical.Request, dat
 			return &logical.Response{
 				Data: map[string]interface{}{
 					"valid":      false,
-					"public_key": hex.EncodeToString(entry.PublicKey),
+					"public_key": hex.EncodeToString(pubKeyBytes),
 				},
 			}, nil
 		}
@@ -144,7 +156,7 @@ func (b *backend) pathVerifyWrite(ctx context.Context, req *logical.Request, dat
 	return &logical.Response{
 		Data: map[string]interface{}{
 			"valid":      valid,
-			"public_key": hex.EncodeToString(entry.PublicKey),
+			"public_key": hex.EncodeToString(pubKeyBytes),
 		},
 	}, nil
 }
@@ -162,6 +174,7 @@ Parameters:
   signature      - Base64-encoded signature to verify
   prehashed      - If true, input is already a 32-byte hash (default: false)
   hash_algorithm - Hash algorithm used: sha256 (default) or keccak256
+  key_version    - Key version to verify against (default: current version)
 
 Example:
   $ bao write secp256k1/verify/mykey \
@@ -170,5 +183,5 @@ Example:
 
 Response:
   valid      - true if signature is valid, false otherwise
-  public_key - Hex-encoded compressed public key (33 bytes)
+  public_key - Hex-encoded compressed public key of the version checked (33 bytes)
 `
@@ -24,4 +24,59 @@ type keyEntry struct {
 
 	// Imported indicates whether the key was imported (vs generated).
 	Imported bool `json:"imported"`
+
+	// DerivationPath is the BIP-32 path the key was derived from the
+	// mount's seed with, if it was derived rather than generated.
+	DerivationPath string `json:"derivation_path,omitempty"`
+
+	// Version is the current key version. Zero means 1, for keys stored
+	// before rotation existed. Rotation increments it.
+	Version int `json:"version,omitempty"`
+
+	// RotatedAt is when the current version was created by rotation.
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+
+	// PreviousVersions holds the public keys replaced by rotation, keyed by
+	// version, so signatures made before a rotation can still be verified.
+	// Their private keys are not kept.
+	PreviousVersions map[int]*keyVersion `json:"previous_versions,omitempty"`
+}
+
+// keyVersion is a retired version of a key.
+type keyVersion struct {
+	// PublicKey is the compressed 33-byte public key of the version.
+	PublicKey []byte `json:"public_key"`
+
+	// CreatedAt is when the version was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// currentVersion returns the key's current version number.
+func (e *keyEntry) currentVersion() int {
+	if e.Version < 1 {
+		return 1
+	}
+	return e.Version
+}
+
+// publicKeyForVersion returns the public key of the given version.
+// Version 0 selects the current version.
+func (e *keyEntry) publicKeyForVersion(version int) ([]byte, bool) {
+	if version == 0 || version == e.currentVersion() {
+		return e.PublicKey, true
+	}
+	if v, ok := e.PreviousVersions[version]; ok {
+		return v.PublicKey, true
+	}
+	return nil, false
+}
+
+// seedEntry is the HD seed keys can be derived from. It is stored seal
+// wrapped and never returned.
+type seedEntry struct {
+	// Seed is the 16 to 64-byte BIP-32 seed.
+	Seed []byte `json:"seed"`
+
+	// CreatedAt is when the seed was configured.
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -14,20 +14,23 @@ type AuditService struct {
 	client *Client
 }
 
-// AuditFilter specifies filters for querying audit logs.
-type AuditFilter struct {
-	// Event filters by event type.
-	Event *AuditEvent
+// AuditQuery specifies filters for querying audit logs.
+type AuditQuery struct {
+	// KeyID restricts results to events on a single key.
+	// It takes precedence over ResourceType and ResourceID.
+	KeyID *uuid.UUID
+	// Action filters by event type, e.g. AuditEventKeySigned.
+	Action *AuditEvent
+	// ActorID filters by actor ID.
+	ActorID *uuid.UUID
 	// ResourceType filters by resource type.
 	ResourceType *ResourceType
 	// ResourceID filters by resource ID.
 	ResourceID *uuid.UUID
-	// ActorID filters by actor ID.
-	ActorID *uuid.UUID
-	// StartTime filters logs after this time.
-	StartTime *time.Time
-	// EndTime filters logs before this time.
-	EndTime *time.Time
+	// From returns only logs created at or after this time.
+	From *time.Time
+	// To returns only logs created at or before this time.
+	To *time.Time
 	// Limit is the maximum number of logs to return (max 100).
 	Limit int
 	// Cursor is the pagination cursor from a previous response.
@@ -36,60 +39,71 @@ type AuditFilter struct {
 
 // AuditListResponse is the response from listing audit logs.
 type AuditListResponse struct {
-	// Logs is the list of audit logs.
+	// Logs is the list of audit logs, newest first.
 	Logs []*AuditLog
 	// NextCursor is the cursor for the next page, empty if no more pages.
 	NextCursor string
 }
 
-// List retrieves audit logs with optional filters.
+// List retrieves a page of audit logs matching the query.
 //
 // Example:
 //
 //	// Get all audit logs
-//	resp, err := client.Audit.List(ctx, nil)
+//	resp, err := client.Audit.List(ctx, popsigner.AuditQuery{})
 //
-//	// Get logs for a specific key
-//	keyID := uuid.MustParse("...")
-//	resp, err := client.Audit.List(ctx, &popsigner.AuditFilter{
-//	    ResourceType: popsigner.Ptr(popsigner.ResourceTypeKey),
-//	    ResourceID:   &keyID,
+//	// Get signatures made with a key over the last day
+//	resp, err := client.Audit.List(ctx, popsigner.AuditQuery{
+//	    KeyID:  &keyID,
+//	    Action: popsigner.Ptr(popsigner.AuditEventKeySigned),
+//	    From:   popsigner.Ptr(time.Now().Add(-24 * time.Hour)),
 //	})
 //
 //	// Paginate through results
-//	resp, _ := client.Audit.List(ctx, nil)
-//	for resp.NextCursor != "" {
-//	    resp, _ = client.Audit.List(ctx, &popsigner.AuditFilter{Cursor: resp.NextCursor})
+//	q := popsigner.AuditQuery{Limit: 100}
+//	for {
+//	    resp, err := client.Audit.List(ctx, q)
+//	    if err != nil {
+//	        break
+//	    }
+//	    // ... use resp.Logs
+//	    if resp.NextCursor == "" {
+//	        break
+//	    }
+//	    q.Cursor = resp.NextCursor
 //	}
-func (s *AuditService) List(ctx context.Context, filter *AuditFilter) (*AuditListResponse, error) {
+func (s *AuditService) List(ctx context.Context, query AuditQuery) (*AuditListResponse, error) {
 	// Build query parameters
 	params := url.Values{}
-	if filter != nil {
-		if filter.Event != nil {
-			params.Set("event", string(*filter.Event))
-		}
-		if filter.ResourceType != nil {
-			params.Set("resource_type", string(*filter.ResourceType))
-		}
-		if filter.ResourceID != nil {
-			params.Set("resource_id", filter.ResourceID.String())
-		}
-		if filter.ActorID != nil {
-			params.Set("actor_id", filter.ActorID.String())
-		}
-		if filter.StartTime != nil {
-			params.Set("start_time", filter.StartTime.Format(time.RFC3339))
-		}
-		if filter.EndTime != nil {
-			params.Set("end_time", filter.EndTime.Format(time.RFC3339))
-		}
-		if filter.Limit > 0 {
-			params.Set("limit", fmt.Sprintf("%d", filter.Limit))
+	if query.Action != nil {
+		params.Set("event", string(*query.Action))
+	}
+	if query.KeyID != nil {
+		params.Set("resource_type", string(ResourceTypeKey))
+		params.Set("resource_id", query.KeyID.String())
+	} else {
+		if query.ResourceType != nil {
+			params.Set("resource_type", string(*query.ResourceType))
 		}
-		if filter.Cursor != "" {
-			params.Set("cursor", filter.Cursor)
+		if query.ResourceID != nil {
+			params.Set("resource_id", query.ResourceID.String())
 		}
 	}
+	if query.ActorID != nil {
+		params.Set("actor_id", query.ActorID.String())
+	}
+	if query.From != nil {
+		params.Set("start_time", query.From.UTC().Format(time.RFC3339))
+	}
+	if query.To != nil {
+		params.Set("end_time", query.To.UTC().Format(time.RFC3339))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", query.Limit))
+	}
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	}
 
 	path := "/v1/audit/logs"
 	if len(params) > 0 {
@@ -167,17 +181,25 @@ func (r *auditLogResponse) toAuditLog() *AuditLog {
 		IPAddress:    r.IPAddress,
 		UserAgent:    r.UserAgent,
 		Metadata:     r.Metadata,
+		RequestHash:  metadataString(r.Metadata, "request_hash"),
+		Result:       metadataString(r.Metadata, "result"),
 		CreatedAt:    createdAt,
 	}
 }
 
+// metadataString returns a string metadata value, or "" if absent.
+func metadataString(metadata map[string]interface{}, key string) string {
+	v, _ := metadata[key].(string)
+	return v
+}
+
 // Ptr is a helper function to create a pointer to a value.
 // Useful for setting optional filter parameters.
 //
 // Example:
 //
-//	filter := &popsigner.AuditFilter{
-//	    Event: popsigner.Ptr(popsigner.AuditEventKeySigned),
+//	query := popsigner.AuditQuery{
+//	    Action: popsigner.Ptr(popsigner.AuditEventKeySigned),
 //	}
 func Ptr[T any](v T) *T {
 	return &v
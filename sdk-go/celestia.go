@@ -134,7 +134,7 @@ func NewCelestiaKeyring(apiKey, keyNameOrID string, opts ...CelestiaKeyringOptio
 		}
 	} else {
 		// Not a UUID - treat as key name, look it up
-		keys, err := client.Keys.List(context.Background(), nil)
+		keys, err := client.Keys.ListAll(context.Background(), nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list keys: %w", err)
 		}
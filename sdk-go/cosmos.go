@@ -0,0 +1,85 @@
+package popsigner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/google/uuid"
+)
+
+// CosmosSignResult is the result of signing Cosmos SDK sign bytes.
+type CosmosSignResult struct {
+	// Signature is the 64-byte R||S secp256k1 signature.
+	Signature []byte
+	// PubKey is the secp256k1 public key that produced the signature.
+	PubKey cryptotypes.PubKey
+	// SignMode is the sign mode the sign bytes were produced with.
+	SignMode signing.SignMode
+	// KeyVersion is the version of the key that signed.
+	KeyVersion int
+}
+
+// SignatureV2 assembles a single-signer SignatureV2 for use with a Cosmos SDK
+// TxBuilder's SetSignatures.
+func (r *CosmosSignResult) SignatureV2(sequence uint64) signing.SignatureV2 {
+	return signing.SignatureV2{
+		PubKey: r.PubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  r.SignMode,
+			Signature: r.Signature,
+		},
+		Sequence: sequence,
+	}
+}
+
+// CosmosTx signs Cosmos SDK sign bytes with a secp256k1 key.
+//
+// signDoc is the output of the sign mode handler for the transaction, e.g. the
+// serialized SignDoc for SIGN_MODE_DIRECT or the canonical JSON for
+// SIGN_MODE_LEGACY_AMINO_JSON. It is hashed with SHA-256, the same convention
+// used by BaoKeyring.Sign and Cosmos SDK secp256k1 keys.
+//
+// Example:
+//
+//	signBytes, err := authsigning.GetSignBytesAdapter(ctx, handler, signing.SignMode_SIGN_MODE_DIRECT, signerData, txBuilder.GetTx())
+//	result, err := client.Sign.CosmosTx(ctx, keyID, signBytes, signing.SignMode_SIGN_MODE_DIRECT)
+//	err = txBuilder.SetSignatures(result.SignatureV2(sequence))
+func (s *SignService) CosmosTx(ctx context.Context, keyID uuid.UUID, signDoc []byte, mode signing.SignMode) (*CosmosSignResult, error) {
+	if len(signDoc) == 0 {
+		return nil, errors.New("sign doc is required")
+	}
+	if mode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		return nil, errors.New("sign mode is required")
+	}
+
+	hash := sha256.Sum256(signDoc)
+	result, err := s.Sign(ctx, keyID, hash[:], true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Signature) != 64 {
+		return nil, fmt.Errorf("invalid signature length: expected 64 bytes, got %d", len(result.Signature))
+	}
+
+	pubKeyBytes, err := hex.DecodeString(result.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(pubKeyBytes) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("invalid public key length: expected %d bytes, got %d", secp256k1.PubKeySize, len(pubKeyBytes))
+	}
+
+	return &CosmosSignResult{
+		Signature:  result.Signature,
+		PubKey:     &secp256k1.PubKey{Key: pubKeyBytes},
+		SignMode:   mode,
+		KeyVersion: result.KeyVersion,
+	}, nil
+}
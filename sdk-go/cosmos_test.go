@@ -0,0 +1,109 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/google/uuid"
+)
+
+func TestSignService_CosmosTx(t *testing.T) {
+	privKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	pubKeyHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	directSignDoc, err := (&txtypes.SignDoc{
+		BodyBytes:     []byte{0x0a, 0x00},
+		AuthInfoBytes: []byte{0x12, 0x00},
+		ChainId:       "mocha-4",
+		AccountNumber: 42,
+	}).Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal sign doc: %v", err)
+	}
+	// Canonical (sorted, compact) StdSignDoc JSON as produced by the amino JSON sign mode handler.
+	aminoSignDoc := []byte(`{"account_number":"42","chain_id":"mocha-4","fee":{"amount":[],"gas":"200000"},"memo":"memo","msgs":[],"sequence":"7"}`)
+
+	tests := []struct {
+		name    string
+		signDoc []byte
+		mode    signing.SignMode
+	}{
+		{"direct", directSignDoc, signing.SignMode_SIGN_MODE_DIRECT},
+		{"legacy amino json", aminoSignDoc, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Data      string `json:"data"`
+					Prehashed bool   `json:"prehashed"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("failed to decode request: %v", err)
+				}
+				if !req.Prehashed {
+					t.Error("expected prehashed=true")
+				}
+				hash, _ := base64.StdEncoding.DecodeString(req.Data)
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{
+						"signature":   base64.StdEncoding.EncodeToString(signCompact(t, privKey, hash)),
+						"public_key":  pubKeyHex,
+						"key_version": 1,
+					},
+				})
+			})
+
+			result, err := client.Sign.CosmosTx(context.Background(), uuid.New(), tt.signDoc, tt.mode)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(result.Signature) != 64 {
+				t.Errorf("expected 64-byte signature, got %d", len(result.Signature))
+			}
+			if !result.PubKey.VerifySignature(tt.signDoc, result.Signature) {
+				t.Error("expected signature to verify against the returned public key")
+			}
+
+			sigV2 := result.SignatureV2(7)
+			data, ok := sigV2.Data.(*signing.SingleSignatureData)
+			if !ok {
+				t.Fatalf("expected SingleSignatureData, got %T", sigV2.Data)
+			}
+			if data.SignMode != tt.mode {
+				t.Errorf("expected sign mode %v, got %v", tt.mode, data.SignMode)
+			}
+			if sigV2.Sequence != 7 {
+				t.Errorf("expected sequence 7, got %d", sigV2.Sequence)
+			}
+			if !sigV2.PubKey.Equals(result.PubKey) {
+				t.Error("expected SignatureV2 to carry the signing public key")
+			}
+		})
+	}
+}
+
+func TestSignService_CosmosTx_InvalidArgs(t *testing.T) {
+	client := NewClient("test-api-key")
+
+	if _, err := client.Sign.CosmosTx(context.Background(), uuid.New(), nil, signing.SignMode_SIGN_MODE_DIRECT); err == nil {
+		t.Error("expected error for empty sign doc")
+	}
+	if _, err := client.Sign.CosmosTx(context.Background(), uuid.New(), []byte("doc"), signing.SignMode_SIGN_MODE_UNSPECIFIED); err == nil {
+		t.Error("expected error for unspecified sign mode")
+	}
+}
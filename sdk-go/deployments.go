@@ -0,0 +1,297 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeploymentsService handles chain deployment operations.
+type DeploymentsService struct {
+	client *Client
+}
+
+// Deployment statuses.
+const (
+	DeploymentStatusPending   = "pending"
+	DeploymentStatusRunning   = "running"
+	DeploymentStatusPaused    = "paused"
+	DeploymentStatusCompleted = "completed"
+	DeploymentStatusSimulated = "simulated" // Ran in simulation mode; no contracts were deployed
+	DeploymentStatusFailed    = "failed"
+)
+
+// DefaultDeploymentPollInterval is how often WaitForCompletion checks the
+// deployment status unless WaitOptions.PollInterval is set.
+const DefaultDeploymentPollInterval = 5 * time.Second
+
+// Deployment is a chain deployment.
+type Deployment struct {
+	ID           uuid.UUID       `json:"id"`
+	OrgID        uuid.UUID       `json:"org_id"`
+	ChainID      int64           `json:"chain_id"`
+	ChainName    string          `json:"chain_name,omitempty"`
+	Stack        string          `json:"stack"`
+	BundleStack  string          `json:"bundle_stack,omitempty"` // "opstack" or "nitro" for pop-bundle deployments
+	Status       string          `json:"status"`
+	CurrentStage string          `json:"current_stage,omitempty"`
+	Config       json.RawMessage `json:"config"`
+	Error        string          `json:"error,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// Done reports whether the deployment has finished, successfully or not.
+func (d *Deployment) Done() bool {
+	switch d.Status {
+	case DeploymentStatusCompleted, DeploymentStatusSimulated, DeploymentStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeploymentArtifact is a file produced by a deployment, such as the
+// genesis or rollup config. Content is the stored JSON form; use
+// GetArtifact for the file itself.
+type DeploymentArtifact struct {
+	Type      string          `json:"type"`
+	Content   json.RawMessage `json:"content"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ArtifactInfo describes a deployment artifact without its content.
+type ArtifactInfo struct {
+	// Type is the artifact name, e.g. "genesis.json".
+	Type string `json:"type"`
+	// Size is the length in bytes of the file GetArtifact returns.
+	Size int64 `json:"size"`
+	// ContentType is the inferred media type, e.g. "application/json".
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DeploymentResult is the final state of a deployment.
+type DeploymentResult struct {
+	Deployment *Deployment
+	Artifacts  []*DeploymentArtifact
+}
+
+// WaitOptions configures WaitForCompletion.
+type WaitOptions struct {
+	// PollInterval is how often the status is checked (default: DefaultDeploymentPollInterval).
+	PollInterval time.Duration
+	// OnProgress, if set, is called with the deployment each time its status
+	// or stage changes, including the first and final checks.
+	OnProgress func(*Deployment)
+}
+
+// DeploymentListOptions are options for listing deployments.
+type DeploymentListOptions struct {
+	// Status filters deployments by status, e.g. DeploymentStatusFailed.
+	Status string
+	// Limit is the maximum number of deployments to return per page (max 100).
+	Limit int
+	// Cursor is the pagination cursor from a previous response.
+	Cursor string
+}
+
+// DeploymentListResult is the response from listing deployments.
+type DeploymentListResult struct {
+	// Deployments is the list of deployments in this page, newest first.
+	Deployments []*Deployment
+	// NextCursor is the cursor for the next page, empty if no more pages.
+	NextCursor string
+}
+
+// List returns a page of the organization's deployments, newest first.
+// Pagination is opt-in: unless Limit or Cursor is set, the server returns
+// every deployment in a single response and NextCursor is empty.
+//
+// Example:
+//
+//	result, err := client.Deployments.List(ctx, &popsigner.DeploymentListOptions{Limit: 20})
+//	for _, d := range result.Deployments {
+//	    fmt.Println(d.ChainName, d.Status, d.CreatedAt)
+//	}
+//
+//	// Paginate through results
+//	for result.NextCursor != "" {
+//	    result, err = client.Deployments.List(ctx, &popsigner.DeploymentListOptions{
+//	        Limit:  20,
+//	        Cursor: result.NextCursor,
+//	    })
+//	}
+func (s *DeploymentsService) List(ctx context.Context, opts *DeploymentListOptions) (*DeploymentListResult, error) {
+	params := url.Values{}
+	if opts != nil {
+		if opts.Status != "" {
+			params.Set("status", opts.Status)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+	}
+
+	path := "/v1/deployments"
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+
+	var resp struct {
+		Data []*Deployment `json:"data"`
+		Meta *struct {
+			NextCursor string `json:"next_cursor,omitempty"`
+		} `json:"meta,omitempty"`
+	}
+	if err := s.client.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &DeploymentListResult{Deployments: resp.Data}
+	if resp.Meta != nil {
+		result.NextCursor = resp.Meta.NextCursor
+	}
+	return result, nil
+}
+
+// Get retrieves a deployment by ID.
+//
+// Example:
+//
+//	d, err := client.Deployments.Get(ctx, deploymentID)
+//	fmt.Println(d.Status, d.CurrentStage)
+func (s *DeploymentsService) Get(ctx context.Context, deploymentID uuid.UUID) (*Deployment, error) {
+	var resp struct {
+		Data Deployment `json:"data"`
+	}
+	if err := s.client.get(ctx, fmt.Sprintf("/v1/deployments/%s", deploymentID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete permanently removes a deployment together with its transactions and
+// artifacts. Deleting a running deployment fails with a conflict error; it
+// has to be cancelled first.
+//
+// Example:
+//
+//	err := client.Deployments.Delete(ctx, deploymentID)
+//	if apiErr, ok := popsigner.IsAPIError(err); ok && apiErr.IsConflict() {
+//	    // still running
+//	}
+func (s *DeploymentsService) Delete(ctx context.Context, deploymentID uuid.UUID) error {
+	return s.client.delete(ctx, fmt.Sprintf("/v1/deployments/%s", deploymentID))
+}
+
+// Artifacts returns the artifacts a deployment has produced so far.
+//
+// Example:
+//
+//	artifacts, err := client.Deployments.Artifacts(ctx, deploymentID)
+func (s *DeploymentsService) Artifacts(ctx context.Context, deploymentID uuid.UUID) ([]*DeploymentArtifact, error) {
+	var resp struct {
+		Data struct {
+			Artifacts []*DeploymentArtifact `json:"artifacts"`
+		} `json:"data"`
+	}
+	if err := s.client.get(ctx, fmt.Sprintf("/v1/deployments/%s/artifacts", deploymentID), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data.Artifacts, nil
+}
+
+// ListArtifacts describes a deployment's artifacts without downloading
+// them, e.g. to render a file browser. Internal deployer state is left out.
+//
+// Example:
+//
+//	files, err := client.Deployments.ListArtifacts(ctx, deploymentID)
+//	for _, f := range files {
+//	    fmt.Printf("%s\t%d bytes\t%s\n", f.Type, f.Size, f.ContentType)
+//	}
+func (s *DeploymentsService) ListArtifacts(ctx context.Context, deploymentID uuid.UUID) ([]*ArtifactInfo, error) {
+	var resp struct {
+		Data struct {
+			Artifacts []*ArtifactInfo `json:"artifacts"`
+		} `json:"data"`
+	}
+	if err := s.client.get(ctx, fmt.Sprintf("/v1/deployments/%s/artifacts?content=false", deploymentID), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data.Artifacts, nil
+}
+
+// GetArtifact downloads a single artifact by name, such as "genesis.json"
+// or "jwt.txt", without fetching the whole bundle. Plain-text artifacts are
+// returned as the original text rather than in their JSON storage form.
+//
+// Example:
+//
+//	genesis, err := client.Deployments.GetArtifact(ctx, deploymentID, "genesis.json")
+//	err = os.WriteFile("genesis.json", genesis, 0o644)
+func (s *DeploymentsService) GetArtifact(ctx context.Context, deploymentID uuid.UUID, name string) ([]byte, error) {
+	var content []byte
+	path := fmt.Sprintf("/v1/deployments/%s/artifacts/%s?raw=true", deploymentID, url.PathEscape(name))
+	if err := s.client.get(ctx, path, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// WaitForCompletion polls a deployment until it is completed, simulated or
+// failed, then returns its final state and artifacts. A failed deployment is
+// not an error: check Deployment.Status and Deployment.Error. Use a context
+// deadline to bound the wait; the context's error is returned if it ends
+// first.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+//	defer cancel()
+//	result, err := client.Deployments.WaitForCompletion(ctx, deploymentID, popsigner.WaitOptions{
+//	    OnProgress: func(d *popsigner.Deployment) {
+//	        log.Printf("deployment %s: %s %s", d.ID, d.Status, d.CurrentStage)
+//	    },
+//	})
+//	if err == nil && result.Deployment.Status == popsigner.DeploymentStatusFailed {
+//	    log.Printf("deployment failed: %s", result.Deployment.Error)
+//	}
+func (s *DeploymentsService) WaitForCompletion(ctx context.Context, deploymentID uuid.UUID, opts WaitOptions) (*DeploymentResult, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultDeploymentPollInterval
+	}
+
+	var last *Deployment
+	for {
+		d, err := s.Get(ctx, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		if opts.OnProgress != nil && (last == nil || d.Status != last.Status || d.CurrentStage != last.CurrentStage) {
+			opts.OnProgress(d)
+		}
+		last = d
+
+		if d.Done() {
+			artifacts, err := s.Artifacts(ctx, deploymentID)
+			if err != nil {
+				return nil, err
+			}
+			return &DeploymentResult{Deployment: d, Artifacts: artifacts}, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDeploymentsService_WaitForCompletion(t *testing.T) {
+	deploymentID := uuid.New()
+	stages := []struct{ status, stage string }{
+		{"running", "init"},
+		{"running", "init"},
+		{"running", "contracts"},
+		{"completed", "done"},
+	}
+	var polls int32
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/deployments/" + deploymentID.String():
+			n := int(atomic.AddInt32(&polls, 1)) - 1
+			if n >= len(stages) {
+				n = len(stages) - 1
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":            deploymentID.String(),
+					"chain_id":      42069,
+					"stack":         "opstack",
+					"status":        stages[n].status,
+					"current_stage": stages[n].stage,
+					"created_at":    "2024-01-01T00:00:00Z",
+					"updated_at":    "2024-01-01T00:00:00Z",
+				},
+			})
+		case "/v1/deployments/" + deploymentID.String() + "/artifacts":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"artifacts": []map[string]interface{}{
+						{"type": "genesis", "content": map[string]string{"hash": "0xabc"}, "created_at": "2024-01-01T00:00:00Z"},
+						{"type": "rollup_config", "content": map[string]string{}, "created_at": "2024-01-01T00:00:00Z"},
+					},
+				},
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	var progress []string
+	result, err := client.Deployments.WaitForCompletion(context.Background(), deploymentID, WaitOptions{
+		PollInterval: time.Millisecond,
+		OnProgress: func(d *Deployment) {
+			progress = append(progress, d.Status+"/"+d.CurrentStage)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Deployment.Status != DeploymentStatusCompleted {
+		t.Errorf("expected status completed, got %s", result.Deployment.Status)
+	}
+	if len(result.Artifacts) != 2 || result.Artifacts[0].Type != "genesis" {
+		t.Errorf("unexpected artifacts: %+v", result.Artifacts)
+	}
+	if polls != 4 {
+		t.Errorf("expected 4 status polls, got %d", polls)
+	}
+	want := []string{"running/init", "running/contracts", "completed/done"}
+	if len(progress) != len(want) {
+		t.Fatalf("expected progress %v, got %v", want, progress)
+	}
+	for i := range want {
+		if progress[i] != want[i] {
+			t.Errorf("progress[%d] = %s, want %s", i, progress[i], want[i])
+		}
+	}
+}
+
+func TestDeploymentsService_WaitForCompletionFailed(t *testing.T) {
+	deploymentID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/deployments/"+deploymentID.String()+"/artifacts" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"artifacts": []interface{}{}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":     deploymentID.String(),
+				"status": "failed",
+				"error":  "insufficient L1 funds",
+			},
+		})
+	})
+
+	result, err := client.Deployments.WaitForCompletion(context.Background(), deploymentID, WaitOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Deployment.Status != DeploymentStatusFailed || result.Deployment.Error != "insufficient L1 funds" {
+		t.Errorf("unexpected deployment: %+v", result.Deployment)
+	}
+}
+
+func TestDeploymentsService_WaitForCompletionTimeout(t *testing.T) {
+	deploymentID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":     deploymentID.String(),
+				"status": "running",
+			},
+		})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Deployments.WaitForCompletion(ctx, deploymentID, WaitOptions{PollInterval: time.Hour})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return at the deadline, took %v", elapsed)
+	}
+}
+
+func TestDeploymentsService_GetArtifact(t *testing.T) {
+	deploymentID := uuid.New()
+	files := map[string]string{
+		"genesis.json": `{"config":{"chainId":42069}}`,
+		"jwt.txt":      "0xdeadbeef\n",
+	}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		prefix := "/v1/deployments/" + deploymentID.String() + "/artifacts/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("raw") != "true" {
+			t.Errorf("expected raw=true, got %q", r.URL.RawQuery)
+		}
+		content, ok := files[strings.TrimPrefix(r.URL.Path, prefix)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"code": "not_found", "message": "artifact not found"},
+			})
+			return
+		}
+		w.Write([]byte(content))
+	})
+
+	for name, want := range files {
+		got, err := client.Deployments.GetArtifact(context.Background(), deploymentID, name)
+		if err != nil {
+			t.Fatalf("GetArtifact(%s): unexpected error: %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("GetArtifact(%s) = %q, want %q", name, got, want)
+		}
+	}
+
+	_, err := client.Deployments.GetArtifact(context.Background(), deploymentID, "missing.json")
+	if apiErr, ok := IsAPIError(err); !ok || !apiErr.IsNotFound() {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestDeploymentsService_ListArtifacts(t *testing.T) {
+	deploymentID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/deployments/"+deploymentID.String()+"/artifacts" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("content") != "false" {
+			t.Errorf("expected content=false, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"artifacts": []map[string]interface{}{
+					{"type": "genesis.json", "size": 1048576, "content_type": "application/json", "created_at": "2024-01-01T00:00:00Z"},
+					{"type": "jwt.txt", "size": 67, "content_type": "text/plain; charset=utf-8", "created_at": "2024-01-01T00:00:00Z"},
+				},
+			},
+		})
+	})
+
+	files, err := client.Deployments.ListArtifacts(context.Background(), deploymentID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 artifacts, got %d", len(files))
+	}
+	if files[0].Type != "genesis.json" || files[0].Size != 1048576 || files[0].ContentType != "application/json" {
+		t.Errorf("unexpected artifact: %+v", files[0])
+	}
+	if files[1].Type != "jwt.txt" || files[1].Size != 67 || files[1].CreatedAt.IsZero() {
+		t.Errorf("unexpected artifact: %+v", files[1])
+	}
+}
+
+func TestDeploymentsService_List(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/deployments" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("limit") != "2" || q.Get("status") != "completed" || q.Get("cursor") != "abc" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{
+					"id":           uuid.New().String(),
+					"chain_id":     42069,
+					"chain_name":   "devnet",
+					"stack":        "pop-bundle",
+					"bundle_stack": "nitro",
+					"status":       "completed",
+					"created_at":   "2024-01-02T00:00:00Z",
+					"updated_at":   "2024-01-02T00:00:00Z",
+				},
+				{
+					"id":         uuid.New().String(),
+					"chain_id":   42070,
+					"stack":      "opstack",
+					"status":     "completed",
+					"created_at": "2024-01-01T00:00:00Z",
+					"updated_at": "2024-01-01T00:00:00Z",
+				},
+			},
+			"meta": map[string]string{"next_cursor": "def"},
+		})
+	})
+
+	result, err := client.Deployments.List(context.Background(), &DeploymentListOptions{
+		Status: DeploymentStatusCompleted,
+		Limit:  2,
+		Cursor: "abc",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Deployments) != 2 {
+		t.Fatalf("expected 2 deployments, got %d", len(result.Deployments))
+	}
+	d := result.Deployments[0]
+	if d.ChainName != "devnet" || d.BundleStack != "nitro" || d.ChainID != 42069 || d.CreatedAt.IsZero() {
+		t.Errorf("unexpected deployment: %+v", d)
+	}
+	if result.NextCursor != "def" {
+		t.Errorf("expected next cursor def, got %q", result.NextCursor)
+	}
+}
+
+func TestDeploymentsService_ListAll(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+	})
+
+	result, err := client.Deployments.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Deployments) != 0 || result.NextCursor != "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestDeploymentsService_Delete(t *testing.T) {
+	deploymentID := uuid.New()
+	running := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected DELETE, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/v1/deployments/" + deploymentID.String():
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/deployments/" + running.String():
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"code": "conflict", "message": "deployment is running; cancel it before deleting"},
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	if err := client.Deployments.Delete(context.Background(), deploymentID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := client.Deployments.Delete(context.Background(), running)
+	if apiErr, ok := IsAPIError(err); !ok || !apiErr.IsConflict() {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+}
@@ -2,10 +2,40 @@ package popsigner
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// Error codes returned by the API. These match the codes emitted by the
+// control plane's error responses.
+const (
+	CodeBadRequest         = "bad_request"
+	CodeValidation         = "validation_error"
+	CodeUnauthorized       = "unauthorized"
+	CodeForbidden          = "forbidden"
+	CodeNotFound           = "not_found"
+	CodeConflict           = "conflict"
+	CodeQuotaExceeded      = "quota_exceeded"
+	CodeRateLimited        = "rate_limited"
+	CodeInternal           = "internal_error"
+	CodeServiceUnavailable = "service_unavailable"
+)
+
+// statusCodes maps HTTP status codes to the error code the API uses for them.
+// It is used when a response carries no structured error body.
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusPaymentRequired:     CodeQuotaExceeded,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusTooManyRequests:     CodeRateLimited,
+	http.StatusInternalServerError: CodeInternal,
+	http.StatusServiceUnavailable:  CodeServiceUnavailable,
+}
+
 // Error represents an API error response.
 type Error struct {
 	// StatusCode is the HTTP status code.
@@ -26,29 +56,80 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// Is reports whether the error matches target, so that errors.Is works with
+// the sentinel errors below. Sentinels match by category using the predicate
+// methods; any other *Error matches on Code.
+//
+// Example:
+//
+//	if errors.Is(err, popsigner.ErrQuotaExceeded) { ... }
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	switch t {
+	case ErrUnauthorized:
+		return e.IsUnauthorized()
+	case ErrForbidden:
+		return e.IsForbidden()
+	case ErrNotFound:
+		return e.IsNotFound()
+	case ErrRateLimited:
+		return e.IsRateLimited()
+	case ErrQuotaExceeded:
+		return e.IsQuotaExceeded()
+	case ErrValidation:
+		return e.IsValidation()
+	case ErrConflict:
+		return e.IsConflict()
+	}
+	return e.Code != "" && e.Code == t.Code
+}
+
 // IsNotFound returns true if the error is a not found error.
 func (e *Error) IsNotFound() bool {
-	return e.StatusCode == http.StatusNotFound || e.Code == "not_found"
+	return e.StatusCode == http.StatusNotFound || e.Code == CodeNotFound
 }
 
 // IsUnauthorized returns true if the error is an authorization error.
 func (e *Error) IsUnauthorized() bool {
-	return e.StatusCode == http.StatusUnauthorized || e.Code == "unauthorized"
+	return e.StatusCode == http.StatusUnauthorized || e.Code == CodeUnauthorized
 }
 
 // IsForbidden returns true if the error is a permission error.
 func (e *Error) IsForbidden() bool {
-	return e.StatusCode == http.StatusForbidden || e.Code == "forbidden"
+	return e.StatusCode == http.StatusForbidden || e.Code == CodeForbidden
 }
 
 // IsRateLimited returns true if the error is a rate limit error.
 func (e *Error) IsRateLimited() bool {
-	return e.StatusCode == http.StatusTooManyRequests || e.Code == "rate_limited"
+	return e.StatusCode == http.StatusTooManyRequests || e.Code == CodeRateLimited
+}
+
+// IsQuotaExceeded returns true if the organization's plan limits were exceeded.
+func (e *Error) IsQuotaExceeded() bool {
+	return e.StatusCode == http.StatusPaymentRequired || e.Code == CodeQuotaExceeded
+}
+
+// IsValidation returns true if the request was rejected as invalid, either
+// because a field failed validation or because the request was malformed.
+func (e *Error) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.Code == CodeValidation || e.Code == CodeBadRequest
 }
 
 // IsValidationError returns true if the error is a validation error.
+//
+// Deprecated: Use IsValidation.
 func (e *Error) IsValidationError() bool {
-	return e.StatusCode == http.StatusBadRequest || e.Code == "validation_error"
+	return e.IsValidation()
+}
+
+// IsConflict returns true if the resource already exists or is in a
+// conflicting state.
+func (e *Error) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict || e.Code == CodeConflict
 }
 
 // Common error codes.
@@ -56,30 +137,51 @@ var (
 	// ErrUnauthorized is returned when the API key is invalid or missing.
 	ErrUnauthorized = &Error{
 		StatusCode: http.StatusUnauthorized,
-		Code:       "unauthorized",
+		Code:       CodeUnauthorized,
 		Message:    "Invalid or missing API key",
 	}
 
 	// ErrForbidden is returned when the API key lacks required permissions.
 	ErrForbidden = &Error{
 		StatusCode: http.StatusForbidden,
-		Code:       "forbidden",
+		Code:       CodeForbidden,
 		Message:    "Insufficient permissions",
 	}
 
 	// ErrNotFound is returned when a resource is not found.
 	ErrNotFound = &Error{
 		StatusCode: http.StatusNotFound,
-		Code:       "not_found",
+		Code:       CodeNotFound,
 		Message:    "Resource not found",
 	}
 
 	// ErrRateLimited is returned when rate limits are exceeded.
 	ErrRateLimited = &Error{
 		StatusCode: http.StatusTooManyRequests,
-		Code:       "rate_limited",
+		Code:       CodeRateLimited,
 		Message:    "Rate limit exceeded",
 	}
+
+	// ErrQuotaExceeded is returned when the organization's plan limits are exceeded.
+	ErrQuotaExceeded = &Error{
+		StatusCode: http.StatusPaymentRequired,
+		Code:       CodeQuotaExceeded,
+		Message:    "Plan limits exceeded",
+	}
+
+	// ErrValidation is returned when the request is malformed or fails validation.
+	ErrValidation = &Error{
+		StatusCode: http.StatusBadRequest,
+		Code:       CodeValidation,
+		Message:    "Validation failed",
+	}
+
+	// ErrConflict is returned when a resource already exists.
+	ErrConflict = &Error{
+		StatusCode: http.StatusConflict,
+		Code:       CodeConflict,
+		Message:    "Resource already exists",
+	}
 )
 
 // parseError parses an error response from the API.
@@ -119,16 +221,22 @@ func parseError(statusCode int, body []byte) error {
 	}
 
 	// Fallback to generic error
+	code, ok := statusCodes[statusCode]
+	if !ok {
+		code = http.StatusText(statusCode)
+	}
 	return &Error{
 		StatusCode: statusCode,
-		Code:       http.StatusText(statusCode),
+		Code:       code,
 		Message:    string(body),
 	}
 }
 
 // IsAPIError checks if an error is an API error and returns it.
+// Wrapped errors are unwrapped.
 func IsAPIError(err error) (*Error, bool) {
-	if apiErr, ok := err.(*Error); ok {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
 		return apiErr, true
 	}
 	return nil, false
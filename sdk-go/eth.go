@@ -0,0 +1,100 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// secp256k1HalfN is half the order of the secp256k1 curve, used to normalize
+// signatures to low-S form as required by Ethereum.
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// EthTransaction signs an Ethereum transaction with a secp256k1 key.
+//
+// The signing hash is computed client-side for the transaction's type (legacy
+// with EIP-155 replay protection, EIP-2930 or EIP-1559) and sent to the API as
+// a prehashed Keccak-256 digest. The recovery ID is derived locally from the
+// key's public key, and the returned transaction carries the v, r, s values.
+//
+// Example:
+//
+//	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: nonce, ...})
+//	signed, err := client.Sign.EthTransaction(ctx, keyID, tx, chainID)
+//	err = ethClient.SendTransaction(ctx, signed)
+func (s *SignService) EthTransaction(ctx context.Context, keyID uuid.UUID, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, errors.New("chain ID must be positive")
+	}
+
+	signer := types.LatestSignerForChainID(chainID)
+	hash := signer.Hash(tx)
+
+	result, err := s.Sign(ctx, keyID, hash[:], true)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := ethSignature(hash[:], result.Signature, result.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.WithSignature(signer, sig)
+}
+
+// ethSignature converts a 64-byte R||S signature into the 65-byte R||S||V form
+// expected by go-ethereum, normalizing S and finding the recovery ID that
+// matches pubKeyHex.
+func ethSignature(hash, rs []byte, pubKeyHex string) ([]byte, error) {
+	if len(rs) != 64 && len(rs) != 65 {
+		return nil, fmt.Errorf("invalid signature length: expected 64 or 65 bytes, got %d", len(rs))
+	}
+
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(pubKeyBytes) == 33 {
+		pub, err := crypto.DecompressPubkey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		pubKeyBytes = crypto.FromECDSAPub(pub)
+	}
+	expected, err := crypto.UnmarshalPubkey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	expectedAddr := crypto.PubkeyToAddress(*expected)
+
+	sig := make([]byte, 65)
+	copy(sig, rs[:64])
+
+	// Ethereum rejects high-S signatures; flip S into the lower half of the curve.
+	sVal := new(big.Int).SetBytes(sig[32:64])
+	if sVal.Cmp(secp256k1HalfN) > 0 {
+		sVal.Sub(crypto.S256().Params().N, sVal)
+		sVal.FillBytes(sig[32:64])
+	}
+
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.SigToPub(hash, sig)
+		if err == nil && crypto.PubkeyToAddress(*recovered) == expectedAddr {
+			return sig, nil
+		}
+	}
+
+	return nil, errors.New("signature does not match the key's public key")
+}
@@ -0,0 +1,164 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+func TestSignService_EthTransaction(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyAddr := crypto.PubkeyToAddress(privKey.PublicKey)
+	keyID := uuid.New()
+	chainID := big.NewInt(11155420)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	tests := []struct {
+		name  string
+		tx    *types.Transaction
+		highS bool
+	}{
+		{
+			name: "legacy",
+			tx: types.NewTx(&types.LegacyTx{
+				Nonce:    7,
+				GasPrice: big.NewInt(1_000_000_000),
+				Gas:      21000,
+				To:       &to,
+				Value:    big.NewInt(1),
+			}),
+		},
+		{
+			name: "eip1559",
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     8,
+				GasTipCap: big.NewInt(1_000_000),
+				GasFeeCap: big.NewInt(2_000_000_000),
+				Gas:       21000,
+				To:        &to,
+				Value:     big.NewInt(1),
+			}),
+		},
+		{
+			name: "eip1559 with high-S signature",
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     9,
+				GasTipCap: big.NewInt(1_000_000),
+				GasFeeCap: big.NewInt(2_000_000_000),
+				Gas:       21000,
+				To:        &to,
+			}),
+			highS: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Data      string `json:"data"`
+					Prehashed bool   `json:"prehashed"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("failed to decode request: %v", err)
+				}
+				if !req.Prehashed {
+					t.Error("expected prehashed=true")
+				}
+				hash, _ := base64.StdEncoding.DecodeString(req.Data)
+				if len(hash) != 32 {
+					t.Fatalf("expected 32-byte digest, got %d bytes", len(hash))
+				}
+
+				sig, err := crypto.Sign(hash, privKey)
+				if err != nil {
+					t.Fatalf("failed to sign: %v", err)
+				}
+				sig = sig[:64]
+				if tt.highS {
+					s := new(big.Int).SetBytes(sig[32:])
+					s.Sub(crypto.S256().Params().N, s)
+					s.FillBytes(sig[32:])
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{
+						"signature":   base64.StdEncoding.EncodeToString(sig),
+						"public_key":  hex.EncodeToString(crypto.CompressPubkey(&privKey.PublicKey)),
+						"key_version": 1,
+					},
+				})
+			})
+
+			signed, err := client.Sign.EthTransaction(context.Background(), keyID, tt.tx, chainID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			if err != nil {
+				t.Fatalf("failed to recover sender: %v", err)
+			}
+			if sender != keyAddr {
+				t.Errorf("expected sender %s, got %s", keyAddr, sender)
+			}
+			if signed.Hash() == tt.tx.Hash() {
+				t.Error("expected signed transaction to differ from unsigned")
+			}
+		})
+	}
+}
+
+func TestSignService_EthTransaction_WrongKey(t *testing.T) {
+	signingKey, _ := crypto.GenerateKey()
+	otherKey, _ := crypto.GenerateKey()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Data string `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		hash, _ := base64.StdEncoding.DecodeString(req.Data)
+		sig, _ := crypto.Sign(hash, signingKey)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"signature":  base64.StdEncoding.EncodeToString(sig[:64]),
+				"public_key": hex.EncodeToString(crypto.CompressPubkey(&otherKey.PublicKey)),
+			},
+		})
+	})
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	if _, err := client.Sign.EthTransaction(context.Background(), uuid.New(), tx, big.NewInt(1)); err == nil {
+		t.Error("expected error when signature does not match the key")
+	}
+}
+
+func TestSignService_EthTransaction_InvalidArgs(t *testing.T) {
+	client := NewClient("test-api-key")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+
+	if _, err := client.Sign.EthTransaction(context.Background(), uuid.New(), nil, big.NewInt(1)); err == nil {
+		t.Error("expected error for nil transaction")
+	}
+	if _, err := client.Sign.EthTransaction(context.Background(), uuid.New(), tx, nil); err == nil {
+		t.Error("expected error for nil chain ID")
+	}
+}
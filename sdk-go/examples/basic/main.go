@@ -32,14 +32,10 @@ func main() {
 
 	ctx := context.Background()
 
-	// Get namespace ID from environment or use default
-	namespaceIDStr := os.Getenv("NAMESPACE_ID")
-	if namespaceIDStr == "" {
-		log.Fatal("NAMESPACE_ID environment variable is required")
-	}
-	namespaceID, err := uuid.Parse(namespaceIDStr)
+	// Get namespace ID from environment, or fall back to the org's first namespace
+	namespaceID, err := resolveNamespace(ctx, client)
 	if err != nil {
-		log.Fatalf("Invalid NAMESPACE_ID: %v", err)
+		log.Fatal(err)
 	}
 
 	// ============================================
@@ -75,7 +71,7 @@ func main() {
 	fmt.Printf("\nFetched key: %s (version %d)\n", fetchedKey.Name, fetchedKey.Version)
 
 	// List all keys in the namespace
-	keys, err := client.Keys.List(ctx, &popsigner.ListOptions{
+	keys, err := client.Keys.ListAll(ctx, &popsigner.ListOptions{
 		NamespaceID: &namespaceID,
 	})
 	if err != nil {
@@ -159,3 +155,25 @@ func main() {
 
 	fmt.Println("\nExample completed successfully!")
 }
+
+// resolveNamespace returns NAMESPACE_ID if set, otherwise the first namespace
+// of the API key's organization.
+func resolveNamespace(ctx context.Context, client *popsigner.Client) (uuid.UUID, error) {
+	if namespaceIDStr := os.Getenv("NAMESPACE_ID"); namespaceIDStr != "" {
+		namespaceID, err := uuid.Parse(namespaceIDStr)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("invalid NAMESPACE_ID: %w", err)
+		}
+		return namespaceID, nil
+	}
+
+	namespaces, err := client.Namespaces.List(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	if len(namespaces) == 0 {
+		return uuid.Nil, fmt.Errorf("no namespaces found; create one with client.Namespaces.Create")
+	}
+	fmt.Printf("Using namespace %s (%s)\n", namespaces[0].Name, namespaces[0].ID)
+	return namespaces[0].ID, nil
+}
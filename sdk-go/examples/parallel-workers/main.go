@@ -29,14 +29,10 @@ func main() {
 	client := popsigner.NewClient(apiKey)
 	ctx := context.Background()
 
-	// Get namespace ID
-	namespaceIDStr := os.Getenv("NAMESPACE_ID")
-	if namespaceIDStr == "" {
-		log.Fatal("NAMESPACE_ID environment variable is required")
-	}
-	namespaceID, err := uuid.Parse(namespaceIDStr)
+	// Get namespace ID from environment, or fall back to the org's first namespace
+	namespaceID, err := resolveNamespace(ctx, client)
 	if err != nil {
-		log.Fatalf("Invalid NAMESPACE_ID: %v", err)
+		log.Fatal(err)
 	}
 
 	// ============================================
@@ -191,3 +187,25 @@ func main() {
 
 	fmt.Println("\nParallel workers example completed!")
 }
+
+// resolveNamespace returns NAMESPACE_ID if set, otherwise the first namespace
+// of the API key's organization.
+func resolveNamespace(ctx context.Context, client *popsigner.Client) (uuid.UUID, error) {
+	if namespaceIDStr := os.Getenv("NAMESPACE_ID"); namespaceIDStr != "" {
+		namespaceID, err := uuid.Parse(namespaceIDStr)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("invalid NAMESPACE_ID: %w", err)
+		}
+		return namespaceID, nil
+	}
+
+	namespaces, err := client.Namespaces.List(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	if len(namespaces) == 0 {
+		return uuid.Nil, fmt.Errorf("no namespaces found; create one with client.Namespaces.Create")
+	}
+	fmt.Printf("Using namespace %s (%s)\n", namespaces[0].Name, namespaces[0].ID)
+	return namespaces[0].ID, nil
+}
@@ -9,18 +9,34 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
 	headerAPIKey      = "X-API-Key"
 	headerContentType = "Content-Type"
 	headerUserAgent   = "User-Agent"
+	headerIdempotency = "Idempotency-Key"
 	contentTypeJSON   = "application/json"
 	sdkUserAgent      = "popsigner-go/1.0.0"
 )
 
 // doRequest performs an HTTP request and handles common error cases.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+// Rate-limited requests are retried only when retryable is true. A non-empty
+// idempotencyKey is sent as the Idempotency-Key header on every attempt.
+// A *[]byte result receives the raw response body instead of decoded JSON.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}, retryable bool, idempotencyKey string) (err error) {
+	ctx, span := c.startSpan(ctx, method, strings.SplitN(path, "?", 2)[0])
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	// Build URL
 	reqURL, err := url.JoinPath(c.baseURL, path)
 	if err != nil {
@@ -32,73 +48,113 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		reqURL = c.baseURL + path
 	}
 
-	// Prepare request body
-	var bodyReader io.Reader
+	// Prepare request body once so it can be replayed on retry
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
 
-	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	req.Header.Set(headerUserAgent, sdkUserAgent)
-	if body != nil {
-		req.Header.Set(headerContentType, contentTypeJSON)
-	}
+		// Create request
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+		// Set headers
+		req.Header.Set(headerAPIKey, c.apiKey)
+		req.Header.Set(headerUserAgent, sdkUserAgent)
+		if body != nil {
+			req.Header.Set(headerContentType, contentTypeJSON)
+		}
+		if idempotencyKey != "" {
+			req.Header.Set(headerIdempotency, idempotencyKey)
+		}
+		traceContext.Inject(ctx, propagation.HeaderCarrier(req.Header))
+		if attempt > 0 {
+			span.SetAttributes(attrRetryCount.Int(attempt))
+		}
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
+		// Execute request
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		span.SetAttributes(attrHTTPStatus.Int(resp.StatusCode))
 
-	// Check for errors
-	if resp.StatusCode >= 400 {
-		return parseError(resp.StatusCode, respBody)
-	}
+		// Read response body
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
 
-	// Parse successful response
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+		// Retry rate-limited idempotent requests
+		if retryable {
+			if delay, ok := c.retry.retryDelay(resp, attempt); ok {
+				if err := sleepContext(ctx, delay); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		// Check for errors
+		if resp.StatusCode >= 400 {
+			return parseError(resp.StatusCode, respBody)
 		}
-	}
 
-	return nil
+		// Parse successful response
+		if raw, ok := result.(*[]byte); ok {
+			*raw = respBody
+			return nil
+		}
+		if result != nil && len(respBody) > 0 {
+			if err := json.Unmarshal(respBody, result); err != nil {
+				return fmt.Errorf("failed to parse response: %w", err)
+			}
+		}
+
+		return nil
+	}
 }
 
 // get performs a GET request.
 func (c *Client) get(ctx context.Context, path string, result interface{}) error {
-	return c.doRequest(ctx, http.MethodGet, path, nil, result)
+	return c.doRequest(ctx, http.MethodGet, path, nil, result, true, "")
 }
 
-// post performs a POST request.
+// post performs a POST request. It is never retried.
 func (c *Client) post(ctx context.Context, path string, body interface{}, result interface{}) error {
-	return c.doRequest(ctx, http.MethodPost, path, body, result)
+	return c.doRequest(ctx, http.MethodPost, path, body, result, false, "")
+}
+
+// postIdempotent performs a POST request that is safe to retry, such as signing.
+func (c *Client) postIdempotent(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.doRequest(ctx, http.MethodPost, path, body, result, true, "")
+}
+
+// postWithIdempotencyKey performs a POST request carrying an Idempotency-Key
+// header. The server replays the first result for repeats of the key, so the
+// request is safe to retry.
+func (c *Client) postWithIdempotencyKey(ctx context.Context, path, idempotencyKey string, body interface{}, result interface{}) error {
+	return c.doRequest(ctx, http.MethodPost, path, body, result, true, idempotencyKey)
 }
 
 // patch performs a PATCH request.
 func (c *Client) patch(ctx context.Context, path string, body interface{}, result interface{}) error {
-	return c.doRequest(ctx, http.MethodPatch, path, body, result)
+	return c.doRequest(ctx, http.MethodPatch, path, body, result, false, "")
 }
 
 // delete performs a DELETE request.
 func (c *Client) delete(ctx context.Context, path string) error {
-	return c.doRequest(ctx, http.MethodDelete, path, nil, nil)
+	return c.doRequest(ctx, http.MethodDelete, path, nil, nil, true, "")
 }
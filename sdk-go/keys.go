@@ -3,6 +3,7 @@ package popsigner
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +26,11 @@ type CreateKeyRequest struct {
 	Exportable bool `json:"exportable,omitempty"`
 	// Metadata is optional key-value metadata.
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// IdempotencyKey makes the request safe to retry: the server returns
+	// the originally created key for repeats with the same value instead
+	// of creating another. If empty, a random key is generated per call.
+	// Set it yourself to retry a Create call after a network error.
+	IdempotencyKey string `json:"-"`
 }
 
 // CreateBatchRequest creates multiple keys at once.
@@ -59,6 +65,13 @@ type ImportKeyRequest struct {
 	Exportable bool `json:"exportable,omitempty"`
 }
 
+// RotateKeyRequest configures a key rotation.
+type RotateKeyRequest struct {
+	// AllowPreviousVersionSigning keeps the replaced version usable through
+	// Sign.SignVersion. By default it can no longer sign.
+	AllowPreviousVersionSigning bool `json:"allow_previous_version_signing,omitempty"`
+}
+
 // ExportKeyResponse is the response from exporting a key.
 type ExportKeyResponse struct {
 	// PrivateKey is the base64-encoded private key.
@@ -67,6 +80,15 @@ type ExportKeyResponse struct {
 	Warning string `json:"warning"`
 }
 
+// ExportEncryptedResponse is the response from an encrypted key export.
+type ExportEncryptedResponse struct {
+	// Ciphertext is the ASCII-armored age file containing the base64-encoded
+	// private key.
+	Ciphertext string `json:"ciphertext"`
+	// Format is the encryption format, "age".
+	Format string `json:"format"`
+}
+
 // keyResponse is the internal API response format for keys.
 type keyResponse struct {
 	ID          uuid.UUID              `json:"id"`
@@ -75,10 +97,13 @@ type keyResponse struct {
 	PublicKey   string                 `json:"public_key"`
 	Address     string                 `json:"address"`
 	Algorithm   Algorithm              `json:"algorithm"`
+	Curve       string                 `json:"curve"`
 	Exportable  bool                   `json:"exportable"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	Version     int                    `json:"version"`
 	CreatedAt   string                 `json:"created_at"`
+	RotatedAt   *time.Time             `json:"rotated_at,omitempty"`
+	LastUsedAt  *time.Time             `json:"last_used_at,omitempty"`
 }
 
 // keyResponseWrapper handles the {"data": {...}} response format.
@@ -90,6 +115,12 @@ type keyResponseWrapper struct {
 func (r *keyResponse) toKey() *Key {
 	createdAt, _ := time.Parse("2006-01-02T15:04:05Z", r.CreatedAt)
 
+	// Older servers don't report the curve
+	curve := r.Curve
+	if curve == "" {
+		curve = r.Algorithm.Curve()
+	}
+
 	metadata := make(map[string]string)
 	for k, v := range r.Metadata {
 		if s, ok := v.(string); ok {
@@ -104,10 +135,13 @@ func (r *keyResponse) toKey() *Key {
 		PublicKey:   r.PublicKey,
 		Address:     r.Address,
 		Algorithm:   r.Algorithm,
+		Curve:       curve,
 		Exportable:  r.Exportable,
 		Metadata:    metadata,
 		Version:     r.Version,
 		CreatedAt:   createdAt,
+		RotatedAt:   r.RotatedAt,
+		LastUsedAt:  r.LastUsedAt,
 	}
 }
 
@@ -135,8 +169,13 @@ func (s *KeysService) Create(ctx context.Context, req CreateKeyRequest) (*Key, e
 		apiReq["metadata"] = req.Metadata
 	}
 
+	idempotencyKey := req.IdempotencyKey
+	if idempotencyKey == "" {
+		idempotencyKey = uuid.NewString()
+	}
+
 	var resp keyResponseWrapper
-	if err := s.client.post(ctx, "/v1/keys", apiReq, &resp); err != nil {
+	if err := s.client.postWithIdempotencyKey(ctx, "/v1/keys", idempotencyKey, apiReq, &resp); err != nil {
 		return nil, err
 	}
 	return resp.Data.toKey(), nil
@@ -145,6 +184,9 @@ func (s *KeysService) Create(ctx context.Context, req CreateKeyRequest) (*Key, e
 // CreateBatch creates multiple keys in parallel.
 // This is optimized for Celestia's parallel worker pattern.
 //
+// Cancelling ctx aborts the request and returns the context's error; the
+// server stops provisioning further keys for the cancelled batch.
+//
 // Example:
 //
 //	keys, err := client.Keys.CreateBatch(ctx, popsigner.CreateBatchRequest{
@@ -185,37 +227,108 @@ func (s *KeysService) CreateBatch(ctx context.Context, req CreateBatchRequest) (
 //	key, err := client.Keys.Get(ctx, keyID)
 func (s *KeysService) Get(ctx context.Context, keyID uuid.UUID) (*Key, error) {
 	var resp keyResponseWrapper
-	if err := s.client.get(ctx, fmt.Sprintf("/v1/keys/%s", keyID), &resp); err != nil {
+	if err := s.client.get(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s", keyID), &resp); err != nil {
 		return nil, err
 	}
 	return resp.Data.toKey(), nil
 }
 
+// Addresses returns the key's public key alongside its Cosmos bech32 and
+// EIP-55 Ethereum addresses. An empty prefix uses the server default ("celestia").
+// Only secp256k1 keys are supported.
+//
+// Example:
+//
+//	addrs, err := client.Keys.Addresses(ctx, keyID, "cosmos")
+//	fmt.Println(addrs.Address, addrs.EthAddress)
+func (s *KeysService) Addresses(ctx context.Context, keyID uuid.UUID, prefix string) (*KeyAddresses, error) {
+	path := fmt.Sprintf("/v1/keys/%s/pubkey", keyID)
+	if prefix != "" {
+		path += "?" + url.Values{"prefix": {prefix}}.Encode()
+	}
+
+	var resp struct {
+		Data KeyAddresses `json:"data"`
+	}
+	if err := s.client.get(withKeyID(ctx, keyID), path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
 // ListOptions are options for listing keys.
 type ListOptions struct {
 	// NamespaceID filters keys by namespace.
 	NamespaceID *uuid.UUID
+	// NamePrefix filters keys whose name starts with the given prefix.
+	NamePrefix string
+	// Label filters keys by metadata, either "key" or "key=value".
+	Label string
+	// Limit is the maximum number of keys to return per page (max 100).
+	Limit int
+	// Cursor is the pagination cursor from a previous response.
+	Cursor string
+}
+
+// ListResult is the response from listing keys.
+type ListResult struct {
+	// Keys is the list of keys in this page.
+	Keys []*Key
+	// NextCursor is the cursor for the next page, empty if no more pages.
+	NextCursor string
 }
 
-// List returns all keys, optionally filtered by namespace.
+// List returns a page of keys, optionally filtered by namespace, name prefix or label.
+// Pagination is opt-in: with nil opts, or opts that set only NamespaceID, the
+// server returns every key in a single response and NextCursor is empty.
 //
 // Example:
 //
-//	// List all keys
-//	keys, err := client.Keys.List(ctx, nil)
-//
 //	// List keys in a specific namespace
-//	keys, err := client.Keys.List(ctx, &popsigner.ListOptions{
+//	result, err := client.Keys.List(ctx, &popsigner.ListOptions{
 //	    NamespaceID: &namespaceID,
+//	    Limit:       50,
 //	})
-func (s *KeysService) List(ctx context.Context, opts *ListOptions) ([]*Key, error) {
+//
+//	// Paginate through results
+//	for result.NextCursor != "" {
+//	    result, err = client.Keys.List(ctx, &popsigner.ListOptions{
+//	        NamespaceID: &namespaceID,
+//	        Limit:       50,
+//	        Cursor:      result.NextCursor,
+//	    })
+//	}
+func (s *KeysService) List(ctx context.Context, opts *ListOptions) (*ListResult, error) {
+	// Build query parameters
+	params := url.Values{}
+	if opts != nil {
+		if opts.NamespaceID != nil {
+			params.Set("namespace_id", opts.NamespaceID.String())
+		}
+		if opts.NamePrefix != "" {
+			params.Set("name_prefix", opts.NamePrefix)
+		}
+		if opts.Label != "" {
+			params.Set("label", opts.Label)
+		}
+		if opts.Limit > 0 {
+			params.Set("limit", fmt.Sprintf("%d", opts.Limit))
+		}
+		if opts.Cursor != "" {
+			params.Set("cursor", opts.Cursor)
+		}
+	}
+
 	path := "/v1/keys"
-	if opts != nil && opts.NamespaceID != nil {
-		path = fmt.Sprintf("/v1/keys?namespace_id=%s", opts.NamespaceID)
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
 	}
 
 	var resp struct {
 		Data []*keyResponse `json:"data"`
+		Meta *struct {
+			NextCursor string `json:"next_cursor,omitempty"`
+		} `json:"meta,omitempty"`
 	}
 	if err := s.client.get(ctx, path, &resp); err != nil {
 		return nil, err
@@ -225,16 +338,88 @@ func (s *KeysService) List(ctx context.Context, opts *ListOptions) ([]*Key, erro
 	for i, k := range resp.Data {
 		keys[i] = k.toKey()
 	}
-	return keys, nil
+
+	result := &ListResult{
+		Keys: keys,
+	}
+	if resp.Meta != nil {
+		result.NextCursor = resp.Meta.NextCursor
+	}
+
+	return result, nil
+}
+
+// ListAll returns every key matching the options, following pagination cursors.
+// The Cursor field of opts is ignored.
+//
+// Example:
+//
+//	keys, err := client.Keys.ListAll(ctx, &popsigner.ListOptions{
+//	    NamePrefix: "blob-worker",
+//	})
+func (s *KeysService) ListAll(ctx context.Context, opts *ListOptions) ([]*Key, error) {
+	pageOpts := ListOptions{}
+	if opts != nil {
+		pageOpts = *opts
+	}
+	pageOpts.Cursor = ""
+	if pageOpts.Limit <= 0 {
+		pageOpts.Limit = 100
+	}
+
+	var keys []*Key
+	for {
+		result, err := s.List(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, result.Keys...)
+		if result.NextCursor == "" {
+			return keys, nil
+		}
+		pageOpts.Cursor = result.NextCursor
+	}
 }
 
-// Delete deletes a key.
+// Delete deletes a key. The key stops appearing in lists and can no longer
+// sign, but it can be restored with Restore until the server's deletion grace
+// period (7 days by default) passes and it is purged.
 //
 // Example:
 //
 //	err := client.Keys.Delete(ctx, keyID)
 func (s *KeysService) Delete(ctx context.Context, keyID uuid.UUID) error {
-	return s.client.delete(ctx, fmt.Sprintf("/v1/keys/%s", keyID))
+	return s.client.delete(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s", keyID))
+}
+
+// Restore undoes the deletion of a key within the deletion grace period.
+// Restoring fails with a conflict if the key's name has been reused since.
+//
+// Example:
+//
+//	key, err := client.Keys.Restore(ctx, keyID)
+func (s *KeysService) Restore(ctx context.Context, keyID uuid.UUID) (*Key, error) {
+	var resp keyResponseWrapper
+	if err := s.client.post(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s/restore", keyID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data.toKey(), nil
+}
+
+// Rotate replaces a key's material with a newly generated key and increments
+// its Version. The key ID is kept, but the public key and addresses change.
+// Rotation is not retried automatically.
+//
+// Example:
+//
+//	key, err := client.Keys.Rotate(ctx, keyID, popsigner.RotateKeyRequest{})
+//	fmt.Println(key.Version, key.Address)
+func (s *KeysService) Rotate(ctx context.Context, keyID uuid.UUID, req RotateKeyRequest) (*Key, error) {
+	var resp keyResponseWrapper
+	if err := s.client.post(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s/rotate", keyID), req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data.toKey(), nil
 }
 
 // Import imports a private key.
@@ -273,7 +458,28 @@ func (s *KeysService) Export(ctx context.Context, keyID uuid.UUID) (*ExportKeyRe
 	var resp struct {
 		Data ExportKeyResponse `json:"data"`
 	}
-	if err := s.client.post(ctx, fmt.Sprintf("/v1/keys/%s/export", keyID), nil, &resp); err != nil {
+	if err := s.client.post(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s/export", keyID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ExportEncrypted exports a key encrypted to an age X25519 public key
+// ("age1..."), for offline disaster recovery. The key is encrypted by the
+// server, so the plaintext never reaches the client; only the holder of the
+// matching age identity can decrypt it, e.g. with "age -d -i key.txt".
+// The key must have been created with Exportable: true.
+//
+// Example:
+//
+//	result, err := client.Keys.ExportEncrypted(ctx, keyID, "age1...")
+//	err = os.WriteFile("key.age", []byte(result.Ciphertext), 0o600)
+func (s *KeysService) ExportEncrypted(ctx context.Context, keyID uuid.UUID, recipientPublicKey string) (*ExportEncryptedResponse, error) {
+	body := map[string]string{"recipient": recipientPublicKey}
+	var resp struct {
+		Data ExportEncryptedResponse `json:"data"`
+	}
+	if err := s.client.post(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s/export-encrypted", keyID), body, &resp); err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
@@ -0,0 +1,158 @@
+package popsigner
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+)
+
+// Scrypt parameters for exported keystores, matching geth's standard
+// (non-light) settings. Variables so tests can use cheaper ones.
+var (
+	keystoreScryptN = 1 << 18
+	keystoreScryptP = 1
+)
+
+const (
+	keystoreScryptR     = 8
+	keystoreScryptDKLen = 32
+)
+
+// keystoreV3 is the Ethereum keystore (Web3 Secret Storage, version 3) format.
+type keystoreV3 struct {
+	Address string         `json:"address"`
+	Crypto  keystoreCrypto `json:"crypto"`
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string               `json:"cipher"`
+	CipherText   string               `json:"ciphertext"`
+	CipherParams keystoreCipherParams `json:"cipherparams"`
+	KDF          string               `json:"kdf"`
+	KDFParams    keystoreScryptParams `json:"kdfparams"`
+	MAC          string               `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+type keystoreScryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+// ExportKeystore exports a key as an Ethereum keystore (V3) JSON file,
+// encrypted with passphrase using scrypt and AES-128-CTR. The result can be
+// imported by geth, Foundry (cast wallet import) and most Ethereum wallets.
+// The key must have been created with Exportable: true.
+//
+// The private key is exported from the API and encrypted locally; it is
+// never sent back to the server.
+//
+// Example:
+//
+//	keystoreJSON, err := client.Keys.ExportKeystore(ctx, keyID, passphrase)
+//	err = os.WriteFile("key.json", keystoreJSON, 0o600)
+func (s *KeysService) ExportKeystore(ctx context.Context, keyID uuid.UUID, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+
+	exported, err := s.Export(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := base64.StdEncoding.DecodeString(exported.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exported private key encoding: %w", err)
+	}
+	defer clear(privKey)
+
+	return encryptKeystore(privKey, passphrase, keystoreScryptN, keystoreScryptP)
+}
+
+// encryptKeystore encrypts a raw 32-byte secp256k1 private key into V3
+// keystore JSON.
+func encryptKeystore(privKey []byte, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	if len(privKey) != 32 {
+		return nil, fmt.Errorf("invalid private key length: expected 32 bytes, got %d", len(privKey))
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("failed to generate IV: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, keystoreScryptR, scryptP, keystoreScryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	defer clear(derivedKey)
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	cipherText := make([]byte, len(privKey))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, privKey)
+
+	return json.Marshal(keystoreV3{
+		Address: hex.EncodeToString(keystoreAddress(privKey)),
+		Crypto: keystoreCrypto{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: keystoreScryptParams{
+				DKLen: keystoreScryptDKLen,
+				N:     scryptN,
+				P:     scryptP,
+				R:     keystoreScryptR,
+				Salt:  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derivedKey, cipherText)),
+		},
+		ID:      uuid.NewString(),
+		Version: 3,
+	})
+}
+
+// keystoreMAC is Keccak-256 of the second half of the derived key followed
+// by the ciphertext.
+func keystoreMAC(derivedKey, cipherText []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(derivedKey[16:32])
+	h.Write(cipherText)
+	return h.Sum(nil)
+}
+
+// keystoreAddress returns the Ethereum address of a private key: the last 20
+// bytes of the Keccak-256 hash of the uncompressed public key.
+func keystoreAddress(privKey []byte) []byte {
+	pubKey := secp256k1.PrivKeyFromBytes(privKey).PubKey().SerializeUncompressed()
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pubKey[1:])
+	return h.Sum(nil)[12:]
+}
@@ -0,0 +1,164 @@
+package popsigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/scrypt"
+)
+
+var errKeystoreMAC = errors.New("could not decrypt key with given passphrase")
+
+// decryptKeystore decrypts V3 keystore JSON the way geth does.
+func decryptKeystore(keyJSON []byte, passphrase string) ([]byte, error) {
+	var ks keystoreV3
+	if err := json.Unmarshal(keyJSON, &ks); err != nil {
+		return nil, err
+	}
+	if ks.Version != 3 || ks.Crypto.Cipher != "aes-128-ctr" || ks.Crypto.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported keystore: version %d, cipher %s, kdf %s", ks.Version, ks.Crypto.Cipher, ks.Crypto.KDF)
+	}
+
+	salt, _ := hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	iv, _ := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	cipherText, _ := hex.DecodeString(ks.Crypto.CipherText)
+	mac, _ := hex.DecodeString(ks.Crypto.MAC)
+
+	params := ks.Crypto.KDFParams
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(keystoreMAC(derivedKey, cipherText), mac) {
+		return nil, errKeystoreMAC
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	privKey := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(privKey, cipherText)
+	return privKey, nil
+}
+
+// useLightScrypt makes keystore encryption cheap for the duration of a test.
+func useLightScrypt(t *testing.T) {
+	t.Helper()
+	n, p := keystoreScryptN, keystoreScryptP
+	keystoreScryptN, keystoreScryptP = 1<<12, 6
+	t.Cleanup(func() { keystoreScryptN, keystoreScryptP = n, p })
+}
+
+func TestKeysService_ExportKeystore(t *testing.T) {
+	useLightScrypt(t)
+
+	// First Anvil/Hardhat dev account
+	privKey, _ := hex.DecodeString("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	keyID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := fmt.Sprintf("/v1/keys/%s/export", keyID)
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected %s, got %s", expectedPath, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]string{
+				"private_key": base64.StdEncoding.EncodeToString(privKey),
+				"warning":     "Store it securely.",
+			},
+		})
+	})
+
+	keyJSON, err := client.Keys.ExportKeystore(context.Background(), keyID, "correct horse")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ks keystoreV3
+	if err := json.Unmarshal(keyJSON, &ks); err != nil {
+		t.Fatalf("keystore is not valid JSON: %v", err)
+	}
+	if ks.Version != 3 {
+		t.Errorf("expected version 3, got %d", ks.Version)
+	}
+	if ks.Address != "f39fd6e51aad88f6f4ce6ab8827279cfffb92266" {
+		t.Errorf("expected Anvil account 0 address, got %s", ks.Address)
+	}
+	if bytes.Contains(keyJSON, []byte(hex.EncodeToString(privKey))) {
+		t.Error("keystore contains the plaintext private key")
+	}
+
+	decrypted, err := decryptKeystore(keyJSON, "correct horse")
+	if err != nil {
+		t.Fatalf("failed to decrypt keystore: %v", err)
+	}
+	if !bytes.Equal(decrypted, privKey) {
+		t.Errorf("decrypted key = %x, want %x", decrypted, privKey)
+	}
+
+	if _, err := decryptKeystore(keyJSON, "wrong horse"); !errors.Is(err, errKeystoreMAC) {
+		t.Errorf("expected MAC error for wrong passphrase, got %v", err)
+	}
+}
+
+func TestKeysService_ExportKeystore_NotExportable(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{"code": "forbidden", "message": "Key is not exportable"},
+		})
+	})
+
+	_, err := client.Keys.ExportKeystore(context.Background(), uuid.New(), "correct horse")
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+func TestKeysService_ExportKeystore_RequiresPassphrase(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request without a passphrase")
+	})
+
+	if _, err := client.Keys.ExportKeystore(context.Background(), uuid.New(), ""); err == nil {
+		t.Error("expected error for empty passphrase")
+	}
+}
+
+// TestDecryptKeystore_Vector checks the test helper against the scrypt test
+// vector from the Web3 Secret Storage definition.
+func TestDecryptKeystore_Vector(t *testing.T) {
+	keyJSON := []byte(`{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {"dklen": 32, "n": 262144, "p": 8, "r": 1, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`)
+
+	privKey, err := decryptKeystore(keyJSON, "testpassword")
+	if err != nil {
+		t.Fatalf("failed to decrypt vector: %v", err)
+	}
+	if got := hex.EncodeToString(privKey); got != "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d" {
+		t.Errorf("decrypted key = %s", got)
+	}
+}
@@ -0,0 +1,74 @@
+package popsigner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// NamespacesService handles namespace operations for the API key's organization.
+//
+// Unlike the namespace methods on OrgsService, these routes take the
+// organization from the API key, so no organization ID is needed.
+type NamespacesService struct {
+	client *Client
+}
+
+// Create creates a new namespace.
+//
+// Example:
+//
+//	ns, err := client.Namespaces.Create(ctx, popsigner.CreateNamespaceRequest{
+//	    Name:        "staging",
+//	    Description: "Staging keys",
+//	})
+func (s *NamespacesService) Create(ctx context.Context, req CreateNamespaceRequest) (*Namespace, error) {
+	var resp struct {
+		Data Namespace `json:"data"`
+	}
+	if err := s.client.post(ctx, "/v1/namespaces", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// List returns all namespaces in the organization.
+//
+// Example:
+//
+//	namespaces, err := client.Namespaces.List(ctx)
+func (s *NamespacesService) List(ctx context.Context) ([]*Namespace, error) {
+	var resp struct {
+		Data []*Namespace `json:"data"`
+	}
+	if err := s.client.get(ctx, "/v1/namespaces", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Get retrieves a namespace by ID.
+//
+// Example:
+//
+//	ns, err := client.Namespaces.Get(ctx, namespaceID)
+func (s *NamespacesService) Get(ctx context.Context, namespaceID uuid.UUID) (*Namespace, error) {
+	var resp struct {
+		Data Namespace `json:"data"`
+	}
+	if err := s.client.get(ctx, fmt.Sprintf("/v1/namespaces/%s", namespaceID), &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// Delete deletes a namespace and all keys in it.
+// The last namespace of an organization cannot be deleted.
+//
+// Example:
+//
+//	err := client.Namespaces.Delete(ctx, namespaceID)
+func (s *NamespacesService) Delete(ctx context.Context, namespaceID uuid.UUID) error {
+	return s.client.delete(ctx, fmt.Sprintf("/v1/namespaces/%s", namespaceID))
+}
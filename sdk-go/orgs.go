@@ -44,6 +44,9 @@ type CreateNamespaceRequest struct {
 	Name string `json:"name"`
 	// Description is an optional description.
 	Description string `json:"description,omitempty"`
+	// Bech32Prefix is the prefix for Cosmos addresses of keys in the namespace.
+	// Defaults to "celestia". Only supported by Namespaces.Create.
+	Bech32Prefix string `json:"bech32_prefix,omitempty"`
 }
 
 // Create creates a new organization.
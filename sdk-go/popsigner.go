@@ -7,6 +7,9 @@ package popsigner
 import (
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 const (
@@ -26,14 +29,31 @@ type Client struct {
 	baseURL    string
 	httpClient *http.Client
 
+	retry      RetryConfig
+	tracer     trace.Tracer
+
+	// Options resolved into httpClient by NewClient
+	transport http.RoundTripper
+	timeout   *time.Duration
+
 	// Services
-	Keys   *KeysService
-	Sign   *SignService
-	Orgs   *OrgsService
-	Audit  *AuditService
+	Keys        *KeysService
+	Sign        *SignService
+	Orgs        *OrgsService
+	Namespaces  *NamespacesService
+	Audit       *AuditService
+	Usage       *UsageService
+	Deployments *DeploymentsService
 }
 
 // Option configures the client.
+//
+// HTTP options compose in a fixed order regardless of how they are passed:
+// WithHTTPClient supplies the base client, WithTransport replaces its transport,
+// and WithTimeout replaces its timeout. A client passed to WithHTTPClient is
+// copied before being modified, and its own Timeout is kept unless WithTimeout
+// is also given. Retries configured with WithRetry wrap whichever client results,
+// so the timeout applies to each attempt rather than to the whole call.
 type Option func(*Client)
 
 // WithBaseURL sets a custom API base URL.
@@ -48,6 +68,7 @@ func WithBaseURL(url string) Option {
 }
 
 // WithHTTPClient sets a custom HTTP client.
+// Use this to configure proxies, custom TLS roots or connection pooling.
 //
 // Example:
 //
@@ -59,17 +80,41 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithTransport sets the HTTP transport used for requests.
+// It takes precedence over the transport of a client set with WithHTTPClient.
+//
+// Example:
+//
+//	transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+//	client := popsigner.NewClient("key", popsigner.WithTransport(transport))
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = transport
+	}
+}
+
 // WithTimeout sets the HTTP client timeout.
+// It takes precedence over the timeout of a client set with WithHTTPClient.
 //
 // Example:
 //
 //	client := popsigner.NewClient("key", popsigner.WithTimeout(60 * time.Second))
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
-		if c.httpClient == nil {
-			c.httpClient = &http.Client{}
-		}
-		c.httpClient.Timeout = timeout
+		c.timeout = &timeout
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry tracer provider used to create a
+// client span for each API request. The span context is propagated to the
+// server as a W3C traceparent header. Tracing is disabled by default.
+//
+// Example:
+//
+//	client := popsigner.NewClient("key", popsigner.WithTracerProvider(otel.GetTracerProvider()))
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = tp.Tracer(tracerName)
 	}
 }
 
@@ -89,24 +134,56 @@ func NewClient(apiKey string, opts ...Option) *Client {
 	c := &Client{
 		apiKey:  apiKey,
 		baseURL: DefaultBaseURL,
-		httpClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
+		retry:   DefaultRetryConfig,
+		tracer:  noop.NewTracerProvider().Tracer(tracerName),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	c.httpClient = c.buildHTTPClient()
+
 	// Initialize services
 	c.Keys = &KeysService{client: c}
 	c.Sign = &SignService{client: c}
 	c.Orgs = &OrgsService{client: c}
+	c.Namespaces = &NamespacesService{client: c}
 	c.Audit = &AuditService{client: c}
+	c.Usage = &UsageService{client: c}
+	c.Deployments = &DeploymentsService{client: c}
 
 	return c
 }
 
+// buildHTTPClient resolves the HTTP options into the client used for requests.
+func (c *Client) buildHTTPClient() *http.Client {
+	if c.httpClient == nil {
+		httpClient := &http.Client{Timeout: DefaultTimeout}
+		if c.transport != nil {
+			httpClient.Transport = c.transport
+		}
+		if c.timeout != nil {
+			httpClient.Timeout = *c.timeout
+		}
+		return httpClient
+	}
+
+	if c.transport == nil && c.timeout == nil {
+		return c.httpClient
+	}
+
+	// Copy so the caller's client is never mutated
+	httpClient := *c.httpClient
+	if c.transport != nil {
+		httpClient.Transport = c.transport
+	}
+	if c.timeout != nil {
+		httpClient.Timeout = *c.timeout
+	}
+	return &httpClient
+}
+
 // BaseURL returns the current base URL.
 func (c *Client) BaseURL() string {
 	return c.baseURL
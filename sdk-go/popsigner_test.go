@@ -2,10 +2,15 @@ package popsigner
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -67,6 +72,73 @@ func TestNewClient_WithTimeout(t *testing.T) {
 	}
 }
 
+// recordingTransport is an http.RoundTripper that records the requests it sees.
+type recordingTransport struct {
+	requests []*http.Request
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.requests = append(rt.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := &recordingTransport{}
+	client := NewClient("test-key", WithBaseURL(server.URL), WithTransport(transport))
+
+	if err := client.Keys.Delete(context.Background(), uuid.New()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(transport.requests) != 1 {
+		t.Fatalf("expected 1 request through custom transport, got %d", len(transport.requests))
+	}
+	if transport.requests[0].Method != http.MethodDelete {
+		t.Errorf("expected DELETE, got %s", transport.requests[0].Method)
+	}
+	if client.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("expected default timeout %v, got %v", DefaultTimeout, client.httpClient.Timeout)
+	}
+}
+
+func TestNewClient_OptionPrecedence(t *testing.T) {
+	transport := &recordingTransport{}
+	customClient := &http.Client{Timeout: 60 * time.Second}
+
+	// Options apply in a fixed order, independent of argument order
+	client := NewClient("test-key",
+		WithTimeout(5*time.Second),
+		WithTransport(transport),
+		WithHTTPClient(customClient),
+	)
+
+	if client.httpClient.Transport != transport {
+		t.Error("expected WithTransport to override the custom client's transport")
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected timeout 5s, got %v", client.httpClient.Timeout)
+	}
+
+	// The caller's client must not be mutated
+	if customClient.Timeout != 60*time.Second {
+		t.Errorf("custom client timeout was modified to %v", customClient.Timeout)
+	}
+	if customClient.Transport != nil {
+		t.Error("custom client transport was modified")
+	}
+
+	// Without WithTimeout the custom client's timeout is kept
+	client = NewClient("test-key", WithHTTPClient(customClient), WithTransport(transport))
+	if client.httpClient.Timeout != 60*time.Second {
+		t.Errorf("expected custom client timeout 60s, got %v", client.httpClient.Timeout)
+	}
+}
+
 func TestClient_BaseURL(t *testing.T) {
 	client := NewClient("test-key", WithBaseURL("https://test.api.io"))
 	if client.BaseURL() != "https://test.api.io" {
@@ -111,7 +183,7 @@ func TestKeysService_Create(t *testing.T) {
 			"created_at":   "2024-01-01T00:00:00Z",
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -171,7 +243,7 @@ func TestKeysService_CreateBatch(t *testing.T) {
 			"count": 2,
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -207,11 +279,14 @@ func TestKeysService_Get(t *testing.T) {
 			"public_key":   "0x1234",
 			"address":      "0xabcd",
 			"algorithm":    "secp256k1",
-			"version":      1,
+			"curve":        "secp256k1",
+			"version":      2,
 			"created_at":   "2024-01-01T00:00:00Z",
+			"rotated_at":   "2024-02-01T00:00:00Z",
+			"last_used_at": "2024-03-01T00:00:00Z",
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -224,6 +299,69 @@ func TestKeysService_Get(t *testing.T) {
 	if key.ID != keyID {
 		t.Errorf("expected key ID %s, got %s", keyID, key.ID)
 	}
+	if key.RotatedAt == nil || !key.RotatedAt.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected rotated_at 2024-02-01, got %v", key.RotatedAt)
+	}
+	if key.LastUsedAt == nil || !key.LastUsedAt.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected last_used_at 2024-03-01, got %v", key.LastUsedAt)
+	}
+	if key.Algorithm != AlgorithmSecp256k1 {
+		t.Errorf("expected algorithm secp256k1, got %s", key.Algorithm)
+	}
+	if key.Curve != "secp256k1" {
+		t.Errorf("expected curve secp256k1, got %s", key.Curve)
+	}
+}
+
+func TestKeyResponse_CurveFromAlgorithm(t *testing.T) {
+	// Servers that predate the curve field only send the algorithm
+	r := &keyResponse{Algorithm: AlgorithmSecp256k1}
+	if got := r.toKey().Curve; got != "secp256k1" {
+		t.Errorf("expected curve secp256k1, got %q", got)
+	}
+
+	r = &keyResponse{Algorithm: AlgorithmEd25519}
+	if got := r.toKey().Curve; got != "edwards25519" {
+		t.Errorf("expected curve edwards25519, got %q", got)
+	}
+}
+
+func TestKeysService_Addresses(t *testing.T) {
+	keyID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := fmt.Sprintf("/v1/keys/%s/pubkey", keyID)
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected %s, got %s", expectedPath, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("prefix"); got != "cosmos" {
+			t.Errorf("expected prefix cosmos, got %q", got)
+		}
+
+		resp := map[string]interface{}{
+			"id":            keyID.String(),
+			"public_key":    "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			"bech32_prefix": "cosmos",
+			"address":       "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c",
+			"eth_address":   "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
+	})
+
+	addrs, err := client.Keys.Addresses(context.Background(), keyID, "cosmos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addrs.Address != "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c" {
+		t.Errorf("unexpected address %s", addrs.Address)
+	}
+	if addrs.EthAddress != "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf" {
+		t.Errorf("unexpected eth address %s", addrs.EthAddress)
+	}
+	if addrs.Bech32Prefix != "cosmos" {
+		t.Errorf("unexpected prefix %s", addrs.Bech32Prefix)
+	}
 }
 
 func TestKeysService_List(t *testing.T) {
@@ -233,27 +371,35 @@ func TestKeysService_List(t *testing.T) {
 		if r.URL.Path != "/v1/keys" {
 			t.Errorf("expected /v1/keys, got %s", r.URL.Path)
 		}
+		if got := r.URL.Query().Get("name_prefix"); got != "key-" {
+			t.Errorf("expected name_prefix 'key-', got %q", got)
+		}
+		if got := r.URL.Query().Get("label"); got != "env=prod" {
+			t.Errorf("expected label 'env=prod', got %q", got)
+		}
 
-		resp := []map[string]interface{}{
-			{
-				"id":           uuid.New().String(),
-				"namespace_id": namespaceID.String(),
-				"name":         "key-1",
-				"public_key":   "0x1111",
-				"address":      "0xaaaa",
-				"algorithm":    "secp256k1",
-				"version":      1,
-				"created_at":   "2024-01-01T00:00:00Z",
-			},
-			{
-				"id":           uuid.New().String(),
-				"namespace_id": namespaceID.String(),
-				"name":         "key-2",
-				"public_key":   "0x2222",
-				"address":      "0xbbbb",
-				"algorithm":    "secp256k1",
-				"version":      1,
-				"created_at":   "2024-01-01T00:00:00Z",
+		resp := map[string]interface{}{
+			"data": []map[string]interface{}{
+				{
+					"id":           uuid.New().String(),
+					"namespace_id": namespaceID.String(),
+					"name":         "key-1",
+					"public_key":   "0x1111",
+					"address":      "0xaaaa",
+					"algorithm":    "secp256k1",
+					"version":      1,
+					"created_at":   "2024-01-01T00:00:00Z",
+				},
+				{
+					"id":           uuid.New().String(),
+					"namespace_id": namespaceID.String(),
+					"name":         "key-2",
+					"public_key":   "0x2222",
+					"address":      "0xbbbb",
+					"algorithm":    "secp256k1",
+					"version":      1,
+					"created_at":   "2024-01-01T00:00:00Z",
+				},
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -261,14 +407,91 @@ func TestKeysService_List(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	keys, err := client.Keys.List(ctx, nil)
+	result, err := client.Keys.List(ctx, &ListOptions{NamePrefix: "key-", Label: "env=prod"})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(keys) != 2 {
-		t.Errorf("expected 2 keys, got %d", len(keys))
+	if len(result.Keys) != 2 {
+		t.Errorf("expected 2 keys, got %d", len(result.Keys))
+	}
+	if result.NextCursor != "" {
+		t.Errorf("expected empty next cursor, got %q", result.NextCursor)
+	}
+}
+
+func TestKeysService_ListPagination(t *testing.T) {
+	const total, pageSize = 23, 5
+
+	ids := make([]uuid.UUID, total)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit != pageSize {
+			t.Errorf("expected limit %d, got %d", pageSize, limit)
+		}
+
+		start := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+		end := start + limit
+		if end > total {
+			end = total
+		}
+
+		page := make([]map[string]interface{}, 0, end-start)
+		for _, id := range ids[start:end] {
+			page = append(page, map[string]interface{}{
+				"id":         id.String(),
+				"name":       id.String(),
+				"algorithm":  "secp256k1",
+				"created_at": "2024-01-01T00:00:00Z",
+			})
+		}
+
+		resp := map[string]interface{}{"data": page}
+		if end < total {
+			resp["meta"] = map[string]interface{}{"next_cursor": strconv.Itoa(end)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	ctx := context.Background()
+	seen := make(map[uuid.UUID]bool)
+	opts := &ListOptions{Limit: pageSize}
+	for {
+		result, err := client.Keys.List(ctx, opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, k := range result.Keys {
+			if seen[k.ID] {
+				t.Errorf("key %s returned twice", k.ID)
+			}
+			seen[k.ID] = true
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		opts.Cursor = result.NextCursor
+	}
+
+	if len(seen) != total {
+		t.Errorf("expected %d keys, got %d", total, len(seen))
+	}
+
+	all, err := client.Keys.ListAll(ctx, &ListOptions{Limit: pageSize})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != total {
+		t.Errorf("ListAll: expected %d keys, got %d", total, len(all))
 	}
 }
 
@@ -294,6 +517,126 @@ func TestKeysService_Delete(t *testing.T) {
 	}
 }
 
+func TestKeysService_Restore(t *testing.T) {
+	keyID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		expectedPath := fmt.Sprintf("/v1/keys/%s/restore", keyID)
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		resp := map[string]interface{}{
+			"id":         keyID.String(),
+			"name":       "sequencer",
+			"public_key": "0x1234",
+			"address":    "celestia1abc",
+			"version":    1,
+			"created_at": "2025-01-01T00:00:00Z",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
+	})
+
+	key, err := client.Keys.Restore(context.Background(), keyID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != keyID {
+		t.Errorf("expected ID %s, got %s", keyID, key.ID)
+	}
+}
+
+func TestKeysService_Rotate(t *testing.T) {
+	keyID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		expectedPath := fmt.Sprintf("/v1/keys/%s/rotate", keyID)
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["allow_previous_version_signing"] != true {
+			t.Errorf("expected allow_previous_version_signing=true, got %v", body["allow_previous_version_signing"])
+		}
+
+		resp := map[string]interface{}{
+			"id":         keyID.String(),
+			"name":       "sequencer",
+			"public_key": "0x5678",
+			"address":    "celestia1rotated",
+			"version":    2,
+			"created_at": "2025-01-01T00:00:00Z",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
+	})
+
+	key, err := client.Keys.Rotate(context.Background(), keyID, RotateKeyRequest{AllowPreviousVersionSigning: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != keyID {
+		t.Errorf("expected ID %s, got %s", keyID, key.ID)
+	}
+	if key.Version != 2 {
+		t.Errorf("expected version 2, got %d", key.Version)
+	}
+	if key.Address != "celestia1rotated" {
+		t.Errorf("expected rotated address, got %s", key.Address)
+	}
+}
+
+func TestSignService_SignVersion(t *testing.T) {
+	keyID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body["key_version"] != float64(1) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"signature":   "c2lnbmF0dXJl",
+				"public_key":  "0x5678",
+				"key_version": 2,
+			}})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{
+			"code":    "forbidden",
+			"message": "Key version 1 was rotated and can no longer sign",
+		}})
+	})
+
+	ctx := context.Background()
+
+	result, err := client.Sign.Sign(ctx, keyID, []byte("data"), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.KeyVersion != 2 {
+		t.Errorf("expected key version 2, got %d", result.KeyVersion)
+	}
+
+	_, err = client.Sign.SignVersion(ctx, keyID, 1, []byte("data"), false)
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
 func TestSignService_Sign(t *testing.T) {
 	keyID := uuid.New()
 
@@ -309,7 +652,7 @@ func TestSignService_Sign(t *testing.T) {
 			"key_version": 1,
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -354,7 +697,7 @@ func TestSignService_SignBatch(t *testing.T) {
 			"count": 2,
 		}
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -374,6 +717,130 @@ func TestSignService_SignBatch(t *testing.T) {
 	}
 }
 
+func TestSignService_SignBatchCancelled(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// The batch is rate limited; the worker shuts down while waiting to retry
+		cancel()
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	start := time.Now()
+	_, err := client.Sign.SignBatch(ctx, BatchSignRequest{
+		Requests: []SignRequest{
+			{KeyID: uuid.New(), Data: []byte("msg1")},
+			{KeyID: uuid.New(), Data: []byte("msg2")},
+		},
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return promptly, took %v", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("expected no requests after cancellation, got %d calls", calls)
+	}
+}
+
+func TestKeysService_CreateBatchCancelled(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// The worker shuts down while the batch is still being created
+		cancel()
+		<-unblock
+	})
+
+	start := time.Now()
+	_, err := client.Keys.CreateBatch(ctx, CreateBatchRequest{
+		Prefix:      "worker",
+		Count:       4,
+		NamespaceID: uuid.New(),
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return promptly, took %v", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestSignService_Verify(t *testing.T) {
+	digest := sha256.Sum256([]byte("msg"))
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/verify" {
+			t.Errorf("expected /v1/verify, got %s", r.URL.Path)
+		}
+
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["public_key"] != "02abcd" || req["data"] != base64.StdEncoding.EncodeToString(digest[:]) || req["signature"] != "c2ln" || req["prehashed"] != true {
+			t.Errorf("unexpected request body: %v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"valid": true}})
+	})
+
+	valid, err := client.Sign.Verify(context.Background(), "02abcd", digest[:], true, []byte("sig"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !valid {
+		t.Error("expected valid signature")
+	}
+}
+
+func TestSignService_PrehashedDigestLength(t *testing.T) {
+	keyID := uuid.New()
+	var calls int32
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"signature": "c2ln", "public_key": "0x1234", "key_version": 1},
+		})
+	})
+
+	ctx := context.Background()
+	digest := sha256.Sum256([]byte("msg"))
+
+	if _, err := client.Sign.Sign(ctx, keyID, digest[:10], true); !errors.Is(err, ErrInvalidDigest) {
+		t.Errorf("Sign: expected ErrInvalidDigest, got %v", err)
+	}
+	_, err := client.Sign.SignBatch(ctx, BatchSignRequest{
+		Requests: []SignRequest{{KeyID: keyID, Data: digest[:]}, {KeyID: keyID, Data: []byte("msg"), Prehashed: true}},
+	})
+	if !errors.Is(err, ErrInvalidDigest) {
+		t.Errorf("SignBatch: expected ErrInvalidDigest, got %v", err)
+	}
+	if _, err := client.Sign.Verify(ctx, "02abcd", digest[:31], true, []byte("sig")); !errors.Is(err, ErrInvalidDigest) {
+		t.Errorf("Verify: expected ErrInvalidDigest, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("expected no requests for invalid digests, got %d", n)
+	}
+
+	if _, err := client.Sign.Sign(ctx, keyID, digest[:], true); err != nil {
+		t.Fatalf("unexpected error for a 32-byte digest: %v", err)
+	}
+}
+
 func TestOrgsService_Create(t *testing.T) {
 	orgID := uuid.New()
 
@@ -391,7 +858,7 @@ func TestOrgsService_Create(t *testing.T) {
 		}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		json.NewEncoder(w).Encode(resp)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 	})
 
 	ctx := context.Background()
@@ -406,6 +873,129 @@ func TestOrgsService_Create(t *testing.T) {
 	}
 }
 
+func TestNamespacesService_CreateListAndUseInCreateKey(t *testing.T) {
+	var namespaces []map[string]interface{}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/namespaces":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			prefix, _ := req["bech32_prefix"].(string)
+			if prefix == "" {
+				prefix = "celestia"
+			}
+			ns := map[string]interface{}{
+				"id":            uuid.New().String(),
+				"org_id":        uuid.New().String(),
+				"name":          req["name"],
+				"bech32_prefix": prefix,
+				"created_at":    "2024-01-01T00:00:00Z",
+			}
+			namespaces = append(namespaces, ns)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": ns})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/namespaces":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": namespaces})
+
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/keys":
+			var req map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&req)
+			found := false
+			for _, ns := range namespaces {
+				if ns["id"] == req["namespace_id"] {
+					found = true
+				}
+			}
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"error": map[string]interface{}{"code": "not_found", "message": "Namespace not found"},
+				})
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"id":           uuid.New().String(),
+				"namespace_id": req["namespace_id"],
+				"name":         req["name"],
+				"algorithm":    "secp256k1",
+				"created_at":   "2024-01-01T00:00:00Z",
+			}})
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+
+	created, err := client.Namespaces.Create(ctx, CreateNamespaceRequest{Name: "staging", Bech32Prefix: "mocha"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Name != "staging" || created.Bech32Prefix != "mocha" {
+		t.Errorf("unexpected namespace: %+v", created)
+	}
+
+	list, err := client.Namespaces.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != created.ID {
+		t.Fatalf("expected list to contain created namespace, got %+v", list)
+	}
+
+	key, err := client.Keys.Create(ctx, CreateKeyRequest{Name: "sequencer", NamespaceID: list[0].ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.NamespaceID != created.ID {
+		t.Errorf("expected key in namespace %s, got %s", created.ID, key.NamespaceID)
+	}
+
+	if _, err := client.Keys.Create(ctx, CreateKeyRequest{Name: "orphan", NamespaceID: uuid.New()}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found for unknown namespace, got %v", err)
+	}
+}
+
+func TestNamespacesService_GetAndDelete(t *testing.T) {
+	nsID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/namespaces/"+nsID.String() {
+			t.Errorf("expected /v1/namespaces/%s, got %s", nsID, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+			"id":            nsID.String(),
+			"name":          "production",
+			"bech32_prefix": "celestia",
+		}})
+	})
+
+	ctx := context.Background()
+	ns, err := client.Namespaces.Get(ctx, nsID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ns.Bech32Prefix != "celestia" {
+		t.Errorf("expected bech32 prefix 'celestia', got %q", ns.Bech32Prefix)
+	}
+
+	if err := client.Namespaces.Delete(ctx, nsID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestAuditService_List(t *testing.T) {
 	logID := uuid.New()
 	orgID := uuid.New()
@@ -434,7 +1024,7 @@ func TestAuditService_List(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	result, err := client.Audit.List(ctx, nil)
+	result, err := client.Audit.List(ctx, AuditQuery{})
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -448,6 +1038,278 @@ func TestAuditService_List(t *testing.T) {
 	}
 }
 
+func TestAuditService_ListPagination(t *testing.T) {
+	keyID := uuid.New()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Five key.signed entries, newest first, served two per page.
+	var entries []map[string]interface{}
+	for i := 0; i < 5; i++ {
+		entries = append(entries, map[string]interface{}{
+			"id":            uuid.New().String(),
+			"org_id":        uuid.New().String(),
+			"event":         "key.signed",
+			"actor_type":    "api_key",
+			"resource_type": "key",
+			"resource_id":   keyID.String(),
+			"metadata": map[string]interface{}{
+				"request_hash": fmt.Sprintf("%064x", i),
+				"result":       "success",
+			},
+			"created_at": base.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
+		})
+	}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("resource_type") != "key" || q.Get("resource_id") != keyID.String() {
+			t.Errorf("expected key filter, got %s", r.URL.RawQuery)
+		}
+		if q.Get("event") != "key.signed" {
+			t.Errorf("expected event=key.signed, got %q", q.Get("event"))
+		}
+
+		offset := 0
+		if c := q.Get("cursor"); c != "" {
+			offset, _ = strconv.Atoi(c)
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		end := offset + limit
+		meta := map[string]interface{}{}
+		if end < len(entries) {
+			meta["next_cursor"] = strconv.Itoa(end)
+		} else {
+			end = len(entries)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": entries[offset:end], "meta": meta})
+	})
+
+	ctx := context.Background()
+	query := AuditQuery{KeyID: &keyID, Action: Ptr(AuditEventKeySigned), Limit: 2}
+
+	var logs []*AuditLog
+	pages := 0
+	for {
+		resp, err := client.Audit.List(ctx, query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		pages++
+		logs = append(logs, resp.Logs...)
+		if resp.NextCursor == "" {
+			break
+		}
+		query.Cursor = resp.NextCursor
+	}
+
+	if pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+	if len(logs) != 5 {
+		t.Fatalf("expected 5 logs, got %d", len(logs))
+	}
+	for i, log := range logs {
+		if log.ResourceID == nil || *log.ResourceID != keyID {
+			t.Errorf("log %d: expected resource ID %s", i, keyID)
+		}
+		if log.RequestHash != fmt.Sprintf("%064x", i) {
+			t.Errorf("log %d: unexpected request hash %q", i, log.RequestHash)
+		}
+		if log.Result != "success" {
+			t.Errorf("log %d: expected result 'success', got %q", i, log.Result)
+		}
+	}
+}
+
+func TestAuditService_ListTimeRange(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for i := 0; i < 24; i++ {
+		times = append(times, base.Add(time.Duration(i)*time.Hour))
+	}
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		from, err := time.Parse(time.RFC3339, r.URL.Query().Get("start_time"))
+		if err != nil {
+			t.Fatalf("invalid start_time: %v", err)
+		}
+		to, err := time.Parse(time.RFC3339, r.URL.Query().Get("end_time"))
+		if err != nil {
+			t.Fatalf("invalid end_time: %v", err)
+		}
+
+		var data []map[string]interface{}
+		for _, ts := range times {
+			if ts.Before(from) || ts.After(to) {
+				continue
+			}
+			data = append(data, map[string]interface{}{
+				"id":         uuid.New().String(),
+				"org_id":     uuid.New().String(),
+				"event":      "key.signed",
+				"actor_type": "api_key",
+				"created_at": ts.Format(time.RFC3339),
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	})
+
+	// Bounds in a non-UTC zone are sent as UTC
+	zone := time.FixedZone("UTC+2", 2*60*60)
+	from := base.Add(6 * time.Hour).In(zone)
+	to := base.Add(9 * time.Hour).In(zone)
+
+	resp, err := client.Audit.List(context.Background(), AuditQuery{From: &from, To: &to})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(resp.Logs) != 4 {
+		t.Fatalf("expected 4 logs, got %d", len(resp.Logs))
+	}
+	for _, log := range resp.Logs {
+		if log.CreatedAt.Before(from) || log.CreatedAt.After(to) {
+			t.Errorf("log at %s outside [%s, %s]", log.CreatedAt, from, to)
+		}
+	}
+	if resp.NextCursor != "" {
+		t.Errorf("expected no next cursor, got %q", resp.NextCursor)
+	}
+}
+
+func TestUsageService_Current(t *testing.T) {
+	orgID := uuid.New()
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/usage" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"org_id":             orgID.String(),
+				"plan":               "free",
+				"keys":               2,
+				"keys_limit":         3,
+				"signatures_month":   9500,
+				"signatures_limit":   10000,
+				"namespaces":         1,
+				"namespaces_limit":   1,
+				"team_members":       1,
+				"team_members_limit": 1,
+				"period_start":       "2024-01-01T00:00:00Z",
+				"period_end":         "2024-01-31T23:59:59Z",
+			},
+		})
+	})
+
+	usage, err := client.Usage.Current(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if usage.OrgID != orgID || usage.Plan != "free" {
+		t.Errorf("unexpected org/plan: %s %s", usage.OrgID, usage.Plan)
+	}
+	if usage.SignaturesMonth != 9500 || usage.SignaturesLimit != 10000 {
+		t.Errorf("unexpected signatures: %d/%d", usage.SignaturesMonth, usage.SignaturesLimit)
+	}
+	if usage.SignaturesRemaining() != 500 {
+		t.Errorf("expected 500 remaining, got %d", usage.SignaturesRemaining())
+	}
+	if usage.Keys != 2 || usage.KeysLimit != 3 {
+		t.Errorf("unexpected keys: %d/%d", usage.Keys, usage.KeysLimit)
+	}
+	wantStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !usage.PeriodStart.Equal(wantStart) {
+		t.Errorf("expected period start %s, got %s", wantStart, usage.PeriodStart)
+	}
+	if !usage.PeriodEnd.After(usage.PeriodStart) {
+		t.Errorf("expected period end after start, got %s", usage.PeriodEnd)
+	}
+}
+
+func TestUsage_SignaturesRemaining(t *testing.T) {
+	tests := []struct {
+		used, limit, want int64
+	}{
+		{used: 10, limit: 100, want: 90},
+		{used: 120, limit: 100, want: 0},
+		{used: 1000000, limit: -1, want: -1},
+	}
+	for _, tt := range tests {
+		u := &Usage{SignaturesMonth: tt.used, SignaturesLimit: tt.limit}
+		if got := u.SignaturesRemaining(); got != tt.want {
+			t.Errorf("SignaturesRemaining(%d/%d) = %d, want %d", tt.used, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestUsageService_ByKey(t *testing.T) {
+	keyID := uuid.New()
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	to := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		window    UsageWindow
+		wantQuery map[string]string
+	}{
+		{
+			name:      "passes window as UTC RFC3339",
+			window:    UsageWindow{From: from, To: to},
+			wantQuery: map[string]string{"start_time": "2024-01-01T10:00:00Z", "end_time": "2024-01-02T00:00:00Z"},
+		},
+		{
+			name:      "omits open bounds",
+			window:    UsageWindow{From: from},
+			wantQuery: map[string]string{"start_time": "2024-01-01T10:00:00Z", "end_time": ""},
+		},
+		{
+			name:      "omits empty window",
+			wantQuery: map[string]string{"start_time": "", "end_time": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/usage/keys/"+keyID.String() {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				for k, want := range tt.wantQuery {
+					if got := r.URL.Query().Get(k); got != want {
+						t.Errorf("%s = %q, want %q", k, got, want)
+					}
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"data": map[string]interface{}{
+						"key_id":       keyID.String(),
+						"signatures":   42,
+						"period_start": "2024-01-01T10:00:00Z",
+						"period_end":   "2024-01-02T00:00:00Z",
+					},
+				})
+			})
+
+			usage, err := client.Usage.ByKey(context.Background(), keyID, tt.window)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if usage.KeyID != keyID || usage.Signatures != 42 {
+				t.Errorf("unexpected usage: %+v", usage)
+			}
+		})
+	}
+}
+
 func TestError_Handling(t *testing.T) {
 	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -508,6 +1370,50 @@ func TestError_ErrorString(t *testing.T) {
 	}
 }
 
+func TestError_Predicates(t *testing.T) {
+	// Payloads mirror the control plane's error responses.
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel *Error
+		check    func(*Error) bool
+	}{
+		{"unauthorized", 401, `{"error":{"code":"unauthorized","message":"Authentication required"}}`, ErrUnauthorized, (*Error).IsUnauthorized},
+		{"forbidden", 403, `{"error":{"code":"forbidden","message":"You don't have permission to perform this action"}}`, ErrForbidden, (*Error).IsForbidden},
+		{"not found", 404, `{"error":{"code":"not_found","message":"Key not found"}}`, ErrNotFound, (*Error).IsNotFound},
+		{"rate limited", 429, `{"error":{"code":"rate_limited","message":"Too many requests. Please try again later."}}`, ErrRateLimited, (*Error).IsRateLimited},
+		{"quota exceeded", 402, `{"error":{"code":"quota_exceeded","message":"You've exceeded your plan limits"}}`, ErrQuotaExceeded, (*Error).IsQuotaExceeded},
+		{"validation", 400, `{"error":{"code":"validation_error","message":"Validation failed: name is required","details":{"field":"name","error":"name is required"}}}`, ErrValidation, (*Error).IsValidation},
+		{"bad request", 400, `{"error":{"code":"bad_request","message":"Invalid request body"}}`, ErrValidation, (*Error).IsValidation},
+		{"conflict", 409, `{"error":{"code":"conflict","message":"Key with this name already exists"}}`, ErrConflict, (*Error).IsConflict},
+		{"quota exceeded without body", 402, ``, ErrQuotaExceeded, (*Error).IsQuotaExceeded},
+	}
+
+	sentinels := []*Error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrQuotaExceeded, ErrValidation, ErrConflict}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseError(tt.status, []byte(tt.body))
+
+			apiErr, ok := IsAPIError(fmt.Errorf("request failed: %w", err))
+			if !ok {
+				t.Fatal("expected wrapped error to be an API error")
+			}
+			if !tt.check(apiErr) {
+				t.Errorf("expected predicate to match %s", apiErr)
+			}
+
+			for _, sentinel := range sentinels {
+				want := sentinel == tt.sentinel
+				if got := errors.Is(err, sentinel); got != want {
+					t.Errorf("errors.Is(err, %s) = %v, want %v", sentinel.Code, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestPtr(t *testing.T) {
 	event := AuditEventKeySigned
 	ptr := Ptr(event)
@@ -0,0 +1,106 @@
+package popsigner
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig configures automatic retries of rate-limited requests.
+//
+// Only idempotent operations (Get, List, Delete and signing) are retried,
+// plus Create, which sends an Idempotency-Key so a retry can't create a
+// second key. Import, Export and update operations are never retried
+// automatically.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	// Zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry when the response
+	// has no Retry-After header. It doubles on each subsequent retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest the client will wait before a retry. If the
+	// server asks for a longer delay via Retry-After, the error is returned
+	// instead of waiting.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryConfig is the retry configuration used when WithRetry is not set.
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     60 * time.Second,
+}
+
+// WithRetry configures automatic retries of rate-limited (429) requests.
+// Retries wrap the HTTP client, so each attempt gets the full client timeout.
+//
+// Example:
+//
+//	client := popsigner.NewClient("key", popsigner.WithRetry(popsigner.RetryConfig{
+//	    MaxRetries:     5,
+//	    InitialBackoff: time.Second,
+//	    MaxBackoff:     30 * time.Second,
+//	}))
+//
+//	// Disable retries
+//	client := popsigner.NewClient("key", popsigner.WithRetry(popsigner.RetryConfig{}))
+func WithRetry(cfg RetryConfig) Option {
+	return func(c *Client) {
+		c.retry = cfg
+	}
+}
+
+// retryDelay returns how long to wait before retrying a rate-limited response,
+// and false if the request should not be retried.
+func (cfg RetryConfig) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests || attempt >= cfg.MaxRetries {
+		return 0, false
+	}
+
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"))
+	if !ok {
+		delay = cfg.InitialBackoff << attempt
+	}
+
+	if cfg.MaxBackoff > 0 && delay > cfg.MaxBackoff {
+		if ok {
+			// The server asked for longer than we are willing to wait
+			return 0, false
+		}
+		delay = cfg.MaxBackoff
+	}
+	return delay, true
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		delay := time.Until(t)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,204 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRetry_RateLimitedThenSuccess(t *testing.T) {
+	keyID := uuid.New()
+	var calls int32
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]string{"code": "rate_limited", "message": "Too many requests"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":         keyID.String(),
+				"name":       "test-key",
+				"created_at": "2024-01-01T00:00:00Z",
+			},
+		})
+	})
+
+	start := time.Now()
+	key, err := client.Keys.Get(context.Background(), keyID)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != keyID {
+		t.Errorf("expected key ID %s, got %s", keyID, key.ID)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+	if elapsed < time.Second {
+		t.Errorf("expected to wait at least the advertised 1s, waited %v", elapsed)
+	}
+}
+
+func TestRetry_ImportNotRetried(t *testing.T) {
+	var calls int32
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.Keys.Import(context.Background(), ImportKeyRequest{
+		Name:        "test-key",
+		NamespaceID: uuid.New(),
+		PrivateKey:  "a2V5",
+	})
+
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsRateLimited() {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected Import to be attempted once, got %d calls", calls)
+	}
+}
+
+func TestRetry_CreateRetriedWithIdempotencyKey(t *testing.T) {
+	keyID := uuid.New()
+	var calls int32
+	var mu sync.Mutex
+	var idempotencyKeys []string
+
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		idempotencyKeys = append(idempotencyKeys, r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":         keyID.String(),
+				"name":       "test-key",
+				"created_at": "2024-01-01T00:00:00Z",
+			},
+		})
+	})
+
+	key, err := client.Keys.Create(context.Background(), CreateKeyRequest{
+		Name:        "test-key",
+		NamespaceID: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != keyID {
+		t.Errorf("expected key ID %s, got %s", keyID, key.ID)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+	sent := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), idempotencyKeys...)
+	}
+	if keys := sent(); keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the same Idempotency-Key on both attempts, got %q", keys)
+	}
+
+	// Each Create call gets its own key unless one is given
+	client.Keys.Create(context.Background(), CreateKeyRequest{Name: "other", NamespaceID: uuid.New()})
+	if keys := sent(); keys[2] == keys[0] {
+		t.Errorf("expected a new Idempotency-Key per Create call")
+	}
+
+	client.Keys.Create(context.Background(), CreateKeyRequest{Name: "other", NamespaceID: uuid.New(), IdempotencyKey: "my-key"})
+	if keys := sent(); keys[3] != "my-key" {
+		t.Errorf("expected caller's Idempotency-Key, got %q", keys[3])
+	}
+}
+
+func TestRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRetry(RetryConfig{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}))
+
+	_, err := client.Keys.Get(context.Background(), uuid.New())
+
+	apiErr, ok := IsAPIError(err)
+	if !ok || !apiErr.IsRateLimited() {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls (1 + 2 retries), got %d", calls)
+	}
+}
+
+func TestRetry_RetryAfterBeyondMaxBackoff(t *testing.T) {
+	var calls int32
+
+	server, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithRetry(RetryConfig{
+		MaxRetries: 3,
+		MaxBackoff: time.Second,
+	}))
+
+	_, err := client.Keys.Get(context.Background(), uuid.New())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected no retry when Retry-After exceeds MaxBackoff, got %d calls", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"0", 0, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
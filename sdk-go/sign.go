@@ -3,11 +3,27 @@ package popsigner
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 )
 
+// digestSize is the length of a prehashed digest.
+const digestSize = 32
+
+// ErrInvalidDigest is returned when data marked as prehashed is not a 32-byte
+// digest. The check runs before any request is sent.
+var ErrInvalidDigest = errors.New("prehashed data must be a 32-byte digest")
+
+// checkDigest returns ErrInvalidDigest if data is prehashed but not 32 bytes.
+func checkDigest(data []byte, prehashed bool) error {
+	if prehashed && len(data) != digestSize {
+		return fmt.Errorf("%w, got %d bytes", ErrInvalidDigest, len(data))
+	}
+	return nil
+}
+
 // SignService handles signing operations.
 type SignService struct {
 	client *Client
@@ -21,6 +37,8 @@ type SignRequest struct {
 	Data []byte `json:"-"`
 	// Prehashed indicates if the data is already hashed.
 	Prehashed bool `json:"prehashed,omitempty"`
+	// KeyVersion signs with a previous key version; 0 uses the current version.
+	KeyVersion int `json:"key_version,omitempty"`
 }
 
 // SignResponse is the response from a sign operation.
@@ -62,10 +80,27 @@ type BatchSignResult struct {
 //	result, err := client.Sign.Sign(ctx, keyID, []byte("message to sign"), false)
 //	signature := result.Signature
 func (s *SignService) Sign(ctx context.Context, keyID uuid.UUID, data []byte, prehashed bool) (*SignResponse, error) {
+	return s.SignVersion(ctx, keyID, 0, data, prehashed)
+}
+
+// SignVersion signs data with a specific version of a key. A previous version
+// only signs if the key was rotated with AllowPreviousVersionSigning; otherwise
+// the API returns a forbidden error. Version 0 signs with the current version.
+//
+// Example:
+//
+//	result, err := client.Sign.SignVersion(ctx, keyID, 1, data, false)
+func (s *SignService) SignVersion(ctx context.Context, keyID uuid.UUID, version int, data []byte, prehashed bool) (*SignResponse, error) {
+	if err := checkDigest(data, prehashed); err != nil {
+		return nil, err
+	}
 	req := map[string]interface{}{
 		"data":      base64.StdEncoding.EncodeToString(data),
 		"prehashed": prehashed,
 	}
+	if version > 0 {
+		req["key_version"] = version
+	}
 
 	var resp struct {
 		Data struct {
@@ -75,7 +110,7 @@ func (s *SignService) Sign(ctx context.Context, keyID uuid.UUID, data []byte, pr
 		} `json:"data"`
 	}
 
-	if err := s.client.post(ctx, fmt.Sprintf("/v1/keys/%s/sign", keyID), req, &resp); err != nil {
+	if err := s.client.postIdempotent(withKeyID(ctx, keyID), fmt.Sprintf("/v1/keys/%s/sign", keyID), req, &resp); err != nil {
 		return nil, err
 	}
 
@@ -95,6 +130,11 @@ func (s *SignService) Sign(ctx context.Context, keyID uuid.UUID, data []byte, pr
 // SignBatch signs multiple messages in parallel.
 // This is critical for Celestia's parallel blob submission pattern.
 //
+// Cancelling ctx aborts the request, including any wait before a retry, and
+// returns the context's error. The server stops starting signs for the
+// cancelled batch; any it had not started are reported with a
+// "context canceled" Error.
+//
 // Example:
 //
 //	results, err := client.Sign.SignBatch(ctx, popsigner.BatchSignRequest{
@@ -110,11 +150,17 @@ func (s *SignService) SignBatch(ctx context.Context, req BatchSignRequest) ([]*B
 	// Convert to API format
 	requests := make([]map[string]interface{}, len(req.Requests))
 	for i, r := range req.Requests {
+		if err := checkDigest(r.Data, r.Prehashed); err != nil {
+			return nil, fmt.Errorf("request %d: %w", i, err)
+		}
 		requests[i] = map[string]interface{}{
 			"key_id":    r.KeyID.String(),
 			"data":      base64.StdEncoding.EncodeToString(r.Data),
 			"prehashed": r.Prehashed,
 		}
+		if r.KeyVersion > 0 {
+			requests[i]["key_version"] = r.KeyVersion
+		}
 	}
 
 	apiReq := map[string]interface{}{
@@ -134,7 +180,7 @@ func (s *SignService) SignBatch(ctx context.Context, req BatchSignRequest) ([]*B
 		} `json:"data"`
 	}
 
-	if err := s.client.post(ctx, "/v1/sign/batch", apiReq, &resp); err != nil {
+	if err := s.client.postIdempotent(ctx, "/v1/sign/batch", apiReq, &resp); err != nil {
 		return nil, err
 	}
 
@@ -161,6 +207,43 @@ func (s *SignService) SignBatch(ctx context.Context, req BatchSignRequest) ([]*B
 	return results, nil
 }
 
+// Verify asks the API to verify a secp256k1 signature, so callers don't need
+// crypto libraries of their own. The data is hashed the same way as for Sign:
+// with SHA-256 unless prehashed is true, in which case it must be a 32-byte digest.
+//
+// pubKeyHex is a hex-encoded public key such as SignResponse.PublicKey, and sig
+// is a 64-byte R||S signature, optionally followed by a recovery byte. A
+// signature that does not match returns false with a nil error; malformed
+// input returns an error. VerifySignature performs the same check locally.
+//
+// Example:
+//
+//	result, err := client.Sign.Sign(ctx, keyID, msg, false)
+//	ok, err := client.Sign.Verify(ctx, result.PublicKey, msg, false, result.Signature)
+func (s *SignService) Verify(ctx context.Context, pubKeyHex string, data []byte, prehashed bool, sig []byte) (bool, error) {
+	if err := checkDigest(data, prehashed); err != nil {
+		return false, err
+	}
+	req := map[string]interface{}{
+		"public_key": pubKeyHex,
+		"data":       base64.StdEncoding.EncodeToString(data),
+		"prehashed":  prehashed,
+		"signature":  base64.StdEncoding.EncodeToString(sig),
+	}
+
+	var resp struct {
+		Data struct {
+			Valid bool `json:"valid"`
+		} `json:"data"`
+	}
+
+	if err := s.client.postIdempotent(ctx, "/v1/verify", req, &resp); err != nil {
+		return false, err
+	}
+
+	return resp.Data.Valid, nil
+}
+
 // SignWithOptions provides additional signing options.
 type SignWithOptions struct {
 	// Prehashed indicates if the data is already hashed.
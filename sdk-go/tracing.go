@@ -0,0 +1,54 @@
+package popsigner
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// tracerName is the instrumentation scope of spans created by the SDK.
+	tracerName = "github.com/Bidon15/popsigner/sdk-go"
+
+	attrKeyID      = attribute.Key("popsigner.key_id")
+	attrHTTPMethod = attribute.Key("http.request.method")
+	attrURLPath    = attribute.Key("url.path")
+	attrHTTPStatus = attribute.Key("http.response.status_code")
+	attrRetryCount = attribute.Key("http.request.resend_count")
+)
+
+// traceContext propagates spans to the server as a W3C traceparent header.
+var traceContext = propagation.TraceContext{}
+
+type keyIDContextKey struct{}
+
+// withKeyID records the key a request operates on so that its client span
+// can be annotated with it.
+func withKeyID(ctx context.Context, keyID uuid.UUID) context.Context {
+	return context.WithValue(ctx, keyIDContextKey{}, keyID)
+}
+
+// keyIDFromContext returns the key recorded by withKeyID, if any.
+func keyIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	keyID, ok := ctx.Value(keyIDContextKey{}).(uuid.UUID)
+	return keyID, ok
+}
+
+// startSpan starts the client span for an API request.
+func (c *Client) startSpan(ctx context.Context, method, path string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attrHTTPMethod.String(method),
+		attrURLPath.String(path),
+	}
+	if keyID, ok := keyIDFromContext(ctx); ok {
+		attrs = append(attrs, attrKeyID.String(keyID.String()))
+	}
+
+	return c.tracer.Start(ctx, "POPSigner "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
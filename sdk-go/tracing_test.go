@@ -0,0 +1,136 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracing_PropagatesParentSpan(t *testing.T) {
+	clientSpans := tracetest.NewSpanRecorder()
+	clientTP := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(clientSpans))
+
+	// The server continues the trace from the traceparent header and records
+	// its own span with a separate provider, as a real backend would.
+	serverSpans := tracetest.NewSpanRecorder()
+	serverTracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(serverSpans)).Tracer("server")
+
+	keyID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		_, span := serverTracer.Start(ctx, "sign", trace.WithSpanKind(trace.SpanKindServer))
+		span.End()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"key_id":     keyID.String(),
+				"signature":  "c2ln",
+				"public_key": "02ab",
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithTracerProvider(clientTP))
+
+	// Parent the request under an application span
+	ctx, appSpan := clientTP.Tracer("app").Start(context.Background(), "app")
+	if _, err := client.Sign.Sign(ctx, keyID, []byte("hello"), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	appSpan.End()
+
+	var clientSpan sdktrace.ReadOnlySpan
+	for _, s := range clientSpans.Ended() {
+		if s.SpanKind() == trace.SpanKindClient {
+			clientSpan = s
+		}
+	}
+	if clientSpan == nil {
+		t.Fatal("expected a client span")
+	}
+	if clientSpan.Parent().SpanID() != appSpan.SpanContext().SpanID() {
+		t.Errorf("client span parent = %s, want %s", clientSpan.Parent().SpanID(), appSpan.SpanContext().SpanID())
+	}
+	if v, ok := spanAttr(clientSpan, attrKeyID); !ok || v.AsString() != keyID.String() {
+		t.Errorf("expected key_id attribute %s, got %v", keyID, v.AsString())
+	}
+	if v, ok := spanAttr(clientSpan, attrHTTPMethod); !ok || v.AsString() != http.MethodPost {
+		t.Errorf("expected method attribute POST, got %v", v.AsString())
+	}
+	if v, ok := spanAttr(clientSpan, attrHTTPStatus); !ok || v.AsInt64() != http.StatusOK {
+		t.Errorf("expected status attribute 200, got %v", v.AsInt64())
+	}
+
+	serverEnded := serverSpans.Ended()
+	if len(serverEnded) != 1 {
+		t.Fatalf("expected 1 server span, got %d", len(serverEnded))
+	}
+	serverSpan := serverEnded[0]
+	if serverSpan.Parent().SpanID() != clientSpan.SpanContext().SpanID() {
+		t.Errorf("server span parent = %s, want client span %s", serverSpan.Parent().SpanID(), clientSpan.SpanContext().SpanID())
+	}
+	if serverSpan.SpanContext().TraceID() != appSpan.SpanContext().TraceID() {
+		t.Errorf("server span trace = %s, want %s", serverSpan.SpanContext().TraceID(), appSpan.SpanContext().TraceID())
+	}
+}
+
+func TestTracing_RecordsErrors(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"code": "not_found", "message": "Key not found"},
+		})
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("test-api-key", WithBaseURL(server.URL), WithTracerProvider(tp))
+
+	if _, err := client.Keys.Get(context.Background(), uuid.New()); err == nil {
+		t.Fatal("expected error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	if ended[0].Status().Code != codes.Error {
+		t.Errorf("expected error status, got %s", ended[0].Status().Code)
+	}
+}
+
+func TestTracing_NoopByDefault(t *testing.T) {
+	_, client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if tp := r.Header.Get("traceparent"); tp != "" {
+			t.Errorf("expected no traceparent without a tracer provider, got %q", tp)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	if _, err := client.Namespaces.List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -20,6 +20,19 @@ const (
 	AlgorithmEd25519 Algorithm = "ed25519"
 )
 
+// Curve returns the name of the elliptic curve the algorithm signs over,
+// or an empty string for an unknown algorithm.
+func (a Algorithm) Curve() string {
+	switch a {
+	case AlgorithmSecp256k1:
+		return "secp256k1"
+	case AlgorithmEd25519:
+		return "edwards25519"
+	default:
+		return ""
+	}
+}
+
 // Key represents a cryptographic key.
 type Key struct {
 	ID          uuid.UUID         `json:"id"`
@@ -28,10 +41,22 @@ type Key struct {
 	PublicKey   string            `json:"public_key"` // Hex encoded
 	Address     string            `json:"address"`
 	Algorithm   Algorithm         `json:"algorithm"`
+	Curve       string            `json:"curve"`
 	Exportable  bool              `json:"exportable"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
 	Version     int               `json:"version"`
 	CreatedAt   time.Time         `json:"created_at"`
+	RotatedAt   *time.Time        `json:"rotated_at,omitempty"`   // nil if never rotated
+	LastUsedAt  *time.Time        `json:"last_used_at,omitempty"` // nil if never used to sign
+}
+
+// KeyAddresses holds the encodings derived from a secp256k1 key's public key.
+type KeyAddresses struct {
+	ID           uuid.UUID `json:"id"`
+	PublicKey    string    `json:"public_key"` // Hex encoded, compressed
+	Bech32Prefix string    `json:"bech32_prefix"`
+	Address      string    `json:"address"`     // Cosmos bech32 address
+	EthAddress   string    `json:"eth_address"` // EIP-55 checksummed
 }
 
 // Organization represents an organization.
@@ -45,11 +70,12 @@ type Organization struct {
 
 // Namespace represents a key namespace within an organization.
 type Namespace struct {
-	ID          uuid.UUID `json:"id"`
-	OrgID       uuid.UUID `json:"org_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID           uuid.UUID `json:"id"`
+	OrgID        uuid.UUID `json:"org_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description,omitempty"`
+	Bech32Prefix string    `json:"bech32_prefix,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 // Member represents an organization member.
@@ -85,11 +111,12 @@ type Invitation struct {
 type AuditEvent string
 
 const (
-	AuditEventKeyCreated  AuditEvent = "key.created"
-	AuditEventKeyDeleted  AuditEvent = "key.deleted"
-	AuditEventKeySigned   AuditEvent = "key.signed"
-	AuditEventKeyExported AuditEvent = "key.exported"
-	AuditEventKeyImported AuditEvent = "key.imported"
+	AuditEventKeyCreated    AuditEvent = "key.created"
+	AuditEventKeyDeleted    AuditEvent = "key.deleted"
+	AuditEventKeySigned     AuditEvent = "key.signed"
+	AuditEventKeySignFailed AuditEvent = "key.sign_failed"
+	AuditEventKeyExported   AuditEvent = "key.exported"
+	AuditEventKeyImported   AuditEvent = "key.imported"
 )
 
 // ResourceType represents the type of resource in an audit log.
@@ -123,6 +150,8 @@ type AuditLog struct {
 	IPAddress    *string                `json:"ip_address,omitempty"`
 	UserAgent    *string                `json:"user_agent,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	RequestHash  string                 `json:"request_hash,omitempty"` // Hex SHA-256 of the signed payload (key.signed and key.sign_failed only)
+	Result       string                 `json:"result,omitempty"`       // "success" or "error", when recorded
 	CreatedAt    time.Time              `json:"created_at"`
 }
 
@@ -0,0 +1,108 @@
+package popsigner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageService reports consumption against the organization's plan limits.
+type UsageService struct {
+	client *Client
+}
+
+// Usage is the organization's usage for the current billing period.
+// Limits of -1 mean unlimited.
+type Usage struct {
+	OrgID            uuid.UUID `json:"org_id"`
+	Plan             string    `json:"plan"`
+	Keys             int       `json:"keys"`
+	KeysLimit        int       `json:"keys_limit"`
+	SignaturesMonth  int64     `json:"signatures_month"`
+	SignaturesLimit  int64     `json:"signatures_limit"`
+	Namespaces       int       `json:"namespaces"`
+	NamespacesLimit  int       `json:"namespaces_limit"`
+	TeamMembers      int       `json:"team_members"`
+	TeamMembersLimit int       `json:"team_members_limit"`
+	PeriodStart      time.Time `json:"period_start"`
+	PeriodEnd        time.Time `json:"period_end"`
+}
+
+// SignaturesRemaining returns how many signatures are left in the current
+// period, or -1 if the plan is unlimited.
+func (u *Usage) SignaturesRemaining() int64 {
+	if u.SignaturesLimit < 0 {
+		return -1
+	}
+	if remaining := u.SignaturesLimit - u.SignaturesMonth; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// KeyUsage is the signing activity of a single key within a window.
+type KeyUsage struct {
+	KeyID       uuid.UUID `json:"key_id"`
+	Signatures  int64     `json:"signatures"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+}
+
+// UsageWindow bounds a usage query. A zero From or To defaults to the
+// corresponding bound of the current billing period.
+type UsageWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// Current returns the organization's usage for the current billing period.
+//
+// Example:
+//
+//	usage, err := client.Usage.Current(ctx)
+//	if usage.SignaturesRemaining() == 0 {
+//	    // back off until usage.PeriodEnd
+//	}
+func (s *UsageService) Current(ctx context.Context) (*Usage, error) {
+	var resp struct {
+		Data Usage `json:"data"`
+	}
+	if err := s.client.get(ctx, "/v1/usage", &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ByKey returns the number of signatures made with a key within a window.
+//
+// Example:
+//
+//	// Signatures in the last hour
+//	usage, err := client.Usage.ByKey(ctx, keyID, popsigner.UsageWindow{
+//	    From: time.Now().Add(-time.Hour),
+//	})
+func (s *UsageService) ByKey(ctx context.Context, keyID uuid.UUID, window UsageWindow) (*KeyUsage, error) {
+	params := url.Values{}
+	if !window.From.IsZero() {
+		params.Set("start_time", window.From.UTC().Format(time.RFC3339))
+	}
+	if !window.To.IsZero() {
+		params.Set("end_time", window.To.UTC().Format(time.RFC3339))
+	}
+
+	path := fmt.Sprintf("/v1/usage/keys/%s", keyID)
+	if len(params) > 0 {
+		path = fmt.Sprintf("%s?%s", path, params.Encode())
+	}
+
+	var resp struct {
+		Data KeyUsage `json:"data"`
+	}
+	if err := s.client.get(withKeyID(ctx, keyID), path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,63 @@
+package popsigner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// VerifySignature verifies a secp256k1 signature returned by the API.
+//
+// pubKeyHex is the hex-encoded public key from SignResponse.PublicKey, either
+// compressed (33 bytes) or uncompressed (65 bytes). Unless prehashed is true, data
+// is hashed with SHA-256 before verification, matching how the API signs it.
+// For Keccak-256 (Ethereum) digests, hash the data yourself and pass prehashed=true.
+//
+// sig must be a 64-byte R||S signature; a trailing recovery byte (65 bytes) is ignored.
+// A well-formed signature that does not match returns false with a nil error.
+//
+// Example:
+//
+//	result, err := client.Sign.Sign(ctx, keyID, msg, false)
+//	ok, err := popsigner.VerifySignature(result.PublicKey, msg, false, result.Signature)
+//	if err != nil || !ok {
+//	    log.Fatal("backend returned an invalid signature")
+//	}
+func VerifySignature(pubKeyHex string, data []byte, prehashed bool, sig []byte) (bool, error) {
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(pubKeyHex, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+
+	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	if len(sig) != 64 && len(sig) != 65 {
+		return false, fmt.Errorf("invalid signature length: expected 64 or 65 bytes, got %d", len(sig))
+	}
+
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(sig[:32]); overflow || r.IsZero() {
+		return false, fmt.Errorf("invalid signature: R is out of range")
+	}
+	if overflow := s.SetByteSlice(sig[32:64]); overflow || s.IsZero() {
+		return false, fmt.Errorf("invalid signature: S is out of range")
+	}
+
+	hash := data
+	if err := checkDigest(data, prehashed); err != nil {
+		return false, err
+	}
+	if !prehashed {
+		h := sha256.Sum256(data)
+		hash = h[:]
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pubKey), nil
+}
@@ -0,0 +1,70 @@
+package popsigner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// signCompact signs a 32-byte hash and returns the 64-byte R||S signature.
+func signCompact(t *testing.T, privKey *secp256k1.PrivateKey, hash []byte) []byte {
+	t.Helper()
+	// SignCompact returns V||R||S
+	compact := ecdsa.SignCompact(privKey, hash, true)
+	return compact[1:]
+}
+
+func TestVerifySignature(t *testing.T) {
+	privKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	pubKeyHex := hex.EncodeToString(privKey.PubKey().SerializeCompressed())
+
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherPubKeyHex := hex.EncodeToString(otherKey.PubKey().SerializeCompressed())
+
+	data := []byte("message to sign")
+	hash := sha256.Sum256(data)
+	sig := signCompact(t, privKey, hash[:])
+
+	tests := []struct {
+		name      string
+		pubKey    string
+		data      []byte
+		prehashed bool
+		sig       []byte
+		want      bool
+		wantErr   bool
+	}{
+		{name: "valid signature", pubKey: pubKeyHex, data: data, sig: sig, want: true},
+		{name: "valid prehashed signature", pubKey: pubKeyHex, data: hash[:], prehashed: true, sig: sig, want: true},
+		{name: "valid with 0x prefix", pubKey: "0x" + pubKeyHex, data: data, sig: sig, want: true},
+		{name: "valid uncompressed key", pubKey: hex.EncodeToString(privKey.PubKey().SerializeUncompressed()), data: data, sig: sig, want: true},
+		{name: "valid with recovery byte", pubKey: pubKeyHex, data: data, sig: append(append([]byte{}, sig...), 0x00), want: true},
+		{name: "tampered data", pubKey: pubKeyHex, data: []byte("message to sigN"), sig: sig, want: false},
+		{name: "wrong key", pubKey: otherPubKeyHex, data: data, sig: sig, want: false},
+		{name: "invalid public key hex", pubKey: "zz", data: data, sig: sig, wantErr: true},
+		{name: "invalid public key", pubKey: "0102", data: data, sig: sig, wantErr: true},
+		{name: "short signature", pubKey: pubKeyHex, data: data, sig: sig[:63], wantErr: true},
+		{name: "prehashed wrong length", pubKey: pubKeyHex, data: data, prehashed: true, sig: sig, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifySignature(tt.pubKey, tt.data, tt.prehashed, tt.sig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package popsigner
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// validateSignatureFormat returns ErrUnsupportedFormat for unknown formats.
+// The empty format is SignatureFormatCompact64.
+func validateSignatureFormat(format SignatureFormat) error {
+	switch format {
+	case "", SignatureFormatCompact64, SignatureFormatEthereum65, SignatureFormatDER:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// formatSignature encodes a 64-byte R||S signature over hash in the given
+// format. pubKey is the 33-byte compressed public key of the signing key,
+// used to find the recovery id of Ethereum signatures.
+func formatSignature(sig, hash, pubKey []byte, format SignatureFormat) ([]byte, error) {
+	switch format {
+	case "", SignatureFormatCompact64:
+		return sig, nil
+	case SignatureFormatDER:
+		r, s, err := parseCompactSignature(sig)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.NewSignature(r, s).Serialize(), nil
+	case SignatureFormatEthereum65:
+		recoveryID, err := recoveryIDFor(sig, hash, pubKey)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]byte, 65)
+		copy(result, sig)
+		result[64] = 27 + recoveryID
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+// normalizeLowS returns the 64-byte R||S signature with S in the lower half
+// of the group order. A high S is replaced by N - S, which is an equally
+// valid signature; only the low-S form is accepted by Ethereum (EIP-2) and
+// the Cosmos SDK.
+func normalizeLowS(sig []byte) ([]byte, error) {
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("%w: expected 64 bytes, got %d", ErrInvalidSignature, len(sig))
+	}
+	var s secp256k1.ModNScalar
+	if overflow := s.SetByteSlice(sig[32:]); overflow {
+		return nil, fmt.Errorf("%w: S out of range", ErrInvalidSignature)
+	}
+	if !s.IsOverHalfOrder() {
+		return sig, nil
+	}
+	s.Negate()
+
+	result := make([]byte, 64)
+	copy(result, sig[:32])
+	s.PutBytesUnchecked(result[32:])
+	return result, nil
+}
+
+// parseCompactSignature parses a 64-byte R||S signature.
+func parseCompactSignature(sig []byte) (*secp256k1.ModNScalar, *secp256k1.ModNScalar, error) {
+	if len(sig) != 64 {
+		return nil, nil, fmt.Errorf("%w: expected 64 bytes, got %d", ErrInvalidSignature, len(sig))
+	}
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(sig[:32]); overflow || r.IsZero() {
+		return nil, nil, fmt.Errorf("%w: R out of range", ErrInvalidSignature)
+	}
+	if overflow := s.SetByteSlice(sig[32:]); overflow || s.IsZero() {
+		return nil, nil, fmt.Errorf("%w: S out of range", ErrInvalidSignature)
+	}
+	return &r, &s, nil
+}
+
+// recoveryIDFor returns the recovery id (0 or 1) that recovers pubKey from
+// the 64-byte R||S signature over hash.
+func recoveryIDFor(sig, hash, pubKey []byte) (byte, error) {
+	if _, _, err := parseCompactSignature(sig); err != nil {
+		return 0, err
+	}
+
+	// RecoverCompact expects V||R||S with V = 27 + recovery id
+	compact := make([]byte, 65)
+	copy(compact[1:], sig)
+	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
+		compact[0] = 27 + recoveryID
+		recovered, _, err := ecdsa.RecoverCompact(compact, hash)
+		if err == nil && bytes.Equal(recovered.SerializeCompressed(), pubKey) {
+			return recoveryID, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: public key is not recoverable from signature", ErrInvalidSignature)
+}
@@ -0,0 +1,31 @@
+package popsigner
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+)
+
+// Signer is a secp256k1 signing backend for BaoKeyring.
+// BaoClient (OpenBao) and KMSClient (AWS KMS) both implement it.
+type Signer interface {
+	// Sign signs data with the named key and returns a 64-byte R||S signature
+	// with a low S value. If prehashed is false, data is hashed with SHA-256.
+	Sign(ctx context.Context, keyName string, data []byte, prehashed bool) ([]byte, error)
+
+	// PublicKey returns the 33-byte compressed public key of the named key.
+	PublicKey(ctx context.Context, keyName string) ([]byte, error)
+
+	// Address returns the hex-encoded Cosmos address of the named key.
+	Address(ctx context.Context, keyName string) (string, error)
+}
+
+// cosmosAddressHex derives the hex-encoded Cosmos address for a compressed
+// secp256k1 public key, matching the address format returned by OpenBao.
+func cosmosAddressHex(pubKey []byte) string {
+	return hex.EncodeToString((&secp256k1.PubKey{Key: pubKey}).Address())
+}
+
+// Compile-time check to ensure BaoClient implements Signer.
+var _ Signer = (*BaoClient)(nil)
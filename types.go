@@ -4,17 +4,29 @@ package popsigner
 
 import (
 	"crypto/tls"
+	"fmt"
+	"strings"
 	"time"
 )
 
 // Algorithm constants
 const (
 	AlgorithmSecp256k1   = "secp256k1"
+	CurveSecp256k1       = "secp256k1"
 	DefaultSecp256k1Path = "secp256k1"
 	DefaultHTTPTimeout   = 30 * time.Second
 	DefaultStoreVersion  = 1
 )
 
+// Auth methods for Config.AuthMethod
+const (
+	AuthMethodToken      = "token"      // BaoToken or BaoTokenFile (default)
+	AuthMethodKubernetes = "kubernetes" // Log in with the pod's service-account token
+
+	DefaultKubernetesAuthPath  = "kubernetes"
+	DefaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
 // Source constants
 const (
 	SourceGenerated = "generated"
@@ -26,37 +38,98 @@ const (
 type Config struct {
 	BaoAddr       string        // OpenBao server address
 	BaoToken      string        // OpenBao authentication token
-	BaoNamespace  string        // Optional: OpenBao namespace
-	Secp256k1Path string        // Plugin mount path (default: "secp256k1")
+	BaoTokenFile  string        // Optional: file holding the token, re-read when it changes; preferred over BaoToken
+	AuthMethod    string        // Optional: AuthMethodToken (default) or AuthMethodKubernetes
+	BaoNamespace  string        // Optional: OpenBao namespace, sent as X-Vault-Namespace
+	Secp256k1Path string        // Plugin mount path, may be nested (default: "secp256k1")
 	StorePath     string        // Path to local metadata store
 	HTTPTimeout   time.Duration // HTTP request timeout
 	TLSConfig     *tls.Config   // Optional: custom TLS config
 	SkipTLSVerify bool          // INSECURE: skip TLS verification
+
+	StoreEncryptionKey []byte // Optional: 32-byte key to encrypt the metadata store at rest
+	StorePassphrase    string // Optional: passphrase to encrypt the metadata store (scrypt)
+
+	// Kubernetes auth (AuthMethodKubernetes): the service-account JWT is
+	// exchanged for an OpenBao token at auth/<KubernetesAuthPath>/login,
+	// and again before that token's lease runs out.
+	KubernetesRole      string // OpenBao role to log in as (required)
+	KubernetesAuthPath  string // Auth method mount (default: "kubernetes")
+	KubernetesTokenPath string // Service-account token file (default: DefaultKubernetesTokenPath)
+
+	// HealthCheckInterval enables a background OpenBao health check at this
+	// interval, reported by BaoKeyring.Healthy. Zero disables it.
+	HealthCheckInterval time.Duration
+
+	// Backend optionally replaces OpenBao as the signing backend, e.g. a KMSClient.
+	// When set, the Bao* fields are not required and key lifecycle operations
+	// (create, import, export) that only OpenBao supports return ErrUnsupportedBackend.
+	Backend Signer
 }
 
 // WithDefaults returns Config with default values applied.
 func (c Config) WithDefaults() Config {
+	// Paths are joined as /v1/<mount>/..., so "/team-a/secp256k1/" works too
+	c.Secp256k1Path = strings.Trim(c.Secp256k1Path, "/")
+	c.BaoNamespace = strings.Trim(c.BaoNamespace, "/")
 	if c.Secp256k1Path == "" {
 		c.Secp256k1Path = DefaultSecp256k1Path
 	}
 	if c.HTTPTimeout == 0 {
 		c.HTTPTimeout = DefaultHTTPTimeout
 	}
+	if c.AuthMethod == "" {
+		c.AuthMethod = AuthMethodToken
+	}
+	c.KubernetesAuthPath = strings.Trim(c.KubernetesAuthPath, "/")
+	if c.KubernetesAuthPath == "" {
+		c.KubernetesAuthPath = DefaultKubernetesAuthPath
+	}
+	if c.KubernetesTokenPath == "" {
+		c.KubernetesTokenPath = DefaultKubernetesTokenPath
+	}
 	return c
 }
 
 // Validate checks required configuration fields.
 func (c *Config) Validate() error {
+	if c.Backend != nil {
+		if c.StorePath == "" {
+			return ErrMissingStorePath
+		}
+		return c.StoreEncryption().Validate()
+	}
 	if c.BaoAddr == "" {
 		return ErrMissingBaoAddr
 	}
-	if c.BaoToken == "" {
-		return ErrMissingBaoToken
+	switch c.AuthMethod {
+	case "", AuthMethodToken:
+		if c.BaoToken == "" && c.BaoTokenFile == "" {
+			return ErrMissingBaoToken
+		}
+	case AuthMethodKubernetes:
+		if c.KubernetesRole == "" {
+			return ErrMissingKubernetesRole
+		}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAuthMethod, c.AuthMethod)
 	}
 	if c.StorePath == "" {
 		return ErrMissingStorePath
 	}
-	return nil
+	return c.StoreEncryption().Validate()
+}
+
+// StoreEncryption returns the at-rest encryption settings for the metadata store.
+func (c Config) StoreEncryption() StoreEncryption {
+	return StoreEncryption{Key: c.StoreEncryptionKey, Passphrase: c.StorePassphrase}
+}
+
+// HealthStatus is the result of the most recent OpenBao health check.
+type HealthStatus struct {
+	Healthy   bool
+	Err       error     // ErrBaoSealed, ErrBaoUnavailable or ErrBaoConnection when unhealthy
+	CheckedAt time.Time // zero if OpenBao has not been checked
 }
 
 // KeyMetadata contains locally stored key information.
@@ -68,9 +141,28 @@ type KeyMetadata struct {
 	Address     string    `json:"address"`
 	BaoKeyPath  string    `json:"bao_key_path"`
 	Algorithm   string    `json:"algorithm"`
+	Curve       string    `json:"curve,omitempty"`
 	Exportable  bool      `json:"exportable"`
 	CreatedAt   time.Time `json:"created_at"`
 	Source      string    `json:"source"`
+
+	// LastUsedAt is when the key last signed successfully. It is persisted
+	// at most once per store flush interval; see BaoStore.MarkUsed.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
+	// Labels are user-defined key/value tags, e.g. environment=prod.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MatchesLabels reports whether the metadata carries every label in selector.
+// An empty selector matches all keys.
+func (m *KeyMetadata) MatchesLabels(selector map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := m.Labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
 }
 
 // KeyInfo represents public key information from OpenBao.
@@ -85,6 +177,28 @@ type KeyInfo struct {
 // KeyOptions configures key creation.
 type KeyOptions struct {
 	Exportable bool
+	Labels     map[string]string // Optional labels stored in local metadata
+}
+
+// SignatureFormat is the encoding of a signature returned by
+// BaoKeyring.SignWithOptions.
+type SignatureFormat string
+
+// Signature formats
+const (
+	// SignatureFormatCompact64 is the 64-byte R||S format used by Cosmos SDK.
+	SignatureFormatCompact64 SignatureFormat = "compact64"
+	// SignatureFormatEthereum65 is the 65-byte R||S||V format used by
+	// Ethereum, where V is 27 plus the recovery id.
+	SignatureFormatEthereum65 SignatureFormat = "ethereum65"
+	// SignatureFormatDER is the ASN.1 DER encoding of R and S.
+	SignatureFormatDER SignatureFormat = "der"
+)
+
+// SignOptions configures BaoKeyring.SignWithOptions.
+type SignOptions struct {
+	// OutputFormat is the signature encoding. Defaults to SignatureFormatCompact64.
+	OutputFormat SignatureFormat
 }
 
 // SignRequest for OpenBao signing.